@@ -25,17 +25,34 @@ const (
 
 // Models
 type Order struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	Id                 int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId             int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Status             string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
-	Items              []*OrderItem           `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // "pending", "confirmed", "cancelled", "completed", "partially_refunded", "refunded"
+	Items  []*OrderItem           `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	// Deprecated: use total_amount_minor and currency.
+	//
+	// Deprecated: Marked as deprecated in bff/api/proto/order/v1/order.proto.
 	TotalAmount        float64                `protobuf:"fixed64,5,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
 	CreatedAt          *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt          *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	CancellationReason string                 `protobuf:"bytes,8,opt,name=cancellation_reason,json=cancellationReason,proto3" json:"cancellation_reason,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	TotalAmountMinor   int64                  `protobuf:"varint,9,opt,name=total_amount_minor,json=totalAmountMinor,proto3" json:"total_amount_minor,omitempty"`
+	// ISO 4217 currency code shared by the order and all its items.
+	Currency string `protobuf:"bytes,10,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Sum of the lines before discounts; total_amount_minor = subtotal_minor - discount_minor.
+	SubtotalMinor int64               `protobuf:"varint,11,opt,name=subtotal_minor,json=subtotalMinor,proto3" json:"subtotal_minor,omitempty"`
+	DiscountMinor int64               `protobuf:"varint,12,opt,name=discount_minor,json=discountMinor,proto3" json:"discount_minor,omitempty"`
+	Promotions    []*AppliedPromotion `protobuf:"bytes,13,rep,name=promotions,proto3" json:"promotions,omitempty"`
+	// Snapshot of the address taken when the order was created; later address book edits do not change it.
+	ShippingAddress *ShippingAddress `protobuf:"bytes,14,opt,name=shipping_address,json=shippingAddress,proto3" json:"shipping_address,omitempty"`
+	DeliveryMethod  string           `protobuf:"bytes,15,opt,name=delivery_method,json=deliveryMethod,proto3" json:"delivery_method,omitempty"` // "courier", "pickup", "post"
+	// Incremented on every change; pass it back as expected_version to reject stale edits.
+	Version int64 `protobuf:"varint,16,opt,name=version,proto3" json:"version,omitempty"`
+	// Money returned to the customer for returned items.
+	RefundedMinor int64 `protobuf:"varint,17,opt,name=refunded_minor,json=refundedMinor,proto3" json:"refunded_minor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Order) Reset() {
@@ -96,6 +113,7 @@ func (x *Order) GetItems() []*OrderItem {
 	return nil
 }
 
+// Deprecated: Marked as deprecated in bff/api/proto/order/v1/order.proto.
 func (x *Order) GetTotalAmount() float64 {
 	if x != nil {
 		return x.TotalAmount
@@ -124,12 +142,86 @@ func (x *Order) GetCancellationReason() string {
 	return ""
 }
 
+func (x *Order) GetTotalAmountMinor() int64 {
+	if x != nil {
+		return x.TotalAmountMinor
+	}
+	return 0
+}
+
+func (x *Order) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Order) GetSubtotalMinor() int64 {
+	if x != nil {
+		return x.SubtotalMinor
+	}
+	return 0
+}
+
+func (x *Order) GetDiscountMinor() int64 {
+	if x != nil {
+		return x.DiscountMinor
+	}
+	return 0
+}
+
+func (x *Order) GetPromotions() []*AppliedPromotion {
+	if x != nil {
+		return x.Promotions
+	}
+	return nil
+}
+
+func (x *Order) GetShippingAddress() *ShippingAddress {
+	if x != nil {
+		return x.ShippingAddress
+	}
+	return nil
+}
+
+func (x *Order) GetDeliveryMethod() string {
+	if x != nil {
+		return x.DeliveryMethod
+	}
+	return ""
+}
+
+func (x *Order) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Order) GetRefundedMinor() int64 {
+	if x != nil {
+		return x.RefundedMinor
+	}
+	return 0
+}
+
 type OrderItem struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
-	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
-	ProductName   string                 `protobuf:"bytes,4,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity  int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// Deprecated: use price_minor and currency.
+	//
+	// Deprecated: Marked as deprecated in bff/api/proto/order/v1/order.proto.
+	Price       float64 `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
+	ProductName string  `protobuf:"bytes,4,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	PriceMinor  int64   `protobuf:"varint,5,opt,name=price_minor,json=priceMinor,proto3" json:"price_minor,omitempty"`
+	Currency    string  `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Discount for the whole line (all units), in minor units.
+	DiscountMinor int64 `protobuf:"varint,7,opt,name=discount_minor,json=discountMinor,proto3" json:"discount_minor,omitempty"`
+	// Product variant; 0 is allowed only for a product with a single variant.
+	VariantId int64 `protobuf:"varint,8,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	// SKU of the variant at the time of the order.
+	Sku           string `protobuf:"bytes,9,opt,name=sku,proto3" json:"sku,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -178,6 +270,7 @@ func (x *OrderItem) GetQuantity() int32 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in bff/api/proto/order/v1/order.proto.
 func (x *OrderItem) GetPrice() float64 {
 	if x != nil {
 		return x.Price
@@ -192,29 +285,70 @@ func (x *OrderItem) GetProductName() string {
 	return ""
 }
 
-type Error struct {
+func (x *OrderItem) GetPriceMinor() int64 {
+	if x != nil {
+		return x.PriceMinor
+	}
+	return 0
+}
+
+func (x *OrderItem) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *OrderItem) GetDiscountMinor() int64 {
+	if x != nil {
+		return x.DiscountMinor
+	}
+	return 0
+}
+
+func (x *OrderItem) GetVariantId() int64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
+func (x *OrderItem) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+type ShippingAddress struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Details       map[string]string      `protobuf:"bytes,3,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	RecipientName string                 `protobuf:"bytes,1,opt,name=recipient_name,json=recipientName,proto3" json:"recipient_name,omitempty"`
+	Phone         string                 `protobuf:"bytes,2,opt,name=phone,proto3" json:"phone,omitempty"`
+	Country       string                 `protobuf:"bytes,3,opt,name=country,proto3" json:"country,omitempty"` // ISO 3166-1 alpha-2
+	Region        string                 `protobuf:"bytes,4,opt,name=region,proto3" json:"region,omitempty"`
+	City          string                 `protobuf:"bytes,5,opt,name=city,proto3" json:"city,omitempty"`
+	PostalCode    string                 `protobuf:"bytes,6,opt,name=postal_code,json=postalCode,proto3" json:"postal_code,omitempty"`
+	Line1         string                 `protobuf:"bytes,7,opt,name=line1,proto3" json:"line1,omitempty"`
+	Line2         string                 `protobuf:"bytes,8,opt,name=line2,proto3" json:"line2,omitempty"`
+	AddressId     int64                  `protobuf:"varint,9,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"` // address book entry the snapshot was taken from, 0 for inline addresses
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Error) Reset() {
-	*x = Error{}
+func (x *ShippingAddress) Reset() {
+	*x = ShippingAddress{}
 	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Error) String() string {
+func (x *ShippingAddress) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Error) ProtoMessage() {}
+func (*ShippingAddress) ProtoMessage() {}
 
-func (x *Error) ProtoReflect() protoreflect.Message {
+func (x *ShippingAddress) ProtoReflect() protoreflect.Message {
 	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -226,55 +360,99 @@ func (x *Error) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Error.ProtoReflect.Descriptor instead.
-func (*Error) Descriptor() ([]byte, []int) {
+// Deprecated: Use ShippingAddress.ProtoReflect.Descriptor instead.
+func (*ShippingAddress) Descriptor() ([]byte, []int) {
 	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *Error) GetCode() string {
+func (x *ShippingAddress) GetRecipientName() string {
 	if x != nil {
-		return x.Code
+		return x.RecipientName
 	}
 	return ""
 }
 
-func (x *Error) GetMessage() string {
+func (x *ShippingAddress) GetPhone() string {
 	if x != nil {
-		return x.Message
+		return x.Phone
 	}
 	return ""
 }
 
-func (x *Error) GetDetails() map[string]string {
+func (x *ShippingAddress) GetCountry() string {
 	if x != nil {
-		return x.Details
+		return x.Country
 	}
-	return nil
+	return ""
 }
 
-// Request/Response
-type CreateOrderRequest struct {
+func (x *ShippingAddress) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *ShippingAddress) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *ShippingAddress) GetPostalCode() string {
+	if x != nil {
+		return x.PostalCode
+	}
+	return ""
+}
+
+func (x *ShippingAddress) GetLine1() string {
+	if x != nil {
+		return x.Line1
+	}
+	return ""
+}
+
+func (x *ShippingAddress) GetLine2() string {
+	if x != nil {
+		return x.Line2
+	}
+	return ""
+}
+
+func (x *ShippingAddress) GetAddressId() int64 {
+	if x != nil {
+		return x.AddressId
+	}
+	return 0
+}
+
+type AppliedPromotion struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Items         []*OrderItem           `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	PromotionId   int64                  `protobuf:"varint,1,opt,name=promotion_id,json=promotionId,proto3" json:"promotion_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // empty for automatic promotions
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	DiscountMinor int64                  `protobuf:"varint,4,opt,name=discount_minor,json=discountMinor,proto3" json:"discount_minor,omitempty"`
+	Currency      string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateOrderRequest) Reset() {
-	*x = CreateOrderRequest{}
+func (x *AppliedPromotion) Reset() {
+	*x = AppliedPromotion{}
 	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateOrderRequest) String() string {
+func (x *AppliedPromotion) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateOrderRequest) ProtoMessage() {}
+func (*AppliedPromotion) ProtoMessage() {}
 
-func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
+func (x *AppliedPromotion) ProtoReflect() protoreflect.Message {
 	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -286,50 +464,83 @@ func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
-func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use AppliedPromotion.ProtoReflect.Descriptor instead.
+func (*AppliedPromotion) Descriptor() ([]byte, []int) {
 	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *CreateOrderRequest) GetUserId() int64 {
+func (x *AppliedPromotion) GetPromotionId() int64 {
 	if x != nil {
-		return x.UserId
+		return x.PromotionId
 	}
 	return 0
 }
 
-func (x *CreateOrderRequest) GetItems() []*OrderItem {
+func (x *AppliedPromotion) GetCode() string {
 	if x != nil {
-		return x.Items
+		return x.Code
 	}
-	return nil
+	return ""
 }
 
-type CreateOrderResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Result:
-	//
-	//	*CreateOrderResponse_OrderId
-	//	*CreateOrderResponse_Error
-	Result        isCreateOrderResponse_Result `protobuf_oneof:"result"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *AppliedPromotion) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-func (x *CreateOrderResponse) Reset() {
-	*x = CreateOrderResponse{}
+func (x *AppliedPromotion) GetDiscountMinor() int64 {
+	if x != nil {
+		return x.DiscountMinor
+	}
+	return 0
+}
+
+func (x *AppliedPromotion) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type Promotion struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code           string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"` // empty code makes the promotion automatic
+	Name           string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Type           string                 `protobuf:"bytes,4,opt,name=type,proto3" json:"type,omitempty"` // "percentage", "fixed_amount", "buy_x_get_y"
+	PercentOff     int32                  `protobuf:"varint,5,opt,name=percent_off,json=percentOff,proto3" json:"percent_off,omitempty"`
+	AmountOffMinor int64                  `protobuf:"varint,6,opt,name=amount_off_minor,json=amountOffMinor,proto3" json:"amount_off_minor,omitempty"`
+	Currency       string                 `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
+	BuyQuantity    int32                  `protobuf:"varint,8,opt,name=buy_quantity,json=buyQuantity,proto3" json:"buy_quantity,omitempty"`
+	GetQuantity    int32                  `protobuf:"varint,9,opt,name=get_quantity,json=getQuantity,proto3" json:"get_quantity,omitempty"`
+	ProductId      int64                  `protobuf:"varint,10,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"` // 0 applies the promotion to the whole basket
+	MinBasketMinor int64                  `protobuf:"varint,11,opt,name=min_basket_minor,json=minBasketMinor,proto3" json:"min_basket_minor,omitempty"`
+	PerUserLimit   int32                  `protobuf:"varint,12,opt,name=per_user_limit,json=perUserLimit,proto3" json:"per_user_limit,omitempty"` // 0 means unlimited
+	StartsAt       *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=starts_at,json=startsAt,proto3" json:"starts_at,omitempty"`
+	EndsAt         *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=ends_at,json=endsAt,proto3" json:"ends_at,omitempty"`
+	Active         bool                   `protobuf:"varint,15,opt,name=active,proto3" json:"active,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Promotion) Reset() {
+	*x = Promotion{}
 	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateOrderResponse) String() string {
+func (x *Promotion) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateOrderResponse) ProtoMessage() {}
+func (*Promotion) ProtoMessage() {}
 
-func (x *CreateOrderResponse) ProtoReflect() protoreflect.Message {
+func (x *Promotion) ProtoReflect() protoreflect.Message {
 	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -341,138 +552,5192 @@ func (x *CreateOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateOrderResponse.ProtoReflect.Descriptor instead.
-func (*CreateOrderResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use Promotion.ProtoReflect.Descriptor instead.
+func (*Promotion) Descriptor() ([]byte, []int) {
 	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *CreateOrderResponse) GetResult() isCreateOrderResponse_Result {
+func (x *Promotion) GetId() int64 {
 	if x != nil {
-		return x.Result
+		return x.Id
 	}
-	return nil
+	return 0
 }
 
-func (x *CreateOrderResponse) GetOrderId() int64 {
+func (x *Promotion) GetCode() string {
 	if x != nil {
-		if x, ok := x.Result.(*CreateOrderResponse_OrderId); ok {
-			return x.OrderId
-		}
+		return x.Code
 	}
-	return 0
+	return ""
 }
 
-func (x *CreateOrderResponse) GetError() *Error {
+func (x *Promotion) GetName() string {
 	if x != nil {
-		if x, ok := x.Result.(*CreateOrderResponse_Error); ok {
-			return x.Error
-		}
+		return x.Name
 	}
-	return nil
-}
-
-type isCreateOrderResponse_Result interface {
-	isCreateOrderResponse_Result()
-}
-
-type CreateOrderResponse_OrderId struct {
-	OrderId int64 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3,oneof"`
+	return ""
 }
 
-type CreateOrderResponse_Error struct {
-	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+func (x *Promotion) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
 }
 
-func (*CreateOrderResponse_OrderId) isCreateOrderResponse_Result() {}
-
-func (*CreateOrderResponse_Error) isCreateOrderResponse_Result() {}
-
-type CancelOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Promotion) GetPercentOff() int32 {
+	if x != nil {
+		return x.PercentOff
+	}
+	return 0
 }
 
-func (x *CancelOrderRequest) Reset() {
-	*x = CancelOrderRequest{}
-	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[5]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Promotion) GetAmountOffMinor() int64 {
+	if x != nil {
+		return x.AmountOffMinor
+	}
+	return 0
 }
 
-func (x *CancelOrderRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Promotion) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
 }
 
-func (*CancelOrderRequest) ProtoMessage() {}
-
-func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[5]
+func (x *Promotion) GetBuyQuantity() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.BuyQuantity
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
-func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
-	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{5}
+func (x *Promotion) GetGetQuantity() int32 {
+	if x != nil {
+		return x.GetQuantity
+	}
+	return 0
 }
 
-func (x *CancelOrderRequest) GetOrderId() int64 {
+func (x *Promotion) GetProductId() int64 {
 	if x != nil {
-		return x.OrderId
+		return x.ProductId
 	}
 	return 0
 }
 
-func (x *CancelOrderRequest) GetUserId() int64 {
+func (x *Promotion) GetMinBasketMinor() int64 {
 	if x != nil {
-		return x.UserId
+		return x.MinBasketMinor
 	}
 	return 0
 }
 
-func (x *CancelOrderRequest) GetReason() string {
+func (x *Promotion) GetPerUserLimit() int32 {
 	if x != nil {
-		return x.Reason
+		return x.PerUserLimit
 	}
-	return ""
+	return 0
+}
+
+func (x *Promotion) GetStartsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartsAt
+	}
+	return nil
+}
+
+func (x *Promotion) GetEndsAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndsAt
+	}
+	return nil
+}
+
+func (x *Promotion) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *Promotion) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Promotion) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type Payment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId       int64                  `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Provider      string                 `protobuf:"bytes,3,opt,name=provider,proto3" json:"provider,omitempty"`
+	ProviderRef   string                 `protobuf:"bytes,4,opt,name=provider_ref,json=providerRef,proto3" json:"provider_ref,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"` // "pending", "authorized", "captured", "partially_refunded", "refunded", "voided", "failed"
+	AmountMinor   int64                  `protobuf:"varint,6,opt,name=amount_minor,json=amountMinor,proto3" json:"amount_minor,omitempty"`
+	CapturedMinor int64                  `protobuf:"varint,7,opt,name=captured_minor,json=capturedMinor,proto3" json:"captured_minor,omitempty"`
+	RefundedMinor int64                  `protobuf:"varint,8,opt,name=refunded_minor,json=refundedMinor,proto3" json:"refunded_minor,omitempty"`
+	Currency      string                 `protobuf:"bytes,9,opt,name=currency,proto3" json:"currency,omitempty"`
+	FailureReason string                 `protobuf:"bytes,10,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Payment) Reset() {
+	*x = Payment{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Payment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payment) ProtoMessage() {}
+
+func (x *Payment) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payment.ProtoReflect.Descriptor instead.
+func (*Payment) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Payment) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Payment) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *Payment) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Payment) GetProviderRef() string {
+	if x != nil {
+		return x.ProviderRef
+	}
+	return ""
+}
+
+func (x *Payment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Payment) GetAmountMinor() int64 {
+	if x != nil {
+		return x.AmountMinor
+	}
+	return 0
+}
+
+func (x *Payment) GetCapturedMinor() int64 {
+	if x != nil {
+		return x.CapturedMinor
+	}
+	return 0
+}
+
+func (x *Payment) GetRefundedMinor() int64 {
+	if x != nil {
+		return x.RefundedMinor
+	}
+	return 0
+}
+
+func (x *Payment) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Payment) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *Payment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Payment) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type Quote struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*OrderItem           `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	SubtotalMinor int64                  `protobuf:"varint,2,opt,name=subtotal_minor,json=subtotalMinor,proto3" json:"subtotal_minor,omitempty"`
+	DiscountMinor int64                  `protobuf:"varint,3,opt,name=discount_minor,json=discountMinor,proto3" json:"discount_minor,omitempty"`
+	TotalMinor    int64                  `protobuf:"varint,4,opt,name=total_minor,json=totalMinor,proto3" json:"total_minor,omitempty"`
+	Currency      string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	Promotions    []*AppliedPromotion    `protobuf:"bytes,6,rep,name=promotions,proto3" json:"promotions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Quote) Reset() {
+	*x = Quote{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Quote) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Quote) ProtoMessage() {}
+
+func (x *Quote) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Quote.ProtoReflect.Descriptor instead.
+func (*Quote) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Quote) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *Quote) GetSubtotalMinor() int64 {
+	if x != nil {
+		return x.SubtotalMinor
+	}
+	return 0
+}
+
+func (x *Quote) GetDiscountMinor() int64 {
+	if x != nil {
+		return x.DiscountMinor
+	}
+	return 0
+}
+
+func (x *Quote) GetTotalMinor() int64 {
+	if x != nil {
+		return x.TotalMinor
+	}
+	return 0
+}
+
+func (x *Quote) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Quote) GetPromotions() []*AppliedPromotion {
+	if x != nil {
+		return x.Promotions
+	}
+	return nil
+}
+
+type StockCompensation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Dead          bool                   `protobuf:"varint,2,opt,name=dead,proto3" json:"dead,omitempty"`
+	OrderId       int64                  `protobuf:"varint,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	ProductId     int64                  `protobuf:"varint,4,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	QuantityDelta int32                  `protobuf:"varint,5,opt,name=quantity_delta,json=quantityDelta,proto3" json:"quantity_delta,omitempty"`
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"` // "order_cancelled", "payment_failed", "order_expired", "rollback", "return_received"
+	Attempts      int32                  `protobuf:"varint,7,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastError     string                 `protobuf:"bytes,8,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"`
+	NextAttemptAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"` // queued entries only
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	DeadAt        *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=dead_at,json=deadAt,proto3" json:"dead_at,omitempty"`
+	ResolvedAt    *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=resolved_at,json=resolvedAt,proto3" json:"resolved_at,omitempty"`
+	Resolution    string                 `protobuf:"bytes,13,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	VariantId     int64                  `protobuf:"varint,14,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockCompensation) Reset() {
+	*x = StockCompensation{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockCompensation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockCompensation) ProtoMessage() {}
+
+func (x *StockCompensation) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockCompensation.ProtoReflect.Descriptor instead.
+func (*StockCompensation) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *StockCompensation) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *StockCompensation) GetDead() bool {
+	if x != nil {
+		return x.Dead
+	}
+	return false
+}
+
+func (x *StockCompensation) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *StockCompensation) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *StockCompensation) GetQuantityDelta() int32 {
+	if x != nil {
+		return x.QuantityDelta
+	}
+	return 0
+}
+
+func (x *StockCompensation) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *StockCompensation) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *StockCompensation) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+func (x *StockCompensation) GetNextAttemptAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return nil
+}
+
+func (x *StockCompensation) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *StockCompensation) GetDeadAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeadAt
+	}
+	return nil
+}
+
+func (x *StockCompensation) GetResolvedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ResolvedAt
+	}
+	return nil
+}
+
+func (x *StockCompensation) GetResolution() string {
+	if x != nil {
+		return x.Resolution
+	}
+	return ""
+}
+
+func (x *StockCompensation) GetVariantId() int64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
+type OrderReturn struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId int64                  `protobuf:"varint,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId  int64                  `protobuf:"varint,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Status  string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"` // "requested", "approved", "rejected", "received", "refunded"
+	Reason  string                 `protobuf:"bytes,5,opt,name=reason,proto3" json:"reason,omitempty"` // "damaged", "defective", "wrong_item", "not_as_described", "changed_mind", "other"
+	Comment string                 `protobuf:"bytes,6,opt,name=comment,proto3" json:"comment,omitempty"`
+	Items   []*ReturnItem          `protobuf:"bytes,7,rep,name=items,proto3" json:"items,omitempty"`
+	// Refund for all items: their share of the price after discounts.
+	RefundMinor     int64                  `protobuf:"varint,8,opt,name=refund_minor,json=refundMinor,proto3" json:"refund_minor,omitempty"`
+	Currency        string                 `protobuf:"bytes,9,opt,name=currency,proto3" json:"currency,omitempty"`
+	RejectionReason string                 `protobuf:"bytes,10,opt,name=rejection_reason,json=rejectionReason,proto3" json:"rejection_reason,omitempty"`
+	PaymentId       int64                  `protobuf:"varint,11,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"` // payment the money was refunded to, 0 until refunded
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ReviewedAt      *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=reviewed_at,json=reviewedAt,proto3" json:"reviewed_at,omitempty"`
+	ReceivedAt      *timestamppb.Timestamp `protobuf:"bytes,15,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+	RefundedAt      *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=refunded_at,json=refundedAt,proto3" json:"refunded_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *OrderReturn) Reset() {
+	*x = OrderReturn{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderReturn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderReturn) ProtoMessage() {}
+
+func (x *OrderReturn) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderReturn.ProtoReflect.Descriptor instead.
+func (*OrderReturn) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *OrderReturn) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *OrderReturn) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *OrderReturn) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *OrderReturn) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *OrderReturn) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OrderReturn) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *OrderReturn) GetItems() []*ReturnItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *OrderReturn) GetRefundMinor() int64 {
+	if x != nil {
+		return x.RefundMinor
+	}
+	return 0
+}
+
+func (x *OrderReturn) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *OrderReturn) GetRejectionReason() string {
+	if x != nil {
+		return x.RejectionReason
+	}
+	return ""
+}
+
+func (x *OrderReturn) GetPaymentId() int64 {
+	if x != nil {
+		return x.PaymentId
+	}
+	return 0
+}
+
+func (x *OrderReturn) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *OrderReturn) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *OrderReturn) GetReviewedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReviewedAt
+	}
+	return nil
+}
+
+func (x *OrderReturn) GetReceivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return nil
+}
+
+func (x *OrderReturn) GetRefundedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RefundedAt
+	}
+	return nil
+}
+
+type Invoice struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Number         string                 `protobuf:"bytes,2,opt,name=number,proto3" json:"number,omitempty"` // "INV-2026-000001", sequential within a year
+	OrderId        int64                  `protobuf:"varint,3,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId         int64                  `protobuf:"varint,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Currency       string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	SubtotalMinor  int64                  `protobuf:"varint,6,opt,name=subtotal_minor,json=subtotalMinor,proto3" json:"subtotal_minor,omitempty"`
+	DiscountMinor  int64                  `protobuf:"varint,7,opt,name=discount_minor,json=discountMinor,proto3" json:"discount_minor,omitempty"`
+	TaxMinor       int64                  `protobuf:"varint,8,opt,name=tax_minor,json=taxMinor,proto3" json:"tax_minor,omitempty"` // VAT included in the total
+	TotalMinor     int64                  `protobuf:"varint,9,opt,name=total_minor,json=totalMinor,proto3" json:"total_minor,omitempty"`
+	TaxRatePercent int32                  `protobuf:"varint,10,opt,name=tax_rate_percent,json=taxRatePercent,proto3" json:"tax_rate_percent,omitempty"`
+	IssuedAt       *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Invoice) Reset() {
+	*x = Invoice{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Invoice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Invoice) ProtoMessage() {}
+
+func (x *Invoice) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Invoice.ProtoReflect.Descriptor instead.
+func (*Invoice) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Invoice) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Invoice) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *Invoice) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *Invoice) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *Invoice) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Invoice) GetSubtotalMinor() int64 {
+	if x != nil {
+		return x.SubtotalMinor
+	}
+	return 0
+}
+
+func (x *Invoice) GetDiscountMinor() int64 {
+	if x != nil {
+		return x.DiscountMinor
+	}
+	return 0
+}
+
+func (x *Invoice) GetTaxMinor() int64 {
+	if x != nil {
+		return x.TaxMinor
+	}
+	return 0
+}
+
+func (x *Invoice) GetTotalMinor() int64 {
+	if x != nil {
+		return x.TotalMinor
+	}
+	return 0
+}
+
+func (x *Invoice) GetTaxRatePercent() int32 {
+	if x != nil {
+		return x.TaxRatePercent
+	}
+	return 0
+}
+
+func (x *Invoice) GetIssuedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return nil
+}
+
+type ReturnItem struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ProductId   int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity    int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	RefundMinor int64                  `protobuf:"varint,3,opt,name=refund_minor,json=refundMinor,proto3" json:"refund_minor,omitempty"`
+	// Variant of the order line; 0 matches the only line of the product.
+	VariantId     int64 `protobuf:"varint,4,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReturnItem) Reset() {
+	*x = ReturnItem{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnItem) ProtoMessage() {}
+
+func (x *ReturnItem) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnItem.ProtoReflect.Descriptor instead.
+func (*ReturnItem) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ReturnItem) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ReturnItem) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ReturnItem) GetRefundMinor() int64 {
+	if x != nil {
+		return x.RefundMinor
+	}
+	return 0
+}
+
+func (x *ReturnItem) GetVariantId() int64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
+type Error struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Details       map[string]string      `protobuf:"bytes,3,rep,name=details,proto3" json:"details,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Error) Reset() {
+	*x = Error{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Error) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Error) ProtoMessage() {}
+
+func (x *Error) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Error.ProtoReflect.Descriptor instead.
+func (*Error) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Error) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *Error) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *Error) GetDetails() map[string]string {
+	if x != nil {
+		return x.Details
+	}
+	return nil
+}
+
+// Request/Response
+type CreateOrderRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	UserId     int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items      []*OrderItem           `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	PromoCodes []string               `protobuf:"bytes,3,rep,name=promo_codes,json=promoCodes,proto3" json:"promo_codes,omitempty"`
+	// Either an address from the user's address book or an inline address.
+	// Required unless delivery_method is "pickup".
+	//
+	// Types that are valid to be assigned to Shipping:
+	//
+	//	*CreateOrderRequest_AddressId
+	//	*CreateOrderRequest_Address
+	Shipping       isCreateOrderRequest_Shipping `protobuf_oneof:"shipping"`
+	DeliveryMethod string                        `protobuf:"bytes,6,opt,name=delivery_method,json=deliveryMethod,proto3" json:"delivery_method,omitempty"` // defaults to "courier"
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateOrderRequest) Reset() {
+	*x = CreateOrderRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrderRequest) ProtoMessage() {}
+
+func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateOrderRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CreateOrderRequest) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetPromoCodes() []string {
+	if x != nil {
+		return x.PromoCodes
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetShipping() isCreateOrderRequest_Shipping {
+	if x != nil {
+		return x.Shipping
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetAddressId() int64 {
+	if x != nil {
+		if x, ok := x.Shipping.(*CreateOrderRequest_AddressId); ok {
+			return x.AddressId
+		}
+	}
+	return 0
+}
+
+func (x *CreateOrderRequest) GetAddress() *ShippingAddress {
+	if x != nil {
+		if x, ok := x.Shipping.(*CreateOrderRequest_Address); ok {
+			return x.Address
+		}
+	}
+	return nil
+}
+
+func (x *CreateOrderRequest) GetDeliveryMethod() string {
+	if x != nil {
+		return x.DeliveryMethod
+	}
+	return ""
+}
+
+type isCreateOrderRequest_Shipping interface {
+	isCreateOrderRequest_Shipping()
+}
+
+type CreateOrderRequest_AddressId struct {
+	AddressId int64 `protobuf:"varint,4,opt,name=address_id,json=addressId,proto3,oneof"`
+}
+
+type CreateOrderRequest_Address struct {
+	Address *ShippingAddress `protobuf:"bytes,5,opt,name=address,proto3,oneof"`
+}
+
+func (*CreateOrderRequest_AddressId) isCreateOrderRequest_Shipping() {}
+
+func (*CreateOrderRequest_Address) isCreateOrderRequest_Shipping() {}
+
+type CreateOrderResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*CreateOrderResponse_OrderId
+	//	*CreateOrderResponse_Error
+	Result        isCreateOrderResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrderResponse) Reset() {
+	*x = CreateOrderResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrderResponse) ProtoMessage() {}
+
+func (x *CreateOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrderResponse.ProtoReflect.Descriptor instead.
+func (*CreateOrderResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateOrderResponse) GetResult() isCreateOrderResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *CreateOrderResponse) GetOrderId() int64 {
+	if x != nil {
+		if x, ok := x.Result.(*CreateOrderResponse_OrderId); ok {
+			return x.OrderId
+		}
+	}
+	return 0
+}
+
+func (x *CreateOrderResponse) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Result.(*CreateOrderResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isCreateOrderResponse_Result interface {
+	isCreateOrderResponse_Result()
+}
+
+type CreateOrderResponse_OrderId struct {
+	OrderId int64 `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3,oneof"`
+}
+
+type CreateOrderResponse_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*CreateOrderResponse_OrderId) isCreateOrderResponse_Result() {}
+
+func (*CreateOrderResponse_Error) isCreateOrderResponse_Result() {}
+
+type CancelOrderRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId  int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Reason  string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// When set, the order is cancelled only if its version still matches (ABORTED otherwise).
+	ExpectedVersion *int64 `protobuf:"varint,4,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CancelOrderRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *CancelOrderRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *CancelOrderRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CancelOrderRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
 }
 
 type CancelOrderResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Types that are valid to be assigned to Result:
 	//
-	//	*CancelOrderResponse_Success
-	//	*CancelOrderResponse_Error
-	Result        isCancelOrderResponse_Result `protobuf_oneof:"result"`
+	//	*CancelOrderResponse_Success
+	//	*CancelOrderResponse_Error
+	Result        isCancelOrderResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderResponse) Reset() {
+	*x = CancelOrderResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderResponse) ProtoMessage() {}
+
+func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
+func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CancelOrderResponse) GetResult() isCancelOrderResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *CancelOrderResponse) GetSuccess() *emptypb.Empty {
+	if x != nil {
+		if x, ok := x.Result.(*CancelOrderResponse_Success); ok {
+			return x.Success
+		}
+	}
+	return nil
+}
+
+func (x *CancelOrderResponse) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Result.(*CancelOrderResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isCancelOrderResponse_Result interface {
+	isCancelOrderResponse_Result()
+}
+
+type CancelOrderResponse_Success struct {
+	Success *emptypb.Empty `protobuf:"bytes,1,opt,name=success,proto3,oneof"`
+}
+
+type CancelOrderResponse_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*CancelOrderResponse_Success) isCancelOrderResponse_Result() {}
+
+func (*CancelOrderResponse_Error) isCancelOrderResponse_Result() {}
+
+type UpdateOrderRequest struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	OrderId            int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId             int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // для проверки ownership
+	Status             *string                `protobuf:"bytes,3,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	CancellationReason *string                `protobuf:"bytes,4,opt,name=cancellation_reason,json=cancellationReason,proto3,oneof" json:"cancellation_reason,omitempty"`
+	ExpectedVersion    *int64                 `protobuf:"varint,5,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *UpdateOrderRequest) Reset() {
+	*x = UpdateOrderRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderRequest) ProtoMessage() {}
+
+func (x *UpdateOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrderRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *UpdateOrderRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *UpdateOrderRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UpdateOrderRequest) GetStatus() string {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ""
+}
+
+func (x *UpdateOrderRequest) GetCancellationReason() string {
+	if x != nil && x.CancellationReason != nil {
+		return *x.CancellationReason
+	}
+	return ""
+}
+
+func (x *UpdateOrderRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type UpdateOrderResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*UpdateOrderResponse_Success
+	//	*UpdateOrderResponse_Error
+	Result        isUpdateOrderResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderResponse) Reset() {
+	*x = UpdateOrderResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderResponse) ProtoMessage() {}
+
+func (x *UpdateOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderResponse.ProtoReflect.Descriptor instead.
+func (*UpdateOrderResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *UpdateOrderResponse) GetResult() isUpdateOrderResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *UpdateOrderResponse) GetSuccess() *emptypb.Empty {
+	if x != nil {
+		if x, ok := x.Result.(*UpdateOrderResponse_Success); ok {
+			return x.Success
+		}
+	}
+	return nil
+}
+
+func (x *UpdateOrderResponse) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Result.(*UpdateOrderResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isUpdateOrderResponse_Result interface {
+	isUpdateOrderResponse_Result()
+}
+
+type UpdateOrderResponse_Success struct {
+	Success *emptypb.Empty `protobuf:"bytes,1,opt,name=success,proto3,oneof"`
+}
+
+type UpdateOrderResponse_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*UpdateOrderResponse_Success) isUpdateOrderResponse_Result() {}
+
+func (*UpdateOrderResponse_Error) isUpdateOrderResponse_Result() {}
+
+// UpdateOrderItemsRequest replaces the lines of a pending order.
+// Products missing from items are removed from the order.
+type UpdateOrderItemsRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	OrderId         int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId          int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items           []*OrderItem           `protobuf:"bytes,3,rep,name=items,proto3" json:"items,omitempty"`
+	ExpectedVersion *int64                 `protobuf:"varint,4,opt,name=expected_version,json=expectedVersion,proto3,oneof" json:"expected_version,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UpdateOrderItemsRequest) Reset() {
+	*x = UpdateOrderItemsRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderItemsRequest) ProtoMessage() {}
+
+func (x *UpdateOrderItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderItemsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateOrderItemsRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UpdateOrderItemsRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *UpdateOrderItemsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *UpdateOrderItemsRequest) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *UpdateOrderItemsRequest) GetExpectedVersion() int64 {
+	if x != nil && x.ExpectedVersion != nil {
+		return *x.ExpectedVersion
+	}
+	return 0
+}
+
+type UpdateOrderItemsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*UpdateOrderItemsResponse_Order
+	//	*UpdateOrderItemsResponse_Error
+	Result        isUpdateOrderItemsResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateOrderItemsResponse) Reset() {
+	*x = UpdateOrderItemsResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateOrderItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateOrderItemsResponse) ProtoMessage() {}
+
+func (x *UpdateOrderItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateOrderItemsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateOrderItemsResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateOrderItemsResponse) GetResult() isUpdateOrderItemsResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *UpdateOrderItemsResponse) GetOrder() *Order {
+	if x != nil {
+		if x, ok := x.Result.(*UpdateOrderItemsResponse_Order); ok {
+			return x.Order
+		}
+	}
+	return nil
+}
+
+func (x *UpdateOrderItemsResponse) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Result.(*UpdateOrderItemsResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isUpdateOrderItemsResponse_Result interface {
+	isUpdateOrderItemsResponse_Result()
+}
+
+type UpdateOrderItemsResponse_Order struct {
+	Order *Order `protobuf:"bytes,1,opt,name=order,proto3,oneof"`
+}
+
+type UpdateOrderItemsResponse_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*UpdateOrderItemsResponse_Order) isUpdateOrderItemsResponse_Result() {}
+
+func (*UpdateOrderItemsResponse_Error) isUpdateOrderItemsResponse_Result() {}
+
+type GetOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetOrderRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+type GetOrderResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*GetOrderResponse_Order
+	//	*GetOrderResponse_Error
+	Result        isGetOrderResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderResponse) Reset() {
+	*x = GetOrderResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderResponse) ProtoMessage() {}
+
+func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetOrderResponse) GetResult() isGetOrderResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *GetOrderResponse) GetOrder() *Order {
+	if x != nil {
+		if x, ok := x.Result.(*GetOrderResponse_Order); ok {
+			return x.Order
+		}
+	}
+	return nil
+}
+
+func (x *GetOrderResponse) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Result.(*GetOrderResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isGetOrderResponse_Result interface {
+	isGetOrderResponse_Result()
+}
+
+type GetOrderResponse_Order struct {
+	Order *Order `protobuf:"bytes,1,opt,name=order,proto3,oneof"`
+}
+
+type GetOrderResponse_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*GetOrderResponse_Order) isGetOrderResponse_Result() {}
+
+func (*GetOrderResponse_Error) isGetOrderResponse_Result() {}
+
+type GetUserOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Status        *string                `protobuf:"bytes,4,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	FromDate      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=from_date,json=fromDate,proto3,oneof" json:"from_date,omitempty"`
+	ToDate        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=to_date,json=toDate,proto3,oneof" json:"to_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserOrdersRequest) Reset() {
+	*x = GetUserOrdersRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserOrdersRequest) ProtoMessage() {}
+
+func (x *GetUserOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserOrdersRequest.ProtoReflect.Descriptor instead.
+func (*GetUserOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetUserOrdersRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *GetUserOrdersRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetUserOrdersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *GetUserOrdersRequest) GetStatus() string {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ""
+}
+
+func (x *GetUserOrdersRequest) GetFromDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FromDate
+	}
+	return nil
+}
+
+func (x *GetUserOrdersRequest) GetToDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ToDate
+	}
+	return nil
+}
+
+type GetUserOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserOrdersResponse) Reset() {
+	*x = GetUserOrdersResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserOrdersResponse) ProtoMessage() {}
+
+func (x *GetUserOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserOrdersResponse.ProtoReflect.Descriptor instead.
+func (*GetUserOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *GetUserOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *GetUserOrdersResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *GetUserOrdersResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *GetUserOrdersResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type SearchOrdersRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Statuses []string               `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	UserId   int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Orders containing the product.
+	ProductId int64 `protobuf:"varint,3,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	// Order total range in minor units, inclusive.
+	MinTotalMinor *int64                 `protobuf:"varint,4,opt,name=min_total_minor,json=minTotalMinor,proto3,oneof" json:"min_total_minor,omitempty"`
+	MaxTotalMinor *int64                 `protobuf:"varint,5,opt,name=max_total_minor,json=maxTotalMinor,proto3,oneof" json:"max_total_minor,omitempty"`
+	FromDate      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=from_date,json=fromDate,proto3,oneof" json:"from_date,omitempty"`
+	ToDate        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=to_date,json=toDate,proto3,oneof" json:"to_date,omitempty"`
+	// Case-insensitive substring of a product name in the order.
+	Query string `protobuf:"bytes,8,opt,name=query,proto3" json:"query,omitempty"`
+	// "created_at" (default) or "total".
+	SortBy string `protobuf:"bytes,9,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	// "desc" (default) or "asc".
+	SortOrder string `protobuf:"bytes,10,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
+	PageSize  int32  `protobuf:"varint,11,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_cursor of the previous page; the filters and sorting must stay the same.
+	Cursor        string `protobuf:"bytes,12,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchOrdersRequest) Reset() {
+	*x = SearchOrdersRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchOrdersRequest) ProtoMessage() {}
+
+func (x *SearchOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchOrdersRequest.ProtoReflect.Descriptor instead.
+func (*SearchOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SearchOrdersRequest) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *SearchOrdersRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SearchOrdersRequest) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *SearchOrdersRequest) GetMinTotalMinor() int64 {
+	if x != nil && x.MinTotalMinor != nil {
+		return *x.MinTotalMinor
+	}
+	return 0
+}
+
+func (x *SearchOrdersRequest) GetMaxTotalMinor() int64 {
+	if x != nil && x.MaxTotalMinor != nil {
+		return *x.MaxTotalMinor
+	}
+	return 0
+}
+
+func (x *SearchOrdersRequest) GetFromDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FromDate
+	}
+	return nil
+}
+
+func (x *SearchOrdersRequest) GetToDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ToDate
+	}
+	return nil
+}
+
+func (x *SearchOrdersRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchOrdersRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *SearchOrdersRequest) GetSortOrder() string {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ""
+}
+
+func (x *SearchOrdersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchOrdersRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type SearchOrdersResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Orders []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	// Empty on the last page.
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchOrdersResponse) Reset() {
+	*x = SearchOrdersResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchOrdersResponse) ProtoMessage() {}
+
+func (x *SearchOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchOrdersResponse.ProtoReflect.Descriptor instead.
+func (*SearchOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SearchOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *SearchOrdersResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type GetOrderStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderStatsRequest) Reset() {
+	*x = GetOrderStatsRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderStatsRequest) ProtoMessage() {}
+
+func (x *GetOrderStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderStatsRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetOrderStatsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+type GetOrderStatsResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	TotalOrders  int32                  `protobuf:"varint,1,opt,name=total_orders,json=totalOrders,proto3" json:"total_orders,omitempty"`
+	ActiveOrders int32                  `protobuf:"varint,2,opt,name=active_orders,json=activeOrders,proto3" json:"active_orders,omitempty"`
+	// Deprecated: use total_spent_minor and currency.
+	//
+	// Deprecated: Marked as deprecated in bff/api/proto/order/v1/order.proto.
+	TotalSpent      float64                `protobuf:"fixed64,3,opt,name=total_spent,json=totalSpent,proto3" json:"total_spent,omitempty"`
+	LastOrderDate   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_order_date,json=lastOrderDate,proto3" json:"last_order_date,omitempty"`
+	TotalSpentMinor int64                  `protobuf:"varint,5,opt,name=total_spent_minor,json=totalSpentMinor,proto3" json:"total_spent_minor,omitempty"`
+	Currency        string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetOrderStatsResponse) Reset() {
+	*x = GetOrderStatsResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderStatsResponse) ProtoMessage() {}
+
+func (x *GetOrderStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderStatsResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetOrderStatsResponse) GetTotalOrders() int32 {
+	if x != nil {
+		return x.TotalOrders
+	}
+	return 0
+}
+
+func (x *GetOrderStatsResponse) GetActiveOrders() int32 {
+	if x != nil {
+		return x.ActiveOrders
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in bff/api/proto/order/v1/order.proto.
+func (x *GetOrderStatsResponse) GetTotalSpent() float64 {
+	if x != nil {
+		return x.TotalSpent
+	}
+	return 0
+}
+
+func (x *GetOrderStatsResponse) GetLastOrderDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastOrderDate
+	}
+	return nil
+}
+
+func (x *GetOrderStatsResponse) GetTotalSpentMinor() int64 {
+	if x != nil {
+		return x.TotalSpentMinor
+	}
+	return 0
+}
+
+func (x *GetOrderStatsResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type QuoteOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Items         []*OrderItem           `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	PromoCodes    []string               `protobuf:"bytes,3,rep,name=promo_codes,json=promoCodes,proto3" json:"promo_codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoteOrderRequest) Reset() {
+	*x = QuoteOrderRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteOrderRequest) ProtoMessage() {}
+
+func (x *QuoteOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteOrderRequest.ProtoReflect.Descriptor instead.
+func (*QuoteOrderRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *QuoteOrderRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *QuoteOrderRequest) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *QuoteOrderRequest) GetPromoCodes() []string {
+	if x != nil {
+		return x.PromoCodes
+	}
+	return nil
+}
+
+type QuoteOrderResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*QuoteOrderResponse_Quote
+	//	*QuoteOrderResponse_Error
+	Result        isQuoteOrderResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QuoteOrderResponse) Reset() {
+	*x = QuoteOrderResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QuoteOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteOrderResponse) ProtoMessage() {}
+
+func (x *QuoteOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteOrderResponse.ProtoReflect.Descriptor instead.
+func (*QuoteOrderResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *QuoteOrderResponse) GetResult() isQuoteOrderResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *QuoteOrderResponse) GetQuote() *Quote {
+	if x != nil {
+		if x, ok := x.Result.(*QuoteOrderResponse_Quote); ok {
+			return x.Quote
+		}
+	}
+	return nil
+}
+
+func (x *QuoteOrderResponse) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Result.(*QuoteOrderResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isQuoteOrderResponse_Result interface {
+	isQuoteOrderResponse_Result()
+}
+
+type QuoteOrderResponse_Quote struct {
+	Quote *Quote `protobuf:"bytes,1,opt,name=quote,proto3,oneof"`
+}
+
+type QuoteOrderResponse_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*QuoteOrderResponse_Quote) isQuoteOrderResponse_Result() {}
+
+func (*QuoteOrderResponse_Error) isQuoteOrderResponse_Result() {}
+
+type CreatePromotionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promotion     *Promotion             `protobuf:"bytes,1,opt,name=promotion,proto3" json:"promotion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePromotionRequest) Reset() {
+	*x = CreatePromotionRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePromotionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePromotionRequest) ProtoMessage() {}
+
+func (x *CreatePromotionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePromotionRequest.ProtoReflect.Descriptor instead.
+func (*CreatePromotionRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CreatePromotionRequest) GetPromotion() *Promotion {
+	if x != nil {
+		return x.Promotion
+	}
+	return nil
+}
+
+type CreatePromotionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*CreatePromotionResponse_Promotion
+	//	*CreatePromotionResponse_Error
+	Result        isCreatePromotionResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePromotionResponse) Reset() {
+	*x = CreatePromotionResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePromotionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePromotionResponse) ProtoMessage() {}
+
+func (x *CreatePromotionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePromotionResponse.ProtoReflect.Descriptor instead.
+func (*CreatePromotionResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CreatePromotionResponse) GetResult() isCreatePromotionResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *CreatePromotionResponse) GetPromotion() *Promotion {
+	if x != nil {
+		if x, ok := x.Result.(*CreatePromotionResponse_Promotion); ok {
+			return x.Promotion
+		}
+	}
+	return nil
+}
+
+func (x *CreatePromotionResponse) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Result.(*CreatePromotionResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isCreatePromotionResponse_Result interface {
+	isCreatePromotionResponse_Result()
+}
+
+type CreatePromotionResponse_Promotion struct {
+	Promotion *Promotion `protobuf:"bytes,1,opt,name=promotion,proto3,oneof"`
+}
+
+type CreatePromotionResponse_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*CreatePromotionResponse_Promotion) isCreatePromotionResponse_Result() {}
+
+func (*CreatePromotionResponse_Error) isCreatePromotionResponse_Result() {}
+
+// UpdatePromotionRequest replaces all editable fields of the promotion with the given id.
+type UpdatePromotionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promotion     *Promotion             `protobuf:"bytes,1,opt,name=promotion,proto3" json:"promotion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePromotionRequest) Reset() {
+	*x = UpdatePromotionRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePromotionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePromotionRequest) ProtoMessage() {}
+
+func (x *UpdatePromotionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePromotionRequest.ProtoReflect.Descriptor instead.
+func (*UpdatePromotionRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *UpdatePromotionRequest) GetPromotion() *Promotion {
+	if x != nil {
+		return x.Promotion
+	}
+	return nil
+}
+
+type UpdatePromotionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*UpdatePromotionResponse_Promotion
+	//	*UpdatePromotionResponse_Error
+	Result        isUpdatePromotionResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdatePromotionResponse) Reset() {
+	*x = UpdatePromotionResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdatePromotionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdatePromotionResponse) ProtoMessage() {}
+
+func (x *UpdatePromotionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdatePromotionResponse.ProtoReflect.Descriptor instead.
+func (*UpdatePromotionResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UpdatePromotionResponse) GetResult() isUpdatePromotionResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *UpdatePromotionResponse) GetPromotion() *Promotion {
+	if x != nil {
+		if x, ok := x.Result.(*UpdatePromotionResponse_Promotion); ok {
+			return x.Promotion
+		}
+	}
+	return nil
+}
+
+func (x *UpdatePromotionResponse) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Result.(*UpdatePromotionResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isUpdatePromotionResponse_Result interface {
+	isUpdatePromotionResponse_Result()
+}
+
+type UpdatePromotionResponse_Promotion struct {
+	Promotion *Promotion `protobuf:"bytes,1,opt,name=promotion,proto3,oneof"`
+}
+
+type UpdatePromotionResponse_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*UpdatePromotionResponse_Promotion) isUpdatePromotionResponse_Result() {}
+
+func (*UpdatePromotionResponse_Error) isUpdatePromotionResponse_Result() {}
+
+type ListPromotionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ActiveOnly    bool                   `protobuf:"varint,1,opt,name=active_only,json=activeOnly,proto3" json:"active_only,omitempty"`
+	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPromotionsRequest) Reset() {
+	*x = ListPromotionsRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPromotionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPromotionsRequest) ProtoMessage() {}
+
+func (x *ListPromotionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPromotionsRequest.ProtoReflect.Descriptor instead.
+func (*ListPromotionsRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListPromotionsRequest) GetActiveOnly() bool {
+	if x != nil {
+		return x.ActiveOnly
+	}
+	return false
+}
+
+func (x *ListPromotionsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPromotionsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListPromotionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Promotions    []*Promotion           `protobuf:"bytes,1,rep,name=promotions,proto3" json:"promotions,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPromotionsResponse) Reset() {
+	*x = ListPromotionsResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPromotionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPromotionsResponse) ProtoMessage() {}
+
+func (x *ListPromotionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPromotionsResponse.ProtoReflect.Descriptor instead.
+func (*ListPromotionsResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListPromotionsResponse) GetPromotions() []*Promotion {
+	if x != nil {
+		return x.Promotions
+	}
+	return nil
+}
+
+func (x *ListPromotionsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListPromotionsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListPromotionsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type DeactivatePromotionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PromotionId   int64                  `protobuf:"varint,1,opt,name=promotion_id,json=promotionId,proto3" json:"promotion_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeactivatePromotionRequest) Reset() {
+	*x = DeactivatePromotionRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeactivatePromotionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeactivatePromotionRequest) ProtoMessage() {}
+
+func (x *DeactivatePromotionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeactivatePromotionRequest.ProtoReflect.Descriptor instead.
+func (*DeactivatePromotionRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *DeactivatePromotionRequest) GetPromotionId() int64 {
+	if x != nil {
+		return x.PromotionId
+	}
+	return 0
+}
+
+type DeactivatePromotionResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*DeactivatePromotionResponse_Success
+	//	*DeactivatePromotionResponse_Error
+	Result        isDeactivatePromotionResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeactivatePromotionResponse) Reset() {
+	*x = DeactivatePromotionResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeactivatePromotionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeactivatePromotionResponse) ProtoMessage() {}
+
+func (x *DeactivatePromotionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeactivatePromotionResponse.ProtoReflect.Descriptor instead.
+func (*DeactivatePromotionResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DeactivatePromotionResponse) GetResult() isDeactivatePromotionResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *DeactivatePromotionResponse) GetSuccess() *emptypb.Empty {
+	if x != nil {
+		if x, ok := x.Result.(*DeactivatePromotionResponse_Success); ok {
+			return x.Success
+		}
+	}
+	return nil
+}
+
+func (x *DeactivatePromotionResponse) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Result.(*DeactivatePromotionResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isDeactivatePromotionResponse_Result interface {
+	isDeactivatePromotionResponse_Result()
+}
+
+type DeactivatePromotionResponse_Success struct {
+	Success *emptypb.Empty `protobuf:"bytes,1,opt,name=success,proto3,oneof"`
+}
+
+type DeactivatePromotionResponse_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*DeactivatePromotionResponse_Success) isDeactivatePromotionResponse_Result() {}
+
+func (*DeactivatePromotionResponse_Error) isDeactivatePromotionResponse_Result() {}
+
+type PayOrderRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// One-time token of the payment method issued by the provider to the client.
+	PaymentToken  string `protobuf:"bytes,2,opt,name=payment_token,json=paymentToken,proto3" json:"payment_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PayOrderRequest) Reset() {
+	*x = PayOrderRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PayOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayOrderRequest) ProtoMessage() {}
+
+func (x *PayOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayOrderRequest.ProtoReflect.Descriptor instead.
+func (*PayOrderRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *PayOrderRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *PayOrderRequest) GetPaymentToken() string {
+	if x != nil {
+		return x.PaymentToken
+	}
+	return ""
+}
+
+type PayOrderResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*PayOrderResponse_Payment
+	//	*PayOrderResponse_Error
+	Result        isPayOrderResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PayOrderResponse) Reset() {
+	*x = PayOrderResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PayOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayOrderResponse) ProtoMessage() {}
+
+func (x *PayOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayOrderResponse.ProtoReflect.Descriptor instead.
+func (*PayOrderResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *PayOrderResponse) GetResult() isPayOrderResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *PayOrderResponse) GetPayment() *Payment {
+	if x != nil {
+		if x, ok := x.Result.(*PayOrderResponse_Payment); ok {
+			return x.Payment
+		}
+	}
+	return nil
+}
+
+func (x *PayOrderResponse) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Result.(*PayOrderResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isPayOrderResponse_Result interface {
+	isPayOrderResponse_Result()
+}
+
+type PayOrderResponse_Payment struct {
+	Payment *Payment `protobuf:"bytes,1,opt,name=payment,proto3,oneof"`
+}
+
+type PayOrderResponse_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*PayOrderResponse_Payment) isPayOrderResponse_Result() {}
+
+func (*PayOrderResponse_Error) isPayOrderResponse_Result() {}
+
+type GetOrderPaymentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderPaymentsRequest) Reset() {
+	*x = GetOrderPaymentsRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderPaymentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderPaymentsRequest) ProtoMessage() {}
+
+func (x *GetOrderPaymentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderPaymentsRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderPaymentsRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *GetOrderPaymentsRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+type GetOrderPaymentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payments      []*Payment             `protobuf:"bytes,1,rep,name=payments,proto3" json:"payments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderPaymentsResponse) Reset() {
+	*x = GetOrderPaymentsResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderPaymentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderPaymentsResponse) ProtoMessage() {}
+
+func (x *GetOrderPaymentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderPaymentsResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderPaymentsResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetOrderPaymentsResponse) GetPayments() []*Payment {
+	if x != nil {
+		return x.Payments
+	}
+	return nil
+}
+
+type CapturePaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     int64                  `protobuf:"varint,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	AmountMinor   int64                  `protobuf:"varint,2,opt,name=amount_minor,json=amountMinor,proto3" json:"amount_minor,omitempty"` // 0 captures the full authorized amount
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CapturePaymentRequest) Reset() {
+	*x = CapturePaymentRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CapturePaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapturePaymentRequest) ProtoMessage() {}
+
+func (x *CapturePaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapturePaymentRequest.ProtoReflect.Descriptor instead.
+func (*CapturePaymentRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *CapturePaymentRequest) GetPaymentId() int64 {
+	if x != nil {
+		return x.PaymentId
+	}
+	return 0
+}
+
+func (x *CapturePaymentRequest) GetAmountMinor() int64 {
+	if x != nil {
+		return x.AmountMinor
+	}
+	return 0
+}
+
+type CapturePaymentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*CapturePaymentResponse_Payment
+	//	*CapturePaymentResponse_Error
+	Result        isCapturePaymentResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CapturePaymentResponse) Reset() {
+	*x = CapturePaymentResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CapturePaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapturePaymentResponse) ProtoMessage() {}
+
+func (x *CapturePaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapturePaymentResponse.ProtoReflect.Descriptor instead.
+func (*CapturePaymentResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *CapturePaymentResponse) GetResult() isCapturePaymentResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *CapturePaymentResponse) GetPayment() *Payment {
+	if x != nil {
+		if x, ok := x.Result.(*CapturePaymentResponse_Payment); ok {
+			return x.Payment
+		}
+	}
+	return nil
+}
+
+func (x *CapturePaymentResponse) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Result.(*CapturePaymentResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isCapturePaymentResponse_Result interface {
+	isCapturePaymentResponse_Result()
+}
+
+type CapturePaymentResponse_Payment struct {
+	Payment *Payment `protobuf:"bytes,1,opt,name=payment,proto3,oneof"`
+}
+
+type CapturePaymentResponse_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*CapturePaymentResponse_Payment) isCapturePaymentResponse_Result() {}
+
+func (*CapturePaymentResponse_Error) isCapturePaymentResponse_Result() {}
+
+type RefundPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentId     int64                  `protobuf:"varint,1,opt,name=payment_id,json=paymentId,proto3" json:"payment_id,omitempty"`
+	AmountMinor   int64                  `protobuf:"varint,2,opt,name=amount_minor,json=amountMinor,proto3" json:"amount_minor,omitempty"` // 0 refunds everything captured and not yet refunded
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundPaymentRequest) Reset() {
+	*x = RefundPaymentRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundPaymentRequest) ProtoMessage() {}
+
+func (x *RefundPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundPaymentRequest.ProtoReflect.Descriptor instead.
+func (*RefundPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *RefundPaymentRequest) GetPaymentId() int64 {
+	if x != nil {
+		return x.PaymentId
+	}
+	return 0
+}
+
+func (x *RefundPaymentRequest) GetAmountMinor() int64 {
+	if x != nil {
+		return x.AmountMinor
+	}
+	return 0
+}
+
+type RefundPaymentResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*RefundPaymentResponse_Payment
+	//	*RefundPaymentResponse_Error
+	Result        isRefundPaymentResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundPaymentResponse) Reset() {
+	*x = RefundPaymentResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundPaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundPaymentResponse) ProtoMessage() {}
+
+func (x *RefundPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundPaymentResponse.ProtoReflect.Descriptor instead.
+func (*RefundPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *RefundPaymentResponse) GetResult() isRefundPaymentResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *RefundPaymentResponse) GetPayment() *Payment {
+	if x != nil {
+		if x, ok := x.Result.(*RefundPaymentResponse_Payment); ok {
+			return x.Payment
+		}
+	}
+	return nil
+}
+
+func (x *RefundPaymentResponse) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Result.(*RefundPaymentResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isRefundPaymentResponse_Result interface {
+	isRefundPaymentResponse_Result()
+}
+
+type RefundPaymentResponse_Payment struct {
+	Payment *Payment `protobuf:"bytes,1,opt,name=payment,proto3,oneof"`
+}
+
+type RefundPaymentResponse_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*RefundPaymentResponse_Payment) isRefundPaymentResponse_Result() {}
+
+func (*RefundPaymentResponse_Error) isRefundPaymentResponse_Result() {}
+
+type ListStockCompensationsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Dead  bool                   `protobuf:"varint,1,opt,name=dead,proto3" json:"dead,omitempty"`
+	// Only for dead entries: also return the ones already resolved.
+	IncludeResolved bool  `protobuf:"varint,2,opt,name=include_resolved,json=includeResolved,proto3" json:"include_resolved,omitempty"`
+	Page            int32 `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize        int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ListStockCompensationsRequest) Reset() {
+	*x = ListStockCompensationsRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStockCompensationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStockCompensationsRequest) ProtoMessage() {}
+
+func (x *ListStockCompensationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStockCompensationsRequest.ProtoReflect.Descriptor instead.
+func (*ListStockCompensationsRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ListStockCompensationsRequest) GetDead() bool {
+	if x != nil {
+		return x.Dead
+	}
+	return false
+}
+
+func (x *ListStockCompensationsRequest) GetIncludeResolved() bool {
+	if x != nil {
+		return x.IncludeResolved
+	}
+	return false
+}
+
+func (x *ListStockCompensationsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListStockCompensationsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListStockCompensationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Compensations []*StockCompensation   `protobuf:"bytes,1,rep,name=compensations,proto3" json:"compensations,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStockCompensationsResponse) Reset() {
+	*x = ListStockCompensationsResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStockCompensationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStockCompensationsResponse) ProtoMessage() {}
+
+func (x *ListStockCompensationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStockCompensationsResponse.ProtoReflect.Descriptor instead.
+func (*ListStockCompensationsResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ListStockCompensationsResponse) GetCompensations() []*StockCompensation {
+	if x != nil {
+		return x.Compensations
+	}
+	return nil
+}
+
+func (x *ListStockCompensationsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListStockCompensationsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListStockCompensationsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type RetryStockCompensationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Dead          bool                   `protobuf:"varint,2,opt,name=dead,proto3" json:"dead,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryStockCompensationRequest) Reset() {
+	*x = RetryStockCompensationRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryStockCompensationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryStockCompensationRequest) ProtoMessage() {}
+
+func (x *RetryStockCompensationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryStockCompensationRequest.ProtoReflect.Descriptor instead.
+func (*RetryStockCompensationRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *RetryStockCompensationRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RetryStockCompensationRequest) GetDead() bool {
+	if x != nil {
+		return x.Dead
+	}
+	return false
+}
+
+type RetryStockCompensationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*RetryStockCompensationResponse_Compensation
+	//	*RetryStockCompensationResponse_Error
+	Result        isRetryStockCompensationResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryStockCompensationResponse) Reset() {
+	*x = RetryStockCompensationResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryStockCompensationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryStockCompensationResponse) ProtoMessage() {}
+
+func (x *RetryStockCompensationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryStockCompensationResponse.ProtoReflect.Descriptor instead.
+func (*RetryStockCompensationResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *RetryStockCompensationResponse) GetResult() isRetryStockCompensationResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *RetryStockCompensationResponse) GetCompensation() *StockCompensation {
+	if x != nil {
+		if x, ok := x.Result.(*RetryStockCompensationResponse_Compensation); ok {
+			return x.Compensation
+		}
+	}
+	return nil
+}
+
+func (x *RetryStockCompensationResponse) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Result.(*RetryStockCompensationResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isRetryStockCompensationResponse_Result interface {
+	isRetryStockCompensationResponse_Result()
+}
+
+type RetryStockCompensationResponse_Compensation struct {
+	Compensation *StockCompensation `protobuf:"bytes,1,opt,name=compensation,proto3,oneof"`
+}
+
+type RetryStockCompensationResponse_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*RetryStockCompensationResponse_Compensation) isRetryStockCompensationResponse_Result() {}
+
+func (*RetryStockCompensationResponse_Error) isRetryStockCompensationResponse_Result() {}
+
+type ResolveStockCompensationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Dead          bool                   `protobuf:"varint,2,opt,name=dead,proto3" json:"dead,omitempty"`
+	Resolution    string                 `protobuf:"bytes,3,opt,name=resolution,proto3" json:"resolution,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveStockCompensationRequest) Reset() {
+	*x = ResolveStockCompensationRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveStockCompensationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveStockCompensationRequest) ProtoMessage() {}
+
+func (x *ResolveStockCompensationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveStockCompensationRequest.ProtoReflect.Descriptor instead.
+func (*ResolveStockCompensationRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *ResolveStockCompensationRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ResolveStockCompensationRequest) GetDead() bool {
+	if x != nil {
+		return x.Dead
+	}
+	return false
+}
+
+func (x *ResolveStockCompensationRequest) GetResolution() string {
+	if x != nil {
+		return x.Resolution
+	}
+	return ""
+}
+
+type ResolveStockCompensationResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*ResolveStockCompensationResponse_Compensation
+	//	*ResolveStockCompensationResponse_Error
+	Result        isResolveStockCompensationResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolveStockCompensationResponse) Reset() {
+	*x = ResolveStockCompensationResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolveStockCompensationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolveStockCompensationResponse) ProtoMessage() {}
+
+func (x *ResolveStockCompensationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolveStockCompensationResponse.ProtoReflect.Descriptor instead.
+func (*ResolveStockCompensationResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ResolveStockCompensationResponse) GetResult() isResolveStockCompensationResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *ResolveStockCompensationResponse) GetCompensation() *StockCompensation {
+	if x != nil {
+		if x, ok := x.Result.(*ResolveStockCompensationResponse_Compensation); ok {
+			return x.Compensation
+		}
+	}
+	return nil
+}
+
+func (x *ResolveStockCompensationResponse) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Result.(*ResolveStockCompensationResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isResolveStockCompensationResponse_Result interface {
+	isResolveStockCompensationResponse_Result()
+}
+
+type ResolveStockCompensationResponse_Compensation struct {
+	Compensation *StockCompensation `protobuf:"bytes,1,opt,name=compensation,proto3,oneof"`
+}
+
+type ResolveStockCompensationResponse_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*ResolveStockCompensationResponse_Compensation) isResolveStockCompensationResponse_Result() {}
+
+func (*ResolveStockCompensationResponse_Error) isResolveStockCompensationResponse_Result() {}
+
+type CreateReturnRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// Only product_id, variant_id and quantity are used; an order line may appear once.
+	Items         []*ReturnItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	Reason        string        `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	Comment       string        `protobuf:"bytes,4,opt,name=comment,proto3" json:"comment,omitempty"` // required for reason "other"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateReturnRequest) Reset() {
+	*x = CreateReturnRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReturnRequest) ProtoMessage() {}
+
+func (x *CreateReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReturnRequest.ProtoReflect.Descriptor instead.
+func (*CreateReturnRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *CreateReturnRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *CreateReturnRequest) GetItems() []*ReturnItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *CreateReturnRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *CreateReturnRequest) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+type CreateReturnResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*CreateReturnResponse_OrderReturn
+	//	*CreateReturnResponse_Error
+	Result        isCreateReturnResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateReturnResponse) Reset() {
+	*x = CreateReturnResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateReturnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateReturnResponse) ProtoMessage() {}
+
+func (x *CreateReturnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateReturnResponse.ProtoReflect.Descriptor instead.
+func (*CreateReturnResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *CreateReturnResponse) GetResult() isCreateReturnResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *CreateReturnResponse) GetOrderReturn() *OrderReturn {
+	if x != nil {
+		if x, ok := x.Result.(*CreateReturnResponse_OrderReturn); ok {
+			return x.OrderReturn
+		}
+	}
+	return nil
+}
+
+func (x *CreateReturnResponse) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Result.(*CreateReturnResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isCreateReturnResponse_Result interface {
+	isCreateReturnResponse_Result()
+}
+
+type CreateReturnResponse_OrderReturn struct {
+	OrderReturn *OrderReturn `protobuf:"bytes,1,opt,name=order_return,json=orderReturn,proto3,oneof"`
+}
+
+type CreateReturnResponse_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*CreateReturnResponse_OrderReturn) isCreateReturnResponse_Result() {}
+
+func (*CreateReturnResponse_Error) isCreateReturnResponse_Result() {}
+
+type GetReturnRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReturnId      int64                  `protobuf:"varint,1,opt,name=return_id,json=returnId,proto3" json:"return_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReturnRequest) Reset() {
+	*x = GetReturnRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReturnRequest) ProtoMessage() {}
+
+func (x *GetReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReturnRequest.ProtoReflect.Descriptor instead.
+func (*GetReturnRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *GetReturnRequest) GetReturnId() int64 {
+	if x != nil {
+		return x.ReturnId
+	}
+	return 0
+}
+
+type GetReturnResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*GetReturnResponse_OrderReturn
+	//	*GetReturnResponse_Error
+	Result        isGetReturnResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReturnResponse) Reset() {
+	*x = GetReturnResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReturnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReturnResponse) ProtoMessage() {}
+
+func (x *GetReturnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReturnResponse.ProtoReflect.Descriptor instead.
+func (*GetReturnResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetReturnResponse) GetResult() isGetReturnResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *GetReturnResponse) GetOrderReturn() *OrderReturn {
+	if x != nil {
+		if x, ok := x.Result.(*GetReturnResponse_OrderReturn); ok {
+			return x.OrderReturn
+		}
+	}
+	return nil
+}
+
+func (x *GetReturnResponse) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Result.(*GetReturnResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isGetReturnResponse_Result interface {
+	isGetReturnResponse_Result()
+}
+
+type GetReturnResponse_OrderReturn struct {
+	OrderReturn *OrderReturn `protobuf:"bytes,1,opt,name=order_return,json=orderReturn,proto3,oneof"`
+}
+
+type GetReturnResponse_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*GetReturnResponse_OrderReturn) isGetReturnResponse_Result() {}
+
+func (*GetReturnResponse_Error) isGetReturnResponse_Result() {}
+
+type ListReturnsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	UserId        int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // admins only; customers always get their own returns
+	Status        *string                `protobuf:"bytes,3,opt,name=status,proto3,oneof" json:"status,omitempty"`
+	Page          int32                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,5,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReturnsRequest) Reset() {
+	*x = ListReturnsRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReturnsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReturnsRequest) ProtoMessage() {}
+
+func (x *ListReturnsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReturnsRequest.ProtoReflect.Descriptor instead.
+func (*ListReturnsRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ListReturnsRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *ListReturnsRequest) GetUserId() int64 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *ListReturnsRequest) GetStatus() string {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return ""
+}
+
+func (x *ListReturnsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListReturnsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListReturnsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Returns       []*OrderReturn         `protobuf:"bytes,1,rep,name=returns,proto3" json:"returns,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListReturnsResponse) Reset() {
+	*x = ListReturnsResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListReturnsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListReturnsResponse) ProtoMessage() {}
+
+func (x *ListReturnsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListReturnsResponse.ProtoReflect.Descriptor instead.
+func (*ListReturnsResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ListReturnsResponse) GetReturns() []*OrderReturn {
+	if x != nil {
+		return x.Returns
+	}
+	return nil
+}
+
+func (x *ListReturnsResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+func (x *ListReturnsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListReturnsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ApproveReturnRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReturnId      int64                  `protobuf:"varint,1,opt,name=return_id,json=returnId,proto3" json:"return_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveReturnRequest) Reset() {
+	*x = ApproveReturnRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveReturnRequest) ProtoMessage() {}
+
+func (x *ApproveReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveReturnRequest.ProtoReflect.Descriptor instead.
+func (*ApproveReturnRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ApproveReturnRequest) GetReturnId() int64 {
+	if x != nil {
+		return x.ReturnId
+	}
+	return 0
+}
+
+type ApproveReturnResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*ApproveReturnResponse_OrderReturn
+	//	*ApproveReturnResponse_Error
+	Result        isApproveReturnResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApproveReturnResponse) Reset() {
+	*x = ApproveReturnResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApproveReturnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApproveReturnResponse) ProtoMessage() {}
+
+func (x *ApproveReturnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApproveReturnResponse.ProtoReflect.Descriptor instead.
+func (*ApproveReturnResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ApproveReturnResponse) GetResult() isApproveReturnResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *ApproveReturnResponse) GetOrderReturn() *OrderReturn {
+	if x != nil {
+		if x, ok := x.Result.(*ApproveReturnResponse_OrderReturn); ok {
+			return x.OrderReturn
+		}
+	}
+	return nil
+}
+
+func (x *ApproveReturnResponse) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Result.(*ApproveReturnResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isApproveReturnResponse_Result interface {
+	isApproveReturnResponse_Result()
+}
+
+type ApproveReturnResponse_OrderReturn struct {
+	OrderReturn *OrderReturn `protobuf:"bytes,1,opt,name=order_return,json=orderReturn,proto3,oneof"`
+}
+
+type ApproveReturnResponse_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*ApproveReturnResponse_OrderReturn) isApproveReturnResponse_Result() {}
+
+func (*ApproveReturnResponse_Error) isApproveReturnResponse_Result() {}
+
+type RejectReturnRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReturnId      int64                  `protobuf:"varint,1,opt,name=return_id,json=returnId,proto3" json:"return_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectReturnRequest) Reset() {
+	*x = RejectReturnRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectReturnRequest) ProtoMessage() {}
+
+func (x *RejectReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectReturnRequest.ProtoReflect.Descriptor instead.
+func (*RejectReturnRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *RejectReturnRequest) GetReturnId() int64 {
+	if x != nil {
+		return x.ReturnId
+	}
+	return 0
+}
+
+func (x *RejectReturnRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type RejectReturnResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*RejectReturnResponse_OrderReturn
+	//	*RejectReturnResponse_Error
+	Result        isRejectReturnResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RejectReturnResponse) Reset() {
+	*x = RejectReturnResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RejectReturnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RejectReturnResponse) ProtoMessage() {}
+
+func (x *RejectReturnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RejectReturnResponse.ProtoReflect.Descriptor instead.
+func (*RejectReturnResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *RejectReturnResponse) GetResult() isRejectReturnResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *RejectReturnResponse) GetOrderReturn() *OrderReturn {
+	if x != nil {
+		if x, ok := x.Result.(*RejectReturnResponse_OrderReturn); ok {
+			return x.OrderReturn
+		}
+	}
+	return nil
+}
+
+func (x *RejectReturnResponse) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Result.(*RejectReturnResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isRejectReturnResponse_Result interface {
+	isRejectReturnResponse_Result()
+}
+
+type RejectReturnResponse_OrderReturn struct {
+	OrderReturn *OrderReturn `protobuf:"bytes,1,opt,name=order_return,json=orderReturn,proto3,oneof"`
+}
+
+type RejectReturnResponse_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*RejectReturnResponse_OrderReturn) isRejectReturnResponse_Result() {}
+
+func (*RejectReturnResponse_Error) isRejectReturnResponse_Result() {}
+
+// ReceiveReturnRequest confirms that the goods of an approved return arrived; they are put back in stock.
+type ReceiveReturnRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReturnId      int64                  `protobuf:"varint,1,opt,name=return_id,json=returnId,proto3" json:"return_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceiveReturnRequest) Reset() {
+	*x = ReceiveReturnRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiveReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiveReturnRequest) ProtoMessage() {}
+
+func (x *ReceiveReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiveReturnRequest.ProtoReflect.Descriptor instead.
+func (*ReceiveReturnRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ReceiveReturnRequest) GetReturnId() int64 {
+	if x != nil {
+		return x.ReturnId
+	}
+	return 0
+}
+
+type ReceiveReturnResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*ReceiveReturnResponse_OrderReturn
+	//	*ReceiveReturnResponse_Error
+	Result        isReceiveReturnResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReceiveReturnResponse) Reset() {
+	*x = ReceiveReturnResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReceiveReturnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReceiveReturnResponse) ProtoMessage() {}
+
+func (x *ReceiveReturnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReceiveReturnResponse.ProtoReflect.Descriptor instead.
+func (*ReceiveReturnResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ReceiveReturnResponse) GetResult() isReceiveReturnResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *ReceiveReturnResponse) GetOrderReturn() *OrderReturn {
+	if x != nil {
+		if x, ok := x.Result.(*ReceiveReturnResponse_OrderReturn); ok {
+			return x.OrderReturn
+		}
+	}
+	return nil
+}
+
+func (x *ReceiveReturnResponse) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Result.(*ReceiveReturnResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isReceiveReturnResponse_Result interface {
+	isReceiveReturnResponse_Result()
+}
+
+type ReceiveReturnResponse_OrderReturn struct {
+	OrderReturn *OrderReturn `protobuf:"bytes,1,opt,name=order_return,json=orderReturn,proto3,oneof"`
+}
+
+type ReceiveReturnResponse_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*ReceiveReturnResponse_OrderReturn) isReceiveReturnResponse_Result() {}
+
+func (*ReceiveReturnResponse_Error) isReceiveReturnResponse_Result() {}
+
+// RefundReturnRequest refunds a received return to the captured payment of the order.
+type RefundReturnRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReturnId      int64                  `protobuf:"varint,1,opt,name=return_id,json=returnId,proto3" json:"return_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundReturnRequest) Reset() {
+	*x = RefundReturnRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundReturnRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundReturnRequest) ProtoMessage() {}
+
+func (x *RefundReturnRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundReturnRequest.ProtoReflect.Descriptor instead.
+func (*RefundReturnRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *RefundReturnRequest) GetReturnId() int64 {
+	if x != nil {
+		return x.ReturnId
+	}
+	return 0
+}
+
+type RefundReturnResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*RefundReturnResponse_OrderReturn
+	//	*RefundReturnResponse_Error
+	Result        isRefundReturnResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundReturnResponse) Reset() {
+	*x = RefundReturnResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundReturnResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundReturnResponse) ProtoMessage() {}
+
+func (x *RefundReturnResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundReturnResponse.ProtoReflect.Descriptor instead.
+func (*RefundReturnResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *RefundReturnResponse) GetResult() isRefundReturnResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *RefundReturnResponse) GetOrderReturn() *OrderReturn {
+	if x != nil {
+		if x, ok := x.Result.(*RefundReturnResponse_OrderReturn); ok {
+			return x.OrderReturn
+		}
+	}
+	return nil
+}
+
+func (x *RefundReturnResponse) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Result.(*RefundReturnResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isRefundReturnResponse_Result interface {
+	isRefundReturnResponse_Result()
+}
+
+type RefundReturnResponse_OrderReturn struct {
+	OrderReturn *OrderReturn `protobuf:"bytes,1,opt,name=order_return,json=orderReturn,proto3,oneof"`
+}
+
+type RefundReturnResponse_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*RefundReturnResponse_OrderReturn) isRefundReturnResponse_Result() {}
+
+func (*RefundReturnResponse_Error) isRefundReturnResponse_Result() {}
+
+type GetInvoiceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Format        string                 `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"` // "html" (default) or "text"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInvoiceRequest) Reset() {
+	*x = GetInvoiceRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInvoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoiceRequest) ProtoMessage() {}
+
+func (x *GetInvoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoiceRequest.ProtoReflect.Descriptor instead.
+func (*GetInvoiceRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *GetInvoiceRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *GetInvoiceRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+type InvoiceDocument struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Invoice       *Invoice               `protobuf:"bytes,1,opt,name=invoice,proto3" json:"invoice,omitempty"`
+	ContentType   string                 `protobuf:"bytes,2,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // e.g. "text/html; charset=utf-8"
+	Filename      string                 `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`                          // e.g. "INV-2026-000001.html"
+	Content       []byte                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvoiceDocument) Reset() {
+	*x = InvoiceDocument{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvoiceDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvoiceDocument) ProtoMessage() {}
+
+func (x *InvoiceDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvoiceDocument.ProtoReflect.Descriptor instead.
+func (*InvoiceDocument) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *InvoiceDocument) GetInvoice() *Invoice {
+	if x != nil {
+		return x.Invoice
+	}
+	return nil
+}
+
+func (x *InvoiceDocument) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *InvoiceDocument) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *InvoiceDocument) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+type GetInvoiceResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*GetInvoiceResponse_Document
+	//	*GetInvoiceResponse_Error
+	Result        isGetInvoiceResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetInvoiceResponse) Reset() {
+	*x = GetInvoiceResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetInvoiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetInvoiceResponse) ProtoMessage() {}
+
+func (x *GetInvoiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetInvoiceResponse.ProtoReflect.Descriptor instead.
+func (*GetInvoiceResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *GetInvoiceResponse) GetResult() isGetInvoiceResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *GetInvoiceResponse) GetDocument() *InvoiceDocument {
+	if x != nil {
+		if x, ok := x.Result.(*GetInvoiceResponse_Document); ok {
+			return x.Document
+		}
+	}
+	return nil
+}
+
+func (x *GetInvoiceResponse) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Result.(*GetInvoiceResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isGetInvoiceResponse_Result interface {
+	isGetInvoiceResponse_Result()
+}
+
+type GetInvoiceResponse_Document struct {
+	Document *InvoiceDocument `protobuf:"bytes,1,opt,name=document,proto3,oneof"`
+}
+
+type GetInvoiceResponse_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*GetInvoiceResponse_Document) isGetInvoiceResponse_Result() {}
+
+func (*GetInvoiceResponse_Error) isGetInvoiceResponse_Result() {}
+
+// A line of a reorder or a submitted template compared with the current catalog.
+type ReorderLine struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ProductId         int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	ProductName       string                 `protobuf:"bytes,2,opt,name=product_name,json=productName,proto3" json:"product_name,omitempty"`
+	RequestedQuantity int32                  `protobuf:"varint,3,opt,name=requested_quantity,json=requestedQuantity,proto3" json:"requested_quantity,omitempty"`
+	// Quantity in the new order: reduced to the stock left, 0 when the line is skipped.
+	Quantity int32 `protobuf:"varint,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// Unit price in the source order; 0 for templates, which do not keep prices.
+	PreviousPriceMinor int64 `protobuf:"varint,5,opt,name=previous_price_minor,json=previousPriceMinor,proto3" json:"previous_price_minor,omitempty"`
+	// Current unit price; 0 when the product no longer exists.
+	PriceMinor int64  `protobuf:"varint,6,opt,name=price_minor,json=priceMinor,proto3" json:"price_minor,omitempty"`
+	Currency   string `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
+	// "available", "partial" (not enough stock, quantity reduced) or "unavailable" (skipped).
+	Availability string `protobuf:"bytes,8,opt,name=availability,proto3" json:"availability,omitempty"`
+	PriceChanged bool   `protobuf:"varint,9,opt,name=price_changed,json=priceChanged,proto3" json:"price_changed,omitempty"`
+	// Variant being ordered; 0 for lines that predate variants. A line whose variant
+	// no longer exists, or whose product now has several variants, is unavailable.
+	VariantId     int64 `protobuf:"varint,10,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderLine) Reset() {
+	*x = ReorderLine{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderLine) ProtoMessage() {}
+
+func (x *ReorderLine) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderLine.ProtoReflect.Descriptor instead.
+func (*ReorderLine) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ReorderLine) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *ReorderLine) GetProductName() string {
+	if x != nil {
+		return x.ProductName
+	}
+	return ""
+}
+
+func (x *ReorderLine) GetRequestedQuantity() int32 {
+	if x != nil {
+		return x.RequestedQuantity
+	}
+	return 0
+}
+
+func (x *ReorderLine) GetQuantity() int32 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *ReorderLine) GetPreviousPriceMinor() int64 {
+	if x != nil {
+		return x.PreviousPriceMinor
+	}
+	return 0
+}
+
+func (x *ReorderLine) GetPriceMinor() int64 {
+	if x != nil {
+		return x.PriceMinor
+	}
+	return 0
+}
+
+func (x *ReorderLine) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ReorderLine) GetAvailability() string {
+	if x != nil {
+		return x.Availability
+	}
+	return ""
+}
+
+func (x *ReorderLine) GetPriceChanged() bool {
+	if x != nil {
+		return x.PriceChanged
+	}
+	return false
+}
+
+func (x *ReorderLine) GetVariantId() int64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
+type ReorderResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// ID of the created order; 0 on a dry run.
+	OrderId int64          `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Lines   []*ReorderLine `protobuf:"bytes,2,rep,name=lines,proto3" json:"lines,omitempty"`
+	// True when any line changed price or availability since the source order.
+	Changed       bool `protobuf:"varint,3,opt,name=changed,proto3" json:"changed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderResult) Reset() {
+	*x = ReorderResult{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderResult) ProtoMessage() {}
+
+func (x *ReorderResult) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderResult.ProtoReflect.Descriptor instead.
+func (*ReorderResult) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *ReorderResult) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *ReorderResult) GetLines() []*ReorderLine {
+	if x != nil {
+		return x.Lines
+	}
+	return nil
+}
+
+func (x *ReorderResult) GetChanged() bool {
+	if x != nil {
+		return x.Changed
+	}
+	return false
+}
+
+type ReorderRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderId int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	// Only compare with the catalog, do not create the order or reserve stock.
+	DryRun     bool     `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	PromoCodes []string `protobuf:"bytes,3,rep,name=promo_codes,json=promoCodes,proto3" json:"promo_codes,omitempty"`
+	// Overrides the delivery of the source order. By default its delivery method and address are reused.
+	//
+	// Types that are valid to be assigned to Shipping:
+	//
+	//	*ReorderRequest_AddressId
+	//	*ReorderRequest_Address
+	Shipping       isReorderRequest_Shipping `protobuf_oneof:"shipping"`
+	DeliveryMethod string                    `protobuf:"bytes,6,opt,name=delivery_method,json=deliveryMethod,proto3" json:"delivery_method,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReorderRequest) Reset() {
+	*x = ReorderRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderRequest) ProtoMessage() {}
+
+func (x *ReorderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderRequest.ProtoReflect.Descriptor instead.
+func (*ReorderRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *ReorderRequest) GetOrderId() int64 {
+	if x != nil {
+		return x.OrderId
+	}
+	return 0
+}
+
+func (x *ReorderRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ReorderRequest) GetPromoCodes() []string {
+	if x != nil {
+		return x.PromoCodes
+	}
+	return nil
+}
+
+func (x *ReorderRequest) GetShipping() isReorderRequest_Shipping {
+	if x != nil {
+		return x.Shipping
+	}
+	return nil
+}
+
+func (x *ReorderRequest) GetAddressId() int64 {
+	if x != nil {
+		if x, ok := x.Shipping.(*ReorderRequest_AddressId); ok {
+			return x.AddressId
+		}
+	}
+	return 0
+}
+
+func (x *ReorderRequest) GetAddress() *ShippingAddress {
+	if x != nil {
+		if x, ok := x.Shipping.(*ReorderRequest_Address); ok {
+			return x.Address
+		}
+	}
+	return nil
+}
+
+func (x *ReorderRequest) GetDeliveryMethod() string {
+	if x != nil {
+		return x.DeliveryMethod
+	}
+	return ""
+}
+
+type isReorderRequest_Shipping interface {
+	isReorderRequest_Shipping()
+}
+
+type ReorderRequest_AddressId struct {
+	AddressId int64 `protobuf:"varint,4,opt,name=address_id,json=addressId,proto3,oneof"`
+}
+
+type ReorderRequest_Address struct {
+	Address *ShippingAddress `protobuf:"bytes,5,opt,name=address,proto3,oneof"`
+}
+
+func (*ReorderRequest_AddressId) isReorderRequest_Shipping() {}
+
+func (*ReorderRequest_Address) isReorderRequest_Shipping() {}
+
+type ReorderResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*ReorderResponse_Reorder
+	//	*ReorderResponse_Error
+	Result        isReorderResponse_Result `protobuf_oneof:"result"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CancelOrderResponse) Reset() {
-	*x = CancelOrderResponse{}
-	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[6]
+func (x *ReorderResponse) Reset() {
+	*x = ReorderResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[72]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CancelOrderResponse) String() string {
+func (x *ReorderResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelOrderResponse) ProtoMessage() {}
+func (*ReorderResponse) ProtoMessage() {}
 
-func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[6]
+func (x *ReorderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[72]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -483,77 +5748,83 @@ func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
-func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
-	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use ReorderResponse.ProtoReflect.Descriptor instead.
+func (*ReorderResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{72}
 }
 
-func (x *CancelOrderResponse) GetResult() isCancelOrderResponse_Result {
+func (x *ReorderResponse) GetResult() isReorderResponse_Result {
 	if x != nil {
 		return x.Result
 	}
 	return nil
 }
 
-func (x *CancelOrderResponse) GetSuccess() *emptypb.Empty {
+func (x *ReorderResponse) GetReorder() *ReorderResult {
 	if x != nil {
-		if x, ok := x.Result.(*CancelOrderResponse_Success); ok {
-			return x.Success
+		if x, ok := x.Result.(*ReorderResponse_Reorder); ok {
+			return x.Reorder
 		}
 	}
 	return nil
 }
 
-func (x *CancelOrderResponse) GetError() *Error {
+func (x *ReorderResponse) GetError() *Error {
 	if x != nil {
-		if x, ok := x.Result.(*CancelOrderResponse_Error); ok {
+		if x, ok := x.Result.(*ReorderResponse_Error); ok {
 			return x.Error
 		}
 	}
 	return nil
 }
 
-type isCancelOrderResponse_Result interface {
-	isCancelOrderResponse_Result()
+type isReorderResponse_Result interface {
+	isReorderResponse_Result()
 }
 
-type CancelOrderResponse_Success struct {
-	Success *emptypb.Empty `protobuf:"bytes,1,opt,name=success,proto3,oneof"`
+type ReorderResponse_Reorder struct {
+	Reorder *ReorderResult `protobuf:"bytes,1,opt,name=reorder,proto3,oneof"`
 }
 
-type CancelOrderResponse_Error struct {
+type ReorderResponse_Error struct {
 	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
 }
 
-func (*CancelOrderResponse_Success) isCancelOrderResponse_Result() {}
+func (*ReorderResponse_Reorder) isReorderResponse_Result() {}
 
-func (*CancelOrderResponse_Error) isCancelOrderResponse_Result() {}
+func (*ReorderResponse_Error) isReorderResponse_Result() {}
 
-type UpdateOrderRequest struct {
-	state              protoimpl.MessageState `protogen:"open.v1"`
-	OrderId            int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	UserId             int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Status             *string                `protobuf:"bytes,3,opt,name=status,proto3,oneof" json:"status,omitempty"`
-	CancellationReason *string                `protobuf:"bytes,4,opt,name=cancellation_reason,json=cancellationReason,proto3,oneof" json:"cancellation_reason,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+type OrderTemplate struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId int64                  `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name   string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// Only product_id and quantity are stored; prices are taken when the template is submitted.
+	Items          []*OrderItem `protobuf:"bytes,4,rep,name=items,proto3" json:"items,omitempty"`
+	DeliveryMethod string       `protobuf:"bytes,5,opt,name=delivery_method,json=deliveryMethod,proto3" json:"delivery_method,omitempty"`
+	// Address from the user's address book; 0 for pickup.
+	AddressId     int64                  `protobuf:"varint,6,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateOrderRequest) Reset() {
-	*x = UpdateOrderRequest{}
-	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[7]
+func (x *OrderTemplate) Reset() {
+	*x = OrderTemplate{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[73]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateOrderRequest) String() string {
+func (x *OrderTemplate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateOrderRequest) ProtoMessage() {}
+func (*OrderTemplate) ProtoMessage() {}
 
-func (x *UpdateOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[7]
+func (x *OrderTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[73]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -564,65 +5835,94 @@ func (x *UpdateOrderRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateOrderRequest.ProtoReflect.Descriptor instead.
-func (*UpdateOrderRequest) Descriptor() ([]byte, []int) {
-	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use OrderTemplate.ProtoReflect.Descriptor instead.
+func (*OrderTemplate) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{73}
 }
 
-func (x *UpdateOrderRequest) GetOrderId() int64 {
+func (x *OrderTemplate) GetId() int64 {
 	if x != nil {
-		return x.OrderId
+		return x.Id
 	}
 	return 0
 }
 
-func (x *UpdateOrderRequest) GetUserId() int64 {
+func (x *OrderTemplate) GetUserId() int64 {
 	if x != nil {
 		return x.UserId
 	}
 	return 0
 }
 
-func (x *UpdateOrderRequest) GetStatus() string {
-	if x != nil && x.Status != nil {
-		return *x.Status
+func (x *OrderTemplate) GetName() string {
+	if x != nil {
+		return x.Name
 	}
 	return ""
 }
 
-func (x *UpdateOrderRequest) GetCancellationReason() string {
-	if x != nil && x.CancellationReason != nil {
-		return *x.CancellationReason
+func (x *OrderTemplate) GetItems() []*OrderItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *OrderTemplate) GetDeliveryMethod() string {
+	if x != nil {
+		return x.DeliveryMethod
 	}
 	return ""
 }
 
-type UpdateOrderResponse struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Result:
-	//
-	//	*UpdateOrderResponse_Success
-	//	*UpdateOrderResponse_Error
-	Result        isUpdateOrderResponse_Result `protobuf_oneof:"result"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *OrderTemplate) GetAddressId() int64 {
+	if x != nil {
+		return x.AddressId
+	}
+	return 0
 }
 
-func (x *UpdateOrderResponse) Reset() {
-	*x = UpdateOrderResponse{}
-	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[8]
+func (x *OrderTemplate) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *OrderTemplate) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreateOrderTemplateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Items []*OrderItem           `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	// Copies items and delivery from an existing order of the user instead of items.
+	FromOrderId    int64  `protobuf:"varint,3,opt,name=from_order_id,json=fromOrderId,proto3" json:"from_order_id,omitempty"`
+	DeliveryMethod string `protobuf:"bytes,4,opt,name=delivery_method,json=deliveryMethod,proto3" json:"delivery_method,omitempty"`
+	AddressId      int64  `protobuf:"varint,5,opt,name=address_id,json=addressId,proto3" json:"address_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateOrderTemplateRequest) Reset() {
+	*x = CreateOrderTemplateRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[74]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateOrderResponse) String() string {
+func (x *CreateOrderTemplateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateOrderResponse) ProtoMessage() {}
+func (*CreateOrderTemplateRequest) ProtoMessage() {}
 
-func (x *UpdateOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[8]
+func (x *CreateOrderTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[74]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -633,122 +5933,72 @@ func (x *UpdateOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateOrderResponse.ProtoReflect.Descriptor instead.
-func (*UpdateOrderResponse) Descriptor() ([]byte, []int) {
-	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use CreateOrderTemplateRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrderTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{74}
 }
 
-func (x *UpdateOrderResponse) GetResult() isUpdateOrderResponse_Result {
+func (x *CreateOrderTemplateRequest) GetName() string {
 	if x != nil {
-		return x.Result
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *UpdateOrderResponse) GetSuccess() *emptypb.Empty {
+func (x *CreateOrderTemplateRequest) GetItems() []*OrderItem {
 	if x != nil {
-		if x, ok := x.Result.(*UpdateOrderResponse_Success); ok {
-			return x.Success
-		}
+		return x.Items
 	}
 	return nil
 }
 
-func (x *UpdateOrderResponse) GetError() *Error {
+func (x *CreateOrderTemplateRequest) GetFromOrderId() int64 {
 	if x != nil {
-		if x, ok := x.Result.(*UpdateOrderResponse_Error); ok {
-			return x.Error
-		}
+		return x.FromOrderId
 	}
-	return nil
-}
-
-type isUpdateOrderResponse_Result interface {
-	isUpdateOrderResponse_Result()
-}
-
-type UpdateOrderResponse_Success struct {
-	Success *emptypb.Empty `protobuf:"bytes,1,opt,name=success,proto3,oneof"`
-}
-
-type UpdateOrderResponse_Error struct {
-	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
-}
-
-func (*UpdateOrderResponse_Success) isUpdateOrderResponse_Result() {}
-
-func (*UpdateOrderResponse_Error) isUpdateOrderResponse_Result() {}
-
-type GetOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderId       int64                  `protobuf:"varint,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *GetOrderRequest) Reset() {
-	*x = GetOrderRequest{}
-	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[9]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *GetOrderRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+	return 0
 }
 
-func (*GetOrderRequest) ProtoMessage() {}
-
-func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[9]
+func (x *CreateOrderTemplateRequest) GetDeliveryMethod() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.DeliveryMethod
 	}
-	return mi.MessageOf(x)
-}
-
-// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
-func (*GetOrderRequest) Descriptor() ([]byte, []int) {
-	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{9}
+	return ""
 }
 
-func (x *GetOrderRequest) GetOrderId() int64 {
+func (x *CreateOrderTemplateRequest) GetAddressId() int64 {
 	if x != nil {
-		return x.OrderId
+		return x.AddressId
 	}
 	return 0
 }
 
-type GetOrderResponse struct {
+type CreateOrderTemplateResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Types that are valid to be assigned to Result:
 	//
-	//	*GetOrderResponse_Order
-	//	*GetOrderResponse_Error
-	Result        isGetOrderResponse_Result `protobuf_oneof:"result"`
+	//	*CreateOrderTemplateResponse_Template
+	//	*CreateOrderTemplateResponse_Error
+	Result        isCreateOrderTemplateResponse_Result `protobuf_oneof:"result"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrderResponse) Reset() {
-	*x = GetOrderResponse{}
-	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[10]
+func (x *CreateOrderTemplateResponse) Reset() {
+	*x = CreateOrderTemplateResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[75]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrderResponse) String() string {
+func (x *CreateOrderTemplateResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrderResponse) ProtoMessage() {}
+func (*CreateOrderTemplateResponse) ProtoMessage() {}
 
-func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[10]
+func (x *CreateOrderTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[75]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -759,79 +6009,73 @@ func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrderResponse.ProtoReflect.Descriptor instead.
-func (*GetOrderResponse) Descriptor() ([]byte, []int) {
-	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use CreateOrderTemplateResponse.ProtoReflect.Descriptor instead.
+func (*CreateOrderTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{75}
 }
 
-func (x *GetOrderResponse) GetResult() isGetOrderResponse_Result {
+func (x *CreateOrderTemplateResponse) GetResult() isCreateOrderTemplateResponse_Result {
 	if x != nil {
 		return x.Result
 	}
 	return nil
 }
 
-func (x *GetOrderResponse) GetOrder() *Order {
+func (x *CreateOrderTemplateResponse) GetTemplate() *OrderTemplate {
 	if x != nil {
-		if x, ok := x.Result.(*GetOrderResponse_Order); ok {
-			return x.Order
+		if x, ok := x.Result.(*CreateOrderTemplateResponse_Template); ok {
+			return x.Template
 		}
 	}
 	return nil
 }
 
-func (x *GetOrderResponse) GetError() *Error {
+func (x *CreateOrderTemplateResponse) GetError() *Error {
 	if x != nil {
-		if x, ok := x.Result.(*GetOrderResponse_Error); ok {
+		if x, ok := x.Result.(*CreateOrderTemplateResponse_Error); ok {
 			return x.Error
 		}
 	}
 	return nil
 }
 
-type isGetOrderResponse_Result interface {
-	isGetOrderResponse_Result()
+type isCreateOrderTemplateResponse_Result interface {
+	isCreateOrderTemplateResponse_Result()
 }
 
-type GetOrderResponse_Order struct {
-	Order *Order `protobuf:"bytes,1,opt,name=order,proto3,oneof"`
+type CreateOrderTemplateResponse_Template struct {
+	Template *OrderTemplate `protobuf:"bytes,1,opt,name=template,proto3,oneof"`
 }
 
-type GetOrderResponse_Error struct {
+type CreateOrderTemplateResponse_Error struct {
 	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
 }
 
-func (*GetOrderResponse_Order) isGetOrderResponse_Result() {}
+func (*CreateOrderTemplateResponse_Template) isCreateOrderTemplateResponse_Result() {}
 
-func (*GetOrderResponse_Error) isGetOrderResponse_Result() {}
+func (*CreateOrderTemplateResponse_Error) isCreateOrderTemplateResponse_Result() {}
 
-type GetUserOrdersRequest struct {
+type ListOrderTemplatesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	Status        *string                `protobuf:"bytes,4,opt,name=status,proto3,oneof" json:"status,omitempty"`
-	FromDate      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=from_date,json=fromDate,proto3,oneof" json:"from_date,omitempty"`
-	ToDate        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=to_date,json=toDate,proto3,oneof" json:"to_date,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUserOrdersRequest) Reset() {
-	*x = GetUserOrdersRequest{}
-	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[11]
+func (x *ListOrderTemplatesRequest) Reset() {
+	*x = ListOrderTemplatesRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[76]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUserOrdersRequest) String() string {
+func (x *ListOrderTemplatesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUserOrdersRequest) ProtoMessage() {}
+func (*ListOrderTemplatesRequest) ProtoMessage() {}
 
-func (x *GetUserOrdersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[11]
+func (x *ListOrderTemplatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[76]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -842,78 +6086,125 @@ func (x *GetUserOrdersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUserOrdersRequest.ProtoReflect.Descriptor instead.
-func (*GetUserOrdersRequest) Descriptor() ([]byte, []int) {
-	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use ListOrderTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*ListOrderTemplatesRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{76}
 }
 
-func (x *GetUserOrdersRequest) GetUserId() int64 {
-	if x != nil {
-		return x.UserId
-	}
-	return 0
+type ListOrderTemplatesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Templates     []*OrderTemplate       `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUserOrdersRequest) GetPage() int32 {
+func (x *ListOrderTemplatesResponse) Reset() {
+	*x = ListOrderTemplatesResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrderTemplatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrderTemplatesResponse) ProtoMessage() {}
+
+func (x *ListOrderTemplatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[77]
 	if x != nil {
-		return x.Page
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrderTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*ListOrderTemplatesResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{77}
 }
 
-func (x *GetUserOrdersRequest) GetPageSize() int32 {
+func (x *ListOrderTemplatesResponse) GetTemplates() []*OrderTemplate {
 	if x != nil {
-		return x.PageSize
+		return x.Templates
 	}
-	return 0
+	return nil
 }
 
-func (x *GetUserOrdersRequest) GetStatus() string {
-	if x != nil && x.Status != nil {
-		return *x.Status
-	}
-	return ""
+type DeleteOrderTemplateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TemplateId    int64                  `protobuf:"varint,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUserOrdersRequest) GetFromDate() *timestamppb.Timestamp {
+func (x *DeleteOrderTemplateRequest) Reset() {
+	*x = DeleteOrderTemplateRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOrderTemplateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOrderTemplateRequest) ProtoMessage() {}
+
+func (x *DeleteOrderTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[78]
 	if x != nil {
-		return x.FromDate
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *GetUserOrdersRequest) GetToDate() *timestamppb.Timestamp {
+// Deprecated: Use DeleteOrderTemplateRequest.ProtoReflect.Descriptor instead.
+func (*DeleteOrderTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *DeleteOrderTemplateRequest) GetTemplateId() int64 {
 	if x != nil {
-		return x.ToDate
+		return x.TemplateId
 	}
-	return nil
+	return 0
 }
 
-type GetUserOrdersResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
-	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
-	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+type DeleteOrderTemplateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*DeleteOrderTemplateResponse_Success
+	//	*DeleteOrderTemplateResponse_Error
+	Result        isDeleteOrderTemplateResponse_Result `protobuf_oneof:"result"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUserOrdersResponse) Reset() {
-	*x = GetUserOrdersResponse{}
-	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[12]
+func (x *DeleteOrderTemplateResponse) Reset() {
+	*x = DeleteOrderTemplateResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[79]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUserOrdersResponse) String() string {
+func (x *DeleteOrderTemplateResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUserOrdersResponse) ProtoMessage() {}
+func (*DeleteOrderTemplateResponse) ProtoMessage() {}
 
-func (x *GetUserOrdersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[12]
+func (x *DeleteOrderTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[79]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -924,61 +6215,76 @@ func (x *GetUserOrdersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUserOrdersResponse.ProtoReflect.Descriptor instead.
-func (*GetUserOrdersResponse) Descriptor() ([]byte, []int) {
-	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use DeleteOrderTemplateResponse.ProtoReflect.Descriptor instead.
+func (*DeleteOrderTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{79}
 }
 
-func (x *GetUserOrdersResponse) GetOrders() []*Order {
+func (x *DeleteOrderTemplateResponse) GetResult() isDeleteOrderTemplateResponse_Result {
 	if x != nil {
-		return x.Orders
+		return x.Result
 	}
 	return nil
 }
 
-func (x *GetUserOrdersResponse) GetTotalCount() int32 {
+func (x *DeleteOrderTemplateResponse) GetSuccess() *emptypb.Empty {
 	if x != nil {
-		return x.TotalCount
+		if x, ok := x.Result.(*DeleteOrderTemplateResponse_Success); ok {
+			return x.Success
+		}
 	}
-	return 0
+	return nil
 }
 
-func (x *GetUserOrdersResponse) GetPage() int32 {
+func (x *DeleteOrderTemplateResponse) GetError() *Error {
 	if x != nil {
-		return x.Page
+		if x, ok := x.Result.(*DeleteOrderTemplateResponse_Error); ok {
+			return x.Error
+		}
 	}
-	return 0
+	return nil
 }
 
-func (x *GetUserOrdersResponse) GetPageSize() int32 {
-	if x != nil {
-		return x.PageSize
-	}
-	return 0
+type isDeleteOrderTemplateResponse_Result interface {
+	isDeleteOrderTemplateResponse_Result()
 }
 
-type GetOrderStatsRequest struct {
+type DeleteOrderTemplateResponse_Success struct {
+	Success *emptypb.Empty `protobuf:"bytes,1,opt,name=success,proto3,oneof"`
+}
+
+type DeleteOrderTemplateResponse_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*DeleteOrderTemplateResponse_Success) isDeleteOrderTemplateResponse_Result() {}
+
+func (*DeleteOrderTemplateResponse_Error) isDeleteOrderTemplateResponse_Result() {}
+
+type SubmitOrderTemplateRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TemplateId    int64                  `protobuf:"varint,1,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
+	DryRun        bool                   `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	PromoCodes    []string               `protobuf:"bytes,3,rep,name=promo_codes,json=promoCodes,proto3" json:"promo_codes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrderStatsRequest) Reset() {
-	*x = GetOrderStatsRequest{}
-	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[13]
+func (x *SubmitOrderTemplateRequest) Reset() {
+	*x = SubmitOrderTemplateRequest{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[80]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrderStatsRequest) String() string {
+func (x *SubmitOrderTemplateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrderStatsRequest) ProtoMessage() {}
+func (*SubmitOrderTemplateRequest) ProtoMessage() {}
 
-func (x *GetOrderStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[13]
+func (x *SubmitOrderTemplateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[80]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -989,43 +6295,58 @@ func (x *GetOrderStatsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrderStatsRequest.ProtoReflect.Descriptor instead.
-func (*GetOrderStatsRequest) Descriptor() ([]byte, []int) {
-	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use SubmitOrderTemplateRequest.ProtoReflect.Descriptor instead.
+func (*SubmitOrderTemplateRequest) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{80}
 }
 
-func (x *GetOrderStatsRequest) GetUserId() int64 {
+func (x *SubmitOrderTemplateRequest) GetTemplateId() int64 {
 	if x != nil {
-		return x.UserId
+		return x.TemplateId
 	}
 	return 0
 }
 
-type GetOrderStatsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TotalOrders   int32                  `protobuf:"varint,1,opt,name=total_orders,json=totalOrders,proto3" json:"total_orders,omitempty"`
-	ActiveOrders  int32                  `protobuf:"varint,2,opt,name=active_orders,json=activeOrders,proto3" json:"active_orders,omitempty"`
-	TotalSpent    float64                `protobuf:"fixed64,3,opt,name=total_spent,json=totalSpent,proto3" json:"total_spent,omitempty"`
-	LastOrderDate *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_order_date,json=lastOrderDate,proto3" json:"last_order_date,omitempty"`
+func (x *SubmitOrderTemplateRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *SubmitOrderTemplateRequest) GetPromoCodes() []string {
+	if x != nil {
+		return x.PromoCodes
+	}
+	return nil
+}
+
+type SubmitOrderTemplateResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*SubmitOrderTemplateResponse_Reorder
+	//	*SubmitOrderTemplateResponse_Error
+	Result        isSubmitOrderTemplateResponse_Result `protobuf_oneof:"result"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetOrderStatsResponse) Reset() {
-	*x = GetOrderStatsResponse{}
-	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[14]
+func (x *SubmitOrderTemplateResponse) Reset() {
+	*x = SubmitOrderTemplateResponse{}
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[81]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetOrderStatsResponse) String() string {
+func (x *SubmitOrderTemplateResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetOrderStatsResponse) ProtoMessage() {}
+func (*SubmitOrderTemplateResponse) ProtoMessage() {}
 
-func (x *GetOrderStatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[14]
+func (x *SubmitOrderTemplateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_bff_api_proto_order_v1_order_proto_msgTypes[81]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1036,93 +6357,283 @@ func (x *GetOrderStatsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetOrderStatsResponse.ProtoReflect.Descriptor instead.
-func (*GetOrderStatsResponse) Descriptor() ([]byte, []int) {
-	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use SubmitOrderTemplateResponse.ProtoReflect.Descriptor instead.
+func (*SubmitOrderTemplateResponse) Descriptor() ([]byte, []int) {
+	return file_bff_api_proto_order_v1_order_proto_rawDescGZIP(), []int{81}
 }
 
-func (x *GetOrderStatsResponse) GetTotalOrders() int32 {
+func (x *SubmitOrderTemplateResponse) GetResult() isSubmitOrderTemplateResponse_Result {
 	if x != nil {
-		return x.TotalOrders
+		return x.Result
 	}
-	return 0
+	return nil
 }
 
-func (x *GetOrderStatsResponse) GetActiveOrders() int32 {
+func (x *SubmitOrderTemplateResponse) GetReorder() *ReorderResult {
 	if x != nil {
-		return x.ActiveOrders
+		if x, ok := x.Result.(*SubmitOrderTemplateResponse_Reorder); ok {
+			return x.Reorder
+		}
 	}
-	return 0
+	return nil
 }
 
-func (x *GetOrderStatsResponse) GetTotalSpent() float64 {
+func (x *SubmitOrderTemplateResponse) GetError() *Error {
 	if x != nil {
-		return x.TotalSpent
+		if x, ok := x.Result.(*SubmitOrderTemplateResponse_Error); ok {
+			return x.Error
+		}
 	}
-	return 0
+	return nil
 }
 
-func (x *GetOrderStatsResponse) GetLastOrderDate() *timestamppb.Timestamp {
-	if x != nil {
-		return x.LastOrderDate
-	}
-	return nil
+type isSubmitOrderTemplateResponse_Result interface {
+	isSubmitOrderTemplateResponse_Result()
+}
+
+type SubmitOrderTemplateResponse_Reorder struct {
+	Reorder *ReorderResult `protobuf:"bytes,1,opt,name=reorder,proto3,oneof"`
 }
 
+type SubmitOrderTemplateResponse_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*SubmitOrderTemplateResponse_Reorder) isSubmitOrderTemplateResponse_Result() {}
+
+func (*SubmitOrderTemplateResponse_Error) isSubmitOrderTemplateResponse_Result() {}
+
 var File_bff_api_proto_order_v1_order_proto protoreflect.FileDescriptor
 
 const file_bff_api_proto_order_v1_order_proto_rawDesc = "" +
 	"\n" +
-	"\"bff/api/proto/order/v1/order.proto\x12\border.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\xbd\x02\n" +
+	"\"bff/api/proto/order/v1/order.proto\x12\border.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\xc5\x05\n" +
 	"\x05Order\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x16\n" +
 	"\x06status\x18\x03 \x01(\tR\x06status\x12)\n" +
-	"\x05items\x18\x04 \x03(\v2\x13.order.v1.OrderItemR\x05items\x12!\n" +
-	"\ftotal_amount\x18\x05 \x01(\x01R\vtotalAmount\x129\n" +
+	"\x05items\x18\x04 \x03(\v2\x13.order.v1.OrderItemR\x05items\x12%\n" +
+	"\ftotal_amount\x18\x05 \x01(\x01B\x02\x18\x01R\vtotalAmount\x129\n" +
 	"\n" +
 	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12/\n" +
-	"\x13cancellation_reason\x18\b \x01(\tR\x12cancellationReason\"\x7f\n" +
+	"\x13cancellation_reason\x18\b \x01(\tR\x12cancellationReason\x12,\n" +
+	"\x12total_amount_minor\x18\t \x01(\x03R\x10totalAmountMinor\x12\x1a\n" +
+	"\bcurrency\x18\n" +
+	" \x01(\tR\bcurrency\x12%\n" +
+	"\x0esubtotal_minor\x18\v \x01(\x03R\rsubtotalMinor\x12%\n" +
+	"\x0ediscount_minor\x18\f \x01(\x03R\rdiscountMinor\x12:\n" +
+	"\n" +
+	"promotions\x18\r \x03(\v2\x1a.order.v1.AppliedPromotionR\n" +
+	"promotions\x12D\n" +
+	"\x10shipping_address\x18\x0e \x01(\v2\x19.order.v1.ShippingAddressR\x0fshippingAddress\x12'\n" +
+	"\x0fdelivery_method\x18\x0f \x01(\tR\x0edeliveryMethod\x12\x18\n" +
+	"\aversion\x18\x10 \x01(\x03R\aversion\x12%\n" +
+	"\x0erefunded_minor\x18\x11 \x01(\x03R\rrefundedMinor\"\x98\x02\n" +
 	"\tOrderItem\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\x03R\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x14\n" +
-	"\x05price\x18\x03 \x01(\x01R\x05price\x12!\n" +
-	"\fproduct_name\x18\x04 \x01(\tR\vproductName\"\xa9\x01\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x18\n" +
+	"\x05price\x18\x03 \x01(\x01B\x02\x18\x01R\x05price\x12!\n" +
+	"\fproduct_name\x18\x04 \x01(\tR\vproductName\x12\x1f\n" +
+	"\vprice_minor\x18\x05 \x01(\x03R\n" +
+	"priceMinor\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12%\n" +
+	"\x0ediscount_minor\x18\a \x01(\x03R\rdiscountMinor\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\b \x01(\x03R\tvariantId\x12\x10\n" +
+	"\x03sku\x18\t \x01(\tR\x03sku\"\x80\x02\n" +
+	"\x0fShippingAddress\x12%\n" +
+	"\x0erecipient_name\x18\x01 \x01(\tR\rrecipientName\x12\x14\n" +
+	"\x05phone\x18\x02 \x01(\tR\x05phone\x12\x18\n" +
+	"\acountry\x18\x03 \x01(\tR\acountry\x12\x16\n" +
+	"\x06region\x18\x04 \x01(\tR\x06region\x12\x12\n" +
+	"\x04city\x18\x05 \x01(\tR\x04city\x12\x1f\n" +
+	"\vpostal_code\x18\x06 \x01(\tR\n" +
+	"postalCode\x12\x14\n" +
+	"\x05line1\x18\a \x01(\tR\x05line1\x12\x14\n" +
+	"\x05line2\x18\b \x01(\tR\x05line2\x12\x1d\n" +
+	"\n" +
+	"address_id\x18\t \x01(\x03R\taddressId\"\xa0\x01\n" +
+	"\x10AppliedPromotion\x12!\n" +
+	"\fpromotion_id\x18\x01 \x01(\x03R\vpromotionId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12%\n" +
+	"\x0ediscount_minor\x18\x04 \x01(\x03R\rdiscountMinor\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\"\xef\x04\n" +
+	"\tPromotion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x12\n" +
+	"\x04type\x18\x04 \x01(\tR\x04type\x12\x1f\n" +
+	"\vpercent_off\x18\x05 \x01(\x05R\n" +
+	"percentOff\x12(\n" +
+	"\x10amount_off_minor\x18\x06 \x01(\x03R\x0eamountOffMinor\x12\x1a\n" +
+	"\bcurrency\x18\a \x01(\tR\bcurrency\x12!\n" +
+	"\fbuy_quantity\x18\b \x01(\x05R\vbuyQuantity\x12!\n" +
+	"\fget_quantity\x18\t \x01(\x05R\vgetQuantity\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\n" +
+	" \x01(\x03R\tproductId\x12(\n" +
+	"\x10min_basket_minor\x18\v \x01(\x03R\x0eminBasketMinor\x12$\n" +
+	"\x0eper_user_limit\x18\f \x01(\x05R\fperUserLimit\x127\n" +
+	"\tstarts_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\bstartsAt\x123\n" +
+	"\aends_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\x06endsAt\x12\x16\n" +
+	"\x06active\x18\x0f \x01(\bR\x06active\x129\n" +
+	"\n" +
+	"created_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xb5\x03\n" +
+	"\aPayment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\x03R\aorderId\x12\x1a\n" +
+	"\bprovider\x18\x03 \x01(\tR\bprovider\x12!\n" +
+	"\fprovider_ref\x18\x04 \x01(\tR\vproviderRef\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12!\n" +
+	"\famount_minor\x18\x06 \x01(\x03R\vamountMinor\x12%\n" +
+	"\x0ecaptured_minor\x18\a \x01(\x03R\rcapturedMinor\x12%\n" +
+	"\x0erefunded_minor\x18\b \x01(\x03R\rrefundedMinor\x12\x1a\n" +
+	"\bcurrency\x18\t \x01(\tR\bcurrency\x12%\n" +
+	"\x0efailure_reason\x18\n" +
+	" \x01(\tR\rfailureReason\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xf9\x01\n" +
+	"\x05Quote\x12)\n" +
+	"\x05items\x18\x01 \x03(\v2\x13.order.v1.OrderItemR\x05items\x12%\n" +
+	"\x0esubtotal_minor\x18\x02 \x01(\x03R\rsubtotalMinor\x12%\n" +
+	"\x0ediscount_minor\x18\x03 \x01(\x03R\rdiscountMinor\x12\x1f\n" +
+	"\vtotal_minor\x18\x04 \x01(\x03R\n" +
+	"totalMinor\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12:\n" +
+	"\n" +
+	"promotions\x18\x06 \x03(\v2\x1a.order.v1.AppliedPromotionR\n" +
+	"promotions\"\x9b\x04\n" +
+	"\x11StockCompensation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04dead\x18\x02 \x01(\bR\x04dead\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\x03R\aorderId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x04 \x01(\x03R\tproductId\x12%\n" +
+	"\x0equantity_delta\x18\x05 \x01(\x05R\rquantityDelta\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12\x1a\n" +
+	"\battempts\x18\a \x01(\x05R\battempts\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\b \x01(\tR\tlastError\x12B\n" +
+	"\x0fnext_attempt_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\rnextAttemptAt\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x123\n" +
+	"\adead_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x06deadAt\x12;\n" +
+	"\vresolved_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"resolvedAt\x12\x1e\n" +
+	"\n" +
+	"resolution\x18\r \x01(\tR\n" +
+	"resolution\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x0e \x01(\x03R\tvariantId\"\xfd\x04\n" +
+	"\vOrderReturn\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\x03R\aorderId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\x03R\x06userId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x05 \x01(\tR\x06reason\x12\x18\n" +
+	"\acomment\x18\x06 \x01(\tR\acomment\x12*\n" +
+	"\x05items\x18\a \x03(\v2\x14.order.v1.ReturnItemR\x05items\x12!\n" +
+	"\frefund_minor\x18\b \x01(\x03R\vrefundMinor\x12\x1a\n" +
+	"\bcurrency\x18\t \x01(\tR\bcurrency\x12)\n" +
+	"\x10rejection_reason\x18\n" +
+	" \x01(\tR\x0frejectionReason\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\v \x01(\x03R\tpaymentId\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12;\n" +
+	"\vreviewed_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"reviewedAt\x12;\n" +
+	"\vreceived_at\x18\x0f \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"receivedAt\x12;\n" +
+	"\vrefunded_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"refundedAt\"\xf0\x02\n" +
+	"\aInvoice\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x16\n" +
+	"\x06number\x18\x02 \x01(\tR\x06number\x12\x19\n" +
+	"\border_id\x18\x03 \x01(\x03R\aorderId\x12\x17\n" +
+	"\auser_id\x18\x04 \x01(\x03R\x06userId\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12%\n" +
+	"\x0esubtotal_minor\x18\x06 \x01(\x03R\rsubtotalMinor\x12%\n" +
+	"\x0ediscount_minor\x18\a \x01(\x03R\rdiscountMinor\x12\x1b\n" +
+	"\ttax_minor\x18\b \x01(\x03R\btaxMinor\x12\x1f\n" +
+	"\vtotal_minor\x18\t \x01(\x03R\n" +
+	"totalMinor\x12(\n" +
+	"\x10tax_rate_percent\x18\n" +
+	" \x01(\x05R\x0etaxRatePercent\x127\n" +
+	"\tissued_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\bissuedAt\"\x89\x01\n" +
+	"\n" +
+	"ReturnItem\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x03R\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12!\n" +
+	"\frefund_minor\x18\x03 \x01(\x03R\vrefundMinor\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x04 \x01(\x03R\tvariantId\"\xa9\x01\n" +
 	"\x05Error\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x126\n" +
 	"\adetails\x18\x03 \x03(\v2\x1c.order.v1.Error.DetailsEntryR\adetails\x1a:\n" +
 	"\fDetailsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"X\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x86\x02\n" +
 	"\x12CreateOrderRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12)\n" +
-	"\x05items\x18\x02 \x03(\v2\x13.order.v1.OrderItemR\x05items\"e\n" +
+	"\x05items\x18\x02 \x03(\v2\x13.order.v1.OrderItemR\x05items\x12\x1f\n" +
+	"\vpromo_codes\x18\x03 \x03(\tR\n" +
+	"promoCodes\x12\x1f\n" +
+	"\n" +
+	"address_id\x18\x04 \x01(\x03H\x00R\taddressId\x125\n" +
+	"\aaddress\x18\x05 \x01(\v2\x19.order.v1.ShippingAddressH\x00R\aaddress\x12'\n" +
+	"\x0fdelivery_method\x18\x06 \x01(\tR\x0edeliveryMethodB\n" +
+	"\n" +
+	"\bshipping\"e\n" +
 	"\x13CreateOrderResponse\x12\x1b\n" +
 	"\border_id\x18\x01 \x01(\x03H\x00R\aorderId\x12'\n" +
 	"\x05error\x18\x02 \x01(\v2\x0f.order.v1.ErrorH\x00R\x05errorB\b\n" +
-	"\x06result\"`\n" +
+	"\x06result\"\xa5\x01\n" +
 	"\x12CancelOrderRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\x03R\aorderId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x16\n" +
-	"\x06reason\x18\x03 \x01(\tR\x06reason\"|\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12.\n" +
+	"\x10expected_version\x18\x04 \x01(\x03H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"|\n" +
 	"\x13CancelOrderResponse\x122\n" +
 	"\asuccess\x18\x01 \x01(\v2\x16.google.protobuf.EmptyH\x00R\asuccess\x12'\n" +
 	"\x05error\x18\x02 \x01(\v2\x0f.order.v1.ErrorH\x00R\x05errorB\b\n" +
-	"\x06result\"\xbe\x01\n" +
+	"\x06result\"\x83\x02\n" +
 	"\x12UpdateOrderRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\x03R\aorderId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x1b\n" +
 	"\x06status\x18\x03 \x01(\tH\x00R\x06status\x88\x01\x01\x124\n" +
-	"\x13cancellation_reason\x18\x04 \x01(\tH\x01R\x12cancellationReason\x88\x01\x01B\t\n" +
+	"\x13cancellation_reason\x18\x04 \x01(\tH\x01R\x12cancellationReason\x88\x01\x01\x12.\n" +
+	"\x10expected_version\x18\x05 \x01(\x03H\x02R\x0fexpectedVersion\x88\x01\x01B\t\n" +
 	"\a_statusB\x16\n" +
-	"\x14_cancellation_reason\"|\n" +
+	"\x14_cancellation_reasonB\x13\n" +
+	"\x11_expected_version\"|\n" +
 	"\x13UpdateOrderResponse\x122\n" +
 	"\asuccess\x18\x01 \x01(\v2\x16.google.protobuf.EmptyH\x00R\asuccess\x12'\n" +
 	"\x05error\x18\x02 \x01(\v2\x0f.order.v1.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"\xbd\x01\n" +
+	"\x17UpdateOrderItemsRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12)\n" +
+	"\x05items\x18\x03 \x03(\v2\x13.order.v1.OrderItemR\x05items\x12.\n" +
+	"\x10expected_version\x18\x04 \x01(\x03H\x00R\x0fexpectedVersion\x88\x01\x01B\x13\n" +
+	"\x11_expected_version\"v\n" +
+	"\x18UpdateOrderItemsResponse\x12'\n" +
+	"\x05order\x18\x01 \x01(\v2\x0f.order.v1.OrderH\x00R\x05order\x12'\n" +
+	"\x05error\x18\x02 \x01(\v2\x0f.order.v1.ErrorH\x00R\x05errorB\b\n" +
 	"\x06result\",\n" +
 	"\x0fGetOrderRequest\x12\x19\n" +
 	"\border_id\x18\x01 \x01(\x03R\aorderId\"n\n" +
@@ -1147,22 +6658,316 @@ const file_bff_api_proto_order_v1_order_proto_rawDesc = "" +
 	"\vtotal_count\x18\x02 \x01(\x05R\n" +
 	"totalCount\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"/\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\x80\x04\n" +
+	"\x13SearchOrdersRequest\x12\x1a\n" +
+	"\bstatuses\x18\x01 \x03(\tR\bstatuses\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x03 \x01(\x03R\tproductId\x12+\n" +
+	"\x0fmin_total_minor\x18\x04 \x01(\x03H\x00R\rminTotalMinor\x88\x01\x01\x12+\n" +
+	"\x0fmax_total_minor\x18\x05 \x01(\x03H\x01R\rmaxTotalMinor\x88\x01\x01\x12<\n" +
+	"\tfrom_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampH\x02R\bfromDate\x88\x01\x01\x128\n" +
+	"\ato_date\x18\a \x01(\v2\x1a.google.protobuf.TimestampH\x03R\x06toDate\x88\x01\x01\x12\x14\n" +
+	"\x05query\x18\b \x01(\tR\x05query\x12\x17\n" +
+	"\asort_by\x18\t \x01(\tR\x06sortBy\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\n" +
+	" \x01(\tR\tsortOrder\x12\x1b\n" +
+	"\tpage_size\x18\v \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06cursor\x18\f \x01(\tR\x06cursorB\x12\n" +
+	"\x10_min_total_minorB\x12\n" +
+	"\x10_max_total_minorB\f\n" +
+	"\n" +
+	"_from_dateB\n" +
+	"\n" +
+	"\b_to_date\"`\n" +
+	"\x14SearchOrdersResponse\x12'\n" +
+	"\x06orders\x18\x01 \x03(\v2\x0f.order.v1.OrderR\x06orders\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"/\n" +
 	"\x14GetOrderStatsRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\x03R\x06userId\"\xc4\x01\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\"\x90\x02\n" +
 	"\x15GetOrderStatsResponse\x12!\n" +
 	"\ftotal_orders\x18\x01 \x01(\x05R\vtotalOrders\x12#\n" +
-	"\ractive_orders\x18\x02 \x01(\x05R\factiveOrders\x12\x1f\n" +
-	"\vtotal_spent\x18\x03 \x01(\x01R\n" +
+	"\ractive_orders\x18\x02 \x01(\x05R\factiveOrders\x12#\n" +
+	"\vtotal_spent\x18\x03 \x01(\x01B\x02\x18\x01R\n" +
 	"totalSpent\x12B\n" +
-	"\x0flast_order_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rlastOrderDate2\xd9\x03\n" +
+	"\x0flast_order_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\rlastOrderDate\x12*\n" +
+	"\x11total_spent_minor\x18\x05 \x01(\x03R\x0ftotalSpentMinor\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\"x\n" +
+	"\x11QuoteOrderRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12)\n" +
+	"\x05items\x18\x02 \x03(\v2\x13.order.v1.OrderItemR\x05items\x12\x1f\n" +
+	"\vpromo_codes\x18\x03 \x03(\tR\n" +
+	"promoCodes\"p\n" +
+	"\x12QuoteOrderResponse\x12'\n" +
+	"\x05quote\x18\x01 \x01(\v2\x0f.order.v1.QuoteH\x00R\x05quote\x12'\n" +
+	"\x05error\x18\x02 \x01(\v2\x0f.order.v1.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"K\n" +
+	"\x16CreatePromotionRequest\x121\n" +
+	"\tpromotion\x18\x01 \x01(\v2\x13.order.v1.PromotionR\tpromotion\"\x81\x01\n" +
+	"\x17CreatePromotionResponse\x123\n" +
+	"\tpromotion\x18\x01 \x01(\v2\x13.order.v1.PromotionH\x00R\tpromotion\x12'\n" +
+	"\x05error\x18\x02 \x01(\v2\x0f.order.v1.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"K\n" +
+	"\x16UpdatePromotionRequest\x121\n" +
+	"\tpromotion\x18\x01 \x01(\v2\x13.order.v1.PromotionR\tpromotion\"\x81\x01\n" +
+	"\x17UpdatePromotionResponse\x123\n" +
+	"\tpromotion\x18\x01 \x01(\v2\x13.order.v1.PromotionH\x00R\tpromotion\x12'\n" +
+	"\x05error\x18\x02 \x01(\v2\x0f.order.v1.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"i\n" +
+	"\x15ListPromotionsRequest\x12\x1f\n" +
+	"\vactive_only\x18\x01 \x01(\bR\n" +
+	"activeOnly\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\x9f\x01\n" +
+	"\x16ListPromotionsResponse\x123\n" +
+	"\n" +
+	"promotions\x18\x01 \x03(\v2\x13.order.v1.PromotionR\n" +
+	"promotions\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"?\n" +
+	"\x1aDeactivatePromotionRequest\x12!\n" +
+	"\fpromotion_id\x18\x01 \x01(\x03R\vpromotionId\"\x84\x01\n" +
+	"\x1bDeactivatePromotionResponse\x122\n" +
+	"\asuccess\x18\x01 \x01(\v2\x16.google.protobuf.EmptyH\x00R\asuccess\x12'\n" +
+	"\x05error\x18\x02 \x01(\v2\x0f.order.v1.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"Q\n" +
+	"\x0fPayOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x12#\n" +
+	"\rpayment_token\x18\x02 \x01(\tR\fpaymentToken\"t\n" +
+	"\x10PayOrderResponse\x12-\n" +
+	"\apayment\x18\x01 \x01(\v2\x11.order.v1.PaymentH\x00R\apayment\x12'\n" +
+	"\x05error\x18\x02 \x01(\v2\x0f.order.v1.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"4\n" +
+	"\x17GetOrderPaymentsRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\"I\n" +
+	"\x18GetOrderPaymentsResponse\x12-\n" +
+	"\bpayments\x18\x01 \x03(\v2\x11.order.v1.PaymentR\bpayments\"Y\n" +
+	"\x15CapturePaymentRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\x03R\tpaymentId\x12!\n" +
+	"\famount_minor\x18\x02 \x01(\x03R\vamountMinor\"z\n" +
+	"\x16CapturePaymentResponse\x12-\n" +
+	"\apayment\x18\x01 \x01(\v2\x11.order.v1.PaymentH\x00R\apayment\x12'\n" +
+	"\x05error\x18\x02 \x01(\v2\x0f.order.v1.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"X\n" +
+	"\x14RefundPaymentRequest\x12\x1d\n" +
+	"\n" +
+	"payment_id\x18\x01 \x01(\x03R\tpaymentId\x12!\n" +
+	"\famount_minor\x18\x02 \x01(\x03R\vamountMinor\"y\n" +
+	"\x15RefundPaymentResponse\x12-\n" +
+	"\apayment\x18\x01 \x01(\v2\x11.order.v1.PaymentH\x00R\apayment\x12'\n" +
+	"\x05error\x18\x02 \x01(\v2\x0f.order.v1.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"\x8f\x01\n" +
+	"\x1dListStockCompensationsRequest\x12\x12\n" +
+	"\x04dead\x18\x01 \x01(\bR\x04dead\x12)\n" +
+	"\x10include_resolved\x18\x02 \x01(\bR\x0fincludeResolved\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\xb5\x01\n" +
+	"\x1eListStockCompensationsResponse\x12A\n" +
+	"\rcompensations\x18\x01 \x03(\v2\x1b.order.v1.StockCompensationR\rcompensations\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"C\n" +
+	"\x1dRetryStockCompensationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04dead\x18\x02 \x01(\bR\x04dead\"\x96\x01\n" +
+	"\x1eRetryStockCompensationResponse\x12A\n" +
+	"\fcompensation\x18\x01 \x01(\v2\x1b.order.v1.StockCompensationH\x00R\fcompensation\x12'\n" +
+	"\x05error\x18\x02 \x01(\v2\x0f.order.v1.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"e\n" +
+	"\x1fResolveStockCompensationRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04dead\x18\x02 \x01(\bR\x04dead\x12\x1e\n" +
+	"\n" +
+	"resolution\x18\x03 \x01(\tR\n" +
+	"resolution\"\x98\x01\n" +
+	" ResolveStockCompensationResponse\x12A\n" +
+	"\fcompensation\x18\x01 \x01(\v2\x1b.order.v1.StockCompensationH\x00R\fcompensation\x12'\n" +
+	"\x05error\x18\x02 \x01(\v2\x0f.order.v1.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"\x8e\x01\n" +
+	"\x13CreateReturnRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x12*\n" +
+	"\x05items\x18\x02 \x03(\v2\x14.order.v1.ReturnItemR\x05items\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x18\n" +
+	"\acomment\x18\x04 \x01(\tR\acomment\"\x85\x01\n" +
+	"\x14CreateReturnResponse\x12:\n" +
+	"\forder_return\x18\x01 \x01(\v2\x15.order.v1.OrderReturnH\x00R\vorderReturn\x12'\n" +
+	"\x05error\x18\x02 \x01(\v2\x0f.order.v1.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"/\n" +
+	"\x10GetReturnRequest\x12\x1b\n" +
+	"\treturn_id\x18\x01 \x01(\x03R\breturnId\"\x82\x01\n" +
+	"\x11GetReturnResponse\x12:\n" +
+	"\forder_return\x18\x01 \x01(\v2\x15.order.v1.OrderReturnH\x00R\vorderReturn\x12'\n" +
+	"\x05error\x18\x02 \x01(\v2\x0f.order.v1.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"\xa1\x01\n" +
+	"\x12ListReturnsRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x1b\n" +
+	"\x06status\x18\x03 \x01(\tH\x00R\x06status\x88\x01\x01\x12\x12\n" +
+	"\x04page\x18\x04 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x05 \x01(\x05R\bpageSizeB\t\n" +
+	"\a_status\"\x98\x01\n" +
+	"\x13ListReturnsResponse\x12/\n" +
+	"\areturns\x18\x01 \x03(\v2\x15.order.v1.OrderReturnR\areturns\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x05R\n" +
+	"totalCount\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"3\n" +
+	"\x14ApproveReturnRequest\x12\x1b\n" +
+	"\treturn_id\x18\x01 \x01(\x03R\breturnId\"\x86\x01\n" +
+	"\x15ApproveReturnResponse\x12:\n" +
+	"\forder_return\x18\x01 \x01(\v2\x15.order.v1.OrderReturnH\x00R\vorderReturn\x12'\n" +
+	"\x05error\x18\x02 \x01(\v2\x0f.order.v1.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"J\n" +
+	"\x13RejectReturnRequest\x12\x1b\n" +
+	"\treturn_id\x18\x01 \x01(\x03R\breturnId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\x85\x01\n" +
+	"\x14RejectReturnResponse\x12:\n" +
+	"\forder_return\x18\x01 \x01(\v2\x15.order.v1.OrderReturnH\x00R\vorderReturn\x12'\n" +
+	"\x05error\x18\x02 \x01(\v2\x0f.order.v1.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"3\n" +
+	"\x14ReceiveReturnRequest\x12\x1b\n" +
+	"\treturn_id\x18\x01 \x01(\x03R\breturnId\"\x86\x01\n" +
+	"\x15ReceiveReturnResponse\x12:\n" +
+	"\forder_return\x18\x01 \x01(\v2\x15.order.v1.OrderReturnH\x00R\vorderReturn\x12'\n" +
+	"\x05error\x18\x02 \x01(\v2\x0f.order.v1.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"2\n" +
+	"\x13RefundReturnRequest\x12\x1b\n" +
+	"\treturn_id\x18\x01 \x01(\x03R\breturnId\"\x85\x01\n" +
+	"\x14RefundReturnResponse\x12:\n" +
+	"\forder_return\x18\x01 \x01(\v2\x15.order.v1.OrderReturnH\x00R\vorderReturn\x12'\n" +
+	"\x05error\x18\x02 \x01(\v2\x0f.order.v1.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"F\n" +
+	"\x11GetInvoiceRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x12\x16\n" +
+	"\x06format\x18\x02 \x01(\tR\x06format\"\x97\x01\n" +
+	"\x0fInvoiceDocument\x12+\n" +
+	"\ainvoice\x18\x01 \x01(\v2\x11.order.v1.InvoiceR\ainvoice\x12!\n" +
+	"\fcontent_type\x18\x02 \x01(\tR\vcontentType\x12\x1a\n" +
+	"\bfilename\x18\x03 \x01(\tR\bfilename\x12\x18\n" +
+	"\acontent\x18\x04 \x01(\fR\acontent\"\x80\x01\n" +
+	"\x12GetInvoiceResponse\x127\n" +
+	"\bdocument\x18\x01 \x01(\v2\x19.order.v1.InvoiceDocumentH\x00R\bdocument\x12'\n" +
+	"\x05error\x18\x02 \x01(\v2\x0f.order.v1.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"\xf1\x02\n" +
+	"\vReorderLine\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x03R\tproductId\x12!\n" +
+	"\fproduct_name\x18\x02 \x01(\tR\vproductName\x12-\n" +
+	"\x12requested_quantity\x18\x03 \x01(\x05R\x11requestedQuantity\x12\x1a\n" +
+	"\bquantity\x18\x04 \x01(\x05R\bquantity\x120\n" +
+	"\x14previous_price_minor\x18\x05 \x01(\x03R\x12previousPriceMinor\x12\x1f\n" +
+	"\vprice_minor\x18\x06 \x01(\x03R\n" +
+	"priceMinor\x12\x1a\n" +
+	"\bcurrency\x18\a \x01(\tR\bcurrency\x12\"\n" +
+	"\favailability\x18\b \x01(\tR\favailability\x12#\n" +
+	"\rprice_changed\x18\t \x01(\bR\fpriceChanged\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\n" +
+	" \x01(\x03R\tvariantId\"q\n" +
+	"\rReorderResult\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x12+\n" +
+	"\x05lines\x18\x02 \x03(\v2\x15.order.v1.ReorderLineR\x05lines\x12\x18\n" +
+	"\achanged\x18\x03 \x01(\bR\achanged\"\xf2\x01\n" +
+	"\x0eReorderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\x03R\aorderId\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\x12\x1f\n" +
+	"\vpromo_codes\x18\x03 \x03(\tR\n" +
+	"promoCodes\x12\x1f\n" +
+	"\n" +
+	"address_id\x18\x04 \x01(\x03H\x00R\taddressId\x125\n" +
+	"\aaddress\x18\x05 \x01(\v2\x19.order.v1.ShippingAddressH\x00R\aaddress\x12'\n" +
+	"\x0fdelivery_method\x18\x06 \x01(\tR\x0edeliveryMethodB\n" +
+	"\n" +
+	"\bshipping\"y\n" +
+	"\x0fReorderResponse\x123\n" +
+	"\areorder\x18\x01 \x01(\v2\x17.order.v1.ReorderResultH\x00R\areorder\x12'\n" +
+	"\x05error\x18\x02 \x01(\v2\x0f.order.v1.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"\xb5\x02\n" +
+	"\rOrderTemplate\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\x03R\x06userId\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12)\n" +
+	"\x05items\x18\x04 \x03(\v2\x13.order.v1.OrderItemR\x05items\x12'\n" +
+	"\x0fdelivery_method\x18\x05 \x01(\tR\x0edeliveryMethod\x12\x1d\n" +
+	"\n" +
+	"address_id\x18\x06 \x01(\x03R\taddressId\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xc7\x01\n" +
+	"\x1aCreateOrderTemplateRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12)\n" +
+	"\x05items\x18\x02 \x03(\v2\x13.order.v1.OrderItemR\x05items\x12\"\n" +
+	"\rfrom_order_id\x18\x03 \x01(\x03R\vfromOrderId\x12'\n" +
+	"\x0fdelivery_method\x18\x04 \x01(\tR\x0edeliveryMethod\x12\x1d\n" +
+	"\n" +
+	"address_id\x18\x05 \x01(\x03R\taddressId\"\x87\x01\n" +
+	"\x1bCreateOrderTemplateResponse\x125\n" +
+	"\btemplate\x18\x01 \x01(\v2\x17.order.v1.OrderTemplateH\x00R\btemplate\x12'\n" +
+	"\x05error\x18\x02 \x01(\v2\x0f.order.v1.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"\x1b\n" +
+	"\x19ListOrderTemplatesRequest\"S\n" +
+	"\x1aListOrderTemplatesResponse\x125\n" +
+	"\ttemplates\x18\x01 \x03(\v2\x17.order.v1.OrderTemplateR\ttemplates\"=\n" +
+	"\x1aDeleteOrderTemplateRequest\x12\x1f\n" +
+	"\vtemplate_id\x18\x01 \x01(\x03R\n" +
+	"templateId\"\x84\x01\n" +
+	"\x1bDeleteOrderTemplateResponse\x122\n" +
+	"\asuccess\x18\x01 \x01(\v2\x16.google.protobuf.EmptyH\x00R\asuccess\x12'\n" +
+	"\x05error\x18\x02 \x01(\v2\x0f.order.v1.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"w\n" +
+	"\x1aSubmitOrderTemplateRequest\x12\x1f\n" +
+	"\vtemplate_id\x18\x01 \x01(\x03R\n" +
+	"templateId\x12\x17\n" +
+	"\adry_run\x18\x02 \x01(\bR\x06dryRun\x12\x1f\n" +
+	"\vpromo_codes\x18\x03 \x03(\tR\n" +
+	"promoCodes\"\x85\x01\n" +
+	"\x1bSubmitOrderTemplateResponse\x123\n" +
+	"\areorder\x18\x01 \x01(\v2\x17.order.v1.ReorderResultH\x00R\areorder\x12'\n" +
+	"\x05error\x18\x02 \x01(\v2\x0f.order.v1.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result2\x80\x16\n" +
 	"\fOrderService\x12J\n" +
 	"\vCreateOrder\x12\x1c.order.v1.CreateOrderRequest\x1a\x1d.order.v1.CreateOrderResponse\x12J\n" +
 	"\vCancelOrder\x12\x1c.order.v1.CancelOrderRequest\x1a\x1d.order.v1.CancelOrderResponse\x12J\n" +
-	"\vUpdateOrder\x12\x1c.order.v1.UpdateOrderRequest\x1a\x1d.order.v1.UpdateOrderResponse\x12A\n" +
+	"\vUpdateOrder\x12\x1c.order.v1.UpdateOrderRequest\x1a\x1d.order.v1.UpdateOrderResponse\x12Y\n" +
+	"\x10UpdateOrderItems\x12!.order.v1.UpdateOrderItemsRequest\x1a\".order.v1.UpdateOrderItemsResponse\x12A\n" +
 	"\bGetOrder\x12\x19.order.v1.GetOrderRequest\x1a\x1a.order.v1.GetOrderResponse\x12P\n" +
 	"\rGetUserOrders\x12\x1e.order.v1.GetUserOrdersRequest\x1a\x1f.order.v1.GetUserOrdersResponse\x12P\n" +
-	"\rGetOrderStats\x12\x1e.order.v1.GetOrderStatsRequest\x1a\x1f.order.v1.GetOrderStatsResponseBIZGgithub.com/microserviceteam0/bff-gateway/bff/api/proto/order/v1;orderv1b\x06proto3"
+	"\rGetOrderStats\x12\x1e.order.v1.GetOrderStatsRequest\x1a\x1f.order.v1.GetOrderStatsResponse\x12M\n" +
+	"\fSearchOrders\x12\x1d.order.v1.SearchOrdersRequest\x1a\x1e.order.v1.SearchOrdersResponse\x12G\n" +
+	"\n" +
+	"QuoteOrder\x12\x1b.order.v1.QuoteOrderRequest\x1a\x1c.order.v1.QuoteOrderResponse\x12A\n" +
+	"\bPayOrder\x12\x19.order.v1.PayOrderRequest\x1a\x1a.order.v1.PayOrderResponse\x12Y\n" +
+	"\x10GetOrderPayments\x12!.order.v1.GetOrderPaymentsRequest\x1a\".order.v1.GetOrderPaymentsResponse\x12S\n" +
+	"\x0eCapturePayment\x12\x1f.order.v1.CapturePaymentRequest\x1a .order.v1.CapturePaymentResponse\x12P\n" +
+	"\rRefundPayment\x12\x1e.order.v1.RefundPaymentRequest\x1a\x1f.order.v1.RefundPaymentResponse\x12V\n" +
+	"\x0fCreatePromotion\x12 .order.v1.CreatePromotionRequest\x1a!.order.v1.CreatePromotionResponse\x12V\n" +
+	"\x0fUpdatePromotion\x12 .order.v1.UpdatePromotionRequest\x1a!.order.v1.UpdatePromotionResponse\x12S\n" +
+	"\x0eListPromotions\x12\x1f.order.v1.ListPromotionsRequest\x1a .order.v1.ListPromotionsResponse\x12b\n" +
+	"\x13DeactivatePromotion\x12$.order.v1.DeactivatePromotionRequest\x1a%.order.v1.DeactivatePromotionResponse\x12k\n" +
+	"\x16ListStockCompensations\x12'.order.v1.ListStockCompensationsRequest\x1a(.order.v1.ListStockCompensationsResponse\x12k\n" +
+	"\x16RetryStockCompensation\x12'.order.v1.RetryStockCompensationRequest\x1a(.order.v1.RetryStockCompensationResponse\x12q\n" +
+	"\x18ResolveStockCompensation\x12).order.v1.ResolveStockCompensationRequest\x1a*.order.v1.ResolveStockCompensationResponse\x12M\n" +
+	"\fCreateReturn\x12\x1d.order.v1.CreateReturnRequest\x1a\x1e.order.v1.CreateReturnResponse\x12D\n" +
+	"\tGetReturn\x12\x1a.order.v1.GetReturnRequest\x1a\x1b.order.v1.GetReturnResponse\x12J\n" +
+	"\vListReturns\x12\x1c.order.v1.ListReturnsRequest\x1a\x1d.order.v1.ListReturnsResponse\x12P\n" +
+	"\rApproveReturn\x12\x1e.order.v1.ApproveReturnRequest\x1a\x1f.order.v1.ApproveReturnResponse\x12M\n" +
+	"\fRejectReturn\x12\x1d.order.v1.RejectReturnRequest\x1a\x1e.order.v1.RejectReturnResponse\x12P\n" +
+	"\rReceiveReturn\x12\x1e.order.v1.ReceiveReturnRequest\x1a\x1f.order.v1.ReceiveReturnResponse\x12M\n" +
+	"\fRefundReturn\x12\x1d.order.v1.RefundReturnRequest\x1a\x1e.order.v1.RefundReturnResponse\x12G\n" +
+	"\n" +
+	"GetInvoice\x12\x1b.order.v1.GetInvoiceRequest\x1a\x1c.order.v1.GetInvoiceResponse\x12>\n" +
+	"\aReorder\x12\x18.order.v1.ReorderRequest\x1a\x19.order.v1.ReorderResponse\x12b\n" +
+	"\x13CreateOrderTemplate\x12$.order.v1.CreateOrderTemplateRequest\x1a%.order.v1.CreateOrderTemplateResponse\x12_\n" +
+	"\x12ListOrderTemplates\x12#.order.v1.ListOrderTemplatesRequest\x1a$.order.v1.ListOrderTemplatesResponse\x12b\n" +
+	"\x13DeleteOrderTemplate\x12$.order.v1.DeleteOrderTemplateRequest\x1a%.order.v1.DeleteOrderTemplateResponse\x12b\n" +
+	"\x13SubmitOrderTemplate\x12$.order.v1.SubmitOrderTemplateRequest\x1a%.order.v1.SubmitOrderTemplateResponseBIZGgithub.com/microserviceteam0/bff-gateway/bff/api/proto/order/v1;orderv1b\x06proto3"
 
 var (
 	file_bff_api_proto_order_v1_order_proto_rawDescOnce sync.Once
@@ -1176,61 +6981,266 @@ func file_bff_api_proto_order_v1_order_proto_rawDescGZIP() []byte {
 	return file_bff_api_proto_order_v1_order_proto_rawDescData
 }
 
-var file_bff_api_proto_order_v1_order_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_bff_api_proto_order_v1_order_proto_msgTypes = make([]protoimpl.MessageInfo, 83)
 var file_bff_api_proto_order_v1_order_proto_goTypes = []any{
-	(*Order)(nil),                 // 0: order.v1.Order
-	(*OrderItem)(nil),             // 1: order.v1.OrderItem
-	(*Error)(nil),                 // 2: order.v1.Error
-	(*CreateOrderRequest)(nil),    // 3: order.v1.CreateOrderRequest
-	(*CreateOrderResponse)(nil),   // 4: order.v1.CreateOrderResponse
-	(*CancelOrderRequest)(nil),    // 5: order.v1.CancelOrderRequest
-	(*CancelOrderResponse)(nil),   // 6: order.v1.CancelOrderResponse
-	(*UpdateOrderRequest)(nil),    // 7: order.v1.UpdateOrderRequest
-	(*UpdateOrderResponse)(nil),   // 8: order.v1.UpdateOrderResponse
-	(*GetOrderRequest)(nil),       // 9: order.v1.GetOrderRequest
-	(*GetOrderResponse)(nil),      // 10: order.v1.GetOrderResponse
-	(*GetUserOrdersRequest)(nil),  // 11: order.v1.GetUserOrdersRequest
-	(*GetUserOrdersResponse)(nil), // 12: order.v1.GetUserOrdersResponse
-	(*GetOrderStatsRequest)(nil),  // 13: order.v1.GetOrderStatsRequest
-	(*GetOrderStatsResponse)(nil), // 14: order.v1.GetOrderStatsResponse
-	nil,                           // 15: order.v1.Error.DetailsEntry
-	(*timestamppb.Timestamp)(nil), // 16: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),         // 17: google.protobuf.Empty
+	(*Order)(nil),                            // 0: order.v1.Order
+	(*OrderItem)(nil),                        // 1: order.v1.OrderItem
+	(*ShippingAddress)(nil),                  // 2: order.v1.ShippingAddress
+	(*AppliedPromotion)(nil),                 // 3: order.v1.AppliedPromotion
+	(*Promotion)(nil),                        // 4: order.v1.Promotion
+	(*Payment)(nil),                          // 5: order.v1.Payment
+	(*Quote)(nil),                            // 6: order.v1.Quote
+	(*StockCompensation)(nil),                // 7: order.v1.StockCompensation
+	(*OrderReturn)(nil),                      // 8: order.v1.OrderReturn
+	(*Invoice)(nil),                          // 9: order.v1.Invoice
+	(*ReturnItem)(nil),                       // 10: order.v1.ReturnItem
+	(*Error)(nil),                            // 11: order.v1.Error
+	(*CreateOrderRequest)(nil),               // 12: order.v1.CreateOrderRequest
+	(*CreateOrderResponse)(nil),              // 13: order.v1.CreateOrderResponse
+	(*CancelOrderRequest)(nil),               // 14: order.v1.CancelOrderRequest
+	(*CancelOrderResponse)(nil),              // 15: order.v1.CancelOrderResponse
+	(*UpdateOrderRequest)(nil),               // 16: order.v1.UpdateOrderRequest
+	(*UpdateOrderResponse)(nil),              // 17: order.v1.UpdateOrderResponse
+	(*UpdateOrderItemsRequest)(nil),          // 18: order.v1.UpdateOrderItemsRequest
+	(*UpdateOrderItemsResponse)(nil),         // 19: order.v1.UpdateOrderItemsResponse
+	(*GetOrderRequest)(nil),                  // 20: order.v1.GetOrderRequest
+	(*GetOrderResponse)(nil),                 // 21: order.v1.GetOrderResponse
+	(*GetUserOrdersRequest)(nil),             // 22: order.v1.GetUserOrdersRequest
+	(*GetUserOrdersResponse)(nil),            // 23: order.v1.GetUserOrdersResponse
+	(*SearchOrdersRequest)(nil),              // 24: order.v1.SearchOrdersRequest
+	(*SearchOrdersResponse)(nil),             // 25: order.v1.SearchOrdersResponse
+	(*GetOrderStatsRequest)(nil),             // 26: order.v1.GetOrderStatsRequest
+	(*GetOrderStatsResponse)(nil),            // 27: order.v1.GetOrderStatsResponse
+	(*QuoteOrderRequest)(nil),                // 28: order.v1.QuoteOrderRequest
+	(*QuoteOrderResponse)(nil),               // 29: order.v1.QuoteOrderResponse
+	(*CreatePromotionRequest)(nil),           // 30: order.v1.CreatePromotionRequest
+	(*CreatePromotionResponse)(nil),          // 31: order.v1.CreatePromotionResponse
+	(*UpdatePromotionRequest)(nil),           // 32: order.v1.UpdatePromotionRequest
+	(*UpdatePromotionResponse)(nil),          // 33: order.v1.UpdatePromotionResponse
+	(*ListPromotionsRequest)(nil),            // 34: order.v1.ListPromotionsRequest
+	(*ListPromotionsResponse)(nil),           // 35: order.v1.ListPromotionsResponse
+	(*DeactivatePromotionRequest)(nil),       // 36: order.v1.DeactivatePromotionRequest
+	(*DeactivatePromotionResponse)(nil),      // 37: order.v1.DeactivatePromotionResponse
+	(*PayOrderRequest)(nil),                  // 38: order.v1.PayOrderRequest
+	(*PayOrderResponse)(nil),                 // 39: order.v1.PayOrderResponse
+	(*GetOrderPaymentsRequest)(nil),          // 40: order.v1.GetOrderPaymentsRequest
+	(*GetOrderPaymentsResponse)(nil),         // 41: order.v1.GetOrderPaymentsResponse
+	(*CapturePaymentRequest)(nil),            // 42: order.v1.CapturePaymentRequest
+	(*CapturePaymentResponse)(nil),           // 43: order.v1.CapturePaymentResponse
+	(*RefundPaymentRequest)(nil),             // 44: order.v1.RefundPaymentRequest
+	(*RefundPaymentResponse)(nil),            // 45: order.v1.RefundPaymentResponse
+	(*ListStockCompensationsRequest)(nil),    // 46: order.v1.ListStockCompensationsRequest
+	(*ListStockCompensationsResponse)(nil),   // 47: order.v1.ListStockCompensationsResponse
+	(*RetryStockCompensationRequest)(nil),    // 48: order.v1.RetryStockCompensationRequest
+	(*RetryStockCompensationResponse)(nil),   // 49: order.v1.RetryStockCompensationResponse
+	(*ResolveStockCompensationRequest)(nil),  // 50: order.v1.ResolveStockCompensationRequest
+	(*ResolveStockCompensationResponse)(nil), // 51: order.v1.ResolveStockCompensationResponse
+	(*CreateReturnRequest)(nil),              // 52: order.v1.CreateReturnRequest
+	(*CreateReturnResponse)(nil),             // 53: order.v1.CreateReturnResponse
+	(*GetReturnRequest)(nil),                 // 54: order.v1.GetReturnRequest
+	(*GetReturnResponse)(nil),                // 55: order.v1.GetReturnResponse
+	(*ListReturnsRequest)(nil),               // 56: order.v1.ListReturnsRequest
+	(*ListReturnsResponse)(nil),              // 57: order.v1.ListReturnsResponse
+	(*ApproveReturnRequest)(nil),             // 58: order.v1.ApproveReturnRequest
+	(*ApproveReturnResponse)(nil),            // 59: order.v1.ApproveReturnResponse
+	(*RejectReturnRequest)(nil),              // 60: order.v1.RejectReturnRequest
+	(*RejectReturnResponse)(nil),             // 61: order.v1.RejectReturnResponse
+	(*ReceiveReturnRequest)(nil),             // 62: order.v1.ReceiveReturnRequest
+	(*ReceiveReturnResponse)(nil),            // 63: order.v1.ReceiveReturnResponse
+	(*RefundReturnRequest)(nil),              // 64: order.v1.RefundReturnRequest
+	(*RefundReturnResponse)(nil),             // 65: order.v1.RefundReturnResponse
+	(*GetInvoiceRequest)(nil),                // 66: order.v1.GetInvoiceRequest
+	(*InvoiceDocument)(nil),                  // 67: order.v1.InvoiceDocument
+	(*GetInvoiceResponse)(nil),               // 68: order.v1.GetInvoiceResponse
+	(*ReorderLine)(nil),                      // 69: order.v1.ReorderLine
+	(*ReorderResult)(nil),                    // 70: order.v1.ReorderResult
+	(*ReorderRequest)(nil),                   // 71: order.v1.ReorderRequest
+	(*ReorderResponse)(nil),                  // 72: order.v1.ReorderResponse
+	(*OrderTemplate)(nil),                    // 73: order.v1.OrderTemplate
+	(*CreateOrderTemplateRequest)(nil),       // 74: order.v1.CreateOrderTemplateRequest
+	(*CreateOrderTemplateResponse)(nil),      // 75: order.v1.CreateOrderTemplateResponse
+	(*ListOrderTemplatesRequest)(nil),        // 76: order.v1.ListOrderTemplatesRequest
+	(*ListOrderTemplatesResponse)(nil),       // 77: order.v1.ListOrderTemplatesResponse
+	(*DeleteOrderTemplateRequest)(nil),       // 78: order.v1.DeleteOrderTemplateRequest
+	(*DeleteOrderTemplateResponse)(nil),      // 79: order.v1.DeleteOrderTemplateResponse
+	(*SubmitOrderTemplateRequest)(nil),       // 80: order.v1.SubmitOrderTemplateRequest
+	(*SubmitOrderTemplateResponse)(nil),      // 81: order.v1.SubmitOrderTemplateResponse
+	nil,                                      // 82: order.v1.Error.DetailsEntry
+	(*timestamppb.Timestamp)(nil),            // 83: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                    // 84: google.protobuf.Empty
 }
 var file_bff_api_proto_order_v1_order_proto_depIdxs = []int32{
-	1,  // 0: order.v1.Order.items:type_name -> order.v1.OrderItem
-	16, // 1: order.v1.Order.created_at:type_name -> google.protobuf.Timestamp
-	16, // 2: order.v1.Order.updated_at:type_name -> google.protobuf.Timestamp
-	15, // 3: order.v1.Error.details:type_name -> order.v1.Error.DetailsEntry
-	1,  // 4: order.v1.CreateOrderRequest.items:type_name -> order.v1.OrderItem
-	2,  // 5: order.v1.CreateOrderResponse.error:type_name -> order.v1.Error
-	17, // 6: order.v1.CancelOrderResponse.success:type_name -> google.protobuf.Empty
-	2,  // 7: order.v1.CancelOrderResponse.error:type_name -> order.v1.Error
-	17, // 8: order.v1.UpdateOrderResponse.success:type_name -> google.protobuf.Empty
-	2,  // 9: order.v1.UpdateOrderResponse.error:type_name -> order.v1.Error
-	0,  // 10: order.v1.GetOrderResponse.order:type_name -> order.v1.Order
-	2,  // 11: order.v1.GetOrderResponse.error:type_name -> order.v1.Error
-	16, // 12: order.v1.GetUserOrdersRequest.from_date:type_name -> google.protobuf.Timestamp
-	16, // 13: order.v1.GetUserOrdersRequest.to_date:type_name -> google.protobuf.Timestamp
-	0,  // 14: order.v1.GetUserOrdersResponse.orders:type_name -> order.v1.Order
-	16, // 15: order.v1.GetOrderStatsResponse.last_order_date:type_name -> google.protobuf.Timestamp
-	3,  // 16: order.v1.OrderService.CreateOrder:input_type -> order.v1.CreateOrderRequest
-	5,  // 17: order.v1.OrderService.CancelOrder:input_type -> order.v1.CancelOrderRequest
-	7,  // 18: order.v1.OrderService.UpdateOrder:input_type -> order.v1.UpdateOrderRequest
-	9,  // 19: order.v1.OrderService.GetOrder:input_type -> order.v1.GetOrderRequest
-	11, // 20: order.v1.OrderService.GetUserOrders:input_type -> order.v1.GetUserOrdersRequest
-	13, // 21: order.v1.OrderService.GetOrderStats:input_type -> order.v1.GetOrderStatsRequest
-	4,  // 22: order.v1.OrderService.CreateOrder:output_type -> order.v1.CreateOrderResponse
-	6,  // 23: order.v1.OrderService.CancelOrder:output_type -> order.v1.CancelOrderResponse
-	8,  // 24: order.v1.OrderService.UpdateOrder:output_type -> order.v1.UpdateOrderResponse
-	10, // 25: order.v1.OrderService.GetOrder:output_type -> order.v1.GetOrderResponse
-	12, // 26: order.v1.OrderService.GetUserOrders:output_type -> order.v1.GetUserOrdersResponse
-	14, // 27: order.v1.OrderService.GetOrderStats:output_type -> order.v1.GetOrderStatsResponse
-	22, // [22:28] is the sub-list for method output_type
-	16, // [16:22] is the sub-list for method input_type
-	16, // [16:16] is the sub-list for extension type_name
-	16, // [16:16] is the sub-list for extension extendee
-	0,  // [0:16] is the sub-list for field type_name
+	1,   // 0: order.v1.Order.items:type_name -> order.v1.OrderItem
+	83,  // 1: order.v1.Order.created_at:type_name -> google.protobuf.Timestamp
+	83,  // 2: order.v1.Order.updated_at:type_name -> google.protobuf.Timestamp
+	3,   // 3: order.v1.Order.promotions:type_name -> order.v1.AppliedPromotion
+	2,   // 4: order.v1.Order.shipping_address:type_name -> order.v1.ShippingAddress
+	83,  // 5: order.v1.Promotion.starts_at:type_name -> google.protobuf.Timestamp
+	83,  // 6: order.v1.Promotion.ends_at:type_name -> google.protobuf.Timestamp
+	83,  // 7: order.v1.Promotion.created_at:type_name -> google.protobuf.Timestamp
+	83,  // 8: order.v1.Promotion.updated_at:type_name -> google.protobuf.Timestamp
+	83,  // 9: order.v1.Payment.created_at:type_name -> google.protobuf.Timestamp
+	83,  // 10: order.v1.Payment.updated_at:type_name -> google.protobuf.Timestamp
+	1,   // 11: order.v1.Quote.items:type_name -> order.v1.OrderItem
+	3,   // 12: order.v1.Quote.promotions:type_name -> order.v1.AppliedPromotion
+	83,  // 13: order.v1.StockCompensation.next_attempt_at:type_name -> google.protobuf.Timestamp
+	83,  // 14: order.v1.StockCompensation.created_at:type_name -> google.protobuf.Timestamp
+	83,  // 15: order.v1.StockCompensation.dead_at:type_name -> google.protobuf.Timestamp
+	83,  // 16: order.v1.StockCompensation.resolved_at:type_name -> google.protobuf.Timestamp
+	10,  // 17: order.v1.OrderReturn.items:type_name -> order.v1.ReturnItem
+	83,  // 18: order.v1.OrderReturn.created_at:type_name -> google.protobuf.Timestamp
+	83,  // 19: order.v1.OrderReturn.updated_at:type_name -> google.protobuf.Timestamp
+	83,  // 20: order.v1.OrderReturn.reviewed_at:type_name -> google.protobuf.Timestamp
+	83,  // 21: order.v1.OrderReturn.received_at:type_name -> google.protobuf.Timestamp
+	83,  // 22: order.v1.OrderReturn.refunded_at:type_name -> google.protobuf.Timestamp
+	83,  // 23: order.v1.Invoice.issued_at:type_name -> google.protobuf.Timestamp
+	82,  // 24: order.v1.Error.details:type_name -> order.v1.Error.DetailsEntry
+	1,   // 25: order.v1.CreateOrderRequest.items:type_name -> order.v1.OrderItem
+	2,   // 26: order.v1.CreateOrderRequest.address:type_name -> order.v1.ShippingAddress
+	11,  // 27: order.v1.CreateOrderResponse.error:type_name -> order.v1.Error
+	84,  // 28: order.v1.CancelOrderResponse.success:type_name -> google.protobuf.Empty
+	11,  // 29: order.v1.CancelOrderResponse.error:type_name -> order.v1.Error
+	84,  // 30: order.v1.UpdateOrderResponse.success:type_name -> google.protobuf.Empty
+	11,  // 31: order.v1.UpdateOrderResponse.error:type_name -> order.v1.Error
+	1,   // 32: order.v1.UpdateOrderItemsRequest.items:type_name -> order.v1.OrderItem
+	0,   // 33: order.v1.UpdateOrderItemsResponse.order:type_name -> order.v1.Order
+	11,  // 34: order.v1.UpdateOrderItemsResponse.error:type_name -> order.v1.Error
+	0,   // 35: order.v1.GetOrderResponse.order:type_name -> order.v1.Order
+	11,  // 36: order.v1.GetOrderResponse.error:type_name -> order.v1.Error
+	83,  // 37: order.v1.GetUserOrdersRequest.from_date:type_name -> google.protobuf.Timestamp
+	83,  // 38: order.v1.GetUserOrdersRequest.to_date:type_name -> google.protobuf.Timestamp
+	0,   // 39: order.v1.GetUserOrdersResponse.orders:type_name -> order.v1.Order
+	83,  // 40: order.v1.SearchOrdersRequest.from_date:type_name -> google.protobuf.Timestamp
+	83,  // 41: order.v1.SearchOrdersRequest.to_date:type_name -> google.protobuf.Timestamp
+	0,   // 42: order.v1.SearchOrdersResponse.orders:type_name -> order.v1.Order
+	83,  // 43: order.v1.GetOrderStatsResponse.last_order_date:type_name -> google.protobuf.Timestamp
+	1,   // 44: order.v1.QuoteOrderRequest.items:type_name -> order.v1.OrderItem
+	6,   // 45: order.v1.QuoteOrderResponse.quote:type_name -> order.v1.Quote
+	11,  // 46: order.v1.QuoteOrderResponse.error:type_name -> order.v1.Error
+	4,   // 47: order.v1.CreatePromotionRequest.promotion:type_name -> order.v1.Promotion
+	4,   // 48: order.v1.CreatePromotionResponse.promotion:type_name -> order.v1.Promotion
+	11,  // 49: order.v1.CreatePromotionResponse.error:type_name -> order.v1.Error
+	4,   // 50: order.v1.UpdatePromotionRequest.promotion:type_name -> order.v1.Promotion
+	4,   // 51: order.v1.UpdatePromotionResponse.promotion:type_name -> order.v1.Promotion
+	11,  // 52: order.v1.UpdatePromotionResponse.error:type_name -> order.v1.Error
+	4,   // 53: order.v1.ListPromotionsResponse.promotions:type_name -> order.v1.Promotion
+	84,  // 54: order.v1.DeactivatePromotionResponse.success:type_name -> google.protobuf.Empty
+	11,  // 55: order.v1.DeactivatePromotionResponse.error:type_name -> order.v1.Error
+	5,   // 56: order.v1.PayOrderResponse.payment:type_name -> order.v1.Payment
+	11,  // 57: order.v1.PayOrderResponse.error:type_name -> order.v1.Error
+	5,   // 58: order.v1.GetOrderPaymentsResponse.payments:type_name -> order.v1.Payment
+	5,   // 59: order.v1.CapturePaymentResponse.payment:type_name -> order.v1.Payment
+	11,  // 60: order.v1.CapturePaymentResponse.error:type_name -> order.v1.Error
+	5,   // 61: order.v1.RefundPaymentResponse.payment:type_name -> order.v1.Payment
+	11,  // 62: order.v1.RefundPaymentResponse.error:type_name -> order.v1.Error
+	7,   // 63: order.v1.ListStockCompensationsResponse.compensations:type_name -> order.v1.StockCompensation
+	7,   // 64: order.v1.RetryStockCompensationResponse.compensation:type_name -> order.v1.StockCompensation
+	11,  // 65: order.v1.RetryStockCompensationResponse.error:type_name -> order.v1.Error
+	7,   // 66: order.v1.ResolveStockCompensationResponse.compensation:type_name -> order.v1.StockCompensation
+	11,  // 67: order.v1.ResolveStockCompensationResponse.error:type_name -> order.v1.Error
+	10,  // 68: order.v1.CreateReturnRequest.items:type_name -> order.v1.ReturnItem
+	8,   // 69: order.v1.CreateReturnResponse.order_return:type_name -> order.v1.OrderReturn
+	11,  // 70: order.v1.CreateReturnResponse.error:type_name -> order.v1.Error
+	8,   // 71: order.v1.GetReturnResponse.order_return:type_name -> order.v1.OrderReturn
+	11,  // 72: order.v1.GetReturnResponse.error:type_name -> order.v1.Error
+	8,   // 73: order.v1.ListReturnsResponse.returns:type_name -> order.v1.OrderReturn
+	8,   // 74: order.v1.ApproveReturnResponse.order_return:type_name -> order.v1.OrderReturn
+	11,  // 75: order.v1.ApproveReturnResponse.error:type_name -> order.v1.Error
+	8,   // 76: order.v1.RejectReturnResponse.order_return:type_name -> order.v1.OrderReturn
+	11,  // 77: order.v1.RejectReturnResponse.error:type_name -> order.v1.Error
+	8,   // 78: order.v1.ReceiveReturnResponse.order_return:type_name -> order.v1.OrderReturn
+	11,  // 79: order.v1.ReceiveReturnResponse.error:type_name -> order.v1.Error
+	8,   // 80: order.v1.RefundReturnResponse.order_return:type_name -> order.v1.OrderReturn
+	11,  // 81: order.v1.RefundReturnResponse.error:type_name -> order.v1.Error
+	9,   // 82: order.v1.InvoiceDocument.invoice:type_name -> order.v1.Invoice
+	67,  // 83: order.v1.GetInvoiceResponse.document:type_name -> order.v1.InvoiceDocument
+	11,  // 84: order.v1.GetInvoiceResponse.error:type_name -> order.v1.Error
+	69,  // 85: order.v1.ReorderResult.lines:type_name -> order.v1.ReorderLine
+	2,   // 86: order.v1.ReorderRequest.address:type_name -> order.v1.ShippingAddress
+	70,  // 87: order.v1.ReorderResponse.reorder:type_name -> order.v1.ReorderResult
+	11,  // 88: order.v1.ReorderResponse.error:type_name -> order.v1.Error
+	1,   // 89: order.v1.OrderTemplate.items:type_name -> order.v1.OrderItem
+	83,  // 90: order.v1.OrderTemplate.created_at:type_name -> google.protobuf.Timestamp
+	83,  // 91: order.v1.OrderTemplate.updated_at:type_name -> google.protobuf.Timestamp
+	1,   // 92: order.v1.CreateOrderTemplateRequest.items:type_name -> order.v1.OrderItem
+	73,  // 93: order.v1.CreateOrderTemplateResponse.template:type_name -> order.v1.OrderTemplate
+	11,  // 94: order.v1.CreateOrderTemplateResponse.error:type_name -> order.v1.Error
+	73,  // 95: order.v1.ListOrderTemplatesResponse.templates:type_name -> order.v1.OrderTemplate
+	84,  // 96: order.v1.DeleteOrderTemplateResponse.success:type_name -> google.protobuf.Empty
+	11,  // 97: order.v1.DeleteOrderTemplateResponse.error:type_name -> order.v1.Error
+	70,  // 98: order.v1.SubmitOrderTemplateResponse.reorder:type_name -> order.v1.ReorderResult
+	11,  // 99: order.v1.SubmitOrderTemplateResponse.error:type_name -> order.v1.Error
+	12,  // 100: order.v1.OrderService.CreateOrder:input_type -> order.v1.CreateOrderRequest
+	14,  // 101: order.v1.OrderService.CancelOrder:input_type -> order.v1.CancelOrderRequest
+	16,  // 102: order.v1.OrderService.UpdateOrder:input_type -> order.v1.UpdateOrderRequest
+	18,  // 103: order.v1.OrderService.UpdateOrderItems:input_type -> order.v1.UpdateOrderItemsRequest
+	20,  // 104: order.v1.OrderService.GetOrder:input_type -> order.v1.GetOrderRequest
+	22,  // 105: order.v1.OrderService.GetUserOrders:input_type -> order.v1.GetUserOrdersRequest
+	26,  // 106: order.v1.OrderService.GetOrderStats:input_type -> order.v1.GetOrderStatsRequest
+	24,  // 107: order.v1.OrderService.SearchOrders:input_type -> order.v1.SearchOrdersRequest
+	28,  // 108: order.v1.OrderService.QuoteOrder:input_type -> order.v1.QuoteOrderRequest
+	38,  // 109: order.v1.OrderService.PayOrder:input_type -> order.v1.PayOrderRequest
+	40,  // 110: order.v1.OrderService.GetOrderPayments:input_type -> order.v1.GetOrderPaymentsRequest
+	42,  // 111: order.v1.OrderService.CapturePayment:input_type -> order.v1.CapturePaymentRequest
+	44,  // 112: order.v1.OrderService.RefundPayment:input_type -> order.v1.RefundPaymentRequest
+	30,  // 113: order.v1.OrderService.CreatePromotion:input_type -> order.v1.CreatePromotionRequest
+	32,  // 114: order.v1.OrderService.UpdatePromotion:input_type -> order.v1.UpdatePromotionRequest
+	34,  // 115: order.v1.OrderService.ListPromotions:input_type -> order.v1.ListPromotionsRequest
+	36,  // 116: order.v1.OrderService.DeactivatePromotion:input_type -> order.v1.DeactivatePromotionRequest
+	46,  // 117: order.v1.OrderService.ListStockCompensations:input_type -> order.v1.ListStockCompensationsRequest
+	48,  // 118: order.v1.OrderService.RetryStockCompensation:input_type -> order.v1.RetryStockCompensationRequest
+	50,  // 119: order.v1.OrderService.ResolveStockCompensation:input_type -> order.v1.ResolveStockCompensationRequest
+	52,  // 120: order.v1.OrderService.CreateReturn:input_type -> order.v1.CreateReturnRequest
+	54,  // 121: order.v1.OrderService.GetReturn:input_type -> order.v1.GetReturnRequest
+	56,  // 122: order.v1.OrderService.ListReturns:input_type -> order.v1.ListReturnsRequest
+	58,  // 123: order.v1.OrderService.ApproveReturn:input_type -> order.v1.ApproveReturnRequest
+	60,  // 124: order.v1.OrderService.RejectReturn:input_type -> order.v1.RejectReturnRequest
+	62,  // 125: order.v1.OrderService.ReceiveReturn:input_type -> order.v1.ReceiveReturnRequest
+	64,  // 126: order.v1.OrderService.RefundReturn:input_type -> order.v1.RefundReturnRequest
+	66,  // 127: order.v1.OrderService.GetInvoice:input_type -> order.v1.GetInvoiceRequest
+	71,  // 128: order.v1.OrderService.Reorder:input_type -> order.v1.ReorderRequest
+	74,  // 129: order.v1.OrderService.CreateOrderTemplate:input_type -> order.v1.CreateOrderTemplateRequest
+	76,  // 130: order.v1.OrderService.ListOrderTemplates:input_type -> order.v1.ListOrderTemplatesRequest
+	78,  // 131: order.v1.OrderService.DeleteOrderTemplate:input_type -> order.v1.DeleteOrderTemplateRequest
+	80,  // 132: order.v1.OrderService.SubmitOrderTemplate:input_type -> order.v1.SubmitOrderTemplateRequest
+	13,  // 133: order.v1.OrderService.CreateOrder:output_type -> order.v1.CreateOrderResponse
+	15,  // 134: order.v1.OrderService.CancelOrder:output_type -> order.v1.CancelOrderResponse
+	17,  // 135: order.v1.OrderService.UpdateOrder:output_type -> order.v1.UpdateOrderResponse
+	19,  // 136: order.v1.OrderService.UpdateOrderItems:output_type -> order.v1.UpdateOrderItemsResponse
+	21,  // 137: order.v1.OrderService.GetOrder:output_type -> order.v1.GetOrderResponse
+	23,  // 138: order.v1.OrderService.GetUserOrders:output_type -> order.v1.GetUserOrdersResponse
+	27,  // 139: order.v1.OrderService.GetOrderStats:output_type -> order.v1.GetOrderStatsResponse
+	25,  // 140: order.v1.OrderService.SearchOrders:output_type -> order.v1.SearchOrdersResponse
+	29,  // 141: order.v1.OrderService.QuoteOrder:output_type -> order.v1.QuoteOrderResponse
+	39,  // 142: order.v1.OrderService.PayOrder:output_type -> order.v1.PayOrderResponse
+	41,  // 143: order.v1.OrderService.GetOrderPayments:output_type -> order.v1.GetOrderPaymentsResponse
+	43,  // 144: order.v1.OrderService.CapturePayment:output_type -> order.v1.CapturePaymentResponse
+	45,  // 145: order.v1.OrderService.RefundPayment:output_type -> order.v1.RefundPaymentResponse
+	31,  // 146: order.v1.OrderService.CreatePromotion:output_type -> order.v1.CreatePromotionResponse
+	33,  // 147: order.v1.OrderService.UpdatePromotion:output_type -> order.v1.UpdatePromotionResponse
+	35,  // 148: order.v1.OrderService.ListPromotions:output_type -> order.v1.ListPromotionsResponse
+	37,  // 149: order.v1.OrderService.DeactivatePromotion:output_type -> order.v1.DeactivatePromotionResponse
+	47,  // 150: order.v1.OrderService.ListStockCompensations:output_type -> order.v1.ListStockCompensationsResponse
+	49,  // 151: order.v1.OrderService.RetryStockCompensation:output_type -> order.v1.RetryStockCompensationResponse
+	51,  // 152: order.v1.OrderService.ResolveStockCompensation:output_type -> order.v1.ResolveStockCompensationResponse
+	53,  // 153: order.v1.OrderService.CreateReturn:output_type -> order.v1.CreateReturnResponse
+	55,  // 154: order.v1.OrderService.GetReturn:output_type -> order.v1.GetReturnResponse
+	57,  // 155: order.v1.OrderService.ListReturns:output_type -> order.v1.ListReturnsResponse
+	59,  // 156: order.v1.OrderService.ApproveReturn:output_type -> order.v1.ApproveReturnResponse
+	61,  // 157: order.v1.OrderService.RejectReturn:output_type -> order.v1.RejectReturnResponse
+	63,  // 158: order.v1.OrderService.ReceiveReturn:output_type -> order.v1.ReceiveReturnResponse
+	65,  // 159: order.v1.OrderService.RefundReturn:output_type -> order.v1.RefundReturnResponse
+	68,  // 160: order.v1.OrderService.GetInvoice:output_type -> order.v1.GetInvoiceResponse
+	72,  // 161: order.v1.OrderService.Reorder:output_type -> order.v1.ReorderResponse
+	75,  // 162: order.v1.OrderService.CreateOrderTemplate:output_type -> order.v1.CreateOrderTemplateResponse
+	77,  // 163: order.v1.OrderService.ListOrderTemplates:output_type -> order.v1.ListOrderTemplatesResponse
+	79,  // 164: order.v1.OrderService.DeleteOrderTemplate:output_type -> order.v1.DeleteOrderTemplateResponse
+	81,  // 165: order.v1.OrderService.SubmitOrderTemplate:output_type -> order.v1.SubmitOrderTemplateResponse
+	133, // [133:166] is the sub-list for method output_type
+	100, // [100:133] is the sub-list for method input_type
+	100, // [100:100] is the sub-list for extension type_name
+	100, // [100:100] is the sub-list for extension extendee
+	0,   // [0:100] is the sub-list for field type_name
 }
 
 func init() { file_bff_api_proto_order_v1_order_proto_init() }
@@ -1238,31 +7248,127 @@ func file_bff_api_proto_order_v1_order_proto_init() {
 	if File_bff_api_proto_order_v1_order_proto != nil {
 		return
 	}
-	file_bff_api_proto_order_v1_order_proto_msgTypes[4].OneofWrappers = []any{
+	file_bff_api_proto_order_v1_order_proto_msgTypes[12].OneofWrappers = []any{
+		(*CreateOrderRequest_AddressId)(nil),
+		(*CreateOrderRequest_Address)(nil),
+	}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[13].OneofWrappers = []any{
 		(*CreateOrderResponse_OrderId)(nil),
 		(*CreateOrderResponse_Error)(nil),
 	}
-	file_bff_api_proto_order_v1_order_proto_msgTypes[6].OneofWrappers = []any{
+	file_bff_api_proto_order_v1_order_proto_msgTypes[14].OneofWrappers = []any{}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[15].OneofWrappers = []any{
 		(*CancelOrderResponse_Success)(nil),
 		(*CancelOrderResponse_Error)(nil),
 	}
-	file_bff_api_proto_order_v1_order_proto_msgTypes[7].OneofWrappers = []any{}
-	file_bff_api_proto_order_v1_order_proto_msgTypes[8].OneofWrappers = []any{
+	file_bff_api_proto_order_v1_order_proto_msgTypes[16].OneofWrappers = []any{}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[17].OneofWrappers = []any{
 		(*UpdateOrderResponse_Success)(nil),
 		(*UpdateOrderResponse_Error)(nil),
 	}
-	file_bff_api_proto_order_v1_order_proto_msgTypes[10].OneofWrappers = []any{
+	file_bff_api_proto_order_v1_order_proto_msgTypes[18].OneofWrappers = []any{}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[19].OneofWrappers = []any{
+		(*UpdateOrderItemsResponse_Order)(nil),
+		(*UpdateOrderItemsResponse_Error)(nil),
+	}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[21].OneofWrappers = []any{
 		(*GetOrderResponse_Order)(nil),
 		(*GetOrderResponse_Error)(nil),
 	}
-	file_bff_api_proto_order_v1_order_proto_msgTypes[11].OneofWrappers = []any{}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[22].OneofWrappers = []any{}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[24].OneofWrappers = []any{}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[29].OneofWrappers = []any{
+		(*QuoteOrderResponse_Quote)(nil),
+		(*QuoteOrderResponse_Error)(nil),
+	}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[31].OneofWrappers = []any{
+		(*CreatePromotionResponse_Promotion)(nil),
+		(*CreatePromotionResponse_Error)(nil),
+	}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[33].OneofWrappers = []any{
+		(*UpdatePromotionResponse_Promotion)(nil),
+		(*UpdatePromotionResponse_Error)(nil),
+	}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[37].OneofWrappers = []any{
+		(*DeactivatePromotionResponse_Success)(nil),
+		(*DeactivatePromotionResponse_Error)(nil),
+	}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[39].OneofWrappers = []any{
+		(*PayOrderResponse_Payment)(nil),
+		(*PayOrderResponse_Error)(nil),
+	}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[43].OneofWrappers = []any{
+		(*CapturePaymentResponse_Payment)(nil),
+		(*CapturePaymentResponse_Error)(nil),
+	}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[45].OneofWrappers = []any{
+		(*RefundPaymentResponse_Payment)(nil),
+		(*RefundPaymentResponse_Error)(nil),
+	}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[49].OneofWrappers = []any{
+		(*RetryStockCompensationResponse_Compensation)(nil),
+		(*RetryStockCompensationResponse_Error)(nil),
+	}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[51].OneofWrappers = []any{
+		(*ResolveStockCompensationResponse_Compensation)(nil),
+		(*ResolveStockCompensationResponse_Error)(nil),
+	}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[53].OneofWrappers = []any{
+		(*CreateReturnResponse_OrderReturn)(nil),
+		(*CreateReturnResponse_Error)(nil),
+	}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[55].OneofWrappers = []any{
+		(*GetReturnResponse_OrderReturn)(nil),
+		(*GetReturnResponse_Error)(nil),
+	}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[56].OneofWrappers = []any{}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[59].OneofWrappers = []any{
+		(*ApproveReturnResponse_OrderReturn)(nil),
+		(*ApproveReturnResponse_Error)(nil),
+	}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[61].OneofWrappers = []any{
+		(*RejectReturnResponse_OrderReturn)(nil),
+		(*RejectReturnResponse_Error)(nil),
+	}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[63].OneofWrappers = []any{
+		(*ReceiveReturnResponse_OrderReturn)(nil),
+		(*ReceiveReturnResponse_Error)(nil),
+	}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[65].OneofWrappers = []any{
+		(*RefundReturnResponse_OrderReturn)(nil),
+		(*RefundReturnResponse_Error)(nil),
+	}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[68].OneofWrappers = []any{
+		(*GetInvoiceResponse_Document)(nil),
+		(*GetInvoiceResponse_Error)(nil),
+	}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[71].OneofWrappers = []any{
+		(*ReorderRequest_AddressId)(nil),
+		(*ReorderRequest_Address)(nil),
+	}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[72].OneofWrappers = []any{
+		(*ReorderResponse_Reorder)(nil),
+		(*ReorderResponse_Error)(nil),
+	}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[75].OneofWrappers = []any{
+		(*CreateOrderTemplateResponse_Template)(nil),
+		(*CreateOrderTemplateResponse_Error)(nil),
+	}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[79].OneofWrappers = []any{
+		(*DeleteOrderTemplateResponse_Success)(nil),
+		(*DeleteOrderTemplateResponse_Error)(nil),
+	}
+	file_bff_api_proto_order_v1_order_proto_msgTypes[81].OneofWrappers = []any{
+		(*SubmitOrderTemplateResponse_Reorder)(nil),
+		(*SubmitOrderTemplateResponse_Error)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_bff_api_proto_order_v1_order_proto_rawDesc), len(file_bff_api_proto_order_v1_order_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   16,
+			NumMessages:   83,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
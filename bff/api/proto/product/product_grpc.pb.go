@@ -19,10 +19,14 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ProductService_GetProduct_FullMethodName  = "/product.ProductService/GetProduct"
-	ProductService_GetProducts_FullMethodName = "/product.ProductService/GetProducts"
-	ProductService_CheckStock_FullMethodName  = "/product.ProductService/CheckStock"
-	ProductService_UpdateStock_FullMethodName = "/product.ProductService/UpdateStock"
+	ProductService_GetProduct_FullMethodName       = "/product.ProductService/GetProduct"
+	ProductService_GetProducts_FullMethodName      = "/product.ProductService/GetProducts"
+	ProductService_ListProducts_FullMethodName     = "/product.ProductService/ListProducts"
+	ProductService_SearchProducts_FullMethodName   = "/product.ProductService/SearchProducts"
+	ProductService_CheckStock_FullMethodName       = "/product.ProductService/CheckStock"
+	ProductService_UpdateStock_FullMethodName      = "/product.ProductService/UpdateStock"
+	ProductService_BatchUpdateStock_FullMethodName = "/product.ProductService/BatchUpdateStock"
+	ProductService_PurgeProduct_FullMethodName     = "/product.ProductService/PurgeProduct"
 )
 
 // ProductServiceClient is the client API for ProductService service.
@@ -30,9 +34,19 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ProductServiceClient interface {
 	GetProduct(ctx context.Context, in *GetProductRequest, opts ...grpc.CallOption) (*ProductResponse, error)
+	// Products are returned in request order, duplicates collapsed; at most 500 distinct ids per call.
 	GetProducts(ctx context.Context, in *GetProductsRequest, opts ...grpc.CallOption) (*ProductsResponse, error)
+	// Page of the catalog with filters and sorting; cursor takes precedence over page.
+	ListProducts(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error)
+	// Full-text search over name and description, most relevant first.
+	SearchProducts(ctx context.Context, in *SearchProductsRequest, opts ...grpc.CallOption) (*SearchProductsResponse, error)
 	CheckStock(ctx context.Context, in *CheckStockRequest, opts ...grpc.CallOption) (*CheckStockResponse, error)
 	UpdateStock(ctx context.Context, in *UpdateStockRequest, opts ...grpc.CallOption) (*UpdateStockResponse, error)
+	// Applies all items in one transaction or none of them; at most 500 items, variants must be unique.
+	BatchUpdateStock(ctx context.Context, in *BatchUpdateStockRequest, opts ...grpc.CallOption) (*BatchUpdateStockResponse, error)
+	// Permanently deletes an archived product with its variants, stock ledger, price history and images.
+	// Admins only; the caller must make sure no order references the product.
+	PurgeProduct(ctx context.Context, in *PurgeProductRequest, opts ...grpc.CallOption) (*PurgeProductResponse, error)
 }
 
 type productServiceClient struct {
@@ -63,6 +77,26 @@ func (c *productServiceClient) GetProducts(ctx context.Context, in *GetProductsR
 	return out, nil
 }
 
+func (c *productServiceClient) ListProducts(ctx context.Context, in *ListProductsRequest, opts ...grpc.CallOption) (*ListProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListProductsResponse)
+	err := c.cc.Invoke(ctx, ProductService_ListProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) SearchProducts(ctx context.Context, in *SearchProductsRequest, opts ...grpc.CallOption) (*SearchProductsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchProductsResponse)
+	err := c.cc.Invoke(ctx, ProductService_SearchProducts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *productServiceClient) CheckStock(ctx context.Context, in *CheckStockRequest, opts ...grpc.CallOption) (*CheckStockResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CheckStockResponse)
@@ -83,14 +117,44 @@ func (c *productServiceClient) UpdateStock(ctx context.Context, in *UpdateStockR
 	return out, nil
 }
 
+func (c *productServiceClient) BatchUpdateStock(ctx context.Context, in *BatchUpdateStockRequest, opts ...grpc.CallOption) (*BatchUpdateStockResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchUpdateStockResponse)
+	err := c.cc.Invoke(ctx, ProductService_BatchUpdateStock_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *productServiceClient) PurgeProduct(ctx context.Context, in *PurgeProductRequest, opts ...grpc.CallOption) (*PurgeProductResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PurgeProductResponse)
+	err := c.cc.Invoke(ctx, ProductService_PurgeProduct_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProductServiceServer is the server API for ProductService service.
 // All implementations must embed UnimplementedProductServiceServer
 // for forward compatibility.
 type ProductServiceServer interface {
 	GetProduct(context.Context, *GetProductRequest) (*ProductResponse, error)
+	// Products are returned in request order, duplicates collapsed; at most 500 distinct ids per call.
 	GetProducts(context.Context, *GetProductsRequest) (*ProductsResponse, error)
+	// Page of the catalog with filters and sorting; cursor takes precedence over page.
+	ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error)
+	// Full-text search over name and description, most relevant first.
+	SearchProducts(context.Context, *SearchProductsRequest) (*SearchProductsResponse, error)
 	CheckStock(context.Context, *CheckStockRequest) (*CheckStockResponse, error)
 	UpdateStock(context.Context, *UpdateStockRequest) (*UpdateStockResponse, error)
+	// Applies all items in one transaction or none of them; at most 500 items, variants must be unique.
+	BatchUpdateStock(context.Context, *BatchUpdateStockRequest) (*BatchUpdateStockResponse, error)
+	// Permanently deletes an archived product with its variants, stock ledger, price history and images.
+	// Admins only; the caller must make sure no order references the product.
+	PurgeProduct(context.Context, *PurgeProductRequest) (*PurgeProductResponse, error)
 	mustEmbedUnimplementedProductServiceServer()
 }
 
@@ -107,12 +171,24 @@ func (UnimplementedProductServiceServer) GetProduct(context.Context, *GetProduct
 func (UnimplementedProductServiceServer) GetProducts(context.Context, *GetProductsRequest) (*ProductsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetProducts not implemented")
 }
+func (UnimplementedProductServiceServer) ListProducts(context.Context, *ListProductsRequest) (*ListProductsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListProducts not implemented")
+}
+func (UnimplementedProductServiceServer) SearchProducts(context.Context, *SearchProductsRequest) (*SearchProductsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchProducts not implemented")
+}
 func (UnimplementedProductServiceServer) CheckStock(context.Context, *CheckStockRequest) (*CheckStockResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method CheckStock not implemented")
 }
 func (UnimplementedProductServiceServer) UpdateStock(context.Context, *UpdateStockRequest) (*UpdateStockResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateStock not implemented")
 }
+func (UnimplementedProductServiceServer) BatchUpdateStock(context.Context, *BatchUpdateStockRequest) (*BatchUpdateStockResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BatchUpdateStock not implemented")
+}
+func (UnimplementedProductServiceServer) PurgeProduct(context.Context, *PurgeProductRequest) (*PurgeProductResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PurgeProduct not implemented")
+}
 func (UnimplementedProductServiceServer) mustEmbedUnimplementedProductServiceServer() {}
 func (UnimplementedProductServiceServer) testEmbeddedByValue()                        {}
 
@@ -170,6 +246,42 @@ func _ProductService_GetProducts_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProductService_ListProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).ListProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_ListProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).ListProducts(ctx, req.(*ListProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_SearchProducts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchProductsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).SearchProducts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_SearchProducts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).SearchProducts(ctx, req.(*SearchProductsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProductService_CheckStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CheckStockRequest)
 	if err := dec(in); err != nil {
@@ -206,6 +318,42 @@ func _ProductService_UpdateStock_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProductService_BatchUpdateStock_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchUpdateStockRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).BatchUpdateStock(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_BatchUpdateStock_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).BatchUpdateStock(ctx, req.(*BatchUpdateStockRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ProductService_PurgeProduct_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PurgeProductRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProductServiceServer).PurgeProduct(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProductService_PurgeProduct_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProductServiceServer).PurgeProduct(ctx, req.(*PurgeProductRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProductService_ServiceDesc is the grpc.ServiceDesc for ProductService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -221,6 +369,14 @@ var ProductService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProducts",
 			Handler:    _ProductService_GetProducts_Handler,
 		},
+		{
+			MethodName: "ListProducts",
+			Handler:    _ProductService_ListProducts_Handler,
+		},
+		{
+			MethodName: "SearchProducts",
+			Handler:    _ProductService_SearchProducts_Handler,
+		},
 		{
 			MethodName: "CheckStock",
 			Handler:    _ProductService_CheckStock_Handler,
@@ -229,6 +385,14 @@ var ProductService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateStock",
 			Handler:    _ProductService_UpdateStock_Handler,
 		},
+		{
+			MethodName: "BatchUpdateStock",
+			Handler:    _ProductService_BatchUpdateStock_Handler,
+		},
+		{
+			MethodName: "PurgeProduct",
+			Handler:    _ProductService_PurgeProduct_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "bff/api/proto/product/product.proto",
@@ -11,4 +11,8 @@ var (
 	ErrServiceUnavailable = errors.New("service unavailable")
 	ErrInternal           = errors.New("internal server error")
 	ErrTimeout            = errors.New("request timeout")
+	// ErrConflict — ресурс изменился после чтения (устаревшая версия)
+	ErrConflict = errors.New("resource was modified")
+	// ErrStateConflict — операция недопустима в текущем состоянии ресурса
+	ErrStateConflict = errors.New("resource is in a conflicting state")
 )
@@ -22,6 +22,8 @@ func MapStatusToError(statusCode int, body string) error {
 		return fmt.Errorf("%w: %s", apperr.ErrNotFound, body)
 	case http.StatusConflict:
 		return fmt.Errorf("%w: %s", apperr.ErrAlreadyExists, body)
+	case http.StatusPreconditionFailed:
+		return fmt.Errorf("%w: %s", apperr.ErrConflict, body)
 	case http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusGatewayTimeout:
 		return fmt.Errorf("%w: %s", apperr.ErrServiceUnavailable, body)
 	case http.StatusRequestTimeout:
@@ -55,6 +57,8 @@ func MapGRPCError(err error) error {
 		return fmt.Errorf("%w: %s", apperr.ErrForbidden, st.Message())
 	case codes.AlreadyExists:
 		return fmt.Errorf("%w: %s", apperr.ErrAlreadyExists, st.Message())
+	case codes.Aborted:
+		return fmt.Errorf("%w: %s", apperr.ErrConflict, st.Message())
 	case codes.Unavailable:
 		return fmt.Errorf("%w: %s", apperr.ErrServiceUnavailable, st.Message())
 	case codes.DeadlineExceeded:
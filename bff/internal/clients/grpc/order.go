@@ -31,12 +31,8 @@ func (c *orderClient) CreateOrder(ctx context.Context, req *orderv1.CreateOrderR
 	return resp, clients.MapGRPCError(err)
 }
 
-func (c *orderClient) CancelOrder(ctx context.Context, orderID, userID int64, reason string, opts ...grpc.CallOption) (*orderv1.CancelOrderResponse, error) {
-	resp, err := c.api.CancelOrder(ctx, &orderv1.CancelOrderRequest{
-		OrderId: orderID,
-		UserId:  userID,
-		Reason:  reason,
-	}, opts...)
+func (c *orderClient) CancelOrder(ctx context.Context, req *orderv1.CancelOrderRequest, opts ...grpc.CallOption) (*orderv1.CancelOrderResponse, error) {
+	resp, err := c.api.CancelOrder(ctx, req, opts...)
 	return resp, clients.MapGRPCError(err)
 }
 
@@ -45,6 +41,22 @@ func (c *orderClient) UpdateOrder(ctx context.Context, req *orderv1.UpdateOrderR
 	return resp, clients.MapGRPCError(err)
 }
 
+func (c *orderClient) UpdateOrderItems(ctx context.Context, req *orderv1.UpdateOrderItemsRequest, opts ...grpc.CallOption) (*orderv1.UpdateOrderItemsResponse, error) {
+	resp, err := c.api.UpdateOrderItems(ctx, req, opts...)
+	return resp, clients.MapGRPCError(err)
+}
+
+func (c *orderClient) QuoteOrder(ctx context.Context, req *orderv1.QuoteOrderRequest, opts ...grpc.CallOption) (*orderv1.QuoteOrderResponse, error) {
+	resp, err := c.api.QuoteOrder(ctx, req, opts...)
+	return resp, clients.MapGRPCError(err)
+}
+
+// PayOrder не повторяется автоматически: повтор мог бы создать второй платеж
+func (c *orderClient) PayOrder(ctx context.Context, req *orderv1.PayOrderRequest, opts ...grpc.CallOption) (*orderv1.PayOrderResponse, error) {
+	resp, err := c.api.PayOrder(ctx, req, opts...)
+	return resp, clients.MapGRPCError(err)
+}
+
 func (c *orderClient) GetOrder(ctx context.Context, orderID int64, opts ...grpc.CallOption) (*orderv1.GetOrderResponse, error) {
 	var resp *orderv1.GetOrderResponse
 	err := retry.Do(
@@ -87,3 +99,72 @@ func (c *orderClient) GetOrderStats(ctx context.Context, userID int64, opts ...g
 	return resp, clients.MapGRPCError(err)
 }
 
+func (c *orderClient) SearchOrders(ctx context.Context, req *orderv1.SearchOrdersRequest, opts ...grpc.CallOption) (*orderv1.SearchOrdersResponse, error) {
+	resp, err := c.api.SearchOrders(ctx, req, opts...)
+	return resp, clients.MapGRPCError(err)
+}
+
+func (c *orderClient) CreateReturn(ctx context.Context, req *orderv1.CreateReturnRequest, opts ...grpc.CallOption) (*orderv1.CreateReturnResponse, error) {
+	resp, err := c.api.CreateReturn(ctx, req, opts...)
+	return resp, clients.MapGRPCError(err)
+}
+
+func (c *orderClient) GetReturn(ctx context.Context, returnID int64, opts ...grpc.CallOption) (*orderv1.GetReturnResponse, error) {
+	resp, err := c.api.GetReturn(ctx, &orderv1.GetReturnRequest{ReturnId: returnID}, opts...)
+	return resp, clients.MapGRPCError(err)
+}
+
+func (c *orderClient) ListReturns(ctx context.Context, req *orderv1.ListReturnsRequest, opts ...grpc.CallOption) (*orderv1.ListReturnsResponse, error) {
+	resp, err := c.api.ListReturns(ctx, req, opts...)
+	return resp, clients.MapGRPCError(err)
+}
+
+func (c *orderClient) ApproveReturn(ctx context.Context, returnID int64, opts ...grpc.CallOption) (*orderv1.ApproveReturnResponse, error) {
+	resp, err := c.api.ApproveReturn(ctx, &orderv1.ApproveReturnRequest{ReturnId: returnID}, opts...)
+	return resp, clients.MapGRPCError(err)
+}
+
+func (c *orderClient) RejectReturn(ctx context.Context, req *orderv1.RejectReturnRequest, opts ...grpc.CallOption) (*orderv1.RejectReturnResponse, error) {
+	resp, err := c.api.RejectReturn(ctx, req, opts...)
+	return resp, clients.MapGRPCError(err)
+}
+
+func (c *orderClient) ReceiveReturn(ctx context.Context, returnID int64, opts ...grpc.CallOption) (*orderv1.ReceiveReturnResponse, error) {
+	resp, err := c.api.ReceiveReturn(ctx, &orderv1.ReceiveReturnRequest{ReturnId: returnID}, opts...)
+	return resp, clients.MapGRPCError(err)
+}
+
+func (c *orderClient) RefundReturn(ctx context.Context, returnID int64, opts ...grpc.CallOption) (*orderv1.RefundReturnResponse, error) {
+	resp, err := c.api.RefundReturn(ctx, &orderv1.RefundReturnRequest{ReturnId: returnID}, opts...)
+	return resp, clients.MapGRPCError(err)
+}
+
+func (c *orderClient) GetInvoice(ctx context.Context, orderID int64, format string, opts ...grpc.CallOption) (*orderv1.GetInvoiceResponse, error) {
+	resp, err := c.api.GetInvoice(ctx, &orderv1.GetInvoiceRequest{OrderId: orderID, Format: format}, opts...)
+	return resp, clients.MapGRPCError(err)
+}
+
+func (c *orderClient) Reorder(ctx context.Context, req *orderv1.ReorderRequest, opts ...grpc.CallOption) (*orderv1.ReorderResponse, error) {
+	resp, err := c.api.Reorder(ctx, req, opts...)
+	return resp, clients.MapGRPCError(err)
+}
+
+func (c *orderClient) CreateOrderTemplate(ctx context.Context, req *orderv1.CreateOrderTemplateRequest, opts ...grpc.CallOption) (*orderv1.CreateOrderTemplateResponse, error) {
+	resp, err := c.api.CreateOrderTemplate(ctx, req, opts...)
+	return resp, clients.MapGRPCError(err)
+}
+
+func (c *orderClient) ListOrderTemplates(ctx context.Context, opts ...grpc.CallOption) (*orderv1.ListOrderTemplatesResponse, error) {
+	resp, err := c.api.ListOrderTemplates(ctx, &orderv1.ListOrderTemplatesRequest{}, opts...)
+	return resp, clients.MapGRPCError(err)
+}
+
+func (c *orderClient) DeleteOrderTemplate(ctx context.Context, templateID int64, opts ...grpc.CallOption) (*orderv1.DeleteOrderTemplateResponse, error) {
+	resp, err := c.api.DeleteOrderTemplate(ctx, &orderv1.DeleteOrderTemplateRequest{TemplateId: templateID}, opts...)
+	return resp, clients.MapGRPCError(err)
+}
+
+func (c *orderClient) SubmitOrderTemplate(ctx context.Context, req *orderv1.SubmitOrderTemplateRequest, opts ...grpc.CallOption) (*orderv1.SubmitOrderTemplateResponse, error) {
+	resp, err := c.api.SubmitOrderTemplate(ctx, req, opts...)
+	return resp, clients.MapGRPCError(err)
+}
@@ -43,4 +43,14 @@ func (c *productClient) UpdateStock(ctx context.Context, productID int64, delta
 		QuantityDelta: delta,
 	}, opts...)
 	return resp, clients.MapGRPCError(err)
-}
\ No newline at end of file
+}
+
+func (c *productClient) SearchProducts(ctx context.Context, req *productv1.SearchProductsRequest, opts ...grpc.CallOption) (*productv1.SearchProductsResponse, error) {
+	resp, err := c.api.SearchProducts(ctx, req, opts...)
+	return resp, clients.MapGRPCError(err)
+}
+
+func (c *productClient) PurgeProduct(ctx context.Context, id int64, opts ...grpc.CallOption) error {
+	_, err := c.api.PurgeProduct(ctx, &productv1.PurgeProductRequest{Id: id}, opts...)
+	return clients.MapGRPCError(err)
+}
@@ -10,9 +10,26 @@ import (
 
 type OrderClient interface {
 	CreateOrder(ctx context.Context, req *orderv1.CreateOrderRequest, opts ...grpc.CallOption) (*orderv1.CreateOrderResponse, error)
-	CancelOrder(ctx context.Context, orderID, userID int64, reason string, opts ...grpc.CallOption) (*orderv1.CancelOrderResponse, error)
+	CancelOrder(ctx context.Context, req *orderv1.CancelOrderRequest, opts ...grpc.CallOption) (*orderv1.CancelOrderResponse, error)
 	UpdateOrder(ctx context.Context, req *orderv1.UpdateOrderRequest, opts ...grpc.CallOption) (*orderv1.UpdateOrderResponse, error)
+	UpdateOrderItems(ctx context.Context, req *orderv1.UpdateOrderItemsRequest, opts ...grpc.CallOption) (*orderv1.UpdateOrderItemsResponse, error)
 	GetOrder(ctx context.Context, orderID int64, opts ...grpc.CallOption) (*orderv1.GetOrderResponse, error)
 	GetUserOrders(ctx context.Context, req *orderv1.GetUserOrdersRequest, opts ...grpc.CallOption) (*orderv1.GetUserOrdersResponse, error)
 	GetOrderStats(ctx context.Context, userID int64, opts ...grpc.CallOption) (*orderv1.GetOrderStatsResponse, error)
+	SearchOrders(ctx context.Context, req *orderv1.SearchOrdersRequest, opts ...grpc.CallOption) (*orderv1.SearchOrdersResponse, error)
+	QuoteOrder(ctx context.Context, req *orderv1.QuoteOrderRequest, opts ...grpc.CallOption) (*orderv1.QuoteOrderResponse, error)
+	PayOrder(ctx context.Context, req *orderv1.PayOrderRequest, opts ...grpc.CallOption) (*orderv1.PayOrderResponse, error)
+	CreateReturn(ctx context.Context, req *orderv1.CreateReturnRequest, opts ...grpc.CallOption) (*orderv1.CreateReturnResponse, error)
+	GetReturn(ctx context.Context, returnID int64, opts ...grpc.CallOption) (*orderv1.GetReturnResponse, error)
+	ListReturns(ctx context.Context, req *orderv1.ListReturnsRequest, opts ...grpc.CallOption) (*orderv1.ListReturnsResponse, error)
+	ApproveReturn(ctx context.Context, returnID int64, opts ...grpc.CallOption) (*orderv1.ApproveReturnResponse, error)
+	RejectReturn(ctx context.Context, req *orderv1.RejectReturnRequest, opts ...grpc.CallOption) (*orderv1.RejectReturnResponse, error)
+	ReceiveReturn(ctx context.Context, returnID int64, opts ...grpc.CallOption) (*orderv1.ReceiveReturnResponse, error)
+	RefundReturn(ctx context.Context, returnID int64, opts ...grpc.CallOption) (*orderv1.RefundReturnResponse, error)
+	GetInvoice(ctx context.Context, orderID int64, format string, opts ...grpc.CallOption) (*orderv1.GetInvoiceResponse, error)
+	Reorder(ctx context.Context, req *orderv1.ReorderRequest, opts ...grpc.CallOption) (*orderv1.ReorderResponse, error)
+	CreateOrderTemplate(ctx context.Context, req *orderv1.CreateOrderTemplateRequest, opts ...grpc.CallOption) (*orderv1.CreateOrderTemplateResponse, error)
+	ListOrderTemplates(ctx context.Context, opts ...grpc.CallOption) (*orderv1.ListOrderTemplatesResponse, error)
+	DeleteOrderTemplate(ctx context.Context, templateID int64, opts ...grpc.CallOption) (*orderv1.DeleteOrderTemplateResponse, error)
+	SubmitOrderTemplate(ctx context.Context, req *orderv1.SubmitOrderTemplateRequest, opts ...grpc.CallOption) (*orderv1.SubmitOrderTemplateResponse, error)
 }
@@ -13,4 +13,7 @@ type ProductClient interface {
 	GetProducts(ctx context.Context, ids []int64, opts ...grpc.CallOption) (*productv1.ProductsResponse, error)
 	CheckStock(ctx context.Context, productID int64, quantity int32, opts ...grpc.CallOption) (*productv1.CheckStockResponse, error)
 	UpdateStock(ctx context.Context, productID int64, delta int32, opts ...grpc.CallOption) (*productv1.UpdateStockResponse, error)
+	SearchProducts(ctx context.Context, req *productv1.SearchProductsRequest, opts ...grpc.CallOption) (*productv1.SearchProductsResponse, error)
+	// PurgeProduct окончательно удаляет архивный продукт; доступно только администратору
+	PurgeProduct(ctx context.Context, id int64, opts ...grpc.CallOption) error
 }
@@ -4,8 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/avast/retry-go/v4"
@@ -16,11 +19,62 @@ type ProductHTTPResponse struct {
 	Name        string  `json:"name"`
 	Description string  `json:"description"`
 	Price       float64 `json:"price"`
+	PriceMinor  int64   `json:"price_minor"`
+	Currency    string  `json:"currency"`
 	Stock       int32   `json:"stock"`
+	Version     int64   `json:"version"`
+	// Хлебные крошки каждой категории продукта, от корня дерева
+	Categories [][]CategoryHTTPRef   `json:"categories"`
+	Variants   []VariantHTTPResponse `json:"variants"`
+	Images     []ImageHTTPResponse   `json:"images"`
+	// Цена до идущей распродажи, если она выше текущей
+	CompareAtPriceMinor *int64 `json:"compare_at_price_minor"`
+}
+
+// ImageHTTPResponse — изображение продукта с адресами миниатюр по имени размера
+type ImageHTTPResponse struct {
+	ID         int64             `json:"id"`
+	URL        string            `json:"url"`
+	Width      int32             `json:"width"`
+	Height     int32             `json:"height"`
+	AltText    string            `json:"alt_text"`
+	Thumbnails map[string]string `json:"thumbnails"`
+}
+
+// VariantHTTPResponse — вариант продукта; price_minor — итоговая цена варианта
+type VariantHTTPResponse struct {
+	ID         int64             `json:"id"`
+	SKU        string            `json:"sku"`
+	Attributes map[string]string `json:"attributes"`
+	PriceMinor int64             `json:"price_minor"`
+	Stock      int32             `json:"stock"`
+}
+
+type CategoryHTTPRef struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// CategoryHTTPNode — узел дерева категорий product-service
+type CategoryHTTPNode struct {
+	ID       int64              `json:"id"`
+	Name     string             `json:"name"`
+	ParentID *int64             `json:"parent_id"`
+	Children []CategoryHTTPNode `json:"children"`
+}
+
+// ProductHTTPList — страница каталога с метаданными из заголовков ответа
+type ProductHTTPList struct {
+	Products   []ProductHTTPResponse
+	TotalCount int64
+	NextCursor string
 }
 
 type ProductHTTPClient interface {
-	ListProducts(ctx context.Context) ([]ProductHTTPResponse, error)
+	// ListProducts передаёт query (страница, фильтры, сортировка) в GET /api/products как есть
+	ListProducts(ctx context.Context, query url.Values) (*ProductHTTPList, error)
+	// ListCategories отдает дерево категорий из GET /api/categories
+	ListCategories(ctx context.Context) ([]CategoryHTTPNode, error)
 }
 
 type httpProductClient struct {
@@ -41,12 +95,19 @@ func NewHTTPProductClient(baseURL string, retryAttempts uint, retryDelay time.Du
 	}
 }
 
-func (c *httpProductClient) ListProducts(ctx context.Context) ([]ProductHTTPResponse, error) {
-	var products []ProductHTTPResponse
+func (c *httpProductClient) ListProducts(ctx context.Context, query url.Values) (*ProductHTTPList, error) {
+	list := &ProductHTTPList{}
+	target := c.baseURL + "/api/products"
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	// Ошибки запроса (4xx) не скрываются за пустым списком — клиент должен увидеть, что не так с параметрами
+	var requestErr error
 
 	err := retry.Do(
 		func() error {
-			req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/api/products", nil)
+			req, err := http.NewRequestWithContext(ctx, "GET", target, nil)
 			if err != nil {
 				return retry.Unrecoverable(fmt.Errorf("failed to create request: %w", err))
 			}
@@ -62,12 +123,16 @@ func (c *httpProductClient) ListProducts(ctx context.Context) ([]ProductHTTPResp
 				if resp.StatusCode >= 500 {
 					return fmt.Errorf("server error: %d", resp.StatusCode)
 				}
-				return retry.Unrecoverable(MapStatusToError(resp.StatusCode, "failed to list products"))
+				body, _ := io.ReadAll(resp.Body)
+				requestErr = MapStatusToError(resp.StatusCode, string(body))
+				return retry.Unrecoverable(requestErr)
 			}
 
-			if err := json.NewDecoder(resp.Body).Decode(&products); err != nil {
+			if err := json.NewDecoder(resp.Body).Decode(&list.Products); err != nil {
 				return retry.Unrecoverable(fmt.Errorf("failed to decode response: %w", err))
 			}
+			list.TotalCount, _ = strconv.ParseInt(resp.Header.Get("X-Total-Count"), 10, 64)
+			list.NextCursor = resp.Header.Get("X-Next-Cursor")
 
 			return nil
 		},
@@ -80,11 +145,59 @@ func (c *httpProductClient) ListProducts(ctx context.Context) ([]ProductHTTPResp
 		}),
 	)
 
+	if requestErr != nil {
+		return nil, requestErr
+	}
 	if err != nil {
 		// FALLBACK: Return empty list instead of error
 		slog.Error("All retries failed for ListProducts. Falling back to empty list", "error", err)
-		return []ProductHTTPResponse{}, nil
+		return &ProductHTTPList{Products: []ProductHTTPResponse{}}, nil
+	}
+
+	return list, nil
+}
+
+func (c *httpProductClient) ListCategories(ctx context.Context) ([]CategoryHTTPNode, error) {
+	var tree []CategoryHTTPNode
+
+	err := retry.Do(
+		func() error {
+			req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/api/categories", nil)
+			if err != nil {
+				return retry.Unrecoverable(fmt.Errorf("failed to create request: %w", err))
+			}
+
+			resp, err := c.httpClient.Do(req)
+			if err != nil {
+				return err
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != http.StatusOK {
+				if resp.StatusCode >= 500 {
+					return fmt.Errorf("server error: %d", resp.StatusCode)
+				}
+				body, _ := io.ReadAll(resp.Body)
+				return retry.Unrecoverable(MapStatusToError(resp.StatusCode, string(body)))
+			}
+
+			if err := json.NewDecoder(resp.Body).Decode(&tree); err != nil {
+				return retry.Unrecoverable(fmt.Errorf("failed to decode response: %w", err))
+			}
+			return nil
+		},
+		retry.Context(ctx),
+		retry.Attempts(c.retryAttempts),
+		retry.Delay(c.retryDelay),
+		retry.DelayType(retry.BackOffDelay),
+		retry.LastErrorOnly(true),
+		retry.OnRetry(func(n uint, err error) {
+			slog.Warn("Retrying request", "attempt", n+1, "error", err)
+		}),
+	)
+	if err != nil {
+		return nil, err
 	}
 
-	return products, nil
+	return tree, nil
 }
@@ -2,10 +2,14 @@ package clients
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
+
+	"github.com/microserviceteam0/bff-gateway/bff/internal/apperr"
 )
 
 func TestListProducts_RetryAndFallback(t *testing.T) {
@@ -24,12 +28,12 @@ func TestListProducts_RetryAndFallback(t *testing.T) {
 		defer server.Close()
 
 		client := NewHTTPProductClient(server.URL, 3, 10*time.Millisecond, 5*time.Second)
-		products, err := client.ListProducts(context.Background())
+		products, err := client.ListProducts(context.Background(), nil)
 		if err != nil {
 			t.Fatalf("expected success, got error: %v", err)
 		}
-		if len(products) != 1 {
-			t.Errorf("expected 1 product, got %d", len(products))
+		if len(products.Products) != 1 {
+			t.Errorf("expected 1 product, got %d", len(products.Products))
 		}
 		if attempts != 2 {
 			t.Errorf("expected 2 attempts, got %d", attempts)
@@ -46,15 +50,15 @@ func TestListProducts_RetryAndFallback(t *testing.T) {
 		defer server.Close()
 
 		client := NewHTTPProductClient(server.URL, 3, 10*time.Millisecond, 5*time.Second)
-		products, err := client.ListProducts(context.Background())
+		products, err := client.ListProducts(context.Background(), nil)
 
 		// We expect nil error because of fallback
 		if err != nil {
 			t.Fatalf("expected nil error (fallback), got: %v", err)
 		}
 		// We expect empty list
-		if len(products) != 0 {
-			t.Errorf("expected empty list, got %d items", len(products))
+		if len(products.Products) != 0 {
+			t.Errorf("expected empty list, got %d items", len(products.Products))
 		}
 		// Should retry 3 times (initial + 2 retries or whatever retry-go defaults/config is, we set 3 attempts total)
 		// We set retry.Attempts(3)
@@ -62,4 +66,49 @@ func TestListProducts_RetryAndFallback(t *testing.T) {
 			t.Errorf("expected 3 attempts, got %d", attempts)
 		}
 	})
+
+	// 3. Invalid query is reported, not hidden behind the fallback
+	t.Run("Bad request is not retried", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if r.URL.Query().Get("sort") != "rating" {
+				t.Errorf("expected query to be forwarded, got %s", r.URL.RawQuery)
+			}
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"unsupported sort field"}`))
+		}))
+		defer server.Close()
+
+		client := NewHTTPProductClient(server.URL, 3, 10*time.Millisecond, 5*time.Second)
+		_, err := client.ListProducts(context.Background(), url.Values{"sort": {"rating"}})
+		if !errors.Is(err, apperr.ErrInvalidInput) {
+			t.Fatalf("expected ErrInvalidInput, got: %v", err)
+		}
+		if attempts != 1 {
+			t.Errorf("expected 1 attempt, got %d", attempts)
+		}
+	})
+
+	// 4. Paging headers are passed through
+	t.Run("Paging headers", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Total-Count", "120")
+			w.Header().Set("X-Next-Cursor", "abc")
+			w.Write([]byte(`[{"id":1, "name":"Product A", "categories":[[{"id":1,"name":"Electronics"},{"id":4,"name":"Laptops"}]]}]`))
+		}))
+		defer server.Close()
+
+		client := NewHTTPProductClient(server.URL, 3, 10*time.Millisecond, 5*time.Second)
+		list, err := client.ListProducts(context.Background(), url.Values{"page_size": {"1"}})
+		if err != nil {
+			t.Fatalf("expected success, got error: %v", err)
+		}
+		if list.TotalCount != 120 || list.NextCursor != "abc" {
+			t.Errorf("expected total 120 and cursor abc, got %d and %q", list.TotalCount, list.NextCursor)
+		}
+		if len(list.Products[0].Categories) != 1 || list.Products[0].Categories[0][1].Name != "Laptops" {
+			t.Errorf("expected breadcrumbs Electronics > Laptops, got %v", list.Products[0].Categories)
+		}
+	})
 }
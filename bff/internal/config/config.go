@@ -28,6 +28,9 @@ type Config struct {
 	// Timeouts
 	HttpClientTimeout time.Duration
 	ShutdownTimeout   time.Duration
+
+	// InternalAuthSecret подписывает identity-токены для сервисов
+	InternalAuthSecret string
 }
 
 func Load() *Config {
@@ -49,6 +52,8 @@ func Load() *Config {
 
 		HttpClientTimeout: getEnvDuration("HTTP_CLIENT_TIMEOUT_MS", 5000) * time.Millisecond,
 		ShutdownTimeout:   getEnvDuration("SHUTDOWN_TIMEOUT_SECONDS", 5) * time.Second,
+
+		InternalAuthSecret: os.Getenv("INTERNAL_AUTH_SECRET"),
 	}
 }
 
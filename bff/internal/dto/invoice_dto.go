@@ -0,0 +1,9 @@
+package dto
+
+// InvoiceDocumentDTO — готовый документ счета для отдачи клиенту как есть
+type InvoiceDocumentDTO struct {
+	Number      string
+	ContentType string
+	Filename    string
+	Content     []byte
+}
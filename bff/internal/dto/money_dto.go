@@ -0,0 +1,18 @@
+package dto
+
+import "github.com/microserviceteam0/bff-gateway/shared/money"
+
+// MoneyDTO — точная денежная сумма: целое число минимальных единиц валюты и готовая строка для отображения
+type MoneyDTO struct {
+	AmountMinor int64  `json:"amount_minor" example:"8500000"`
+	Currency    string `json:"currency" example:"RUB"`
+	Formatted   string `json:"formatted" example:"85 000.00 ₽"`
+}
+
+func NewMoneyDTO(m money.Money) MoneyDTO {
+	return MoneyDTO{
+		AmountMinor: m.Amount,
+		Currency:    m.Currency,
+		Formatted:   m.Format(),
+	}
+}
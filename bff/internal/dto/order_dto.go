@@ -3,12 +3,25 @@ package dto
 import "time"
 
 type OrderResponseDTO struct {
-	ID        int64          `json:"id"`
-	User      UserSummaryDTO `json:"user"`
-	Items     []OrderItemDTO `json:"items"`
-	Status    string         `json:"status"`
-	TotalSum  float64        `json:"total_sum"`
-	CreatedAt time.Time      `json:"created_at"`
+	ID       int64          `json:"id"`
+	User     UserSummaryDTO `json:"user"`
+	Items    []OrderItemDTO `json:"items"`
+	Status   string         `json:"status"`
+	TotalSum float64        `json:"total_sum"`
+	Subtotal MoneyDTO       `json:"subtotal"`
+	Discount MoneyDTO       `json:"discount"`
+	Total    MoneyDTO       `json:"total"`
+	// Сумма, возвращенная покупателю по возвратам товаров
+	Refunded   MoneyDTO              `json:"refunded"`
+	Promotions []AppliedPromotionDTO `json:"promotions"`
+	// Адрес, зафиксированный в заказе при оформлении; пустой для самовывоза
+	ShippingAddress *ShippingAddressDTO `json:"shipping_address,omitempty"`
+	DeliveryMethod  string              `json:"delivery_method,omitempty"`
+	// Причина отмены, например "payment_failed"
+	CancellationReason string    `json:"cancellation_reason,omitempty"`
+	CreatedAt          time.Time `json:"created_at"`
+	// Версия заказа; также отдается в заголовке ETag
+	Version int64 `json:"version"`
 }
 
 type UserSummaryDTO struct {
@@ -18,22 +31,95 @@ type UserSummaryDTO struct {
 }
 
 type OrderItemDTO struct {
-	ProductID   int64   `json:"product_id"`
-	ProductName string  `json:"product_name"`
-	Quantity    int32   `json:"quantity"`
-	UnitPrice   float64 `json:"unit_price"`
+	ProductID   int64    `json:"product_id"`
+	VariantID   int64    `json:"variant_id,omitempty"`
+	SKU         string   `json:"sku,omitempty"`
+	ProductName string   `json:"product_name"`
+	Quantity    int32    `json:"quantity"`
+	UnitPrice   float64  `json:"unit_price"`
+	Price       MoneyDTO `json:"price"`
+	LineTotal   MoneyDTO `json:"line_total"`
+	Discount    MoneyDTO `json:"discount"`
 }
 
-// CreateOrderRequestDTO — запрос на создание заказа
+type AppliedPromotionDTO struct {
+	PromotionID int64    `json:"promotion_id"`
+	Code        string   `json:"code,omitempty"`
+	Name        string   `json:"name"`
+	Discount    MoneyDTO `json:"discount"`
+}
+
+// ShippingAddressDTO — адрес доставки; country — код ISO 3166-1 alpha-2
+type ShippingAddressDTO struct {
+	AddressID     int64  `json:"address_id,omitempty"`
+	RecipientName string `json:"recipient_name"`
+	Phone         string `json:"phone,omitempty"`
+	Country       string `json:"country"`
+	Region        string `json:"region,omitempty"`
+	City          string `json:"city"`
+	PostalCode    string `json:"postal_code,omitempty"`
+	Line1         string `json:"line1"`
+	Line2         string `json:"line2,omitempty"`
+}
+
+// CreateOrderRequestDTO — запрос на создание заказа.
+// Адрес задается либо address_id из адресной книги, либо address; для самовывоза (pickup) не обязателен
 type CreateOrderRequestDTO struct {
-	Items []CreateOrderItemDTO `json:"items"`
+	Items          []CreateOrderItemDTO `json:"items"`
+	PromoCodes     []string             `json:"promo_codes,omitempty"`
+	AddressID      int64                `json:"address_id,omitempty"`
+	Address        *ShippingAddressDTO  `json:"address,omitempty"`
+	DeliveryMethod string               `json:"delivery_method,omitempty" enums:"courier,pickup,post"`
 }
 
+// CreateOrderItemDTO — позиция заказа; variant_id обязателен для товара с несколькими вариантами
 type CreateOrderItemDTO struct {
 	ProductID int64 `json:"product_id"`
+	VariantID int64 `json:"variant_id,omitempty"`
 	Quantity  int32 `json:"quantity"`
 }
 
+// UpdateOrderItemsRequestDTO — новый состав заказа; позиции, которых нет в списке, удаляются
+type UpdateOrderItemsRequestDTO struct {
+	Items []CreateOrderItemDTO `json:"items"`
+	// Ожидаемая версия заказа; заголовок If-Match имеет приоритет
+	Version *int64 `json:"version,omitempty"`
+}
+
+type PayOrderRequestDTO struct {
+	// Одноразовый токен способа оплаты от платежного провайдера
+	PaymentToken string `json:"payment_token" binding:"required" example:"tok_success"`
+}
+
+// PaymentDTO — платеж по заказу. Статус pending означает, что итог придет от провайдера позже
+type PaymentDTO struct {
+	ID            int64     `json:"id"`
+	OrderID       int64     `json:"order_id"`
+	Status        string    `json:"status" enums:"pending,authorized,captured,partially_refunded,refunded,voided,failed"`
+	Amount        MoneyDTO  `json:"amount"`
+	FailureReason string    `json:"failure_reason,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
 type CancelOrderRequestDTO struct {
 	Reason string `json:"reason"`
+	// Ожидаемая версия заказа; заголовок If-Match имеет приоритет
+	Version *int64 `json:"version,omitempty"`
+}
+
+// QuoteRequestDTO — корзина для предварительного расчета стоимости
+type QuoteRequestDTO struct {
+	Items      []CreateOrderItemDTO `json:"items"`
+	PromoCodes []string             `json:"promo_codes,omitempty"`
+}
+
+// QuoteResponseDTO — стоимость корзины со скидками; заказ не создается и товары не резервируются
+type QuoteResponseDTO struct {
+	Items    []OrderItemDTO `json:"items"`
+	Subtotal MoneyDTO       `json:"subtotal"`
+	Discount MoneyDTO       `json:"discount"`
+	Total    MoneyDTO       `json:"total"`
+	// Сумма, возвращенная покупателю по возвратам товаров
+	Refunded   MoneyDTO              `json:"refunded"`
+	Promotions []AppliedPromotionDTO `json:"promotions"`
 }
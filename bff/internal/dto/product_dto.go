@@ -1,9 +1,98 @@
 package dto
 
+import "time"
+
 type ProductResponseDTO struct {
-	ID          int64   `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	Quantity    int32   `json:"quantity"`
+	ID          int64    `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Price       float64  `json:"price"`
+	PriceMoney  MoneyDTO `json:"price_money"`
+
+	// Цена, по которой товар продается сейчас; при распродаже compare_at_price — прежняя цена для зачеркивания
+	CurrentPrice   MoneyDTO  `json:"current_price"`
+	CompareAtPrice *MoneyDTO `json:"compare_at_price,omitempty"`
+
+	Quantity int32 `json:"quantity"`
+	Version  int64 `json:"version"`
+	// Хлебные крошки каждой категории товара, от корня дерева
+	Categories [][]CategoryRefDTO `json:"categories,omitempty"`
+	// Варианты товара (размер, цвет...); при заказе товара с несколькими вариантами нужен variant_id
+	Variants []ProductVariantDTO `json:"variants,omitempty"`
+	// Галерея товара; первое изображение — главное
+	Images []ProductImageDTO `json:"images,omitempty"`
+}
+
+// ProductImageDTO — изображение товара; thumbnails — адреса миниатюр small, medium, large
+type ProductImageDTO struct {
+	ID         int64             `json:"id"`
+	URL        string            `json:"url"`
+	Width      int32             `json:"width"`
+	Height     int32             `json:"height"`
+	AltText    string            `json:"alt_text,omitempty"`
+	Thumbnails map[string]string `json:"thumbnails,omitempty"`
+}
+
+type ProductVariantDTO struct {
+	ID         int64             `json:"id"`
+	SKU        string            `json:"sku"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	Price      MoneyDTO          `json:"price"`
+	Quantity   int32             `json:"quantity"`
+}
+
+type CategoryRefDTO struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// CategoryNodeDTO — узел дерева категорий для навигации
+type CategoryNodeDTO struct {
+	ID       int64             `json:"id"`
+	Name     string            `json:"name"`
+	Children []CategoryNodeDTO `json:"children,omitempty"`
+}
+
+// ProductListFilterDTO — параметры списка продуктов; передаются в product-service без изменений
+type ProductListFilterDTO struct {
+	Page          int       `form:"page"`
+	PageSize      int       `form:"page_size"`
+	Cursor        string    `form:"cursor"`
+	SortBy        string    `form:"sort"`
+	SortOrder     string    `form:"order"`
+	MinPriceMinor *int64    `form:"min_price_minor"`
+	MaxPriceMinor *int64    `form:"max_price_minor"`
+	Currency      string    `form:"currency"`
+	InStock       bool      `form:"in_stock"`
+	UpdatedSince  time.Time `form:"updated_since"`
+	// Категория вместе с подкатегориями
+	CategoryID int64 `form:"category_id"`
+}
+
+type ProductListDTO struct {
+	Products   []*ProductResponseDTO
+	TotalCount int64
+	NextCursor string
+}
+
+// ProductSearchFilterDTO — полнотекстовый поиск по каталогу
+type ProductSearchFilterDTO struct {
+	Query    string `form:"q" binding:"required"`
+	Page     int32  `form:"page"`
+	PageSize int32  `form:"page_size"`
+}
+
+type ProductSearchResultDTO struct {
+	Results    []ProductSearchHitDTO `json:"results"`
+	TotalCount int64                 `json:"total_count"`
+	Page       int32                 `json:"page"`
+	PageSize   int32                 `json:"page_size"`
+}
+
+type ProductSearchHitDTO struct {
+	Product ProductResponseDTO `json:"product"`
+	Rank    float64            `json:"rank"`
+	// Фрагменты с совпадениями, выделенными <b>...</b>
+	NameHighlight        string `json:"name_highlight"`
+	DescriptionHighlight string `json:"description_highlight,omitempty"`
 }
@@ -0,0 +1,58 @@
+package dto
+
+import "time"
+
+// ReorderRequestDTO — повтор заказа. Без address_id, address и delivery_method
+// повторяется доставка исходного заказа
+type ReorderRequestDTO struct {
+	DryRun         bool                `json:"dry_run,omitempty"`
+	PromoCodes     []string            `json:"promo_codes,omitempty"`
+	AddressID      int64               `json:"address_id,omitempty"`
+	Address        *ShippingAddressDTO `json:"address,omitempty"`
+	DeliveryMethod string              `json:"delivery_method,omitempty" enums:"courier,pickup,post"`
+}
+
+// ReorderResultDTO — созданный заказ (кроме dry_run) и сравнение позиций с текущим каталогом
+type ReorderResultDTO struct {
+	OrderID int64             `json:"order_id,omitempty"`
+	Order   *OrderResponseDTO `json:"order,omitempty"`
+	Lines   []ReorderLineDTO  `json:"lines"`
+	// Изменилась цена или доступность хотя бы одной позиции
+	Changed bool `json:"changed"`
+}
+
+type ReorderLineDTO struct {
+	ProductID         int64     `json:"product_id"`
+	VariantID         int64     `json:"variant_id,omitempty"`
+	ProductName       string    `json:"product_name"`
+	RequestedQuantity int32     `json:"requested_quantity"`
+	Quantity          int32     `json:"quantity"`
+	PreviousPrice     *MoneyDTO `json:"previous_price,omitempty"`
+	Price             *MoneyDTO `json:"price,omitempty"`
+	Availability      string    `json:"availability" enums:"available,partial,unavailable"`
+	PriceChanged      bool      `json:"price_changed"`
+}
+
+type OrderTemplateDTO struct {
+	ID             int64                `json:"id"`
+	Name           string               `json:"name"`
+	Items          []CreateOrderItemDTO `json:"items"`
+	DeliveryMethod string               `json:"delivery_method"`
+	AddressID      int64                `json:"address_id,omitempty"`
+	CreatedAt      time.Time            `json:"created_at"`
+	UpdatedAt      time.Time            `json:"updated_at"`
+}
+
+// CreateOrderTemplateRequestDTO — позиции задаются списком или копируются из заказа from_order_id
+type CreateOrderTemplateRequestDTO struct {
+	Name           string               `json:"name" binding:"required"`
+	Items          []CreateOrderItemDTO `json:"items,omitempty"`
+	FromOrderID    int64                `json:"from_order_id,omitempty"`
+	DeliveryMethod string               `json:"delivery_method,omitempty" enums:"courier,pickup,post"`
+	AddressID      int64                `json:"address_id,omitempty"`
+}
+
+type SubmitOrderTemplateRequestDTO struct {
+	DryRun     bool     `json:"dry_run,omitempty"`
+	PromoCodes []string `json:"promo_codes,omitempty"`
+}
@@ -0,0 +1,55 @@
+package dto
+
+import "time"
+
+// ReturnDTO — заявка на возврат товаров заказа.
+// Статусы: requested → approved → received → refunded, либо requested → rejected
+type ReturnDTO struct {
+	ID              int64           `json:"id"`
+	OrderID         int64           `json:"order_id"`
+	UserID          int64           `json:"user_id"`
+	Status          string          `json:"status" enums:"requested,approved,rejected,received,refunded"`
+	Reason          string          `json:"reason" enums:"damaged,defective,wrong_item,not_as_described,changed_mind,other"`
+	Comment         string          `json:"comment,omitempty"`
+	Items           []ReturnItemDTO `json:"items"`
+	Refund          MoneyDTO        `json:"refund"`
+	RejectionReason string          `json:"rejection_reason,omitempty"`
+	CreatedAt       time.Time       `json:"created_at"`
+	ReviewedAt      *time.Time      `json:"reviewed_at,omitempty"`
+	ReceivedAt      *time.Time      `json:"received_at,omitempty"`
+	RefundedAt      *time.Time      `json:"refunded_at,omitempty"`
+}
+
+type ReturnItemDTO struct {
+	ProductID int64    `json:"product_id"`
+	VariantID int64    `json:"variant_id,omitempty"`
+	Quantity  int32    `json:"quantity"`
+	Refund    MoneyDTO `json:"refund"`
+}
+
+// CreateReturnRequestDTO — позиции к возврату; комментарий обязателен для причины other
+type CreateReturnRequestDTO struct {
+	Items   []CreateOrderItemDTO `json:"items" binding:"required,min=1"`
+	Reason  string               `json:"reason" binding:"required" enums:"damaged,defective,wrong_item,not_as_described,changed_mind,other"`
+	Comment string               `json:"comment,omitempty"`
+}
+
+type RejectReturnRequestDTO struct {
+	Reason string `json:"reason" binding:"required" example:"Товар поврежден покупателем"`
+}
+
+type ReturnListDTO struct {
+	Returns    []ReturnDTO `json:"returns"`
+	TotalCount int32       `json:"total_count"`
+	Page       int32       `json:"page"`
+	PageSize   int32       `json:"page_size"`
+}
+
+// ReturnFilterDTO — фильтр списка заявок; user_id учитывается только для администратора
+type ReturnFilterDTO struct {
+	OrderID  int64  `form:"order_id"`
+	UserID   int64  `form:"user_id"`
+	Status   string `form:"status"`
+	Page     int32  `form:"page"`
+	PageSize int32  `form:"page_size"`
+}
@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// OrderSearchFilterDTO — фильтр поиска заказов администратором; все поля необязательны
+type OrderSearchFilterDTO struct {
+	Statuses      []string  `form:"status"`
+	UserID        int64     `form:"user_id"`
+	ProductID     int64     `form:"product_id"`
+	MinTotalMinor *int64    `form:"min_total_minor"`
+	MaxTotalMinor *int64    `form:"max_total_minor"`
+	From          time.Time `form:"from"`
+	To            time.Time `form:"to"`
+	// Подстрока названия товара в позициях заказа
+	Query     string `form:"q"`
+	SortBy    string `form:"sort"`
+	SortOrder string `form:"order"`
+	PageSize  int32  `form:"page_size"`
+	Cursor    string `form:"cursor"`
+}
+
+type OrderSearchResultDTO struct {
+	Orders []OrderResponseDTO `json:"orders"`
+	// Курсор следующей страницы; пустой, если страниц больше нет
+	NextCursor string `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/microserviceteam0/bff-gateway/shared/etag"
+)
+
+// setVersionETag отдает версию ресурса в заголовке ETag
+func setVersionETag(c *gin.Context, version int64) {
+	if version > 0 {
+		c.Header("ETag", etag.Format(version))
+	}
+}
+
+// ifMatchVersion возвращает версию из заголовка If-Match; nil — заголовка нет или он равен "*"
+func ifMatchVersion(c *gin.Context) (*int64, error) {
+	version, err := etag.ParseIfMatch(c.GetHeader("If-Match"))
+	if err != nil || version == 0 {
+		return nil, err
+	}
+	return &version, nil
+}
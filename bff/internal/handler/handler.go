@@ -8,6 +8,7 @@ import (
 	"github.com/microserviceteam0/bff-gateway/bff/internal/apperr"
 	"github.com/microserviceteam0/bff-gateway/bff/internal/middleware"
 	"github.com/microserviceteam0/bff-gateway/bff/internal/service"
+	"github.com/microserviceteam0/bff-gateway/shared/etag"
 )
 
 type Handler struct {
@@ -63,6 +64,12 @@ func (h *Handler) respondWithError(c *gin.Context, err error) {
 	} else if errors.Is(err, apperr.ErrAlreadyExists) {
 		httpCode = http.StatusConflict
 		message = err.Error()
+	} else if errors.Is(err, apperr.ErrConflict) {
+		httpCode = etag.ConflictStatus(c.GetHeader("If-Match"))
+		message = err.Error()
+	} else if errors.Is(err, apperr.ErrStateConflict) {
+		httpCode = http.StatusConflict
+		message = err.Error()
 	} else if errors.Is(err, apperr.ErrServiceUnavailable) {
 		httpCode = http.StatusServiceUnavailable
 		message = "Service unavailable"
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetOrderInvoice godoc
+// @Summary      Download order invoice
+// @Description  Returns the invoice document of a paid order. The first request issues the invoice with the next number; afterwards the same document is returned
+// @Tags         orders
+// @Produce      html
+// @Produce      plain
+// @Security     BearerAuth
+// @Param        id      path      int     true   "Order ID"
+// @Param        format  query     string  false  "Document format" Enums(html, text) default(html)
+// @Success      200  {file}    file
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /orders/{id}/invoice [get]
+func (h *Handler) GetOrderInvoice(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	userRole := getUserRoleFromContext(c)
+	orderID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order ID"})
+		return
+	}
+
+	doc, err := h.bffService.GetOrderInvoice(c.Request.Context(), userID, userRole, orderID, c.Query("format"))
+	if err != nil {
+		h.respondWithError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", doc.Filename))
+	c.Header("X-Invoice-Number", doc.Number)
+	c.Data(http.StatusOK, doc.ContentType, doc.Content)
+}
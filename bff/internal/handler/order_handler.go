@@ -10,7 +10,7 @@ import (
 
 // CreateOrder godoc
 // @Summary      Create a new order
-// @Description  Create a new order for the authenticated user
+// @Description  Create a new order for the authenticated user. Shipping is taken from address_id (address book) or an inline address; pickup orders need neither.
 // @Tags         orders
 // @Accept       json
 // @Produce      json
@@ -36,18 +36,20 @@ func (h *Handler) CreateOrder(c *gin.Context) {
 		return
 	}
 
+	setVersionETag(c, resp.Version)
 	c.JSON(http.StatusCreated, resp)
 }
 
 // GetOrder godoc
 // @Summary      Get order details
-// @Description  Get order details by ID
+// @Description  Get order details by ID. The order version is returned in the ETag header
 // @Tags         orders
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id   path      int  true  "Order ID"
 // @Success      200  {object}  dto.OrderResponseDTO
+// @Header       200  {string}  ETag  "Order version"
 // @Failure      400  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /orders/{id} [get]
@@ -67,20 +69,24 @@ func (h *Handler) GetOrder(c *gin.Context) {
 		return
 	}
 
+	setVersionETag(c, resp.Version)
 	c.JSON(http.StatusOK, resp)
 }
 
 // CancelOrder godoc
 // @Summary      Cancel an order
-// @Description  Cancel an order by ID
+// @Description  Cancel an order by ID. Pass the ETag from GET /orders/{id} in If-Match (or "version" in the body) to reject the cancellation if the order has changed since
 // @Tags         orders
 // @Accept       json
 // @Produce      json
 // @Security     BearerAuth
 // @Param        id   path      int  true  "Order ID"
+// @Param        If-Match header string false "Expected order version (ETag)"
 // @Param        input body dto.CancelOrderRequestDTO true "Cancellation reason"
 // @Success      200  {object}  map[string]string
 // @Failure      400  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      412  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /orders/{id}/cancel [post]
 func (h *Handler) CancelOrder(c *gin.Context) {
@@ -96,7 +102,16 @@ func (h *Handler) CancelOrder(c *gin.Context) {
 	var req dto.CancelOrderRequestDTO
 	_ = c.ShouldBindJSON(&req)
 
-	err = h.bffService.CancelOrder(c.Request.Context(), userID, userRole, id, req.Reason)
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if version != nil {
+		req.Version = version
+	}
+
+	err = h.bffService.CancelOrder(c.Request.Context(), userID, userRole, id, req)
 	if err != nil {
 		h.respondWithError(c, err)
 		return
@@ -104,3 +119,130 @@ func (h *Handler) CancelOrder(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"status": "cancelled"})
 }
+
+// UpdateOrderItems godoc
+// @Summary      Update order items
+// @Description  Replace the items of a pending order. Prices are refreshed and stock is adjusted by the difference. If-Match (or "version" in the body) rejects the change if the order has been modified since it was read
+// @Tags         orders
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Order ID"
+// @Param        If-Match header string false "Expected order version (ETag)"
+// @Param        input body dto.UpdateOrderItemsRequestDTO true "New order items"
+// @Success      200  {object}  dto.OrderResponseDTO
+// @Header       200  {string}  ETag  "New order version"
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      412  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /orders/{id}/items [patch]
+func (h *Handler) UpdateOrderItems(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	userRole := getUserRoleFromContext(c)
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order ID"})
+		return
+	}
+
+	var req dto.UpdateOrderItemsRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if version != nil {
+		req.Version = version
+	}
+
+	resp, err := h.bffService.UpdateOrderItems(c.Request.Context(), userID, userRole, id, req)
+	if err != nil {
+		h.respondWithError(c, err)
+		return
+	}
+
+	setVersionETag(c, resp.Version)
+	c.JSON(http.StatusOK, resp)
+}
+
+// PayOrder godoc
+// @Summary      Pay for an order
+// @Description  Authorize a payment for a pending order. An authorized payment confirms the order, a declined one cancels it; status "pending" means the provider reports the outcome later
+// @Tags         orders
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Order ID"
+// @Param        input body dto.PayOrderRequestDTO true "Payment token"
+// @Success      200  {object}  dto.PaymentDTO
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Failure      503  {object}  map[string]string
+// @Router       /orders/{id}/pay [post]
+func (h *Handler) PayOrder(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	userRole := getUserRoleFromContext(c)
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order ID"})
+		return
+	}
+
+	var req dto.PayOrderRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.bffService.PayOrder(c.Request.Context(), userID, userRole, id, req)
+	if err != nil {
+		h.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// QuoteOrder godoc
+// @Summary      Quote a basket
+// @Description  Price a basket with automatic promotions and promo codes without creating an order or reserving stock
+// @Tags         orders
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        input body dto.QuoteRequestDTO true "Basket and promo codes"
+// @Success      200  {object}  dto.QuoteResponseDTO
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /orders/quote [post]
+func (h *Handler) QuoteOrder(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	userRole := getUserRoleFromContext(c)
+
+	var req dto.QuoteRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.bffService.QuoteOrder(c.Request.Context(), userID, userRole, req)
+	if err != nil {
+		h.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -2,25 +2,125 @@ package handler
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/microserviceteam0/bff-gateway/bff/internal/dto"
 )
 
 // GetProducts godoc
-// @Summary      List all products
-// @Description  Get a list of all products
+// @Summary      List products
+// @Description  Get a page of products. Pass X-Next-Cursor from the response as cursor to get the next page
 // @Tags         products
 // @Accept       json
 // @Produce      json
+// @Param        page             query  int     false  "Page number, starting from 1"
+// @Param        page_size        query  int     false  "Page size, 50 by default, up to 200"
+// @Param        cursor           query  string  false  "Cursor from the previous page"
+// @Param        sort             query  string  false  "Sort field; price requires currency"  Enums(created_at, price, name, stock)
+// @Param        order            query  string  false  "Sort order"  Enums(asc, desc)
+// @Param        min_price_minor  query  int     false  "Minimum price, minor units of currency"
+// @Param        max_price_minor  query  int     false  "Maximum price, minor units of currency"
+// @Param        currency         query  string  false  "ISO 4217 code; required with a price filter or sort=price"
+// @Param        in_stock         query  bool    false  "Only products in stock"
+// @Param        updated_since    query  string  false  "Updated at or after, RFC 3339"
+// @Param        category_id      query  int     false  "Category, including its subcategories"
 // @Success      200  {array}   dto.ProductResponseDTO
+// @Header       200  {integer}  X-Total-Count  "Number of products matching the filter"
+// @Header       200  {string}   X-Next-Cursor  "Cursor of the next page, if any"
+// @Failure      400  {object}  map[string]string
 // @Failure      500  {object}  map[string]string
 // @Router       /products [get]
 func (h *Handler) GetProducts(c *gin.Context) {
-	products, err := h.bffService.ListProducts(c.Request.Context())
+	var filter dto.ProductListFilterDTO
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	list, err := h.bffService.ListProducts(c.Request.Context(), filter)
+	if err != nil {
+		h.respondWithError(c, err)
+		return
+	}
+
+	c.Header("X-Total-Count", strconv.FormatInt(list.TotalCount, 10))
+	if list.NextCursor != "" {
+		c.Header("X-Next-Cursor", list.NextCursor)
+	}
+	c.JSON(http.StatusOK, list.Products)
+}
+
+// SearchProducts godoc
+// @Summary      Search products
+// @Description  Full-text search over product names and descriptions, most relevant first. Words are matched as prefixes and all of them must be present
+// @Tags         products
+// @Produce      json
+// @Param        q          query  string  true   "Search query"
+// @Param        page       query  int     false  "Page number, starting from 1"
+// @Param        page_size  query  int     false  "Page size, 50 by default, up to 200"
+// @Success      200  {object}  dto.ProductSearchResultDTO
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /products/search [get]
+func (h *Handler) SearchProducts(c *gin.Context) {
+	var filter dto.ProductSearchFilterDTO
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.bffService.SearchProducts(c.Request.Context(), filter)
+	if err != nil {
+		h.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListCategories godoc
+// @Summary      List categories
+// @Description  Get the category tree for catalog navigation. Pass a category id as category_id to /products to list its products
+// @Tags         products
+// @Produce      json
+// @Success      200  {array}   dto.CategoryNodeDTO
+// @Failure      500  {object}  map[string]string
+// @Router       /categories [get]
+func (h *Handler) ListCategories(c *gin.Context) {
+	tree, err := h.bffService.ListCategories(c.Request.Context())
 	if err != nil {
 		h.respondWithError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, products)
+	c.JSON(http.StatusOK, tree)
+}
+
+// PurgeProduct godoc
+// @Summary      Purge a product (admin)
+// @Description  Permanently delete an archived product with its variants and images. Products referenced by any order cannot be purged and stay archived
+// @Tags         admin
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Product ID"
+// @Success      204
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /admin/products/{id} [delete]
+func (h *Handler) PurgeProduct(c *gin.Context) {
+	productID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid product ID"})
+		return
+	}
+
+	if err := h.bffService.PurgeProduct(c.Request.Context(), getUserIDFromContext(c), getUserRoleFromContext(c), productID); err != nil {
+		h.respondWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
 }
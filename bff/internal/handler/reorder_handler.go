@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/microserviceteam0/bff-gateway/bff/internal/dto"
+)
+
+// Reorder godoc
+// @Summary      Order again
+// @Description  Create a new order with the items of a previous order at current prices. Unavailable items are skipped and items with less stock are ordered partially; the response lists price and availability changes. With dry_run only the comparison is returned
+// @Tags         orders
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Source order ID"
+// @Param        input body dto.ReorderRequestDTO false "Delivery overrides, promo codes, dry run"
+// @Success      200  {object}  dto.ReorderResultDTO  "Dry run"
+// @Success      201  {object}  dto.ReorderResultDTO
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /orders/{id}/reorder [post]
+func (h *Handler) Reorder(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	userRole := getUserRoleFromContext(c)
+	orderID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order ID"})
+		return
+	}
+
+	// Тело необязательно, но битое тело не должно молча превращаться в заказ с настройками по умолчанию
+	var req dto.ReorderRequestDTO
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	resp, err := h.bffService.Reorder(c.Request.Context(), userID, userRole, orderID, req)
+	if err != nil {
+		h.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(reorderStatus(resp), resp)
+}
+
+// ListOrderTemplates godoc
+// @Summary      List order templates
+// @Tags         order-templates
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {array}   dto.OrderTemplateDTO
+// @Failure      500  {object}  map[string]string
+// @Router       /order-templates [get]
+func (h *Handler) ListOrderTemplates(c *gin.Context) {
+	resp, err := h.bffService.ListOrderTemplates(c.Request.Context(), getUserIDFromContext(c), getUserRoleFromContext(c))
+	if err != nil {
+		h.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateOrderTemplate godoc
+// @Summary      Save an order template
+// @Description  Save a named basket from items or from a previous order (from_order_id). Prices are not stored; address_id must be from the address book unless delivery is pickup
+// @Tags         order-templates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        input body dto.CreateOrderTemplateRequestDTO true "Template"
+// @Success      201  {object}  dto.OrderTemplateDTO
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /order-templates [post]
+func (h *Handler) CreateOrderTemplate(c *gin.Context) {
+	var req dto.CreateOrderTemplateRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.bffService.CreateOrderTemplate(c.Request.Context(), getUserIDFromContext(c), getUserRoleFromContext(c), req)
+	if err != nil {
+		h.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// DeleteOrderTemplate godoc
+// @Summary      Delete an order template
+// @Tags         order-templates
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Template ID"
+// @Success      204
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /order-templates/{id} [delete]
+func (h *Handler) DeleteOrderTemplate(c *gin.Context) {
+	templateID, ok := parseTemplateID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.bffService.DeleteOrderTemplate(c.Request.Context(), getUserIDFromContext(c), getUserRoleFromContext(c), templateID); err != nil {
+		h.respondWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// SubmitOrderTemplate godoc
+// @Summary      Order from a template
+// @Description  Create an order from a saved template at current prices. Unavailable items are skipped and reported like in reorder
+// @Tags         order-templates
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Template ID"
+// @Param        input body dto.SubmitOrderTemplateRequestDTO false "Promo codes, dry run"
+// @Success      200  {object}  dto.ReorderResultDTO  "Dry run"
+// @Success      201  {object}  dto.ReorderResultDTO
+// @Failure      400  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /order-templates/{id}/submit [post]
+func (h *Handler) SubmitOrderTemplate(c *gin.Context) {
+	templateID, ok := parseTemplateID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.SubmitOrderTemplateRequestDTO
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	resp, err := h.bffService.SubmitOrderTemplate(c.Request.Context(), getUserIDFromContext(c), getUserRoleFromContext(c), templateID, req)
+	if err != nil {
+		h.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(reorderStatus(resp), resp)
+}
+
+// reorderStatus — 201, если заказ создан, и 200 для dry_run
+func reorderStatus(resp *dto.ReorderResultDTO) int {
+	if resp.OrderID != 0 {
+		return http.StatusCreated
+	}
+	return http.StatusOK
+}
+
+func parseTemplateID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid template ID"})
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,265 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/microserviceteam0/bff-gateway/bff/internal/dto"
+)
+
+// CreateReturn godoc
+// @Summary      Request a return
+// @Description  Request a return of some or all items of a completed order. The refund is calculated from the paid price after discounts
+// @Tags         returns
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Order ID"
+// @Param        input body dto.CreateReturnRequestDTO true "Items and reason"
+// @Success      201  {object}  dto.ReturnDTO
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /orders/{id}/returns [post]
+func (h *Handler) CreateReturn(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	userRole := getUserRoleFromContext(c)
+	orderID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order ID"})
+		return
+	}
+
+	var req dto.CreateReturnRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.bffService.CreateReturn(c.Request.Context(), userID, userRole, orderID, req)
+	if err != nil {
+		h.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+// ListOrderReturns godoc
+// @Summary      List returns of an order
+// @Tags         returns
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Order ID"
+// @Success      200  {object}  dto.ReturnListDTO
+// @Failure      400  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /orders/{id}/returns [get]
+func (h *Handler) ListOrderReturns(c *gin.Context) {
+	userID := getUserIDFromContext(c)
+	userRole := getUserRoleFromContext(c)
+	orderID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid order ID"})
+		return
+	}
+
+	resp, err := h.bffService.ListReturns(c.Request.Context(), userID, userRole, dto.ReturnFilterDTO{OrderID: orderID, PageSize: 100})
+	if err != nil {
+		h.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// GetReturn godoc
+// @Summary      Get a return
+// @Tags         returns
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Return ID"
+// @Success      200  {object}  dto.ReturnDTO
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /returns/{id} [get]
+func (h *Handler) GetReturn(c *gin.Context) {
+	returnID, ok := parseReturnID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.bffService.GetReturn(c.Request.Context(), getUserIDFromContext(c), getUserRoleFromContext(c), returnID)
+	if err != nil {
+		h.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// AdminListReturns godoc
+// @Summary      List returns (admin)
+// @Description  List returns of all customers, newest first
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status     query  string  false  "Return status"  Enums(requested, approved, rejected, received, refunded)
+// @Param        user_id    query  int     false  "Customer ID"
+// @Param        order_id   query  int     false  "Order ID"
+// @Param        page       query  int     false  "Page, from 1"
+// @Param        page_size  query  int     false  "Page size, up to 100"
+// @Success      200  {object}  dto.ReturnListDTO
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /admin/returns [get]
+func (h *Handler) AdminListReturns(c *gin.Context) {
+	var filter dto.ReturnFilterDTO
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.bffService.ListReturns(c.Request.Context(), getUserIDFromContext(c), getUserRoleFromContext(c), filter)
+	if err != nil {
+		h.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ApproveReturn godoc
+// @Summary      Approve a return (admin)
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Return ID"
+// @Success      200  {object}  dto.ReturnDTO
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /admin/returns/{id}/approve [post]
+func (h *Handler) ApproveReturn(c *gin.Context) {
+	returnID, ok := parseReturnID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.bffService.ApproveReturn(c.Request.Context(), getUserIDFromContext(c), getUserRoleFromContext(c), returnID)
+	if err != nil {
+		h.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// RejectReturn godoc
+// @Summary      Reject a return (admin)
+// @Description  Reject a requested return; its items may be requested again
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Return ID"
+// @Param        input body dto.RejectReturnRequestDTO true "Rejection reason"
+// @Success      200  {object}  dto.ReturnDTO
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /admin/returns/{id}/reject [post]
+func (h *Handler) RejectReturn(c *gin.Context) {
+	returnID, ok := parseReturnID(c)
+	if !ok {
+		return
+	}
+
+	var req dto.RejectReturnRequestDTO
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.bffService.RejectReturn(c.Request.Context(), getUserIDFromContext(c), getUserRoleFromContext(c), returnID, req)
+	if err != nil {
+		h.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ReceiveReturn godoc
+// @Summary      Receive returned goods (admin)
+// @Description  Confirm that the goods of an approved return arrived; they are put back in stock
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Return ID"
+// @Success      200  {object}  dto.ReturnDTO
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /admin/returns/{id}/receive [post]
+func (h *Handler) ReceiveReturn(c *gin.Context) {
+	returnID, ok := parseReturnID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.bffService.ReceiveReturn(c.Request.Context(), getUserIDFromContext(c), getUserRoleFromContext(c), returnID)
+	if err != nil {
+		h.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// RefundReturn godoc
+// @Summary      Refund a return (admin)
+// @Description  Refund a received return to the captured payment. The order becomes partially_refunded, or refunded once every item is returned
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      int  true  "Return ID"
+// @Success      200  {object}  dto.ReturnDTO
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      404  {object}  map[string]string
+// @Failure      409  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Failure      503  {object}  map[string]string
+// @Router       /admin/returns/{id}/refund [post]
+func (h *Handler) RefundReturn(c *gin.Context) {
+	returnID, ok := parseReturnID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.bffService.RefundReturn(c.Request.Context(), getUserIDFromContext(c), getUserRoleFromContext(c), returnID)
+	if err != nil {
+		h.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+func parseReturnID(c *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid return ID"})
+		return 0, false
+	}
+	return id, true
+}
@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/microserviceteam0/bff-gateway/bff/internal/dto"
+)
+
+// AdminSearchOrders godoc
+// @Summary      Search orders (admin)
+// @Description  Search orders of all customers. Filters are combined with AND; pass next_cursor from the response as cursor to get the next page
+// @Tags         admin
+// @Produce      json
+// @Security     BearerAuth
+// @Param        status           query  []string  false  "Order statuses"  collectionFormat(multi)
+// @Param        user_id          query  int       false  "Customer ID"
+// @Param        product_id       query  int       false  "Orders containing this product"
+// @Param        min_total_minor  query  int       false  "Minimum order total, minor units"
+// @Param        max_total_minor  query  int       false  "Maximum order total, minor units"
+// @Param        from             query  string    false  "Created at or after, RFC 3339"
+// @Param        to               query  string    false  "Created at or before, RFC 3339"
+// @Param        q                query  string    false  "Product name substring"
+// @Param        sort             query  string    false  "Sort field"  Enums(created_at, total)
+// @Param        order            query  string    false  "Sort order"  Enums(desc, asc)
+// @Param        page_size        query  int       false  "Page size, up to 100"
+// @Param        cursor           query  string    false  "Cursor from the previous page"
+// @Success      200  {object}  dto.OrderSearchResultDTO
+// @Failure      400  {object}  map[string]string
+// @Failure      403  {object}  map[string]string
+// @Failure      500  {object}  map[string]string
+// @Router       /admin/orders [get]
+func (h *Handler) AdminSearchOrders(c *gin.Context) {
+	var filter dto.OrderSearchFilterDTO
+	if err := c.ShouldBindQuery(&filter); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.bffService.SearchOrders(c.Request.Context(), getUserIDFromContext(c), getUserRoleFromContext(c), filter)
+	if err != nil {
+		h.respondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
@@ -53,3 +53,14 @@ func AuthMiddleware(authClient clients.AuthClient) gin.HandlerFunc {
 	}
 }
 
+
+// RequireRole пропускает только пользователей с указанной ролью; ставится после AuthMiddleware
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if userRole, _ := c.Get(UserRoleKey); userRole != role {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Access denied"})
+			return
+		}
+		c.Next()
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -51,8 +52,9 @@ func RedisCacheMiddleware(rdb *redis.Client, expiration time.Duration) gin.Handl
 
 		c.Next()
 
-		// Если запрос успешен, сохраняем в Redis
-		if c.Writer.Status() == http.StatusOK {
+		// Если запрос успешен, сохраняем в Redis. Кэш отдается как JSON, поэтому документы
+		// (счета) не кэшируются и каждый раз проходят проверку доступа
+		if c.Writer.Status() == http.StatusOK && strings.HasPrefix(c.Writer.Header().Get("Content-Type"), "application/json") {
 			rdb.Set(context.Background(), cacheKey, w.body.Bytes(), expiration)
 		}
 	}
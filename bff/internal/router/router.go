@@ -45,16 +45,43 @@ func SetupRouter(
 	v1.POST("/register", h.Register)
 	v1.POST("/login", h.Login)
 	v1.GET("/products", h.GetProducts)
+	v1.GET("/products/search", h.SearchProducts)
+	v1.GET("/categories", h.ListCategories)
 
 	// Защищенные маршруты
 	authorized := v1.Group("")
 	authorized.Use(middleware.AuthMiddleware(authClient))
 	{
 		authorized.POST("/orders", h.CreateOrder)
+		authorized.POST("/orders/quote", h.QuoteOrder)
 		authorized.GET("/orders/:id", h.GetOrder)
 		authorized.POST("/orders/:id/cancel", h.CancelOrder)
+		authorized.PATCH("/orders/:id/items", h.UpdateOrderItems)
+		authorized.POST("/orders/:id/pay", h.PayOrder)
+		authorized.GET("/orders/:id/invoice", h.GetOrderInvoice)
+		authorized.POST("/orders/:id/reorder", h.Reorder)
+		authorized.GET("/order-templates", h.ListOrderTemplates)
+		authorized.POST("/order-templates", h.CreateOrderTemplate)
+		authorized.DELETE("/order-templates/:id", h.DeleteOrderTemplate)
+		authorized.POST("/order-templates/:id/submit", h.SubmitOrderTemplate)
+		authorized.POST("/orders/:id/returns", h.CreateReturn)
+		authorized.GET("/orders/:id/returns", h.ListOrderReturns)
+		authorized.GET("/returns/:id", h.GetReturn)
 		authorized.GET("/profile", h.GetProfile)
 	}
 
+	// Маршруты администратора
+	admin := authorized.Group("/admin")
+	admin.Use(middleware.RequireRole("admin"))
+	{
+		admin.GET("/orders", h.AdminSearchOrders)
+		admin.GET("/returns", h.AdminListReturns)
+		admin.POST("/returns/:id/approve", h.ApproveReturn)
+		admin.POST("/returns/:id/reject", h.RejectReturn)
+		admin.POST("/returns/:id/receive", h.ReceiveReturn)
+		admin.POST("/returns/:id/refund", h.RefundReturn)
+		admin.DELETE("/products/:id", h.PurgeProduct)
+	}
+
 	return r
 }
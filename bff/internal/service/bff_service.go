@@ -2,11 +2,10 @@ package service
 
 import (
 	"context"
-	"strconv"
 
 	"github.com/microserviceteam0/bff-gateway/bff/internal/clients"
 	"github.com/microserviceteam0/bff-gateway/bff/internal/dto"
-	"google.golang.org/grpc/metadata"
+	"github.com/microserviceteam0/bff-gateway/shared/identity"
 )
 
 type BFFService interface {
@@ -15,9 +14,31 @@ type BFFService interface {
 	Register(ctx context.Context, req dto.RegisterUserRequestDTO) (*dto.UserResponseDTO, error)
 	Login(ctx context.Context, req dto.LoginRequestDTO) (*dto.LoginResponseDTO, error)
 	CreateOrder(ctx context.Context, userID int64, userRole string, req dto.CreateOrderRequestDTO) (*dto.OrderResponseDTO, error)
-	CancelOrder(ctx context.Context, userID int64, userRole string, orderID int64, reason string) error
+	CancelOrder(ctx context.Context, userID int64, userRole string, orderID int64, req dto.CancelOrderRequestDTO) error
+	UpdateOrderItems(ctx context.Context, userID int64, userRole string, orderID int64, req dto.UpdateOrderItemsRequestDTO) (*dto.OrderResponseDTO, error)
+	SearchOrders(ctx context.Context, userID int64, userRole string, filter dto.OrderSearchFilterDTO) (*dto.OrderSearchResultDTO, error)
+	QuoteOrder(ctx context.Context, userID int64, userRole string, req dto.QuoteRequestDTO) (*dto.QuoteResponseDTO, error)
+	PayOrder(ctx context.Context, userID int64, userRole string, orderID int64, req dto.PayOrderRequestDTO) (*dto.PaymentDTO, error)
+	CreateReturn(ctx context.Context, userID int64, userRole string, orderID int64, req dto.CreateReturnRequestDTO) (*dto.ReturnDTO, error)
+	GetReturn(ctx context.Context, userID int64, userRole string, returnID int64) (*dto.ReturnDTO, error)
+	ListReturns(ctx context.Context, userID int64, userRole string, filter dto.ReturnFilterDTO) (*dto.ReturnListDTO, error)
+	ApproveReturn(ctx context.Context, userID int64, userRole string, returnID int64) (*dto.ReturnDTO, error)
+	RejectReturn(ctx context.Context, userID int64, userRole string, returnID int64, req dto.RejectReturnRequestDTO) (*dto.ReturnDTO, error)
+	ReceiveReturn(ctx context.Context, userID int64, userRole string, returnID int64) (*dto.ReturnDTO, error)
+	RefundReturn(ctx context.Context, userID int64, userRole string, returnID int64) (*dto.ReturnDTO, error)
+	GetOrderInvoice(ctx context.Context, userID int64, userRole string, orderID int64, format string) (*dto.InvoiceDocumentDTO, error)
+	Reorder(ctx context.Context, userID int64, userRole string, orderID int64, req dto.ReorderRequestDTO) (*dto.ReorderResultDTO, error)
+	CreateOrderTemplate(ctx context.Context, userID int64, userRole string, req dto.CreateOrderTemplateRequestDTO) (*dto.OrderTemplateDTO, error)
+	ListOrderTemplates(ctx context.Context, userID int64, userRole string) ([]dto.OrderTemplateDTO, error)
+	DeleteOrderTemplate(ctx context.Context, userID int64, userRole string, templateID int64) error
+	SubmitOrderTemplate(ctx context.Context, userID int64, userRole string, templateID int64, req dto.SubmitOrderTemplateRequestDTO) (*dto.ReorderResultDTO, error)
 	GetUserProfile(ctx context.Context, userID int64, userRole string) (*dto.UserProfileDTO, error)
-	ListProducts(ctx context.Context) ([]*dto.ProductResponseDTO, error)
+	ListProducts(ctx context.Context, filter dto.ProductListFilterDTO) (*dto.ProductListDTO, error)
+	SearchProducts(ctx context.Context, filter dto.ProductSearchFilterDTO) (*dto.ProductSearchResultDTO, error)
+	// ListCategories отдает дерево категорий для навигации по каталогу
+	ListCategories(ctx context.Context) ([]dto.CategoryNodeDTO, error)
+	// PurgeProduct окончательно удаляет архивный продукт, на который не ссылается ни один заказ
+	PurgeProduct(ctx context.Context, userID int64, userRole string, productID int64) error
 }
 
 type bffService struct {
@@ -48,10 +69,8 @@ func NewBFFService(
 	}
 }
 
+// withAuthMetadata задает пользователя, от имени которого пойдут gRPC-вызовы;
+// сам токен подписывает identity.UnaryClientInterceptor
 func withAuthMetadata(ctx context.Context, userID int64, role string) context.Context {
-	md := metadata.Pairs(
-		"x-user-id", strconv.FormatInt(userID, 10),
-		"x-user-role", role, 
-	)
-	return metadata.NewOutgoingContext(ctx, md)
+	return identity.NewOutgoingContext(ctx, identity.Principal{UserID: userID, Role: role})
 }
\ No newline at end of file
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+
+	"github.com/microserviceteam0/bff-gateway/bff/internal/dto"
+)
+
+// GetOrderInvoice отдает счет по заказу; владельца заказа проверяет order-service
+func (s *bffService) GetOrderInvoice(ctx context.Context, userID int64, userRole string, orderID int64, format string) (*dto.InvoiceDocumentDTO, error) {
+	ctx = withAuthMetadata(ctx, userID, userRole)
+	resp, err := s.orderClient.GetInvoice(ctx, orderID, format)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := resp.GetDocument()
+	return &dto.InvoiceDocumentDTO{
+		Number:      doc.GetInvoice().GetNumber(),
+		ContentType: doc.GetContentType(),
+		Filename:    doc.GetFilename(),
+		Content:     doc.GetContent(),
+	}, nil
+}
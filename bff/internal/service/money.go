@@ -0,0 +1,30 @@
+package service
+
+import (
+	orderv1 "github.com/microserviceteam0/bff-gateway/bff/api/proto/order/v1"
+	"github.com/microserviceteam0/bff-gateway/shared/money"
+)
+
+// Старые версии order-service заполняют только double поля, поэтому при нулевых
+// минимальных единицах сумма восстанавливается из них.
+
+func orderTotal(order *orderv1.Order) money.Money {
+	if order.GetTotalAmountMinor() == 0 && order.GetTotalAmount() != 0 {
+		return money.FromFloat(order.GetTotalAmount(), order.GetCurrency())
+	}
+	return money.New(order.GetTotalAmountMinor(), order.GetCurrency())
+}
+
+func orderSubtotal(order *orderv1.Order) money.Money {
+	if order.GetSubtotalMinor() == 0 {
+		return orderTotal(order)
+	}
+	return money.New(order.GetSubtotalMinor(), order.GetCurrency())
+}
+
+func orderItemPrice(item *orderv1.OrderItem) money.Money {
+	if item.GetPriceMinor() == 0 && item.GetPrice() != 0 {
+		return money.FromFloat(item.GetPrice(), item.GetCurrency())
+	}
+	return money.New(item.GetPriceMinor(), item.GetCurrency())
+}
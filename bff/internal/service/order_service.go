@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/microserviceteam0/bff-gateway/bff/internal/apperr"
 	"github.com/microserviceteam0/bff-gateway/bff/internal/dto"
 	orderv1 "github.com/microserviceteam0/bff-gateway/bff/api/proto/order/v1"
 	productv1 "github.com/microserviceteam0/bff-gateway/bff/api/proto/product"
 	userv1 "github.com/microserviceteam0/bff-gateway/bff/api/proto/user"
+	"github.com/microserviceteam0/bff-gateway/shared/address"
+	"github.com/microserviceteam0/bff-gateway/shared/money"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -16,33 +19,110 @@ func (s *bffService) CreateOrder(ctx context.Context, userID int64, userRole str
 	for i, item := range req.Items {
 		items[i] = &orderv1.OrderItem{
 			ProductId: item.ProductID,
+			VariantId: item.VariantID,
 			Quantity:  item.Quantity,
 		}
 	}
 
 	createReq := &orderv1.CreateOrderRequest{
-		UserId: userID,
-		Items:  items,
+		UserId:         userID,
+		Items:          items,
+		PromoCodes:     req.PromoCodes,
+		DeliveryMethod: req.DeliveryMethod,
 	}
 
-	ctx = withAuthMetadata(ctx, userID, userRole)
-	resp, err := s.orderClient.CreateOrder(ctx, createReq)
+	switch {
+	case req.AddressID != 0 && req.Address != nil:
+		return nil, fmt.Errorf("%w: address_id and address are mutually exclusive", apperr.ErrInvalidInput)
+	case req.AddressID != 0:
+		createReq.Shipping = &orderv1.CreateOrderRequest_AddressId{AddressId: req.AddressID}
+	case req.Address != nil:
+		addr, err := shippingAddressFromDTO(req.Address)
+		if err != nil {
+			return nil, err
+		}
+		createReq.Shipping = &orderv1.CreateOrderRequest_Address{Address: addr}
+	}
+
+	authCtx := withAuthMetadata(ctx, userID, userRole)
+	resp, err := s.orderClient.CreateOrder(authCtx, createReq)
 	if err != nil {
 		return nil, err
 	}
 
-	return &dto.OrderResponseDTO{
-		ID:     resp.GetOrderId(),
-		Status: "pending",
-	}, nil
+	details, err := s.GetOrderDetails(ctx, userID, userRole, resp.GetOrderId())
+	if err != nil {
+		// Заказ уже создан, поэтому сбой чтения деталей не превращаем в ошибку
+		return &dto.OrderResponseDTO{
+			ID:             resp.GetOrderId(),
+			Status:         "pending",
+			DeliveryMethod: req.DeliveryMethod,
+		}, nil
+	}
+
+	return details, nil
 }
 
-func (s *bffService) CancelOrder(ctx context.Context, userID int64, userRole string, orderID int64, reason string) error {
+func (s *bffService) CancelOrder(ctx context.Context, userID int64, userRole string, orderID int64, req dto.CancelOrderRequestDTO) error {
 	ctx = withAuthMetadata(ctx, userID, userRole)
-	_, err := s.orderClient.CancelOrder(ctx, orderID, userID, reason)
+	_, err := s.orderClient.CancelOrder(ctx, &orderv1.CancelOrderRequest{
+		OrderId:         orderID,
+		UserId:          userID,
+		Reason:          req.Reason,
+		ExpectedVersion: req.Version,
+	})
 	return err
 }
 
+func (s *bffService) UpdateOrderItems(ctx context.Context, userID int64, userRole string, orderID int64, req dto.UpdateOrderItemsRequestDTO) (*dto.OrderResponseDTO, error) {
+	items := make([]*orderv1.OrderItem, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = &orderv1.OrderItem{
+			ProductId: item.ProductID,
+			VariantId: item.VariantID,
+			Quantity:  item.Quantity,
+		}
+	}
+
+	authCtx := withAuthMetadata(ctx, userID, userRole)
+	_, err := s.orderClient.UpdateOrderItems(authCtx, &orderv1.UpdateOrderItemsRequest{
+		OrderId:         orderID,
+		UserId:          userID,
+		Items:           items,
+		ExpectedVersion: req.Version,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.GetOrderDetails(ctx, userID, userRole, orderID)
+}
+
+func (s *bffService) PayOrder(ctx context.Context, userID int64, userRole string, orderID int64, req dto.PayOrderRequestDTO) (*dto.PaymentDTO, error) {
+	ctx = withAuthMetadata(ctx, userID, userRole)
+	resp, err := s.orderClient.PayOrder(ctx, &orderv1.PayOrderRequest{
+		OrderId:      orderID,
+		PaymentToken: req.PaymentToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := resp.GetPayment()
+	if p == nil {
+		return nil, fmt.Errorf("payment missing in response")
+	}
+
+	return &dto.PaymentDTO{
+		ID:            p.GetId(),
+		OrderID:       p.GetOrderId(),
+		Status:        p.GetStatus(),
+		Amount:        dto.NewMoneyDTO(money.New(p.GetAmountMinor(), p.GetCurrency())),
+		FailureReason: p.GetFailureReason(),
+		CreatedAt:     p.GetCreatedAt().AsTime(),
+	}, nil
+}
+
 func (s *bffService) GetOrderDetails(ctx context.Context, userID int64, userRole string, orderID int64) (*dto.OrderResponseDTO, error) {
 	ctx = withAuthMetadata(ctx, userID, userRole)
 	
@@ -100,6 +180,7 @@ func (s *bffService) GetOrderDetails(ctx context.Context, userID int64, userRole
 		}
 	}
 
+	total := orderTotal(order)
 	resp := &dto.OrderResponseDTO{
 		ID: order.GetId(),
 		User: dto.UserSummaryDTO{
@@ -108,8 +189,17 @@ func (s *bffService) GetOrderDetails(ctx context.Context, userID int64, userRole
 			Email: user.GetEmail(),
 		},
 		Status:    order.GetStatus(), 
-		TotalSum:  order.GetTotalAmount(), 
+		TotalSum:   total.Float64(),
+		Subtotal:   dto.NewMoneyDTO(orderSubtotal(order)),
+		Discount:   dto.NewMoneyDTO(money.New(order.GetDiscountMinor(), total.Currency)),
+		Total:      dto.NewMoneyDTO(total),
+		Refunded:   dto.NewMoneyDTO(money.New(order.GetRefundedMinor(), total.Currency)),
+		Promotions: appliedPromotionsToDTO(order.GetPromotions()),
+		ShippingAddress: shippingAddressToDTO(order.GetShippingAddress()),
+		DeliveryMethod:  order.GetDeliveryMethod(),
+		CancellationReason: order.GetCancellationReason(),
 		CreatedAt: order.GetCreatedAt().AsTime(),
+		Version:   order.GetVersion(),
 		Items:     make([]dto.OrderItemDTO, 0, len(order.GetItems())),
 	}
 
@@ -119,13 +209,119 @@ func (s *bffService) GetOrderDetails(ctx context.Context, userID int64, userRole
 			prodName = name
 		}
 
-		resp.Items = append(resp.Items, dto.OrderItemDTO{
-			ProductID:   item.GetProductId(),
-			ProductName: prodName,
-			Quantity:    item.GetQuantity(),
-			UnitPrice:   item.GetPrice(),
-		})
+		resp.Items = append(resp.Items, orderItemToDTO(item, prodName))
 	}
 
 	return resp, nil
 }
+
+func (s *bffService) QuoteOrder(ctx context.Context, userID int64, userRole string, req dto.QuoteRequestDTO) (*dto.QuoteResponseDTO, error) {
+	items := make([]*orderv1.OrderItem, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = &orderv1.OrderItem{
+			ProductId: item.ProductID,
+			VariantId: item.VariantID,
+			Quantity:  item.Quantity,
+		}
+	}
+
+	ctx = withAuthMetadata(ctx, userID, userRole)
+	resp, err := s.orderClient.QuoteOrder(ctx, &orderv1.QuoteOrderRequest{
+		UserId:     userID,
+		Items:      items,
+		PromoCodes: req.PromoCodes,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	quote := resp.GetQuote()
+	if quote == nil {
+		return nil, fmt.Errorf("quote missing in response")
+	}
+
+	result := &dto.QuoteResponseDTO{
+		Items:      make([]dto.OrderItemDTO, 0, len(quote.GetItems())),
+		Subtotal:   dto.NewMoneyDTO(money.New(quote.GetSubtotalMinor(), quote.GetCurrency())),
+		Discount:   dto.NewMoneyDTO(money.New(quote.GetDiscountMinor(), quote.GetCurrency())),
+		Total:      dto.NewMoneyDTO(money.New(quote.GetTotalMinor(), quote.GetCurrency())),
+		Promotions: appliedPromotionsToDTO(quote.GetPromotions()),
+	}
+	for _, item := range quote.GetItems() {
+		result.Items = append(result.Items, orderItemToDTO(item, item.GetProductName()))
+	}
+
+	return result, nil
+}
+
+func orderItemToDTO(item *orderv1.OrderItem, productName string) dto.OrderItemDTO {
+	price := orderItemPrice(item)
+	return dto.OrderItemDTO{
+		ProductID:   item.GetProductId(),
+		VariantID:   item.GetVariantId(),
+		SKU:         item.GetSku(),
+		ProductName: productName,
+		Quantity:    item.GetQuantity(),
+		UnitPrice:   price.Float64(),
+		Price:       dto.NewMoneyDTO(price),
+		LineTotal:   dto.NewMoneyDTO(price.Mul(int64(item.GetQuantity()))),
+		Discount:    dto.NewMoneyDTO(money.New(item.GetDiscountMinor(), price.Currency)),
+	}
+}
+
+func appliedPromotionsToDTO(promotions []*orderv1.AppliedPromotion) []dto.AppliedPromotionDTO {
+	result := make([]dto.AppliedPromotionDTO, 0, len(promotions))
+	for _, p := range promotions {
+		result = append(result, dto.AppliedPromotionDTO{
+			PromotionID: p.GetPromotionId(),
+			Code:        p.GetCode(),
+			Name:        p.GetName(),
+			Discount:    dto.NewMoneyDTO(money.New(p.GetDiscountMinor(), p.GetCurrency())),
+		})
+	}
+	return result
+}
+
+func shippingAddressToDTO(a *orderv1.ShippingAddress) *dto.ShippingAddressDTO {
+	if a == nil {
+		return nil
+	}
+	return &dto.ShippingAddressDTO{
+		AddressID:     a.GetAddressId(),
+		RecipientName: a.GetRecipientName(),
+		Phone:         a.GetPhone(),
+		Country:       a.GetCountry(),
+		Region:        a.GetRegion(),
+		City:          a.GetCity(),
+		PostalCode:    a.GetPostalCode(),
+		Line1:         a.GetLine1(),
+		Line2:         a.GetLine2(),
+	}
+}
+
+// shippingAddressFromDTO проверяет адрес до похода в order-service, чтобы сразу вернуть понятную ошибку
+func shippingAddressFromDTO(a *dto.ShippingAddressDTO) (*orderv1.ShippingAddress, error) {
+	addr := address.Normalize(address.Address{
+		RecipientName: a.RecipientName,
+		Phone:         a.Phone,
+		Country:       a.Country,
+		Region:        a.Region,
+		City:          a.City,
+		PostalCode:    a.PostalCode,
+		Line1:         a.Line1,
+		Line2:         a.Line2,
+	})
+	if err := address.Validate(addr); err != nil {
+		return nil, fmt.Errorf("%w: %v", apperr.ErrInvalidInput, err)
+	}
+	return &orderv1.ShippingAddress{
+		RecipientName: addr.RecipientName,
+		Phone:         addr.Phone,
+		Country:       addr.Country,
+		Region:        addr.Region,
+		City:          addr.City,
+		PostalCode:    addr.PostalCode,
+		Line1:         addr.Line1,
+		Line2:         addr.Line2,
+	}, nil
+}
@@ -2,26 +2,224 @@ package service
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
 
+	orderv1 "github.com/microserviceteam0/bff-gateway/bff/api/proto/order/v1"
+	productv1 "github.com/microserviceteam0/bff-gateway/bff/api/proto/product"
+	"github.com/microserviceteam0/bff-gateway/bff/internal/apperr"
+	"github.com/microserviceteam0/bff-gateway/bff/internal/clients"
 	"github.com/microserviceteam0/bff-gateway/bff/internal/dto"
+	"github.com/microserviceteam0/bff-gateway/shared/money"
 )
 
-func (s *bffService) ListProducts(ctx context.Context) ([]*dto.ProductResponseDTO, error) {
-	products, err := s.productHTTPClient.ListProducts(ctx)
+func (s *bffService) ListProducts(ctx context.Context, filter dto.ProductListFilterDTO) (*dto.ProductListDTO, error) {
+	list, err := s.productHTTPClient.ListProducts(ctx, productListQuery(filter))
 	if err != nil {
 		return nil, err
 	}
 
-	dtos := make([]*dto.ProductResponseDTO, 0, len(products))
-	for _, p := range products {
-		dtos = append(dtos, &dto.ProductResponseDTO{
-			ID:          p.ID,
-			Name:        p.Name,
-			Description: p.Description,
-			Price:       p.Price,
-			Quantity:    p.Stock,
+	dtos := make([]*dto.ProductResponseDTO, 0, len(list.Products))
+	for _, p := range list.Products {
+		product := productToDTO(p.ID, p.Name, p.Description, p.PriceMinor, p.Price, p.Currency, p.Stock, p.Version)
+		setCompareAtPrice(product, p.CompareAtPriceMinor)
+		for _, path := range p.Categories {
+			refs := make([]dto.CategoryRefDTO, 0, len(path))
+			for _, c := range path {
+				refs = append(refs, dto.CategoryRefDTO{ID: c.ID, Name: c.Name})
+			}
+			product.Categories = append(product.Categories, refs)
+		}
+		for _, v := range p.Variants {
+			product.Variants = append(product.Variants, dto.ProductVariantDTO{
+				ID:         v.ID,
+				SKU:        v.SKU,
+				Attributes: v.Attributes,
+				Price:      dto.NewMoneyDTO(money.New(v.PriceMinor, p.Currency)),
+				Quantity:   v.Stock,
+			})
+		}
+		for _, img := range p.Images {
+			product.Images = append(product.Images, dto.ProductImageDTO{
+				ID:         img.ID,
+				URL:        img.URL,
+				Width:      img.Width,
+				Height:     img.Height,
+				AltText:    img.AltText,
+				Thumbnails: img.Thumbnails,
+			})
+		}
+		dtos = append(dtos, product)
+	}
+
+	return &dto.ProductListDTO{Products: dtos, TotalCount: list.TotalCount, NextCursor: list.NextCursor}, nil
+}
+
+func (s *bffService) SearchProducts(ctx context.Context, filter dto.ProductSearchFilterDTO) (*dto.ProductSearchResultDTO, error) {
+	resp, err := s.productClient.SearchProducts(ctx, &productv1.SearchProductsRequest{
+		Query:    filter.Query,
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]dto.ProductSearchHitDTO, 0, len(resp.GetResults()))
+	for _, r := range resp.GetResults() {
+		p := r.GetProduct()
+		product := productToDTO(p.GetId(), p.GetName(), p.GetDescription(), p.GetPriceMinor(), p.GetPrice(), p.GetCurrency(), p.GetStock(), p.GetVersion())
+		setCompareAtPrice(product, p.CompareAtPriceMinor)
+		for _, path := range p.GetCategories() {
+			refs := make([]dto.CategoryRefDTO, 0, len(path.GetCategories()))
+			for _, c := range path.GetCategories() {
+				refs = append(refs, dto.CategoryRefDTO{ID: c.GetId(), Name: c.GetName()})
+			}
+			product.Categories = append(product.Categories, refs)
+		}
+		for _, v := range p.GetVariants() {
+			product.Variants = append(product.Variants, dto.ProductVariantDTO{
+				ID:         v.GetId(),
+				SKU:        v.GetSku(),
+				Attributes: v.GetAttributes(),
+				Price:      dto.NewMoneyDTO(money.New(v.GetPriceMinor(), p.GetCurrency())),
+				Quantity:   v.GetStock(),
+			})
+		}
+		for _, img := range p.GetImages() {
+			product.Images = append(product.Images, dto.ProductImageDTO{
+				ID:         img.GetId(),
+				URL:        img.GetUrl(),
+				Width:      img.GetWidth(),
+				Height:     img.GetHeight(),
+				AltText:    img.GetAltText(),
+				Thumbnails: img.GetThumbnails(),
+			})
+		}
+		results = append(results, dto.ProductSearchHitDTO{
+			Product:              *product,
+			Rank:                 r.GetRank(),
+			NameHighlight:        r.GetNameHighlight(),
+			DescriptionHighlight: r.GetDescriptionHighlight(),
 		})
 	}
 
-	return dtos, nil
+	return &dto.ProductSearchResultDTO{
+		Results:    results,
+		TotalCount: resp.GetTotalCount(),
+		Page:       resp.GetPage(),
+		PageSize:   resp.GetPageSize(),
+	}, nil
+}
+
+func (s *bffService) ListCategories(ctx context.Context) ([]dto.CategoryNodeDTO, error) {
+	tree, err := s.productHTTPClient.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return categoryNodesToDTO(tree), nil
+}
+
+func categoryNodesToDTO(nodes []clients.CategoryHTTPNode) []dto.CategoryNodeDTO {
+	result := make([]dto.CategoryNodeDTO, 0, len(nodes))
+	for _, n := range nodes {
+		node := dto.CategoryNodeDTO{ID: n.ID, Name: n.Name}
+		if len(n.Children) > 0 {
+			node.Children = categoryNodesToDTO(n.Children)
+		}
+		result = append(result, node)
+	}
+	return result
+}
+
+// productToDTO собирает продукт из полей REST- и gRPC-ответов product-service.
+// Старые версии заполняют только price, тогда цена восстанавливается из него
+func productToDTO(id int64, name, description string, priceMinor int64, legacyPrice float64, currency string, stock int32, version int64) *dto.ProductResponseDTO {
+	price := money.New(priceMinor, currency)
+	if priceMinor == 0 && legacyPrice != 0 {
+		price = money.FromFloat(legacyPrice, currency)
+	}
+	return &dto.ProductResponseDTO{
+		ID:          id,
+		Name:        name,
+		Description: description,
+		Price:       price.Float64(),
+		PriceMoney:  dto.NewMoneyDTO(price),
+		Quantity:    stock,
+		Version:     version,
+
+		CurrentPrice: dto.NewMoneyDTO(price),
+	}
+}
+
+// setCompareAtPrice добавляет зачеркнутую цену, если товар продается по распродаже
+func setCompareAtPrice(product *dto.ProductResponseDTO, compareAtMinor *int64) {
+	if compareAtMinor == nil {
+		return
+	}
+	compareAt := dto.NewMoneyDTO(money.New(*compareAtMinor, product.CurrentPrice.Currency))
+	product.CompareAtPrice = &compareAt
+}
+
+// productListQuery собирает query-параметры GET /api/products; пустые значения не передаются
+func productListQuery(filter dto.ProductListFilterDTO) url.Values {
+	q := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			q.Set(key, value)
+		}
+	}
+	if filter.Page != 0 {
+		set("page", strconv.Itoa(filter.Page))
+	}
+	if filter.PageSize != 0 {
+		set("page_size", strconv.Itoa(filter.PageSize))
+	}
+	set("cursor", filter.Cursor)
+	set("sort", filter.SortBy)
+	set("order", filter.SortOrder)
+	if filter.MinPriceMinor != nil {
+		set("min_price_minor", strconv.FormatInt(*filter.MinPriceMinor, 10))
+	}
+	if filter.MaxPriceMinor != nil {
+		set("max_price_minor", strconv.FormatInt(*filter.MaxPriceMinor, 10))
+	}
+	set("currency", filter.Currency)
+	if filter.InStock {
+		set("in_stock", "true")
+	}
+	if !filter.UpdatedSince.IsZero() {
+		set("updated_since", filter.UpdatedSince.Format(time.RFC3339))
+	}
+	if filter.CategoryID != 0 {
+		set("category_id", strconv.FormatInt(filter.CategoryID, 10))
+	}
+	return q
+}
+
+// PurgeProduct удаляет продукт навсегда. Удалить можно только архивный продукт,
+// иначе пропали бы названия товаров в истории заказов. Заказы с продуктом проверяются
+// здесь, чтобы быстро ответить 409; product-service повторяет и эту проверку, и проверку роли
+func (s *bffService) PurgeProduct(ctx context.Context, userID int64, userRole string, productID int64) error {
+	ctx = withAuthMetadata(ctx, userID, userRole)
+
+	product, err := s.productClient.GetProduct(ctx, productID)
+	if err != nil {
+		return err
+	}
+	if product.GetStatus() != "archived" {
+		return fmt.Errorf("%w: product %d is not archived", apperr.ErrStateConflict, productID)
+	}
+
+	orders, err := s.orderClient.SearchOrders(ctx, &orderv1.SearchOrdersRequest{ProductId: productID, PageSize: 1})
+	if err != nil {
+		return err
+	}
+	if len(orders.GetOrders()) > 0 {
+		return fmt.Errorf("%w: product %d is referenced by orders", apperr.ErrStateConflict, productID)
+	}
+
+	return s.productClient.PurgeProduct(ctx, productID)
 }
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	orderv1 "github.com/microserviceteam0/bff-gateway/bff/api/proto/order/v1"
+	"github.com/microserviceteam0/bff-gateway/bff/internal/apperr"
+	"github.com/microserviceteam0/bff-gateway/bff/internal/dto"
+	"github.com/microserviceteam0/bff-gateway/shared/money"
+)
+
+func (s *bffService) Reorder(ctx context.Context, userID int64, userRole string, orderID int64, req dto.ReorderRequestDTO) (*dto.ReorderResultDTO, error) {
+	reorderReq := &orderv1.ReorderRequest{
+		OrderId:        orderID,
+		DryRun:         req.DryRun,
+		PromoCodes:     req.PromoCodes,
+		DeliveryMethod: req.DeliveryMethod,
+	}
+
+	switch {
+	case req.AddressID != 0 && req.Address != nil:
+		return nil, fmt.Errorf("%w: address_id and address are mutually exclusive", apperr.ErrInvalidInput)
+	case req.AddressID != 0:
+		reorderReq.Shipping = &orderv1.ReorderRequest_AddressId{AddressId: req.AddressID}
+	case req.Address != nil:
+		addr, err := shippingAddressFromDTO(req.Address)
+		if err != nil {
+			return nil, err
+		}
+		reorderReq.Shipping = &orderv1.ReorderRequest_Address{Address: addr}
+	}
+
+	resp, err := s.orderClient.Reorder(withAuthMetadata(ctx, userID, userRole), reorderReq)
+	if err != nil {
+		return nil, err
+	}
+	return s.reorderResultToDTO(ctx, userID, userRole, resp.GetReorder()), nil
+}
+
+func (s *bffService) CreateOrderTemplate(ctx context.Context, userID int64, userRole string, req dto.CreateOrderTemplateRequestDTO) (*dto.OrderTemplateDTO, error) {
+	items := make([]*orderv1.OrderItem, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = &orderv1.OrderItem{ProductId: item.ProductID, VariantId: item.VariantID, Quantity: item.Quantity}
+	}
+
+	ctx = withAuthMetadata(ctx, userID, userRole)
+	resp, err := s.orderClient.CreateOrderTemplate(ctx, &orderv1.CreateOrderTemplateRequest{
+		Name:           req.Name,
+		Items:          items,
+		FromOrderId:    req.FromOrderID,
+		DeliveryMethod: req.DeliveryMethod,
+		AddressId:      req.AddressID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	template := orderTemplateToDTO(resp.GetTemplate())
+	return &template, nil
+}
+
+func (s *bffService) ListOrderTemplates(ctx context.Context, userID int64, userRole string) ([]dto.OrderTemplateDTO, error) {
+	ctx = withAuthMetadata(ctx, userID, userRole)
+	resp, err := s.orderClient.ListOrderTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]dto.OrderTemplateDTO, len(resp.GetTemplates()))
+	for i, t := range resp.GetTemplates() {
+		templates[i] = orderTemplateToDTO(t)
+	}
+	return templates, nil
+}
+
+func (s *bffService) DeleteOrderTemplate(ctx context.Context, userID int64, userRole string, templateID int64) error {
+	ctx = withAuthMetadata(ctx, userID, userRole)
+	_, err := s.orderClient.DeleteOrderTemplate(ctx, templateID)
+	return err
+}
+
+func (s *bffService) SubmitOrderTemplate(ctx context.Context, userID int64, userRole string, templateID int64, req dto.SubmitOrderTemplateRequestDTO) (*dto.ReorderResultDTO, error) {
+	resp, err := s.orderClient.SubmitOrderTemplate(withAuthMetadata(ctx, userID, userRole), &orderv1.SubmitOrderTemplateRequest{
+		TemplateId: templateID,
+		DryRun:     req.DryRun,
+		PromoCodes: req.PromoCodes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.reorderResultToDTO(ctx, userID, userRole, resp.GetReorder()), nil
+}
+
+// reorderResultToDTO дополняет результат деталями созданного заказа; заказ уже создан,
+// поэтому сбой их чтения не превращаем в ошибку
+func (s *bffService) reorderResultToDTO(ctx context.Context, userID int64, userRole string, result *orderv1.ReorderResult) *dto.ReorderResultDTO {
+	resp := &dto.ReorderResultDTO{
+		OrderID: result.GetOrderId(),
+		Lines:   make([]dto.ReorderLineDTO, len(result.GetLines())),
+		Changed: result.GetChanged(),
+	}
+
+	for i, line := range result.GetLines() {
+		l := dto.ReorderLineDTO{
+			ProductID:         line.GetProductId(),
+			VariantID:         line.GetVariantId(),
+			ProductName:       line.GetProductName(),
+			RequestedQuantity: line.GetRequestedQuantity(),
+			Quantity:          line.GetQuantity(),
+			Availability:      line.GetAvailability(),
+			PriceChanged:      line.GetPriceChanged(),
+		}
+		if line.GetPreviousPriceMinor() != 0 {
+			previous := dto.NewMoneyDTO(money.New(line.GetPreviousPriceMinor(), line.GetCurrency()))
+			l.PreviousPrice = &previous
+		}
+		if line.GetPriceMinor() != 0 {
+			price := dto.NewMoneyDTO(money.New(line.GetPriceMinor(), line.GetCurrency()))
+			l.Price = &price
+		}
+		resp.Lines[i] = l
+	}
+
+	if resp.OrderID != 0 {
+		if details, err := s.GetOrderDetails(ctx, userID, userRole, resp.OrderID); err == nil {
+			resp.Order = details
+		}
+	}
+	return resp
+}
+
+func orderTemplateToDTO(t *orderv1.OrderTemplate) dto.OrderTemplateDTO {
+	items := make([]dto.CreateOrderItemDTO, len(t.GetItems()))
+	for i, item := range t.GetItems() {
+		items[i] = dto.CreateOrderItemDTO{ProductID: item.GetProductId(), VariantID: item.GetVariantId(), Quantity: item.GetQuantity()}
+	}
+	return dto.OrderTemplateDTO{
+		ID:             t.GetId(),
+		Name:           t.GetName(),
+		Items:          items,
+		DeliveryMethod: t.GetDeliveryMethod(),
+		AddressID:      t.GetAddressId(),
+		CreatedAt:      t.GetCreatedAt().AsTime(),
+		UpdatedAt:      t.GetUpdatedAt().AsTime(),
+	}
+}
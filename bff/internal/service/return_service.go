@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	orderv1 "github.com/microserviceteam0/bff-gateway/bff/api/proto/order/v1"
+	"github.com/microserviceteam0/bff-gateway/bff/internal/dto"
+	"github.com/microserviceteam0/bff-gateway/shared/money"
+)
+
+func (s *bffService) CreateReturn(ctx context.Context, userID int64, userRole string, orderID int64, req dto.CreateReturnRequestDTO) (*dto.ReturnDTO, error) {
+	items := make([]*orderv1.ReturnItem, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = &orderv1.ReturnItem{
+			ProductId: item.ProductID,
+			VariantId: item.VariantID,
+			Quantity:  item.Quantity,
+		}
+	}
+
+	ctx = withAuthMetadata(ctx, userID, userRole)
+	resp, err := s.orderClient.CreateReturn(ctx, &orderv1.CreateReturnRequest{
+		OrderId: orderID,
+		Items:   items,
+		Reason:  req.Reason,
+		Comment: req.Comment,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return returnToDTO(resp.GetOrderReturn())
+}
+
+func (s *bffService) GetReturn(ctx context.Context, userID int64, userRole string, returnID int64) (*dto.ReturnDTO, error) {
+	ctx = withAuthMetadata(ctx, userID, userRole)
+	resp, err := s.orderClient.GetReturn(ctx, returnID)
+	if err != nil {
+		return nil, err
+	}
+	return returnToDTO(resp.GetOrderReturn())
+}
+
+// ListReturns отдает заявки по фильтру; order-service сам ограничивает покупателя его заявками
+func (s *bffService) ListReturns(ctx context.Context, userID int64, userRole string, filter dto.ReturnFilterDTO) (*dto.ReturnListDTO, error) {
+	req := &orderv1.ListReturnsRequest{
+		OrderId:  filter.OrderID,
+		UserId:   filter.UserID,
+		Page:     filter.Page,
+		PageSize: filter.PageSize,
+	}
+	if filter.Status != "" {
+		req.Status = &filter.Status
+	}
+
+	ctx = withAuthMetadata(ctx, userID, userRole)
+	resp, err := s.orderClient.ListReturns(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.ReturnListDTO{
+		Returns:    make([]dto.ReturnDTO, 0, len(resp.GetReturns())),
+		TotalCount: resp.GetTotalCount(),
+		Page:       resp.GetPage(),
+		PageSize:   resp.GetPageSize(),
+	}
+	for _, r := range resp.GetReturns() {
+		ret, err := returnToDTO(r)
+		if err != nil {
+			return nil, err
+		}
+		result.Returns = append(result.Returns, *ret)
+	}
+	return result, nil
+}
+
+func (s *bffService) ApproveReturn(ctx context.Context, userID int64, userRole string, returnID int64) (*dto.ReturnDTO, error) {
+	ctx = withAuthMetadata(ctx, userID, userRole)
+	resp, err := s.orderClient.ApproveReturn(ctx, returnID)
+	if err != nil {
+		return nil, err
+	}
+	return returnToDTO(resp.GetOrderReturn())
+}
+
+func (s *bffService) RejectReturn(ctx context.Context, userID int64, userRole string, returnID int64, req dto.RejectReturnRequestDTO) (*dto.ReturnDTO, error) {
+	ctx = withAuthMetadata(ctx, userID, userRole)
+	resp, err := s.orderClient.RejectReturn(ctx, &orderv1.RejectReturnRequest{
+		ReturnId: returnID,
+		Reason:   req.Reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return returnToDTO(resp.GetOrderReturn())
+}
+
+func (s *bffService) ReceiveReturn(ctx context.Context, userID int64, userRole string, returnID int64) (*dto.ReturnDTO, error) {
+	ctx = withAuthMetadata(ctx, userID, userRole)
+	resp, err := s.orderClient.ReceiveReturn(ctx, returnID)
+	if err != nil {
+		return nil, err
+	}
+	return returnToDTO(resp.GetOrderReturn())
+}
+
+func (s *bffService) RefundReturn(ctx context.Context, userID int64, userRole string, returnID int64) (*dto.ReturnDTO, error) {
+	ctx = withAuthMetadata(ctx, userID, userRole)
+	resp, err := s.orderClient.RefundReturn(ctx, returnID)
+	if err != nil {
+		return nil, err
+	}
+	return returnToDTO(resp.GetOrderReturn())
+}
+
+func returnToDTO(r *orderv1.OrderReturn) (*dto.ReturnDTO, error) {
+	if r == nil {
+		return nil, fmt.Errorf("return missing in response")
+	}
+
+	items := make([]dto.ReturnItemDTO, len(r.GetItems()))
+	for i, item := range r.GetItems() {
+		items[i] = dto.ReturnItemDTO{
+			ProductID: item.GetProductId(),
+			VariantID: item.GetVariantId(),
+			Quantity:  item.GetQuantity(),
+			Refund:    dto.NewMoneyDTO(money.New(item.GetRefundMinor(), r.GetCurrency())),
+		}
+	}
+
+	ret := &dto.ReturnDTO{
+		ID:              r.GetId(),
+		OrderID:         r.GetOrderId(),
+		UserID:          r.GetUserId(),
+		Status:          r.GetStatus(),
+		Reason:          r.GetReason(),
+		Comment:         r.GetComment(),
+		Items:           items,
+		Refund:          dto.NewMoneyDTO(money.New(r.GetRefundMinor(), r.GetCurrency())),
+		RejectionReason: r.GetRejectionReason(),
+		CreatedAt:       r.GetCreatedAt().AsTime(),
+	}
+	if r.GetReviewedAt() != nil {
+		ret.ReviewedAt = timePtr(r.GetReviewedAt().AsTime())
+	}
+	if r.GetReceivedAt() != nil {
+		ret.ReceivedAt = timePtr(r.GetReceivedAt().AsTime())
+	}
+	if r.GetRefundedAt() != nil {
+		ret.RefundedAt = timePtr(r.GetRefundedAt().AsTime())
+	}
+	return ret, nil
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
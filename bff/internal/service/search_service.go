@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	orderv1 "github.com/microserviceteam0/bff-gateway/bff/api/proto/order/v1"
+	"github.com/microserviceteam0/bff-gateway/bff/internal/dto"
+	"github.com/microserviceteam0/bff-gateway/shared/money"
+)
+
+// SearchOrders ищет заказы всех пользователей; проверку роли повторяет order-service
+func (s *bffService) SearchOrders(ctx context.Context, userID int64, userRole string, filter dto.OrderSearchFilterDTO) (*dto.OrderSearchResultDTO, error) {
+	req := &orderv1.SearchOrdersRequest{
+		Statuses:      filter.Statuses,
+		UserId:        filter.UserID,
+		ProductId:     filter.ProductID,
+		MinTotalMinor: filter.MinTotalMinor,
+		MaxTotalMinor: filter.MaxTotalMinor,
+		Query:         filter.Query,
+		SortBy:        filter.SortBy,
+		SortOrder:     filter.SortOrder,
+		PageSize:      filter.PageSize,
+		Cursor:        filter.Cursor,
+	}
+	if !filter.From.IsZero() {
+		req.FromDate = timestamppb.New(filter.From)
+	}
+	if !filter.To.IsZero() {
+		req.ToDate = timestamppb.New(filter.To)
+	}
+
+	ctx = withAuthMetadata(ctx, userID, userRole)
+	resp, err := s.orderClient.SearchOrders(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &dto.OrderSearchResultDTO{
+		Orders:     make([]dto.OrderResponseDTO, 0, len(resp.GetOrders())),
+		NextCursor: resp.GetNextCursor(),
+	}
+	for _, order := range resp.GetOrders() {
+		result.Orders = append(result.Orders, searchOrderToDTO(order))
+	}
+	return result, nil
+}
+
+// searchOrderToDTO собирает заказ без похода в user и product сервисы:
+// названия товаров берутся из позиций, от покупателя известен только ID
+func searchOrderToDTO(order *orderv1.Order) dto.OrderResponseDTO {
+	total := orderTotal(order)
+	resp := dto.OrderResponseDTO{
+		ID:                 order.GetId(),
+		User:               dto.UserSummaryDTO{ID: order.GetUserId()},
+		Status:             order.GetStatus(),
+		TotalSum:           total.Float64(),
+		Subtotal:           dto.NewMoneyDTO(orderSubtotal(order)),
+		Discount:           dto.NewMoneyDTO(money.New(order.GetDiscountMinor(), total.Currency)),
+		Total:              dto.NewMoneyDTO(total),
+		Refunded:           dto.NewMoneyDTO(money.New(order.GetRefundedMinor(), total.Currency)),
+		Promotions:         appliedPromotionsToDTO(order.GetPromotions()),
+		ShippingAddress:    shippingAddressToDTO(order.GetShippingAddress()),
+		DeliveryMethod:     order.GetDeliveryMethod(),
+		CancellationReason: order.GetCancellationReason(),
+		CreatedAt:          order.GetCreatedAt().AsTime(),
+		Version:            order.GetVersion(),
+		Items:              make([]dto.OrderItemDTO, 0, len(order.GetItems())),
+	}
+	for _, item := range order.GetItems() {
+		resp.Items = append(resp.Items, orderItemToDTO(item, item.GetProductName()))
+	}
+	return resp
+}
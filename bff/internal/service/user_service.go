@@ -8,6 +8,7 @@ import (
 	productv1 "github.com/microserviceteam0/bff-gateway/bff/api/proto/product"
 	userv1 "github.com/microserviceteam0/bff-gateway/bff/api/proto/user"
 	"github.com/microserviceteam0/bff-gateway/bff/internal/dto"
+	"github.com/microserviceteam0/bff-gateway/shared/money"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -104,6 +105,7 @@ func (s *bffService) GetUserProfile(ctx context.Context, userID int64, userRole
 	}
 
 	for _, order := range orders {
+		total := orderTotal(order)
 		orderDTO := dto.OrderResponseDTO{
 			ID: order.GetId(),
 			User: dto.UserSummaryDTO{
@@ -111,10 +113,17 @@ func (s *bffService) GetUserProfile(ctx context.Context, userID int64, userRole
 				Name:  user.GetName(),
 				Email: user.GetEmail(),
 			},
-			Status:    order.GetStatus(),
-			TotalSum:  order.GetTotalAmount(),
-			CreatedAt: order.GetCreatedAt().AsTime(),
-			Items:     make([]dto.OrderItemDTO, 0, len(order.GetItems())),
+			Status:             order.GetStatus(),
+			TotalSum:           total.Float64(),
+			Subtotal:           dto.NewMoneyDTO(orderSubtotal(order)),
+			Discount:           dto.NewMoneyDTO(money.New(order.GetDiscountMinor(), total.Currency)),
+			Total:              dto.NewMoneyDTO(total),
+			Promotions:         appliedPromotionsToDTO(order.GetPromotions()),
+			ShippingAddress:    shippingAddressToDTO(order.GetShippingAddress()),
+			DeliveryMethod:     order.GetDeliveryMethod(),
+			CancellationReason: order.GetCancellationReason(),
+			CreatedAt:          order.GetCreatedAt().AsTime(),
+			Items:              make([]dto.OrderItemDTO, 0, len(order.GetItems())),
 		}
 
 		for _, item := range order.GetItems() {
@@ -122,12 +131,7 @@ func (s *bffService) GetUserProfile(ctx context.Context, userID int64, userRole
 			if name, ok := productsMap[item.GetProductId()]; ok {
 				prodName = name
 			}
-			orderDTO.Items = append(orderDTO.Items, dto.OrderItemDTO{
-				ProductID:   item.GetProductId(),
-				ProductName: prodName,
-				Quantity:    item.GetQuantity(),
-				UnitPrice:   item.GetPrice(),
-			})
+			orderDTO.Items = append(orderDTO.Items, orderItemToDTO(item, prodName))
 		}
 		profile.Orders = append(profile.Orders, orderDTO)
 	}
@@ -18,6 +18,7 @@ import (
 	"github.com/microserviceteam0/bff-gateway/bff/internal/handler"
 	"github.com/microserviceteam0/bff-gateway/bff/internal/router"
 	"github.com/microserviceteam0/bff-gateway/bff/internal/service"
+	"github.com/microserviceteam0/bff-gateway/shared/identity"
 	"github.com/microserviceteam0/bff-gateway/shared/metrics"
 	"github.com/redis/go-redis/v9"
 )
@@ -54,22 +55,29 @@ func main() {
 		go monitorRedisPool(rdb, "bff-gateway")
 	}
 
-	// 4. Инициализация gRPC клиентов
-	userConn, err := grpc.NewClient(cfg.UserServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	// 4. Инициализация gRPC клиентов; каждый вызов несет подписанный identity-токен
+	issuer, err := identity.NewIssuer(cfg.InternalAuthSecret, identity.DefaultTTL)
+	if err != nil {
+		slog.Error("failed to init identity issuer, check INTERNAL_AUTH_SECRET", "error", err)
+		os.Exit(1)
+	}
+	identityOpt := grpc.WithUnaryInterceptor(identity.UnaryClientInterceptor(issuer, "bff"))
+
+	userConn, err := grpc.NewClient(cfg.UserServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()), identityOpt)
 	if err != nil {
 		slog.Error("failed to connect to user service", "error", err)
 		os.Exit(1)
 	}
 	defer userConn.Close()
 
-	orderConn, err := grpc.NewClient(cfg.OrderServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	orderConn, err := grpc.NewClient(cfg.OrderServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()), identityOpt)
 	if err != nil {
 		slog.Error("failed to connect to order service", "error", err)
 		os.Exit(1)
 	}
 	defer orderConn.Close()
 
-	productConn, err := grpc.NewClient(cfg.ProductServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	productConn, err := grpc.NewClient(cfg.ProductServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()), identityOpt)
 	if err != nil {
 		slog.Error("failed to connect to product service", "error", err)
 		os.Exit(1)
@@ -1,17 +1,25 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net"
 	"net/http"
+	"order-service/internal/compensation"
 	"order-service/internal/config"
+	"order-service/internal/database"
+	"order-service/internal/expiry"
+	"order-service/internal/gateway"
+	"order-service/internal/invoice"
 	"order-service/internal/middleware"
-	"order-service/internal/model"
+	"order-service/internal/payment"
 	"order-service/internal/repository"
 	"order-service/internal/service"
 	"order-service/pkg/clients/product"
+	"order-service/pkg/clients/user"
 	"os"
 	"os/signal"
 	"syscall"
@@ -20,6 +28,7 @@ import (
 	pb "order-service/api/order/v1"
 
 	"github.com/gin-gonic/gin"
+	"github.com/microserviceteam0/bff-gateway/shared/identity"
 	"github.com/microserviceteam0/bff-gateway/shared/metrics"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
@@ -29,6 +38,15 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "migrate":
+			os.Exit(runMigrate(os.Args[2:]))
+		case "token":
+			os.Exit(runToken(os.Args[2:]))
+		}
+	}
+
 	// 1. Initialize Logger
 	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 		Level: slog.LevelInfo,
@@ -51,16 +69,19 @@ func main() {
 	}
 	slog.Info("Successfully connected to database.")
 
-	// Auto-migrate models
-	err = db.AutoMigrate(&model.Order{}, &model.OrderItem{})
-	if err != nil {
-		slog.Error("Failed to auto-migrate database", "error", err)
+	if err := checkSchema(cfg); err != nil {
+		slog.Error("Database schema is not ready", "error", err)
 		os.Exit(1)
 	}
-	slog.Info("Database auto-migration completed.")
 
 	// 4. Initialize Repository
 	orderRepo := repository.NewOrderRepository(db)
+	promoRepo := repository.NewPromotionRepository(db)
+	paymentRepo := repository.NewPaymentRepository(db)
+	compensationRepo := repository.NewStockCompensationRepository(db)
+	returnRepo := repository.NewReturnRepository(db)
+	invoiceRepo := repository.NewInvoiceRepository(db)
+	templateRepo := repository.NewTemplateRepository(db)
 	slog.Info("Order Repository initialized.")
 
 	// Monitor DB connection pool
@@ -71,8 +92,21 @@ func main() {
 		slog.Error("Failed to get sql.DB from gorm", "error", err)
 	}
 
+	// Identity tokens: incoming calls are verified, outgoing calls are made on behalf of order-service
+	issuer, err := identity.NewIssuer(cfg.InternalAuthSecret, identity.DefaultTTL)
+	if err != nil {
+		slog.Error("Failed to initialize identity issuer, check INTERNAL_AUTH_SECRET", "error", err)
+		os.Exit(1)
+	}
+	identityClientOpt := grpc.WithUnaryInterceptor(identity.UnaryClientInterceptor(issuer, "order-service"))
+	gatewayIssuer, err := gateway.NewTokenIssuer(cfg.InternalAuthSecret, gateway.MaxTokenTTL)
+	if err != nil {
+		slog.Error("Failed to initialize REST token issuer", "error", err)
+		os.Exit(1)
+	}
+
 	// 5. Initialize Product Service Client
-	productClient, err := product.NewClient(cfg.ProductServiceURL)
+	productClient, err := product.NewClient(cfg.ProductServiceURL, identityClientOpt)
 	if err != nil {
 		slog.Error("Failed to initialize Product Service client", "error", err)
 		os.Exit(1)
@@ -80,12 +114,62 @@ func main() {
 	defer productClient.Close()
 	slog.Info("Product Service client initialized.")
 
+	userClient, err := user.NewClient(cfg.UserServiceURL, identityClientOpt)
+	if err != nil {
+		slog.Error("Failed to initialize User Service client", "error", err)
+		os.Exit(1)
+	}
+	defer userClient.Close()
+	slog.Info("User Service client initialized.")
+
+	paymentProvider, err := newPaymentProvider(cfg.PaymentProvider)
+	if err != nil {
+		slog.Error("Failed to initialize payment provider", "error", err)
+		os.Exit(1)
+	}
+	slog.Info("Payment provider initialized.", "provider", paymentProvider.Name())
+
+	invoices, err := invoice.NewRenderer(invoice.Seller{
+		Name:    cfg.InvoiceSellerName,
+		Address: cfg.InvoiceSellerAddress,
+		TaxID:   cfg.InvoiceSellerTaxID,
+	}, cfg.InvoiceTaxRatePercent)
+	if err != nil {
+		slog.Error("Failed to initialize invoice renderer", "error", err)
+		os.Exit(1)
+	}
+
 	// 6. Initialize Service
-	orderService := service.NewOrderService(orderRepo, productClient)
+	orderService := service.NewOrderService(orderRepo, promoRepo, paymentRepo, compensationRepo, returnRepo, invoiceRepo, invoices, templateRepo, productClient, userClient, paymentProvider)
 	slog.Info("Order Service initialized.")
 
-	// 7. Start Monitoring Server (Gin)
-	startMonitoringServer(cfg.MonitoringPort)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The fake provider delivers asynchronous events in process, without HTTP
+	if fake, ok := paymentProvider.(*payment.FakeProvider); ok {
+		go fake.Run(ctx, time.Second, orderService.HandlePaymentEvent)
+	}
+
+	go compensation.NewWorker(compensationRepo, productClient, compensation.Config{
+		Interval:    cfg.StockCompensationInterval,
+		MaxAttempts: int32(cfg.StockCompensationMaxAttempts),
+	}).Run(ctx)
+
+	if cfg.OrderExpiryTTL > 0 {
+		go expiry.NewWorker(orderService, expiry.Config{
+			TTL:       cfg.OrderExpiryTTL,
+			Interval:  cfg.OrderExpiryInterval,
+			BatchSize: cfg.OrderExpiryBatchSize,
+			DryRun:    cfg.OrderExpiryDryRun,
+		}).Run(ctx)
+	} else {
+		slog.Info("Order expiry is disabled (ORDER_EXPIRY_TTL=0)")
+	}
+
+	// 7. Start Monitoring Server (Gin) with the REST facade
+	startMonitoringServer(cfg.MonitoringPort, cfg.PaymentWebhookSecret, orderService.HandlePaymentEvent,
+		gateway.NewHandler(orderService, gatewayIssuer))
 
 	// 8. Setup gRPC Server
 	lis, err := net.Listen("tcp", ":"+cfg.GRPCPort)
@@ -96,7 +180,10 @@ func main() {
 	slog.Info("gRPC server listening", "address", lis.Addr().String())
 
 	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(metrics.GRPCUnaryServerInterceptor("order-service")),
+		grpc.ChainUnaryInterceptor(
+			metrics.GRPCUnaryServerInterceptor("order-service"),
+			identity.UnaryServerInterceptor(issuer),
+		),
 	)
 	pb.RegisterOrderServiceServer(grpcServer, &service.GRPCServer{Service: orderService})
 	reflection.Register(grpcServer)
@@ -115,11 +202,21 @@ func main() {
 	<-quit
 	slog.Info("Shutdown signal received, stopping servers...")
 
+	cancel()
 	grpcServer.GracefulStop()
 	slog.Info("Servers stopped gracefully")
 }
 
-func startMonitoringServer(port string) {
+func newPaymentProvider(name string) (payment.PaymentProvider, error) {
+	switch name {
+	case "fake":
+		return payment.NewFakeProvider(), nil
+	default:
+		return nil, fmt.Errorf("unknown payment provider %q", name)
+	}
+}
+
+func startMonitoringServer(port, webhookSecret string, handlePaymentEvent payment.EventHandler, api *gateway.Handler) {
 	gin.SetMode(gin.ReleaseMode)
 	router := gin.New()
 	router.Use(gin.Recovery())
@@ -132,6 +229,15 @@ func startMonitoringServer(port string) {
 
 	router.GET("/metrics", gin.WrapH(promhttp.Handler()))
 
+	router.StaticFile("/api/openapi.yaml", "api/openapi.yaml")
+	api.RegisterRoutes(router)
+
+	if webhookSecret != "" {
+		router.POST("/webhooks/payments", payment.WebhookHandler(webhookSecret, handlePaymentEvent))
+	} else {
+		slog.Warn("PAYMENT_WEBHOOK_SECRET is not set, payment webhook endpoint is disabled")
+	}
+
 	server := &http.Server{
 		Addr:    ":" + port,
 		Handler: router,
@@ -167,3 +273,26 @@ func monitorConnectionPool(db *sql.DB, serviceName string) {
 		)
 	}
 }
+
+// checkSchema applies the migrations when AUTO_MIGRATE is set, then refuses to start
+// on a dirty or outdated schema
+func checkSchema(cfg *config.Config) error {
+	migrator, err := database.NewMigrator(cfg.DatabaseURL, cfg.MigrationsPath)
+	if err != nil {
+		return err
+	}
+	defer migrator.Close()
+
+	if cfg.AutoMigrate {
+		if err := migrator.Up(); err != nil {
+			return err
+		}
+	}
+	if err := migrator.Check(); err != nil {
+		return err
+	}
+
+	version, _, _ := migrator.Version()
+	slog.Info("Database schema is up to date.", "version", version)
+	return nil
+}
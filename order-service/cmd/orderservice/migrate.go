@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"order-service/internal/config"
+	"order-service/internal/database"
+	"os"
+	"strconv"
+)
+
+const migrateUsage = `usage: orderservice migrate <command>
+
+commands:
+  up            apply all pending migrations
+  down [N]      roll back the last N migrations (default 1)
+  version       print the current schema version
+  force V       set version V and clear the dirty flag without running migrations`
+
+// runMigrate выполняет подкоманду migrate и возвращает код выхода процесса
+func runMigrate(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+
+	cfg := config.Load()
+	migrator, err := database.NewMigrator(cfg.DatabaseURL, cfg.MigrationsPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	defer migrator.Close()
+
+	switch args[0] {
+	case "up":
+		err = migrator.Up()
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			if steps, err = strconv.Atoi(args[1]); err != nil {
+				fmt.Fprintf(os.Stderr, "invalid number of steps %q\n", args[1])
+				return 2
+			}
+		}
+		err = migrator.Down(steps)
+	case "force":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, migrateUsage)
+			return 2
+		}
+		version, perr := strconv.Atoi(args[1])
+		if perr != nil {
+			fmt.Fprintf(os.Stderr, "invalid version %q\n", args[1])
+			return 2
+		}
+		err = migrator.Force(version)
+	case "version":
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	version, dirty, err := migrator.Version()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Printf("version: %d, dirty: %t\n", version, dirty)
+	return 0
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"order-service/internal/config"
+	"order-service/internal/gateway"
+	"os"
+	"time"
+
+	"github.com/microserviceteam0/bff-gateway/shared/identity"
+)
+
+// runToken выпускает токен REST API (скрипты эксплуатации, QA) и возвращает код выхода процесса.
+// Токен подходит только для REST-фасада, gRPC-сервисы его не принимают
+func runToken(args []string) int {
+	fs := flag.NewFlagSet("token", flag.ContinueOnError)
+	fs.SetOutput(os.Stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: orderservice token -user-id N [-role user|admin] [-ttl 1h]")
+		fs.PrintDefaults()
+	}
+	userID := fs.Int64("user-id", 0, "user the token acts for; admins also need their own ID")
+	role := fs.String("role", identity.RoleAdmin, "role of the caller")
+	ttl := fs.Duration("ttl", time.Hour, fmt.Sprintf("token lifetime, at most %s", gateway.MaxTokenTTL))
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	// Заказы работают только с пользователями, сервисный токен REST API бесполезен
+	if *role != identity.RoleUser && *role != identity.RoleAdmin {
+		fmt.Fprintf(os.Stderr, "unknown role %q\n", *role)
+		return 2
+	}
+	if *userID <= 0 {
+		fmt.Fprintln(os.Stderr, "-user-id is required")
+		return 2
+	}
+	if *ttl > gateway.MaxTokenTTL {
+		fmt.Fprintf(os.Stderr, "-ttl must not exceed %s\n", gateway.MaxTokenTTL)
+		return 2
+	}
+
+	issuer, err := gateway.NewTokenIssuer(config.Load().InternalAuthSecret, *ttl)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	token, err := issuer.Sign(identity.Principal{UserID: *userID, Role: *role, Service: "order-service-cli"})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Println(token)
+	return 0
+}
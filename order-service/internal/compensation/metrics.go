@@ -0,0 +1,30 @@
+package compensation
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	pending = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "stock_compensations_pending",
+			Help: "Number of stock changes waiting to be retried",
+		},
+	)
+
+	deadLetters = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "stock_compensations_dead",
+			Help: "Number of unresolved stock changes that ran out of attempts",
+		},
+	)
+
+	attemptsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "stock_compensation_attempts_total",
+			Help: "Total number of stock compensation retries",
+		},
+		[]string{"result"},
+	)
+)
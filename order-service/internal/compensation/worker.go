@@ -0,0 +1,150 @@
+// Package compensation повторяет изменения остатков, которые не удалось применить сразу
+// (возврат товара при отмене заказа, откат резерва). Без повторов склад расходится с заказами.
+package compensation
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"order-service/internal/repository"
+
+	productpb "order-service/pkg/api/product/v1"
+)
+
+const (
+	baseDelay = 5 * time.Second
+	maxDelay  = time.Hour
+)
+
+// Backoff возвращает задержку перед следующей попыткой после attempts неудачных
+func Backoff(attempts int32) time.Duration {
+	delay := baseDelay
+	for i := int32(1); i < attempts; i++ {
+		delay *= 2
+		if delay >= maxDelay {
+			return maxDelay
+		}
+	}
+	return delay
+}
+
+type StockUpdater interface {
+	UpdateStock(ctx context.Context, productID, variantID int64, quantityDelta int32, reason string, orderID int64) (*productpb.UpdateStockResponse, error)
+}
+
+type Config struct {
+	Interval  time.Duration
+	BatchSize int
+	// MaxAttempts — после стольких неудач компенсация уходит в dead-letter
+	MaxAttempts int32
+	// Lease — на сколько откладывается взятая в работу компенсация, чтобы ее не взяла другая реплика
+	Lease time.Duration
+}
+
+type Worker struct {
+	repo  repository.StockCompensationRepository
+	stock StockUpdater
+	cfg   Config
+	now   func() time.Time
+}
+
+func NewWorker(repo repository.StockCompensationRepository, stock StockUpdater, cfg Config) *Worker {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 10 * time.Second
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 10
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = time.Minute
+	}
+	return &Worker{repo: repo, stock: stock, cfg: cfg, now: time.Now}
+}
+
+// Run обрабатывает очередь раз в Interval до отмены контекста
+func (w *Worker) Run(ctx context.Context) {
+	slog.Info("Stock compensation worker started", "interval", w.cfg.Interval, "max_attempts", w.cfg.MaxAttempts)
+
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := w.RunOnce(ctx); err != nil {
+				slog.Error("Stock compensation run failed", "error", err)
+			}
+		}
+	}
+}
+
+// RunOnce повторяет компенсации, срок которых наступил, и возвращает число успешных.
+// Доставка «как минимум один раз»: если остаток изменился, а удалить запись не удалось, изменение повторится
+func (w *Worker) RunOnce(ctx context.Context) (int, error) {
+	defer w.updateGauges(ctx)
+
+	now := w.now()
+	due, err := w.repo.ClaimDue(ctx, now, w.cfg.BatchSize, w.cfg.Lease)
+	if err != nil {
+		return 0, err
+	}
+
+	succeeded := 0
+	for i := range due {
+		c := &due[i]
+
+		_, err := w.stock.UpdateStock(ctx, c.ProductID, c.VariantID, c.QuantityDelta, c.Reason, c.OrderID)
+		if err == nil {
+			attemptsTotal.WithLabelValues("success").Inc()
+			succeeded++
+			slog.Info("Stock compensation applied", "id", c.ID, "order_id", c.OrderID, "product_id", c.ProductID, "variant_id", c.VariantID, "quantity_delta", c.QuantityDelta)
+			if err := w.repo.Delete(ctx, c.ID); err != nil {
+				slog.Error("Failed to delete applied stock compensation", "id", c.ID, "error", err)
+			}
+			continue
+		}
+
+		c.Attempts++
+		c.LastError = err.Error()
+
+		if c.Attempts >= w.cfg.MaxAttempts {
+			attemptsTotal.WithLabelValues("dead").Inc()
+			slog.Error("Stock compensation moved to dead letter",
+				"id", c.ID,
+				"order_id", c.OrderID,
+				"product_id", c.ProductID,
+				"quantity_delta", c.QuantityDelta,
+				"attempts", c.Attempts,
+				"error", err,
+			)
+			if err := w.repo.MoveToDeadLetter(ctx, c.ID, c.Dead(now)); err != nil {
+				slog.Error("Failed to move stock compensation to dead letter", "id", c.ID, "error", err)
+			}
+			continue
+		}
+
+		attemptsTotal.WithLabelValues("retry").Inc()
+		c.NextAttemptAt = now.Add(Backoff(c.Attempts))
+		if err := w.repo.Save(ctx, c); err != nil {
+			slog.Error("Failed to reschedule stock compensation", "id", c.ID, "error", err)
+		}
+	}
+
+	return succeeded, nil
+}
+
+func (w *Worker) updateGauges(ctx context.Context) {
+	queued, dead, err := w.repo.Count(ctx)
+	if err != nil {
+		slog.Warn("Failed to count stock compensations", "error", err)
+		return
+	}
+	pending.Set(float64(queued))
+	deadLetters.Set(float64(dead))
+}
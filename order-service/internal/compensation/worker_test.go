@@ -0,0 +1,108 @@
+package compensation
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"order-service/internal/model"
+	"order-service/internal/repository"
+
+	productpb "order-service/pkg/api/product/v1"
+)
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int32
+		expected time.Duration
+	}{
+		{1, 5 * time.Second},
+		{2, 10 * time.Second},
+		{5, 80 * time.Second},
+		{20, time.Hour},
+	}
+	for _, tt := range tests {
+		if got := Backoff(tt.attempts); got != tt.expected {
+			t.Errorf("Backoff(%d): expected %v, got %v", tt.attempts, tt.expected, got)
+		}
+	}
+}
+
+type mockRepo struct {
+	repository.StockCompensationRepository
+	due     []model.StockCompensation
+	saved   []model.StockCompensation
+	deleted []int64
+	dead    []model.DeadStockCompensation
+}
+
+func (m *mockRepo) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]model.StockCompensation, error) {
+	return m.due, nil
+}
+
+func (m *mockRepo) Save(ctx context.Context, c *model.StockCompensation) error {
+	m.saved = append(m.saved, *c)
+	return nil
+}
+
+func (m *mockRepo) Delete(ctx context.Context, id int64) error {
+	m.deleted = append(m.deleted, id)
+	return nil
+}
+
+func (m *mockRepo) MoveToDeadLetter(ctx context.Context, id int64, dead *model.DeadStockCompensation) error {
+	m.deleted = append(m.deleted, id)
+	m.dead = append(m.dead, *dead)
+	return nil
+}
+
+func (m *mockRepo) Count(ctx context.Context) (int64, int64, error) {
+	return 0, 0, nil
+}
+
+type stockFunc func(ctx context.Context, productID, variantID int64, quantityDelta int32, reason string, orderID int64) (*productpb.UpdateStockResponse, error)
+
+func (f stockFunc) UpdateStock(ctx context.Context, productID, variantID int64, quantityDelta int32, reason string, orderID int64) (*productpb.UpdateStockResponse, error) {
+	return f(ctx, productID, variantID, quantityDelta, reason, orderID)
+}
+
+func TestRunOnce(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	repo := &mockRepo{due: []model.StockCompensation{
+		{ID: 1, ProductID: 101, QuantityDelta: 2, Attempts: 1},
+		{ID: 2, ProductID: 102, QuantityDelta: 1, Attempts: 1},
+		{ID: 3, ProductID: 102, QuantityDelta: 4, Attempts: 4},
+	}}
+	stock := stockFunc(func(ctx context.Context, productID, variantID int64, quantityDelta int32, reason string, orderID int64) (*productpb.UpdateStockResponse, error) {
+		if productID == 102 {
+			return nil, errors.New("unavailable")
+		}
+		return &productpb.UpdateStockResponse{}, nil
+	})
+
+	w := NewWorker(repo, stock, Config{MaxAttempts: 5})
+	w.now = func() time.Time { return now }
+
+	succeeded, err := w.RunOnce(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if succeeded != 1 {
+		t.Errorf("expected 1 success, got %d", succeeded)
+	}
+
+	if len(repo.saved) != 1 || repo.saved[0].ID != 2 || repo.saved[0].Attempts != 2 {
+		t.Fatalf("expected compensation 2 rescheduled with 2 attempts, got %+v", repo.saved)
+	}
+	if want := now.Add(10 * time.Second); !repo.saved[0].NextAttemptAt.Equal(want) {
+		t.Errorf("expected next attempt at %v, got %v", want, repo.saved[0].NextAttemptAt)
+	}
+
+	if len(repo.dead) != 1 || repo.dead[0].ProductID != 102 || repo.dead[0].Attempts != 5 || repo.dead[0].LastError != "unavailable" {
+		t.Fatalf("expected compensation 3 in dead letter, got %+v", repo.dead)
+	}
+	if len(repo.deleted) != 2 || repo.deleted[0] != 1 || repo.deleted[1] != 3 {
+		t.Errorf("expected compensations 1 and 3 removed from queue, got %v", repo.deleted)
+	}
+}
@@ -0,0 +1,132 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+)
+
+// ErrSchemaDirty — предыдущая миграция упала на середине; нужен ручной разбор и migrate force
+var ErrSchemaDirty = errors.New("database schema is dirty")
+
+// ErrSchemaOutdated — в базе применены не все миграции из каталога
+var ErrSchemaOutdated = errors.New("database schema is outdated")
+
+// Migrator управляет версионированными миграциями из каталога migrationsPath.
+// Открывает собственное соединение, поэтому Close не трогает пул gorm
+type Migrator struct {
+	m    *migrate.Migrate
+	path string
+}
+
+func NewMigrator(databaseURL, migrationsPath string) (*Migrator, error) {
+	m, err := migrate.New("file://"+migrationsPath, databaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("create migrate instance: %w", err)
+	}
+	return &Migrator{m: m, path: migrationsPath}, nil
+}
+
+// Up применяет все непримененные миграции. Драйвер postgres держит advisory lock,
+// поэтому одновременный запуск нескольких реплик безопасен
+func (mg *Migrator) Up() error {
+	if err := mg.m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("apply migrations: %w", err)
+	}
+	return nil
+}
+
+// Down откатывает steps последних миграций
+func (mg *Migrator) Down(steps int) error {
+	if steps <= 0 {
+		return fmt.Errorf("steps must be positive, got %d", steps)
+	}
+	if err := mg.m.Steps(-steps); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("rollback migrations: %w", err)
+	}
+	return nil
+}
+
+// Force выставляет версию и снимает флаг dirty без выполнения миграций
+func (mg *Migrator) Force(version int) error {
+	if err := mg.m.Force(version); err != nil {
+		return fmt.Errorf("force version %d: %w", version, err)
+	}
+	return nil
+}
+
+// Version возвращает текущую версию схемы; 0 — миграции еще не применялись
+func (mg *Migrator) Version() (uint, bool, error) {
+	version, dirty, err := mg.m.Version()
+	if errors.Is(err, migrate.ErrNilVersion) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("get migration version: %w", err)
+	}
+	return version, dirty, nil
+}
+
+// Check проверяет, что схема чистая и соответствует последней миграции из каталога
+func (mg *Migrator) Check() error {
+	version, dirty, err := mg.Version()
+	if err != nil {
+		return err
+	}
+	if dirty {
+		return fmt.Errorf("%w: version %d", ErrSchemaDirty, version)
+	}
+
+	latest, err := LatestVersion(mg.path)
+	if err != nil {
+		return err
+	}
+	if version < latest {
+		return fmt.Errorf("%w: version %d, latest %d", ErrSchemaOutdated, version, latest)
+	}
+	if version > latest {
+		// База новее кода — например, при откате релиза. Старый код обычно совместим с добавленными колонками
+		slog.Warn("Database schema is newer than known migrations", "version", version, "latest", latest)
+	}
+	return nil
+}
+
+func (mg *Migrator) Close() {
+	srcErr, dbErr := mg.m.Close()
+	if srcErr != nil || dbErr != nil {
+		slog.Warn("Failed to close migrator", "source_error", srcErr, "database_error", dbErr)
+	}
+}
+
+// LatestVersion возвращает номер последней миграции в каталоге
+func LatestVersion(migrationsPath string) (uint, error) {
+	src, err := source.Open("file://" + migrationsPath)
+	if err != nil {
+		return 0, fmt.Errorf("open migrations: %w", err)
+	}
+	defer src.Close()
+
+	version, err := src.First()
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("read migrations: %w", err)
+	}
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("read migrations: %w", err)
+		}
+		version = next
+	}
+}
@@ -0,0 +1,154 @@
+package database_test
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"order-service/internal/database"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestLatestVersion(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"001_initial_schema.up.sql", "001_initial_schema.down.sql",
+		"002_add_versions.up.sql", "002_add_versions.down.sql",
+		"010_add_invoices.up.sql",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("SELECT 1;"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	latest, err := database.LatestVersion(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if latest != 10 {
+		t.Errorf("expected latest version 10, got %d", latest)
+	}
+
+	empty, err := database.LatestVersion(t.TempDir())
+	if err != nil || empty != 0 {
+		t.Errorf("expected 0 for empty dir, got %d, %v", empty, err)
+	}
+}
+
+func TestRepositoryMigrationsArePaired(t *testing.T) {
+	ups, _ := filepath.Glob("../../migrations/*.up.sql")
+	if len(ups) == 0 {
+		t.Fatal("no migrations found")
+	}
+	for _, up := range ups {
+		down := up[:len(up)-len(".up.sql")] + ".down.sql"
+		if _, err := os.Stat(down); err != nil {
+			t.Errorf("migration %s has no down file", filepath.Base(up))
+		}
+	}
+
+	if _, err := database.LatestVersion("../../migrations"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+// baselineSchema — таблицы, которые создавал исходный AutoMigrate до появления миграций
+const baselineSchema = `
+CREATE TABLE orders (
+    id           BIGSERIAL PRIMARY KEY,
+    user_id      BIGINT         NOT NULL,
+    status       VARCHAR(50)    NOT NULL DEFAULT 'created',
+    total_amount DECIMAL(15, 2) NOT NULL,
+    created_at   TIMESTAMPTZ,
+    updated_at   TIMESTAMPTZ
+);
+CREATE INDEX idx_orders_user_id ON orders (user_id);
+CREATE TABLE order_items (
+    id           BIGSERIAL PRIMARY KEY,
+    order_id     BIGINT         NOT NULL,
+    product_id   BIGINT         NOT NULL,
+    product_name TEXT           NOT NULL,
+    price        DECIMAL(15, 2) NOT NULL,
+    quantity     INTEGER        NOT NULL,
+    CONSTRAINT fk_orders_items FOREIGN KEY (order_id) REFERENCES orders (id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+CREATE INDEX idx_order_items_order_id ON order_items (order_id);
+INSERT INTO orders (id, user_id, status, total_amount, created_at, updated_at) VALUES (1, 7, 'completed', 12.34, NOW(), NOW());
+INSERT INTO order_items (order_id, product_id, product_name, price, quantity) VALUES (1, 101, 'Legacy product', 6.17, 2);
+`
+
+// TestMigrationsUpgradeBaselineSchema применяет миграции к базе, созданной исходным AutoMigrate.
+// Нужен PostgreSQL в ORDER_SERVICE_TEST_DATABASE_URL; тест работает в отдельной схеме и удаляет ее
+func TestMigrationsUpgradeBaselineSchema(t *testing.T) {
+	databaseURL := os.Getenv("ORDER_SERVICE_TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("ORDER_SERVICE_TEST_DATABASE_URL is not set")
+	}
+
+	admin, err := gorm.Open(postgres.Open(databaseURL), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	schema := fmt.Sprintf("migrations_test_%d", time.Now().UnixNano())
+	if err := admin.Exec("CREATE SCHEMA " + schema).Error; err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+	})
+
+	u, err := url.Parse(databaseURL)
+	if err != nil {
+		t.Fatalf("parse database url: %v", err)
+	}
+	q := u.Query()
+	q.Set("search_path", schema)
+	u.RawQuery = q.Encode()
+	schemaURL := u.String()
+
+	db, err := gorm.Open(postgres.Open(schemaURL), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("connect to schema: %v", err)
+	}
+	if err := db.Exec(baselineSchema).Error; err != nil {
+		t.Fatalf("create baseline schema: %v", err)
+	}
+
+	mg, err := database.NewMigrator(schemaURL, "../../migrations")
+	if err != nil {
+		t.Fatalf("NewMigrator: %v", err)
+	}
+	defer mg.Close()
+	if err := mg.Up(); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	if err := mg.Check(); err != nil {
+		t.Fatalf("Check: %v", err)
+	}
+
+	var order struct {
+		TotalAmountMinor int64
+		SubtotalMinor    int64
+		Currency         string
+		Version          int64
+	}
+	if err := db.Raw("SELECT total_amount_minor, subtotal_minor, currency, version FROM orders WHERE id = 1").Scan(&order).Error; err != nil {
+		t.Fatalf("read order: %v", err)
+	}
+	if order.TotalAmountMinor != 1234 || order.SubtotalMinor != 1234 || order.Currency != "RUB" || order.Version != 1 {
+		t.Errorf("unexpected upgraded order %+v", order)
+	}
+
+	var priceMinor int64
+	if err := db.Raw("SELECT price_minor FROM order_items WHERE order_id = 1").Scan(&priceMinor).Error; err != nil {
+		t.Fatalf("read order item: %v", err)
+	}
+	if priceMinor != 617 {
+		t.Errorf("expected price_minor 617, got %d", priceMinor)
+	}
+}
@@ -0,0 +1,38 @@
+package expiry
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	runsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "order_expiry_runs_total",
+			Help: "Total number of order expiry runs",
+		},
+		[]string{"result"},
+	)
+
+	ordersExpired = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "order_expiry_orders_expired_total",
+			Help: "Total number of pending orders cancelled as expired",
+		},
+	)
+
+	candidates = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "order_expiry_candidates",
+			Help: "Number of stale pending orders found by the last run (dry-run included)",
+		},
+	)
+
+	runDuration = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "order_expiry_run_duration_seconds",
+			Help:    "Order expiry run latency in seconds",
+			Buckets: []float64{.01, .05, .1, .25, .5, 1, 2.5, 5, 10},
+		},
+	)
+)
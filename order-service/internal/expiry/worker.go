@@ -0,0 +1,106 @@
+// Package expiry отменяет ожидающие заказы, которые не оплатили за отведенное время,
+// чтобы брошенные заказы не держали товар на складе.
+package expiry
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// maxBatchesPerRun ограничивает работу одного запуска, чтобы большой хвост разбирался постепенно
+const maxBatchesPerRun = 10
+
+// Expirer отменяет устаревшие заказы; реализуется сервисом заказов
+type Expirer interface {
+	ExpireStaleOrders(ctx context.Context, cutoff time.Time, limit int, dryRun bool) ([]int64, error)
+}
+
+type Config struct {
+	// TTL — сколько заказ может оставаться в статусе pending
+	TTL      time.Duration
+	Interval time.Duration
+	// BatchSize — сколько заказов отменяется в одной транзакции
+	BatchSize int
+	// DryRun только логирует заказы, которые были бы отменены
+	DryRun bool
+}
+
+type Worker struct {
+	expirer Expirer
+	cfg     Config
+	now     func() time.Time
+}
+
+func NewWorker(expirer Expirer, cfg Config) *Worker {
+	if cfg.Interval <= 0 {
+		cfg.Interval = time.Minute
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	return &Worker{expirer: expirer, cfg: cfg, now: time.Now}
+}
+
+// Run запускает отмену раз в Interval до отмены контекста
+func (w *Worker) Run(ctx context.Context) {
+	slog.Info("Order expiry worker started",
+		"ttl", w.cfg.TTL,
+		"interval", w.cfg.Interval,
+		"batch_size", w.cfg.BatchSize,
+		"dry_run", w.cfg.DryRun,
+	)
+
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := w.RunOnce(ctx); err != nil {
+				slog.Error("Order expiry run failed", "error", err)
+			}
+		}
+	}
+}
+
+// RunOnce обрабатывает устаревшие заказы пачками и возвращает их количество.
+// В режиме dry-run выполняется одна выборка: без отмены следующая пачка вернула бы те же заказы
+func (w *Worker) RunOnce(ctx context.Context) (int, error) {
+	start := w.now()
+	defer func() {
+		runDuration.Observe(time.Since(start).Seconds())
+	}()
+
+	cutoff := start.Add(-w.cfg.TTL)
+	total := 0
+	for batch := 0; batch < maxBatchesPerRun; batch++ {
+		ids, err := w.expirer.ExpireStaleOrders(ctx, cutoff, w.cfg.BatchSize, w.cfg.DryRun)
+		if err != nil {
+			runsTotal.WithLabelValues("error").Inc()
+			return total, err
+		}
+
+		total += len(ids)
+		if !w.cfg.DryRun {
+			ordersExpired.Add(float64(len(ids)))
+		}
+		if w.cfg.DryRun || len(ids) < w.cfg.BatchSize {
+			break
+		}
+	}
+
+	candidates.Set(float64(total))
+	if w.cfg.DryRun {
+		runsTotal.WithLabelValues("dry_run").Inc()
+	} else {
+		runsTotal.WithLabelValues("success").Inc()
+	}
+
+	if total > 0 {
+		slog.Info("Order expiry run finished", "orders", total, "dry_run", w.cfg.DryRun)
+	}
+	return total, nil
+}
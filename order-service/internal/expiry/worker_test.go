@@ -0,0 +1,81 @@
+package expiry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type mockExpirer struct {
+	batches [][]int64
+	calls   int
+	dryRuns []bool
+	err     error
+}
+
+func (m *mockExpirer) ExpireStaleOrders(ctx context.Context, cutoff time.Time, limit int, dryRun bool) ([]int64, error) {
+	m.dryRuns = append(m.dryRuns, dryRun)
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.calls >= len(m.batches) {
+		return nil, nil
+	}
+	batch := m.batches[m.calls]
+	m.calls++
+	return batch, nil
+}
+
+func TestRunOnce(t *testing.T) {
+	tests := []struct {
+		name          string
+		batches       [][]int64
+		dryRun        bool
+		err           error
+		expectedTotal int
+		expectedCalls int
+	}{
+		{
+			name:          "Drains full batches until a short one",
+			batches:       [][]int64{{1, 2}, {3, 4}, {5}},
+			expectedTotal: 5,
+			expectedCalls: 3,
+		},
+		{
+			name:          "Dry run looks only once",
+			batches:       [][]int64{{1, 2}, {3, 4}},
+			dryRun:        true,
+			expectedTotal: 2,
+			expectedCalls: 1,
+		},
+		{
+			name:          "Error stops the run",
+			err:           errors.New("db down"),
+			expectedCalls: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mockExpirer{batches: tt.batches, err: tt.err}
+			w := NewWorker(m, Config{TTL: time.Minute, BatchSize: 2, DryRun: tt.dryRun})
+
+			total, err := w.RunOnce(context.Background())
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected error %v, got %v", tt.err, err)
+			}
+			if total != tt.expectedTotal {
+				t.Errorf("expected %d orders, got %d", tt.expectedTotal, total)
+			}
+			if len(m.dryRuns) != tt.expectedCalls {
+				t.Errorf("expected %d calls, got %d", tt.expectedCalls, len(m.dryRuns))
+			}
+			for _, d := range m.dryRuns {
+				if d != tt.dryRun {
+					t.Errorf("expected dryRun=%v to be passed through", tt.dryRun)
+				}
+			}
+		})
+	}
+}
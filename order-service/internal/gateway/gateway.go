@@ -0,0 +1,267 @@
+// Package gateway — REST/JSON фасад над OrderService для скриптов эксплуатации и QA.
+// Запросы и ответы — это сообщения order.v1 в JSON (protojson, имена полей как в .proto),
+// поэтому фасад не расходится с gRPC API; вызовы идут в ту же реализацию OrderService.
+package gateway
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "order-service/api/order/v1"
+	"order-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/microserviceteam0/bff-gateway/shared/identity"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxBodySize ограничивает тело запроса: заказ из сотни позиций укладывается с запасом
+const maxBodySize = 1 << 20
+
+var (
+	marshalOptions   = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+	unmarshalOptions = protojson.UnmarshalOptions{}
+)
+
+type Handler struct {
+	service service.OrderService
+	issuer  *identity.Issuer
+}
+
+func NewHandler(svc service.OrderService, issuer *identity.Issuer) *Handler {
+	return &Handler{service: svc, issuer: issuer}
+}
+
+// RegisterRoutes подключает маршруты /api/v1/...; все они требуют identity-токен
+func (h *Handler) RegisterRoutes(r gin.IRouter) {
+	api := r.Group("/api/v1", h.authMiddleware())
+
+	api.POST("/orders", h.CreateOrder)
+	api.GET("/orders/:id", h.GetOrder)
+	api.PATCH("/orders/:id", h.UpdateOrder)
+	api.POST("/orders/:id/cancel", h.CancelOrder)
+	api.PUT("/orders/:id/items", h.UpdateOrderItems)
+	api.GET("/users/:user_id/orders", h.GetUserOrders)
+	api.GET("/users/:user_id/orders/stats", h.GetOrderStats)
+}
+
+// CreateOrder — POST /api/v1/orders, тело CreateOrderRequest
+func (h *Handler) CreateOrder(c *gin.Context) {
+	req := &pb.CreateOrderRequest{}
+	if !bindProto(c, req) {
+		return
+	}
+
+	resp, err := h.service.CreateOrder(c.Request.Context(), req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.Header("Location", fmt.Sprintf("/api/v1/orders/%d", resp.GetOrderId()))
+	writeProto(c, http.StatusCreated, resp)
+}
+
+// GetOrder — GET /api/v1/orders/{id}; версия заказа отдается в ETag
+func (h *Handler) GetOrder(c *gin.Context) {
+	orderID, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+
+	resp, err := h.service.GetOrder(c.Request.Context(), &pb.GetOrderRequest{OrderId: orderID})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	setVersionETag(c, resp.GetOrder().GetVersion())
+	writeProto(c, http.StatusOK, resp.GetOrder())
+}
+
+// UpdateOrder — PATCH /api/v1/orders/{id}, тело UpdateOrderRequest (status, cancellation_reason)
+func (h *Handler) UpdateOrder(c *gin.Context) {
+	orderID, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	req := &pb.UpdateOrderRequest{}
+	if !bindProto(c, req) {
+		return
+	}
+	req.OrderId = orderID
+	if !applyIfMatch(c, &req.ExpectedVersion) {
+		return
+	}
+
+	if _, err := h.service.UpdateOrder(c.Request.Context(), req); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// CancelOrder — POST /api/v1/orders/{id}/cancel, тело CancelOrderRequest (reason) необязательно
+func (h *Handler) CancelOrder(c *gin.Context) {
+	orderID, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	req := &pb.CancelOrderRequest{}
+	if !bindProto(c, req) {
+		return
+	}
+	req.OrderId = orderID
+	if !applyIfMatch(c, &req.ExpectedVersion) {
+		return
+	}
+
+	if _, err := h.service.CancelOrder(c.Request.Context(), req); err != nil {
+		writeError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// UpdateOrderItems — PUT /api/v1/orders/{id}/items, тело UpdateOrderItemsRequest (items)
+func (h *Handler) UpdateOrderItems(c *gin.Context) {
+	orderID, ok := pathID(c, "id")
+	if !ok {
+		return
+	}
+	req := &pb.UpdateOrderItemsRequest{}
+	if !bindProto(c, req) {
+		return
+	}
+	req.OrderId = orderID
+	if !applyIfMatch(c, &req.ExpectedVersion) {
+		return
+	}
+
+	resp, err := h.service.UpdateOrderItems(c.Request.Context(), req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	setVersionETag(c, resp.GetOrder().GetVersion())
+	writeProto(c, http.StatusOK, resp.GetOrder())
+}
+
+// GetUserOrders — GET /api/v1/users/{user_id}/orders?page=&page_size=&status=&from_date=&to_date=
+func (h *Handler) GetUserOrders(c *gin.Context) {
+	userID, ok := pathID(c, "user_id")
+	if !ok {
+		return
+	}
+
+	req := &pb.GetUserOrdersRequest{UserId: userID}
+	var err error
+	if req.Page, err = queryInt32(c, "page"); err != nil {
+		writeBadRequest(c, err.Error())
+		return
+	}
+	if req.PageSize, err = queryInt32(c, "page_size"); err != nil {
+		writeBadRequest(c, err.Error())
+		return
+	}
+	if s, ok := c.GetQuery("status"); ok {
+		req.Status = &s
+	}
+	if req.FromDate, err = queryTime(c, "from_date"); err != nil {
+		writeBadRequest(c, err.Error())
+		return
+	}
+	if req.ToDate, err = queryTime(c, "to_date"); err != nil {
+		writeBadRequest(c, err.Error())
+		return
+	}
+
+	resp, err := h.service.GetUserOrders(c.Request.Context(), req)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	writeProto(c, http.StatusOK, resp)
+}
+
+// GetOrderStats — GET /api/v1/users/{user_id}/orders/stats
+func (h *Handler) GetOrderStats(c *gin.Context) {
+	userID, ok := pathID(c, "user_id")
+	if !ok {
+		return
+	}
+
+	resp, err := h.service.GetOrderStats(c.Request.Context(), &pb.GetOrderStatsRequest{UserId: userID})
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	writeProto(c, http.StatusOK, resp)
+}
+
+// bindProto разбирает тело запроса в сообщение; пустое тело оставляет сообщение пустым
+func bindProto(c *gin.Context, msg proto.Message) bool {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxBodySize))
+	if err != nil {
+		writeBadRequest(c, "failed to read body")
+		return false
+	}
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return true
+	}
+	if err := unmarshalOptions.Unmarshal(body, msg); err != nil {
+		writeBadRequest(c, fmt.Sprintf("invalid JSON body: %v", err))
+		return false
+	}
+	return true
+}
+
+func writeProto(c *gin.Context, code int, msg proto.Message) {
+	body, err := marshalOptions.Marshal(msg)
+	if err != nil {
+		writeJSONError(c, http.StatusInternalServerError, "INTERNAL", "failed to encode response")
+		return
+	}
+	c.Data(code, "application/json; charset=utf-8", body)
+}
+
+func pathID(c *gin.Context, name string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 64)
+	if err != nil || id <= 0 {
+		writeBadRequest(c, fmt.Sprintf("invalid %s", name))
+		return 0, false
+	}
+	return id, true
+}
+
+func queryInt32(c *gin.Context, name string) (int32, error) {
+	value := c.Query(name)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(value, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s", name)
+	}
+	return int32(n), nil
+}
+
+// queryTime принимает время в RFC 3339, например 2025-01-31T00:00:00Z
+func queryTime(c *gin.Context, name string) (*timestamppb.Timestamp, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s, expected RFC 3339", name)
+	}
+	return timestamppb.New(t), nil
+}
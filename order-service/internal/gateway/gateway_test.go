@@ -0,0 +1,250 @@
+package gateway_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	pb "order-service/api/order/v1"
+	"order-service/internal/gateway"
+	"order-service/internal/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/microserviceteam0/bff-gateway/shared/identity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+// fakeOrderService реализует только нужные тестам методы; остальные паникуют через nil-интерфейс
+type fakeOrderService struct {
+	service.OrderService
+	getOrderFunc    func(ctx context.Context, req *pb.GetOrderRequest) (*pb.GetOrderResponse, error)
+	cancelOrderFunc func(ctx context.Context, req *pb.CancelOrderRequest) (*pb.CancelOrderResponse, error)
+	createOrderFunc func(ctx context.Context, req *pb.CreateOrderRequest) (*pb.CreateOrderResponse, error)
+}
+
+func (f *fakeOrderService) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.GetOrderResponse, error) {
+	return f.getOrderFunc(ctx, req)
+}
+
+func (f *fakeOrderService) CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*pb.CancelOrderResponse, error) {
+	return f.cancelOrderFunc(ctx, req)
+}
+
+func (f *fakeOrderService) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.CreateOrderResponse, error) {
+	return f.createOrderFunc(ctx, req)
+}
+
+func newRouter(t *testing.T, svc service.OrderService) (*gin.Engine, *identity.Issuer) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	issuer, err := gateway.NewTokenIssuer(testSecret, gateway.MaxTokenTTL)
+	if err != nil {
+		t.Fatalf("NewTokenIssuer: %v", err)
+	}
+	r := gin.New()
+	gateway.NewHandler(svc, issuer).RegisterRoutes(r)
+	return r, issuer
+}
+
+func do(r http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func bearer(t *testing.T, issuer *identity.Issuer, p identity.Principal) string {
+	t.Helper()
+	token, err := issuer.Sign(p)
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	return "Bearer " + token
+}
+
+func TestGateway_RequiresIdentityToken(t *testing.T) {
+	r, _ := newRouter(t, &fakeOrderService{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/1", nil)
+	req.Header.Set("Authorization", "Bearer forged")
+	w := do(r, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestGateway_RejectsInternalToken(t *testing.T) {
+	r, _ := newRouter(t, &fakeOrderService{})
+	internal, err := identity.NewIssuer(testSecret, identity.DefaultTTL)
+	if err != nil {
+		t.Fatalf("NewIssuer: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/1", nil)
+	req.Header.Set("Authorization", bearer(t, internal, identity.Principal{UserID: 7, Role: identity.RoleAdmin}))
+	w := do(r, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestNewTokenIssuerCapsLifetime(t *testing.T) {
+	if _, err := gateway.NewTokenIssuer(testSecret, gateway.MaxTokenTTL+time.Hour); err == nil {
+		t.Error("expected error for lifetime above MaxTokenTTL")
+	}
+}
+
+func TestGateway_GetOrder(t *testing.T) {
+	var gotPrincipal identity.Principal
+	svc := &fakeOrderService{
+		getOrderFunc: func(ctx context.Context, req *pb.GetOrderRequest) (*pb.GetOrderResponse, error) {
+			gotPrincipal, _ = identity.FromContext(ctx)
+			return &pb.GetOrderResponse{Result: &pb.GetOrderResponse_Order{
+				Order: &pb.Order{Id: req.OrderId, UserId: 7, Status: "pending", Version: 3},
+			}}, nil
+		},
+	}
+	r, issuer := newRouter(t, svc)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/42", nil)
+	req.Header.Set("Authorization", bearer(t, issuer, identity.Principal{UserID: 7, Role: identity.RoleUser}))
+	w := do(r, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if gotPrincipal.UserID != 7 {
+		t.Errorf("expected principal user 7 in context, got %+v", gotPrincipal)
+	}
+	if etag := w.Header().Get("ETag"); etag != `"3"` {
+		t.Errorf("expected ETag \"3\", got %q", etag)
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if body["id"] != "42" || body["status"] != "pending" {
+		t.Errorf("unexpected body: %s", w.Body.String())
+	}
+}
+
+func TestGateway_CreateOrder(t *testing.T) {
+	svc := &fakeOrderService{
+		createOrderFunc: func(ctx context.Context, req *pb.CreateOrderRequest) (*pb.CreateOrderResponse, error) {
+			if len(req.Items) != 1 || req.Items[0].ProductId != 5 || req.Items[0].Quantity != 2 {
+				t.Errorf("unexpected items: %v", req.Items)
+			}
+			if req.DeliveryMethod != "pickup" {
+				t.Errorf("expected pickup, got %q", req.DeliveryMethod)
+			}
+			return &pb.CreateOrderResponse{Result: &pb.CreateOrderResponse_OrderId{OrderId: 99}}, nil
+		},
+	}
+	r, issuer := newRouter(t, svc)
+
+	body := `{"items":[{"product_id":5,"quantity":2}],"delivery_method":"pickup"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", strings.NewReader(body))
+	req.Header.Set(gateway.IdentityHeader, strings.TrimPrefix(bearer(t, issuer, identity.Principal{UserID: 7, Role: identity.RoleUser}), "Bearer "))
+	w := do(r, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if loc := w.Header().Get("Location"); loc != "/api/v1/orders/99" {
+		t.Errorf("unexpected Location %q", loc)
+	}
+}
+
+func TestGateway_CreateOrderRejectsUnknownFields(t *testing.T) {
+	r, issuer := newRouter(t, &fakeOrderService{})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", strings.NewReader(`{"itemz":[]}`))
+	req.Header.Set("Authorization", bearer(t, issuer, identity.Principal{UserID: 7, Role: identity.RoleUser}))
+	w := do(r, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestGateway_CancelOrderErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		ifMatch      string
+		err          error
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "Not Found",
+			err:          status.Error(codes.NotFound, "NOT_FOUND: Order not found"),
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"code":"NOT_FOUND","message":"Order not found"}`,
+		},
+		{
+			name:         "Conflict Without If-Match",
+			err:          status.Error(codes.Aborted, "VERSION_CONFLICT: Order has version 4, expected 3"),
+			expectedCode: http.StatusConflict,
+		},
+		{
+			name:         "Precondition Failed With If-Match",
+			ifMatch:      `"3"`,
+			err:          status.Error(codes.Aborted, "VERSION_CONFLICT: Order has version 4, expected 3"),
+			expectedCode: http.StatusPreconditionFailed,
+		},
+		{
+			name:         "Invalid If-Match",
+			ifMatch:      "3",
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "Success",
+			ifMatch:      `"3"`,
+			expectedCode: http.StatusNoContent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &fakeOrderService{
+				cancelOrderFunc: func(ctx context.Context, req *pb.CancelOrderRequest) (*pb.CancelOrderResponse, error) {
+					if req.OrderId != 1 || req.Reason != "changed mind" {
+						t.Errorf("unexpected request: %v", req)
+					}
+					if tt.ifMatch != "" && req.GetExpectedVersion() != 3 {
+						t.Errorf("expected version 3 from If-Match, got %d", req.GetExpectedVersion())
+					}
+					if tt.err != nil {
+						return nil, tt.err
+					}
+					return &pb.CancelOrderResponse{}, nil
+				},
+			}
+			r, issuer := newRouter(t, svc)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/1/cancel", strings.NewReader(`{"reason":"changed mind"}`))
+			req.Header.Set("Authorization", bearer(t, issuer, identity.Principal{UserID: 7, Role: identity.RoleUser}))
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			w := do(r, req)
+
+			if w.Code != tt.expectedCode {
+				t.Fatalf("expected %d, got %d: %s", tt.expectedCode, w.Code, w.Body.String())
+			}
+			if tt.expectedBody != "" && w.Body.String() != tt.expectedBody {
+				t.Errorf("expected body %s, got %s", tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package gateway
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/microserviceteam0/bff-gateway/shared/etag"
+	"github.com/microserviceteam0/bff-gateway/shared/identity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// IdentityHeader — альтернатива Authorization: Bearer, совпадает с ключом gRPC-метаданных
+const IdentityHeader = "X-Identity-Token"
+
+const (
+	// TokenAudience — аудитория токенов фасада: gRPC-интерсепторы их не принимают,
+	// а фасад не принимает внутренние токены сервисов
+	TokenAudience = "order-service-rest"
+	// MaxTokenTTL — самый долгий срок токена фасада
+	MaxTokenTTL = 12 * time.Hour
+)
+
+// NewTokenIssuer возвращает Issuer токенов фасада со сроком ttl. Для проверки нужен ttl = MaxTokenTTL:
+// токены, выпущенные на больший срок, отклоняются
+func NewTokenIssuer(secret string, ttl time.Duration) (*identity.Issuer, error) {
+	if ttl > MaxTokenTTL {
+		return nil, fmt.Errorf("token lifetime must not exceed %s", MaxTokenTTL)
+	}
+	issuer, err := identity.NewIssuer(secret, ttl)
+	if err != nil {
+		return nil, err
+	}
+	return issuer.WithAudience(TokenAudience), nil
+}
+
+// authMiddleware проверяет токен фасада (см. NewTokenIssuer) и кладет Principal в контекст
+func (h *Handler) authMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.GetHeader(IdentityHeader)
+		if auth := c.GetHeader("Authorization"); token == "" && strings.HasPrefix(auth, "Bearer ") {
+			token = strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+		}
+
+		p, err := h.issuer.Verify(token)
+		if err != nil {
+			writeJSONError(c, http.StatusUnauthorized, "UNAUTHENTICATED", err.Error())
+			c.Abort()
+			return
+		}
+
+		c.Request = c.Request.WithContext(identity.NewContext(c.Request.Context(), p))
+		c.Next()
+	}
+}
+
+// writeError переводит gRPC статус сервиса в HTTP. Сообщения сервиса имеют вид "CODE: текст",
+// код уходит в поле code, текст — в message
+func writeError(c *gin.Context, err error) {
+	st := status.Convert(err)
+	code, message := splitMessage(st)
+	writeJSONError(c, httpStatus(c, st.Code()), code, message)
+}
+
+func writeBadRequest(c *gin.Context, message string) {
+	writeJSONError(c, http.StatusBadRequest, "INVALID_REQUEST", message)
+}
+
+func writeJSONError(c *gin.Context, httpCode int, code, message string) {
+	c.JSON(httpCode, gin.H{"code": code, "message": message})
+}
+
+func splitMessage(st *status.Status) (string, string) {
+	msg := st.Message()
+	if i := strings.Index(msg, ": "); i > 0 && strings.ToUpper(msg[:i]) == msg[:i] && !strings.Contains(msg[:i], " ") {
+		return msg[:i], msg[i+2:]
+	}
+	return strings.ToUpper(st.Code().String()), msg
+}
+
+func httpStatus(c *gin.Context, code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists:
+		return http.StatusConflict
+	case codes.Aborted:
+		return etag.ConflictStatus(c.GetHeader("If-Match"))
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// setVersionETag отдает версию заказа в заголовке ETag
+func setVersionETag(c *gin.Context, version int64) {
+	if version > 0 {
+		c.Header("ETag", etag.Format(version))
+	}
+}
+
+// applyIfMatch переносит версию из If-Match в expected_version запроса;
+// заголовок важнее поля в теле. false — заголовок некорректен, ответ уже отправлен
+func applyIfMatch(c *gin.Context, expected **int64) bool {
+	version, err := etag.ParseIfMatch(c.GetHeader("If-Match"))
+	if err != nil {
+		writeBadRequest(c, err.Error())
+		return false
+	}
+	if version != 0 {
+		*expected = &version
+	}
+	return true
+}
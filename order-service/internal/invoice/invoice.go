@@ -0,0 +1,211 @@
+// Package invoice собирает счет по заказу и рендерит его из шаблонов в HTML и текст.
+//
+// Пакет не обращается к БД: номер счета выдает репозиторий, а готовые документы
+// сохраняются вместе со счетом, поэтому правка шаблонов не меняет уже выпущенные счета.
+package invoice
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"order-service/internal/model"
+
+	"github.com/microserviceteam0/bff-gateway/shared/money"
+)
+
+// Format — формат документа счета
+type Format string
+
+const (
+	FormatHTML Format = "html"
+	FormatText Format = "text"
+)
+
+//go:embed templates/*
+var templates embed.FS
+
+// ParseFormat разбирает формат из запроса; пустая строка означает HTML
+func ParseFormat(s string) (Format, error) {
+	switch Format(strings.ToLower(s)) {
+	case "", FormatHTML:
+		return FormatHTML, nil
+	case FormatText:
+		return FormatText, nil
+	default:
+		return "", fmt.Errorf("unsupported invoice format %q, expected html or text", s)
+	}
+}
+
+func (f Format) ContentType() string {
+	if f == FormatText {
+		return "text/plain; charset=utf-8"
+	}
+	return "text/html; charset=utf-8"
+}
+
+func (f Format) Extension() string {
+	if f == FormatText {
+		return "txt"
+	}
+	return "html"
+}
+
+// Seller — реквизиты продавца, печатаются в шапке счета
+type Seller struct {
+	Name    string
+	Address string
+	TaxID   string
+}
+
+// Line — строка счета. Total — сумма после скидки, Tax — НДС, входящий в Total
+type Line struct {
+	Number    int
+	ProductID int64
+	Name      string
+	Quantity  int32
+	UnitPrice money.Money
+	Discount  money.Money
+	Total     money.Money
+	Tax       money.Money
+}
+
+// Promotion — промоакция, давшая скидку по заказу
+type Promotion struct {
+	Name     string
+	Code     string
+	Discount money.Money
+}
+
+// Document — данные для шаблонов счета
+type Document struct {
+	Number         string
+	IssuedAt       time.Time
+	OrderID        int64
+	UserID         int64
+	Seller         Seller
+	Buyer          model.ShippingAddress
+	DeliveryMethod string
+	Lines          []Line
+	Promotions     []Promotion
+	Subtotal       money.Money
+	Discount       money.Money
+	Tax            money.Money
+	Total          money.Money
+	TaxRatePercent int
+}
+
+// Renderer хранит реквизиты продавца, ставку НДС и разобранные шаблоны
+type Renderer struct {
+	seller  Seller
+	taxRate int
+	html    *htmltemplate.Template
+	text    *texttemplate.Template
+}
+
+// NewRenderer разбирает встроенные шаблоны; taxRatePercent — ставка НДС, включенного в цены (0 — без НДС)
+func NewRenderer(seller Seller, taxRatePercent int) (*Renderer, error) {
+	if taxRatePercent < 0 || taxRatePercent > 100 {
+		return nil, fmt.Errorf("invalid tax rate %d%%", taxRatePercent)
+	}
+
+	funcs := map[string]any{
+		"money": func(m money.Money) string { return m.Format() },
+		"date":  func(t time.Time) string { return t.Format("02.01.2006") },
+	}
+
+	html, err := htmltemplate.New("invoice.html.tmpl").Funcs(funcs).ParseFS(templates, "templates/invoice.html.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("parse html template: %w", err)
+	}
+	text, err := texttemplate.New("invoice.txt.tmpl").Funcs(funcs).ParseFS(templates, "templates/invoice.txt.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("parse text template: %w", err)
+	}
+
+	return &Renderer{seller: seller, taxRate: taxRatePercent, html: html, text: text}, nil
+}
+
+// TaxRatePercent возвращает ставку НДС, с которой выпускаются счета
+func (r *Renderer) TaxRatePercent() int {
+	return r.taxRate
+}
+
+// Build собирает счет по заказу. Цены в заказе включают НДС, поэтому налог выделяется из суммы строки
+func (r *Renderer) Build(order *model.Order, number string, issuedAt time.Time) *Document {
+	total := order.Total()
+	currency := total.Currency
+
+	doc := &Document{
+		Number:         number,
+		IssuedAt:       issuedAt,
+		OrderID:        order.ID,
+		UserID:         order.UserID,
+		Seller:         r.seller,
+		Buyer:          order.ShippingAddress,
+		DeliveryMethod: order.DeliveryMethod,
+		Promotions:     make([]Promotion, len(order.Promotions)),
+		Lines:          make([]Line, len(order.Items)),
+		Subtotal:       order.Subtotal(),
+		Discount:       money.New(order.DiscountMinor, currency),
+		Total:          total,
+		TaxRatePercent: r.taxRate,
+	}
+
+	var tax int64
+	for i := range order.Items {
+		item := &order.Items[i]
+		lineTotal := item.LineTotal().Amount - item.DiscountMinor
+		lineTax := includedTax(lineTotal, r.taxRate)
+		tax += lineTax
+
+		doc.Lines[i] = Line{
+			Number:    i + 1,
+			ProductID: item.ProductID,
+			Name:      item.ProductName,
+			Quantity:  item.Quantity,
+			UnitPrice: item.UnitPrice(),
+			Discount:  money.New(item.DiscountMinor, currency),
+			Total:     money.New(lineTotal, currency),
+			Tax:       money.New(lineTax, currency),
+		}
+	}
+	doc.Tax = money.New(tax, currency)
+
+	for i, p := range order.Promotions {
+		doc.Promotions[i] = Promotion{Name: p.Name, Code: p.Code, Discount: money.New(p.DiscountMinor, currency)}
+	}
+
+	return doc
+}
+
+// Render рендерит счет в заданном формате
+func (r *Renderer) Render(doc *Document, format Format) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case FormatHTML:
+		err = r.html.Execute(&buf, doc)
+	case FormatText:
+		err = r.text.Execute(&buf, doc)
+	default:
+		err = fmt.Errorf("unsupported invoice format %q", format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// includedTax выделяет НДС из суммы, в которую он уже включен, с округлением до минимальной единицы
+func includedTax(amount int64, ratePercent int) int64 {
+	if ratePercent == 0 {
+		return 0
+	}
+	rate := int64(ratePercent)
+	return (2*amount*rate + 100 + rate) / (2 * (100 + rate))
+}
@@ -0,0 +1,134 @@
+package invoice_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"order-service/internal/invoice"
+	"order-service/internal/model"
+)
+
+func testOrder() *model.Order {
+	order := &model.Order{
+		ID:             42,
+		UserID:         7,
+		Status:         "completed",
+		SubtotalMinor:  14001,
+		DiscountMinor:  1000,
+		DeliveryMethod: "courier",
+		ShippingAddress: model.ShippingAddress{
+			RecipientName: "Иван Петров",
+			Country:       "RU",
+			City:          "Москва",
+			PostalCode:    "101000",
+			Line1:         "ул. Мясницкая, 1",
+		},
+		Items: []model.OrderItem{
+			{ProductID: 1, ProductName: "Кружка <синяя>", PriceMinor: 4000, Currency: "RUB", Quantity: 3, DiscountMinor: 1000},
+			{ProductID: 2, ProductName: "Чайник", PriceMinor: 2001, Currency: "RUB", Quantity: 1},
+		},
+		Promotions: []model.OrderPromotion{
+			{Name: "Осенняя распродажа", Code: "AUTUMN", DiscountMinor: 1000, Currency: "RUB"},
+		},
+	}
+	order.TotalAmountMinor = 13001
+	order.Currency = "RUB"
+	return order
+}
+
+func TestBuild(t *testing.T) {
+	r, err := invoice.NewRenderer(invoice.Seller{Name: "Shop"}, 20)
+	if err != nil {
+		t.Fatalf("NewRenderer: %v", err)
+	}
+
+	issuedAt := time.Date(2026, 3, 5, 10, 0, 0, 0, time.UTC)
+	doc := r.Build(testOrder(), "INV-2026-000001", issuedAt)
+
+	if len(doc.Lines) != 2 {
+		t.Fatalf("expected 2 lines, got %d", len(doc.Lines))
+	}
+	// 3 × 40.00 − 10.00 = 110.00, НДС 20/120 = 18.33
+	if doc.Lines[0].Total.Amount != 11000 || doc.Lines[0].Tax.Amount != 1833 {
+		t.Errorf("unexpected first line: total %d, tax %d", doc.Lines[0].Total.Amount, doc.Lines[0].Tax.Amount)
+	}
+	// 20.01 / 6 = 3.335 округляется вверх
+	if doc.Lines[1].Tax.Amount != 334 {
+		t.Errorf("expected tax 334 on second line, got %d", doc.Lines[1].Tax.Amount)
+	}
+	if doc.Tax.Amount != 2167 {
+		t.Errorf("expected total tax 2167, got %d", doc.Tax.Amount)
+	}
+	if doc.Subtotal.Amount != 14001 || doc.Discount.Amount != 1000 || doc.Total.Amount != 13001 {
+		t.Errorf("unexpected totals: %d - %d = %d", doc.Subtotal.Amount, doc.Discount.Amount, doc.Total.Amount)
+	}
+}
+
+func TestBuild_NoTax(t *testing.T) {
+	r, err := invoice.NewRenderer(invoice.Seller{Name: "Shop"}, 0)
+	if err != nil {
+		t.Fatalf("NewRenderer: %v", err)
+	}
+
+	doc := r.Build(testOrder(), "INV-2026-000001", time.Now())
+	if doc.Tax.Amount != 0 {
+		t.Errorf("expected no tax, got %d", doc.Tax.Amount)
+	}
+
+	text, err := r.Render(doc, invoice.FormatText)
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if !strings.Contains(string(text), "Без НДС") {
+		t.Errorf("expected 'Без НДС' in text invoice:\n%s", text)
+	}
+}
+
+func TestRender(t *testing.T) {
+	r, err := invoice.NewRenderer(invoice.Seller{Name: "Shop", TaxID: "7700000000"}, 20)
+	if err != nil {
+		t.Fatalf("NewRenderer: %v", err)
+	}
+	doc := r.Build(testOrder(), "INV-2026-000001", time.Date(2026, 3, 5, 10, 0, 0, 0, time.UTC))
+
+	html, err := r.Render(doc, invoice.FormatHTML)
+	if err != nil {
+		t.Fatalf("Render html: %v", err)
+	}
+	for _, want := range []string{"INV-2026-000001", "05.03.2026", "7700000000", "Кружка &lt;синяя&gt;", "Осенняя распродажа", "Москва", "130.01"} {
+		if !strings.Contains(string(html), want) {
+			t.Errorf("html invoice does not contain %q", want)
+		}
+	}
+
+	text, err := r.Render(doc, invoice.FormatText)
+	if err != nil {
+		t.Fatalf("Render text: %v", err)
+	}
+	for _, want := range []string{"СЧЕТ INV-2026-000001 от 05.03.2026", "Кружка <синяя>", "в т.ч. НДС 20%", "21.67"} {
+		if !strings.Contains(string(text), want) {
+			t.Errorf("text invoice does not contain %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    invoice.Format
+		wantErr bool
+	}{
+		{in: "", want: invoice.FormatHTML},
+		{in: "HTML", want: invoice.FormatHTML},
+		{in: "text", want: invoice.FormatText},
+		{in: "pdf", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := invoice.ParseFormat(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseFormat(%q) = %q, %v", tt.in, got, err)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/microserviceteam0/bff-gateway/shared/money"
+)
+
+// Invoice — выставленный по заказу счет. Документы хранятся в готовом виде и после выпуска не меняются
+type Invoice struct {
+	ID             int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	Number         string    `gorm:"type:varchar(32);uniqueIndex;not null" json:"number"`
+	OrderID        int64     `gorm:"uniqueIndex;not null" json:"order_id"`
+	UserID         int64     `gorm:"index;not null" json:"user_id"`
+	Currency       string    `gorm:"type:char(3);not null" json:"currency"`
+	SubtotalMinor  int64     `gorm:"not null" json:"subtotal_minor"`
+	DiscountMinor  int64     `gorm:"not null" json:"discount_minor"`
+	TaxMinor       int64     `gorm:"not null" json:"tax_minor"`
+	TotalMinor     int64     `gorm:"not null" json:"total_minor"`
+	TaxRatePercent int       `gorm:"not null" json:"tax_rate_percent"`
+	HTML           string    `gorm:"type:text;not null" json:"-"`
+	Text           string    `gorm:"type:text;not null" json:"-"`
+	IssuedAt       time.Time `gorm:"not null" json:"issued_at"`
+}
+
+func (Invoice) TableName() string {
+	return "invoices"
+}
+
+func (i *Invoice) Total() money.Money {
+	return money.New(i.TotalMinor, i.Currency)
+}
+
+// InvoiceNumber форматирует номер счета: INV-2026-000001
+func InvoiceNumber(year int, seq int64) string {
+	return fmt.Sprintf("INV-%d-%06d", year, seq)
+}
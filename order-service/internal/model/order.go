@@ -1,17 +1,55 @@
 package model
 
-import "time"
+import (
+	"time"
 
+	"github.com/microserviceteam0/bff-gateway/shared/money"
+)
+
+// Order.Version is incremented on every change of the order; updates with a stale version are rejected
 type Order struct {
-	ID          int64       `gorm:"primaryKey;autoIncrement" json:"id"`
-	UserID      int64       `gorm:"index;not null" json:"user_id"`
-	Status      string      `gorm:"type:varchar(50);default:'created';not null" json:"status"`
-	TotalAmount float64     `gorm:"type:decimal(15,2);not null" json:"total_amount"`
-	Items       []OrderItem `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"items"`
-	CreatedAt   time.Time   `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt   time.Time   `gorm:"autoUpdateTime" json:"updated_at"`
+	ID                 int64            `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID             int64            `gorm:"index;not null" json:"user_id"`
+	Status             string           `gorm:"type:varchar(50);default:'created';not null" json:"status"`
+	CancellationReason string           `gorm:"type:varchar(255)" json:"cancellation_reason"`
+	TotalAmount        float64          `gorm:"type:decimal(15,2);not null" json:"total_amount"`
+	TotalAmountMinor   int64            `gorm:"not null;default:0" json:"total_amount_minor"`
+	SubtotalMinor      int64            `gorm:"not null;default:0" json:"subtotal_minor"`
+	DiscountMinor      int64            `gorm:"not null;default:0" json:"discount_minor"`
+	RefundedMinor      int64            `gorm:"not null;default:0" json:"refunded_minor"`
+	Currency           string           `gorm:"type:char(3);not null;default:'RUB'" json:"currency"`
+	Items              []OrderItem      `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"items"`
+	Promotions         []OrderPromotion `gorm:"foreignKey:OrderID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"promotions"`
+	ShippingAddress    ShippingAddress  `gorm:"embedded;embeddedPrefix:shipping_" json:"shipping_address"`
+	DeliveryMethod     string           `gorm:"type:varchar(20);not null;default:''" json:"delivery_method"`
+	Version            int64            `gorm:"not null;default:1" json:"version"`
+	CreatedAt          time.Time        `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt          time.Time        `gorm:"autoUpdateTime" json:"updated_at"`
 }
 
 func (Order) TableName() string {
 	return "orders"
 }
+
+// Total returns the order total; for old rows without minor units it converts TotalAmount
+func (o *Order) Total() money.Money {
+	if o.TotalAmountMinor == 0 && o.TotalAmount != 0 {
+		return money.FromFloat(o.TotalAmount, o.Currency)
+	}
+	return money.New(o.TotalAmountMinor, o.Currency)
+}
+
+// SetTotal stores the order total and keeps the deprecated TotalAmount in sync
+func (o *Order) SetTotal(total money.Money) {
+	o.TotalAmountMinor = total.Amount
+	o.Currency = total.Currency
+	o.TotalAmount = total.Float64()
+}
+
+// Subtotal returns the sum of the lines before discounts; it equals Total for orders without discounts
+func (o *Order) Subtotal() money.Money {
+	if o.SubtotalMinor == 0 {
+		return o.Total()
+	}
+	return money.New(o.SubtotalMinor, o.Currency)
+}
@@ -1,14 +1,53 @@
 package model
 
+import "github.com/microserviceteam0/bff-gateway/shared/money"
+
 type OrderItem struct {
-	ID          int64   `gorm:"primaryKey;autoIncrement" json:"id"`
-	OrderID     int64   `gorm:"index;not null" json:"order_id"`
-	ProductID   int64   `gorm:"not null" json:"product_id"`
-	ProductName string  `gorm:"not null" json:"product_name"`
-	Price       float64 `gorm:"type:decimal(15,2);not null" json:"price"`
-	Quantity    int32   `gorm:"not null" json:"quantity"`
+	ID            int64   `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrderID       int64   `gorm:"index;not null" json:"order_id"`
+	ProductID     int64   `gorm:"not null" json:"product_id"`
+	VariantID     int64   `gorm:"not null;default:0" json:"variant_id"` // 0 for lines created before variants existed
+	VariantSKU    string  `gorm:"type:varchar(64);not null;default:''" json:"variant_sku"`
+	ProductName   string  `gorm:"not null" json:"product_name"`
+	Price         float64 `gorm:"type:decimal(15,2);not null" json:"price"`
+	PriceMinor    int64   `gorm:"not null;default:0" json:"price_minor"`
+	Currency      string  `gorm:"type:char(3);not null;default:'RUB'" json:"currency"`
+	Quantity      int32   `gorm:"not null" json:"quantity"`
+	DiscountMinor int64   `gorm:"not null;default:0" json:"discount_minor"`
+}
+
+// StockKey identifies the stock managed by Product Service: a product variant.
+// VariantID 0 means the only variant of the product
+type StockKey struct {
+	ProductID int64
+	VariantID int64
+}
+
+// StockKey returns the variant whose stock the line uses
+func (i *OrderItem) StockKey() StockKey {
+	return StockKey{ProductID: i.ProductID, VariantID: i.VariantID}
 }
 
 func (OrderItem) TableName() string {
 	return "order_items"
 }
+
+// UnitPrice returns the price per unit; for old rows it converts Price
+func (i *OrderItem) UnitPrice() money.Money {
+	if i.PriceMinor == 0 && i.Price != 0 {
+		return money.FromFloat(i.Price, i.Currency)
+	}
+	return money.New(i.PriceMinor, i.Currency)
+}
+
+// SetUnitPrice stores the price per unit and keeps the deprecated Price in sync
+func (i *OrderItem) SetUnitPrice(price money.Money) {
+	i.PriceMinor = price.Amount
+	i.Currency = price.Currency
+	i.Price = price.Float64()
+}
+
+// LineTotal returns the cost of the line for its quantity
+func (i *OrderItem) LineTotal() money.Money {
+	return i.UnitPrice().Mul(int64(i.Quantity))
+}
@@ -0,0 +1,16 @@
+package model
+
+// OrderPromotion is a promotion applied to an order, with the resulting discount amount.
+type OrderPromotion struct {
+	ID            int64  `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrderID       int64  `gorm:"index;not null" json:"order_id"`
+	PromotionID   int64  `gorm:"index;not null" json:"promotion_id"`
+	Code          string `gorm:"type:varchar(64)" json:"code"`
+	Name          string `gorm:"not null" json:"name"`
+	DiscountMinor int64  `gorm:"not null" json:"discount_minor"`
+	Currency      string `gorm:"type:char(3);not null;default:'RUB'" json:"currency"`
+}
+
+func (OrderPromotion) TableName() string {
+	return "order_promotions"
+}
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"time"
+
+	"github.com/microserviceteam0/bff-gateway/shared/money"
+)
+
+// Статусы возврата: requested → approved → received → refunded, либо requested → rejected
+const (
+	ReturnRequested = "requested"
+	ReturnApproved  = "approved"
+	ReturnRejected  = "rejected"
+	ReturnReceived  = "received"
+	ReturnRefunded  = "refunded"
+)
+
+// Статусы заказа после возврата денег
+const (
+	OrderPartiallyRefunded = "partially_refunded"
+	OrderRefunded          = "refunded"
+)
+
+// ReturnReasons — допустимые коды причин возврата
+var ReturnReasons = map[string]bool{
+	"damaged":          true,
+	"defective":        true,
+	"wrong_item":       true,
+	"not_as_described": true,
+	"changed_mind":     true,
+	"other":            true,
+}
+
+// OrderReturn — заявка на возврат части или всех позиций выполненного заказа
+type OrderReturn struct {
+	ID              int64             `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrderID         int64             `gorm:"index;not null" json:"order_id"`
+	UserID          int64             `gorm:"index;not null" json:"user_id"`
+	Status          string            `gorm:"type:varchar(20);index;not null" json:"status"`
+	Reason          string            `gorm:"type:varchar(30);not null" json:"reason"`
+	Comment         string            `gorm:"type:varchar(1000)" json:"comment"`
+	Items           []OrderReturnItem `gorm:"foreignKey:ReturnID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"items"`
+	RefundMinor     int64             `gorm:"not null;default:0" json:"refund_minor"`
+	Currency        string            `gorm:"type:char(3);not null" json:"currency"`
+	RejectionReason string            `gorm:"type:varchar(255)" json:"rejection_reason"`
+	ReviewedBy      int64             `json:"reviewed_by"`
+	ReviewedAt      *time.Time        `json:"reviewed_at"`
+	ReceivedAt      *time.Time        `json:"received_at"`
+	PaymentID       int64             `json:"payment_id"`
+	RefundedAt      *time.Time        `json:"refunded_at"`
+	CreatedAt       time.Time         `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt       time.Time         `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (OrderReturn) TableName() string {
+	return "order_returns"
+}
+
+// Refund возвращает сумму к возврату
+func (r *OrderReturn) Refund() money.Money {
+	return money.New(r.RefundMinor, r.Currency)
+}
+
+// Active сообщает, держит ли заявка товары: отклоненные заявки не мешают вернуть их снова
+func (r *OrderReturn) Active() bool {
+	return r.Status != ReturnRejected
+}
+
+type OrderReturnItem struct {
+	ID          int64 `gorm:"primaryKey;autoIncrement" json:"id"`
+	ReturnID    int64 `gorm:"index;not null" json:"return_id"`
+	ProductID   int64 `gorm:"not null" json:"product_id"`
+	VariantID   int64 `gorm:"not null;default:0" json:"variant_id"`
+	Quantity    int32 `gorm:"not null" json:"quantity"`
+	RefundMinor int64 `gorm:"not null;default:0" json:"refund_minor"`
+}
+
+// StockKey возвращает вариант, на склад которого возвращается товар
+func (i *OrderReturnItem) StockKey() StockKey {
+	return StockKey{ProductID: i.ProductID, VariantID: i.VariantID}
+}
+
+func (OrderReturnItem) TableName() string {
+	return "order_return_items"
+}
@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// MaxOrderTemplates is how many order templates one user may save.
+const MaxOrderTemplates = 50
+
+// OrderTemplate is a basket saved by a user. Prices are not stored: they are taken when the order is placed.
+type OrderTemplate struct {
+	ID             int64               `gorm:"primaryKey;autoIncrement" json:"id"`
+	UserID         int64               `gorm:"uniqueIndex:idx_order_templates_user_name;not null" json:"user_id"`
+	Name           string              `gorm:"type:varchar(100);uniqueIndex:idx_order_templates_user_name;not null" json:"name"`
+	Items          []OrderTemplateItem `gorm:"foreignKey:TemplateID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"items"`
+	DeliveryMethod string              `gorm:"type:varchar(20);not null;default:''" json:"delivery_method"`
+	AddressID      int64               `gorm:"not null;default:0" json:"address_id"`
+	CreatedAt      time.Time           `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time           `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (OrderTemplate) TableName() string {
+	return "order_templates"
+}
+
+type OrderTemplateItem struct {
+	ID         int64 `gorm:"primaryKey;autoIncrement" json:"id"`
+	TemplateID int64 `gorm:"index;not null" json:"template_id"`
+	ProductID  int64 `gorm:"not null" json:"product_id"`
+	VariantID  int64 `gorm:"not null;default:0" json:"variant_id"`
+	Quantity   int32 `gorm:"not null" json:"quantity"`
+}
+
+func (OrderTemplateItem) TableName() string {
+	return "order_template_items"
+}
@@ -0,0 +1,45 @@
+package model
+
+import (
+	"time"
+
+	"github.com/microserviceteam0/bff-gateway/shared/money"
+)
+
+// Payment — попытка оплаты заказа. У заказа может быть несколько платежей,
+// но активным (pending, authorized, captured) одновременно бывает только один
+type Payment struct {
+	ID            int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrderID       int64     `gorm:"index;not null" json:"order_id"`
+	Provider      string    `gorm:"type:varchar(50);not null" json:"provider"`
+	ProviderRef   string    `gorm:"type:varchar(255);uniqueIndex;not null" json:"provider_ref"`
+	Status        string    `gorm:"type:varchar(30);not null" json:"status"`
+	AmountMinor   int64     `gorm:"not null" json:"amount_minor"`
+	CapturedMinor int64     `gorm:"not null;default:0" json:"captured_minor"`
+	RefundedMinor int64     `gorm:"not null;default:0" json:"refunded_minor"`
+	Currency      string    `gorm:"type:char(3);not null" json:"currency"`
+	FailureReason string    `gorm:"type:varchar(255)" json:"failure_reason"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (Payment) TableName() string {
+	return "payments"
+}
+
+func (p *Payment) Amount() money.Money {
+	return money.New(p.AmountMinor, p.Currency)
+}
+
+// PaymentEvent — обработанное событие провайдера; уникальный EventID защищает от повторной доставки
+type PaymentEvent struct {
+	ID        int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	EventID   string    `gorm:"type:varchar(255);uniqueIndex;not null" json:"event_id"`
+	PaymentID int64     `gorm:"index;not null" json:"payment_id"`
+	Type      string    `gorm:"type:varchar(50);not null" json:"type"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (PaymentEvent) TableName() string {
+	return "payment_events"
+}
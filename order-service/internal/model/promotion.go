@@ -0,0 +1,47 @@
+package model
+
+import "time"
+
+const (
+	PromotionTypePercentage  = "percentage"
+	PromotionTypeFixedAmount = "fixed_amount"
+	PromotionTypeBuyXGetY    = "buy_x_get_y"
+)
+
+// Promotion is a discount applied automatically (Code == nil) or by a promo code.
+type Promotion struct {
+	ID             int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	Code           *string    `gorm:"type:varchar(64);uniqueIndex" json:"code"`
+	Name           string     `gorm:"type:varchar(255);not null" json:"name"`
+	Type           string     `gorm:"type:varchar(32);not null" json:"type"`
+	PercentOff     int32      `gorm:"not null;default:0" json:"percent_off"`
+	AmountOffMinor int64      `gorm:"not null;default:0" json:"amount_off_minor"`
+	Currency       string     `gorm:"type:char(3);not null;default:'RUB'" json:"currency"`
+	BuyQuantity    int32      `gorm:"not null;default:0" json:"buy_quantity"`
+	GetQuantity    int32      `gorm:"not null;default:0" json:"get_quantity"`
+	ProductID      int64      `gorm:"not null;default:0" json:"product_id"`
+	MinBasketMinor int64      `gorm:"not null;default:0" json:"min_basket_minor"`
+	PerUserLimit   int32      `gorm:"not null;default:0" json:"per_user_limit"`
+	StartsAt       *time.Time `json:"starts_at"`
+	EndsAt         *time.Time `json:"ends_at"`
+	Active         bool       `gorm:"not null;default:true;index" json:"active"`
+	CreatedAt      time.Time  `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt      time.Time  `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (Promotion) TableName() string {
+	return "promotions"
+}
+
+// IsAutomatic reports whether the promotion applies without a promo code
+func (p *Promotion) IsAutomatic() bool {
+	return p.Code == nil || *p.Code == ""
+}
+
+// CodeValue returns the promo code or an empty string for automatic promotions
+func (p *Promotion) CodeValue() string {
+	if p.Code == nil {
+		return ""
+	}
+	return *p.Code
+}
@@ -0,0 +1,20 @@
+package model
+
+// ShippingAddress — снимок адреса доставки на момент оформления заказа.
+// Хранится в колонках orders с префиксом shipping_, поэтому изменения адресной книги не влияют на заказ
+type ShippingAddress struct {
+	AddressID     int64  `gorm:"not null;default:0" json:"address_id"`
+	RecipientName string `gorm:"type:varchar(255)" json:"recipient_name"`
+	Phone         string `gorm:"type:varchar(32)" json:"phone"`
+	Country       string `gorm:"type:char(2)" json:"country"`
+	Region        string `gorm:"type:varchar(100)" json:"region"`
+	City          string `gorm:"type:varchar(100)" json:"city"`
+	PostalCode    string `gorm:"type:varchar(20)" json:"postal_code"`
+	Line1         string `gorm:"type:varchar(255)" json:"line1"`
+	Line2         string `gorm:"type:varchar(255)" json:"line2"`
+}
+
+// IsZero сообщает, что у заказа нет адреса (самовывоз или заказ до появления адресов)
+func (a ShippingAddress) IsZero() bool {
+	return a.Country == "" && a.City == "" && a.Line1 == ""
+}
@@ -0,0 +1,76 @@
+package model
+
+import "time"
+
+// Причины списания товара, передаются в журнал движения Product Service
+const (
+	StockReasonOrderCreated      = "order_created"
+	StockReasonOrderItemsChanged = "order_items_changed"
+)
+
+// Причины возврата товара на склад
+const (
+	CompensationOrderCancelled = "order_cancelled"
+	CompensationPaymentFailed  = "payment_failed"
+	CompensationOrderExpired   = "order_expired"
+	// CompensationRollback — откат резерва после неудачного создания или изменения заказа
+	CompensationRollback = "rollback"
+	// CompensationReturnReceived — товар из принятого возврата
+	CompensationReturnReceived = "return_received"
+)
+
+// StockCompensation — изменение остатка, которое не удалось применить в Product Service.
+// Воркер повторяет его с экспоненциальной задержкой, пока не получится или не кончатся попытки
+type StockCompensation struct {
+	ID            int64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrderID       int64     `gorm:"index" json:"order_id"` // 0, если заказ не был создан
+	ProductID     int64     `gorm:"not null" json:"product_id"`
+	VariantID     int64     `gorm:"not null;default:0" json:"variant_id"`
+	QuantityDelta int32     `gorm:"not null" json:"quantity_delta"`
+	Reason        string    `gorm:"type:varchar(50);not null" json:"reason"`
+	Attempts      int32     `gorm:"not null;default:0" json:"attempts"`
+	LastError     string    `gorm:"type:text" json:"last_error"`
+	NextAttemptAt time.Time `gorm:"index;not null" json:"next_attempt_at"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (StockCompensation) TableName() string {
+	return "stock_compensations"
+}
+
+// DeadStockCompensation — компенсация, исчерпавшая попытки. Остается до ручного разбора администратором
+type DeadStockCompensation struct {
+	ID            int64      `gorm:"primaryKey;autoIncrement" json:"id"`
+	OrderID       int64      `gorm:"index" json:"order_id"`
+	ProductID     int64      `gorm:"not null" json:"product_id"`
+	VariantID     int64      `gorm:"not null;default:0" json:"variant_id"`
+	QuantityDelta int32      `gorm:"not null" json:"quantity_delta"`
+	Reason        string     `gorm:"type:varchar(50);not null" json:"reason"`
+	Attempts      int32      `gorm:"not null" json:"attempts"`
+	LastError     string     `gorm:"type:text" json:"last_error"`
+	CreatedAt     time.Time  `gorm:"not null" json:"created_at"`
+	DeadAt        time.Time  `gorm:"not null" json:"dead_at"`
+	ResolvedAt    *time.Time `gorm:"index" json:"resolved_at"`
+	ResolvedBy    int64      `json:"resolved_by"`
+	Resolution    string     `gorm:"type:varchar(255)" json:"resolution"`
+}
+
+func (DeadStockCompensation) TableName() string {
+	return "stock_compensation_dead_letters"
+}
+
+// Dead переносит компенсацию в dead-letter
+func (c *StockCompensation) Dead(now time.Time) *DeadStockCompensation {
+	return &DeadStockCompensation{
+		OrderID:       c.OrderID,
+		ProductID:     c.ProductID,
+		VariantID:     c.VariantID,
+		QuantityDelta: c.QuantityDelta,
+		Reason:        c.Reason,
+		Attempts:      c.Attempts,
+		LastError:     c.LastError,
+		CreatedAt:     c.CreatedAt,
+		DeadAt:        now,
+	}
+}
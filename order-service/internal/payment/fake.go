@@ -0,0 +1,184 @@
+package payment
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/microserviceteam0/bff-gateway/shared/money"
+)
+
+// Токены фейкового провайдера. Любой другой токен ведет себя как TokenSuccess
+const (
+	TokenSuccess      = "tok_success"
+	TokenDeclined     = "tok_declined"
+	TokenAsyncSuccess = "tok_async_success"
+	TokenAsyncDecline = "tok_async_declined"
+)
+
+type fakePayment struct {
+	status   string
+	amount   money.Money
+	captured int64
+	refunded int64
+}
+
+// FakeProvider — детерминированный провайдер в памяти процесса для локального запуска и тестов.
+// Итог авторизации определяется токеном, асинхронные события копятся до вызова DeliverPending
+type FakeProvider struct {
+	mu       sync.Mutex
+	seq      int64
+	payments map[string]*fakePayment
+	pending  []Event
+}
+
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{payments: make(map[string]*fakePayment)}
+}
+
+func (f *FakeProvider) Name() string {
+	return "fake"
+}
+
+func (f *FakeProvider) Authorize(ctx context.Context, req AuthorizeRequest) (*Result, error) {
+	if req.Amount.Amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.seq++
+	ref := fmt.Sprintf("fake_%d_%d", req.OrderID, f.seq)
+	p := &fakePayment{amount: req.Amount}
+	f.payments[ref] = p
+
+	switch req.Token {
+	case TokenDeclined:
+		p.status = StatusFailed
+		return &Result{ProviderRef: ref, Status: StatusFailed, FailureReason: "card_declined"}, nil
+	case TokenAsyncSuccess:
+		p.status = StatusPending
+		f.pending = append(f.pending, Event{ID: "evt_" + ref, Type: EventAuthorized, ProviderRef: ref})
+		return &Result{ProviderRef: ref, Status: StatusPending}, nil
+	case TokenAsyncDecline:
+		p.status = StatusPending
+		f.pending = append(f.pending, Event{ID: "evt_" + ref, Type: EventFailed, ProviderRef: ref, FailureReason: "card_declined"})
+		return &Result{ProviderRef: ref, Status: StatusPending}, nil
+	default:
+		p.status = StatusAuthorized
+		return &Result{ProviderRef: ref, Status: StatusAuthorized}, nil
+	}
+}
+
+func (f *FakeProvider) Capture(ctx context.Context, providerRef string, amount money.Money) (*Result, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	p, ok := f.payments[providerRef]
+	if !ok {
+		return nil, ErrUnknownPayment
+	}
+	if p.status != StatusAuthorized {
+		return nil, ErrInvalidState
+	}
+	if amount.Currency != p.amount.Currency || amount.Amount <= 0 || amount.Amount > p.amount.Amount {
+		return nil, ErrInvalidAmount
+	}
+
+	p.captured = amount.Amount
+	p.status = StatusCaptured
+	return &Result{ProviderRef: providerRef, Status: p.status}, nil
+}
+
+func (f *FakeProvider) Refund(ctx context.Context, providerRef string, amount money.Money) (*Result, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	p, ok := f.payments[providerRef]
+	if !ok {
+		return nil, ErrUnknownPayment
+	}
+	if p.status != StatusCaptured && p.status != StatusPartiallyRefunded {
+		return nil, ErrInvalidState
+	}
+	if amount.Currency != p.amount.Currency || amount.Amount <= 0 || p.refunded+amount.Amount > p.captured {
+		return nil, ErrInvalidAmount
+	}
+
+	p.refunded += amount.Amount
+	p.status = StatusPartiallyRefunded
+	if p.refunded == p.captured {
+		p.status = StatusRefunded
+	}
+	return &Result{ProviderRef: providerRef, Status: p.status}, nil
+}
+
+func (f *FakeProvider) Void(ctx context.Context, providerRef string) (*Result, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	p, ok := f.payments[providerRef]
+	if !ok {
+		return nil, ErrUnknownPayment
+	}
+	if p.status != StatusAuthorized {
+		return nil, ErrInvalidState
+	}
+
+	p.status = StatusVoided
+	return &Result{ProviderRef: providerRef, Status: p.status}, nil
+}
+
+// DeliverPending отправляет накопленные асинхронные события обработчику и применяет их к своему состоянию.
+// Событие, которое обработчик не принял, остается в очереди до следующего вызова
+func (f *FakeProvider) DeliverPending(ctx context.Context, handle EventHandler) int {
+	f.mu.Lock()
+	events := f.pending
+	f.pending = nil
+	for _, ev := range events {
+		if p, ok := f.payments[ev.ProviderRef]; ok && p.status == StatusPending {
+			if ev.Type == EventAuthorized {
+				p.status = StatusAuthorized
+			} else {
+				p.status = StatusFailed
+			}
+		}
+	}
+	f.mu.Unlock()
+
+	delivered := 0
+	var failed []Event
+	for _, ev := range events {
+		if err := handle(ctx, ev); err != nil {
+			slog.Warn("Failed to deliver payment event", "event_id", ev.ID, "error", err)
+			failed = append(failed, ev)
+			continue
+		}
+		delivered++
+	}
+
+	if len(failed) > 0 {
+		f.mu.Lock()
+		f.pending = append(failed, f.pending...)
+		f.mu.Unlock()
+	}
+	return delivered
+}
+
+// Run имитирует webhook-доставку: раз в interval отправляет накопленные события
+func (f *FakeProvider) Run(ctx context.Context, interval time.Duration, handle EventHandler) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			f.DeliverPending(ctx, handle)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/microserviceteam0/bff-gateway/shared/money"
+)
+
+func TestFakeProviderLifecycle(t *testing.T) {
+	ctx := context.Background()
+	f := NewFakeProvider()
+	amount := money.New(1000, "RUB")
+
+	res, err := f.Authorize(ctx, AuthorizeRequest{OrderID: 1, Amount: amount, Token: TokenSuccess})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Status != StatusAuthorized {
+		t.Fatalf("expected %s, got %s", StatusAuthorized, res.Status)
+	}
+
+	if _, err := f.Refund(ctx, res.ProviderRef, amount); !errors.Is(err, ErrInvalidState) {
+		t.Errorf("expected ErrInvalidState for refund before capture, got %v", err)
+	}
+	if _, err := f.Capture(ctx, res.ProviderRef, money.New(2000, "RUB")); !errors.Is(err, ErrInvalidAmount) {
+		t.Errorf("expected ErrInvalidAmount for capture above authorization, got %v", err)
+	}
+
+	if _, err := f.Capture(ctx, res.ProviderRef, amount); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	partial, err := f.Refund(ctx, res.ProviderRef, money.New(400, "RUB"))
+	if err != nil || partial.Status != StatusPartiallyRefunded {
+		t.Fatalf("expected partial refund, got %v, %v", partial, err)
+	}
+	full, err := f.Refund(ctx, res.ProviderRef, money.New(600, "RUB"))
+	if err != nil || full.Status != StatusRefunded {
+		t.Fatalf("expected full refund, got %v, %v", full, err)
+	}
+}
+
+func TestFakeProviderDeliverPendingRetries(t *testing.T) {
+	ctx := context.Background()
+	f := NewFakeProvider()
+
+	res, err := f.Authorize(ctx, AuthorizeRequest{OrderID: 1, Amount: money.New(1000, "RUB"), Token: TokenAsyncSuccess})
+	if err != nil || res.Status != StatusPending {
+		t.Fatalf("expected pending authorization, got %v, %v", res, err)
+	}
+
+	failing := func(ctx context.Context, event Event) error { return errors.New("not ready") }
+	if n := f.DeliverPending(ctx, failing); n != 0 {
+		t.Errorf("expected 0 delivered events, got %d", n)
+	}
+
+	var got []Event
+	if n := f.DeliverPending(ctx, func(ctx context.Context, event Event) error {
+		got = append(got, event)
+		return nil
+	}); n != 1 {
+		t.Fatalf("expected 1 delivered event, got %d", n)
+	}
+	if got[0].Type != EventAuthorized || got[0].ProviderRef != res.ProviderRef {
+		t.Errorf("unexpected event %+v", got[0])
+	}
+}
+
+func TestWebhookHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	body, _ := json.Marshal(Event{ID: "evt_1", Type: EventAuthorized, ProviderRef: "fake_1_1"})
+
+	tests := []struct {
+		name       string
+		signature  string
+		handleErr  error
+		wantStatus int
+	}{
+		{name: "Valid signature", signature: Sign("secret", body), wantStatus: http.StatusNoContent},
+		{name: "Invalid signature", signature: Sign("other", body), wantStatus: http.StatusUnauthorized},
+		{name: "Handler error is retried", signature: Sign("secret", body), handleErr: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var handled bool
+			router := gin.New()
+			router.POST("/webhooks/payments", WebhookHandler("secret", func(ctx context.Context, event Event) error {
+				handled = true
+				return tt.handleErr
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/payments", bytes.NewReader(body))
+			req.Header.Set(SignatureHeader, tt.signature)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if handled != (tt.wantStatus != http.StatusUnauthorized) {
+				t.Errorf("unexpected handler invocation: %v", handled)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// Package payment описывает взаимодействие с платежным провайдером.
+//
+// Провайдер может ответить на авторизацию сразу или позже: в этом случае платеж
+// остается в статусе pending, а итог приходит асинхронным событием (webhook).
+package payment
+
+import (
+	"context"
+	"errors"
+
+	"github.com/microserviceteam0/bff-gateway/shared/money"
+)
+
+// Статусы платежа
+const (
+	StatusPending           = "pending"
+	StatusAuthorized        = "authorized"
+	StatusCaptured          = "captured"
+	StatusPartiallyRefunded = "partially_refunded"
+	StatusRefunded          = "refunded"
+	StatusVoided            = "voided"
+	StatusFailed            = "failed"
+)
+
+// Типы асинхронных событий провайдера
+const (
+	EventAuthorized = "payment.authorized"
+	EventFailed     = "payment.failed"
+)
+
+var (
+	ErrUnknownPayment = errors.New("unknown payment")
+	ErrInvalidState   = errors.New("operation is not allowed in the current payment state")
+	ErrInvalidAmount  = errors.New("invalid amount")
+)
+
+type AuthorizeRequest struct {
+	OrderID int64
+	UserID  int64
+	Amount  money.Money
+	// Token — одноразовый токен способа оплаты, выданный провайдером клиенту
+	Token string
+}
+
+// Result — ответ провайдера на операцию
+type Result struct {
+	ProviderRef   string
+	Status        string
+	FailureReason string
+}
+
+// Event — асинхронное уведомление провайдера об итоге операции
+type Event struct {
+	ID            string `json:"id"`
+	Type          string `json:"type"`
+	ProviderRef   string `json:"provider_ref"`
+	FailureReason string `json:"failure_reason,omitempty"`
+}
+
+// PaymentProvider — платежный шлюз. Все суммы передаются в минимальных единицах валюты
+type PaymentProvider interface {
+	Name() string
+	Authorize(ctx context.Context, req AuthorizeRequest) (*Result, error)
+	Capture(ctx context.Context, providerRef string, amount money.Money) (*Result, error)
+	Refund(ctx context.Context, providerRef string, amount money.Money) (*Result, error)
+	Void(ctx context.Context, providerRef string) (*Result, error)
+}
+
+// EventHandler обрабатывает асинхронные события провайдера
+type EventHandler func(ctx context.Context, event Event) error
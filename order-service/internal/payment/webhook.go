@@ -0,0 +1,55 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SignatureHeader содержит HMAC-SHA256 тела запроса в hex
+const SignatureHeader = "X-Payment-Signature"
+
+// Sign вычисляет подпись тела webhook-запроса
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// WebhookHandler принимает события провайдера по HTTP.
+// Ошибка обработки возвращает 500, чтобы провайдер повторил доставку; повторы безопасны, события дедуплицируются по ID
+func WebhookHandler(secret string, handle EventHandler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		body, err := io.ReadAll(io.LimitReader(c.Request.Body, 64<<10))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read body"})
+			return
+		}
+
+		expected := Sign(secret, body)
+		if !hmac.Equal([]byte(expected), []byte(c.GetHeader(SignatureHeader))) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid signature"})
+			return
+		}
+
+		var event Event
+		if err := json.Unmarshal(body, &event); err != nil || event.ID == "" || event.ProviderRef == "" {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid event"})
+			return
+		}
+
+		if err := handle(c.Request.Context(), event); err != nil {
+			slog.Error("Failed to handle payment event", "event_id", event.ID, "type", event.Type, "error", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to handle event"})
+			return
+		}
+
+		c.Status(http.StatusNoContent)
+	}
+}
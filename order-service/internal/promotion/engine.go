@@ -0,0 +1,220 @@
+// Package promotion calculates promotion discounts for a basket.
+//
+// The calculation is fully deterministic and does not touch the database: the service
+// loads the applicable promotions, checks their usage limits and passes them here
+// together with the basket lines.
+package promotion
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"order-service/internal/model"
+
+	"github.com/microserviceteam0/bff-gateway/shared/money"
+)
+
+var (
+	ErrInactive         = errors.New("promotion is not active")
+	ErrNotStarted       = errors.New("promotion has not started yet")
+	ErrExpired          = errors.New("promotion has expired")
+	ErrMinBasket        = errors.New("basket total is below the promotion minimum")
+	ErrCurrencyMismatch = errors.New("promotion currency does not match the basket")
+	ErrNotApplicable    = errors.New("promotion does not apply to any item in the basket")
+)
+
+// Line is a basket line.
+type Line struct {
+	ProductID int64
+	Quantity  int32
+	UnitPrice money.Money
+}
+
+func (l Line) total() int64 {
+	return l.UnitPrice.Amount * int64(l.Quantity)
+}
+
+// Applied is a promotion that gave a discount.
+type Applied struct {
+	Promotion *model.Promotion
+	Discount  money.Money
+}
+
+// Result is the outcome of pricing a basket. LineDiscounts are in the same order as the lines.
+type Result struct {
+	Subtotal      money.Money
+	Discount      money.Money
+	Total         money.Money
+	LineDiscounts []int64
+	Applied       []Applied
+}
+
+// Validate checks the promotion definition itself, independent of any basket
+func Validate(p *model.Promotion) error {
+	if p.Name == "" {
+		return errors.New("name is required")
+	}
+	if p.StartsAt != nil && p.EndsAt != nil && !p.EndsAt.After(*p.StartsAt) {
+		return errors.New("ends_at must be after starts_at")
+	}
+	if p.MinBasketMinor < 0 || p.PerUserLimit < 0 || p.ProductID < 0 {
+		return errors.New("min_basket_minor, per_user_limit and product_id must not be negative")
+	}
+	if !money.ValidCurrency(p.Currency) {
+		return fmt.Errorf("invalid currency %q", p.Currency)
+	}
+
+	switch p.Type {
+	case model.PromotionTypePercentage:
+		if p.PercentOff <= 0 || p.PercentOff > 100 {
+			return errors.New("percent_off must be between 1 and 100")
+		}
+	case model.PromotionTypeFixedAmount:
+		if p.AmountOffMinor <= 0 {
+			return errors.New("amount_off_minor must be positive")
+		}
+	case model.PromotionTypeBuyXGetY:
+		if p.BuyQuantity <= 0 || p.GetQuantity <= 0 {
+			return errors.New("buy_quantity and get_quantity must be positive")
+		}
+	default:
+		return fmt.Errorf("unknown promotion type %q", p.Type)
+	}
+
+	return nil
+}
+
+// Check reports whether the promotion may be used for a basket with the given subtotal at the given time
+func Check(p *model.Promotion, subtotal money.Money, now time.Time) error {
+	if !p.Active {
+		return ErrInactive
+	}
+	if p.StartsAt != nil && now.Before(*p.StartsAt) {
+		return ErrNotStarted
+	}
+	if p.EndsAt != nil && !now.Before(*p.EndsAt) {
+		return ErrExpired
+	}
+	needsCurrency := p.Type == model.PromotionTypeFixedAmount || p.MinBasketMinor > 0
+	if needsCurrency && money.NormalizeCurrency(p.Currency) != subtotal.Currency {
+		return ErrCurrencyMismatch
+	}
+	if subtotal.Amount < p.MinBasketMinor {
+		return ErrMinBasket
+	}
+	return nil
+}
+
+// Apply prices the basket with the given promotions, in the given order.
+// Promotions stack: each one discounts what is left of the lines after the previous ones.
+// Promotions that cannot be used are skipped and reported in the returned map by promotion ID.
+func Apply(lines []Line, promos []*model.Promotion, now time.Time) (*Result, map[int64]error) {
+	currency := money.DefaultCurrency
+	if len(lines) > 0 {
+		currency = lines[0].UnitPrice.Currency
+	}
+
+	var subtotal int64
+	remaining := make([]int64, len(lines))
+	for i, line := range lines {
+		remaining[i] = line.total()
+		subtotal += remaining[i]
+	}
+
+	result := &Result{
+		Subtotal:      money.New(subtotal, currency),
+		LineDiscounts: make([]int64, len(lines)),
+	}
+	rejected := make(map[int64]error)
+
+	for _, p := range promos {
+		if err := Check(p, result.Subtotal, now); err != nil {
+			rejected[p.ID] = err
+			continue
+		}
+
+		discounts := lineDiscounts(p, lines, remaining)
+		var total int64
+		for i, d := range discounts {
+			remaining[i] -= d
+			result.LineDiscounts[i] += d
+			total += d
+		}
+		if total == 0 {
+			rejected[p.ID] = ErrNotApplicable
+			continue
+		}
+
+		result.Applied = append(result.Applied, Applied{Promotion: p, Discount: money.New(total, currency)})
+	}
+
+	var discount int64
+	for _, d := range result.LineDiscounts {
+		discount += d
+	}
+	result.Discount = money.New(discount, currency)
+	result.Total = money.New(subtotal-discount, currency)
+
+	return result, rejected
+}
+
+// lineDiscounts returns the discount of a single promotion for every line, never exceeding what is left of the line
+func lineDiscounts(p *model.Promotion, lines []Line, remaining []int64) []int64 {
+	discounts := make([]int64, len(lines))
+	eligible := func(i int) bool {
+		return remaining[i] > 0 && (p.ProductID == 0 || lines[i].ProductID == p.ProductID)
+	}
+
+	switch p.Type {
+	case model.PromotionTypePercentage:
+		for i := range lines {
+			if eligible(i) {
+				// Round half up: 10% of 0.05 RUB gives 0.01 RUB
+				discounts[i] = min((remaining[i]*int64(p.PercentOff)+50)/100, remaining[i])
+			}
+		}
+
+	case model.PromotionTypeFixedAmount:
+		var base int64
+		for i := range lines {
+			if eligible(i) {
+				base += remaining[i]
+			}
+		}
+		amount := min(p.AmountOffMinor, base)
+		if amount == 0 {
+			return discounts
+		}
+
+		// The discount is split in proportion to the remaining line amounts; rounding leftovers go to the first lines
+		var allocated int64
+		for i := range lines {
+			if eligible(i) {
+				discounts[i] = amount * remaining[i] / base
+				allocated += discounts[i]
+			}
+		}
+		for i := range lines {
+			if allocated == amount {
+				break
+			}
+			if eligible(i) && discounts[i] < remaining[i] {
+				discounts[i]++
+				allocated++
+			}
+		}
+
+	case model.PromotionTypeBuyXGetY:
+		group := int64(p.BuyQuantity + p.GetQuantity)
+		for i, line := range lines {
+			if !eligible(i) {
+				continue
+			}
+			free := int64(line.Quantity) / group * int64(p.GetQuantity)
+			discounts[i] = min(free*line.UnitPrice.Amount, remaining[i])
+		}
+	}
+
+	return discounts
+}
@@ -0,0 +1,146 @@
+package promotion_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"order-service/internal/model"
+	"order-service/internal/promotion"
+
+	"github.com/microserviceteam0/bff-gateway/shared/money"
+)
+
+func TestApply(t *testing.T) {
+	now := time.Date(2025, 12, 20, 12, 0, 0, 0, time.UTC)
+	yesterday := now.Add(-24 * time.Hour)
+	tomorrow := now.Add(24 * time.Hour)
+
+	lines := []promotion.Line{
+		{ProductID: 1, Quantity: 3, UnitPrice: money.New(1000, "RUB")}, // 30.00
+		{ProductID: 2, Quantity: 1, UnitPrice: money.New(2001, "RUB")}, // 20.01
+	}
+
+	tests := []struct {
+		name              string
+		promos            []*model.Promotion
+		expectedLines     []int64
+		expectedDiscount  int64
+		expectedRejection error
+	}{
+		{
+			name:             "Percentage Off Whole Basket",
+			promos:           []*model.Promotion{{ID: 1, Type: model.PromotionTypePercentage, PercentOff: 10, Active: true}},
+			expectedLines:    []int64{300, 200},
+			expectedDiscount: 500,
+		},
+		{
+			name:             "Fixed Amount Split Across Lines",
+			promos:           []*model.Promotion{{ID: 1, Type: model.PromotionTypeFixedAmount, AmountOffMinor: 1000, Currency: "RUB", Active: true}},
+			expectedLines:    []int64{600, 400},
+			expectedDiscount: 1000,
+		},
+		{
+			name:             "Fixed Amount Capped By Basket",
+			promos:           []*model.Promotion{{ID: 1, Type: model.PromotionTypeFixedAmount, AmountOffMinor: 1000, Currency: "RUB", ProductID: 2, Active: true}},
+			expectedLines:    []int64{0, 1000},
+			expectedDiscount: 1000,
+		},
+		{
+			name:             "Buy Two Get One",
+			promos:           []*model.Promotion{{ID: 1, Type: model.PromotionTypeBuyXGetY, BuyQuantity: 2, GetQuantity: 1, ProductID: 1, Active: true}},
+			expectedLines:    []int64{1000, 0},
+			expectedDiscount: 1000,
+		},
+		{
+			name: "Promotions Stack On Remaining Amount",
+			promos: []*model.Promotion{
+				{ID: 1, Type: model.PromotionTypeBuyXGetY, BuyQuantity: 2, GetQuantity: 1, ProductID: 1, Active: true},
+				{ID: 2, Type: model.PromotionTypePercentage, PercentOff: 50, Active: true},
+			},
+			expectedLines:    []int64{2000, 1001},
+			expectedDiscount: 3001,
+		},
+		{
+			name:              "Min Basket Not Reached",
+			promos:            []*model.Promotion{{ID: 1, Type: model.PromotionTypePercentage, PercentOff: 10, MinBasketMinor: 10000, Currency: "RUB", Active: true}},
+			expectedLines:     []int64{0, 0},
+			expectedRejection: promotion.ErrMinBasket,
+		},
+		{
+			name:              "Expired",
+			promos:            []*model.Promotion{{ID: 1, Type: model.PromotionTypePercentage, PercentOff: 10, EndsAt: &yesterday, Active: true}},
+			expectedLines:     []int64{0, 0},
+			expectedRejection: promotion.ErrExpired,
+		},
+		{
+			name:              "Not Started",
+			promos:            []*model.Promotion{{ID: 1, Type: model.PromotionTypePercentage, PercentOff: 10, StartsAt: &tomorrow, Active: true}},
+			expectedLines:     []int64{0, 0},
+			expectedRejection: promotion.ErrNotStarted,
+		},
+		{
+			name:              "Product Not In Basket",
+			promos:            []*model.Promotion{{ID: 1, Type: model.PromotionTypePercentage, PercentOff: 10, ProductID: 99, Active: true}},
+			expectedLines:     []int64{0, 0},
+			expectedRejection: promotion.ErrNotApplicable,
+		},
+		{
+			name:              "Currency Mismatch",
+			promos:            []*model.Promotion{{ID: 1, Type: model.PromotionTypeFixedAmount, AmountOffMinor: 100, Currency: "USD", Active: true}},
+			expectedLines:     []int64{0, 0},
+			expectedRejection: promotion.ErrCurrencyMismatch,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, rejected := promotion.Apply(lines, tt.promos, now)
+
+			if result.Subtotal.Amount != 5001 {
+				t.Errorf("Expected subtotal 5001, got %d", result.Subtotal.Amount)
+			}
+			for i, expected := range tt.expectedLines {
+				if result.LineDiscounts[i] != expected {
+					t.Errorf("Line %d: expected discount %d, got %d", i, expected, result.LineDiscounts[i])
+				}
+			}
+			if result.Discount.Amount != tt.expectedDiscount {
+				t.Errorf("Expected discount %d, got %d", tt.expectedDiscount, result.Discount.Amount)
+			}
+			if result.Total.Amount != result.Subtotal.Amount-result.Discount.Amount {
+				t.Errorf("Total %d does not match subtotal minus discount", result.Total.Amount)
+			}
+			if tt.expectedRejection != nil && !errors.Is(rejected[1], tt.expectedRejection) {
+				t.Errorf("Expected rejection %v, got %v", tt.expectedRejection, rejected[1])
+			}
+			if tt.expectedRejection == nil && len(rejected) > 0 {
+				t.Errorf("Expected no rejections, got %v", rejected)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		promo   model.Promotion
+		wantErr bool
+	}{
+		{"Valid Percentage", model.Promotion{Name: "Sale", Type: model.PromotionTypePercentage, PercentOff: 15, Currency: "RUB"}, false},
+		{"Percentage Over 100", model.Promotion{Name: "Sale", Type: model.PromotionTypePercentage, PercentOff: 150, Currency: "RUB"}, true},
+		{"Fixed Without Amount", model.Promotion{Name: "Sale", Type: model.PromotionTypeFixedAmount, Currency: "RUB"}, true},
+		{"Buy X Get Y Without Quantities", model.Promotion{Name: "Sale", Type: model.PromotionTypeBuyXGetY, Currency: "RUB"}, true},
+		{"Unknown Type", model.Promotion{Name: "Sale", Type: "bogus", Currency: "RUB"}, true},
+		{"Missing Name", model.Promotion{Type: model.PromotionTypePercentage, PercentOff: 15, Currency: "RUB"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := promotion.Validate(&tt.promo)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"order-service/internal/model"
+
+	"github.com/microserviceteam0/bff-gateway/shared/metrics"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockCompensationRepository interface {
+	Enqueue(ctx context.Context, compensation *model.StockCompensation) error
+	// ClaimDue returns up to limit compensations due at now and postpones them by lease,
+	// so that another replica does not pick them up while they are being processed.
+	ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]model.StockCompensation, error)
+	Get(ctx context.Context, id int64) (*model.StockCompensation, error)
+	Save(ctx context.Context, compensation *model.StockCompensation) error
+	Delete(ctx context.Context, id int64) error
+	// MoveToDeadLetter stores dead and removes the queued compensation id in one transaction.
+	MoveToDeadLetter(ctx context.Context, id int64, dead *model.DeadStockCompensation) error
+	List(ctx context.Context, limit int, offset int) ([]model.StockCompensation, int64, error)
+	GetDead(ctx context.Context, id int64) (*model.DeadStockCompensation, error)
+	SaveDead(ctx context.Context, dead *model.DeadStockCompensation) error
+	ListDead(ctx context.Context, includeResolved bool, limit int, offset int) ([]model.DeadStockCompensation, int64, error)
+	// Count returns the number of queued and unresolved dead-letter compensations.
+	Count(ctx context.Context) (queued int64, dead int64, err error)
+}
+
+type StockCompensationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewStockCompensationRepository(db *gorm.DB) StockCompensationRepository {
+	return &StockCompensationRepositoryImpl{db: db}
+}
+
+func observeQuery(operation string, start time.Time, err error) {
+	metrics.DBQueryDuration.WithLabelValues("order-service", operation).Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("order-service", operation).Inc()
+	}
+}
+
+// Enqueue implements StockCompensationRepository.
+func (r *StockCompensationRepositoryImpl) Enqueue(ctx context.Context, compensation *model.StockCompensation) error {
+	start := time.Now()
+	err := r.db.WithContext(ctx).Create(compensation).Error
+	observeQuery("INSERT", start, err)
+	return err
+}
+
+// ClaimDue implements StockCompensationRepository.
+func (r *StockCompensationRepositoryImpl) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]model.StockCompensation, error) {
+	start := time.Now()
+	var compensations []model.StockCompensation
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("next_attempt_at <= ?", now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&compensations).
+			Error; err != nil {
+			return err
+		}
+		if len(compensations) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, len(compensations))
+		for i := range compensations {
+			ids[i] = compensations[i].ID
+		}
+		return tx.
+			Model(&model.StockCompensation{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).
+			Error
+	})
+	observeQuery("UPDATE", start, err)
+	if err != nil {
+		return nil, err
+	}
+	return compensations, nil
+}
+
+// Get implements StockCompensationRepository.
+func (r *StockCompensationRepositoryImpl) Get(ctx context.Context, id int64) (*model.StockCompensation, error) {
+	start := time.Now()
+	var compensation model.StockCompensation
+	err := r.db.WithContext(ctx).First(&compensation, id).Error
+	observeQuery("SELECT", start, err)
+	if err != nil {
+		return nil, err
+	}
+	return &compensation, nil
+}
+
+// Save implements StockCompensationRepository.
+func (r *StockCompensationRepositoryImpl) Save(ctx context.Context, compensation *model.StockCompensation) error {
+	start := time.Now()
+	err := r.db.WithContext(ctx).Save(compensation).Error
+	observeQuery("UPDATE", start, err)
+	return err
+}
+
+// Delete implements StockCompensationRepository.
+func (r *StockCompensationRepositoryImpl) Delete(ctx context.Context, id int64) error {
+	start := time.Now()
+	err := r.db.WithContext(ctx).Delete(&model.StockCompensation{}, id).Error
+	observeQuery("DELETE", start, err)
+	return err
+}
+
+// MoveToDeadLetter implements StockCompensationRepository.
+func (r *StockCompensationRepositoryImpl) MoveToDeadLetter(ctx context.Context, id int64, dead *model.DeadStockCompensation) error {
+	start := time.Now()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(dead).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.StockCompensation{}, id).Error
+	})
+	observeQuery("INSERT", start, err)
+	return err
+}
+
+// List implements StockCompensationRepository.
+func (r *StockCompensationRepositoryImpl) List(ctx context.Context, limit int, offset int) ([]model.StockCompensation, int64, error) {
+	start := time.Now()
+	var (
+		compensations []model.StockCompensation
+		total         int64
+	)
+	query := r.db.WithContext(ctx).Model(&model.StockCompensation{})
+	err := query.Count(&total).Error
+	if err == nil {
+		err = query.Order("id").Limit(limit).Offset(offset).Find(&compensations).Error
+	}
+	observeQuery("SELECT", start, err)
+	if err != nil {
+		return nil, 0, err
+	}
+	return compensations, total, nil
+}
+
+// GetDead implements StockCompensationRepository.
+func (r *StockCompensationRepositoryImpl) GetDead(ctx context.Context, id int64) (*model.DeadStockCompensation, error) {
+	start := time.Now()
+	var dead model.DeadStockCompensation
+	err := r.db.WithContext(ctx).First(&dead, id).Error
+	observeQuery("SELECT", start, err)
+	if err != nil {
+		return nil, err
+	}
+	return &dead, nil
+}
+
+// SaveDead implements StockCompensationRepository.
+func (r *StockCompensationRepositoryImpl) SaveDead(ctx context.Context, dead *model.DeadStockCompensation) error {
+	start := time.Now()
+	err := r.db.WithContext(ctx).Save(dead).Error
+	observeQuery("UPDATE", start, err)
+	return err
+}
+
+// ListDead implements StockCompensationRepository.
+func (r *StockCompensationRepositoryImpl) ListDead(ctx context.Context, includeResolved bool, limit int, offset int) ([]model.DeadStockCompensation, int64, error) {
+	start := time.Now()
+	var (
+		dead  []model.DeadStockCompensation
+		total int64
+	)
+	query := r.db.WithContext(ctx).Model(&model.DeadStockCompensation{})
+	if !includeResolved {
+		query = query.Where("resolved_at IS NULL")
+	}
+	err := query.Count(&total).Error
+	if err == nil {
+		err = query.Order("id").Limit(limit).Offset(offset).Find(&dead).Error
+	}
+	observeQuery("SELECT", start, err)
+	if err != nil {
+		return nil, 0, err
+	}
+	return dead, total, nil
+}
+
+// Count implements StockCompensationRepository.
+func (r *StockCompensationRepositoryImpl) Count(ctx context.Context) (int64, int64, error) {
+	start := time.Now()
+	var queued, dead int64
+	err := r.db.WithContext(ctx).Model(&model.StockCompensation{}).Count(&queued).Error
+	if err == nil {
+		err = r.db.WithContext(ctx).Model(&model.DeadStockCompensation{}).Where("resolved_at IS NULL").Count(&dead).Error
+	}
+	observeQuery("SELECT", start, err)
+	return queued, dead, err
+}
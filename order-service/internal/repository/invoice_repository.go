@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"order-service/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrInvoiceExists is returned when the order already got an invoice from a concurrent request.
+var ErrInvoiceExists = errors.New("invoice already issued")
+
+type InvoiceRepository interface {
+	GetInvoiceByOrderID(ctx context.Context, orderID int64) (*model.Invoice, error)
+	// IssueInvoice takes the next number of the issue year, lets render fill the documents and stores the invoice
+	// in one transaction. A failed issue does not consume the number.
+	IssueInvoice(ctx context.Context, invoice *model.Invoice, render func(invoice *model.Invoice) error) error
+}
+
+type InvoiceRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewInvoiceRepository(db *gorm.DB) InvoiceRepository {
+	return &InvoiceRepositoryImpl{db: db}
+}
+
+// GetInvoiceByOrderID implements InvoiceRepository.
+func (r *InvoiceRepositoryImpl) GetInvoiceByOrderID(ctx context.Context, orderID int64) (*model.Invoice, error) {
+	start := time.Now()
+	var invoice model.Invoice
+	err := r.db.WithContext(ctx).Where("order_id = ?", orderID).First(&invoice).Error
+	observeQuery("SELECT", start, err)
+	if err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+// IssueInvoice implements InvoiceRepository.
+func (r *InvoiceRepositoryImpl) IssueInvoice(ctx context.Context, invoice *model.Invoice, render func(invoice *model.Invoice) error) error {
+	start := time.Now()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The counter row stays locked until the end of the transaction, so numbers have no gaps or duplicates
+		year := invoice.IssuedAt.Year()
+		var seq int64
+		if err := tx.Raw(`
+			INSERT INTO invoice_number_counters (year, last_number) VALUES (?, 1)
+			ON CONFLICT (year) DO UPDATE SET last_number = invoice_number_counters.last_number + 1
+			RETURNING last_number`, year).
+			Scan(&seq).Error; err != nil {
+			return err
+		}
+		invoice.Number = model.InvoiceNumber(year, seq)
+
+		if err := render(invoice); err != nil {
+			return err
+		}
+
+		res := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "order_id"}},
+			DoNothing: true,
+		}).Create(invoice)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrInvoiceExists
+		}
+		return nil
+	})
+	if errors.Is(err, ErrInvoiceExists) {
+		observeQuery("INSERT", start, nil)
+		return err
+	}
+	observeQuery("INSERT", start, err)
+	return err
+}
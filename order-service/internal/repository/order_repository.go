@@ -2,20 +2,94 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"order-service/internal/model"
 
 	"github.com/microserviceteam0/bff-gateway/shared/metrics"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// ErrOrderNotPending is returned when an order changed its status while its items were being replaced.
+var ErrOrderNotPending = errors.New("order is not pending")
+
+// ErrVersionConflict is returned when the order was modified after it had been read.
+var ErrVersionConflict = errors.New("order version conflict")
+
+// ErrPromoLimitReached is returned when a concurrent order used up the per-user limit of an applied promotion.
+var ErrPromoLimitReached = errors.New("promotion per-user limit reached")
+
+// PromoLimitError names the promotion whose limit was reached; it matches ErrPromoLimitReached.
+type PromoLimitError struct {
+	PromotionID int64
+}
+
+func (e *PromoLimitError) Error() string {
+	return fmt.Sprintf("%v: promotion %d", ErrPromoLimitReached, e.PromotionID)
+}
+
+func (e *PromoLimitError) Unwrap() error {
+	return ErrPromoLimitReached
+}
+
+// Sort keys of SearchOrders.
+const (
+	OrderSortCreatedAt = "created_at"
+	OrderSortTotal     = "total"
+)
+
+// OrderSearchFilter narrows SearchOrders; zero fields are ignored.
+type OrderSearchFilter struct {
+	Statuses  []string
+	UserID    int64
+	ProductID int64
+	MinTotal  *int64
+	MaxTotal  *int64
+	From      *time.Time
+	To        *time.Time
+	// Query is a case-insensitive substring of a product name in the order.
+	Query     string
+	SortBy    string
+	Ascending bool
+	// After continues the search after the last order of the previous page.
+	After *OrderCursor
+}
+
+// UserOrderFilter narrows the orders of one user; zero fields are not applied.
+type UserOrderFilter struct {
+	Status string
+	From   *time.Time
+	To     *time.Time
+}
+
+// OrderCursor is the sort key of the last order of a page; only the field of the current sorting is set.
+type OrderCursor struct {
+	CreatedAt time.Time `json:"c,omitempty"`
+	Total     int64     `json:"t,omitempty"`
+	ID        int64     `json:"i"`
+}
+
 type OrderRepository interface {
 	CreateOrder(ctx context.Context, order *model.Order) (*model.Order, error)
 	GetOrder(ctx context.Context, orderID int64) (*model.Order, error)
-	GetOrdersByUserID(ctx context.Context, userID int64, limit int, offset int) ([]model.Order, int64, error)
+	GetOrdersByUserID(ctx context.Context, userID int64, filter UserOrderFilter, limit int, offset int) ([]model.Order, int64, error)
+	// SearchOrders returns up to limit orders of all users in the filter's sort order, starting after filter.After.
+	SearchOrders(ctx context.Context, filter OrderSearchFilter, limit int) ([]model.Order, error)
+	// UpdateOrder saves the order only if its version has not changed since it was read
+	// and increments the version; a stale version yields ErrVersionConflict.
 	UpdateOrder(ctx context.Context, order *model.Order) error
+	// ReplaceOrderItems checks and increments the version the same way as UpdateOrder.
+	ReplaceOrderItems(ctx context.Context, order *model.Order) error
 	Delete(ctx context.Context, orderID int64) error
+	// FindStaleOrders returns pending orders created before cutoff without changing them.
+	FindStaleOrders(ctx context.Context, cutoff time.Time, limit int) ([]model.Order, error)
+	// ExpireStaleOrders cancels up to limit pending orders created before cutoff and returns them with their items.
+	ExpireStaleOrders(ctx context.Context, cutoff time.Time, limit int, reason string) ([]model.Order, error)
 }
 
 type OrderRepositoryImpl struct {
@@ -27,18 +101,23 @@ func NewOrderRepository(db *gorm.DB) OrderRepository {
 }
 
 // CreateOrder implements OrderRepository.
+// Per-user promotion limits are re-checked in the same transaction, see checkPromotionLimits.
 func (o *OrderRepositoryImpl) CreateOrder(ctx context.Context, order *model.Order) (*model.Order, error) {
 	start := time.Now()
-	err := o.db.
-		WithContext(ctx).
-		Create(order).
-		Error
+	err := o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := checkPromotionLimits(tx, order); err != nil {
+			return err
+		}
+		return tx.Create(order).Error
+	})
 
 	duration := time.Since(start).Seconds()
 	metrics.DBQueryDuration.WithLabelValues("order-service", "INSERT").Observe(duration)
 
 	if err != nil {
-		metrics.DBErrors.WithLabelValues("order-service", "INSERT").Inc()
+		if !errors.Is(err, ErrPromoLimitReached) {
+			metrics.DBErrors.WithLabelValues("order-service", "INSERT").Inc()
+		}
 		return nil, err
 	}
 
@@ -52,6 +131,7 @@ func (o *OrderRepositoryImpl) GetOrder(ctx context.Context, orderID int64) (*mod
 	err := o.db.
 		WithContext(ctx).
 		Preload("Items").
+		Preload("Promotions").
 		First(&order, orderID).
 		Error
 
@@ -66,12 +146,21 @@ func (o *OrderRepositoryImpl) GetOrder(ctx context.Context, orderID int64) (*mod
 }
 
 // GetOrdersByUserID implements OrderRepository.
-func (o *OrderRepositoryImpl) GetOrdersByUserID(ctx context.Context, userID int64, limit int, offset int) ([]model.Order, int64, error) {
+func (o *OrderRepositoryImpl) GetOrdersByUserID(ctx context.Context, userID int64, filter UserOrderFilter, limit int, offset int) ([]model.Order, int64, error) {
 	start := time.Now()
 	var orders []model.Order
 	var total int64
 
 	query := o.db.WithContext(ctx).Model(&model.Order{}).Where("user_id = ?", userID)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
 
 	if err := query.Count(&total).Error; err != nil {
 		metrics.DBErrors.WithLabelValues("order-service", "SELECT").Inc()
@@ -80,6 +169,7 @@ func (o *OrderRepositoryImpl) GetOrdersByUserID(ctx context.Context, userID int6
 
 	err := query.
 		Preload("Items").
+		Preload("Promotions").
 		Order("created_at DESC").
 		Limit(limit).
 		Offset(offset).
@@ -96,21 +186,232 @@ func (o *OrderRepositoryImpl) GetOrdersByUserID(ctx context.Context, userID int6
 	return orders, total, nil
 }
 
+// SearchOrders implements OrderRepository.
+// Keyset pagination on (sort value, id): pages do not shift when new orders are inserted.
+func (o *OrderRepositoryImpl) SearchOrders(ctx context.Context, filter OrderSearchFilter, limit int) ([]model.Order, error) {
+	start := time.Now()
+	query := o.db.WithContext(ctx).Model(&model.Order{})
+
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.ProductID != 0 {
+		query = query.Where("EXISTS (SELECT 1 FROM order_items oi WHERE oi.order_id = orders.id AND oi.product_id = ?)", filter.ProductID)
+	}
+	if filter.Query != "" {
+		query = query.Where("EXISTS (SELECT 1 FROM order_items oi WHERE oi.order_id = orders.id AND oi.product_name ILIKE ?)", "%"+escapeLike(filter.Query)+"%")
+	}
+	if filter.MinTotal != nil {
+		query = query.Where("total_amount_minor >= ?", *filter.MinTotal)
+	}
+	if filter.MaxTotal != nil {
+		query = query.Where("total_amount_minor <= ?", *filter.MaxTotal)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at <= ?", *filter.To)
+	}
+
+	column := "created_at"
+	if filter.SortBy == OrderSortTotal {
+		column = "total_amount_minor"
+	}
+	direction, op := "DESC", "<"
+	if filter.Ascending {
+		direction, op = "ASC", ">"
+	}
+
+	if filter.After != nil {
+		var value interface{} = filter.After.CreatedAt
+		if filter.SortBy == OrderSortTotal {
+			value = filter.After.Total
+		}
+		query = query.Where(fmt.Sprintf("(%s, id) %s (?, ?)", column, op), value, filter.After.ID)
+	}
+
+	var orders []model.Order
+	err := query.
+		Preload("Items").
+		Preload("Promotions").
+		Order(fmt.Sprintf("%s %s, id %s", column, direction, direction)).
+		Limit(limit).
+		Find(&orders).
+		Error
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("order-service", "SELECT").Observe(duration)
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("order-service", "SELECT").Inc()
+		return nil, err
+	}
+	return orders, nil
+}
+
+// escapeLike escapes LIKE wildcards so that the search string is matched literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
 // UpdateOrder implements OrderRepository.
+// Items and promotions are not touched: they are replaced only by ReplaceOrderItems.
 func (o *OrderRepositoryImpl) UpdateOrder(ctx context.Context, order *model.Order) error {
 	start := time.Now()
-	err := o.db.
+	expected := order.Version
+	order.Version = expected + 1
+
+	res := o.db.
 		WithContext(ctx).
-		Save(order).
-		Error
+		Model(order).
+		Where("version = ?", expected).
+		Select("*").
+		Omit(clause.Associations, "CreatedAt").
+		Updates(order)
 
 	duration := time.Since(start).Seconds()
 	metrics.DBQueryDuration.WithLabelValues("order-service", "UPDATE").Observe(duration)
 
-	if err != nil {
+	if res.Error != nil {
+		order.Version = expected
 		metrics.DBErrors.WithLabelValues("order-service", "UPDATE").Inc()
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		order.Version = expected
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+// ReplaceOrderItems implements OrderRepository.
+// It swaps the order lines, applied promotions and totals in a single transaction,
+// locking the order row so that a concurrent status change cannot slip in.
+func (o *OrderRepositoryImpl) ReplaceOrderItems(ctx context.Context, order *model.Order) error {
+	start := time.Now()
+	err := o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current model.Order
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			First(&current, order.ID).
+			Error; err != nil {
+			return err
+		}
+
+		if current.Version != order.Version {
+			return ErrVersionConflict
+		}
+		if current.Status != "pending" {
+			return ErrOrderNotPending
+		}
+		if err := checkPromotionLimits(tx, order); err != nil {
+			return err
+		}
+
+		if err := tx.
+			Where("order_id = ?", order.ID).
+			Delete(&model.OrderItem{}).
+			Error; err != nil {
+			return err
+		}
+
+		for i := range order.Items {
+			order.Items[i].ID = 0
+			order.Items[i].OrderID = order.ID
+		}
+
+		if err := tx.Create(&order.Items).Error; err != nil {
+			return err
+		}
+
+		// Discounts are recalculated together with the lines
+		if err := tx.
+			Where("order_id = ?", order.ID).
+			Delete(&model.OrderPromotion{}).
+			Error; err != nil {
+			return err
+		}
+
+		for i := range order.Promotions {
+			order.Promotions[i].ID = 0
+			order.Promotions[i].OrderID = order.ID
+		}
+
+		if len(order.Promotions) > 0 {
+			if err := tx.Create(&order.Promotions).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.
+			Model(&model.Order{ID: order.ID}).
+			Updates(map[string]interface{}{
+				"total_amount":       order.TotalAmount,
+				"total_amount_minor": order.TotalAmountMinor,
+				"subtotal_minor":     order.SubtotalMinor,
+				"discount_minor":     order.DiscountMinor,
+				"currency":           order.Currency,
+				"updated_at":         order.UpdatedAt,
+				"version":            order.Version + 1,
+			}).
+			Error
+	})
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("order-service", "UPDATE").Observe(duration)
+
+	if err != nil {
+		if !errors.Is(err, ErrVersionConflict) && !errors.Is(err, ErrOrderNotPending) && !errors.Is(err, ErrPromoLimitReached) {
+			metrics.DBErrors.WithLabelValues("order-service", "UPDATE").Inc()
+		}
 		return err
 	}
+	order.Version++
+	return nil
+}
+
+// checkPromotionLimits re-counts the usage of every applied promotion with a per-user limit
+// under a lock on the promotion row: the service checks the limit before reserving stock,
+// and without the lock parallel checkouts of one user could all pass that check.
+// Promotions are locked in id order so that orders with several of them cannot deadlock.
+func checkPromotionLimits(tx *gorm.DB, order *model.Order) error {
+	ids := make([]int64, 0, len(order.Promotions))
+	for _, p := range order.Promotions {
+		ids = append(ids, p.PromotionID)
+	}
+	slices.Sort(ids)
+
+	for _, id := range slices.Compact(ids) {
+		var promo model.Promotion
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id", "per_user_limit").
+			First(&promo, id).
+			Error; err != nil {
+			return err
+		}
+		if promo.PerUserLimit <= 0 {
+			continue
+		}
+
+		var used int64
+		if err := tx.
+			Model(&model.OrderPromotion{}).
+			Joins("JOIN orders ON orders.id = order_promotions.order_id").
+			Where("order_promotions.promotion_id = ? AND orders.user_id = ? AND orders.status <> ? AND orders.id <> ?",
+				id, order.UserID, "cancelled", order.ID).
+			Count(&used).
+			Error; err != nil {
+			return err
+		}
+		if used >= int64(promo.PerUserLimit) {
+			return &PromoLimitError{PromotionID: id}
+		}
+	}
 	return nil
 }
 
@@ -132,3 +433,98 @@ func (o *OrderRepositoryImpl) Delete(ctx context.Context, orderID int64) error {
 
 	return nil
 }
+
+// staleOrders selects pending orders older than cutoff. Orders with a payment in progress
+// are skipped: the payment outcome may arrive at any moment.
+func staleOrders(db *gorm.DB, cutoff time.Time, limit int) *gorm.DB {
+	return db.
+		Model(&model.Order{}).
+		Where("status = ? AND created_at < ?", "pending", cutoff).
+		Where("NOT EXISTS (SELECT 1 FROM payments p WHERE p.order_id = orders.id AND p.status = ?)", "pending").
+		Order("created_at").
+		Limit(limit)
+}
+
+// FindStaleOrders implements OrderRepository.
+func (o *OrderRepositoryImpl) FindStaleOrders(ctx context.Context, cutoff time.Time, limit int) ([]model.Order, error) {
+	start := time.Now()
+	var orders []model.Order
+	err := staleOrders(o.db.WithContext(ctx), cutoff, limit).
+		Preload("Items").
+		Find(&orders).
+		Error
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("order-service", "SELECT").Observe(duration)
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("order-service", "SELECT").Inc()
+		return nil, err
+	}
+	return orders, nil
+}
+
+// ExpireStaleOrders implements OrderRepository.
+// Rows are claimed with FOR UPDATE SKIP LOCKED, so several replicas can run the expiry
+// concurrently: each one gets its own orders and nobody waits on the others.
+func (o *OrderRepositoryImpl) ExpireStaleOrders(ctx context.Context, cutoff time.Time, limit int, reason string) ([]model.Order, error) {
+	start := time.Now()
+	var orders []model.Order
+	err := o.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := staleOrders(tx, cutoff, limit).
+			Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Find(&orders).
+			Error; err != nil {
+			return err
+		}
+		if len(orders) == 0 {
+			return nil
+		}
+
+		ids := make([]int64, len(orders))
+		for i := range orders {
+			ids[i] = orders[i].ID
+		}
+
+		var items []model.OrderItem
+		if err := tx.Where("order_id IN ?", ids).Find(&items).Error; err != nil {
+			return err
+		}
+		byOrder := make(map[int64][]model.OrderItem, len(orders))
+		for _, item := range items {
+			byOrder[item.OrderID] = append(byOrder[item.OrderID], item)
+		}
+
+		now := time.Now()
+		if err := tx.
+			Model(&model.Order{}).
+			Where("id IN ?", ids).
+			Updates(map[string]interface{}{
+				"status":              "cancelled",
+				"cancellation_reason": reason,
+				"updated_at":          now,
+				"version":             gorm.Expr("version + 1"),
+			}).
+			Error; err != nil {
+			return err
+		}
+
+		for i := range orders {
+			orders[i].Items = byOrder[orders[i].ID]
+			orders[i].Status = "cancelled"
+			orders[i].CancellationReason = reason
+			orders[i].UpdatedAt = now
+			orders[i].Version++
+		}
+		return nil
+	})
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("order-service", "UPDATE").Observe(duration)
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("order-service", "UPDATE").Inc()
+		return nil, err
+	}
+	return orders, nil
+}
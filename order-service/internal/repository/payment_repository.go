@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"order-service/internal/model"
+
+	"github.com/microserviceteam0/bff-gateway/shared/metrics"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrDuplicateEvent is returned when a payment event with the same ID has already been processed.
+var ErrDuplicateEvent = errors.New("payment event already processed")
+
+// ErrRefundExceedsCaptured is returned when a refund is larger than what is left of the captured amount.
+var ErrRefundExceedsCaptured = errors.New("refund exceeds the captured amount")
+
+type PaymentRepository interface {
+	// CreatePayment locks the order row, passes the order's payments to prepare and inserts payment if prepare succeeds.
+	// Concurrent payments of one order are serialized, so two of them cannot both pass the check in prepare;
+	// an error of prepare is returned as is.
+	CreatePayment(ctx context.Context, payment *model.Payment, prepare func(existing []model.Payment) error) error
+	GetPayment(ctx context.Context, paymentID int64) (*model.Payment, error)
+	GetPaymentByProviderRef(ctx context.Context, providerRef string) (*model.Payment, error)
+	ListPaymentsByOrderID(ctx context.Context, orderID int64) ([]model.Payment, error)
+	// SavePayment stores the payment together with the order status it caused and the event that triggered it.
+	// order and event are optional. The order is updated only if its version has not changed since it was read,
+	// otherwise nothing is saved and ErrVersionConflict is returned.
+	SavePayment(ctx context.Context, payment *model.Payment, order *model.Order, event *model.PaymentEvent) error
+	// ReserveRefund adds amountMinor to the refunded amount if the captured amount still covers it,
+	// otherwise it yields ErrRefundExceedsCaptured. The refunded amount is changed only here and in ReleaseRefund,
+	// so concurrent refunds of one payment cannot exceed the captured amount or overwrite each other.
+	ReserveRefund(ctx context.Context, paymentID int64, amountMinor int64) error
+	// ReleaseRefund takes back a reservation whose refund the provider rejected.
+	ReleaseRefund(ctx context.Context, paymentID int64, amountMinor int64) error
+}
+
+type PaymentRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPaymentRepository(db *gorm.DB) PaymentRepository {
+	return &PaymentRepositoryImpl{db: db}
+}
+
+// CreatePayment implements PaymentRepository.
+func (p *PaymentRepositoryImpl) CreatePayment(ctx context.Context, payment *model.Payment, prepare func(existing []model.Payment) error) error {
+	start := time.Now()
+	var prepareErr error
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			First(&model.Order{}, payment.OrderID).
+			Error; err != nil {
+			return err
+		}
+
+		var existing []model.Payment
+		if err := tx.
+			Where("order_id = ?", payment.OrderID).
+			Order("id").
+			Find(&existing).
+			Error; err != nil {
+			return err
+		}
+
+		if prepareErr = prepare(existing); prepareErr != nil {
+			return prepareErr
+		}
+		return tx.Create(payment).Error
+	})
+	if prepareErr != nil {
+		return prepareErr
+	}
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("order-service", "INSERT").Observe(duration)
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("order-service", "INSERT").Inc()
+		return err
+	}
+	return nil
+}
+
+// GetPayment implements PaymentRepository.
+func (p *PaymentRepositoryImpl) GetPayment(ctx context.Context, paymentID int64) (*model.Payment, error) {
+	start := time.Now()
+	var payment model.Payment
+	err := p.db.
+		WithContext(ctx).
+		First(&payment, paymentID).
+		Error
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("order-service", "SELECT").Observe(duration)
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("order-service", "SELECT").Inc()
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// GetPaymentByProviderRef implements PaymentRepository.
+func (p *PaymentRepositoryImpl) GetPaymentByProviderRef(ctx context.Context, providerRef string) (*model.Payment, error) {
+	start := time.Now()
+	var payment model.Payment
+	err := p.db.
+		WithContext(ctx).
+		Where("provider_ref = ?", providerRef).
+		First(&payment).
+		Error
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("order-service", "SELECT").Observe(duration)
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("order-service", "SELECT").Inc()
+		return nil, err
+	}
+	return &payment, nil
+}
+
+// ListPaymentsByOrderID implements PaymentRepository.
+func (p *PaymentRepositoryImpl) ListPaymentsByOrderID(ctx context.Context, orderID int64) ([]model.Payment, error) {
+	start := time.Now()
+	var payments []model.Payment
+	err := p.db.
+		WithContext(ctx).
+		Where("order_id = ?", orderID).
+		Order("id").
+		Find(&payments).
+		Error
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("order-service", "SELECT").Observe(duration)
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("order-service", "SELECT").Inc()
+		return nil, err
+	}
+	return payments, nil
+}
+
+// SavePayment implements PaymentRepository.
+func (p *PaymentRepositoryImpl) SavePayment(ctx context.Context, payment *model.Payment, order *model.Order, event *model.PaymentEvent) error {
+	start := time.Now()
+	err := p.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if event != nil {
+			event.PaymentID = payment.ID
+			res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(event)
+			if res.Error != nil {
+				return res.Error
+			}
+			if res.RowsAffected == 0 {
+				return ErrDuplicateEvent
+			}
+		}
+
+		// refunded_minor is changed only by ReserveRefund and ReleaseRefund
+		if err := tx.Omit("RefundedMinor").Save(payment).Error; err != nil {
+			return err
+		}
+
+		if order == nil {
+			return nil
+		}
+		res := tx.
+			Model(&model.Order{}).
+			Where("id = ? AND version = ?", order.ID, order.Version).
+			Updates(map[string]interface{}{
+				"status":              order.Status,
+				"cancellation_reason": order.CancellationReason,
+				"updated_at":          order.UpdatedAt,
+				"version":             order.Version + 1,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrVersionConflict
+		}
+		return nil
+	})
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("order-service", "UPDATE").Observe(duration)
+
+	if err != nil && !errors.Is(err, ErrDuplicateEvent) && !errors.Is(err, ErrVersionConflict) {
+		metrics.DBErrors.WithLabelValues("order-service", "UPDATE").Inc()
+		return err
+	}
+	if err == nil && order != nil {
+		order.Version++
+	}
+	return err
+}
+
+// ReserveRefund implements PaymentRepository.
+func (p *PaymentRepositoryImpl) ReserveRefund(ctx context.Context, paymentID int64, amountMinor int64) error {
+	start := time.Now()
+	res := p.db.
+		WithContext(ctx).
+		Model(&model.Payment{}).
+		Where("id = ? AND captured_minor - refunded_minor >= ?", paymentID, amountMinor).
+		Update("refunded_minor", gorm.Expr("refunded_minor + ?", amountMinor))
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("order-service", "UPDATE").Observe(duration)
+
+	if res.Error != nil {
+		metrics.DBErrors.WithLabelValues("order-service", "UPDATE").Inc()
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrRefundExceedsCaptured
+	}
+	return nil
+}
+
+// ReleaseRefund implements PaymentRepository.
+func (p *PaymentRepositoryImpl) ReleaseRefund(ctx context.Context, paymentID int64, amountMinor int64) error {
+	start := time.Now()
+	err := p.db.
+		WithContext(ctx).
+		Model(&model.Payment{}).
+		Where("id = ?", paymentID).
+		Update("refunded_minor", gorm.Expr("refunded_minor - ?", amountMinor)).
+		Error
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("order-service", "UPDATE").Observe(duration)
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("order-service", "UPDATE").Inc()
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"order-service/internal/model"
+
+	"github.com/microserviceteam0/bff-gateway/shared/metrics"
+	"gorm.io/gorm"
+)
+
+type PromotionRepository interface {
+	CreatePromotion(ctx context.Context, promotion *model.Promotion) error
+	UpdatePromotion(ctx context.Context, promotion *model.Promotion) error
+	GetPromotion(ctx context.Context, promotionID int64) (*model.Promotion, error)
+	GetPromotionByCode(ctx context.Context, code string) (*model.Promotion, error)
+	ListPromotions(ctx context.Context, activeOnly bool, limit int, offset int) ([]model.Promotion, int64, error)
+	ListAutomaticPromotions(ctx context.Context) ([]model.Promotion, error)
+	CountUserUsage(ctx context.Context, promotionID int64, userID int64) (int64, error)
+}
+
+type PromotionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPromotionRepository(db *gorm.DB) PromotionRepository {
+	return &PromotionRepositoryImpl{db: db}
+}
+
+// CreatePromotion implements PromotionRepository.
+func (p *PromotionRepositoryImpl) CreatePromotion(ctx context.Context, promotion *model.Promotion) error {
+	start := time.Now()
+	err := p.db.
+		WithContext(ctx).
+		Create(promotion).
+		Error
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("order-service", "INSERT").Observe(duration)
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("order-service", "INSERT").Inc()
+		return err
+	}
+	return nil
+}
+
+// UpdatePromotion implements PromotionRepository.
+func (p *PromotionRepositoryImpl) UpdatePromotion(ctx context.Context, promotion *model.Promotion) error {
+	start := time.Now()
+	err := p.db.
+		WithContext(ctx).
+		Save(promotion).
+		Error
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("order-service", "UPDATE").Observe(duration)
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("order-service", "UPDATE").Inc()
+		return err
+	}
+	return nil
+}
+
+// GetPromotion implements PromotionRepository.
+func (p *PromotionRepositoryImpl) GetPromotion(ctx context.Context, promotionID int64) (*model.Promotion, error) {
+	start := time.Now()
+	var promotion model.Promotion
+	err := p.db.
+		WithContext(ctx).
+		First(&promotion, promotionID).
+		Error
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("order-service", "SELECT").Observe(duration)
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("order-service", "SELECT").Inc()
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+// GetPromotionByCode implements PromotionRepository.
+func (p *PromotionRepositoryImpl) GetPromotionByCode(ctx context.Context, code string) (*model.Promotion, error) {
+	start := time.Now()
+	var promotion model.Promotion
+	err := p.db.
+		WithContext(ctx).
+		Where("code = ?", code).
+		First(&promotion).
+		Error
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("order-service", "SELECT").Observe(duration)
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("order-service", "SELECT").Inc()
+		return nil, err
+	}
+	return &promotion, nil
+}
+
+// ListPromotions implements PromotionRepository.
+func (p *PromotionRepositoryImpl) ListPromotions(ctx context.Context, activeOnly bool, limit int, offset int) ([]model.Promotion, int64, error) {
+	start := time.Now()
+	var promotions []model.Promotion
+	var total int64
+
+	query := p.db.WithContext(ctx).Model(&model.Promotion{})
+	if activeOnly {
+		query = query.Where("active = ?", true)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		metrics.DBErrors.WithLabelValues("order-service", "SELECT").Inc()
+		return nil, 0, err
+	}
+
+	err := query.
+		Order("id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&promotions).
+		Error
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("order-service", "SELECT").Observe(duration)
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("order-service", "SELECT").Inc()
+		return nil, 0, err
+	}
+	return promotions, total, nil
+}
+
+// ListAutomaticPromotions implements PromotionRepository.
+// Validity windows and basket rules are checked by the promotion engine.
+func (p *PromotionRepositoryImpl) ListAutomaticPromotions(ctx context.Context) ([]model.Promotion, error) {
+	start := time.Now()
+	var promotions []model.Promotion
+	err := p.db.
+		WithContext(ctx).
+		Where("active = ? AND code IS NULL", true).
+		Order("id ASC").
+		Find(&promotions).
+		Error
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("order-service", "SELECT").Observe(duration)
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("order-service", "SELECT").Inc()
+		return nil, err
+	}
+	return promotions, nil
+}
+
+// CountUserUsage implements PromotionRepository.
+// Cancelled orders do not count towards the per-user limit.
+func (p *PromotionRepositoryImpl) CountUserUsage(ctx context.Context, promotionID int64, userID int64) (int64, error) {
+	start := time.Now()
+	var count int64
+	err := p.db.
+		WithContext(ctx).
+		Model(&model.OrderPromotion{}).
+		Joins("JOIN orders ON orders.id = order_promotions.order_id").
+		Where("order_promotions.promotion_id = ? AND orders.user_id = ? AND orders.status <> ?", promotionID, userID, "cancelled").
+		Count(&count).
+		Error
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("order-service", "SELECT").Observe(duration)
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("order-service", "SELECT").Inc()
+		return 0, err
+	}
+	return count, nil
+}
@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"order-service/internal/model"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrReturnStatusChanged is returned when the return left the expected status while it was being processed.
+var ErrReturnStatusChanged = errors.New("return status changed")
+
+// ReturnFilter narrows ListReturns; zero fields are ignored.
+type ReturnFilter struct {
+	OrderID int64
+	UserID  int64
+	Status  string
+}
+
+type ReturnRepository interface {
+	// CreateReturn locks the order row, passes the order's returns to prepare and inserts ret if prepare succeeds.
+	// Concurrent requests for one order are serialized, so the quantities prepare checks cannot change before the insert;
+	// an error of prepare is returned as is.
+	CreateReturn(ctx context.Context, ret *model.OrderReturn, prepare func(existing []model.OrderReturn) error) error
+	GetReturn(ctx context.Context, returnID int64) (*model.OrderReturn, error)
+	ListReturnsByOrderID(ctx context.Context, orderID int64) ([]model.OrderReturn, error)
+	ListReturns(ctx context.Context, filter ReturnFilter, limit int, offset int) ([]model.OrderReturn, int64, error)
+	// UpdateReturn saves the return only if it is still in fromStatus; otherwise it yields ErrReturnStatusChanged.
+	// Items are not touched.
+	UpdateReturn(ctx context.Context, ret *model.OrderReturn, fromStatus string) error
+	// CompleteRefund stores the refunded return, the status of the payment it was refunded to (nil for a zero refund)
+	// and order.Status in one transaction; the refund is added to the order's refunded amount.
+	// The order is updated only if its version has not changed since it was read,
+	// otherwise nothing is saved and ErrVersionConflict is returned.
+	// The payment's refunded amount must already be reserved with PaymentRepository.ReserveRefund.
+	CompleteRefund(ctx context.Context, ret *model.OrderReturn, payment *model.Payment, order *model.Order) error
+}
+
+type ReturnRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewReturnRepository(db *gorm.DB) ReturnRepository {
+	return &ReturnRepositoryImpl{db: db}
+}
+
+// CreateReturn implements ReturnRepository.
+func (r *ReturnRepositoryImpl) CreateReturn(ctx context.Context, ret *model.OrderReturn, prepare func(existing []model.OrderReturn) error) error {
+	start := time.Now()
+	var prepareErr error
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			First(&model.Order{}, ret.OrderID).
+			Error; err != nil {
+			return err
+		}
+
+		var existing []model.OrderReturn
+		if err := tx.
+			Preload("Items").
+			Where("order_id = ?", ret.OrderID).
+			Order("id").
+			Find(&existing).
+			Error; err != nil {
+			return err
+		}
+
+		if prepareErr = prepare(existing); prepareErr != nil {
+			return prepareErr
+		}
+		return tx.Create(ret).Error
+	})
+	if prepareErr != nil {
+		return prepareErr
+	}
+	observeQuery("INSERT", start, err)
+	return err
+}
+
+// GetReturn implements ReturnRepository.
+func (r *ReturnRepositoryImpl) GetReturn(ctx context.Context, returnID int64) (*model.OrderReturn, error) {
+	start := time.Now()
+	var ret model.OrderReturn
+	err := r.db.
+		WithContext(ctx).
+		Preload("Items").
+		First(&ret, returnID).
+		Error
+	observeQuery("SELECT", start, err)
+	if err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+// ListReturnsByOrderID implements ReturnRepository.
+func (r *ReturnRepositoryImpl) ListReturnsByOrderID(ctx context.Context, orderID int64) ([]model.OrderReturn, error) {
+	start := time.Now()
+	var returns []model.OrderReturn
+	err := r.db.
+		WithContext(ctx).
+		Preload("Items").
+		Where("order_id = ?", orderID).
+		Order("id").
+		Find(&returns).
+		Error
+	observeQuery("SELECT", start, err)
+	if err != nil {
+		return nil, err
+	}
+	return returns, nil
+}
+
+// ListReturns implements ReturnRepository.
+func (r *ReturnRepositoryImpl) ListReturns(ctx context.Context, filter ReturnFilter, limit int, offset int) ([]model.OrderReturn, int64, error) {
+	start := time.Now()
+	query := r.db.WithContext(ctx).Model(&model.OrderReturn{})
+	if filter.OrderID != 0 {
+		query = query.Where("order_id = ?", filter.OrderID)
+	}
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var (
+		returns []model.OrderReturn
+		total   int64
+	)
+	err := query.Count(&total).Error
+	if err == nil {
+		err = query.
+			Preload("Items").
+			Order("created_at DESC, id DESC").
+			Limit(limit).
+			Offset(offset).
+			Find(&returns).
+			Error
+	}
+	observeQuery("SELECT", start, err)
+	if err != nil {
+		return nil, 0, err
+	}
+	return returns, total, nil
+}
+
+// UpdateReturn implements ReturnRepository.
+func (r *ReturnRepositoryImpl) UpdateReturn(ctx context.Context, ret *model.OrderReturn, fromStatus string) error {
+	start := time.Now()
+	res := r.db.
+		WithContext(ctx).
+		Model(ret).
+		Where("status = ?", fromStatus).
+		Select("*").
+		Omit(clause.Associations, "CreatedAt").
+		Updates(ret)
+	observeQuery("UPDATE", start, res.Error)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrReturnStatusChanged
+	}
+	return nil
+}
+
+// CompleteRefund implements ReturnRepository.
+func (r *ReturnRepositoryImpl) CompleteRefund(ctx context.Context, ret *model.OrderReturn, payment *model.Payment, order *model.Order) error {
+	start := time.Now()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// A zero refund (the item was sold at a 100% discount) has no payment.
+		// The amount is already reserved on the payment, only its status is saved here
+		if payment != nil {
+			if err := tx.
+				Model(payment).
+				Update("status", payment.Status).
+				Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.
+			Model(ret).
+			Select("*").
+			Omit(clause.Associations, "CreatedAt").
+			Updates(ret).
+			Error; err != nil {
+			return err
+		}
+
+		// The amount is added in SQL so that concurrent refunds of one order do not overwrite each other
+		res := tx.
+			Model(&model.Order{}).
+			Where("id = ? AND version = ?", order.ID, order.Version).
+			Updates(map[string]interface{}{
+				"status":         order.Status,
+				"refunded_minor": gorm.Expr("refunded_minor + ?", ret.RefundMinor),
+				"updated_at":     time.Now(),
+				"version":        order.Version + 1,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrVersionConflict
+		}
+		return nil
+	})
+	if errors.Is(err, ErrVersionConflict) {
+		observeQuery("UPDATE", start, nil)
+		return err
+	}
+	observeQuery("UPDATE", start, err)
+	if err == nil {
+		order.Version++
+	}
+	return err
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"order-service/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type TemplateRepository interface {
+	CreateTemplate(ctx context.Context, template *model.OrderTemplate) error
+	GetTemplate(ctx context.Context, templateID int64) (*model.OrderTemplate, error)
+	ListTemplatesByUserID(ctx context.Context, userID int64) ([]model.OrderTemplate, error)
+	DeleteTemplate(ctx context.Context, templateID int64) error
+}
+
+type TemplateRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewTemplateRepository(db *gorm.DB) TemplateRepository {
+	return &TemplateRepositoryImpl{db: db}
+}
+
+// CreateTemplate implements TemplateRepository.
+func (r *TemplateRepositoryImpl) CreateTemplate(ctx context.Context, template *model.OrderTemplate) error {
+	start := time.Now()
+	err := r.db.WithContext(ctx).Create(template).Error
+	observeQuery("INSERT", start, err)
+	return err
+}
+
+// GetTemplate implements TemplateRepository.
+func (r *TemplateRepositoryImpl) GetTemplate(ctx context.Context, templateID int64) (*model.OrderTemplate, error) {
+	start := time.Now()
+	var template model.OrderTemplate
+	err := r.db.
+		WithContext(ctx).
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		First(&template, templateID).
+		Error
+	observeQuery("SELECT", start, err)
+	if err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// ListTemplatesByUserID implements TemplateRepository.
+func (r *TemplateRepositoryImpl) ListTemplatesByUserID(ctx context.Context, userID int64) ([]model.OrderTemplate, error) {
+	start := time.Now()
+	var templates []model.OrderTemplate
+	err := r.db.
+		WithContext(ctx).
+		Preload("Items", func(db *gorm.DB) *gorm.DB { return db.Order("id") }).
+		Where("user_id = ?", userID).
+		Order("name").
+		Find(&templates).
+		Error
+	observeQuery("SELECT", start, err)
+	if err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// DeleteTemplate implements TemplateRepository. Items are deleted by cascade.
+func (r *TemplateRepositoryImpl) DeleteTemplate(ctx context.Context, templateID int64) error {
+	start := time.Now()
+	err := r.db.WithContext(ctx).Delete(&model.OrderTemplate{}, templateID).Error
+	observeQuery("DELETE", start, err)
+	return err
+}
@@ -0,0 +1,375 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	pb "order-service/api/order/v1"
+	"order-service/internal/compensation"
+	"order-service/internal/model"
+	productpb "order-service/pkg/api/product/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// compensateStock применяет изменение остатка, а при ошибке ставит его в очередь повторов.
+// Вызывается там, где заказ уже изменен и вернуть ошибку клиенту нельзя
+func (s *OrderServiceImpl) compensateStock(ctx context.Context, orderID int64, key model.StockKey, delta int32, reason string) {
+	_, err := s.productClient.UpdateStock(ctx, key.ProductID, key.VariantID, delta, reason, orderID)
+	if err == nil {
+		return
+	}
+	s.enqueueCompensation(ctx, orderID, key, delta, reason, err)
+}
+
+// compensateStockBatch возвращает на склад несколько вариантов одним BatchUpdateStock.
+// Пакет применяется целиком или не применяется, поэтому при ошибке в очередь встают все позиции
+func (s *OrderServiceImpl) compensateStockBatch(ctx context.Context, orderID int64, deltas map[model.StockKey]int32, reason string) {
+	items := stockAdjustments(s.resolveLegacyVariants(ctx, deltas))
+	if len(items) == 0 {
+		return
+	}
+	_, err := s.productClient.BatchUpdateStock(ctx, items, reason, orderID)
+	if err == nil {
+		return
+	}
+	for _, item := range items {
+		key := model.StockKey{ProductID: item.ProductId, VariantID: item.VariantId}
+		s.enqueueCompensation(ctx, orderID, key, item.QuantityDelta, reason, err)
+	}
+}
+
+// resolveLegacyVariants переводит позиции заказов, созданных до появления вариантов (variant_id 0),
+// на вариант товара по умолчанию: у товара с несколькими вариантами Product Service не примет позицию без варианта.
+// Если товары получить не удалось, позиции остаются как есть
+func (s *OrderServiceImpl) resolveLegacyVariants(ctx context.Context, deltas map[model.StockKey]int32) map[model.StockKey]int32 {
+	var productIDs []int64
+	for key := range deltas {
+		if key.VariantID == 0 {
+			productIDs = append(productIDs, key.ProductID)
+		}
+	}
+	if len(productIDs) == 0 {
+		return deltas
+	}
+	productsMap, err := s.productClient.GetProducts(ctx, productIDs)
+	if err != nil {
+		slog.Warn("Failed to resolve variants of legacy order lines", "error", err)
+		return deltas
+	}
+
+	resolved := make(map[model.StockKey]int32, len(deltas))
+	for key, delta := range deltas {
+		resolved[legacyStockKey(key, productsMap)] += delta
+	}
+	return resolved
+}
+
+func (s *OrderServiceImpl) enqueueCompensation(ctx context.Context, orderID int64, key model.StockKey, delta int32, reason string, err error) {
+	c := &model.StockCompensation{
+		OrderID:       orderID,
+		ProductID:     key.ProductID,
+		VariantID:     key.VariantID,
+		QuantityDelta: delta,
+		Reason:        reason,
+		Attempts:      1,
+		LastError:     err.Error(),
+		NextAttemptAt: time.Now().Add(compensation.Backoff(1)),
+	}
+	// Запрос клиента мог уже завершиться, а запись в очередь терять нельзя
+	if qerr := s.compensationRepo.Enqueue(context.WithoutCancel(ctx), c); qerr != nil {
+		slog.Error("FAILED_TO_RESTORE_STOCK",
+			"order_id", orderID,
+			"product_id", key.ProductID,
+			"variant_id", key.VariantID,
+			"quantity_delta", delta,
+			"error", err,
+			"enqueue_error", qerr,
+		)
+		return
+	}
+
+	slog.Warn("Stock change failed, queued for retry",
+		"compensation_id", c.ID,
+		"order_id", orderID,
+		"product_id", key.ProductID,
+		"variant_id", key.VariantID,
+		"quantity_delta", delta,
+		"error", err,
+	)
+}
+
+// stockAdjustments превращает изменения остатков в позиции BatchUpdateStock. Нулевые изменения
+// Product Service не принимает, поэтому они пропускаются; позиции идут по возрастанию (product_id, variant_id),
+// чтобы одинаковые пакеты давали одинаковые запросы
+func stockAdjustments(deltas map[model.StockKey]int32) []*productpb.StockAdjustment {
+	keys := slices.SortedFunc(maps.Keys(deltas), func(a, b model.StockKey) int {
+		if c := cmp.Compare(a.ProductID, b.ProductID); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.VariantID, b.VariantID)
+	})
+	items := make([]*productpb.StockAdjustment, 0, len(keys))
+	for _, key := range keys {
+		if deltas[key] == 0 {
+			continue
+		}
+		items = append(items, &productpb.StockAdjustment{
+			ProductId:     key.ProductID,
+			VariantId:     key.VariantID,
+			QuantityDelta: deltas[key],
+		})
+	}
+	return items
+}
+
+// nonZeroDeltas убирает нулевые изменения: Product Service их не принимает
+func nonZeroDeltas(deltas map[model.StockKey]int32) map[model.StockKey]int32 {
+	result := make(map[model.StockKey]int32, len(deltas))
+	for key, delta := range deltas {
+		if delta != 0 {
+			result[key] = delta
+		}
+	}
+	return result
+}
+
+func (s *OrderServiceImpl) ListStockCompensations(ctx context.Context, req *pb.ListStockCompensationsRequest) (*pb.ListStockCompensationsResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	page := req.Page
+	pageSize := req.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+	limit, offset := int(pageSize), int((page-1)*pageSize)
+
+	var (
+		result []*pb.StockCompensation
+		total  int64
+	)
+	if req.Dead {
+		dead, count, err := s.compensationRepo.ListDead(ctx, req.IncludeResolved, limit, offset)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to list compensations: %v", err)
+		}
+		for i := range dead {
+			result = append(result, deadCompensationToProto(&dead[i]))
+		}
+		total = count
+	} else {
+		queued, count, err := s.compensationRepo.List(ctx, limit, offset)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to list compensations: %v", err)
+		}
+		for i := range queued {
+			result = append(result, compensationToProto(&queued[i]))
+		}
+		total = count
+	}
+
+	return &pb.ListStockCompensationsResponse{
+		Compensations: result,
+		TotalCount:    int32(total),
+		Page:          page,
+		PageSize:      pageSize,
+	}, nil
+}
+
+// RetryStockCompensation сразу применяет изменение остатка. Успешная запись из очереди удаляется,
+// из dead-letter — помечается решенной; при ошибке фиксируется попытка и возвращается STOCK_ERROR
+func (s *OrderServiceImpl) RetryStockCompensation(ctx context.Context, req *pb.RetryStockCompensationRequest) (*pb.RetryStockCompensationResponse, error) {
+	adminID, err := s.requireAdminID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Dead {
+		dead, err := s.getDeadCompensation(ctx, req.Id)
+		if err != nil {
+			return nil, err
+		}
+		if dead.ResolvedAt != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "ALREADY_RESOLVED: Compensation is already resolved")
+		}
+
+		if _, err := s.productClient.UpdateStock(ctx, dead.ProductID, dead.VariantID, dead.QuantityDelta, dead.Reason, dead.OrderID); err != nil {
+			dead.Attempts++
+			dead.LastError = err.Error()
+			if serr := s.compensationRepo.SaveDead(ctx, dead); serr != nil {
+				slog.Error("Failed to record compensation attempt", "id", dead.ID, "error", serr)
+			}
+			return nil, status.Errorf(codes.Unavailable, "STOCK_ERROR: %v", err)
+		}
+
+		now := time.Now()
+		dead.Attempts++
+		dead.ResolvedAt = &now
+		dead.ResolvedBy = adminID
+		dead.Resolution = "retried"
+		if err := s.compensationRepo.SaveDead(ctx, dead); err != nil {
+			return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Stock updated but compensation not saved: %v", err)
+		}
+
+		return &pb.RetryStockCompensationResponse{
+			Result: &pb.RetryStockCompensationResponse_Compensation{
+				Compensation: deadCompensationToProto(dead),
+			},
+		}, nil
+	}
+
+	c, err := s.getCompensation(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.productClient.UpdateStock(ctx, c.ProductID, c.VariantID, c.QuantityDelta, c.Reason, c.OrderID); err != nil {
+		c.Attempts++
+		c.LastError = err.Error()
+		c.NextAttemptAt = time.Now().Add(compensation.Backoff(c.Attempts))
+		if serr := s.compensationRepo.Save(ctx, c); serr != nil {
+			slog.Error("Failed to record compensation attempt", "id", c.ID, "error", serr)
+		}
+		return nil, status.Errorf(codes.Unavailable, "STOCK_ERROR: %v", err)
+	}
+
+	c.Attempts++
+	if err := s.compensationRepo.Delete(ctx, c.ID); err != nil {
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Stock updated but compensation not removed: %v", err)
+	}
+
+	return &pb.RetryStockCompensationResponse{
+		Result: &pb.RetryStockCompensationResponse_Compensation{
+			Compensation: compensationToProto(c),
+		},
+	}, nil
+}
+
+// ResolveStockCompensation закрывает запись без изменения остатков. Запись из очереди
+// переносится в dead-letter уже решенной, чтобы осталась история
+func (s *OrderServiceImpl) ResolveStockCompensation(ctx context.Context, req *pb.ResolveStockCompensationRequest) (*pb.ResolveStockCompensationResponse, error) {
+	adminID, err := s.requireAdminID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Resolution == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: resolution is required")
+	}
+
+	now := time.Now()
+	var dead *model.DeadStockCompensation
+	if req.Dead {
+		dead, err = s.getDeadCompensation(ctx, req.Id)
+		if err != nil {
+			return nil, err
+		}
+		if dead.ResolvedAt != nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "ALREADY_RESOLVED: Compensation is already resolved")
+		}
+	} else {
+		c, err := s.getCompensation(ctx, req.Id)
+		if err != nil {
+			return nil, err
+		}
+		dead = c.Dead(now)
+	}
+
+	dead.ResolvedAt = &now
+	dead.ResolvedBy = adminID
+	dead.Resolution = req.Resolution
+
+	if req.Dead {
+		err = s.compensationRepo.SaveDead(ctx, dead)
+	} else {
+		err = s.compensationRepo.MoveToDeadLetter(ctx, req.Id, dead)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to resolve compensation: %v", err)
+	}
+
+	return &pb.ResolveStockCompensationResponse{
+		Result: &pb.ResolveStockCompensationResponse_Compensation{
+			Compensation: deadCompensationToProto(dead),
+		},
+	}, nil
+}
+
+func (s *OrderServiceImpl) requireAdminID(ctx context.Context) (int64, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return 0, err
+	}
+	userID, _, _ := s.getUserInfoFromContext(ctx)
+	return userID, nil
+}
+
+func (s *OrderServiceImpl) getCompensation(ctx context.Context, id int64) (*model.StockCompensation, error) {
+	c, err := s.compensationRepo.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "NOT_FOUND: Compensation not found")
+		}
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to get compensation: %v", err)
+	}
+	return c, nil
+}
+
+func (s *OrderServiceImpl) getDeadCompensation(ctx context.Context, id int64) (*model.DeadStockCompensation, error) {
+	dead, err := s.compensationRepo.GetDead(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "NOT_FOUND: Compensation not found")
+		}
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to get compensation: %v", err)
+	}
+	return dead, nil
+}
+
+func compensationToProto(c *model.StockCompensation) *pb.StockCompensation {
+	return &pb.StockCompensation{
+		Id:            c.ID,
+		OrderId:       c.OrderID,
+		ProductId:     c.ProductID,
+		VariantId:     c.VariantID,
+		QuantityDelta: c.QuantityDelta,
+		Reason:        c.Reason,
+		Attempts:      c.Attempts,
+		LastError:     c.LastError,
+		NextAttemptAt: timestamppb.New(c.NextAttemptAt),
+		CreatedAt:     timestamppb.New(c.CreatedAt),
+	}
+}
+
+func deadCompensationToProto(d *model.DeadStockCompensation) *pb.StockCompensation {
+	result := &pb.StockCompensation{
+		Id:            d.ID,
+		Dead:          true,
+		OrderId:       d.OrderID,
+		ProductId:     d.ProductID,
+		VariantId:     d.VariantID,
+		QuantityDelta: d.QuantityDelta,
+		Reason:        d.Reason,
+		Attempts:      d.Attempts,
+		LastError:     d.LastError,
+		CreatedAt:     timestamppb.New(d.CreatedAt),
+		DeadAt:        timestamppb.New(d.DeadAt),
+		Resolution:    d.Resolution,
+	}
+	if d.ResolvedAt != nil {
+		result.ResolvedAt = timestamppb.New(*d.ResolvedAt)
+	}
+	return result
+}
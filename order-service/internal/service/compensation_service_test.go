@@ -0,0 +1,282 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pb "order-service/api/order/v1"
+	"order-service/internal/model"
+	"order-service/internal/repository"
+	"order-service/internal/service"
+
+	productpb "order-service/pkg/api/product/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// mockCompensationRepository хранит очередь и dead-letter в памяти
+type mockCompensationRepository struct {
+	queued []model.StockCompensation
+	dead   []model.DeadStockCompensation
+}
+
+var _ repository.StockCompensationRepository = (*mockCompensationRepository)(nil)
+
+func (m *mockCompensationRepository) Enqueue(ctx context.Context, c *model.StockCompensation) error {
+	c.ID = int64(len(m.queued) + 1)
+	m.queued = append(m.queued, *c)
+	return nil
+}
+
+func (m *mockCompensationRepository) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]model.StockCompensation, error) {
+	return nil, nil
+}
+
+func (m *mockCompensationRepository) Get(ctx context.Context, id int64) (*model.StockCompensation, error) {
+	for _, c := range m.queued {
+		if c.ID == id {
+			return &c, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *mockCompensationRepository) Save(ctx context.Context, c *model.StockCompensation) error {
+	for i := range m.queued {
+		if m.queued[i].ID == c.ID {
+			m.queued[i] = *c
+		}
+	}
+	return nil
+}
+
+func (m *mockCompensationRepository) Delete(ctx context.Context, id int64) error {
+	for i := range m.queued {
+		if m.queued[i].ID == id {
+			m.queued = append(m.queued[:i], m.queued[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *mockCompensationRepository) MoveToDeadLetter(ctx context.Context, id int64, dead *model.DeadStockCompensation) error {
+	dead.ID = int64(len(m.dead) + 1)
+	m.dead = append(m.dead, *dead)
+	return m.Delete(ctx, id)
+}
+
+func (m *mockCompensationRepository) List(ctx context.Context, limit int, offset int) ([]model.StockCompensation, int64, error) {
+	return m.queued, int64(len(m.queued)), nil
+}
+
+func (m *mockCompensationRepository) GetDead(ctx context.Context, id int64) (*model.DeadStockCompensation, error) {
+	for _, d := range m.dead {
+		if d.ID == id {
+			return &d, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *mockCompensationRepository) SaveDead(ctx context.Context, dead *model.DeadStockCompensation) error {
+	for i := range m.dead {
+		if m.dead[i].ID == dead.ID {
+			m.dead[i] = *dead
+		}
+	}
+	return nil
+}
+
+func (m *mockCompensationRepository) ListDead(ctx context.Context, includeResolved bool, limit int, offset int) ([]model.DeadStockCompensation, int64, error) {
+	var result []model.DeadStockCompensation
+	for _, d := range m.dead {
+		if includeResolved || d.ResolvedAt == nil {
+			result = append(result, d)
+		}
+	}
+	return result, int64(len(result)), nil
+}
+
+func (m *mockCompensationRepository) Count(ctx context.Context) (int64, int64, error) {
+	return int64(len(m.queued)), int64(len(m.dead)), nil
+}
+
+func TestCancelOrderQueuesFailedStockRestore(t *testing.T) {
+	mockRepo := &mockOrderRepository{
+		getOrderFunc: func(ctx context.Context, orderID int64) (*model.Order, error) {
+			return &model.Order{ID: 7, UserID: 1, Status: "pending", Items: []model.OrderItem{
+				{ProductID: 101, Quantity: 2},
+				{ProductID: 102, Quantity: 1},
+			}}, nil
+		},
+		updateOrderFunc: func(ctx context.Context, order *model.Order) error { return nil },
+	}
+	// Пакет возврата не применяется частично, поэтому в очередь встают все позиции заказа
+	mockProd := &mockProductClient{
+		batchUpdateStockFunc: func(ctx context.Context, items []*productpb.StockAdjustment, reason string, orderID int64) (*productpb.BatchUpdateStockResponse, error) {
+			deltas := productDeltas(items)
+			if deltas[101] != 2 || deltas[102] != 1 || reason != model.CompensationOrderCancelled || orderID != 7 {
+				t.Errorf("unexpected batch %v, reason %q, order %d", deltas, reason, orderID)
+			}
+			return nil, errors.New("product service unavailable")
+		},
+	}
+	compensations := &mockCompensationRepository{}
+
+	s := service.NewOrderService(mockRepo, &mockPromotionRepository{}, &mockPaymentRepository{}, compensations, &mockReturnRepository{}, &mockInvoiceRepository{}, nil, &mockTemplateRepository{}, mockProd, nil, nil)
+	if _, err := s.CancelOrder(contextWithAuth("1", "user"), &pb.CancelOrderRequest{OrderId: 7}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(compensations.queued) != 2 {
+		t.Fatalf("expected 2 queued compensations, got %d", len(compensations.queued))
+	}
+	for i, want := range []struct {
+		productID int64
+		delta     int32
+	}{{101, 2}, {102, 1}} {
+		c := compensations.queued[i]
+		if c.OrderID != 7 || c.ProductID != want.productID || c.QuantityDelta != want.delta || c.Reason != model.CompensationOrderCancelled {
+			t.Errorf("unexpected compensation %+v", c)
+		}
+		if c.Attempts != 1 || c.LastError == "" || !c.NextAttemptAt.After(time.Now()) {
+			t.Errorf("expected a scheduled retry after the first failure, got %+v", c)
+		}
+	}
+}
+
+// Позиция заказа, созданного до появления вариантов, возвращается на вариант по умолчанию,
+// даже если у товара с тех пор появились другие варианты
+func TestCancelOrderRestocksLegacyLineToDefaultVariant(t *testing.T) {
+	mockRepo := &mockOrderRepository{
+		getOrderFunc: func(ctx context.Context, orderID int64) (*model.Order, error) {
+			return &model.Order{ID: 7, UserID: 1, Status: "pending", Items: []model.OrderItem{
+				{ProductID: 101, Quantity: 2},
+				{ProductID: 101, VariantID: 12, Quantity: 1},
+			}}, nil
+		},
+		updateOrderFunc: func(ctx context.Context, order *model.Order) error { return nil },
+	}
+	var batch []*productpb.StockAdjustment
+	mockProd := &mockProductClient{
+		getProductsFunc: func(ctx context.Context, ids []int64) (map[int64]*productpb.ProductResponse, error) {
+			return map[int64]*productpb.ProductResponse{
+				101: {Id: 101, Name: "Mug", PriceMinor: 1000, Currency: "RUB", Variants: []*productpb.ProductVariant{
+					{Id: 11, Sku: "P-101"},
+					{Id: 12, Sku: "MUG-RED"},
+				}},
+			}, nil
+		},
+		batchUpdateStockFunc: func(ctx context.Context, items []*productpb.StockAdjustment, reason string, orderID int64) (*productpb.BatchUpdateStockResponse, error) {
+			batch = items
+			return &productpb.BatchUpdateStockResponse{}, nil
+		},
+	}
+	compensations := &mockCompensationRepository{}
+
+	s := service.NewOrderService(mockRepo, &mockPromotionRepository{}, &mockPaymentRepository{}, compensations, &mockReturnRepository{}, &mockInvoiceRepository{}, nil, &mockTemplateRepository{}, mockProd, nil, nil)
+	if _, err := s.CancelOrder(contextWithAuth("1", "user"), &pb.CancelOrderRequest{OrderId: 7}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(batch) != 2 || batch[0].VariantId != 11 || batch[0].QuantityDelta != 2 || batch[1].VariantId != 12 || batch[1].QuantityDelta != 1 {
+		t.Errorf("expected variant 11 +2 and variant 12 +1, got %v", batch)
+	}
+	if len(compensations.queued) != 0 {
+		t.Errorf("expected nothing queued, got %d", len(compensations.queued))
+	}
+}
+
+func TestRetryStockCompensation(t *testing.T) {
+	tests := []struct {
+		name          string
+		dead          bool
+		stockErr      error
+		expectedCode  codes.Code
+		expectQueued  int
+		expectResolve bool
+	}{
+		{name: "Queued success removes entry", expectedCode: codes.OK, expectQueued: 0},
+		{name: "Queued failure records attempt", stockErr: errors.New("down"), expectedCode: codes.Unavailable, expectQueued: 1},
+		{name: "Dead success resolves entry", dead: true, expectedCode: codes.OK, expectResolve: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compensations := &mockCompensationRepository{
+				queued: []model.StockCompensation{{ID: 1, ProductID: 101, QuantityDelta: 2, Attempts: 3}},
+				dead:   []model.DeadStockCompensation{{ID: 1, ProductID: 101, QuantityDelta: 2, Attempts: 10}},
+			}
+			mockProd := &mockProductClient{
+				updateStockFunc: func(ctx context.Context, productID, variantID int64, quantityDelta int32, reason string, orderID int64) (*productpb.UpdateStockResponse, error) {
+					return &productpb.UpdateStockResponse{}, tt.stockErr
+				},
+			}
+			s := service.NewOrderService(&mockOrderRepository{}, &mockPromotionRepository{}, &mockPaymentRepository{}, compensations, &mockReturnRepository{}, &mockInvoiceRepository{}, nil, &mockTemplateRepository{}, mockProd, nil, nil)
+
+			_, err := s.RetryStockCompensation(contextWithAuth("1", "user"), &pb.RetryStockCompensationRequest{Id: 1, Dead: tt.dead})
+			if status.Code(err) != codes.PermissionDenied {
+				t.Fatalf("expected PermissionDenied for non-admin, got %v", err)
+			}
+
+			_, err = s.RetryStockCompensation(contextWithAuth("99", "admin"), &pb.RetryStockCompensationRequest{Id: 1, Dead: tt.dead})
+			if status.Code(err) != tt.expectedCode {
+				t.Fatalf("expected code %v, got %v", tt.expectedCode, err)
+			}
+
+			if tt.dead {
+				if (compensations.dead[0].ResolvedAt != nil) != tt.expectResolve || compensations.dead[0].ResolvedBy != 99 {
+					t.Errorf("unexpected dead entry %+v", compensations.dead[0])
+				}
+				return
+			}
+			if len(compensations.queued) != tt.expectQueued {
+				t.Fatalf("expected %d queued, got %d", tt.expectQueued, len(compensations.queued))
+			}
+			if tt.expectQueued == 1 && compensations.queued[0].Attempts != 4 {
+				t.Errorf("expected attempts to grow to 4, got %d", compensations.queued[0].Attempts)
+			}
+		})
+	}
+}
+
+func TestResolveStockCompensation(t *testing.T) {
+	compensations := &mockCompensationRepository{
+		queued: []model.StockCompensation{{ID: 1, OrderID: 7, ProductID: 101, QuantityDelta: 2}},
+	}
+	s := service.NewOrderService(&mockOrderRepository{}, &mockPromotionRepository{}, &mockPaymentRepository{}, compensations, &mockReturnRepository{}, &mockInvoiceRepository{}, nil, &mockTemplateRepository{}, nil, nil, nil)
+	admin := contextWithAuth("99", "admin")
+
+	if _, err := s.ResolveStockCompensation(admin, &pb.ResolveStockCompensationRequest{Id: 1}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument without resolution, got %v", err)
+	}
+
+	resp, err := s.ResolveStockCompensation(admin, &pb.ResolveStockCompensationRequest{Id: 1, Resolution: "fixed in warehouse"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !resp.GetCompensation().Dead || resp.GetCompensation().ResolvedAt == nil {
+		t.Errorf("expected resolved dead-letter entry, got %+v", resp.GetCompensation())
+	}
+	if len(compensations.queued) != 0 || len(compensations.dead) != 1 {
+		t.Fatalf("expected entry moved to dead letter, got %d queued, %d dead", len(compensations.queued), len(compensations.dead))
+	}
+
+	list, err := s.ListStockCompensations(admin, &pb.ListStockCompensationsRequest{Dead: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(list.Compensations) != 0 {
+		t.Errorf("resolved entries must be hidden by default, got %d", len(list.Compensations))
+	}
+
+	_, err = s.ResolveStockCompensation(admin, &pb.ResolveStockCompensationRequest{Id: 1, Dead: true, Resolution: "again"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected FailedPrecondition for resolved entry, got %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"order-service/internal/model"
+)
+
+// ExpiredReason — причина отмены заказа, который не оплатили за отведенное время
+const ExpiredReason = "expired"
+
+// ExpireStaleOrders отменяет до limit ожидающих заказов, созданных раньше cutoff, и возвращает товар на склад.
+// В режиме dryRun заказы только находятся и логируются. Возвращает ID затронутых заказов
+func (s *OrderServiceImpl) ExpireStaleOrders(ctx context.Context, cutoff time.Time, limit int, dryRun bool) ([]int64, error) {
+	var (
+		orders []model.Order
+		err    error
+	)
+	if dryRun {
+		orders, err = s.repo.FindStaleOrders(ctx, cutoff, limit)
+	} else {
+		orders, err = s.repo.ExpireStaleOrders(ctx, cutoff, limit, ExpiredReason)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int64, len(orders))
+	for i := range orders {
+		ids[i] = orders[i].ID
+
+		if dryRun {
+			slog.Info("Order would expire", "order_id", orders[i].ID, "created_at", orders[i].CreatedAt)
+			continue
+		}
+
+		slog.Info("Order expired", "order_id", orders[i].ID, "created_at", orders[i].CreatedAt)
+		s.restoreStock(ctx, orders[i].ID, orders[i].Items, model.CompensationOrderExpired)
+	}
+	return ids, nil
+}
@@ -0,0 +1,90 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"order-service/internal/model"
+	"order-service/internal/service"
+
+	productpb "order-service/pkg/api/product/v1"
+)
+
+func TestExpireStaleOrders(t *testing.T) {
+	cutoff := time.Now().Add(-30 * time.Minute)
+	stale := []model.Order{
+		{ID: 1, Status: "cancelled", Items: []model.OrderItem{{ProductID: 101, Quantity: 2}}},
+		{ID: 2, Status: "cancelled", Items: []model.OrderItem{{ProductID: 102, Quantity: 1}, {ProductID: 101, Quantity: 3}}},
+	}
+
+	tests := []struct {
+		name            string
+		dryRun          bool
+		expectedRestore map[int64]int32
+		expectExpire    bool
+	}{
+		{
+			name:            "Cancels orders and restores stock",
+			expectedRestore: map[int64]int32{101: 5, 102: 1},
+			expectExpire:    true,
+		},
+		{
+			name:            "Dry run changes nothing",
+			dryRun:          true,
+			expectedRestore: map[int64]int32{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expired := false
+			mockRepo := &mockOrderRepository{
+				findStaleOrdersFunc: func(ctx context.Context, c time.Time, limit int) ([]model.Order, error) {
+					return stale, nil
+				},
+				expireStaleOrdersFunc: func(ctx context.Context, c time.Time, limit int, reason string) ([]model.Order, error) {
+					if !c.Equal(cutoff) || limit != 50 {
+						t.Errorf("unexpected cutoff %v or limit %d", c, limit)
+					}
+					if reason != service.ExpiredReason {
+						t.Errorf("expected reason %q, got %q", service.ExpiredReason, reason)
+					}
+					expired = true
+					return stale, nil
+				},
+			}
+			restored := map[int64]int32{}
+			mockProd := &mockProductClient{
+				updateStockFunc: func(ctx context.Context, productID, variantID int64, quantityDelta int32, reason string, orderID int64) (*productpb.UpdateStockResponse, error) {
+					if reason != model.CompensationOrderExpired || orderID == 0 {
+						t.Errorf("expected stock movement %q with order reference, got %q for order %d", model.CompensationOrderExpired, reason, orderID)
+					}
+					restored[productID] += quantityDelta
+					return &productpb.UpdateStockResponse{}, nil
+				},
+			}
+
+			s := service.NewOrderService(mockRepo, &mockPromotionRepository{}, &mockPaymentRepository{}, &mockCompensationRepository{}, &mockReturnRepository{}, &mockInvoiceRepository{}, nil, &mockTemplateRepository{}, mockProd, nil, nil)
+			ids, err := s.ExpireStaleOrders(context.Background(), cutoff, 50, tt.dryRun)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+				t.Errorf("expected order IDs [1 2], got %v", ids)
+			}
+			if expired != tt.expectExpire {
+				t.Errorf("expected ExpireStaleOrders called = %v, got %v", tt.expectExpire, expired)
+			}
+			if len(restored) != len(tt.expectedRestore) {
+				t.Fatalf("expected restored stock %v, got %v", tt.expectedRestore, restored)
+			}
+			for productID, qty := range tt.expectedRestore {
+				if restored[productID] != qty {
+					t.Errorf("product %d: expected %d restored, got %d", productID, qty, restored[productID])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	pb "order-service/api/order/v1"
+	"order-service/internal/invoice"
+	"order-service/internal/model"
+	"order-service/internal/repository"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// invoiceStatuses — статусы, в которых по заказу можно выставить счет: заказ подтвержден и оплачен
+var invoiceStatuses = map[string]bool{
+	"confirmed":                  true,
+	"processing":                 true,
+	"completed":                  true,
+	model.OrderPartiallyRefunded: true,
+	model.OrderRefunded:          true,
+}
+
+// GetInvoice отдает счет по заказу в запрошенном формате. Первый вызов выпускает счет,
+// дальше отдается сохраненный документ, даже если заказ или шаблоны изменились
+func (s *OrderServiceImpl) GetInvoice(ctx context.Context, req *pb.GetInvoiceRequest) (*pb.GetInvoiceResponse, error) {
+	userID, isAdmin, err := s.getUserInfoFromContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "UNAUTHORIZED: %v", err)
+	}
+
+	format, err := invoice.ParseFormat(req.Format)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "UNSUPPORTED_FORMAT: %v", err)
+	}
+
+	order, err := s.repo.GetOrder(ctx, req.OrderId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "NOT_FOUND: Order not found")
+		}
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to get order: %v", err)
+	}
+
+	if !isAdmin && order.UserID != userID {
+		return nil, status.Errorf(codes.PermissionDenied, "FORBIDDEN: Access denied")
+	}
+
+	inv, err := s.invoiceRepo.GetInvoiceByOrderID(ctx, order.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		inv, err = s.issueInvoice(ctx, order)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	content := inv.HTML
+	if format == invoice.FormatText {
+		content = inv.Text
+	}
+
+	return &pb.GetInvoiceResponse{
+		Result: &pb.GetInvoiceResponse_Document{
+			Document: &pb.InvoiceDocument{
+				Invoice:     invoiceToProto(inv),
+				ContentType: format.ContentType(),
+				Filename:    inv.Number + "." + format.Extension(),
+				Content:     []byte(content),
+			},
+		},
+	}, nil
+}
+
+// issueInvoice выпускает счет по заказу. Если параллельный запрос успел раньше, возвращается его счет
+func (s *OrderServiceImpl) issueInvoice(ctx context.Context, order *model.Order) (*model.Invoice, error) {
+	if !invoiceStatuses[order.Status] {
+		return nil, status.Errorf(codes.FailedPrecondition, "INVOICE_NOT_AVAILABLE: Cannot issue invoice for order with status '%s'", order.Status)
+	}
+
+	total := order.Total()
+	inv := &model.Invoice{
+		OrderID:        order.ID,
+		UserID:         order.UserID,
+		Currency:       total.Currency,
+		SubtotalMinor:  order.Subtotal().Amount,
+		DiscountMinor:  order.DiscountMinor,
+		TotalMinor:     total.Amount,
+		TaxRatePercent: s.invoices.TaxRatePercent(),
+		IssuedAt:       time.Now().UTC(),
+	}
+
+	err := s.invoiceRepo.IssueInvoice(ctx, inv, func(inv *model.Invoice) error {
+		doc := s.invoices.Build(order, inv.Number, inv.IssuedAt)
+		inv.TaxMinor = doc.Tax.Amount
+
+		html, err := s.invoices.Render(doc, invoice.FormatHTML)
+		if err != nil {
+			return err
+		}
+		text, err := s.invoices.Render(doc, invoice.FormatText)
+		if err != nil {
+			return err
+		}
+		inv.HTML, inv.Text = string(html), string(text)
+		return nil
+	})
+	if errors.Is(err, repository.ErrInvoiceExists) {
+		inv, err = s.invoiceRepo.GetInvoiceByOrderID(ctx, order.ID)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to issue invoice: %v", err)
+	}
+
+	slog.Info("Invoice issued", "order_id", order.ID, "number", inv.Number)
+	return inv, nil
+}
+
+func invoiceToProto(inv *model.Invoice) *pb.Invoice {
+	return &pb.Invoice{
+		Id:             inv.ID,
+		Number:         inv.Number,
+		OrderId:        inv.OrderID,
+		UserId:         inv.UserID,
+		Currency:       inv.Currency,
+		SubtotalMinor:  inv.SubtotalMinor,
+		DiscountMinor:  inv.DiscountMinor,
+		TaxMinor:       inv.TaxMinor,
+		TotalMinor:     inv.TotalMinor,
+		TaxRatePercent: int32(inv.TaxRatePercent),
+		IssuedAt:       timestamppb.New(inv.IssuedAt),
+	}
+}
@@ -0,0 +1,168 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	pb "order-service/api/order/v1"
+	"order-service/internal/invoice"
+	"order-service/internal/model"
+	"order-service/internal/repository"
+	"order-service/internal/service"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// mockInvoiceRepository хранит счета в памяти и нумерует их подряд
+type mockInvoiceRepository struct {
+	invoices []model.Invoice
+	issued   int
+}
+
+var _ repository.InvoiceRepository = (*mockInvoiceRepository)(nil)
+
+func (m *mockInvoiceRepository) GetInvoiceByOrderID(ctx context.Context, orderID int64) (*model.Invoice, error) {
+	for _, inv := range m.invoices {
+		if inv.OrderID == orderID {
+			return &inv, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *mockInvoiceRepository) IssueInvoice(ctx context.Context, inv *model.Invoice, render func(inv *model.Invoice) error) error {
+	inv.Number = model.InvoiceNumber(inv.IssuedAt.Year(), int64(m.issued+1))
+	if err := render(inv); err != nil {
+		return err
+	}
+	m.issued++
+	inv.ID = int64(m.issued)
+	m.invoices = append(m.invoices, *inv)
+	return nil
+}
+
+func newInvoiceService(t *testing.T, order *model.Order, invoices *mockInvoiceRepository) service.OrderService {
+	t.Helper()
+	renderer, err := invoice.NewRenderer(invoice.Seller{Name: "Shop"}, 20)
+	if err != nil {
+		t.Fatalf("NewRenderer: %v", err)
+	}
+	repo := &mockOrderRepository{
+		getOrderFunc: func(ctx context.Context, orderID int64) (*model.Order, error) {
+			if orderID != order.ID {
+				return nil, gorm.ErrRecordNotFound
+			}
+			return order, nil
+		},
+	}
+	return service.NewOrderService(repo, &mockPromotionRepository{}, &mockPaymentRepository{}, &mockCompensationRepository{}, &mockReturnRepository{}, invoices, renderer, &mockTemplateRepository{}, nil, nil, nil)
+}
+
+func TestGetInvoice_IssuesOnceAndKeepsDocument(t *testing.T) {
+	order := &model.Order{
+		ID:     1,
+		UserID: 1,
+		Status: "confirmed",
+		Items:  []model.OrderItem{{ProductID: 5, ProductName: "Лампа", PriceMinor: 1200, Currency: "RUB", Quantity: 2}},
+	}
+	order.TotalAmountMinor, order.Currency = 2400, "RUB"
+	invoices := &mockInvoiceRepository{}
+	s := newInvoiceService(t, order, invoices)
+
+	resp, err := s.GetInvoice(contextWithAuth("1", "user"), &pb.GetInvoiceRequest{OrderId: 1})
+	if err != nil {
+		t.Fatalf("GetInvoice: %v", err)
+	}
+	doc := resp.GetDocument()
+	if doc.Invoice.Number == "" || doc.Invoice.TotalMinor != 2400 || doc.Invoice.TaxMinor != 400 {
+		t.Errorf("unexpected invoice: %+v", doc.Invoice)
+	}
+	if doc.ContentType != "text/html; charset=utf-8" || doc.Filename != doc.Invoice.Number+".html" {
+		t.Errorf("unexpected document meta: %s, %s", doc.ContentType, doc.Filename)
+	}
+	if !strings.Contains(string(doc.Content), "Лампа") {
+		t.Errorf("html does not contain line item")
+	}
+
+	// Изменение заказа после выпуска не меняет счет
+	order.Items[0].ProductName = "Торшер"
+	resp, err = s.GetInvoice(contextWithAuth("99", "admin"), &pb.GetInvoiceRequest{OrderId: 1, Format: "text"})
+	if err != nil {
+		t.Fatalf("GetInvoice text: %v", err)
+	}
+	doc = resp.GetDocument()
+	if invoices.issued != 1 {
+		t.Errorf("expected invoice issued once, got %d", invoices.issued)
+	}
+	if doc.ContentType != "text/plain; charset=utf-8" || !strings.HasSuffix(doc.Filename, ".txt") {
+		t.Errorf("unexpected document meta: %s, %s", doc.ContentType, doc.Filename)
+	}
+	if !strings.Contains(string(doc.Content), "Лампа") || strings.Contains(string(doc.Content), "Торшер") {
+		t.Errorf("issued invoice changed:\n%s", doc.Content)
+	}
+}
+
+func TestGetInvoice_Errors(t *testing.T) {
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		orderStatus  string
+		req          *pb.GetInvoiceRequest
+		expectedCode codes.Code
+		expectedMsg  string
+	}{
+		{
+			name:         "Other User",
+			ctx:          contextWithAuth("2", "user"),
+			orderStatus:  "completed",
+			req:          &pb.GetInvoiceRequest{OrderId: 1},
+			expectedCode: codes.PermissionDenied,
+			expectedMsg:  "FORBIDDEN: Access denied",
+		},
+		{
+			name:         "Not Found",
+			ctx:          contextWithAuth("1", "user"),
+			orderStatus:  "completed",
+			req:          &pb.GetInvoiceRequest{OrderId: 404},
+			expectedCode: codes.NotFound,
+		},
+		{
+			name:         "Unpaid Order",
+			ctx:          contextWithAuth("1", "user"),
+			orderStatus:  "pending",
+			req:          &pb.GetInvoiceRequest{OrderId: 1},
+			expectedCode: codes.FailedPrecondition,
+			expectedMsg:  "INVOICE_NOT_AVAILABLE: Cannot issue invoice for order with status 'pending'",
+		},
+		{
+			name:         "Unsupported Format",
+			ctx:          contextWithAuth("1", "user"),
+			orderStatus:  "completed",
+			req:          &pb.GetInvoiceRequest{OrderId: 1, Format: "pdf"},
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := &model.Order{ID: 1, UserID: 1, Status: tt.orderStatus, TotalAmountMinor: 100, Currency: "RUB"}
+			invoices := &mockInvoiceRepository{}
+			s := newInvoiceService(t, order, invoices)
+
+			_, err := s.GetInvoice(tt.ctx, tt.req)
+			st, _ := status.FromError(err)
+			if st.Code() != tt.expectedCode {
+				t.Fatalf("expected code %v, got %v (%v)", tt.expectedCode, st.Code(), err)
+			}
+			if tt.expectedMsg != "" && st.Message() != tt.expectedMsg {
+				t.Errorf("expected message %q, got %q", tt.expectedMsg, st.Message())
+			}
+			if invoices.issued != 0 {
+				t.Errorf("expected no invoice issued, got %d", invoices.issued)
+			}
+		})
+	}
+}
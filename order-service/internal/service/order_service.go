@@ -4,26 +4,31 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	pb "order-service/api/order/v1"
+	"order-service/internal/invoice"
 	"order-service/internal/model"
+	"order-service/internal/payment"
 	"order-service/internal/repository"
 	productpb "order-service/pkg/api/product/v1"
-	"strconv"
 	"time"
 
+	"github.com/microserviceteam0/bff-gateway/shared/identity"
+	"github.com/microserviceteam0/bff-gateway/shared/money"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"gorm.io/gorm"
 
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
 type ProductClient interface {
 	GetProducts(ctx context.Context, ids []int64) (map[int64]*productpb.ProductResponse, error)
-	CheckStock(ctx context.Context, productID int64, quantity int32) (*productpb.CheckStockResponse, error)
-	UpdateStock(ctx context.Context, productID int64, quantityDelta int32) (*productpb.UpdateStockResponse, error)
+	CheckStock(ctx context.Context, productID, variantID int64, quantity int32) (*productpb.CheckStockResponse, error)
+	UpdateStock(ctx context.Context, productID, variantID int64, quantityDelta int32, reason string, orderID int64) (*productpb.UpdateStockResponse, error)
+	// BatchUpdateStock applies stock changes of several variants atomically
+	BatchUpdateStock(ctx context.Context, items []*productpb.StockAdjustment, reason string, orderID int64) (*productpb.BatchUpdateStockResponse, error)
 }
 
 type OrderService interface {
@@ -32,21 +37,79 @@ type OrderService interface {
 	GetUserOrders(ctx context.Context, req *pb.GetUserOrdersRequest) (*pb.GetUserOrdersResponse, error)
 	CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*pb.CancelOrderResponse, error)
 	UpdateOrder(ctx context.Context, req *pb.UpdateOrderRequest) (*pb.UpdateOrderResponse, error)
+	UpdateOrderItems(ctx context.Context, req *pb.UpdateOrderItemsRequest) (*pb.UpdateOrderItemsResponse, error)
 	GetOrderStats(ctx context.Context, req *pb.GetOrderStatsRequest) (*pb.GetOrderStatsResponse, error)
+	SearchOrders(ctx context.Context, req *pb.SearchOrdersRequest) (*pb.SearchOrdersResponse, error)
+	QuoteOrder(ctx context.Context, req *pb.QuoteOrderRequest) (*pb.QuoteOrderResponse, error)
+	CreatePromotion(ctx context.Context, req *pb.CreatePromotionRequest) (*pb.CreatePromotionResponse, error)
+	UpdatePromotion(ctx context.Context, req *pb.UpdatePromotionRequest) (*pb.UpdatePromotionResponse, error)
+	ListPromotions(ctx context.Context, req *pb.ListPromotionsRequest) (*pb.ListPromotionsResponse, error)
+	DeactivatePromotion(ctx context.Context, req *pb.DeactivatePromotionRequest) (*pb.DeactivatePromotionResponse, error)
+	PayOrder(ctx context.Context, req *pb.PayOrderRequest) (*pb.PayOrderResponse, error)
+	GetOrderPayments(ctx context.Context, req *pb.GetOrderPaymentsRequest) (*pb.GetOrderPaymentsResponse, error)
+	CapturePayment(ctx context.Context, req *pb.CapturePaymentRequest) (*pb.CapturePaymentResponse, error)
+	RefundPayment(ctx context.Context, req *pb.RefundPaymentRequest) (*pb.RefundPaymentResponse, error)
+	// HandlePaymentEvent applies an asynchronous payment provider event (webhook); it is not exposed over gRPC
+	HandlePaymentEvent(ctx context.Context, event payment.Event) error
+	// ExpireStaleOrders cancels pending orders created before cutoff; it is driven by the expiry worker
+	ExpireStaleOrders(ctx context.Context, cutoff time.Time, limit int, dryRun bool) ([]int64, error)
+	ListStockCompensations(ctx context.Context, req *pb.ListStockCompensationsRequest) (*pb.ListStockCompensationsResponse, error)
+	RetryStockCompensation(ctx context.Context, req *pb.RetryStockCompensationRequest) (*pb.RetryStockCompensationResponse, error)
+	ResolveStockCompensation(ctx context.Context, req *pb.ResolveStockCompensationRequest) (*pb.ResolveStockCompensationResponse, error)
+	CreateReturn(ctx context.Context, req *pb.CreateReturnRequest) (*pb.CreateReturnResponse, error)
+	GetReturn(ctx context.Context, req *pb.GetReturnRequest) (*pb.GetReturnResponse, error)
+	ListReturns(ctx context.Context, req *pb.ListReturnsRequest) (*pb.ListReturnsResponse, error)
+	ApproveReturn(ctx context.Context, req *pb.ApproveReturnRequest) (*pb.ApproveReturnResponse, error)
+	RejectReturn(ctx context.Context, req *pb.RejectReturnRequest) (*pb.RejectReturnResponse, error)
+	ReceiveReturn(ctx context.Context, req *pb.ReceiveReturnRequest) (*pb.ReceiveReturnResponse, error)
+	RefundReturn(ctx context.Context, req *pb.RefundReturnRequest) (*pb.RefundReturnResponse, error)
+	GetInvoice(ctx context.Context, req *pb.GetInvoiceRequest) (*pb.GetInvoiceResponse, error)
+	Reorder(ctx context.Context, req *pb.ReorderRequest) (*pb.ReorderResponse, error)
+	CreateOrderTemplate(ctx context.Context, req *pb.CreateOrderTemplateRequest) (*pb.CreateOrderTemplateResponse, error)
+	ListOrderTemplates(ctx context.Context, req *pb.ListOrderTemplatesRequest) (*pb.ListOrderTemplatesResponse, error)
+	DeleteOrderTemplate(ctx context.Context, req *pb.DeleteOrderTemplateRequest) (*pb.DeleteOrderTemplateResponse, error)
+	SubmitOrderTemplate(ctx context.Context, req *pb.SubmitOrderTemplateRequest) (*pb.SubmitOrderTemplateResponse, error)
 }
 
 type OrderServiceImpl struct {
-	repo          repository.OrderRepository
-	productClient ProductClient
+	repo             repository.OrderRepository
+	promoRepo        repository.PromotionRepository
+	paymentRepo      repository.PaymentRepository
+	compensationRepo repository.StockCompensationRepository
+	returnRepo       repository.ReturnRepository
+	invoiceRepo      repository.InvoiceRepository
+	invoices         *invoice.Renderer
+	templateRepo     repository.TemplateRepository
+	productClient    ProductClient
+	userClient       UserClient
+	paymentProvider  payment.PaymentProvider
 }
 
 func NewOrderService(
 	repo repository.OrderRepository,
+	promoRepo repository.PromotionRepository,
+	paymentRepo repository.PaymentRepository,
+	compensationRepo repository.StockCompensationRepository,
+	returnRepo repository.ReturnRepository,
+	invoiceRepo repository.InvoiceRepository,
+	invoices *invoice.Renderer,
+	templateRepo repository.TemplateRepository,
 	productClient ProductClient,
+	userClient UserClient,
+	paymentProvider payment.PaymentProvider,
 ) OrderService {
 	return &OrderServiceImpl{
-		repo:          repo,
-		productClient: productClient,
+		repo:             repo,
+		promoRepo:        promoRepo,
+		paymentRepo:      paymentRepo,
+		compensationRepo: compensationRepo,
+		returnRepo:       returnRepo,
+		invoiceRepo:      invoiceRepo,
+		invoices:         invoices,
+		templateRepo:     templateRepo,
+		productClient:    productClient,
+		userClient:       userClient,
+		paymentProvider:  paymentProvider,
 	}
 }
 
@@ -75,10 +138,118 @@ func (s *GRPCServer) UpdateOrder(ctx context.Context, req *pb.UpdateOrderRequest
 	return s.Service.UpdateOrder(ctx, req)
 }
 
+func (s *GRPCServer) UpdateOrderItems(ctx context.Context, req *pb.UpdateOrderItemsRequest) (*pb.UpdateOrderItemsResponse, error) {
+	return s.Service.UpdateOrderItems(ctx, req)
+}
+
 func (s *GRPCServer) GetOrderStats(ctx context.Context, req *pb.GetOrderStatsRequest) (*pb.GetOrderStatsResponse, error) {
 	return s.Service.GetOrderStats(ctx, req)
 }
 
+func (s *GRPCServer) SearchOrders(ctx context.Context, req *pb.SearchOrdersRequest) (*pb.SearchOrdersResponse, error) {
+	return s.Service.SearchOrders(ctx, req)
+}
+
+func (s *GRPCServer) QuoteOrder(ctx context.Context, req *pb.QuoteOrderRequest) (*pb.QuoteOrderResponse, error) {
+	return s.Service.QuoteOrder(ctx, req)
+}
+
+func (s *GRPCServer) CreatePromotion(ctx context.Context, req *pb.CreatePromotionRequest) (*pb.CreatePromotionResponse, error) {
+	return s.Service.CreatePromotion(ctx, req)
+}
+
+func (s *GRPCServer) UpdatePromotion(ctx context.Context, req *pb.UpdatePromotionRequest) (*pb.UpdatePromotionResponse, error) {
+	return s.Service.UpdatePromotion(ctx, req)
+}
+
+func (s *GRPCServer) ListPromotions(ctx context.Context, req *pb.ListPromotionsRequest) (*pb.ListPromotionsResponse, error) {
+	return s.Service.ListPromotions(ctx, req)
+}
+
+func (s *GRPCServer) DeactivatePromotion(ctx context.Context, req *pb.DeactivatePromotionRequest) (*pb.DeactivatePromotionResponse, error) {
+	return s.Service.DeactivatePromotion(ctx, req)
+}
+
+func (s *GRPCServer) PayOrder(ctx context.Context, req *pb.PayOrderRequest) (*pb.PayOrderResponse, error) {
+	return s.Service.PayOrder(ctx, req)
+}
+
+func (s *GRPCServer) GetOrderPayments(ctx context.Context, req *pb.GetOrderPaymentsRequest) (*pb.GetOrderPaymentsResponse, error) {
+	return s.Service.GetOrderPayments(ctx, req)
+}
+
+func (s *GRPCServer) CapturePayment(ctx context.Context, req *pb.CapturePaymentRequest) (*pb.CapturePaymentResponse, error) {
+	return s.Service.CapturePayment(ctx, req)
+}
+
+func (s *GRPCServer) RefundPayment(ctx context.Context, req *pb.RefundPaymentRequest) (*pb.RefundPaymentResponse, error) {
+	return s.Service.RefundPayment(ctx, req)
+}
+
+func (s *GRPCServer) ListStockCompensations(ctx context.Context, req *pb.ListStockCompensationsRequest) (*pb.ListStockCompensationsResponse, error) {
+	return s.Service.ListStockCompensations(ctx, req)
+}
+
+func (s *GRPCServer) RetryStockCompensation(ctx context.Context, req *pb.RetryStockCompensationRequest) (*pb.RetryStockCompensationResponse, error) {
+	return s.Service.RetryStockCompensation(ctx, req)
+}
+
+func (s *GRPCServer) ResolveStockCompensation(ctx context.Context, req *pb.ResolveStockCompensationRequest) (*pb.ResolveStockCompensationResponse, error) {
+	return s.Service.ResolveStockCompensation(ctx, req)
+}
+
+func (s *GRPCServer) CreateReturn(ctx context.Context, req *pb.CreateReturnRequest) (*pb.CreateReturnResponse, error) {
+	return s.Service.CreateReturn(ctx, req)
+}
+
+func (s *GRPCServer) GetReturn(ctx context.Context, req *pb.GetReturnRequest) (*pb.GetReturnResponse, error) {
+	return s.Service.GetReturn(ctx, req)
+}
+
+func (s *GRPCServer) ListReturns(ctx context.Context, req *pb.ListReturnsRequest) (*pb.ListReturnsResponse, error) {
+	return s.Service.ListReturns(ctx, req)
+}
+
+func (s *GRPCServer) ApproveReturn(ctx context.Context, req *pb.ApproveReturnRequest) (*pb.ApproveReturnResponse, error) {
+	return s.Service.ApproveReturn(ctx, req)
+}
+
+func (s *GRPCServer) RejectReturn(ctx context.Context, req *pb.RejectReturnRequest) (*pb.RejectReturnResponse, error) {
+	return s.Service.RejectReturn(ctx, req)
+}
+
+func (s *GRPCServer) ReceiveReturn(ctx context.Context, req *pb.ReceiveReturnRequest) (*pb.ReceiveReturnResponse, error) {
+	return s.Service.ReceiveReturn(ctx, req)
+}
+
+func (s *GRPCServer) RefundReturn(ctx context.Context, req *pb.RefundReturnRequest) (*pb.RefundReturnResponse, error) {
+	return s.Service.RefundReturn(ctx, req)
+}
+
+func (s *GRPCServer) GetInvoice(ctx context.Context, req *pb.GetInvoiceRequest) (*pb.GetInvoiceResponse, error) {
+	return s.Service.GetInvoice(ctx, req)
+}
+
+func (s *GRPCServer) Reorder(ctx context.Context, req *pb.ReorderRequest) (*pb.ReorderResponse, error) {
+	return s.Service.Reorder(ctx, req)
+}
+
+func (s *GRPCServer) CreateOrderTemplate(ctx context.Context, req *pb.CreateOrderTemplateRequest) (*pb.CreateOrderTemplateResponse, error) {
+	return s.Service.CreateOrderTemplate(ctx, req)
+}
+
+func (s *GRPCServer) ListOrderTemplates(ctx context.Context, req *pb.ListOrderTemplatesRequest) (*pb.ListOrderTemplatesResponse, error) {
+	return s.Service.ListOrderTemplates(ctx, req)
+}
+
+func (s *GRPCServer) DeleteOrderTemplate(ctx context.Context, req *pb.DeleteOrderTemplateRequest) (*pb.DeleteOrderTemplateResponse, error) {
+	return s.Service.DeleteOrderTemplate(ctx, req)
+}
+
+func (s *GRPCServer) SubmitOrderTemplate(ctx context.Context, req *pb.SubmitOrderTemplateRequest) (*pb.SubmitOrderTemplateResponse, error) {
+	return s.Service.SubmitOrderTemplate(ctx, req)
+}
+
 // CreateOrder создаёт новый заказ
 func (s *OrderServiceImpl) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.CreateOrderResponse, error) {
 	userID, _, err := s.getUserInfoFromContext(ctx)
@@ -96,6 +267,11 @@ func (s *OrderServiceImpl) CreateOrder(ctx context.Context, req *pb.CreateOrderR
 		return nil, status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: %v", err)
 	}
 
+	shipping, deliveryMethod, err := s.resolveShipping(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
 	// 1. Extract product IDs
 	productIDs := make([]int64, len(req.Items))
 	for i, item := range req.Items {
@@ -108,54 +284,42 @@ func (s *OrderServiceImpl) CreateOrder(ctx context.Context, req *pb.CreateOrderR
 		return nil, status.Errorf(codes.Internal, "PRODUCT_SERVICE_ERROR: Failed to fetch products: %v", err)
 	}
 
-	orderItems := make([]model.OrderItem, len(req.Items))
-	totalAmount := 0.0
-
-	for i, item := range req.Items {
-		product, exists := productsMap[item.ProductId]
-		if !exists {
-			return nil, status.Errorf(codes.NotFound, "PRODUCT_NOT_FOUND: Product with ID %d not found", item.ProductId)
-		}
+	// Use real price and name from product service
+	orderItems, err := s.buildOrderItems(req.Items, productsMap)
+	if err != nil {
+		return nil, err
+	}
 
-		// Use real price and name from product service
-		orderItems[i] = model.OrderItem{
-			ProductID:   item.ProductId,
-			Quantity:    item.Quantity,
-			Price:       product.Price,
-			ProductName: product.Name,
-		}
-		totalAmount += product.Price * float64(item.Quantity)
+	pricing, err := s.applyPromotions(ctx, req.UserId, orderItems, req.PromoCodes, nil)
+	if err != nil {
+		return nil, err
 	}
 
-	// 3. Update stock for each item
-	updatedItems := make([]int, 0)
-	for i, item := range orderItems {
-		_, err := s.productClient.UpdateStock(ctx, item.ProductID, -int32(item.Quantity))
-		if err != nil {
-			// Rollback previously updated items
-			for _, idx := range updatedItems {
-				prevItem := orderItems[idx]
-				_, _ = s.productClient.UpdateStock(ctx, prevItem.ProductID, int32(prevItem.Quantity))
-			}
-			return nil, status.Errorf(codes.InvalidArgument, "STOCK_ERROR: Product %d: %v", item.ProductID, err)
-		}
-		updatedItems = append(updatedItems, i)
+	// 3. Reserve stock for all items at once: either everything is reserved or nothing
+	reserved := make(map[model.StockKey]int32)
+	for _, item := range orderItems {
+		reserved[item.StockKey()] -= item.Quantity
+	}
+	if _, err := s.productClient.BatchUpdateStock(ctx, stockAdjustments(reserved), model.StockReasonOrderCreated, 0); err != nil {
+		return nil, stockError(err)
 	}
 
 	order := &model.Order{
-		UserID:      req.UserId,
-		Status:      "pending",
-		Items:       orderItems,
-		TotalAmount: totalAmount,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		UserID:          req.UserId,
+		Status:          "pending",
+		Items:           orderItems,
+		ShippingAddress: shipping,
+		DeliveryMethod:  deliveryMethod,
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
 	}
+	pricing.applyTo(order)
 
 	createdOrder, err := s.repo.CreateOrder(ctx, order)
 	if err != nil {
-		for _, idx := range updatedItems {
-			item := orderItems[idx]
-			_, _ = s.productClient.UpdateStock(ctx, item.ProductID, int32(item.Quantity))
+		s.revertStockDeltas(ctx, 0, reserved)
+		if errors.Is(err, repository.ErrPromoLimitReached) {
+			return nil, promoLimitRaceError(order, err)
 		}
 		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to create order: %v", err)
 	}
@@ -215,7 +379,26 @@ func (s *OrderServiceImpl) GetUserOrders(ctx context.Context, req *pb.GetUserOrd
 	offset := int((page - 1) * pageSize)
 	limit := int(pageSize)
 
-	orders, totalCountRaw, err := s.repo.GetOrdersByUserID(ctx, req.UserId, limit, offset)
+	var filter repository.UserOrderFilter
+	if req.Status != nil {
+		if !s.isValidStatus(*req.Status) {
+			return nil, status.Errorf(codes.InvalidArgument, "INVALID_STATUS: Invalid status")
+		}
+		filter.Status = *req.Status
+	}
+	if req.FromDate != nil {
+		from := req.FromDate.AsTime()
+		filter.From = &from
+	}
+	if req.ToDate != nil {
+		to := req.ToDate.AsTime()
+		filter.To = &to
+	}
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return nil, status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: from_date is after to_date")
+	}
+
+	orders, totalCountRaw, err := s.repo.GetOrdersByUserID(ctx, req.UserId, filter, limit, offset)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to get user orders: %v", err)
 	}
@@ -253,24 +436,28 @@ func (s *OrderServiceImpl) CancelOrder(ctx context.Context, req *pb.CancelOrderR
 		return nil, status.Errorf(codes.PermissionDenied, "FORBIDDEN: Access denied")
 	}
 
+	if err := checkExpectedVersion(order, req.ExpectedVersion); err != nil {
+		return nil, err
+	}
+
 	if order.Status != "pending" && order.Status != "confirmed" {
 		return nil, status.Errorf(codes.FailedPrecondition, "INVALID_STATUS: Cannot cancel order with status '%s'", order.Status)
 	}
 
 	order.Status = "cancelled"
+	order.CancellationReason = req.Reason
 	order.UpdatedAt = time.Now()
 
 	if err := s.repo.UpdateOrder(ctx, order); err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return nil, versionConflictError()
+		}
 		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to cancel order: %v", err)
 	}
 
-	for _, item := range order.Items {
-		_, err := s.productClient.UpdateStock(ctx, item.ProductID, int32(item.Quantity))
-		if err != nil {
-			// Log error but don't fail the cancellation as the status is already updated in DB
-			fmt.Printf("FAILED_TO_RESTORE_STOCK: product_id=%d, quantity=%d, error=%v\n", item.ProductID, item.Quantity, err)
-		}
-	}
+	// Log errors but don't fail the cancellation as the status is already updated in DB
+	s.restoreStock(ctx, order.ID, order.Items, model.CompensationOrderCancelled)
+	s.releasePayments(ctx, order.ID)
 
 	return &pb.CancelOrderResponse{
 		Result: &pb.CancelOrderResponse_Success{
@@ -297,32 +484,39 @@ func (s *OrderServiceImpl) UpdateOrder(ctx context.Context, req *pb.UpdateOrderR
 		return nil, status.Errorf(codes.PermissionDenied, "FORBIDDEN: Access denied")
 	}
 
+	if err := checkExpectedVersion(order, req.ExpectedVersion); err != nil {
+		return nil, err
+	}
+
+	cancelled := false
 	if req.Status != nil {
 		if !s.isValidStatus(*req.Status) {
 			return nil, status.Errorf(codes.InvalidArgument, "INVALID_STATUS: Invalid status")
 		}
 
-		oldStatus := order.Status
-		newStatus := *req.Status
-
-		if oldStatus != "cancelled" && newStatus == "cancelled" {
-			for _, item := range order.Items {
-				_, err := s.productClient.UpdateStock(ctx, item.ProductID, int32(item.Quantity))
-				if err != nil {
-					fmt.Printf("FAILED_TO_RESTORE_STOCK: product_id=%d, quantity=%d, error=%v\n", item.ProductID, item.Quantity, err)
-				}
-			}
-		}
+		cancelled = order.Status != "cancelled" && *req.Status == "cancelled"
+		order.Status = *req.Status
+	}
 
-		order.Status = newStatus
+	if req.CancellationReason != nil {
+		order.CancellationReason = *req.CancellationReason
 	}
 
 	order.UpdatedAt = time.Now()
 
 	if err := s.repo.UpdateOrder(ctx, order); err != nil {
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return nil, versionConflictError()
+		}
 		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to update order: %v", err)
 	}
 
+	// Stock and payments are released only after the cancellation is saved: on a version conflict the order is unchanged
+	if cancelled {
+		s.restoreStock(ctx, order.ID, order.Items, model.CompensationOrderCancelled)
+		s.releasePayments(ctx, order.ID)
+	}
+
 	return &pb.UpdateOrderResponse{
 		Result: &pb.UpdateOrderResponse_Success{
 			Success: &emptypb.Empty{},
@@ -330,6 +524,130 @@ func (s *OrderServiceImpl) UpdateOrder(ctx context.Context, req *pb.UpdateOrderR
 	}, nil
 }
 
+// UpdateOrderItems replaces the lines of a pending order,
+// re-prices them, recalculates the total and adjusts the stock by the difference
+func (s *OrderServiceImpl) UpdateOrderItems(ctx context.Context, req *pb.UpdateOrderItemsRequest) (*pb.UpdateOrderItemsResponse, error) {
+	userID, isAdmin, err := s.getUserInfoFromContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "UNAUTHORIZED: %v", err)
+	}
+
+	if err := s.validateOrderItems(req.Items); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: %v", err)
+	}
+
+	order, err := s.repo.GetOrder(ctx, req.OrderId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "NOT_FOUND: Order not found")
+		}
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to get order: %v", err)
+	}
+
+	if !isAdmin && order.UserID != userID {
+		return nil, status.Errorf(codes.PermissionDenied, "FORBIDDEN: Access denied")
+	}
+
+	if err := checkExpectedVersion(order, req.ExpectedVersion); err != nil {
+		return nil, err
+	}
+
+	if order.Status != "pending" {
+		return nil, status.Errorf(codes.FailedPrecondition, "INVALID_STATUS: Cannot edit items of order with status '%s'", order.Status)
+	}
+
+	if err := s.ensureNoActivePayment(ctx, order.ID); err != nil {
+		return nil, err
+	}
+
+	// Legacy lines (variant 0) are restocked to their product's default variant,
+	// so their products are fetched too, including the ones the edit removes
+	productIDs := make([]int64, 0, len(req.Items)+len(order.Items))
+	for _, item := range req.Items {
+		productIDs = append(productIDs, item.ProductId)
+	}
+	for _, item := range order.Items {
+		if item.VariantID == 0 {
+			productIDs = append(productIDs, item.ProductID)
+		}
+	}
+
+	productsMap, err := s.productClient.GetProducts(ctx, productIDs)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "PRODUCT_SERVICE_ERROR: Failed to fetch products: %v", err)
+	}
+
+	newItems, err := s.buildOrderItems(req.Items, productsMap)
+	if err != nil {
+		return nil, err
+	}
+	for i := range newItems {
+		newItems[i].OrderID = order.ID
+	}
+
+	// The order's promo codes are applied again; those that no longer fit the new lines are dropped
+	pricing, err := s.applyPromotions(ctx, order.UserID, newItems, appliedCodes(order), order)
+	if err != nil {
+		return nil, err
+	}
+
+	// Positive delta returns items to stock, negative one reserves more
+	deltas := make(map[model.StockKey]int32)
+	for _, item := range order.Items {
+		deltas[legacyStockKey(item.StockKey(), productsMap)] += item.Quantity
+	}
+	for _, item := range newItems {
+		deltas[item.StockKey()] -= item.Quantity
+	}
+
+	applied := nonZeroDeltas(deltas)
+	if len(applied) > 0 {
+		if _, err := s.productClient.BatchUpdateStock(ctx, stockAdjustments(applied), model.StockReasonOrderItemsChanged, order.ID); err != nil {
+			return nil, stockError(err)
+		}
+	}
+
+	order.Items = newItems
+	pricing.applyTo(order)
+	order.UpdatedAt = time.Now()
+
+	if err := s.repo.ReplaceOrderItems(ctx, order); err != nil {
+		s.revertStockDeltas(ctx, order.ID, applied)
+		if errors.Is(err, repository.ErrOrderNotPending) {
+			return nil, status.Errorf(codes.FailedPrecondition, "INVALID_STATUS: Order is no longer pending")
+		}
+		if errors.Is(err, repository.ErrVersionConflict) {
+			return nil, versionConflictError()
+		}
+		if errors.Is(err, repository.ErrPromoLimitReached) {
+			return nil, promoLimitRaceError(order, err)
+		}
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to update order items: %v", err)
+	}
+
+	return &pb.UpdateOrderItemsResponse{
+		Result: &pb.UpdateOrderItemsResponse_Order{
+			Order: s.orderToProto(order),
+		},
+	}, nil
+}
+
+func (s *OrderServiceImpl) revertStockDeltas(ctx context.Context, orderID int64, applied map[model.StockKey]int32) {
+	reverted := make(map[model.StockKey]int32, len(applied))
+	for key, delta := range applied {
+		reverted[key] = -delta
+	}
+	s.compensateStockBatch(ctx, orderID, reverted, model.CompensationRollback)
+}
+
+// stockError turns a BatchUpdateStock rejection into a client error; the Product Service message names the product
+func stockError(err error) error {
+	if st, ok := status.FromError(err); ok {
+		return status.Errorf(codes.InvalidArgument, "STOCK_ERROR: %s", st.Message())
+	}
+	return status.Errorf(codes.InvalidArgument, "STOCK_ERROR: %v", err)
+}
+
 func (s *OrderServiceImpl) GetOrderStats(ctx context.Context, req *pb.GetOrderStatsRequest) (*pb.GetOrderStatsResponse, error) {
 	userID, isAdmin, err := s.getUserInfoFromContext(ctx)
 	if err != nil {
@@ -339,13 +657,13 @@ func (s *OrderServiceImpl) GetOrderStats(ctx context.Context, req *pb.GetOrderSt
 		return nil, status.Errorf(codes.PermissionDenied, "FORBIDDEN: Access denied")
 	}
 
-	orders, _, err := s.repo.GetOrdersByUserID(ctx, req.UserId, 1000, 0)
+	orders, _, err := s.repo.GetOrdersByUserID(ctx, req.UserId, repository.UserOrderFilter{}, 1000, 0)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to get order stats: %v", err)
 	}
 
 	var totalOrders, activeOrders int32
-	var totalSpent float64
+	var totalSpent *money.Money
 	var lastOrderDate *timestamppb.Timestamp
 
 	for _, order := range orders {
@@ -355,8 +673,17 @@ func (s *OrderServiceImpl) GetOrderStats(ctx context.Context, req *pb.GetOrderSt
 			activeOrders++
 		}
 
-		if order.Status == "completed" {
-			totalSpent += order.TotalAmount
+		// Refunded money does not count as spent
+		if order.Status == "completed" || order.Status == model.OrderPartiallyRefunded {
+			orderTotal := order.Total()
+			orderTotal.Amount -= order.RefundedMinor
+			if totalSpent == nil {
+				totalSpent = &orderTotal
+			} else if sum, err := totalSpent.Add(orderTotal); err == nil {
+				totalSpent = &sum
+			} else {
+				slog.Warn("Skipping order in stats: currency differs", "order_id", order.ID, "error", err)
+			}
 		}
 
 		if lastOrderDate == nil || order.CreatedAt.After(lastOrderDate.AsTime()) {
@@ -364,31 +691,177 @@ func (s *OrderServiceImpl) GetOrderStats(ctx context.Context, req *pb.GetOrderSt
 		}
 	}
 
+	if totalSpent == nil {
+		zero := money.Zero(money.DefaultCurrency)
+		totalSpent = &zero
+	}
+
 	return &pb.GetOrderStatsResponse{
-		TotalOrders:   totalOrders,
-		ActiveOrders:  activeOrders,
-		TotalSpent:    totalSpent,
-		LastOrderDate: lastOrderDate,
+		TotalOrders:     totalOrders,
+		ActiveOrders:    activeOrders,
+		TotalSpent:      totalSpent.Float64(),
+		TotalSpentMinor: totalSpent.Amount,
+		Currency:        totalSpent.Currency,
+		LastOrderDate:   lastOrderDate,
 	}, nil
 }
 
+// buildOrderItems prices the requested items with product data; all items must share one currency
+func (s *OrderServiceImpl) buildOrderItems(items []*pb.OrderItem, productsMap map[int64]*productpb.ProductResponse) ([]model.OrderItem, error) {
+	orderItems := make([]model.OrderItem, len(items))
+
+	for i, item := range items {
+		product, exists := productsMap[item.ProductId]
+		if !exists {
+			return nil, status.Errorf(codes.NotFound, "PRODUCT_NOT_FOUND: Product with ID %d not found", item.ProductId)
+		}
+		if !productOnSale(product) {
+			return nil, status.Errorf(codes.FailedPrecondition, "PRODUCT_UNAVAILABLE: Product %d is %s", item.ProductId, product.Status)
+		}
+
+		variant, err := productVariant(product, item.VariantId)
+		if err != nil {
+			return nil, err
+		}
+
+		orderItems[i] = model.OrderItem{
+			ProductID:   item.ProductId,
+			Quantity:    item.Quantity,
+			ProductName: product.Name,
+		}
+		if variant != nil {
+			orderItems[i].VariantID = variant.Id
+			orderItems[i].VariantSKU = variant.Sku
+		}
+		orderItems[i].SetUnitPrice(variantPrice(product, variant))
+
+		if i > 0 && orderItems[i].Currency != orderItems[0].Currency {
+			err := fmt.Errorf("%w: %s and %s", money.ErrCurrencyMismatch, orderItems[0].Currency, orderItems[i].Currency)
+			return nil, status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: All products in an order must have the same currency: %v", err)
+		}
+	}
+
+	return orderItems, nil
+}
+
+// productOnSale reports whether the product can be ordered: Product Service still returns drafts and
+// archived products by ID for order history. Old versions do not send the status
+func productOnSale(product *productpb.ProductResponse) bool {
+	return product.Status == "" || product.Status == "active"
+}
+
+// productVariant picks the variant of a line: variantID 0 is allowed only for a product with a single variant.
+// Old Product Service versions return no variants; then the line refers to the whole product (nil)
+func productVariant(product *productpb.ProductResponse, variantID int64) (*productpb.ProductVariant, error) {
+	if len(product.Variants) == 0 {
+		if variantID != 0 {
+			return nil, status.Errorf(codes.NotFound, "VARIANT_NOT_FOUND: Variant %d of product %d not found", variantID, product.Id)
+		}
+		return nil, nil
+	}
+	if variantID == 0 {
+		if len(product.Variants) > 1 {
+			return nil, status.Errorf(codes.InvalidArgument, "VARIANT_REQUIRED: Product %d has %d variants, variant_id is required", product.Id, len(product.Variants))
+		}
+		return product.Variants[0], nil
+	}
+	for _, v := range product.Variants {
+		if v.Id == variantID {
+			return v, nil
+		}
+	}
+	return nil, status.Errorf(codes.NotFound, "VARIANT_NOT_FOUND: Variant %d of product %d not found", variantID, product.Id)
+}
+
+// legacyStockKey moves a line created before variants existed (variant_id 0) to the default variant,
+// the first variant of the product, which received all its stock in the migration. Otherwise one batch would hold
+// both the whole product and its variant, and once a second variant is added Product Service would reject the line
+func legacyStockKey(key model.StockKey, productsMap map[int64]*productpb.ProductResponse) model.StockKey {
+	if key.VariantID != 0 {
+		return key
+	}
+	if product, ok := productsMap[key.ProductID]; ok && len(product.Variants) > 0 {
+		key.VariantID = product.Variants[0].Id
+	}
+	return key
+}
+
+// variantPrice is the price of a variant: its own price if set, otherwise the product price
+func variantPrice(product *productpb.ProductResponse, variant *productpb.ProductVariant) money.Money {
+	if variant != nil && variant.PriceOverrideMinor != nil {
+		return money.New(*variant.PriceOverrideMinor, product.Currency)
+	}
+	return productPrice(product)
+}
+
+// productPrice returns the exact product price, falling back to the legacy double for old product-service versions
+func productPrice(product *productpb.ProductResponse) money.Money {
+	if product.PriceMinor == 0 && product.Price != 0 {
+		return money.FromFloat(product.Price, product.Currency)
+	}
+	return money.New(product.PriceMinor, product.Currency)
+}
+
 func (s *OrderServiceImpl) validateCreateOrderRequest(req *pb.CreateOrderRequest) error {
-	if len(req.Items) == 0 {
+	return s.validateOrderItems(req.Items)
+}
+
+func (s *OrderServiceImpl) validateOrderItems(items []*pb.OrderItem) error {
+	if len(items) == 0 {
 		return errors.New("order must contain at least one item")
 	}
 
-	for _, item := range req.Items {
+	for _, item := range items {
 		if item.Quantity <= 0 {
 			return fmt.Errorf("invalid quantity for product %d", item.ProductId)
 		}
 		if item.ProductId <= 0 {
 			return errors.New("invalid product_id")
 		}
+		if item.VariantId < 0 {
+			return fmt.Errorf("invalid variant_id for product %d", item.ProductId)
+		}
 	}
 
 	return nil
 }
 
+// restoreStock returns the items of a cancelled order to stock
+func (s *OrderServiceImpl) restoreStock(ctx context.Context, orderID int64, items []model.OrderItem, reason string) {
+	deltas := make(map[model.StockKey]int32)
+	for _, item := range items {
+		deltas[item.StockKey()] += item.Quantity
+	}
+	s.compensateStockBatch(ctx, orderID, deltas, reason)
+}
+
+// checkExpectedVersion rejects a change if the client saw another version of the order
+func checkExpectedVersion(order *model.Order, expected *int64) error {
+	if expected != nil && *expected != order.Version {
+		return status.Errorf(codes.Aborted, "VERSION_CONFLICT: Order has version %d, expected %d", order.Version, *expected)
+	}
+	return nil
+}
+
+// promoLimitRaceError reports that a concurrent order of the same user used up the promotion limit
+// after applyPromotions had checked it
+func promoLimitRaceError(order *model.Order, err error) error {
+	var limitErr *repository.PromoLimitError
+	if errors.As(err, &limitErr) {
+		for _, p := range order.Promotions {
+			if p.PromotionID == limitErr.PromotionID && p.Code != "" {
+				return status.Errorf(codes.FailedPrecondition, "PROMO_LIMIT_REACHED: Promo code %s has already been used", p.Code)
+			}
+		}
+	}
+	// An automatic promotion is simply not applied on retry
+	return status.Errorf(codes.Aborted, "PROMO_LIMIT_REACHED: A promotion of the order has already been used, retry the order")
+}
+
+func versionConflictError() error {
+	return status.Errorf(codes.Aborted, "VERSION_CONFLICT: Order was modified concurrently, reload it and retry")
+}
+
 func (s *OrderServiceImpl) isValidStatus(status string) bool {
 	validStatuses := map[string]bool{
 		"pending": true, "confirmed": true, "processing": true,
@@ -397,50 +870,54 @@ func (s *OrderServiceImpl) isValidStatus(status string) bool {
 	return validStatuses[status]
 }
 
+// getUserInfoFromContext reads the identity verified by the identity interceptor.
+// Service calls without a user are not allowed to access orders
 func (s *OrderServiceImpl) getUserInfoFromContext(ctx context.Context) (userID int64, isAdmin bool, err error) {
-	md, ok := metadata.FromIncomingContext(ctx)
+	p, ok := identity.FromContext(ctx)
 	if !ok {
-		return 0, false, errors.New("metadata is missing")
+		return 0, false, errors.New("identity is missing")
 	}
-
-	vals := md.Get("x-user-id")
-	if len(vals) == 0 {
-		return 0, false, errors.New("x-user-id header is missing")
+	if p.UserID == 0 {
+		return 0, false, errors.New("caller is not a user")
 	}
-
-	id, err := strconv.ParseInt(vals[0], 10, 64)
-	if err != nil {
-		return 0, false, fmt.Errorf("invalid x-user-id header: %v", err)
-	}
-
-	// Check role
-	roles := md.Get("x-user-role")
-	isAdmin = false
-	if len(roles) > 0 && roles[0] == "admin" {
-		isAdmin = true
-	}
-
-	return id, isAdmin, nil
+	return p.UserID, p.IsAdmin(), nil
 }
 
 func (s *OrderServiceImpl) orderToProto(order *model.Order) *pb.Order {
 	items := make([]*pb.OrderItem, len(order.Items))
 	for i, item := range order.Items {
+		price := item.UnitPrice()
 		items[i] = &pb.OrderItem{
-			ProductId:   item.ProductID,
-			Quantity:    item.Quantity,
-			Price:       item.Price,
-			ProductName: item.ProductName,
+			ProductId:     item.ProductID,
+			VariantId:     item.VariantID,
+			Sku:           item.VariantSKU,
+			Quantity:      item.Quantity,
+			Price:         price.Float64(),
+			PriceMinor:    price.Amount,
+			Currency:      price.Currency,
+			ProductName:   item.ProductName,
+			DiscountMinor: item.DiscountMinor,
 		}
 	}
 
+	total := order.Total()
 	return &pb.Order{
-		Id:          order.ID,
-		UserId:      order.UserID,
-		Status:      order.Status,
-		Items:       items,
-		TotalAmount: order.TotalAmount,
-		CreatedAt:   timestamppb.New(order.CreatedAt),
-		UpdatedAt:   timestamppb.New(order.UpdatedAt),
+		Id:                 order.ID,
+		UserId:             order.UserID,
+		Status:             order.Status,
+		CancellationReason: order.CancellationReason,
+		Items:              items,
+		TotalAmount:        total.Float64(),
+		TotalAmountMinor:   total.Amount,
+		Currency:           total.Currency,
+		SubtotalMinor:      order.Subtotal().Amount,
+		DiscountMinor:      order.DiscountMinor,
+		RefundedMinor:      order.RefundedMinor,
+		Promotions:         appliedPromotionsToProto(order.Promotions),
+		ShippingAddress:    shippingAddressToProto(order.ShippingAddress),
+		DeliveryMethod:     order.DeliveryMethod,
+		Version:            order.Version,
+		CreatedAt:          timestamppb.New(order.CreatedAt),
+		UpdatedAt:          timestamppb.New(order.UpdatedAt),
 	}
 }
@@ -3,28 +3,37 @@ package service_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"order-service/internal/model"
 	"order-service/internal/repository"
 	"order-service/internal/service"
+	"strconv"
 	"testing"
+	"time"
 
 	pb "order-service/api/order/v1"
 
 	productpb "order-service/pkg/api/product/v1"
 
+	"github.com/microserviceteam0/bff-gateway/shared/identity"
 	"gorm.io/gorm"
 
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type mockOrderRepository struct {
 	createOrderFunc       func(ctx context.Context, order *model.Order) (*model.Order, error)
 	getOrderFunc          func(ctx context.Context, orderID int64) (*model.Order, error)
-	getOrdersByUserIDFunc func(ctx context.Context, userID int64, limit int, offset int) ([]model.Order, int64, error)
+	getOrdersByUserIDFunc func(ctx context.Context, userID int64, filter repository.UserOrderFilter, limit int, offset int) ([]model.Order, int64, error)
+	searchOrdersFunc      func(ctx context.Context, filter repository.OrderSearchFilter, limit int) ([]model.Order, error)
 	updateOrderFunc       func(ctx context.Context, order *model.Order) error
+	replaceOrderItemsFunc func(ctx context.Context, order *model.Order) error
 	deleteFunc            func(ctx context.Context, orderID int64) error
+	findStaleOrdersFunc   func(ctx context.Context, cutoff time.Time, limit int) ([]model.Order, error)
+	expireStaleOrdersFunc func(ctx context.Context, cutoff time.Time, limit int, reason string) ([]model.Order, error)
 }
 
 var _ repository.OrderRepository = (*mockOrderRepository)(nil)
@@ -43,13 +52,20 @@ func (m *mockOrderRepository) GetOrder(ctx context.Context, orderID int64) (*mod
 	return nil, errors.New("GetOrder not implemented in mock")
 }
 
-func (m *mockOrderRepository) GetOrdersByUserID(ctx context.Context, userID int64, limit int, offset int) ([]model.Order, int64, error) {
+func (m *mockOrderRepository) GetOrdersByUserID(ctx context.Context, userID int64, filter repository.UserOrderFilter, limit int, offset int) ([]model.Order, int64, error) {
 	if m.getOrdersByUserIDFunc != nil {
-		return m.getOrdersByUserIDFunc(ctx, userID, limit, offset)
+		return m.getOrdersByUserIDFunc(ctx, userID, filter, limit, offset)
 	}
 	return nil, 0, errors.New("GetOrdersByUserID not implemented in mock")
 }
 
+func (m *mockOrderRepository) SearchOrders(ctx context.Context, filter repository.OrderSearchFilter, limit int) ([]model.Order, error) {
+	if m.searchOrdersFunc != nil {
+		return m.searchOrdersFunc(ctx, filter, limit)
+	}
+	return nil, errors.New("SearchOrders not implemented in mock")
+}
+
 func (m *mockOrderRepository) UpdateOrder(ctx context.Context, order *model.Order) error {
 	if m.updateOrderFunc != nil {
 		return m.updateOrderFunc(ctx, order)
@@ -57,6 +73,13 @@ func (m *mockOrderRepository) UpdateOrder(ctx context.Context, order *model.Orde
 	return errors.New("UpdateOrder not implemented in mock")
 }
 
+func (m *mockOrderRepository) ReplaceOrderItems(ctx context.Context, order *model.Order) error {
+	if m.replaceOrderItemsFunc != nil {
+		return m.replaceOrderItemsFunc(ctx, order)
+	}
+	return errors.New("ReplaceOrderItems not implemented in mock")
+}
+
 func (m *mockOrderRepository) Delete(ctx context.Context, orderID int64) error {
 	if m.deleteFunc != nil {
 		return m.deleteFunc(ctx, orderID)
@@ -64,10 +87,79 @@ func (m *mockOrderRepository) Delete(ctx context.Context, orderID int64) error {
 	return errors.New("Delete not implemented in mock")
 }
 
+func (m *mockOrderRepository) FindStaleOrders(ctx context.Context, cutoff time.Time, limit int) ([]model.Order, error) {
+	if m.findStaleOrdersFunc != nil {
+		return m.findStaleOrdersFunc(ctx, cutoff, limit)
+	}
+	return nil, errors.New("FindStaleOrders not implemented in mock")
+}
+
+func (m *mockOrderRepository) ExpireStaleOrders(ctx context.Context, cutoff time.Time, limit int, reason string) ([]model.Order, error) {
+	if m.expireStaleOrdersFunc != nil {
+		return m.expireStaleOrdersFunc(ctx, cutoff, limit, reason)
+	}
+	return nil, errors.New("ExpireStaleOrders not implemented in mock")
+}
+
+type mockPromotionRepository struct {
+	getPromotionByCodeFunc      func(ctx context.Context, code string) (*model.Promotion, error)
+	listAutomaticPromotionsFunc func(ctx context.Context) ([]model.Promotion, error)
+	countUserUsageFunc          func(ctx context.Context, promotionID int64, userID int64) (int64, error)
+	createPromotionFunc         func(ctx context.Context, promotion *model.Promotion) error
+}
+
+var _ repository.PromotionRepository = (*mockPromotionRepository)(nil)
+
+func (m *mockPromotionRepository) CreatePromotion(ctx context.Context, promotion *model.Promotion) error {
+	if m.createPromotionFunc != nil {
+		return m.createPromotionFunc(ctx, promotion)
+	}
+	return errors.New("CreatePromotion not implemented in mock")
+}
+
+func (m *mockPromotionRepository) UpdatePromotion(ctx context.Context, promotion *model.Promotion) error {
+	return errors.New("UpdatePromotion not implemented in mock")
+}
+
+func (m *mockPromotionRepository) GetPromotion(ctx context.Context, promotionID int64) (*model.Promotion, error) {
+	return nil, errors.New("GetPromotion not implemented in mock")
+}
+
+func (m *mockPromotionRepository) GetPromotionByCode(ctx context.Context, code string) (*model.Promotion, error) {
+	if m.getPromotionByCodeFunc != nil {
+		return m.getPromotionByCodeFunc(ctx, code)
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *mockPromotionRepository) ListPromotions(ctx context.Context, activeOnly bool, limit int, offset int) ([]model.Promotion, int64, error) {
+	return nil, 0, errors.New("ListPromotions not implemented in mock")
+}
+
+// ListAutomaticPromotions returns no promotions by default so that orders are priced without discounts
+func (m *mockPromotionRepository) ListAutomaticPromotions(ctx context.Context) ([]model.Promotion, error) {
+	if m.listAutomaticPromotionsFunc != nil {
+		return m.listAutomaticPromotionsFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *mockPromotionRepository) CountUserUsage(ctx context.Context, promotionID int64, userID int64) (int64, error) {
+	if m.countUserUsageFunc != nil {
+		return m.countUserUsageFunc(ctx, promotionID, userID)
+	}
+	return 0, nil
+}
+
 type mockProductClient struct {
 	getProductsFunc func(ctx context.Context, ids []int64) (map[int64]*productpb.ProductResponse, error)
+	updateStockFunc func(ctx context.Context, productID, variantID int64, quantityDelta int32, reason string, orderID int64) (*productpb.UpdateStockResponse, error)
+	// batchUpdateStockFunc is emulated with updateStockFunc by default
+	batchUpdateStockFunc func(ctx context.Context, items []*productpb.StockAdjustment, reason string, orderID int64) (*productpb.BatchUpdateStockResponse, error)
 }
 
+var _ service.ProductClient = (*mockProductClient)(nil)
+
 func (m *mockProductClient) GetProducts(ctx context.Context, ids []int64) (map[int64]*productpb.ProductResponse, error) {
 	if m.getProductsFunc != nil {
 		return m.getProductsFunc(ctx, ids)
@@ -75,13 +167,67 @@ func (m *mockProductClient) GetProducts(ctx context.Context, ids []int64) (map[i
 	return nil, errors.New("GetProducts not implemented in mock")
 }
 
-// helper to create context with auth metadata
+func (m *mockProductClient) CheckStock(ctx context.Context, productID, variantID int64, quantity int32) (*productpb.CheckStockResponse, error) {
+	return &productpb.CheckStockResponse{Available: true}, nil
+}
+
+// UpdateStock succeeds by default so that flows which only care about other calls keep working
+func (m *mockProductClient) UpdateStock(ctx context.Context, productID, variantID int64, quantityDelta int32, reason string, orderID int64) (*productpb.UpdateStockResponse, error) {
+	if m.updateStockFunc != nil {
+		return m.updateStockFunc(ctx, productID, variantID, quantityDelta, reason, orderID)
+	}
+	return &productpb.UpdateStockResponse{}, nil
+}
+
+// BatchUpdateStock applies items one by one through UpdateStock in request order and
+// undoes the applied ones on failure, so tests observe the same all-or-nothing result
+func (m *mockProductClient) BatchUpdateStock(ctx context.Context, items []*productpb.StockAdjustment, reason string, orderID int64) (*productpb.BatchUpdateStockResponse, error) {
+	if m.batchUpdateStockFunc != nil {
+		return m.batchUpdateStockFunc(ctx, items, reason, orderID)
+	}
+	resp := &productpb.BatchUpdateStockResponse{}
+	for _, item := range items {
+		r, err := m.UpdateStock(ctx, item.ProductId, item.VariantId, item.QuantityDelta, reason, orderID)
+		if err != nil {
+			for _, applied := range resp.Results {
+				_, _ = m.UpdateStock(ctx, applied.ProductId, applied.VariantId, -applied.QuantityDelta, reason, orderID)
+			}
+			return nil, status.Errorf(codes.InvalidArgument, "product %d: %v", item.ProductId, err)
+		}
+		resp.Results = append(resp.Results, &productpb.StockAdjustmentResult{
+			ProductId:     item.ProductId,
+			VariantId:     item.VariantId,
+			QuantityDelta: item.QuantityDelta,
+			NewStock:      r.GetNewStock(),
+		})
+	}
+	return resp, nil
+}
+
+// productDeltas folds the batch items into changes per product
+func productDeltas(items []*productpb.StockAdjustment) map[int64]int32 {
+	deltas := make(map[int64]int32, len(items))
+	for _, item := range items {
+		deltas[item.ProductId] += item.QuantityDelta
+	}
+	return deltas
+}
+
+// testShipping returns a valid inline address for CreateOrder requests
+func testShipping() *pb.CreateOrderRequest_Address {
+	return &pb.CreateOrderRequest_Address{Address: &pb.ShippingAddress{
+		RecipientName: "Ivan Petrov",
+		Country:       "ru",
+		City:          "Moscow",
+		PostalCode:    "101000",
+		Line1:         "Tverskaya 1",
+	}}
+}
+
+// contextWithAuth simulates a request that passed the identity interceptor
 func contextWithAuth(userID string, role string) context.Context {
-	md := metadata.New(map[string]string{
-		"x-user-id":   userID,
-		"x-user-role": role,
-	})
-	return metadata.NewIncomingContext(context.Background(), md)
+	id, _ := strconv.ParseInt(userID, 10, 64)
+	return identity.NewContext(context.Background(), identity.Principal{UserID: id, Role: role, Service: "bff"})
 }
 
 func TestCreateOrder(t *testing.T) {
@@ -98,7 +244,8 @@ func TestCreateOrder(t *testing.T) {
 		{
 			name: "Success",
 			req: &pb.CreateOrderRequest{
-				UserId: 1,
+				UserId:   1,
+				Shipping: testShipping(),
 				Items: []*pb.OrderItem{
 					{ProductId: 101, Quantity: 2},
 				},
@@ -117,7 +264,8 @@ func TestCreateOrder(t *testing.T) {
 		{
 			name: "Forbidden - Create for another user",
 			req: &pb.CreateOrderRequest{
-				UserId: 2, // Different user
+				UserId:   2, // Different user
+				Shipping: testShipping(),
 				Items: []*pb.OrderItem{
 					{ProductId: 101, Quantity: 1},
 				},
@@ -130,8 +278,9 @@ func TestCreateOrder(t *testing.T) {
 		{
 			name: "Invalid Request - No Items",
 			req: &pb.CreateOrderRequest{
-				UserId: 1,
-				Items:  []*pb.OrderItem{},
+				UserId:   1,
+				Shipping: testShipping(),
+				Items:    []*pb.OrderItem{},
 			},
 			mockGetProducts: nil,
 			mockCreateOrder: nil,
@@ -141,7 +290,8 @@ func TestCreateOrder(t *testing.T) {
 		{
 			name: "Invalid Request - Zero Quantity",
 			req: &pb.CreateOrderRequest{
-				UserId: 1,
+				UserId:   1,
+				Shipping: testShipping(),
 				Items: []*pb.OrderItem{
 					{ProductId: 101, Quantity: 0},
 				},
@@ -154,7 +304,8 @@ func TestCreateOrder(t *testing.T) {
 		{
 			name: "Product Not Found",
 			req: &pb.CreateOrderRequest{
-				UserId: 1,
+				UserId:   1,
+				Shipping: testShipping(),
 				Items: []*pb.OrderItem{
 					{ProductId: 999, Quantity: 1},
 				},
@@ -166,10 +317,29 @@ func TestCreateOrder(t *testing.T) {
 			expectedCode:    codes.NotFound,
 			expectedMsg:     "PRODUCT_NOT_FOUND: Product with ID 999 not found",
 		},
+		{
+			name: "Archived Product",
+			req: &pb.CreateOrderRequest{
+				UserId:   1,
+				Shipping: testShipping(),
+				Items: []*pb.OrderItem{
+					{ProductId: 101, Quantity: 1},
+				},
+			},
+			mockGetProducts: func(ctx context.Context, ids []int64) (map[int64]*productpb.ProductResponse, error) {
+				return map[int64]*productpb.ProductResponse{
+					101: {Id: 101, Name: "Test Product", PriceMinor: 1000, Currency: "USD", Status: "archived"},
+				}, nil
+			},
+			mockCreateOrder: nil,
+			expectedCode:    codes.FailedPrecondition,
+			expectedMsg:     "PRODUCT_UNAVAILABLE: Product 101 is archived",
+		},
 		{
 			name: "Product Service Error",
 			req: &pb.CreateOrderRequest{
-				UserId: 1,
+				UserId:   1,
+				Shipping: testShipping(),
 				Items: []*pb.OrderItem{
 					{ProductId: 101, Quantity: 1},
 				},
@@ -184,7 +354,8 @@ func TestCreateOrder(t *testing.T) {
 		{
 			name: "Database Error",
 			req: &pb.CreateOrderRequest{
-				UserId: 1,
+				UserId:   1,
+				Shipping: testShipping(),
 				Items: []*pb.OrderItem{
 					{ProductId: 101, Quantity: 1},
 				},
@@ -200,6 +371,26 @@ func TestCreateOrder(t *testing.T) {
 			expectedCode: codes.Internal,
 			expectedMsg:  "DATABASE_ERROR: Failed to create order: db connection failed",
 		},
+		{
+			name: "Currency Mismatch",
+			req: &pb.CreateOrderRequest{
+				UserId:   1,
+				Shipping: testShipping(),
+				Items: []*pb.OrderItem{
+					{ProductId: 101, Quantity: 1},
+					{ProductId: 102, Quantity: 1},
+				},
+			},
+			mockGetProducts: func(ctx context.Context, ids []int64) (map[int64]*productpb.ProductResponse, error) {
+				return map[int64]*productpb.ProductResponse{
+					101: {Id: 101, Name: "Test Product", PriceMinor: 1000, Currency: "RUB"},
+					102: {Id: 102, Name: "Imported Product", PriceMinor: 500, Currency: "USD"},
+				}, nil
+			},
+			mockCreateOrder: nil,
+			expectedCode:    codes.InvalidArgument,
+			expectedMsg:     "INVALID_REQUEST: All products in an order must have the same currency: currency mismatch: RUB and USD",
+		},
 	}
 
 	for _, tt := range tests {
@@ -210,7 +401,7 @@ func TestCreateOrder(t *testing.T) {
 			mockProd := &mockProductClient{
 				getProductsFunc: tt.mockGetProducts,
 			}
-			s := service.NewOrderService(mockRepo, mockProd)
+			s := service.NewOrderService(mockRepo, &mockPromotionRepository{}, &mockPaymentRepository{}, &mockCompensationRepository{}, &mockReturnRepository{}, &mockInvoiceRepository{}, nil, &mockTemplateRepository{}, mockProd, nil, nil)
 
 			resp, err := s.CreateOrder(ctx, tt.req)
 
@@ -240,6 +431,225 @@ func TestCreateOrder(t *testing.T) {
 	}
 }
 
+func TestCreateOrderExactTotal(t *testing.T) {
+	ctx := contextWithAuth("1", "user")
+
+	var created *model.Order
+	mockRepo := &mockOrderRepository{
+		createOrderFunc: func(ctx context.Context, order *model.Order) (*model.Order, error) {
+			order.ID = 1
+			created = order
+			return order, nil
+		},
+	}
+	mockProd := &mockProductClient{
+		getProductsFunc: func(ctx context.Context, ids []int64) (map[int64]*productpb.ProductResponse, error) {
+			return map[int64]*productpb.ProductResponse{
+				101: {Id: 101, Name: "Cheap Product", PriceMinor: 10, Currency: "RUB"},
+				102: {Id: 102, Name: "Legacy Product", Price: 0.2},
+			}, nil
+		},
+	}
+	s := service.NewOrderService(mockRepo, &mockPromotionRepository{}, &mockPaymentRepository{}, &mockCompensationRepository{}, &mockReturnRepository{}, &mockInvoiceRepository{}, nil, &mockTemplateRepository{}, mockProd, nil, nil)
+
+	_, err := s.CreateOrder(ctx, &pb.CreateOrderRequest{
+		UserId:   1,
+		Shipping: testShipping(),
+		Items: []*pb.OrderItem{
+			{ProductId: 101, Quantity: 3},
+			{ProductId: 102, Quantity: 1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if created.TotalAmountMinor != 50 || created.Currency != "RUB" {
+		t.Errorf("Expected total 50 RUB minor units, got %d %s", created.TotalAmountMinor, created.Currency)
+	}
+	if created.Items[1].PriceMinor != 20 {
+		t.Errorf("Expected legacy price to be converted to 20 minor units, got %d", created.Items[1].PriceMinor)
+	}
+}
+
+func TestCreateOrderReservesStockInOneBatch(t *testing.T) {
+	products := func(ctx context.Context, ids []int64) (map[int64]*productpb.ProductResponse, error) {
+		return map[int64]*productpb.ProductResponse{
+			101: {Id: 101, Name: "Product A", PriceMinor: 1000, Currency: "RUB"},
+			102: {Id: 102, Name: "Product B", PriceMinor: 500, Currency: "RUB"},
+		}, nil
+	}
+	req := &pb.CreateOrderRequest{
+		UserId:   1,
+		Shipping: testShipping(),
+		Items: []*pb.OrderItem{
+			{ProductId: 102, Quantity: 5},
+			{ProductId: 101, Quantity: 1},
+		},
+	}
+
+	t.Run("Reserved", func(t *testing.T) {
+		batches := 0
+		mockProd := &mockProductClient{
+			getProductsFunc: products,
+			batchUpdateStockFunc: func(ctx context.Context, items []*productpb.StockAdjustment, reason string, orderID int64) (*productpb.BatchUpdateStockResponse, error) {
+				deltas := productDeltas(items)
+				batches++
+				if len(deltas) != 2 || deltas[101] != -1 || deltas[102] != -5 || reason != model.StockReasonOrderCreated {
+					t.Errorf("unexpected reservation %v with reason %q", deltas, reason)
+				}
+				return &productpb.BatchUpdateStockResponse{}, nil
+			},
+		}
+		mockRepo := &mockOrderRepository{
+			createOrderFunc: func(ctx context.Context, order *model.Order) (*model.Order, error) {
+				order.ID = 1
+				return order, nil
+			},
+		}
+		s := service.NewOrderService(mockRepo, &mockPromotionRepository{}, &mockPaymentRepository{}, &mockCompensationRepository{}, &mockReturnRepository{}, &mockInvoiceRepository{}, nil, &mockTemplateRepository{}, mockProd, nil, nil)
+
+		if _, err := s.CreateOrder(contextWithAuth("1", "user"), req); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if batches != 1 {
+			t.Errorf("Expected one BatchUpdateStock call, got %d", batches)
+		}
+	})
+
+	t.Run("Short Item Is Named", func(t *testing.T) {
+		mockProd := &mockProductClient{
+			getProductsFunc: products,
+			batchUpdateStockFunc: func(ctx context.Context, items []*productpb.StockAdjustment, reason string, orderID int64) (*productpb.BatchUpdateStockResponse, error) {
+				return nil, status.Error(codes.InvalidArgument, "insufficient stock for product 102: requested 5, available 3")
+			},
+		}
+		compensations := &mockCompensationRepository{}
+		s := service.NewOrderService(&mockOrderRepository{}, &mockPromotionRepository{}, &mockPaymentRepository{}, compensations, &mockReturnRepository{}, &mockInvoiceRepository{}, nil, &mockTemplateRepository{}, mockProd, nil, nil)
+
+		_, err := s.CreateOrder(contextWithAuth("1", "user"), req)
+		if status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("Expected InvalidArgument, got %v", err)
+		}
+		if msg := status.Convert(err).Message(); msg != "STOCK_ERROR: insufficient stock for product 102: requested 5, available 3" {
+			t.Errorf("Unexpected message %q", msg)
+		}
+		if len(compensations.queued) != 0 {
+			t.Errorf("Nothing was reserved, so nothing must be compensated, got %d", len(compensations.queued))
+		}
+	})
+}
+
+func TestCreateOrderVariants(t *testing.T) {
+	override := int64(1500)
+	products := func(ctx context.Context, ids []int64) (map[int64]*productpb.ProductResponse, error) {
+		return map[int64]*productpb.ProductResponse{
+			101: {Id: 101, Name: "T-shirt", PriceMinor: 1000, Currency: "RUB", Variants: []*productpb.ProductVariant{
+				{Id: 11, Sku: "TS-S", PriceMinor: 1000, Stock: 5},
+				{Id: 12, Sku: "TS-XL", PriceMinor: override, PriceOverrideMinor: &override, Stock: 5},
+			}},
+			102: {Id: 102, Name: "Mug", PriceMinor: 500, Currency: "RUB", Variants: []*productpb.ProductVariant{
+				{Id: 21, Sku: "P-102", PriceMinor: 500, Stock: 5},
+			}},
+		}, nil
+	}
+
+	t.Run("Resolved", func(t *testing.T) {
+		var reserved []*productpb.StockAdjustment
+		var created *model.Order
+		mockProd := &mockProductClient{
+			getProductsFunc: products,
+			batchUpdateStockFunc: func(ctx context.Context, items []*productpb.StockAdjustment, reason string, orderID int64) (*productpb.BatchUpdateStockResponse, error) {
+				reserved = items
+				return &productpb.BatchUpdateStockResponse{}, nil
+			},
+		}
+		mockRepo := &mockOrderRepository{
+			createOrderFunc: func(ctx context.Context, order *model.Order) (*model.Order, error) {
+				order.ID = 1
+				created = order
+				return order, nil
+			},
+		}
+		s := service.NewOrderService(mockRepo, &mockPromotionRepository{}, &mockPaymentRepository{}, &mockCompensationRepository{}, &mockReturnRepository{}, &mockInvoiceRepository{}, nil, &mockTemplateRepository{}, mockProd, nil, nil)
+
+		_, err := s.CreateOrder(contextWithAuth("1", "user"), &pb.CreateOrderRequest{
+			UserId:   1,
+			Shipping: testShipping(),
+			Items: []*pb.OrderItem{
+				{ProductId: 101, VariantId: 12, Quantity: 1},
+				{ProductId: 101, VariantId: 11, Quantity: 2},
+				{ProductId: 102, Quantity: 1},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		items := created.Items
+		if items[0].VariantID != 12 || items[0].VariantSKU != "TS-XL" || items[0].PriceMinor != 1500 {
+			t.Errorf("Expected variant price override, got %+v", items[0])
+		}
+		if items[1].VariantID != 11 || items[1].PriceMinor != 1000 {
+			t.Errorf("Expected product price for variant without override, got %+v", items[1])
+		}
+		if items[2].VariantID != 21 || items[2].VariantSKU != "P-102" {
+			t.Errorf("Expected the only variant to be picked, got %+v", items[2])
+		}
+		if created.TotalAmountMinor != 4000 {
+			t.Errorf("Expected total 4000, got %d", created.TotalAmountMinor)
+		}
+
+		want := []struct {
+			productID, variantID int64
+			delta                int32
+		}{{101, 11, -2}, {101, 12, -1}, {102, 21, -1}}
+		if len(reserved) != len(want) {
+			t.Fatalf("Expected %d reserved variants, got %v", len(want), reserved)
+		}
+		for i, w := range want {
+			if reserved[i].ProductId != w.productID || reserved[i].VariantId != w.variantID || reserved[i].QuantityDelta != w.delta {
+				t.Errorf("item %d: expected %+v, got %v", i, w, reserved[i])
+			}
+		}
+	})
+
+	tests := []struct {
+		name         string
+		item         *pb.OrderItem
+		expectedCode codes.Code
+		expectedMsg  string
+	}{
+		{"Variant Required", &pb.OrderItem{ProductId: 101, Quantity: 1}, codes.InvalidArgument, "VARIANT_REQUIRED: Product 101 has 2 variants, variant_id is required"},
+		{"Unknown Variant", &pb.OrderItem{ProductId: 101, VariantId: 21, Quantity: 1}, codes.NotFound, "VARIANT_NOT_FOUND: Variant 21 of product 101 not found"},
+		{"Negative Variant", &pb.OrderItem{ProductId: 101, VariantId: -1, Quantity: 1}, codes.InvalidArgument, "INVALID_REQUEST: invalid variant_id for product 101"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProd := &mockProductClient{
+				getProductsFunc: products,
+				batchUpdateStockFunc: func(ctx context.Context, items []*productpb.StockAdjustment, reason string, orderID int64) (*productpb.BatchUpdateStockResponse, error) {
+					t.Error("Stock must not be reserved")
+					return nil, nil
+				},
+			}
+			s := service.NewOrderService(&mockOrderRepository{}, &mockPromotionRepository{}, &mockPaymentRepository{}, &mockCompensationRepository{}, &mockReturnRepository{}, &mockInvoiceRepository{}, nil, &mockTemplateRepository{}, mockProd, nil, nil)
+
+			_, err := s.CreateOrder(contextWithAuth("1", "user"), &pb.CreateOrderRequest{
+				UserId:   1,
+				Shipping: testShipping(),
+				Items:    []*pb.OrderItem{tt.item},
+			})
+			if status.Code(err) != tt.expectedCode {
+				t.Fatalf("Expected %v, got %v", tt.expectedCode, err)
+			}
+			if msg := status.Convert(err).Message(); msg != tt.expectedMsg {
+				t.Errorf("Unexpected message %q", msg)
+			}
+		})
+	}
+}
+
 func TestGetOrder(t *testing.T) {
 	testOrder := &model.Order{ID: 1, UserID: 1, Status: "pending", TotalAmount: 50.0}
 
@@ -274,15 +684,15 @@ func TestGetOrder(t *testing.T) {
 			req:          &pb.GetOrderRequest{OrderId: 1},
 			mockGetOrder: nil,
 			expectedCode: codes.Unauthenticated,
-			expectedMsg:  "UNAUTHORIZED: metadata is missing",
+			expectedMsg:  "UNAUTHORIZED: identity is missing",
 		},
 		{
-			name:         "Unauthorized - Invalid User ID Format",
-			ctx:          contextWithAuth("invalid-id", "user"),
+			name:         "Unauthorized - Service Caller Without User",
+			ctx:          identity.NewContext(context.Background(), identity.Principal{Role: identity.RoleService, Service: "order-service"}),
 			req:          &pb.GetOrderRequest{OrderId: 1},
 			mockGetOrder: nil,
 			expectedCode: codes.Unauthenticated,
-			expectedMsg:  "UNAUTHORIZED: invalid x-user-id header: strconv.ParseInt: parsing \"invalid-id\": invalid syntax",
+			expectedMsg:  "UNAUTHORIZED: caller is not a user",
 		},
 		{
 			name:         "Access Denied - Not Owner Nor Admin",
@@ -307,7 +717,7 @@ func TestGetOrder(t *testing.T) {
 			mockRepo := &mockOrderRepository{
 				getOrderFunc: tt.mockGetOrder,
 			}
-			s := service.NewOrderService(mockRepo, nil) // Product client not needed for GetOrder
+			s := service.NewOrderService(mockRepo, &mockPromotionRepository{}, &mockPaymentRepository{}, &mockCompensationRepository{}, &mockReturnRepository{}, &mockInvoiceRepository{}, nil, &mockTemplateRepository{}, nil, nil, nil) // Product client not needed for GetOrder
 
 			resp, err := s.GetOrder(tt.ctx, tt.req)
 
@@ -344,7 +754,7 @@ func TestGetUserOrders(t *testing.T) {
 		name          string
 		ctx           context.Context
 		req           *pb.GetUserOrdersRequest
-		mockGetOrders func(ctx context.Context, userID int64, limit int, offset int) ([]model.Order, int64, error)
+		mockGetOrders func(ctx context.Context, userID int64, filter repository.UserOrderFilter, limit int, offset int) ([]model.Order, int64, error)
 		expectedCode  codes.Code
 		expectedMsg   string
 		expectedCount int
@@ -353,7 +763,7 @@ func TestGetUserOrders(t *testing.T) {
 			name: "Success",
 			ctx:  ctx,
 			req:  &pb.GetUserOrdersRequest{UserId: 1, Page: 1, PageSize: 10},
-			mockGetOrders: func(ctx context.Context, userID int64, limit int, offset int) ([]model.Order, int64, error) {
+			mockGetOrders: func(ctx context.Context, userID int64, filter repository.UserOrderFilter, limit int, offset int) ([]model.Order, int64, error) {
 				return []model.Order{
 					{ID: 1, UserID: 1},
 					{ID: 2, UserID: 1},
@@ -374,7 +784,7 @@ func TestGetUserOrders(t *testing.T) {
 			name: "Pagination - Default Values (Page < 1, Size > 100)",
 			ctx:  ctx,
 			req:  &pb.GetUserOrdersRequest{UserId: 1, Page: 0, PageSize: 1000},
-			mockGetOrders: func(ctx context.Context, userID int64, limit int, offset int) ([]model.Order, int64, error) {
+			mockGetOrders: func(ctx context.Context, userID int64, filter repository.UserOrderFilter, limit int, offset int) ([]model.Order, int64, error) {
 				if limit != 20 {
 					return nil, 0, errors.New("unexpected limit, expected 20 (default)")
 				}
@@ -390,12 +800,48 @@ func TestGetUserOrders(t *testing.T) {
 			name: "Database Error",
 			ctx:  ctx,
 			req:  &pb.GetUserOrdersRequest{UserId: 1, Page: 1, PageSize: 10},
-			mockGetOrders: func(ctx context.Context, userID int64, limit int, offset int) ([]model.Order, int64, error) {
+			mockGetOrders: func(ctx context.Context, userID int64, filter repository.UserOrderFilter, limit int, offset int) ([]model.Order, int64, error) {
 				return nil, 0, errors.New("db error")
 			},
 			expectedCode: codes.Internal,
 			expectedMsg:  "DATABASE_ERROR: Failed to get user orders: db error",
 		},
+		{
+			name: "Filters - Status And Date Range Passed To Repository",
+			ctx:  ctx,
+			req: &pb.GetUserOrdersRequest{
+				UserId:   1,
+				Status:   proto.String("completed"),
+				FromDate: timestamppb.New(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+				ToDate:   timestamppb.New(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			mockGetOrders: func(ctx context.Context, userID int64, filter repository.UserOrderFilter, limit int, offset int) ([]model.Order, int64, error) {
+				if filter.Status != "completed" || filter.From == nil || filter.From.Month() != time.January || filter.To == nil || filter.To.Month() != time.February {
+					return nil, 0, fmt.Errorf("unexpected filter %+v", filter)
+				}
+				return []model.Order{{ID: 1, UserID: 1, Status: "completed"}}, 1, nil
+			},
+			expectedCode:  codes.OK,
+			expectedCount: 1,
+		},
+		{
+			name:         "Filters - Unknown Status",
+			ctx:          ctx,
+			req:          &pb.GetUserOrdersRequest{UserId: 1, Status: proto.String("shipped-ish")},
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "INVALID_STATUS: Invalid status",
+		},
+		{
+			name: "Filters - Inverted Date Range",
+			ctx:  ctx,
+			req: &pb.GetUserOrdersRequest{
+				UserId:   1,
+				FromDate: timestamppb.New(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)),
+				ToDate:   timestamppb.New(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)),
+			},
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "INVALID_REQUEST: from_date is after to_date",
+		},
 	}
 
 	for _, tt := range tests {
@@ -403,7 +849,7 @@ func TestGetUserOrders(t *testing.T) {
 			mockRepo := &mockOrderRepository{
 				getOrdersByUserIDFunc: tt.mockGetOrders,
 			}
-			s := service.NewOrderService(mockRepo, nil)
+			s := service.NewOrderService(mockRepo, &mockPromotionRepository{}, &mockPaymentRepository{}, &mockCompensationRepository{}, &mockReturnRepository{}, &mockInvoiceRepository{}, nil, &mockTemplateRepository{}, nil, nil, nil)
 
 			resp, err := s.GetUserOrders(tt.ctx, tt.req)
 
@@ -498,7 +944,7 @@ func TestCancelOrder(t *testing.T) {
 				getOrderFunc:    tt.mockGetOrder,
 				updateOrderFunc: tt.mockUpdateOrder,
 			}
-			s := service.NewOrderService(mockRepo, nil)
+			s := service.NewOrderService(mockRepo, &mockPromotionRepository{}, &mockPaymentRepository{}, &mockCompensationRepository{}, &mockReturnRepository{}, &mockInvoiceRepository{}, nil, &mockTemplateRepository{}, nil, nil, nil)
 
 			_, err := s.CancelOrder(tt.ctx, tt.req)
 
@@ -526,9 +972,10 @@ func TestCancelOrder(t *testing.T) {
 }
 
 func TestUpdateOrder(t *testing.T) {
-	testOrder := &model.Order{ID: 1, UserID: 1, Status: "pending"}
+	testOrder := &model.Order{ID: 1, UserID: 1, Status: "pending", Version: 3}
 	newStatus := "confirmed"
 	invalidStatus := "weird_status"
+	staleVersion := int64(2)
 
 	tests := []struct {
 		name            string
@@ -580,6 +1027,23 @@ func TestUpdateOrder(t *testing.T) {
 			expectedCode:    codes.Internal,
 			expectedMsg:     "DATABASE_ERROR: Failed to update order: update failed",
 		},
+		{
+			name:         "Stale Expected Version",
+			ctx:          contextWithAuth("1", "user"),
+			req:          &pb.UpdateOrderRequest{OrderId: 1, Status: &newStatus, ExpectedVersion: &staleVersion},
+			mockGetOrder: func(ctx context.Context, orderID int64) (*model.Order, error) { return testOrder, nil },
+			expectedCode: codes.Aborted,
+			expectedMsg:  "VERSION_CONFLICT: Order has version 3, expected 2",
+		},
+		{
+			name:            "Concurrent Modification",
+			ctx:             contextWithAuth("1", "user"),
+			req:             &pb.UpdateOrderRequest{OrderId: 1, Status: &newStatus},
+			mockGetOrder:    func(ctx context.Context, orderID int64) (*model.Order, error) { return testOrder, nil },
+			mockUpdateOrder: func(ctx context.Context, order *model.Order) error { return repository.ErrVersionConflict },
+			expectedCode:    codes.Aborted,
+			expectedMsg:     "VERSION_CONFLICT: Order was modified concurrently, reload it and retry",
+		},
 	}
 
 	for _, tt := range tests {
@@ -588,7 +1052,7 @@ func TestUpdateOrder(t *testing.T) {
 				getOrderFunc:    tt.mockGetOrder,
 				updateOrderFunc: tt.mockUpdateOrder,
 			}
-			s := service.NewOrderService(mockRepo, nil)
+			s := service.NewOrderService(mockRepo, &mockPromotionRepository{}, &mockPaymentRepository{}, &mockCompensationRepository{}, &mockReturnRepository{}, &mockInvoiceRepository{}, nil, &mockTemplateRepository{}, nil, nil, nil)
 
 			_, err := s.UpdateOrder(tt.ctx, tt.req)
 
@@ -615,6 +1079,299 @@ func TestUpdateOrder(t *testing.T) {
 	}
 }
 
+func TestUpdateOrderCancelConflictKeepsStock(t *testing.T) {
+	cancelled := "cancelled"
+	mockRepo := &mockOrderRepository{
+		getOrderFunc: func(ctx context.Context, orderID int64) (*model.Order, error) {
+			return &model.Order{ID: 1, UserID: 1, Status: "pending", Version: 1, Items: []model.OrderItem{{ProductID: 101, Quantity: 2}}}, nil
+		},
+		updateOrderFunc: func(ctx context.Context, order *model.Order) error { return repository.ErrVersionConflict },
+	}
+	stockUpdates := 0
+	mockProd := &mockProductClient{
+		updateStockFunc: func(ctx context.Context, productID, variantID int64, quantityDelta int32, reason string, orderID int64) (*productpb.UpdateStockResponse, error) {
+			stockUpdates++
+			return &productpb.UpdateStockResponse{}, nil
+		},
+	}
+
+	s := service.NewOrderService(mockRepo, &mockPromotionRepository{}, &mockPaymentRepository{}, &mockCompensationRepository{}, &mockReturnRepository{}, &mockInvoiceRepository{}, nil, &mockTemplateRepository{}, mockProd, nil, nil)
+	_, err := s.UpdateOrder(contextWithAuth("99", "admin"), &pb.UpdateOrderRequest{OrderId: 1, Status: &cancelled})
+	if status.Code(err) != codes.Aborted {
+		t.Fatalf("expected Aborted, got %v", err)
+	}
+	if stockUpdates != 0 {
+		t.Errorf("stock must not be restored when the cancellation was not saved, got %d updates", stockUpdates)
+	}
+}
+
+func TestUpdateOrderItems(t *testing.T) {
+	pendingOrder := func() *model.Order {
+		return &model.Order{
+			ID:     1,
+			UserID: 1,
+			Status: "pending",
+			Items: []model.OrderItem{
+				{ProductID: 101, Quantity: 2, Price: 10.0},
+				{ProductID: 102, Quantity: 1, Price: 5.0},
+			},
+			TotalAmount: 25.0,
+		}
+	}
+	products := func(ctx context.Context, ids []int64) (map[int64]*productpb.ProductResponse, error) {
+		return map[int64]*productpb.ProductResponse{
+			101: {Id: 101, Name: "Product A", Price: 12.0},
+			103: {Id: 103, Name: "Product C", Price: 3.0},
+		}, nil
+	}
+
+	tests := []struct {
+		name                  string
+		ctx                   context.Context
+		req                   *pb.UpdateOrderItemsRequest
+		mockGetOrder          func(ctx context.Context, orderID int64) (*model.Order, error)
+		mockGetProducts       func(ctx context.Context, ids []int64) (map[int64]*productpb.ProductResponse, error)
+		mockUpdateStock       func(ctx context.Context, productID, variantID int64, quantityDelta int32, reason string, orderID int64) (*productpb.UpdateStockResponse, error)
+		mockReplaceOrderItems func(ctx context.Context, order *model.Order) error
+		expectedCode          codes.Code
+		expectedMsg           string
+		expectedTotal         float64
+		expectedDeltas        map[int64]int32
+	}{
+		{
+			name: "Success - Change, Remove And Add Items",
+			ctx:  contextWithAuth("1", "user"),
+			req: &pb.UpdateOrderItemsRequest{
+				OrderId: 1,
+				Items: []*pb.OrderItem{
+					{ProductId: 101, Quantity: 3},
+					{ProductId: 103, Quantity: 2},
+				},
+			},
+			mockGetOrder:          func(ctx context.Context, orderID int64) (*model.Order, error) { return pendingOrder(), nil },
+			mockGetProducts:       products,
+			mockReplaceOrderItems: func(ctx context.Context, order *model.Order) error { return nil },
+			expectedCode:          codes.OK,
+			expectedTotal:         42.0,
+			expectedDeltas:        map[int64]int32{101: -1, 102: 1, 103: -2},
+		},
+		{
+			name:         "Invalid Request - No Items",
+			ctx:          contextWithAuth("1", "user"),
+			req:          &pb.UpdateOrderItemsRequest{OrderId: 1},
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "INVALID_REQUEST: order must contain at least one item",
+		},
+		{
+			name:         "Access Denied - Not Owner",
+			ctx:          contextWithAuth("2", "user"),
+			req:          &pb.UpdateOrderItemsRequest{OrderId: 1, Items: []*pb.OrderItem{{ProductId: 101, Quantity: 1}}},
+			mockGetOrder: func(ctx context.Context, orderID int64) (*model.Order, error) { return pendingOrder(), nil },
+			expectedCode: codes.PermissionDenied,
+			expectedMsg:  "FORBIDDEN: Access denied",
+		},
+		{
+			name: "Invalid Status - Not Pending",
+			ctx:  contextWithAuth("1", "user"),
+			req:  &pb.UpdateOrderItemsRequest{OrderId: 1, Items: []*pb.OrderItem{{ProductId: 101, Quantity: 1}}},
+			mockGetOrder: func(ctx context.Context, orderID int64) (*model.Order, error) {
+				order := pendingOrder()
+				order.Status = "confirmed"
+				return order, nil
+			},
+			expectedCode: codes.FailedPrecondition,
+			expectedMsg:  "INVALID_STATUS: Cannot edit items of order with status 'confirmed'",
+		},
+		{
+			name:            "Stock Error Rolls Back Applied Changes",
+			ctx:             contextWithAuth("1", "user"),
+			req:             &pb.UpdateOrderItemsRequest{OrderId: 1, Items: []*pb.OrderItem{{ProductId: 101, Quantity: 2}, {ProductId: 103, Quantity: 5}}},
+			mockGetOrder:    func(ctx context.Context, orderID int64) (*model.Order, error) { return pendingOrder(), nil },
+			mockGetProducts: products,
+			mockUpdateStock: func(ctx context.Context, productID, variantID int64, quantityDelta int32, reason string, orderID int64) (*productpb.UpdateStockResponse, error) {
+				if productID == 103 && quantityDelta < 0 {
+					return nil, errors.New("insufficient stock")
+				}
+				return &productpb.UpdateStockResponse{}, nil
+			},
+			expectedCode:   codes.InvalidArgument,
+			expectedMsg:    "STOCK_ERROR: product 103: insufficient stock",
+			expectedDeltas: map[int64]int32{},
+		},
+		{
+			name:                  "Order Changed Concurrently",
+			ctx:                   contextWithAuth("1", "user"),
+			req:                   &pb.UpdateOrderItemsRequest{OrderId: 1, Items: []*pb.OrderItem{{ProductId: 101, Quantity: 3}}},
+			mockGetOrder:          func(ctx context.Context, orderID int64) (*model.Order, error) { return pendingOrder(), nil },
+			mockGetProducts:       products,
+			mockReplaceOrderItems: func(ctx context.Context, order *model.Order) error { return repository.ErrOrderNotPending },
+			expectedCode:          codes.FailedPrecondition,
+			expectedMsg:           "INVALID_STATUS: Order is no longer pending",
+			expectedDeltas:        map[int64]int32{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stock := make(map[int64]int32)
+			updateStock := tt.mockUpdateStock
+			if updateStock == nil {
+				updateStock = func(ctx context.Context, productID, variantID int64, quantityDelta int32, reason string, orderID int64) (*productpb.UpdateStockResponse, error) {
+					return &productpb.UpdateStockResponse{}, nil
+				}
+			}
+
+			mockRepo := &mockOrderRepository{
+				getOrderFunc:          tt.mockGetOrder,
+				replaceOrderItemsFunc: tt.mockReplaceOrderItems,
+			}
+			mockProd := &mockProductClient{
+				getProductsFunc: tt.mockGetProducts,
+				updateStockFunc: func(ctx context.Context, productID, variantID int64, quantityDelta int32, reason string, orderID int64) (*productpb.UpdateStockResponse, error) {
+					resp, err := updateStock(ctx, productID, variantID, quantityDelta, reason, orderID)
+					if err == nil {
+						stock[productID] += quantityDelta
+						if stock[productID] == 0 {
+							delete(stock, productID)
+						}
+					}
+					return resp, err
+				},
+			}
+			s := service.NewOrderService(mockRepo, &mockPromotionRepository{}, &mockPaymentRepository{}, &mockCompensationRepository{}, &mockReturnRepository{}, &mockInvoiceRepository{}, nil, &mockTemplateRepository{}, mockProd, nil, nil)
+
+			resp, err := s.UpdateOrderItems(tt.ctx, tt.req)
+
+			if tt.expectedDeltas != nil {
+				if len(stock) != len(tt.expectedDeltas) {
+					t.Errorf("Expected stock deltas %v, got %v", tt.expectedDeltas, stock)
+				}
+				for productID, delta := range tt.expectedDeltas {
+					if stock[productID] != delta {
+						t.Errorf("Expected stock delta %d for product %d, got %d", delta, productID, stock[productID])
+					}
+				}
+			}
+
+			if tt.expectedCode != codes.OK {
+				if err == nil {
+					t.Fatalf("Expected error, got nil")
+				}
+				st, ok := status.FromError(err)
+				if !ok {
+					t.Fatalf("Expected gRPC status error, got %T", err)
+				}
+				if st.Code() != tt.expectedCode {
+					t.Errorf("Expected status code %v, got %v", tt.expectedCode, st.Code())
+				}
+				if st.Message() != tt.expectedMsg {
+					t.Errorf("Expected error message '%s', got '%s'", tt.expectedMsg, st.Message())
+				}
+			} else {
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				if resp.GetOrder().GetTotalAmount() != tt.expectedTotal {
+					t.Errorf("Expected total %.2f, got %.2f", tt.expectedTotal, resp.GetOrder().GetTotalAmount())
+				}
+			}
+		})
+	}
+}
+
+// A line created before variants existed moves to the only variant of the product,
+// otherwise the batch would hold both the whole product and its variant
+func TestUpdateOrderItemsLegacyLine(t *testing.T) {
+	mockRepo := &mockOrderRepository{
+		getOrderFunc: func(ctx context.Context, orderID int64) (*model.Order, error) {
+			return &model.Order{ID: 7, UserID: 1, Status: "pending", Items: []model.OrderItem{
+				{OrderID: 7, ProductID: 101, Quantity: 2, PriceMinor: 1000, Currency: "RUB"},
+			}}, nil
+		},
+		replaceOrderItemsFunc: func(ctx context.Context, order *model.Order) error { return nil },
+	}
+	var batch []*productpb.StockAdjustment
+	mockProd := &mockProductClient{
+		getProductsFunc: func(ctx context.Context, ids []int64) (map[int64]*productpb.ProductResponse, error) {
+			return map[int64]*productpb.ProductResponse{
+				101: {Id: 101, Name: "Mug", PriceMinor: 1000, Currency: "RUB", Variants: []*productpb.ProductVariant{
+					{Id: 11, Sku: "P-101", PriceMinor: 1000, Stock: 10},
+				}},
+			}, nil
+		},
+		batchUpdateStockFunc: func(ctx context.Context, items []*productpb.StockAdjustment, reason string, orderID int64) (*productpb.BatchUpdateStockResponse, error) {
+			batch = items
+			return &productpb.BatchUpdateStockResponse{}, nil
+		},
+	}
+	s := service.NewOrderService(mockRepo, &mockPromotionRepository{}, &mockPaymentRepository{}, &mockCompensationRepository{}, &mockReturnRepository{}, &mockInvoiceRepository{}, nil, &mockTemplateRepository{}, mockProd, nil, nil)
+
+	resp, err := s.UpdateOrderItems(contextWithAuth("1", "user"), &pb.UpdateOrderItemsRequest{
+		OrderId: 7,
+		Items:   []*pb.OrderItem{{ProductId: 101, Quantity: 3}},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(batch) != 1 || batch[0].VariantId != 11 || batch[0].QuantityDelta != -1 {
+		t.Errorf("Expected one adjustment of variant 11 by -1, got %v", batch)
+	}
+	if item := resp.GetOrder().Items[0]; item.VariantId != 11 || item.Sku != "P-101" {
+		t.Errorf("Expected the line to reference variant 11, got %v", item)
+	}
+}
+
+func TestUpdateOrderItemsRemovesLegacyLine(t *testing.T) {
+	mockRepo := &mockOrderRepository{
+		getOrderFunc: func(ctx context.Context, orderID int64) (*model.Order, error) {
+			return &model.Order{ID: 7, UserID: 1, Status: "pending", Items: []model.OrderItem{
+				{OrderID: 7, ProductID: 101, Quantity: 2, PriceMinor: 1000, Currency: "RUB"},
+				{OrderID: 7, ProductID: 202, VariantID: 21, Quantity: 1, PriceMinor: 500, Currency: "RUB"},
+			}}, nil
+		},
+		replaceOrderItemsFunc: func(ctx context.Context, order *model.Order) error { return nil },
+	}
+	catalog := map[int64]*productpb.ProductResponse{
+		// Since the order was placed the product got a second variant, so variant 0 is ambiguous
+		101: {Id: 101, Name: "Mug", PriceMinor: 1000, Currency: "RUB", Variants: []*productpb.ProductVariant{
+			{Id: 11, Sku: "P-101", PriceMinor: 1000, Stock: 10},
+			{Id: 12, Sku: "MUG-RED", PriceMinor: 1000, Stock: 10},
+		}},
+		202: {Id: 202, Name: "Spoon", PriceMinor: 500, Currency: "RUB", Variants: []*productpb.ProductVariant{
+			{Id: 21, Sku: "P-202", PriceMinor: 500, Stock: 10},
+		}},
+	}
+	var batch []*productpb.StockAdjustment
+	mockProd := &mockProductClient{
+		getProductsFunc: func(ctx context.Context, ids []int64) (map[int64]*productpb.ProductResponse, error) {
+			result := make(map[int64]*productpb.ProductResponse)
+			for _, id := range ids {
+				if p, ok := catalog[id]; ok {
+					result[id] = p
+				}
+			}
+			return result, nil
+		},
+		batchUpdateStockFunc: func(ctx context.Context, items []*productpb.StockAdjustment, reason string, orderID int64) (*productpb.BatchUpdateStockResponse, error) {
+			batch = items
+			return &productpb.BatchUpdateStockResponse{}, nil
+		},
+	}
+	s := service.NewOrderService(mockRepo, &mockPromotionRepository{}, &mockPaymentRepository{}, &mockCompensationRepository{}, &mockReturnRepository{}, &mockInvoiceRepository{}, nil, &mockTemplateRepository{}, mockProd, nil, nil)
+
+	if _, err := s.UpdateOrderItems(contextWithAuth("1", "user"), &pb.UpdateOrderItemsRequest{
+		OrderId: 7,
+		Items:   []*pb.OrderItem{{ProductId: 202, VariantId: 21, Quantity: 1}},
+	}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(batch) != 1 || batch[0].ProductId != 101 || batch[0].VariantId != 11 || batch[0].QuantityDelta != 2 {
+		t.Errorf("Expected the removed line to return 2 to variant 11, got %v", batch)
+	}
+}
+
 func TestGetOrderStats(t *testing.T) {
 	ctx := contextWithAuth("1", "user")
 
@@ -622,23 +1379,26 @@ func TestGetOrderStats(t *testing.T) {
 		name                string
 		ctx                 context.Context
 		req                 *pb.GetOrderStatsRequest
-		mockGetOrders       func(ctx context.Context, userID int64, limit int, offset int) ([]model.Order, int64, error)
+		mockGetOrders       func(ctx context.Context, userID int64, filter repository.UserOrderFilter, limit int, offset int) ([]model.Order, int64, error)
 		expectedCode        codes.Code
 		expectedMsg         string
 		expectedTotalOrders int32
+		expectedSpentMinor  int64
 	}{
 		{
 			name: "Success",
 			ctx:  ctx,
 			req:  &pb.GetOrderStatsRequest{UserId: 1},
-			mockGetOrders: func(ctx context.Context, userID int64, limit int, offset int) ([]model.Order, int64, error) {
+			mockGetOrders: func(ctx context.Context, userID int64, filter repository.UserOrderFilter, limit int, offset int) ([]model.Order, int64, error) {
 				return []model.Order{
 					{Status: "completed", TotalAmount: 100},
+					{Status: "completed", TotalAmountMinor: 1050, Currency: "RUB"},
 					{Status: "pending"},
-				}, 2, nil
+				}, 3, nil
 			},
 			expectedCode:        codes.OK,
-			expectedTotalOrders: 2,
+			expectedTotalOrders: 3,
+			expectedSpentMinor:  11050,
 		},
 		{
 			name:          "Access Denied - Not Owner",
@@ -652,7 +1412,7 @@ func TestGetOrderStats(t *testing.T) {
 			name: "Database Error",
 			ctx:  ctx,
 			req:  &pb.GetOrderStatsRequest{UserId: 1},
-			mockGetOrders: func(ctx context.Context, userID int64, limit int, offset int) ([]model.Order, int64, error) {
+			mockGetOrders: func(ctx context.Context, userID int64, filter repository.UserOrderFilter, limit int, offset int) ([]model.Order, int64, error) {
 				return nil, 0, errors.New("db error")
 			},
 			expectedCode: codes.Internal,
@@ -665,7 +1425,7 @@ func TestGetOrderStats(t *testing.T) {
 			mockRepo := &mockOrderRepository{
 				getOrdersByUserIDFunc: tt.mockGetOrders,
 			}
-			s := service.NewOrderService(mockRepo, nil)
+			s := service.NewOrderService(mockRepo, &mockPromotionRepository{}, &mockPaymentRepository{}, &mockCompensationRepository{}, &mockReturnRepository{}, &mockInvoiceRepository{}, nil, &mockTemplateRepository{}, nil, nil, nil)
 
 			resp, err := s.GetOrderStats(tt.ctx, tt.req)
 
@@ -690,6 +1450,9 @@ func TestGetOrderStats(t *testing.T) {
 				if resp.GetTotalOrders() != tt.expectedTotalOrders {
 					t.Errorf("Expected %d total orders, got %d", tt.expectedTotalOrders, resp.GetTotalOrders())
 				}
+				if resp.GetTotalSpentMinor() != tt.expectedSpentMinor {
+					t.Errorf("Expected %d spent minor units, got %d", tt.expectedSpentMinor, resp.GetTotalSpentMinor())
+				}
 			}
 		})
 	}
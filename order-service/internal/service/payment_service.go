@@ -0,0 +1,430 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	pb "order-service/api/order/v1"
+	"order-service/internal/model"
+	"order-service/internal/payment"
+	"order-service/internal/repository"
+
+	"github.com/microserviceteam0/bff-gateway/shared/money"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// PaymentFailedReason — причина отмены заказа, оплата которого не прошла
+const PaymentFailedReason = "payment_failed"
+
+// PayOrder авторизует оплату ожидающего заказа. Синхронный итог применяется сразу,
+// асинхронный — в HandlePaymentEvent
+func (s *OrderServiceImpl) PayOrder(ctx context.Context, req *pb.PayOrderRequest) (*pb.PayOrderResponse, error) {
+	userID, isAdmin, err := s.getUserInfoFromContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "UNAUTHORIZED: %v", err)
+	}
+
+	if req.PaymentToken == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: payment_token is required")
+	}
+
+	order, err := s.repo.GetOrder(ctx, req.OrderId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "NOT_FOUND: Order not found")
+		}
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to get order: %v", err)
+	}
+
+	if !isAdmin && order.UserID != userID {
+		return nil, status.Errorf(codes.PermissionDenied, "FORBIDDEN: Access denied")
+	}
+
+	if order.Status != "pending" {
+		return nil, status.Errorf(codes.FailedPrecondition, "INVALID_STATUS: Cannot pay order with status '%s'", order.Status)
+	}
+
+	total := order.Total()
+	p := &model.Payment{
+		OrderID:     order.ID,
+		Provider:    s.paymentProvider.Name(),
+		Status:      payment.StatusPending,
+		AmountMinor: total.Amount,
+		Currency:    total.Currency,
+	}
+
+	// Проверка активной оплаты и авторизация идут под блокировкой заказа:
+	// параллельный PayOrder дождется ее и увидит этот платеж, поэтому деньги не спишутся дважды
+	var result *payment.Result
+	err = s.paymentRepo.CreatePayment(ctx, p, func(existing []model.Payment) error {
+		if err := activePaymentError(existing); err != nil {
+			return err
+		}
+		authorized, err := s.paymentProvider.Authorize(ctx, payment.AuthorizeRequest{
+			OrderID: order.ID,
+			UserID:  order.UserID,
+			Amount:  total,
+			Token:   req.PaymentToken,
+		})
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "PAYMENT_PROVIDER_ERROR: %v", err)
+		}
+		result = authorized
+		p.ProviderRef = authorized.ProviderRef
+		return nil
+	})
+	if err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		// Авторизация прошла, но платеж не сохранен: деньги не должны остаться заблокированными
+		if result != nil {
+			if _, verr := s.paymentProvider.Void(context.WithoutCancel(ctx), result.ProviderRef); verr != nil {
+				slog.Error("Failed to void unsaved authorization", "order_id", order.ID, "provider_ref", result.ProviderRef, "error", verr)
+			}
+		}
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to save payment: %v", err)
+	}
+
+	if result.Status != payment.StatusPending {
+		if err := s.applyPaymentOutcome(ctx, p, order, result.Status, result.FailureReason, nil); err != nil {
+			return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to save payment: %v", err)
+		}
+	}
+
+	return &pb.PayOrderResponse{
+		Result: &pb.PayOrderResponse_Payment{
+			Payment: paymentToProto(p),
+		},
+	}, nil
+}
+
+func (s *OrderServiceImpl) GetOrderPayments(ctx context.Context, req *pb.GetOrderPaymentsRequest) (*pb.GetOrderPaymentsResponse, error) {
+	userID, isAdmin, err := s.getUserInfoFromContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "UNAUTHORIZED: %v", err)
+	}
+
+	order, err := s.repo.GetOrder(ctx, req.OrderId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "NOT_FOUND: Order not found")
+		}
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to get order: %v", err)
+	}
+
+	if !isAdmin && order.UserID != userID {
+		return nil, status.Errorf(codes.PermissionDenied, "FORBIDDEN: Access denied")
+	}
+
+	payments, err := s.paymentRepo.ListPaymentsByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to get payments: %v", err)
+	}
+
+	pbPayments := make([]*pb.Payment, len(payments))
+	for i := range payments {
+		pbPayments[i] = paymentToProto(&payments[i])
+	}
+
+	return &pb.GetOrderPaymentsResponse{Payments: pbPayments}, nil
+}
+
+// CapturePayment списывает авторизованную сумму (целиком или частично)
+func (s *OrderServiceImpl) CapturePayment(ctx context.Context, req *pb.CapturePaymentRequest) (*pb.CapturePaymentResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	p, err := s.getPayment(ctx, req.PaymentId)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := req.AmountMinor
+	if amount == 0 {
+		amount = p.AmountMinor
+	}
+
+	result, err := s.paymentProvider.Capture(ctx, p.ProviderRef, money.New(amount, p.Currency))
+	if err != nil {
+		return nil, providerError(err)
+	}
+
+	p.CapturedMinor = amount
+	p.Status = result.Status
+	if err := s.paymentRepo.SavePayment(ctx, p, nil, nil); err != nil {
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to save payment: %v", err)
+	}
+
+	return &pb.CapturePaymentResponse{
+		Result: &pb.CapturePaymentResponse_Payment{
+			Payment: paymentToProto(p),
+		},
+	}, nil
+}
+
+// RefundPayment возвращает покупателю списанные деньги (целиком или частично)
+func (s *OrderServiceImpl) RefundPayment(ctx context.Context, req *pb.RefundPaymentRequest) (*pb.RefundPaymentResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	p, err := s.getPayment(ctx, req.PaymentId)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := req.AmountMinor
+	if amount == 0 {
+		amount = p.CapturedMinor - p.RefundedMinor
+	}
+
+	if _, err := s.refundPayment(ctx, p, amount); err != nil {
+		return nil, err
+	}
+
+	if err := s.paymentRepo.SavePayment(ctx, p, nil, nil); err != nil {
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to save payment: %v", err)
+	}
+
+	return &pb.RefundPaymentResponse{
+		Result: &pb.RefundPaymentResponse_Payment{
+			Payment: paymentToProto(p),
+		},
+	}, nil
+}
+
+// HandlePaymentEvent применяет асинхронное событие провайдера. Повторная доставка безопасна:
+// события для уже завершенного платежа и события с известным ID игнорируются
+func (s *OrderServiceImpl) HandlePaymentEvent(ctx context.Context, event payment.Event) error {
+	var outcome string
+	switch event.Type {
+	case payment.EventAuthorized:
+		outcome = payment.StatusAuthorized
+	case payment.EventFailed:
+		outcome = payment.StatusFailed
+	default:
+		slog.Warn("Ignoring unknown payment event", "event_id", event.ID, "type", event.Type)
+		return nil
+	}
+
+	p, err := s.paymentRepo.GetPaymentByProviderRef(ctx, event.ProviderRef)
+	if err != nil {
+		return fmt.Errorf("get payment %s: %w", event.ProviderRef, err)
+	}
+
+	if p.Status != payment.StatusPending {
+		slog.Info("Ignoring event for settled payment", "event_id", event.ID, "payment_id", p.ID, "status", p.Status)
+		return nil
+	}
+
+	order, err := s.repo.GetOrder(ctx, p.OrderID)
+	if err != nil {
+		return fmt.Errorf("get order %d: %w", p.OrderID, err)
+	}
+
+	return s.applyPaymentOutcome(ctx, p, order, outcome, event.FailureReason, &model.PaymentEvent{
+		EventID: event.ID,
+		Type:    event.Type,
+	})
+}
+
+// maxPaymentOutcomeAttempts ограничивает повторы, если заказ меняется быстрее, чем применяется итог платежа
+const maxPaymentOutcomeAttempts = 3
+
+// applyPaymentOutcome переводит платеж и заказ в итоговые статусы:
+// authorized подтверждает ожидающий заказ, failed отменяет его и возвращает товар на склад.
+// Если заказ успели изменить (отмена покупателем, истечение срока), итог применяется заново
+// к свежему заказу: авторизация отмененного заказа тогда аннулируется, а не подтверждает его
+func (s *OrderServiceImpl) applyPaymentOutcome(ctx context.Context, p *model.Payment, order *model.Order, outcome, reason string, event *model.PaymentEvent) error {
+	for attempt := 1; ; attempt++ {
+		err := s.savePaymentOutcome(ctx, p, order, outcome, reason, event)
+		if !errors.Is(err, repository.ErrVersionConflict) || attempt == maxPaymentOutcomeAttempts {
+			return err
+		}
+		if order, err = s.repo.GetOrder(ctx, order.ID); err != nil {
+			return fmt.Errorf("reload order %d: %w", p.OrderID, err)
+		}
+	}
+}
+
+func (s *OrderServiceImpl) savePaymentOutcome(ctx context.Context, p *model.Payment, order *model.Order, outcome, reason string, event *model.PaymentEvent) error {
+	p.Status = outcome
+	p.FailureReason = reason
+
+	orderChanged := false
+	switch outcome {
+	case payment.StatusAuthorized:
+		switch order.Status {
+		case "pending":
+			order.Status = "confirmed"
+			orderChanged = true
+		case "cancelled":
+			// Заказ отменили, пока провайдер думал: деньги не держим
+			if _, err := s.paymentProvider.Void(ctx, p.ProviderRef); err != nil {
+				slog.Warn("Failed to void payment of cancelled order", "payment_id", p.ID, "order_id", order.ID, "error", err)
+			} else {
+				p.Status = payment.StatusVoided
+			}
+		}
+	case payment.StatusFailed:
+		if order.Status == "pending" {
+			order.Status = "cancelled"
+			order.CancellationReason = PaymentFailedReason
+			orderChanged = true
+		}
+	}
+
+	var orderUpdate *model.Order
+	if orderChanged {
+		order.UpdatedAt = time.Now()
+		orderUpdate = order
+	}
+
+	if err := s.paymentRepo.SavePayment(ctx, p, orderUpdate, event); err != nil {
+		if errors.Is(err, repository.ErrDuplicateEvent) {
+			return nil
+		}
+		return err
+	}
+
+	if orderChanged && order.Status == "cancelled" {
+		s.restoreStock(ctx, order.ID, order.Items, model.CompensationPaymentFailed)
+	}
+	return nil
+}
+
+// releasePayments отпускает деньги отмененного заказа: авторизация аннулируется, списанное возвращается.
+// Ошибки только логируются — отмена заказа уже сохранена
+func (s *OrderServiceImpl) releasePayments(ctx context.Context, orderID int64) {
+	payments, err := s.paymentRepo.ListPaymentsByOrderID(ctx, orderID)
+	if err != nil {
+		slog.Warn("Failed to load payments of cancelled order", "order_id", orderID, "error", err)
+		return
+	}
+
+	for i := range payments {
+		p := &payments[i]
+
+		var (
+			result *payment.Result
+			err    error
+		)
+		switch p.Status {
+		case payment.StatusAuthorized:
+			result, err = s.paymentProvider.Void(ctx, p.ProviderRef)
+		case payment.StatusCaptured, payment.StatusPartiallyRefunded:
+			result, err = s.refundPayment(ctx, p, p.CapturedMinor-p.RefundedMinor)
+		default:
+			continue
+		}
+
+		if err != nil {
+			slog.Warn("Failed to release payment", "payment_id", p.ID, "order_id", orderID, "status", p.Status, "error", err)
+			continue
+		}
+
+		p.Status = result.Status
+		if err := s.paymentRepo.SavePayment(ctx, p, nil, nil); err != nil {
+			slog.Warn("Failed to save released payment", "payment_id", p.ID, "order_id", orderID, "error", err)
+		}
+	}
+}
+
+// refundPayment возвращает деньги через провайдера. Сумма сначала резервируется на платеже условным UPDATE,
+// поэтому параллельные возвраты не вернут больше списанного; если провайдер отказал, резерв снимается
+func (s *OrderServiceImpl) refundPayment(ctx context.Context, p *model.Payment, amount int64) (*payment.Result, error) {
+	if p.Status != payment.StatusCaptured && p.Status != payment.StatusPartiallyRefunded {
+		return nil, providerError(payment.ErrInvalidState)
+	}
+	if amount <= 0 {
+		return nil, providerError(payment.ErrInvalidAmount)
+	}
+
+	if err := s.paymentRepo.ReserveRefund(ctx, p.ID, amount); err != nil {
+		if errors.Is(err, repository.ErrRefundExceedsCaptured) {
+			return nil, status.Errorf(codes.InvalidArgument, "INVALID_AMOUNT: Refund of %s exceeds the refundable amount of payment %d", money.New(amount, p.Currency).String(), p.ID)
+		}
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to reserve refund: %v", err)
+	}
+
+	result, err := s.paymentProvider.Refund(ctx, p.ProviderRef, money.New(amount, p.Currency))
+	if err != nil {
+		if rerr := s.paymentRepo.ReleaseRefund(context.WithoutCancel(ctx), p.ID, amount); rerr != nil {
+			slog.Error("Failed to release refund reservation", "payment_id", p.ID, "amount_minor", amount, "error", rerr)
+		}
+		return nil, providerError(err)
+	}
+
+	p.RefundedMinor += amount
+	p.Status = result.Status
+	return result, nil
+}
+
+// ensureNoActivePayment запрещает операции, конфликтующие с незавершенной или проведенной оплатой
+func (s *OrderServiceImpl) ensureNoActivePayment(ctx context.Context, orderID int64) error {
+	payments, err := s.paymentRepo.ListPaymentsByOrderID(ctx, orderID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to get payments: %v", err)
+	}
+	return activePaymentError(payments)
+}
+
+// activePaymentError — PAYMENT_IN_PROGRESS, если среди платежей есть незавершенный или проведенный
+func activePaymentError(payments []model.Payment) error {
+	for _, p := range payments {
+		switch p.Status {
+		case payment.StatusPending, payment.StatusAuthorized, payment.StatusCaptured:
+			return status.Errorf(codes.FailedPrecondition, "PAYMENT_IN_PROGRESS: Order already has a %s payment", p.Status)
+		}
+	}
+	return nil
+}
+
+func (s *OrderServiceImpl) getPayment(ctx context.Context, paymentID int64) (*model.Payment, error) {
+	p, err := s.paymentRepo.GetPayment(ctx, paymentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "NOT_FOUND: Payment not found")
+		}
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to get payment: %v", err)
+	}
+	return p, nil
+}
+
+func providerError(err error) error {
+	switch {
+	case errors.Is(err, payment.ErrInvalidAmount):
+		return status.Errorf(codes.InvalidArgument, "INVALID_AMOUNT: %v", err)
+	case errors.Is(err, payment.ErrInvalidState):
+		return status.Errorf(codes.FailedPrecondition, "INVALID_PAYMENT_STATUS: %v", err)
+	case errors.Is(err, payment.ErrUnknownPayment):
+		return status.Errorf(codes.NotFound, "NOT_FOUND: %v", err)
+	default:
+		return status.Errorf(codes.Unavailable, "PAYMENT_PROVIDER_ERROR: %v", err)
+	}
+}
+
+func paymentToProto(p *model.Payment) *pb.Payment {
+	return &pb.Payment{
+		Id:            p.ID,
+		OrderId:       p.OrderID,
+		Provider:      p.Provider,
+		ProviderRef:   p.ProviderRef,
+		Status:        p.Status,
+		AmountMinor:   p.AmountMinor,
+		CapturedMinor: p.CapturedMinor,
+		RefundedMinor: p.RefundedMinor,
+		Currency:      p.Currency,
+		FailureReason: p.FailureReason,
+		CreatedAt:     timestamppb.New(p.CreatedAt),
+		UpdatedAt:     timestamppb.New(p.UpdatedAt),
+	}
+}
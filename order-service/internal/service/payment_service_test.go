@@ -0,0 +1,513 @@
+package service_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	pb "order-service/api/order/v1"
+	"order-service/internal/model"
+	"order-service/internal/payment"
+	"order-service/internal/repository"
+	"order-service/internal/service"
+
+	productpb "order-service/pkg/api/product/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// mockPaymentRepository хранит платежи в памяти; по умолчанию у заказа нет платежей
+type mockPaymentRepository struct {
+	payments []model.Payment
+	events   map[string]bool
+	// beforeReserveRefund и beforeCreatePayment имитируют параллельный запрос, успевший изменить платежи
+	beforeReserveRefund func()
+	beforeCreatePayment func()
+}
+
+var _ repository.PaymentRepository = (*mockPaymentRepository)(nil)
+
+func (m *mockPaymentRepository) CreatePayment(ctx context.Context, p *model.Payment, prepare func(existing []model.Payment) error) error {
+	if m.beforeCreatePayment != nil {
+		m.beforeCreatePayment()
+	}
+	existing, _ := m.ListPaymentsByOrderID(ctx, p.OrderID)
+	if err := prepare(existing); err != nil {
+		return err
+	}
+	p.ID = int64(len(m.payments) + 1)
+	m.payments = append(m.payments, *p)
+	return nil
+}
+
+func (m *mockPaymentRepository) GetPayment(ctx context.Context, paymentID int64) (*model.Payment, error) {
+	for _, p := range m.payments {
+		if p.ID == paymentID {
+			return &p, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *mockPaymentRepository) GetPaymentByProviderRef(ctx context.Context, providerRef string) (*model.Payment, error) {
+	for _, p := range m.payments {
+		if p.ProviderRef == providerRef {
+			return &p, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *mockPaymentRepository) ListPaymentsByOrderID(ctx context.Context, orderID int64) ([]model.Payment, error) {
+	var result []model.Payment
+	for _, p := range m.payments {
+		if p.OrderID == orderID {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockPaymentRepository) SavePayment(ctx context.Context, p *model.Payment, order *model.Order, event *model.PaymentEvent) error {
+	if event != nil {
+		if m.events == nil {
+			m.events = make(map[string]bool)
+		}
+		if m.events[event.EventID] {
+			return repository.ErrDuplicateEvent
+		}
+		m.events[event.EventID] = true
+	}
+	for i := range m.payments {
+		if m.payments[i].ID == p.ID {
+			refunded := m.payments[i].RefundedMinor
+			m.payments[i] = *p
+			m.payments[i].RefundedMinor = refunded
+		}
+	}
+	return nil
+}
+
+func (m *mockPaymentRepository) ReserveRefund(ctx context.Context, paymentID int64, amountMinor int64) error {
+	if m.beforeReserveRefund != nil {
+		m.beforeReserveRefund()
+	}
+	for i := range m.payments {
+		if m.payments[i].ID == paymentID {
+			if m.payments[i].CapturedMinor-m.payments[i].RefundedMinor < amountMinor {
+				return repository.ErrRefundExceedsCaptured
+			}
+			m.payments[i].RefundedMinor += amountMinor
+			return nil
+		}
+	}
+	return repository.ErrRefundExceedsCaptured
+}
+
+func (m *mockPaymentRepository) ReleaseRefund(ctx context.Context, paymentID int64, amountMinor int64) error {
+	for i := range m.payments {
+		if m.payments[i].ID == paymentID {
+			m.payments[i].RefundedMinor -= amountMinor
+		}
+	}
+	return nil
+}
+
+// paymentFixture — заказ в памяти и счетчик возвращенного на склад товара
+type paymentFixture struct {
+	order    *model.Order
+	restored int32
+	payments *mockPaymentRepository
+	returns  *mockReturnRepository
+	provider *payment.FakeProvider
+	svc      service.OrderService
+	// beforeSave вызывается один раз перед SavePayment — так тест вклинивает параллельное изменение заказа
+	beforeSave func()
+}
+
+func newPaymentFixture() *paymentFixture {
+	f := &paymentFixture{
+		order: &model.Order{
+			ID:               1,
+			UserID:           1,
+			Status:           "pending",
+			TotalAmountMinor: 2000,
+			Currency:         "RUB",
+			Items:            []model.OrderItem{{ProductID: 101, Quantity: 2, PriceMinor: 1000, Currency: "RUB"}},
+		},
+		payments: &mockPaymentRepository{},
+		provider: payment.NewFakeProvider(),
+	}
+	repo := &mockOrderRepository{
+		getOrderFunc: func(ctx context.Context, orderID int64) (*model.Order, error) {
+			if orderID != f.order.ID {
+				return nil, gorm.ErrRecordNotFound
+			}
+			order := *f.order
+			return &order, nil
+		},
+		updateOrderFunc: func(ctx context.Context, order *model.Order) error {
+			f.order = order
+			return nil
+		},
+	}
+	prod := &mockProductClient{
+		updateStockFunc: func(ctx context.Context, productID, variantID int64, quantityDelta int32, reason string, orderID int64) (*productpb.UpdateStockResponse, error) {
+			f.restored += quantityDelta
+			return &productpb.UpdateStockResponse{}, nil
+		},
+	}
+	paymentRepo := &orderSyncingPaymentRepository{mockPaymentRepository: f.payments, fixture: f}
+	f.returns = &mockReturnRepository{
+		completeRefundFunc: func(ret *model.OrderReturn, p *model.Payment, order *model.Order) error {
+			if order.Version != f.order.Version {
+				return repository.ErrVersionConflict
+			}
+			if p != nil {
+				_ = f.payments.SavePayment(context.Background(), p, nil, nil)
+			}
+			f.order.Status = order.Status
+			f.order.RefundedMinor += ret.RefundMinor
+			f.order.Version++
+			order.Version++
+			return nil
+		},
+	}
+	f.svc = service.NewOrderService(repo, &mockPromotionRepository{}, paymentRepo, &mockCompensationRepository{}, f.returns, &mockInvoiceRepository{}, nil, &mockTemplateRepository{}, prod, nil, f.provider)
+	return f
+}
+
+// orderSyncingPaymentRepository применяет изменения заказа из SavePayment к заказу фикстуры
+// и, как настоящий репозиторий, отклоняет изменение заказа с устаревшей версией
+type orderSyncingPaymentRepository struct {
+	*mockPaymentRepository
+	fixture *paymentFixture
+}
+
+func (r *orderSyncingPaymentRepository) SavePayment(ctx context.Context, p *model.Payment, order *model.Order, event *model.PaymentEvent) error {
+	if r.fixture.beforeSave != nil {
+		r.fixture.beforeSave()
+		r.fixture.beforeSave = nil
+	}
+	if order != nil && order.Version != r.fixture.order.Version {
+		return repository.ErrVersionConflict
+	}
+	if err := r.mockPaymentRepository.SavePayment(ctx, p, order, event); err != nil {
+		return err
+	}
+	if order != nil {
+		r.fixture.order.Status = order.Status
+		r.fixture.order.CancellationReason = order.CancellationReason
+		r.fixture.order.Version++
+		order.Version++
+	}
+	return nil
+}
+
+func TestPayOrder(t *testing.T) {
+	tests := []struct {
+		name           string
+		token          string
+		expectedStatus string
+		orderStatus    string
+		reason         string
+		restored       int32
+	}{
+		{
+			name:           "Authorized confirms the order",
+			token:          payment.TokenSuccess,
+			expectedStatus: payment.StatusAuthorized,
+			orderStatus:    "confirmed",
+		},
+		{
+			name:           "Declined cancels the order and releases stock",
+			token:          payment.TokenDeclined,
+			expectedStatus: payment.StatusFailed,
+			orderStatus:    "cancelled",
+			reason:         service.PaymentFailedReason,
+			restored:       2,
+		},
+		{
+			name:           "Async payment keeps the order pending",
+			token:          payment.TokenAsyncSuccess,
+			expectedStatus: payment.StatusPending,
+			orderStatus:    "pending",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newPaymentFixture()
+
+			resp, err := f.svc.PayOrder(contextWithAuth("1", "user"), &pb.PayOrderRequest{OrderId: 1, PaymentToken: tt.token})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			p := resp.GetPayment()
+			if p == nil {
+				t.Fatalf("expected p to be set")
+			}
+			if p.Status != tt.expectedStatus {
+				t.Errorf("expected %v, got %v", tt.expectedStatus, p.Status)
+			}
+			if p.AmountMinor != int64(2000) {
+				t.Errorf("expected %v, got %v", int64(2000), p.AmountMinor)
+			}
+			if p.Currency != "RUB" {
+				t.Errorf("expected %v, got %v", "RUB", p.Currency)
+			}
+			if f.order.Status != tt.orderStatus {
+				t.Errorf("expected %v, got %v", tt.orderStatus, f.order.Status)
+			}
+			if f.order.CancellationReason != tt.reason {
+				t.Errorf("expected %v, got %v", tt.reason, f.order.CancellationReason)
+			}
+			if f.restored != tt.restored {
+				t.Errorf("expected %v, got %v", tt.restored, f.restored)
+			}
+		})
+	}
+}
+
+func TestPayOrderRejections(t *testing.T) {
+	t.Run("Other user's order", func(t *testing.T) {
+		f := newPaymentFixture()
+		_, err := f.svc.PayOrder(contextWithAuth("2", "user"), &pb.PayOrderRequest{OrderId: 1, PaymentToken: payment.TokenSuccess})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("expected %v, got %v", codes.PermissionDenied, status.Code(err))
+		}
+	})
+
+	t.Run("Order is not pending", func(t *testing.T) {
+		f := newPaymentFixture()
+		f.order.Status = "confirmed"
+		_, err := f.svc.PayOrder(contextWithAuth("1", "user"), &pb.PayOrderRequest{OrderId: 1, PaymentToken: payment.TokenSuccess})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("expected %v, got %v", codes.FailedPrecondition, status.Code(err))
+		}
+	})
+
+	t.Run("Payment already in progress", func(t *testing.T) {
+		f := newPaymentFixture()
+		_, err := f.svc.PayOrder(contextWithAuth("1", "user"), &pb.PayOrderRequest{OrderId: 1, PaymentToken: payment.TokenAsyncSuccess})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		_, err = f.svc.PayOrder(contextWithAuth("1", "user"), &pb.PayOrderRequest{OrderId: 1, PaymentToken: payment.TokenSuccess})
+		if status.Code(err) != codes.FailedPrecondition {
+			t.Errorf("expected %v, got %v", codes.FailedPrecondition, status.Code(err))
+		}
+		if !strings.Contains(status.Convert(err).Message(), "PAYMENT_IN_PROGRESS") {
+			t.Errorf("expected %q to contain %q", status.Convert(err).Message(), "PAYMENT_IN_PROGRESS")
+		}
+
+		if len(f.payments.payments) != 1 {
+			t.Errorf("expected 1 payment, got %d", len(f.payments.payments))
+		}
+
+		_, err = f.svc.UpdateOrderItems(contextWithAuth("1", "user"), &pb.UpdateOrderItemsRequest{
+			OrderId: 1,
+			Items:   []*pb.OrderItem{{ProductId: 101, Quantity: 1}},
+		})
+		if !strings.Contains(status.Convert(err).Message(), "PAYMENT_IN_PROGRESS") {
+			t.Errorf("expected %q to contain %q", status.Convert(err).Message(), "PAYMENT_IN_PROGRESS")
+		}
+	})
+}
+
+func TestPayOrderLosesRaceToConcurrentPayment(t *testing.T) {
+	f := newPaymentFixture()
+	// Параллельный PayOrder сохранил платеж после того, как этот прочитал заказ
+	f.payments.beforeCreatePayment = func() {
+		f.payments.beforeCreatePayment = nil
+		f.payments.payments = append(f.payments.payments, model.Payment{ID: 1, OrderID: 1, ProviderRef: "concurrent", Status: payment.StatusAuthorized})
+	}
+
+	_, err := f.svc.PayOrder(contextWithAuth("1", "user"), &pb.PayOrderRequest{OrderId: 1, PaymentToken: payment.TokenSuccess})
+	if !strings.Contains(status.Convert(err).Message(), "PAYMENT_IN_PROGRESS") {
+		t.Errorf("expected %q to contain %q", status.Convert(err).Message(), "PAYMENT_IN_PROGRESS")
+	}
+	if len(f.payments.payments) != 1 {
+		t.Errorf("expected 1 payment, got %d", len(f.payments.payments))
+	}
+}
+
+func TestHandlePaymentEvent(t *testing.T) {
+	t.Run("Async success confirms the order once", func(t *testing.T) {
+		f := newPaymentFixture()
+		ctx := contextWithAuth("1", "user")
+		_, err := f.svc.PayOrder(ctx, &pb.PayOrderRequest{OrderId: 1, PaymentToken: payment.TokenAsyncSuccess})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var delivered []payment.Event
+		handle := func(ctx context.Context, ev payment.Event) error {
+			delivered = append(delivered, ev)
+			return f.svc.HandlePaymentEvent(ctx, ev)
+		}
+		if f.provider.DeliverPending(context.Background(), handle) != 1 {
+			t.Errorf("expected %v, got %v", 1, f.provider.DeliverPending(context.Background(), handle))
+		}
+		if f.order.Status != "confirmed" {
+			t.Errorf("expected %v, got %v", "confirmed", f.order.Status)
+		}
+		if f.payments.payments[0].Status != payment.StatusAuthorized {
+			t.Errorf("expected %v, got %v", payment.StatusAuthorized, f.payments.payments[0].Status)
+		}
+
+		// Повторная доставка того же события ничего не меняет
+		if err := f.svc.HandlePaymentEvent(context.Background(), delivered[0]); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if f.order.Status != "confirmed" {
+			t.Errorf("expected %v, got %v", "confirmed", f.order.Status)
+		}
+	})
+
+	t.Run("Async decline cancels the order", func(t *testing.T) {
+		f := newPaymentFixture()
+		_, err := f.svc.PayOrder(contextWithAuth("1", "user"), &pb.PayOrderRequest{OrderId: 1, PaymentToken: payment.TokenAsyncDecline})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if f.provider.DeliverPending(context.Background(), f.svc.HandlePaymentEvent) != 1 {
+			t.Errorf("expected %v, got %v", 1, f.provider.DeliverPending(context.Background(), f.svc.HandlePaymentEvent))
+		}
+		if f.order.Status != "cancelled" {
+			t.Errorf("expected %v, got %v", "cancelled", f.order.Status)
+		}
+		if f.order.CancellationReason != service.PaymentFailedReason {
+			t.Errorf("expected %v, got %v", service.PaymentFailedReason, f.order.CancellationReason)
+		}
+		if f.restored != int32(2) {
+			t.Errorf("expected %v, got %v", int32(2), f.restored)
+		}
+		if f.payments.payments[0].FailureReason != "card_declined" {
+			t.Errorf("expected %v, got %v", "card_declined", f.payments.payments[0].FailureReason)
+		}
+	})
+
+	t.Run("Late authorization of a cancelled order is voided", func(t *testing.T) {
+		f := newPaymentFixture()
+		ctx := contextWithAuth("1", "user")
+		_, err := f.svc.PayOrder(ctx, &pb.PayOrderRequest{OrderId: 1, PaymentToken: payment.TokenAsyncSuccess})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, err = f.svc.CancelOrder(ctx, &pb.CancelOrderRequest{OrderId: 1, Reason: "changed my mind"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		f.provider.DeliverPending(context.Background(), f.svc.HandlePaymentEvent)
+		if f.order.Status != "cancelled" {
+			t.Errorf("expected %v, got %v", "cancelled", f.order.Status)
+		}
+		if f.order.CancellationReason != "changed my mind" {
+			t.Errorf("expected %v, got %v", "changed my mind", f.order.CancellationReason)
+		}
+		if f.payments.payments[0].Status != payment.StatusVoided {
+			t.Errorf("expected %v, got %v", payment.StatusVoided, f.payments.payments[0].Status)
+		}
+	})
+
+	t.Run("Authorization racing a cancel does not confirm the order", func(t *testing.T) {
+		f := newPaymentFixture()
+		_, err := f.svc.PayOrder(contextWithAuth("1", "user"), &pb.PayOrderRequest{OrderId: 1, PaymentToken: payment.TokenAsyncSuccess})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		// Заказ отменяют после того, как обработчик события его прочитал
+		f.beforeSave = func() {
+			f.order.Status = "cancelled"
+			f.order.CancellationReason = "order_expired"
+			f.order.Version++
+		}
+
+		f.provider.DeliverPending(context.Background(), f.svc.HandlePaymentEvent)
+		if f.order.Status != "cancelled" {
+			t.Errorf("expected %v, got %v", "cancelled", f.order.Status)
+		}
+		if f.order.CancellationReason != "order_expired" {
+			t.Errorf("expected %v, got %v", "order_expired", f.order.CancellationReason)
+		}
+		if f.payments.payments[0].Status != payment.StatusVoided {
+			t.Errorf("expected %v, got %v", payment.StatusVoided, f.payments.payments[0].Status)
+		}
+	})
+
+	t.Run("Unknown payment is retried", func(t *testing.T) {
+		f := newPaymentFixture()
+		err := f.svc.HandlePaymentEvent(context.Background(), payment.Event{ID: "evt_1", Type: payment.EventAuthorized, ProviderRef: "missing"})
+		if err == nil {
+			t.Errorf("expected error, got nil")
+		}
+	})
+}
+
+func TestCaptureAndRefundPayment(t *testing.T) {
+	f := newPaymentFixture()
+	_, err := f.svc.PayOrder(contextWithAuth("1", "user"), &pb.PayOrderRequest{OrderId: 1, PaymentToken: payment.TokenSuccess})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	_, err = f.svc.CapturePayment(contextWithAuth("1", "user"), &pb.CapturePaymentRequest{PaymentId: 1})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected %v, got %v", codes.PermissionDenied, status.Code(err))
+	}
+
+	admin := contextWithAuth("99", "admin")
+	captured, err := f.svc.CapturePayment(admin, &pb.CapturePaymentRequest{PaymentId: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if captured.GetPayment().Status != payment.StatusCaptured {
+		t.Errorf("expected %v, got %v", payment.StatusCaptured, captured.GetPayment().Status)
+	}
+	if captured.GetPayment().CapturedMinor != int64(2000) {
+		t.Errorf("expected %v, got %v", int64(2000), captured.GetPayment().CapturedMinor)
+	}
+
+	_, err = f.svc.RefundPayment(admin, &pb.RefundPaymentRequest{PaymentId: 1, AmountMinor: 5000})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected %v, got %v", codes.InvalidArgument, status.Code(err))
+	}
+
+	partial, err := f.svc.RefundPayment(admin, &pb.RefundPaymentRequest{PaymentId: 1, AmountMinor: 500})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if partial.GetPayment().Status != payment.StatusPartiallyRefunded {
+		t.Errorf("expected %v, got %v", payment.StatusPartiallyRefunded, partial.GetPayment().Status)
+	}
+
+	// Отмена заказа возвращает остаток списанного
+	_, err = f.svc.CancelOrder(admin, &pb.CancelOrderRequest{OrderId: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f.restored != int32(2) {
+		t.Errorf("expected %v, got %v", int32(2), f.restored)
+	}
+
+	payments, err := f.svc.GetOrderPayments(contextWithAuth("1", "user"), &pb.GetOrderPaymentsRequest{OrderId: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(payments.Payments) != 1 {
+		t.Fatalf("expected 1 items, got %d", len(payments.Payments))
+	}
+	if payments.Payments[0].Status != payment.StatusRefunded {
+		t.Errorf("expected %v, got %v", payment.StatusRefunded, payments.Payments[0].Status)
+	}
+	if payments.Payments[0].RefundedMinor != int64(2000) {
+		t.Errorf("expected %v, got %v", int64(2000), payments.Payments[0].RefundedMinor)
+	}
+}
@@ -0,0 +1,437 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	pb "order-service/api/order/v1"
+	"order-service/internal/model"
+	"order-service/internal/promotion"
+
+	"github.com/microserviceteam0/bff-gateway/shared/money"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// orderPricing is the result of applying promotions to the order lines
+type orderPricing struct {
+	result     *promotion.Result
+	promotions []model.OrderPromotion
+}
+
+// applyTo writes the totals and the applied promotions into the order
+func (p *orderPricing) applyTo(order *model.Order) {
+	order.SetTotal(p.result.Total)
+	order.SubtotalMinor = p.result.Subtotal.Amount
+	order.DiscountMinor = p.result.Discount.Amount
+	order.Promotions = p.promotions
+}
+
+// applyPromotions prices the items with all automatic promotions and the given promo codes
+// and writes per-line discounts into items.
+//
+// For a new order (existing == nil) an unknown, exhausted or inapplicable promo code is an error.
+// When an existing order is re-priced its promotions do not count against per-user limits again,
+// and codes that no longer apply are silently dropped.
+func (s *OrderServiceImpl) applyPromotions(ctx context.Context, userID int64, items []model.OrderItem, promoCodes []string, existing *model.Order) (*orderPricing, error) {
+	strict := existing == nil
+	alreadyApplied := make(map[int64]bool)
+	if existing != nil {
+		for _, p := range existing.Promotions {
+			alreadyApplied[p.PromotionID] = true
+		}
+	}
+
+	automatic, err := s.promoRepo.ListAutomaticPromotions(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to load promotions: %v", err)
+	}
+
+	candidates := make([]*model.Promotion, 0, len(automatic)+len(promoCodes))
+	for i := range automatic {
+		candidates = append(candidates, &automatic[i])
+	}
+
+	seen := make(map[string]bool, len(promoCodes))
+	for _, code := range promoCodes {
+		code = normalizePromoCode(code)
+		if code == "" || seen[code] {
+			continue
+		}
+		seen[code] = true
+
+		promo, err := s.promoRepo.GetPromotionByCode(ctx, code)
+		if err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to load promotion: %v", err)
+			}
+			if strict {
+				return nil, status.Errorf(codes.NotFound, "PROMO_NOT_FOUND: Promo code %s not found", code)
+			}
+			continue
+		}
+		candidates = append(candidates, promo)
+	}
+
+	eligible := make([]*model.Promotion, 0, len(candidates))
+	for _, promo := range candidates {
+		if promo.PerUserLimit > 0 && !alreadyApplied[promo.ID] {
+			used, err := s.promoRepo.CountUserUsage(ctx, promo.ID, userID)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to check promotion usage: %v", err)
+			}
+			if used >= int64(promo.PerUserLimit) {
+				if strict && !promo.IsAutomatic() {
+					return nil, status.Errorf(codes.FailedPrecondition, "PROMO_LIMIT_REACHED: Promo code %s has already been used", promo.CodeValue())
+				}
+				continue
+			}
+		}
+		eligible = append(eligible, promo)
+	}
+
+	lines := make([]promotion.Line, len(items))
+	for i, item := range items {
+		lines[i] = promotion.Line{ProductID: item.ProductID, Quantity: item.Quantity, UnitPrice: item.UnitPrice()}
+	}
+
+	result, rejected := promotion.Apply(lines, eligible, time.Now())
+	if strict {
+		for _, promo := range eligible {
+			if err, ok := rejected[promo.ID]; ok && !promo.IsAutomatic() {
+				return nil, status.Errorf(codes.FailedPrecondition, "PROMO_NOT_APPLICABLE: Promo code %s: %v", promo.CodeValue(), err)
+			}
+		}
+	}
+
+	for i := range items {
+		items[i].DiscountMinor = result.LineDiscounts[i]
+	}
+
+	applied := make([]model.OrderPromotion, len(result.Applied))
+	for i, a := range result.Applied {
+		applied[i] = model.OrderPromotion{
+			PromotionID:   a.Promotion.ID,
+			Code:          a.Promotion.CodeValue(),
+			Name:          a.Promotion.Name,
+			DiscountMinor: a.Discount.Amount,
+			Currency:      a.Discount.Currency,
+		}
+	}
+
+	return &orderPricing{result: result, promotions: applied}, nil
+}
+
+// QuoteOrder prices a basket with discounts without reserving stock or creating an order
+func (s *OrderServiceImpl) QuoteOrder(ctx context.Context, req *pb.QuoteOrderRequest) (*pb.QuoteOrderResponse, error) {
+	userID, _, err := s.getUserInfoFromContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "UNAUTHORIZED: %v", err)
+	}
+
+	if req.UserId == 0 {
+		req.UserId = userID
+	} else if req.UserId != userID {
+		return nil, status.Errorf(codes.PermissionDenied, "FORBIDDEN: Cannot quote order for another user")
+	}
+
+	if err := s.validateOrderItems(req.Items); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: %v", err)
+	}
+
+	productIDs := make([]int64, len(req.Items))
+	for i, item := range req.Items {
+		productIDs[i] = item.ProductId
+	}
+
+	productsMap, err := s.productClient.GetProducts(ctx, productIDs)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "PRODUCT_SERVICE_ERROR: Failed to fetch products: %v", err)
+	}
+
+	items, err := s.buildOrderItems(req.Items, productsMap)
+	if err != nil {
+		return nil, err
+	}
+
+	pricing, err := s.applyPromotions(ctx, req.UserId, items, req.PromoCodes, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	order := &model.Order{Items: items}
+	pricing.applyTo(order)
+	pbOrder := s.orderToProto(order)
+
+	return &pb.QuoteOrderResponse{
+		Result: &pb.QuoteOrderResponse_Quote{
+			Quote: &pb.Quote{
+				Items:         pbOrder.Items,
+				SubtotalMinor: pbOrder.SubtotalMinor,
+				DiscountMinor: pbOrder.DiscountMinor,
+				TotalMinor:    pbOrder.TotalAmountMinor,
+				Currency:      pbOrder.Currency,
+				Promotions:    pbOrder.Promotions,
+			},
+		},
+	}, nil
+}
+
+// CreatePromotion creates a promotion. New promotions are always active
+func (s *OrderServiceImpl) CreatePromotion(ctx context.Context, req *pb.CreatePromotionRequest) (*pb.CreatePromotionResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.Promotion == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: promotion is required")
+	}
+
+	promo := promotionFromProto(req.Promotion)
+	promo.ID = 0
+	promo.Active = true
+
+	if err := promotion.Validate(promo); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: %v", err)
+	}
+
+	if err := s.ensurePromoCodeAvailable(ctx, promo); err != nil {
+		return nil, err
+	}
+
+	if err := s.promoRepo.CreatePromotion(ctx, promo); err != nil {
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to create promotion: %v", err)
+	}
+
+	return &pb.CreatePromotionResponse{
+		Result: &pb.CreatePromotionResponse_Promotion{
+			Promotion: promotionToProto(promo),
+		},
+	}, nil
+}
+
+// UpdatePromotion replaces all editable fields of a promotion
+func (s *OrderServiceImpl) UpdatePromotion(ctx context.Context, req *pb.UpdatePromotionRequest) (*pb.UpdatePromotionResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	if req.Promotion == nil || req.Promotion.Id == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: promotion id is required")
+	}
+
+	existing, err := s.getPromotion(ctx, req.Promotion.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	promo := promotionFromProto(req.Promotion)
+	promo.CreatedAt = existing.CreatedAt
+
+	if err := promotion.Validate(promo); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: %v", err)
+	}
+
+	if err := s.ensurePromoCodeAvailable(ctx, promo); err != nil {
+		return nil, err
+	}
+
+	if err := s.promoRepo.UpdatePromotion(ctx, promo); err != nil {
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to update promotion: %v", err)
+	}
+
+	return &pb.UpdatePromotionResponse{
+		Result: &pb.UpdatePromotionResponse_Promotion{
+			Promotion: promotionToProto(promo),
+		},
+	}, nil
+}
+
+func (s *OrderServiceImpl) ListPromotions(ctx context.Context, req *pb.ListPromotionsRequest) (*pb.ListPromotionsResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	page := req.Page
+	pageSize := req.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	promotions, total, err := s.promoRepo.ListPromotions(ctx, req.ActiveOnly, int(pageSize), int((page-1)*pageSize))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to list promotions: %v", err)
+	}
+
+	pbPromotions := make([]*pb.Promotion, len(promotions))
+	for i := range promotions {
+		pbPromotions[i] = promotionToProto(&promotions[i])
+	}
+
+	return &pb.ListPromotionsResponse{
+		Promotions: pbPromotions,
+		TotalCount: int32(total),
+		Page:       page,
+		PageSize:   pageSize,
+	}, nil
+}
+
+// DeactivatePromotion switches a promotion off; orders already placed keep their discounts
+func (s *OrderServiceImpl) DeactivatePromotion(ctx context.Context, req *pb.DeactivatePromotionRequest) (*pb.DeactivatePromotionResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	promo, err := s.getPromotion(ctx, req.PromotionId)
+	if err != nil {
+		return nil, err
+	}
+
+	promo.Active = false
+	if err := s.promoRepo.UpdatePromotion(ctx, promo); err != nil {
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to deactivate promotion: %v", err)
+	}
+
+	return &pb.DeactivatePromotionResponse{
+		Result: &pb.DeactivatePromotionResponse_Success{
+			Success: &emptypb.Empty{},
+		},
+	}, nil
+}
+
+func (s *OrderServiceImpl) requireAdmin(ctx context.Context) error {
+	_, isAdmin, err := s.getUserInfoFromContext(ctx)
+	if err != nil {
+		return status.Errorf(codes.Unauthenticated, "UNAUTHORIZED: %v", err)
+	}
+	if !isAdmin {
+		return status.Errorf(codes.PermissionDenied, "FORBIDDEN: Admin access required")
+	}
+	return nil
+}
+
+func (s *OrderServiceImpl) getPromotion(ctx context.Context, promotionID int64) (*model.Promotion, error) {
+	promo, err := s.promoRepo.GetPromotion(ctx, promotionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "NOT_FOUND: Promotion not found")
+		}
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to get promotion: %v", err)
+	}
+	return promo, nil
+}
+
+func (s *OrderServiceImpl) ensurePromoCodeAvailable(ctx context.Context, promo *model.Promotion) error {
+	if promo.IsAutomatic() {
+		return nil
+	}
+
+	existing, err := s.promoRepo.GetPromotionByCode(ctx, promo.CodeValue())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to check promo code: %v", err)
+	}
+	if existing.ID != promo.ID {
+		return status.Errorf(codes.AlreadyExists, "PROMO_CODE_EXISTS: Promo code %s already exists", promo.CodeValue())
+	}
+	return nil
+}
+
+func normalizePromoCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// appliedCodes returns the promo codes that were applied to the order
+func appliedCodes(order *model.Order) []string {
+	var result []string
+	for _, p := range order.Promotions {
+		if p.Code != "" {
+			result = append(result, p.Code)
+		}
+	}
+	return result
+}
+
+func appliedPromotionsToProto(promotions []model.OrderPromotion) []*pb.AppliedPromotion {
+	result := make([]*pb.AppliedPromotion, len(promotions))
+	for i, p := range promotions {
+		result[i] = &pb.AppliedPromotion{
+			PromotionId:   p.PromotionID,
+			Code:          p.Code,
+			Name:          p.Name,
+			DiscountMinor: p.DiscountMinor,
+			Currency:      money.NormalizeCurrency(p.Currency),
+		}
+	}
+	return result
+}
+
+func promotionFromProto(p *pb.Promotion) *model.Promotion {
+	promo := &model.Promotion{
+		ID:             p.Id,
+		Name:           strings.TrimSpace(p.Name),
+		Type:           p.Type,
+		PercentOff:     p.PercentOff,
+		AmountOffMinor: p.AmountOffMinor,
+		Currency:       money.NormalizeCurrency(p.Currency),
+		BuyQuantity:    p.BuyQuantity,
+		GetQuantity:    p.GetQuantity,
+		ProductID:      p.ProductId,
+		MinBasketMinor: p.MinBasketMinor,
+		PerUserLimit:   p.PerUserLimit,
+		Active:         p.Active,
+	}
+
+	if code := normalizePromoCode(p.Code); code != "" {
+		promo.Code = &code
+	}
+	if p.StartsAt != nil {
+		startsAt := p.StartsAt.AsTime()
+		promo.StartsAt = &startsAt
+	}
+	if p.EndsAt != nil {
+		endsAt := p.EndsAt.AsTime()
+		promo.EndsAt = &endsAt
+	}
+
+	return promo
+}
+
+func promotionToProto(p *model.Promotion) *pb.Promotion {
+	result := &pb.Promotion{
+		Id:             p.ID,
+		Code:           p.CodeValue(),
+		Name:           p.Name,
+		Type:           p.Type,
+		PercentOff:     p.PercentOff,
+		AmountOffMinor: p.AmountOffMinor,
+		Currency:       p.Currency,
+		BuyQuantity:    p.BuyQuantity,
+		GetQuantity:    p.GetQuantity,
+		ProductId:      p.ProductID,
+		MinBasketMinor: p.MinBasketMinor,
+		PerUserLimit:   p.PerUserLimit,
+		Active:         p.Active,
+		CreatedAt:      timestamppb.New(p.CreatedAt),
+		UpdatedAt:      timestamppb.New(p.UpdatedAt),
+	}
+
+	if p.StartsAt != nil {
+		result.StartsAt = timestamppb.New(*p.StartsAt)
+	}
+	if p.EndsAt != nil {
+		result.EndsAt = timestamppb.New(*p.EndsAt)
+	}
+
+	return result
+}
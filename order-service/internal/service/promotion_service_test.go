@@ -0,0 +1,321 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	pb "order-service/api/order/v1"
+	"order-service/internal/model"
+	"order-service/internal/repository"
+	"order-service/internal/service"
+
+	productpb "order-service/pkg/api/product/v1"
+
+	"gorm.io/gorm"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func promoProducts(ctx context.Context, ids []int64) (map[int64]*productpb.ProductResponse, error) {
+	return map[int64]*productpb.ProductResponse{
+		101: {Id: 101, Name: "Product A", PriceMinor: 1000, Currency: "RUB"},
+		102: {Id: 102, Name: "Product B", PriceMinor: 500, Currency: "RUB"},
+	}, nil
+}
+
+func TestCreateOrderWithPromotions(t *testing.T) {
+	ctx := contextWithAuth("1", "user")
+	code := "SAVE10"
+
+	tests := []struct {
+		name             string
+		promoCodes       []string
+		mockPromoRepo    *mockPromotionRepository
+		expectedCode     codes.Code
+		expectedMsg      string
+		expectedTotal    int64
+		expectedDiscount []int64
+		expectedApplied  int
+	}{
+		{
+			name:       "Promo Code Applied",
+			promoCodes: []string{" save10 "},
+			mockPromoRepo: &mockPromotionRepository{
+				getPromotionByCodeFunc: func(ctx context.Context, c string) (*model.Promotion, error) {
+					return &model.Promotion{ID: 1, Code: &code, Name: "10% off", Type: model.PromotionTypePercentage, PercentOff: 10, Active: true}, nil
+				},
+			},
+			expectedCode:     codes.OK,
+			expectedTotal:    2250,
+			expectedDiscount: []int64{200, 50},
+			expectedApplied:  1,
+		},
+		{
+			name:       "Automatic And Promo Code Stack",
+			promoCodes: []string{"SAVE10"},
+			mockPromoRepo: &mockPromotionRepository{
+				listAutomaticPromotionsFunc: func(ctx context.Context) ([]model.Promotion, error) {
+					return []model.Promotion{{ID: 2, Name: "Buy 1 get 1", Type: model.PromotionTypeBuyXGetY, BuyQuantity: 1, GetQuantity: 1, ProductID: 101, Active: true}}, nil
+				},
+				getPromotionByCodeFunc: func(ctx context.Context, c string) (*model.Promotion, error) {
+					return &model.Promotion{ID: 1, Code: &code, Name: "10% off", Type: model.PromotionTypePercentage, PercentOff: 10, Active: true}, nil
+				},
+			},
+			expectedCode:     codes.OK,
+			expectedTotal:    1350,
+			expectedDiscount: []int64{1100, 50},
+			expectedApplied:  2,
+		},
+		{
+			name:          "Unknown Promo Code",
+			promoCodes:    []string{"NOPE"},
+			mockPromoRepo: &mockPromotionRepository{},
+			expectedCode:  codes.NotFound,
+			expectedMsg:   "PROMO_NOT_FOUND: Promo code NOPE not found",
+		},
+		{
+			name:       "Per User Limit Reached",
+			promoCodes: []string{"SAVE10"},
+			mockPromoRepo: &mockPromotionRepository{
+				getPromotionByCodeFunc: func(ctx context.Context, c string) (*model.Promotion, error) {
+					return &model.Promotion{ID: 1, Code: &code, Name: "10% off", Type: model.PromotionTypePercentage, PercentOff: 10, PerUserLimit: 1, Active: true}, nil
+				},
+				countUserUsageFunc: func(ctx context.Context, promotionID int64, userID int64) (int64, error) {
+					return 1, nil
+				},
+			},
+			expectedCode: codes.FailedPrecondition,
+			expectedMsg:  "PROMO_LIMIT_REACHED: Promo code SAVE10 has already been used",
+		},
+		{
+			name:       "Min Basket Not Reached",
+			promoCodes: []string{"SAVE10"},
+			mockPromoRepo: &mockPromotionRepository{
+				getPromotionByCodeFunc: func(ctx context.Context, c string) (*model.Promotion, error) {
+					return &model.Promotion{ID: 1, Code: &code, Name: "10% off", Type: model.PromotionTypePercentage, PercentOff: 10, MinBasketMinor: 100000, Currency: "RUB", Active: true}, nil
+				},
+			},
+			expectedCode: codes.FailedPrecondition,
+			expectedMsg:  "PROMO_NOT_APPLICABLE: Promo code SAVE10: basket total is below the promotion minimum",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created *model.Order
+			mockRepo := &mockOrderRepository{
+				createOrderFunc: func(ctx context.Context, order *model.Order) (*model.Order, error) {
+					order.ID = 1
+					created = order
+					return order, nil
+				},
+			}
+			mockProd := &mockProductClient{getProductsFunc: promoProducts}
+			s := service.NewOrderService(mockRepo, tt.mockPromoRepo, &mockPaymentRepository{}, &mockCompensationRepository{}, &mockReturnRepository{}, &mockInvoiceRepository{}, nil, &mockTemplateRepository{}, mockProd, nil, nil)
+
+			_, err := s.CreateOrder(ctx, &pb.CreateOrderRequest{
+				UserId: 1,
+				Items: []*pb.OrderItem{
+					{ProductId: 101, Quantity: 2},
+					{ProductId: 102, Quantity: 1},
+				},
+				PromoCodes: tt.promoCodes,
+				Shipping:   testShipping(),
+			})
+
+			if tt.expectedCode != codes.OK {
+				st, _ := status.FromError(err)
+				if st.Code() != tt.expectedCode {
+					t.Errorf("Expected status code %v, got %v", tt.expectedCode, st.Code())
+				}
+				if st.Message() != tt.expectedMsg {
+					t.Errorf("Expected error message '%s', got '%s'", tt.expectedMsg, st.Message())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if created.SubtotalMinor != 2500 {
+				t.Errorf("Expected subtotal 2500, got %d", created.SubtotalMinor)
+			}
+			if created.TotalAmountMinor != tt.expectedTotal {
+				t.Errorf("Expected total %d, got %d", tt.expectedTotal, created.TotalAmountMinor)
+			}
+			for i, expected := range tt.expectedDiscount {
+				if created.Items[i].DiscountMinor != expected {
+					t.Errorf("Item %d: expected discount %d, got %d", i, expected, created.Items[i].DiscountMinor)
+				}
+			}
+			if len(created.Promotions) != tt.expectedApplied {
+				t.Errorf("Expected %d applied promotions, got %d", tt.expectedApplied, len(created.Promotions))
+			}
+		})
+	}
+}
+
+// A concurrent order of the same user used up the limit after the check:
+// the repository rejects the order under the promotion lock and the stock reservation is released
+func TestCreateOrderPromoLimitRace(t *testing.T) {
+	code := "SAVE10"
+	mockPromoRepo := &mockPromotionRepository{
+		getPromotionByCodeFunc: func(ctx context.Context, c string) (*model.Promotion, error) {
+			return &model.Promotion{ID: 1, Code: &code, Name: "10% off", Type: model.PromotionTypePercentage, PercentOff: 10, PerUserLimit: 1, Active: true}, nil
+		},
+	}
+	mockRepo := &mockOrderRepository{
+		createOrderFunc: func(ctx context.Context, order *model.Order) (*model.Order, error) {
+			return nil, &repository.PromoLimitError{PromotionID: 1}
+		},
+	}
+	var deltas []int32
+	mockProd := &mockProductClient{
+		getProductsFunc: promoProducts,
+		batchUpdateStockFunc: func(ctx context.Context, items []*productpb.StockAdjustment, reason string, orderID int64) (*productpb.BatchUpdateStockResponse, error) {
+			for _, item := range items {
+				deltas = append(deltas, item.QuantityDelta)
+			}
+			return &productpb.BatchUpdateStockResponse{}, nil
+		},
+	}
+	s := service.NewOrderService(mockRepo, mockPromoRepo, &mockPaymentRepository{}, &mockCompensationRepository{}, &mockReturnRepository{}, &mockInvoiceRepository{}, nil, &mockTemplateRepository{}, mockProd, nil, nil)
+
+	_, err := s.CreateOrder(contextWithAuth("1", "user"), &pb.CreateOrderRequest{
+		UserId:     1,
+		Items:      []*pb.OrderItem{{ProductId: 101, Quantity: 2}},
+		PromoCodes: []string{"SAVE10"},
+		Shipping:   testShipping(),
+	})
+
+	st, _ := status.FromError(err)
+	if st.Code() != codes.FailedPrecondition || st.Message() != "PROMO_LIMIT_REACHED: Promo code SAVE10 has already been used" {
+		t.Errorf("Expected PROMO_LIMIT_REACHED, got %v", err)
+	}
+	var sum int32
+	for _, d := range deltas {
+		sum += d
+	}
+	if len(deltas) == 0 || sum != 0 {
+		t.Errorf("Expected the reservation to be reverted, got deltas %v", deltas)
+	}
+}
+
+func TestQuoteOrder(t *testing.T) {
+	code := "MINUS3"
+	mockPromoRepo := &mockPromotionRepository{
+		getPromotionByCodeFunc: func(ctx context.Context, c string) (*model.Promotion, error) {
+			return &model.Promotion{ID: 1, Code: &code, Name: "3 RUB off", Type: model.PromotionTypeFixedAmount, AmountOffMinor: 300, Currency: "RUB", Active: true}, nil
+		},
+	}
+	// CreateOrder must not be reached: the default mock returns an error
+	s := service.NewOrderService(&mockOrderRepository{}, mockPromoRepo, &mockPaymentRepository{}, &mockCompensationRepository{}, &mockReturnRepository{}, &mockInvoiceRepository{}, nil, &mockTemplateRepository{}, &mockProductClient{
+		getProductsFunc: promoProducts,
+		updateStockFunc: func(ctx context.Context, productID, variantID int64, quantityDelta int32, reason string, orderID int64) (*productpb.UpdateStockResponse, error) {
+			t.Fatalf("QuoteOrder must not touch stock")
+			return nil, nil
+		},
+	}, nil, nil)
+
+	resp, err := s.QuoteOrder(contextWithAuth("1", "user"), &pb.QuoteOrderRequest{
+		Items: []*pb.OrderItem{
+			{ProductId: 101, Quantity: 2},
+			{ProductId: 102, Quantity: 1},
+		},
+		PromoCodes: []string{"MINUS3"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	quote := resp.GetQuote()
+	if quote.GetSubtotalMinor() != 2500 || quote.GetDiscountMinor() != 300 || quote.GetTotalMinor() != 2200 {
+		t.Errorf("Unexpected quote totals: %v", quote)
+	}
+	if quote.GetItems()[0].GetDiscountMinor() != 240 || quote.GetItems()[1].GetDiscountMinor() != 60 {
+		t.Errorf("Unexpected line discounts: %v", quote.GetItems())
+	}
+	if len(quote.GetPromotions()) != 1 || quote.GetPromotions()[0].GetCode() != "MINUS3" {
+		t.Errorf("Expected MINUS3 to be applied, got %v", quote.GetPromotions())
+	}
+}
+
+func TestCreatePromotion(t *testing.T) {
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		promotion    *pb.Promotion
+		existingCode bool
+		expectedCode codes.Code
+		expectedMsg  string
+	}{
+		{
+			name:         "Success",
+			ctx:          contextWithAuth("1", "admin"),
+			promotion:    &pb.Promotion{Code: "winter", Name: "Winter sale", Type: model.PromotionTypePercentage, PercentOff: 15},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "Forbidden For Regular User",
+			ctx:          contextWithAuth("1", "user"),
+			promotion:    &pb.Promotion{Code: "WINTER", Name: "Winter sale", Type: model.PromotionTypePercentage, PercentOff: 15},
+			expectedCode: codes.PermissionDenied,
+			expectedMsg:  "FORBIDDEN: Admin access required",
+		},
+		{
+			name:         "Invalid Percentage",
+			ctx:          contextWithAuth("1", "admin"),
+			promotion:    &pb.Promotion{Code: "WINTER", Name: "Winter sale", Type: model.PromotionTypePercentage, PercentOff: 150},
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "INVALID_REQUEST: percent_off must be between 1 and 100",
+		},
+		{
+			name:         "Duplicate Code",
+			ctx:          contextWithAuth("1", "admin"),
+			promotion:    &pb.Promotion{Code: "WINTER", Name: "Winter sale", Type: model.PromotionTypePercentage, PercentOff: 15},
+			existingCode: true,
+			expectedCode: codes.AlreadyExists,
+			expectedMsg:  "PROMO_CODE_EXISTS: Promo code WINTER already exists",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPromoRepo := &mockPromotionRepository{
+				getPromotionByCodeFunc: func(ctx context.Context, code string) (*model.Promotion, error) {
+					if tt.existingCode {
+						return &model.Promotion{ID: 7, Code: &code}, nil
+					}
+					return nil, gorm.ErrRecordNotFound
+				},
+				createPromotionFunc: func(ctx context.Context, promotion *model.Promotion) error {
+					promotion.ID = 1
+					return nil
+				},
+			}
+			s := service.NewOrderService(&mockOrderRepository{}, mockPromoRepo, &mockPaymentRepository{}, &mockCompensationRepository{}, &mockReturnRepository{}, &mockInvoiceRepository{}, nil, &mockTemplateRepository{}, nil, nil, nil)
+
+			resp, err := s.CreatePromotion(tt.ctx, &pb.CreatePromotionRequest{Promotion: tt.promotion})
+
+			if tt.expectedCode != codes.OK {
+				st, _ := status.FromError(err)
+				if st.Code() != tt.expectedCode {
+					t.Errorf("Expected status code %v, got %v", tt.expectedCode, st.Code())
+				}
+				if st.Message() != tt.expectedMsg {
+					t.Errorf("Expected error message '%s', got '%s'", tt.expectedMsg, st.Message())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			promo := resp.GetPromotion()
+			if promo.GetId() != 1 || promo.GetCode() != "WINTER" || !promo.GetActive() || promo.GetCurrency() != "RUB" {
+				t.Errorf("Unexpected promotion: %v", promo)
+			}
+		})
+	}
+}
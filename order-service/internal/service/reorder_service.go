@@ -0,0 +1,380 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	pb "order-service/api/order/v1"
+	"order-service/internal/model"
+
+	"github.com/microserviceteam0/bff-gateway/shared/address"
+	"github.com/microserviceteam0/bff-gateway/shared/money"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// Availability of a reorder line
+const (
+	AvailabilityAvailable   = "available"
+	AvailabilityPartial     = "partial"
+	AvailabilityUnavailable = "unavailable"
+)
+
+// reorderItem is a line to reorder; previous is its price in the original order, zero for templates
+type reorderItem struct {
+	productID int64
+	variantID int64
+	name      string
+	quantity  int32
+	previous  money.Money
+}
+
+// Reorder places a new order with the lines of an earlier order of the user
+func (s *OrderServiceImpl) Reorder(ctx context.Context, req *pb.ReorderRequest) (*pb.ReorderResponse, error) {
+	userID, _, err := s.getUserInfoFromContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "UNAUTHORIZED: %v", err)
+	}
+
+	order, err := s.repo.GetOrder(ctx, req.OrderId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "NOT_FOUND: Order not found")
+		}
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to get order: %v", err)
+	}
+
+	// The order is placed for its owner, so an admin cannot reorder someone else's order either
+	if order.UserID != userID {
+		return nil, status.Errorf(codes.PermissionDenied, "FORBIDDEN: Access denied")
+	}
+
+	items := make([]reorderItem, len(order.Items))
+	for i := range order.Items {
+		item := &order.Items[i]
+		items[i] = reorderItem{
+			productID: item.ProductID,
+			variantID: item.VariantID,
+			name:      item.ProductName,
+			quantity:  item.Quantity,
+			previous:  item.UnitPrice(),
+		}
+	}
+
+	create := &pb.CreateOrderRequest{
+		UserId:         userID,
+		PromoCodes:     req.PromoCodes,
+		DeliveryMethod: req.DeliveryMethod,
+	}
+	switch shipping := req.Shipping.(type) {
+	case *pb.ReorderRequest_AddressId:
+		create.Shipping = &pb.CreateOrderRequest_AddressId{AddressId: shipping.AddressId}
+	case *pb.ReorderRequest_Address:
+		create.Shipping = &pb.CreateOrderRequest_Address{Address: shipping.Address}
+	default:
+		// Without an explicit delivery the original delivery is repeated using the address copy stored in the order
+		if req.DeliveryMethod == "" {
+			create.DeliveryMethod = order.DeliveryMethod
+			if !order.ShippingAddress.IsZero() {
+				create.Shipping = &pb.CreateOrderRequest_Address{Address: shippingAddressToProto(order.ShippingAddress)}
+			}
+		}
+	}
+
+	result, err := s.placeReorder(ctx, items, create, req.DryRun)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ReorderResponse{Result: &pb.ReorderResponse_Reorder{Reorder: result}}, nil
+}
+
+// placeReorder checks the lines against the current catalogue, drops unavailable ones and places the order via CreateOrder.
+// Lines with less stock than requested are ordered in the available quantity
+func (s *OrderServiceImpl) placeReorder(ctx context.Context, items []reorderItem, create *pb.CreateOrderRequest, dryRun bool) (*pb.ReorderResult, error) {
+	productIDs := make([]int64, len(items))
+	for i, item := range items {
+		productIDs[i] = item.productID
+	}
+
+	productsMap, err := s.productClient.GetProducts(ctx, productIDs)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "PRODUCT_SERVICE_ERROR: Failed to fetch products: %v", err)
+	}
+
+	result := &pb.ReorderResult{Lines: make([]*pb.ReorderLine, len(items))}
+	create.Items = make([]*pb.OrderItem, 0, len(items))
+	for i, item := range items {
+		line := &pb.ReorderLine{
+			ProductId:          item.productID,
+			VariantId:          item.variantID,
+			ProductName:        item.name,
+			RequestedQuantity:  item.quantity,
+			PreviousPriceMinor: item.previous.Amount,
+			Currency:           item.previous.Currency,
+			Availability:       AvailabilityUnavailable,
+		}
+		result.Lines[i] = line
+
+		product, ok := productsMap[item.productID]
+		if !ok || !productOnSale(product) {
+			result.Changed = true
+			continue
+		}
+		variant, err := productVariant(product, item.variantID)
+		if err != nil {
+			result.Changed = true
+			continue
+		}
+
+		price := variantPrice(product, variant)
+		stock := product.Stock
+		if variant != nil {
+			line.VariantId = variant.Id
+			stock = variant.Stock
+		}
+		line.ProductName = product.Name
+		line.PriceMinor = price.Amount
+		line.Currency = price.Currency
+		line.PriceChanged = item.previous.Currency != "" && item.previous != price
+		line.Quantity = min(item.quantity, max(stock, 0))
+		switch {
+		case line.Quantity == item.quantity:
+			line.Availability = AvailabilityAvailable
+		case line.Quantity > 0:
+			line.Availability = AvailabilityPartial
+		}
+		if line.PriceChanged || line.Availability != AvailabilityAvailable {
+			result.Changed = true
+		}
+
+		if line.Quantity > 0 {
+			create.Items = append(create.Items, &pb.OrderItem{ProductId: item.productID, VariantId: line.VariantId, Quantity: line.Quantity})
+		}
+	}
+
+	if len(create.Items) == 0 {
+		return nil, status.Errorf(codes.FailedPrecondition, "NOTHING_TO_ORDER: None of the items is available")
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	resp, err := s.CreateOrder(ctx, create)
+	if err != nil {
+		return nil, err
+	}
+	result.OrderId = resp.GetOrderId()
+	return result, nil
+}
+
+// CreateOrderTemplate saves an order template from the given lines or from an earlier order of the user
+func (s *OrderServiceImpl) CreateOrderTemplate(ctx context.Context, req *pb.CreateOrderTemplateRequest) (*pb.CreateOrderTemplateResponse, error) {
+	userID, _, err := s.getUserInfoFromContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "UNAUTHORIZED: %v", err)
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" || utf8.RuneCountInString(name) > 100 {
+		return nil, status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: template name must be 1 to 100 characters")
+	}
+
+	template := &model.OrderTemplate{
+		UserID:         userID,
+		Name:           name,
+		DeliveryMethod: req.DeliveryMethod,
+		AddressID:      req.AddressId,
+	}
+
+	items := req.Items
+	if req.FromOrderId != 0 {
+		if len(req.Items) > 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: items and from_order_id are mutually exclusive")
+		}
+
+		order, err := s.repo.GetOrder(ctx, req.FromOrderId)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, status.Errorf(codes.NotFound, "NOT_FOUND: Order not found")
+			}
+			return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to get order: %v", err)
+		}
+		if order.UserID != userID {
+			return nil, status.Errorf(codes.PermissionDenied, "FORBIDDEN: Access denied")
+		}
+
+		items = make([]*pb.OrderItem, len(order.Items))
+		for i, item := range order.Items {
+			items[i] = &pb.OrderItem{ProductId: item.ProductID, VariantId: item.VariantID, Quantity: item.Quantity}
+		}
+		if template.DeliveryMethod == "" && template.AddressID == 0 {
+			template.DeliveryMethod = order.DeliveryMethod
+			template.AddressID = order.ShippingAddress.AddressID
+		}
+	}
+
+	if err := s.validateOrderItems(items); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: %v", err)
+	}
+	template.Items = make([]model.OrderTemplateItem, len(items))
+	for i, item := range items {
+		template.Items[i] = model.OrderTemplateItem{ProductID: item.ProductId, VariantID: item.VariantId, Quantity: item.Quantity}
+	}
+
+	if err := s.resolveTemplateDelivery(ctx, template); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.templateRepo.ListTemplatesByUserID(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to get templates: %v", err)
+	}
+	if len(existing) >= model.MaxOrderTemplates {
+		return nil, status.Errorf(codes.FailedPrecondition, "TEMPLATE_LIMIT: At most %d templates per user", model.MaxOrderTemplates)
+	}
+	for _, t := range existing {
+		if strings.EqualFold(t.Name, name) {
+			return nil, status.Errorf(codes.AlreadyExists, "TEMPLATE_EXISTS: Template '%s' already exists", name)
+		}
+	}
+
+	if err := s.templateRepo.CreateTemplate(ctx, template); err != nil {
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to create template: %v", err)
+	}
+
+	return &pb.CreateOrderTemplateResponse{
+		Result: &pb.CreateOrderTemplateResponse_Template{Template: templateToProto(template)},
+	}, nil
+}
+
+// resolveTemplateDelivery validates the delivery of a template; the address must be in the user's address book,
+// so that the template stays valid after the address is edited
+func (s *OrderServiceImpl) resolveTemplateDelivery(ctx context.Context, template *model.OrderTemplate) error {
+	method := strings.ToLower(strings.TrimSpace(template.DeliveryMethod))
+	if method == "" {
+		method = address.DefaultDeliveryMethod
+	}
+	if !address.ValidDeliveryMethod(method) {
+		return status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: Unknown delivery method %q", template.DeliveryMethod)
+	}
+	template.DeliveryMethod = method
+
+	if template.AddressID == 0 {
+		if method == address.DeliveryPickup {
+			return nil
+		}
+		return status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: address_id is required for %s delivery", method)
+	}
+
+	if _, err := s.userClient.GetAddress(ctx, template.UserID, template.AddressID); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return status.Errorf(codes.NotFound, "ADDRESS_NOT_FOUND: Address %d not found", template.AddressID)
+		}
+		return status.Errorf(codes.Internal, "USER_SERVICE_ERROR: Failed to fetch address: %v", err)
+	}
+	return nil
+}
+
+// ListOrderTemplates returns the templates of the current user
+func (s *OrderServiceImpl) ListOrderTemplates(ctx context.Context, req *pb.ListOrderTemplatesRequest) (*pb.ListOrderTemplatesResponse, error) {
+	userID, _, err := s.getUserInfoFromContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "UNAUTHORIZED: %v", err)
+	}
+
+	templates, err := s.templateRepo.ListTemplatesByUserID(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to get templates: %v", err)
+	}
+
+	resp := &pb.ListOrderTemplatesResponse{Templates: make([]*pb.OrderTemplate, len(templates))}
+	for i := range templates {
+		resp.Templates[i] = templateToProto(&templates[i])
+	}
+	return resp, nil
+}
+
+func (s *OrderServiceImpl) DeleteOrderTemplate(ctx context.Context, req *pb.DeleteOrderTemplateRequest) (*pb.DeleteOrderTemplateResponse, error) {
+	template, err := s.getOwnTemplate(ctx, req.TemplateId)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.DeleteTemplate(ctx, template.ID); err != nil {
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to delete template: %v", err)
+	}
+
+	return &pb.DeleteOrderTemplateResponse{
+		Result: &pb.DeleteOrderTemplateResponse_Success{Success: &emptypb.Empty{}},
+	}, nil
+}
+
+// SubmitOrderTemplate places an order from a template at current prices
+func (s *OrderServiceImpl) SubmitOrderTemplate(ctx context.Context, req *pb.SubmitOrderTemplateRequest) (*pb.SubmitOrderTemplateResponse, error) {
+	template, err := s.getOwnTemplate(ctx, req.TemplateId)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]reorderItem, len(template.Items))
+	for i, item := range template.Items {
+		items[i] = reorderItem{productID: item.ProductID, variantID: item.VariantID, quantity: item.Quantity}
+	}
+
+	create := &pb.CreateOrderRequest{
+		UserId:         template.UserID,
+		PromoCodes:     req.PromoCodes,
+		DeliveryMethod: template.DeliveryMethod,
+	}
+	if template.AddressID != 0 {
+		create.Shipping = &pb.CreateOrderRequest_AddressId{AddressId: template.AddressID}
+	}
+
+	result, err := s.placeReorder(ctx, items, create, req.DryRun)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.SubmitOrderTemplateResponse{Result: &pb.SubmitOrderTemplateResponse_Reorder{Reorder: result}}, nil
+}
+
+// getOwnTemplate loads a template of the current user; other users' templates are hidden even from admins
+func (s *OrderServiceImpl) getOwnTemplate(ctx context.Context, templateID int64) (*model.OrderTemplate, error) {
+	userID, _, err := s.getUserInfoFromContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "UNAUTHORIZED: %v", err)
+	}
+
+	template, err := s.templateRepo.GetTemplate(ctx, templateID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "NOT_FOUND: Template not found")
+		}
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to get template: %v", err)
+	}
+	if template.UserID != userID {
+		return nil, status.Errorf(codes.NotFound, "NOT_FOUND: Template not found")
+	}
+	return template, nil
+}
+
+func templateToProto(t *model.OrderTemplate) *pb.OrderTemplate {
+	items := make([]*pb.OrderItem, len(t.Items))
+	for i, item := range t.Items {
+		items[i] = &pb.OrderItem{ProductId: item.ProductID, VariantId: item.VariantID, Quantity: item.Quantity}
+	}
+	return &pb.OrderTemplate{
+		Id:             t.ID,
+		UserId:         t.UserID,
+		Name:           t.Name,
+		Items:          items,
+		DeliveryMethod: t.DeliveryMethod,
+		AddressId:      t.AddressID,
+		CreatedAt:      timestamppb.New(t.CreatedAt),
+		UpdatedAt:      timestamppb.New(t.UpdatedAt),
+	}
+}
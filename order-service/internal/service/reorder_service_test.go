@@ -0,0 +1,270 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	pb "order-service/api/order/v1"
+	"order-service/internal/model"
+	"order-service/internal/repository"
+	"order-service/internal/service"
+	productpb "order-service/pkg/api/product/v1"
+	userpb "order-service/pkg/api/user/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// mockTemplateRepository keeps order templates in memory
+type mockTemplateRepository struct {
+	templates []model.OrderTemplate
+}
+
+var _ repository.TemplateRepository = (*mockTemplateRepository)(nil)
+
+func (m *mockTemplateRepository) CreateTemplate(ctx context.Context, template *model.OrderTemplate) error {
+	template.ID = int64(len(m.templates) + 1)
+	m.templates = append(m.templates, *template)
+	return nil
+}
+
+func (m *mockTemplateRepository) GetTemplate(ctx context.Context, templateID int64) (*model.OrderTemplate, error) {
+	for _, t := range m.templates {
+		if t.ID == templateID {
+			return &t, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *mockTemplateRepository) ListTemplatesByUserID(ctx context.Context, userID int64) ([]model.OrderTemplate, error) {
+	var result []model.OrderTemplate
+	for _, t := range m.templates {
+		if t.UserID == userID {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockTemplateRepository) DeleteTemplate(ctx context.Context, templateID int64) error {
+	for i, t := range m.templates {
+		if t.ID == templateID {
+			m.templates = append(m.templates[:i], m.templates[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+// reorderFixture is an earlier order of user 1 and a catalogue that has changed a lot since then
+type reorderFixture struct {
+	created   *model.Order
+	templates *mockTemplateRepository
+	svc       service.OrderService
+}
+
+func newReorderFixture() *reorderFixture {
+	f := &reorderFixture{templates: &mockTemplateRepository{}}
+	tshirtXL := int64(1500)
+
+	source := &model.Order{
+		ID:             10,
+		UserID:         1,
+		Status:         "completed",
+		DeliveryMethod: "courier",
+		ShippingAddress: model.ShippingAddress{
+			AddressID: 7, RecipientName: "John Smith", Country: "US", Region: "NY", City: "New York", PostalCode: "10001", Line1: "5th Ave 1",
+		},
+		Items: []model.OrderItem{
+			{ProductID: 1, ProductName: "Mug", PriceMinor: 1000, Currency: "USD", Quantity: 2},
+			{ProductID: 2, ProductName: "Kettle", PriceMinor: 5000, Currency: "USD", Quantity: 3},
+			{ProductID: 3, ProductName: "Teapot", PriceMinor: 3000, Currency: "USD", Quantity: 1},
+			{ProductID: 4, ProductName: "Spoon", PriceMinor: 200, Currency: "USD", Quantity: 1},
+			{ProductID: 5, VariantID: 52, VariantSKU: "TS-XL", ProductName: "T-shirt", PriceMinor: 1500, Currency: "USD", Quantity: 2},
+			{ProductID: 6, VariantID: 61, VariantSKU: "CAP-RED", ProductName: "Cap", PriceMinor: 700, Currency: "USD", Quantity: 1},
+		},
+	}
+
+	catalog := map[int64]*productpb.ProductResponse{
+		1: {Id: 1, Name: "Mug", PriceMinor: 1200, Currency: "USD", Stock: 10},                      // more expensive now
+		2: {Id: 2, Name: "Kettle", PriceMinor: 5000, Currency: "USD", Stock: 1},                    // less in stock
+		3: {Id: 3, Name: "Teapot", PriceMinor: 3000, Currency: "USD", Stock: 0},                    // out of stock
+		4: {Id: 4, Name: "Spoon", PriceMinor: 200, Currency: "USD", Stock: 50, Status: "archived"}, // no longer sold
+		5: {Id: 5, Name: "T-shirt", PriceMinor: 1000, Currency: "USD", Stock: 11, Variants: []*productpb.ProductVariant{
+			{Id: 51, Sku: "TS-S", PriceMinor: 1000, Stock: 10},
+			{Id: 52, Sku: "TS-XL", PriceMinor: 1500, PriceOverrideMinor: &tshirtXL, Stock: 1}, // less left of this size
+		}},
+		6: {Id: 6, Name: "Cap", PriceMinor: 700, Currency: "USD", Stock: 5, Variants: []*productpb.ProductVariant{
+			{Id: 62, Sku: "CAP-BLUE", PriceMinor: 700, Stock: 5}, // the red variant was removed
+		}},
+	}
+
+	repo := &mockOrderRepository{
+		getOrderFunc: func(ctx context.Context, orderID int64) (*model.Order, error) {
+			if orderID != source.ID {
+				return nil, gorm.ErrRecordNotFound
+			}
+			return source, nil
+		},
+		createOrderFunc: func(ctx context.Context, order *model.Order) (*model.Order, error) {
+			order.ID = 11
+			f.created = order
+			return order, nil
+		},
+	}
+	products := &mockProductClient{
+		getProductsFunc: func(ctx context.Context, ids []int64) (map[int64]*productpb.ProductResponse, error) {
+			return catalog, nil
+		},
+	}
+	users := &mockUserClient{
+		getAddressFunc: func(ctx context.Context, userID, addressID int64) (*userpb.Address, error) {
+			if userID != 1 || addressID != 7 {
+				return nil, status.Error(codes.NotFound, "address not found")
+			}
+			return &userpb.Address{Id: 7, UserId: 1, RecipientName: "John Smith", Country: "US", Region: "MA", City: "Boston", PostalCode: "02101", Line1: "Main St 2"}, nil
+		},
+	}
+
+	f.svc = service.NewOrderService(repo, &mockPromotionRepository{}, &mockPaymentRepository{}, &mockCompensationRepository{}, &mockReturnRepository{}, &mockInvoiceRepository{}, nil, f.templates, products, users, nil)
+	return f
+}
+
+func TestReorder(t *testing.T) {
+	f := newReorderFixture()
+
+	resp, err := f.svc.Reorder(contextWithAuth("1", "user"), &pb.ReorderRequest{OrderId: 10})
+	if err != nil {
+		t.Fatalf("Reorder: %v", err)
+	}
+	result := resp.GetReorder()
+	if result.OrderId != 11 || !result.Changed {
+		t.Errorf("expected changed order 11, got %+v", result)
+	}
+
+	expected := []struct {
+		quantity     int32
+		availability string
+		priceChanged bool
+	}{
+		{2, service.AvailabilityAvailable, true},
+		{1, service.AvailabilityPartial, false},
+		{0, service.AvailabilityUnavailable, false},
+		{0, service.AvailabilityUnavailable, false},
+		{1, service.AvailabilityPartial, false},
+		{0, service.AvailabilityUnavailable, false},
+	}
+	for i, want := range expected {
+		line := result.Lines[i]
+		if line.Quantity != want.quantity || line.Availability != want.availability || line.PriceChanged != want.priceChanged {
+			t.Errorf("line %d: expected %+v, got %+v", i, want, line)
+		}
+	}
+	if result.Lines[0].PreviousPriceMinor != 1000 || result.Lines[0].PriceMinor != 1200 {
+		t.Errorf("unexpected prices in first line: %+v", result.Lines[0])
+	}
+
+	// The new order has current prices, no unavailable lines and the address of the original order
+	if len(f.created.Items) != 3 || f.created.Items[0].PriceMinor != 1200 || f.created.Items[1].Quantity != 1 {
+		t.Errorf("unexpected items of the new order: %+v", f.created.Items)
+	}
+	// The variant is kept together with its own price
+	if item := f.created.Items[2]; item.VariantID != 52 || item.VariantSKU != "TS-XL" || item.PriceMinor != 1500 {
+		t.Errorf("expected variant 52 at its own price, got %+v", item)
+	}
+	if f.created.ShippingAddress.City != "New York" || f.created.DeliveryMethod != "courier" {
+		t.Errorf("expected the source delivery, got %s %+v", f.created.DeliveryMethod, f.created.ShippingAddress)
+	}
+}
+
+func TestReorder_DryRunAndAccess(t *testing.T) {
+	f := newReorderFixture()
+
+	resp, err := f.svc.Reorder(contextWithAuth("1", "user"), &pb.ReorderRequest{OrderId: 10, DryRun: true})
+	if err != nil {
+		t.Fatalf("Reorder dry run: %v", err)
+	}
+	if resp.GetReorder().OrderId != 0 || f.created != nil {
+		t.Errorf("dry run must not create an order")
+	}
+
+	for _, ctx := range []context.Context{contextWithAuth("2", "user"), contextWithAuth("99", "admin")} {
+		_, err = f.svc.Reorder(ctx, &pb.ReorderRequest{OrderId: 10})
+		if status.Code(err) != codes.PermissionDenied {
+			t.Errorf("expected PermissionDenied for a foreign order, got %v", err)
+		}
+	}
+}
+
+func TestOrderTemplates(t *testing.T) {
+	f := newReorderFixture()
+	ctx := contextWithAuth("1", "user")
+
+	created, err := f.svc.CreateOrderTemplate(ctx, &pb.CreateOrderTemplateRequest{Name: " Weekly ", FromOrderId: 10})
+	if err != nil {
+		t.Fatalf("CreateOrderTemplate: %v", err)
+	}
+	template := created.GetTemplate()
+	if template.Name != "Weekly" || len(template.Items) != 6 || template.Items[4].VariantId != 52 || template.AddressId != 7 || template.DeliveryMethod != "courier" {
+		t.Errorf("unexpected template: %+v", template)
+	}
+
+	_, err = f.svc.CreateOrderTemplate(ctx, &pb.CreateOrderTemplateRequest{Name: "weekly", Items: []*pb.OrderItem{{ProductId: 1, Quantity: 1}}, DeliveryMethod: "pickup"})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("expected AlreadyExists for a duplicate name, got %v", err)
+	}
+	_, err = f.svc.CreateOrderTemplate(ctx, &pb.CreateOrderTemplateRequest{Name: "Courier", Items: []*pb.OrderItem{{ProductId: 1, Quantity: 1}}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument without address for courier, got %v", err)
+	}
+
+	// The template is placed with the current address from the address book
+	submitted, err := f.svc.SubmitOrderTemplate(ctx, &pb.SubmitOrderTemplateRequest{TemplateId: template.Id})
+	if err != nil {
+		t.Fatalf("SubmitOrderTemplate: %v", err)
+	}
+	if submitted.GetReorder().OrderId != 11 || f.created.ShippingAddress.City != "Boston" {
+		t.Errorf("unexpected submitted order: %+v, %+v", submitted.GetReorder(), f.created.ShippingAddress)
+	}
+	if submitted.GetReorder().Lines[0].PriceChanged {
+		t.Errorf("templates keep no prices, price_changed must be false")
+	}
+
+	_, err = f.svc.SubmitOrderTemplate(contextWithAuth("2", "user"), &pb.SubmitOrderTemplateRequest{TemplateId: template.Id})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound for a foreign template, got %v", err)
+	}
+
+	if _, err := f.svc.DeleteOrderTemplate(ctx, &pb.DeleteOrderTemplateRequest{TemplateId: template.Id}); err != nil {
+		t.Fatalf("DeleteOrderTemplate: %v", err)
+	}
+	list, err := f.svc.ListOrderTemplates(ctx, &pb.ListOrderTemplatesRequest{})
+	if err != nil || len(list.Templates) != 0 {
+		t.Errorf("expected no templates after delete, got %v, %v", list, err)
+	}
+}
+
+func TestSubmitOrderTemplate_NothingAvailable(t *testing.T) {
+	f := newReorderFixture()
+	ctx := contextWithAuth("1", "user")
+
+	created, err := f.svc.CreateOrderTemplate(ctx, &pb.CreateOrderTemplateRequest{
+		Name:           "Gone",
+		Items:          []*pb.OrderItem{{ProductId: 3, Quantity: 1}, {ProductId: 4, Quantity: 2}},
+		DeliveryMethod: "pickup",
+	})
+	if err != nil {
+		t.Fatalf("CreateOrderTemplate: %v", err)
+	}
+
+	_, err = f.svc.SubmitOrderTemplate(ctx, &pb.SubmitOrderTemplateRequest{TemplateId: created.GetTemplate().Id})
+	st, _ := status.FromError(err)
+	if st.Code() != codes.FailedPrecondition || st.Message() != "NOTHING_TO_ORDER: None of the items is available" {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if f.created != nil {
+		t.Errorf("no order must be created")
+	}
+}
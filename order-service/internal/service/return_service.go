@@ -0,0 +1,524 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	pb "order-service/api/order/v1"
+	"order-service/internal/model"
+	"order-service/internal/payment"
+	"order-service/internal/repository"
+
+	"github.com/microserviceteam0/bff-gateway/shared/money"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// CreateReturn оформляет заявку на возврат позиций выполненного заказа.
+// Сумма к возврату считается сразу, чтобы клиент видел ее до решения администратора
+func (s *OrderServiceImpl) CreateReturn(ctx context.Context, req *pb.CreateReturnRequest) (*pb.CreateReturnResponse, error) {
+	userID, isAdmin, err := s.getUserInfoFromContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "UNAUTHORIZED: %v", err)
+	}
+
+	if !model.ReturnReasons[req.Reason] {
+		return nil, status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: Unknown return reason '%s'", req.Reason)
+	}
+	if req.Reason == "other" && req.Comment == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: comment is required for reason 'other'")
+	}
+	if len(req.Items) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: return must contain at least one item")
+	}
+
+	order, err := s.repo.GetOrder(ctx, req.OrderId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "NOT_FOUND: Order not found")
+		}
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to get order: %v", err)
+	}
+
+	if !isAdmin && order.UserID != userID {
+		return nil, status.Errorf(codes.PermissionDenied, "FORBIDDEN: Access denied")
+	}
+
+	if order.Status != "completed" && order.Status != model.OrderPartiallyRefunded {
+		return nil, status.Errorf(codes.FailedPrecondition, "INVALID_STATUS: Cannot return items of order with status '%s'", order.Status)
+	}
+
+	ret := &model.OrderReturn{
+		OrderID:  order.ID,
+		UserID:   order.UserID,
+		Status:   model.ReturnRequested,
+		Reason:   req.Reason,
+		Comment:  req.Comment,
+		Currency: order.Total().Currency,
+	}
+
+	// Уже возвращенное количество проверяется под блокировкой заказа:
+	// две параллельные заявки не смогут вернуть больше купленного
+	prepare := func(existing []model.OrderReturn) error {
+		returned := returnedQuantities(existing, nil)
+		ret.Items = make([]model.OrderReturnItem, 0, len(req.Items))
+		ret.RefundMinor = 0
+
+		seen := make(map[model.StockKey]bool, len(req.Items))
+		for _, item := range req.Items {
+			if item.Quantity <= 0 {
+				return status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: invalid quantity for product %d", item.ProductId)
+			}
+
+			line, err := findOrderItem(order, item.ProductId, item.VariantId)
+			if err != nil {
+				return err
+			}
+			if seen[line.StockKey()] {
+				return status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: product %d is listed twice", item.ProductId)
+			}
+			seen[line.StockKey()] = true
+
+			before := returned[line.StockKey()]
+			if before+item.Quantity > line.Quantity {
+				return status.Errorf(codes.FailedPrecondition, "RETURN_QUANTITY_EXCEEDED: Only %d of product %d can be returned", line.Quantity-before, item.ProductId)
+			}
+
+			refund := lineRefund(line, before, item.Quantity)
+			ret.Items = append(ret.Items, model.OrderReturnItem{
+				ProductID:   line.ProductID,
+				VariantID:   line.VariantID,
+				Quantity:    item.Quantity,
+				RefundMinor: refund,
+			})
+			ret.RefundMinor += refund
+		}
+		return nil
+	}
+
+	if err := s.returnRepo.CreateReturn(ctx, ret, prepare); err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to create return: %v", err)
+	}
+
+	slog.Info("Return requested", "return_id", ret.ID, "order_id", order.ID, "refund_minor", ret.RefundMinor)
+
+	return &pb.CreateReturnResponse{
+		Result: &pb.CreateReturnResponse_OrderReturn{
+			OrderReturn: returnToProto(ret),
+		},
+	}, nil
+}
+
+func (s *OrderServiceImpl) GetReturn(ctx context.Context, req *pb.GetReturnRequest) (*pb.GetReturnResponse, error) {
+	userID, isAdmin, err := s.getUserInfoFromContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "UNAUTHORIZED: %v", err)
+	}
+
+	ret, err := s.getReturn(ctx, req.ReturnId)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isAdmin && ret.UserID != userID {
+		return nil, status.Errorf(codes.PermissionDenied, "FORBIDDEN: Access denied")
+	}
+
+	return &pb.GetReturnResponse{
+		Result: &pb.GetReturnResponse_OrderReturn{
+			OrderReturn: returnToProto(ret),
+		},
+	}, nil
+}
+
+func (s *OrderServiceImpl) ListReturns(ctx context.Context, req *pb.ListReturnsRequest) (*pb.ListReturnsResponse, error) {
+	userID, isAdmin, err := s.getUserInfoFromContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "UNAUTHORIZED: %v", err)
+	}
+
+	filter := repository.ReturnFilter{
+		OrderID: req.OrderId,
+		UserID:  req.UserId,
+		Status:  req.GetStatus(),
+	}
+	if !isAdmin {
+		if req.UserId != 0 && req.UserId != userID {
+			return nil, status.Errorf(codes.PermissionDenied, "FORBIDDEN: Access denied")
+		}
+		filter.UserID = userID
+	}
+
+	page := req.Page
+	pageSize := req.PageSize
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	returns, total, err := s.returnRepo.ListReturns(ctx, filter, int(pageSize), int((page-1)*pageSize))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to list returns: %v", err)
+	}
+
+	pbReturns := make([]*pb.OrderReturn, len(returns))
+	for i := range returns {
+		pbReturns[i] = returnToProto(&returns[i])
+	}
+
+	return &pb.ListReturnsResponse{
+		Returns:    pbReturns,
+		TotalCount: int32(total),
+		Page:       page,
+		PageSize:   pageSize,
+	}, nil
+}
+
+// ApproveReturn разрешает покупателю отправить товар
+func (s *OrderServiceImpl) ApproveReturn(ctx context.Context, req *pb.ApproveReturnRequest) (*pb.ApproveReturnResponse, error) {
+	adminID, err := s.requireAdminID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := s.getReturn(ctx, req.ReturnId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	ret.Status = model.ReturnApproved
+	ret.ReviewedBy = adminID
+	ret.ReviewedAt = &now
+	if err := s.transitReturn(ctx, ret, model.ReturnRequested); err != nil {
+		return nil, err
+	}
+
+	return &pb.ApproveReturnResponse{
+		Result: &pb.ApproveReturnResponse_OrderReturn{
+			OrderReturn: returnToProto(ret),
+		},
+	}, nil
+}
+
+// RejectReturn отклоняет заявку; ее позиции снова можно вернуть
+func (s *OrderServiceImpl) RejectReturn(ctx context.Context, req *pb.RejectReturnRequest) (*pb.RejectReturnResponse, error) {
+	adminID, err := s.requireAdminID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Reason == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: reason is required")
+	}
+
+	ret, err := s.getReturn(ctx, req.ReturnId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	ret.Status = model.ReturnRejected
+	ret.RejectionReason = req.Reason
+	ret.ReviewedBy = adminID
+	ret.ReviewedAt = &now
+	if err := s.transitReturn(ctx, ret, model.ReturnRequested); err != nil {
+		return nil, err
+	}
+
+	return &pb.RejectReturnResponse{
+		Result: &pb.RejectReturnResponse_OrderReturn{
+			OrderReturn: returnToProto(ret),
+		},
+	}, nil
+}
+
+// ReceiveReturn фиксирует поступление товара на склад и возвращает остатки в product-service
+func (s *OrderServiceImpl) ReceiveReturn(ctx context.Context, req *pb.ReceiveReturnRequest) (*pb.ReceiveReturnResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	ret, err := s.getReturn(ctx, req.ReturnId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	ret.Status = model.ReturnReceived
+	ret.ReceivedAt = &now
+	if err := s.transitReturn(ctx, ret, model.ReturnApproved); err != nil {
+		return nil, err
+	}
+
+	restocked := make(map[model.StockKey]int32)
+	for _, item := range ret.Items {
+		restocked[item.StockKey()] += item.Quantity
+	}
+	s.compensateStockBatch(ctx, ret.OrderID, restocked, model.CompensationReturnReceived)
+
+	return &pb.ReceiveReturnResponse{
+		Result: &pb.ReceiveReturnResponse_OrderReturn{
+			OrderReturn: returnToProto(ret),
+		},
+	}, nil
+}
+
+// RefundReturn возвращает деньги за полученный товар на списанный платеж заказа.
+// Заявка сначала переводится в refunded, чтобы параллельный вызов не вернул деньги дважды;
+// при ошибке провайдера статус откатывается
+func (s *OrderServiceImpl) RefundReturn(ctx context.Context, req *pb.RefundReturnRequest) (*pb.RefundReturnResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	ret, err := s.getReturn(ctx, req.ReturnId)
+	if err != nil {
+		return nil, err
+	}
+	if ret.Status != model.ReturnReceived {
+		return nil, invalidReturnStatusError(ret.Status)
+	}
+
+	order, err := s.repo.GetOrder(ctx, ret.OrderID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to get order: %v", err)
+	}
+
+	var p *model.Payment
+	if ret.RefundMinor > 0 {
+		if p, err = s.refundablePayment(ctx, order.ID, ret.Refund()); err != nil {
+			return nil, err
+		}
+	}
+
+	ret.Status = model.ReturnRefunded
+	if err := s.transitReturn(ctx, ret, model.ReturnReceived); err != nil {
+		return nil, err
+	}
+
+	if p != nil {
+		if _, err := s.refundPayment(ctx, p, ret.RefundMinor); err != nil {
+			ret.Status = model.ReturnReceived
+			if rerr := s.returnRepo.UpdateReturn(context.WithoutCancel(ctx), ret, model.ReturnRefunded); rerr != nil {
+				slog.Error("Failed to release return after refund error", "return_id", ret.ID, "error", rerr)
+			}
+			return nil, err
+		}
+		ret.PaymentID = p.ID
+	}
+
+	now := time.Now()
+	ret.RefundedAt = &now
+
+	// Деньги уже ушли покупателю: запись не должна зависеть от отмены запроса клиентом
+	order, err = s.completeRefund(context.WithoutCancel(ctx), ret, p, order)
+	if err != nil {
+		slog.Error("Refund sent but not recorded", "return_id", ret.ID, "order_id", ret.OrderID, "payment_id", ret.PaymentID, "error", err)
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to save refund: %v", err)
+	}
+
+	slog.Info("Return refunded", "return_id", ret.ID, "order_id", order.ID, "refund_minor", ret.RefundMinor, "order_status", order.Status)
+
+	return &pb.RefundReturnResponse{
+		Result: &pb.RefundReturnResponse_OrderReturn{
+			OrderReturn: returnToProto(ret),
+		},
+	}, nil
+}
+
+// maxRefundRecordAttempts ограничивает повторы, если заказ меняется быстрее, чем сохраняется возврат
+const maxRefundRecordAttempts = 3
+
+// completeRefund сохраняет возврат вместе с новым статусом заказа. Если заказ успели изменить,
+// статус пересчитывается по свежему заказу, чтобы не затереть чужое изменение
+func (s *OrderServiceImpl) completeRefund(ctx context.Context, ret *model.OrderReturn, p *model.Payment, order *model.Order) (*model.Order, error) {
+	for attempt := 1; ; attempt++ {
+		orderStatus, err := s.orderStatusAfterRefund(ctx, order, ret)
+		if err != nil {
+			return nil, err
+		}
+		order.Status = orderStatus
+
+		err = s.returnRepo.CompleteRefund(ctx, ret, p, order)
+		if err == nil {
+			return order, nil
+		}
+		if !errors.Is(err, repository.ErrVersionConflict) || attempt == maxRefundRecordAttempts {
+			return nil, err
+		}
+		if order, err = s.repo.GetOrder(ctx, ret.OrderID); err != nil {
+			return nil, fmt.Errorf("reload order %d: %w", ret.OrderID, err)
+		}
+	}
+}
+
+// refundablePayment выбирает списанный платеж, на котором хватает денег для возврата
+func (s *OrderServiceImpl) refundablePayment(ctx context.Context, orderID int64, amount money.Money) (*model.Payment, error) {
+	payments, err := s.paymentRepo.ListPaymentsByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to get payments: %v", err)
+	}
+
+	for i := range payments {
+		p := &payments[i]
+		if p.Status != payment.StatusCaptured && p.Status != payment.StatusPartiallyRefunded {
+			continue
+		}
+		if p.Currency == amount.Currency && p.CapturedMinor-p.RefundedMinor >= amount.Amount {
+			return p, nil
+		}
+	}
+	return nil, status.Errorf(codes.FailedPrecondition, "NO_REFUNDABLE_PAYMENT: Order has no captured payment to refund %s", amount.String())
+}
+
+// orderStatusAfterRefund — refunded, если вернули деньги за все товары заказа, иначе partially_refunded
+func (s *OrderServiceImpl) orderStatusAfterRefund(ctx context.Context, order *model.Order, refunded *model.OrderReturn) (string, error) {
+	returns, err := s.returnRepo.ListReturnsByOrderID(ctx, order.ID)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to get returns: %v", err)
+	}
+
+	quantities := returnedQuantities(returns, func(r *model.OrderReturn) bool {
+		return r.Status == model.ReturnRefunded || r.ID == refunded.ID
+	})
+	for _, item := range order.Items {
+		if quantities[item.StockKey()] < item.Quantity {
+			return model.OrderPartiallyRefunded, nil
+		}
+	}
+	return model.OrderRefunded, nil
+}
+
+// transitReturn сохраняет заявку, если она все еще в статусе from
+func (s *OrderServiceImpl) transitReturn(ctx context.Context, ret *model.OrderReturn, from string) error {
+	err := s.returnRepo.UpdateReturn(ctx, ret, from)
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, repository.ErrReturnStatusChanged):
+		current, gerr := s.returnRepo.GetReturn(ctx, ret.ID)
+		if gerr != nil {
+			return status.Errorf(codes.Aborted, "CONFLICT: Return was modified concurrently")
+		}
+		return invalidReturnStatusError(current.Status)
+	default:
+		return status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to save return: %v", err)
+	}
+}
+
+func invalidReturnStatusError(current string) error {
+	return status.Errorf(codes.FailedPrecondition, "INVALID_STATUS: Return is '%s'", current)
+}
+
+func (s *OrderServiceImpl) getReturn(ctx context.Context, returnID int64) (*model.OrderReturn, error) {
+	ret, err := s.returnRepo.GetReturn(ctx, returnID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, status.Errorf(codes.NotFound, "NOT_FOUND: Return not found")
+		}
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to get return: %v", err)
+	}
+	return ret, nil
+}
+
+// returnedQuantities суммирует количество товара в активных заявках; include сужает набор заявок
+func returnedQuantities(returns []model.OrderReturn, include func(r *model.OrderReturn) bool) map[model.StockKey]int32 {
+	quantities := make(map[model.StockKey]int32)
+	for i := range returns {
+		r := &returns[i]
+		if !r.Active() || (include != nil && !include(r)) {
+			continue
+		}
+		for _, item := range r.Items {
+			quantities[item.StockKey()] += item.Quantity
+		}
+	}
+	return quantities
+}
+
+// findOrderItem ищет позицию заказа; variantID 0 подходит, если товар в заказе одной позицией
+func findOrderItem(order *model.Order, productID, variantID int64) (*model.OrderItem, error) {
+	var found *model.OrderItem
+	for i := range order.Items {
+		line := &order.Items[i]
+		if line.ProductID != productID {
+			continue
+		}
+		if line.VariantID == variantID {
+			return line, nil
+		}
+		if variantID == 0 {
+			if found != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "VARIANT_REQUIRED: product %d is in the order in several variants", productID)
+			}
+			found = line
+		}
+	}
+	if found == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: product %d is not in the order", productID)
+	}
+	return found, nil
+}
+
+// lineRefund считает возврат за qty единиц позиции, если before единиц уже возвращены.
+// Скидка позиции делится пропорционально количеству; копейки от деления достаются последним
+// единицам, поэтому возврат всей позиции частями в сумме равен ее стоимости со скидкой
+func lineRefund(item *model.OrderItem, before, qty int32) int64 {
+	unit := item.UnitPrice().Amount
+	net := func(n int32) int64 {
+		return unit*int64(n) - item.DiscountMinor*int64(n)/int64(item.Quantity)
+	}
+	return net(before+qty) - net(before)
+}
+
+func returnToProto(r *model.OrderReturn) *pb.OrderReturn {
+	items := make([]*pb.ReturnItem, len(r.Items))
+	for i, item := range r.Items {
+		items[i] = &pb.ReturnItem{
+			ProductId:   item.ProductID,
+			VariantId:   item.VariantID,
+			Quantity:    item.Quantity,
+			RefundMinor: item.RefundMinor,
+		}
+	}
+
+	return &pb.OrderReturn{
+		Id:              r.ID,
+		OrderId:         r.OrderID,
+		UserId:          r.UserID,
+		Status:          r.Status,
+		Reason:          r.Reason,
+		Comment:         r.Comment,
+		Items:           items,
+		RefundMinor:     r.RefundMinor,
+		Currency:        r.Currency,
+		RejectionReason: r.RejectionReason,
+		PaymentId:       r.PaymentID,
+		CreatedAt:       timestamppb.New(r.CreatedAt),
+		UpdatedAt:       timestamppb.New(r.UpdatedAt),
+		ReviewedAt:      optionalTimestamp(r.ReviewedAt),
+		ReceivedAt:      optionalTimestamp(r.ReceivedAt),
+		RefundedAt:      optionalTimestamp(r.RefundedAt),
+	}
+}
+
+func optionalTimestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
@@ -0,0 +1,487 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	pb "order-service/api/order/v1"
+	"order-service/internal/model"
+	"order-service/internal/payment"
+	"order-service/internal/repository"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// mockReturnRepository хранит заявки на возврат в памяти
+type mockReturnRepository struct {
+	returns            []model.OrderReturn
+	completeRefundFunc func(ret *model.OrderReturn, p *model.Payment, order *model.Order) error
+}
+
+var _ repository.ReturnRepository = (*mockReturnRepository)(nil)
+
+func (m *mockReturnRepository) CreateReturn(ctx context.Context, ret *model.OrderReturn, prepare func(existing []model.OrderReturn) error) error {
+	var existing []model.OrderReturn
+	for _, r := range m.returns {
+		if r.OrderID == ret.OrderID {
+			existing = append(existing, r)
+		}
+	}
+	if err := prepare(existing); err != nil {
+		return err
+	}
+	ret.ID = int64(len(m.returns) + 1)
+	m.returns = append(m.returns, *ret)
+	return nil
+}
+
+func (m *mockReturnRepository) GetReturn(ctx context.Context, returnID int64) (*model.OrderReturn, error) {
+	for _, r := range m.returns {
+		if r.ID == returnID {
+			return &r, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (m *mockReturnRepository) ListReturnsByOrderID(ctx context.Context, orderID int64) ([]model.OrderReturn, error) {
+	var result []model.OrderReturn
+	for _, r := range m.returns {
+		if r.OrderID == orderID {
+			result = append(result, r)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockReturnRepository) ListReturns(ctx context.Context, filter repository.ReturnFilter, limit int, offset int) ([]model.OrderReturn, int64, error) {
+	var result []model.OrderReturn
+	for _, r := range m.returns {
+		if (filter.OrderID == 0 || r.OrderID == filter.OrderID) &&
+			(filter.UserID == 0 || r.UserID == filter.UserID) &&
+			(filter.Status == "" || r.Status == filter.Status) {
+			result = append(result, r)
+		}
+	}
+	return result, int64(len(result)), nil
+}
+
+func (m *mockReturnRepository) UpdateReturn(ctx context.Context, ret *model.OrderReturn, fromStatus string) error {
+	for i := range m.returns {
+		if m.returns[i].ID == ret.ID {
+			if m.returns[i].Status != fromStatus {
+				return repository.ErrReturnStatusChanged
+			}
+			m.returns[i] = *ret
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
+
+func (m *mockReturnRepository) CompleteRefund(ctx context.Context, ret *model.OrderReturn, p *model.Payment, order *model.Order) error {
+	if m.completeRefundFunc != nil {
+		if err := m.completeRefundFunc(ret, p, order); err != nil {
+			return err
+		}
+	}
+	for i := range m.returns {
+		if m.returns[i].ID == ret.ID {
+			m.returns[i] = *ret
+		}
+	}
+	return nil
+}
+
+// newCompletedOrderFixture — оплаченный и выполненный заказ: 3 шт. по 10 руб. со скидкой 1 руб. на позицию
+func newCompletedOrderFixture(t *testing.T) *paymentFixture {
+	t.Helper()
+	f := newPaymentFixture()
+	f.order.Items = []model.OrderItem{{ProductID: 101, Quantity: 3, PriceMinor: 1000, Currency: "RUB", DiscountMinor: 100}}
+	f.order.TotalAmountMinor = 2900
+
+	if _, err := f.svc.PayOrder(contextWithAuth("1", "user"), &pb.PayOrderRequest{OrderId: 1, PaymentToken: payment.TokenSuccess}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := f.svc.CapturePayment(contextWithAuth("99", "admin"), &pb.CapturePaymentRequest{PaymentId: 1}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	f.order.Status = "completed"
+	return f
+}
+
+func TestCreateReturnRefundAmounts(t *testing.T) {
+	f := newCompletedOrderFixture(t)
+	ctx := contextWithAuth("1", "user")
+
+	// Скидка 100 делится на 3 единицы: 33 + 33 + 34, сумма частичных возвратов равна оплаченному
+	var total int64
+	for i, expected := range []int64{967, 967, 966} {
+		resp, err := f.svc.CreateReturn(ctx, &pb.CreateReturnRequest{
+			OrderId: 1,
+			Items:   []*pb.ReturnItem{{ProductId: 101, Quantity: 1}},
+			Reason:  "damaged",
+		})
+		if err != nil {
+			t.Fatalf("return %d: unexpected error: %v", i, err)
+		}
+		ret := resp.GetOrderReturn()
+		if ret.Status != model.ReturnRequested {
+			t.Errorf("expected %v, got %v", model.ReturnRequested, ret.Status)
+		}
+		if ret.RefundMinor != expected {
+			t.Errorf("return %d: expected %v, got %v", i, expected, ret.RefundMinor)
+		}
+		total += ret.RefundMinor
+	}
+	if total != f.order.TotalAmountMinor {
+		t.Errorf("expected %v, got %v", f.order.TotalAmountMinor, total)
+	}
+
+	_, err := f.svc.CreateReturn(ctx, &pb.CreateReturnRequest{
+		OrderId: 1,
+		Items:   []*pb.ReturnItem{{ProductId: 101, Quantity: 1}},
+		Reason:  "damaged",
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected %v, got %v", codes.FailedPrecondition, status.Code(err))
+	}
+}
+
+func TestCreateReturnRejections(t *testing.T) {
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		orderStatus  string
+		req          *pb.CreateReturnRequest
+		expectedCode codes.Code
+	}{
+		{
+			name:         "Order Not Completed",
+			ctx:          contextWithAuth("1", "user"),
+			orderStatus:  "confirmed",
+			req:          &pb.CreateReturnRequest{OrderId: 1, Items: []*pb.ReturnItem{{ProductId: 101, Quantity: 1}}, Reason: "damaged"},
+			expectedCode: codes.FailedPrecondition,
+		},
+		{
+			name:         "Foreign Order",
+			ctx:          contextWithAuth("2", "user"),
+			req:          &pb.CreateReturnRequest{OrderId: 1, Items: []*pb.ReturnItem{{ProductId: 101, Quantity: 1}}, Reason: "damaged"},
+			expectedCode: codes.PermissionDenied,
+		},
+		{
+			name:         "Unknown Reason",
+			ctx:          contextWithAuth("1", "user"),
+			req:          &pb.CreateReturnRequest{OrderId: 1, Items: []*pb.ReturnItem{{ProductId: 101, Quantity: 1}}, Reason: "bored"},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "Other Without Comment",
+			ctx:          contextWithAuth("1", "user"),
+			req:          &pb.CreateReturnRequest{OrderId: 1, Items: []*pb.ReturnItem{{ProductId: 101, Quantity: 1}}, Reason: "other"},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "Product Not In Order",
+			ctx:          contextWithAuth("1", "user"),
+			req:          &pb.CreateReturnRequest{OrderId: 1, Items: []*pb.ReturnItem{{ProductId: 202, Quantity: 1}}, Reason: "damaged"},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name: "Duplicate Product",
+			ctx:  contextWithAuth("1", "user"),
+			req: &pb.CreateReturnRequest{OrderId: 1, Items: []*pb.ReturnItem{
+				{ProductId: 101, Quantity: 1},
+				{ProductId: 101, Quantity: 1},
+			}, Reason: "damaged"},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:         "Too Many Items",
+			ctx:          contextWithAuth("1", "user"),
+			req:          &pb.CreateReturnRequest{OrderId: 1, Items: []*pb.ReturnItem{{ProductId: 101, Quantity: 4}}, Reason: "damaged"},
+			expectedCode: codes.FailedPrecondition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newCompletedOrderFixture(t)
+			if tt.orderStatus != "" {
+				f.order.Status = tt.orderStatus
+			}
+
+			_, err := f.svc.CreateReturn(tt.ctx, tt.req)
+			if status.Code(err) != tt.expectedCode {
+				t.Errorf("expected %v, got %v (%v)", tt.expectedCode, status.Code(err), err)
+			}
+			if len(f.returns.returns) != 0 {
+				t.Errorf("expected no returns to be created, got %d", len(f.returns.returns))
+			}
+		})
+	}
+}
+
+func TestCreateReturnVariants(t *testing.T) {
+	newFixture := func(t *testing.T) *paymentFixture {
+		f := newCompletedOrderFixture(t)
+		f.order.Items = []model.OrderItem{
+			{ProductID: 101, VariantID: 11, Quantity: 1, PriceMinor: 1000, Currency: "RUB"},
+			{ProductID: 101, VariantID: 12, Quantity: 2, PriceMinor: 950, Currency: "RUB"},
+		}
+		return f
+	}
+
+	t.Run("Per Variant", func(t *testing.T) {
+		f := newFixture(t)
+		resp, err := f.svc.CreateReturn(contextWithAuth("1", "user"), &pb.CreateReturnRequest{OrderId: 1, Items: []*pb.ReturnItem{
+			{ProductId: 101, VariantId: 12, Quantity: 2},
+			{ProductId: 101, VariantId: 11, Quantity: 1},
+		}, Reason: "damaged"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		ret := resp.GetOrderReturn()
+		if ret.RefundMinor != 2900 || ret.Items[0].VariantId != 12 || ret.Items[1].VariantId != 11 {
+			t.Errorf("unexpected return %v", ret)
+		}
+	})
+
+	tests := []struct {
+		name         string
+		items        []*pb.ReturnItem
+		expectedCode codes.Code
+	}{
+		{"Variant Required", []*pb.ReturnItem{{ProductId: 101, Quantity: 1}}, codes.InvalidArgument},
+		{"Variant Not In Order", []*pb.ReturnItem{{ProductId: 101, VariantId: 13, Quantity: 1}}, codes.InvalidArgument},
+		{"Duplicate Variant", []*pb.ReturnItem{{ProductId: 101, VariantId: 12, Quantity: 1}, {ProductId: 101, VariantId: 12, Quantity: 1}}, codes.InvalidArgument},
+		{"Too Many Of Variant", []*pb.ReturnItem{{ProductId: 101, VariantId: 11, Quantity: 2}}, codes.FailedPrecondition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newFixture(t)
+			_, err := f.svc.CreateReturn(contextWithAuth("1", "user"), &pb.CreateReturnRequest{OrderId: 1, Items: tt.items, Reason: "damaged"})
+			if status.Code(err) != tt.expectedCode {
+				t.Errorf("expected %v, got %v (%v)", tt.expectedCode, status.Code(err), err)
+			}
+		})
+	}
+}
+
+func TestReturnWorkflow(t *testing.T) {
+	f := newCompletedOrderFixture(t)
+	user := contextWithAuth("1", "user")
+	admin := contextWithAuth("99", "admin")
+
+	created, err := f.svc.CreateReturn(user, &pb.CreateReturnRequest{
+		OrderId: 1,
+		Items:   []*pb.ReturnItem{{ProductId: 101, Quantity: 2}},
+		Reason:  "defective",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	returnID := created.GetOrderReturn().Id
+
+	_, err = f.svc.ApproveReturn(user, &pb.ApproveReturnRequest{ReturnId: returnID})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected %v, got %v", codes.PermissionDenied, status.Code(err))
+	}
+
+	// Деньги не возвращаются, пока товар не получен
+	_, err = f.svc.RefundReturn(admin, &pb.RefundReturnRequest{ReturnId: returnID})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected %v, got %v", codes.FailedPrecondition, status.Code(err))
+	}
+
+	if _, err := f.svc.ApproveReturn(admin, &pb.ApproveReturnRequest{ReturnId: returnID}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = f.svc.RejectReturn(admin, &pb.RejectReturnRequest{ReturnId: returnID, Reason: "late"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected %v, got %v", codes.FailedPrecondition, status.Code(err))
+	}
+
+	restoredBefore := f.restored
+	if _, err := f.svc.ReceiveReturn(admin, &pb.ReceiveReturnRequest{ReturnId: returnID}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f.restored-restoredBefore != int32(2) {
+		t.Errorf("expected %v, got %v", int32(2), f.restored-restoredBefore)
+	}
+
+	refunded, err := f.svc.RefundReturn(admin, &pb.RefundReturnRequest{ReturnId: returnID})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ret := refunded.GetOrderReturn()
+	if ret.Status != model.ReturnRefunded {
+		t.Errorf("expected %v, got %v", model.ReturnRefunded, ret.Status)
+	}
+	if ret.PaymentId != int64(1) {
+		t.Errorf("expected %v, got %v", int64(1), ret.PaymentId)
+	}
+	if f.order.Status != model.OrderPartiallyRefunded {
+		t.Errorf("expected %v, got %v", model.OrderPartiallyRefunded, f.order.Status)
+	}
+	if f.order.RefundedMinor != int64(1934) {
+		t.Errorf("expected %v, got %v", int64(1934), f.order.RefundedMinor)
+	}
+	if f.payments.payments[0].RefundedMinor != int64(1934) {
+		t.Errorf("expected %v, got %v", int64(1934), f.payments.payments[0].RefundedMinor)
+	}
+
+	_, err = f.svc.RefundReturn(admin, &pb.RefundReturnRequest{ReturnId: returnID})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("expected %v, got %v", codes.FailedPrecondition, status.Code(err))
+	}
+
+	// Последняя единица закрывает заказ целиком
+	last, err := f.svc.CreateReturn(user, &pb.CreateReturnRequest{
+		OrderId: 1,
+		Items:   []*pb.ReturnItem{{ProductId: 101, Quantity: 1}},
+		Reason:  "changed_mind",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lastID := last.GetOrderReturn().Id
+	for _, step := range []func() error{
+		func() error {
+			_, err := f.svc.ApproveReturn(admin, &pb.ApproveReturnRequest{ReturnId: lastID})
+			return err
+		},
+		func() error {
+			_, err := f.svc.ReceiveReturn(admin, &pb.ReceiveReturnRequest{ReturnId: lastID})
+			return err
+		},
+		func() error {
+			_, err := f.svc.RefundReturn(admin, &pb.RefundReturnRequest{ReturnId: lastID})
+			return err
+		},
+	} {
+		if err := step(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if f.order.Status != model.OrderRefunded {
+		t.Errorf("expected %v, got %v", model.OrderRefunded, f.order.Status)
+	}
+	if f.payments.payments[0].Status != payment.StatusRefunded {
+		t.Errorf("expected %v, got %v", payment.StatusRefunded, f.payments.payments[0].Status)
+	}
+}
+
+func TestRefundReturnLosesRaceForCapturedAmount(t *testing.T) {
+	f := newCompletedOrderFixture(t)
+	admin := contextWithAuth("99", "admin")
+
+	created, err := f.svc.CreateReturn(contextWithAuth("1", "user"), &pb.CreateReturnRequest{
+		OrderId: 1,
+		Items:   []*pb.ReturnItem{{ProductId: 101, Quantity: 1}},
+		Reason:  "defective",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	returnID := created.GetOrderReturn().Id
+	if _, err := f.svc.ApproveReturn(admin, &pb.ApproveReturnRequest{ReturnId: returnID}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := f.svc.ReceiveReturn(admin, &pb.ReceiveReturnRequest{ReturnId: returnID}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Пока возврат проверял платеж, параллельный RefundPayment вернул все списанное
+	f.payments.beforeReserveRefund = func() {
+		f.payments.payments[0].RefundedMinor = f.payments.payments[0].CapturedMinor
+	}
+
+	_, err = f.svc.RefundReturn(admin, &pb.RefundReturnRequest{ReturnId: returnID})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected %v, got %v", codes.InvalidArgument, status.Code(err))
+	}
+	if f.returns.returns[0].Status != model.ReturnReceived {
+		t.Errorf("expected %v, got %v", model.ReturnReceived, f.returns.returns[0].Status)
+	}
+	if f.payments.payments[0].RefundedMinor != f.payments.payments[0].CapturedMinor {
+		t.Errorf("expected %v, got %v", f.payments.payments[0].CapturedMinor, f.payments.payments[0].RefundedMinor)
+	}
+	if f.order.RefundedMinor != int64(0) {
+		t.Errorf("expected %v, got %v", int64(0), f.order.RefundedMinor)
+	}
+}
+
+func TestRefundReturnRetriesOnOrderVersionConflict(t *testing.T) {
+	f := newCompletedOrderFixture(t)
+	admin := contextWithAuth("99", "admin")
+
+	created, err := f.svc.CreateReturn(contextWithAuth("1", "user"), &pb.CreateReturnRequest{
+		OrderId: 1,
+		Items:   []*pb.ReturnItem{{ProductId: 101, Quantity: 1}},
+		Reason:  "defective",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	returnID := created.GetOrderReturn().Id
+	if _, err := f.svc.ApproveReturn(admin, &pb.ApproveReturnRequest{ReturnId: returnID}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := f.svc.ReceiveReturn(admin, &pb.ReceiveReturnRequest{ReturnId: returnID}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Заказ изменили после того, как возврат его прочитал
+	f.payments.beforeReserveRefund = func() {
+		f.order.Version++
+	}
+	version := f.order.Version
+
+	if _, err := f.svc.RefundReturn(admin, &pb.RefundReturnRequest{ReturnId: returnID}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if f.order.Status != model.OrderPartiallyRefunded {
+		t.Errorf("expected %v, got %v", model.OrderPartiallyRefunded, f.order.Status)
+	}
+	if f.order.RefundedMinor != int64(967) {
+		t.Errorf("expected %v, got %v", int64(967), f.order.RefundedMinor)
+	}
+	if f.order.Version != version+2 {
+		t.Errorf("expected %v, got %v", version+2, f.order.Version)
+	}
+	if f.returns.returns[0].Status != model.ReturnRefunded {
+		t.Errorf("expected %v, got %v", model.ReturnRefunded, f.returns.returns[0].Status)
+	}
+}
+
+func TestListReturnsScopesCustomers(t *testing.T) {
+	f := newCompletedOrderFixture(t)
+	f.returns.returns = []model.OrderReturn{
+		{ID: 1, OrderID: 1, UserID: 1, Status: model.ReturnRequested},
+		{ID: 2, OrderID: 5, UserID: 2, Status: model.ReturnRequested},
+	}
+
+	resp, err := f.svc.ListReturns(contextWithAuth("1", "user"), &pb.ListReturnsRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Returns) != 1 || resp.Returns[0].UserId != 1 {
+		t.Errorf("expected only own returns, got %v", resp.Returns)
+	}
+
+	_, err = f.svc.ListReturns(contextWithAuth("1", "user"), &pb.ListReturnsRequest{UserId: 2})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected %v, got %v", codes.PermissionDenied, status.Code(err))
+	}
+
+	resp, err = f.svc.ListReturns(contextWithAuth("99", "admin"), &pb.ListReturnsRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.TotalCount != int32(2) {
+		t.Errorf("expected %v, got %v", int32(2), resp.TotalCount)
+	}
+}
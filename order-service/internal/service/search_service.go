@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	pb "order-service/api/order/v1"
+	"order-service/internal/model"
+	"order-service/internal/repository"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// searchCursor is the content of a SearchOrders cursor. The sort is stored in the cursor
+// so that a cursor of one sort is not applied to another
+type searchCursor struct {
+	SortBy    string `json:"s"`
+	Ascending bool   `json:"a,omitempty"`
+	repository.OrderCursor
+}
+
+// SearchOrders searches the orders of all users; admins only
+func (s *OrderServiceImpl) SearchOrders(ctx context.Context, req *pb.SearchOrdersRequest) (*pb.SearchOrdersResponse, error) {
+	if err := s.requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	filter, err := searchFilterFromProto(req)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: %v", err)
+	}
+
+	pageSize := int(req.PageSize)
+	if pageSize < 1 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	if req.Cursor != "" {
+		cursor, err := decodeSearchCursor(req.Cursor)
+		if err != nil || cursor.SortBy != filter.SortBy || cursor.Ascending != filter.Ascending {
+			return nil, status.Errorf(codes.InvalidArgument, "INVALID_CURSOR: Cursor does not match the search")
+		}
+		filter.After = &cursor.OrderCursor
+	}
+
+	// The extra order tells whether there is a next page
+	orders, err := s.repo.SearchOrders(ctx, filter, pageSize+1)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "DATABASE_ERROR: Failed to search orders: %v", err)
+	}
+
+	resp := &pb.SearchOrdersResponse{}
+	if len(orders) > pageSize {
+		orders = orders[:pageSize]
+		last := orders[pageSize-1]
+		cursor := searchCursor{SortBy: filter.SortBy, Ascending: filter.Ascending, OrderCursor: repository.OrderCursor{ID: last.ID}}
+		if filter.SortBy == repository.OrderSortTotal {
+			cursor.Total = last.TotalAmountMinor
+		} else {
+			cursor.CreatedAt = last.CreatedAt
+		}
+		resp.NextCursor = encodeSearchCursor(cursor)
+	}
+
+	resp.Orders = make([]*pb.Order, len(orders))
+	for i := range orders {
+		resp.Orders[i] = s.orderToProto(&orders[i])
+	}
+	return resp, nil
+}
+
+func searchFilterFromProto(req *pb.SearchOrdersRequest) (repository.OrderSearchFilter, error) {
+	filter := repository.OrderSearchFilter{
+		UserID:    req.UserId,
+		ProductID: req.ProductId,
+		MinTotal:  req.MinTotalMinor,
+		MaxTotal:  req.MaxTotalMinor,
+		Query:     strings.TrimSpace(req.Query),
+	}
+
+	for _, st := range req.Statuses {
+		if !searchableStatuses[st] {
+			return filter, fmt.Errorf("unknown status '%s'", st)
+		}
+		filter.Statuses = append(filter.Statuses, st)
+	}
+
+	if filter.MinTotal != nil && filter.MaxTotal != nil && *filter.MinTotal > *filter.MaxTotal {
+		return filter, fmt.Errorf("min_total_minor is greater than max_total_minor")
+	}
+	if req.FromDate != nil {
+		from := req.FromDate.AsTime()
+		filter.From = &from
+	}
+	if req.ToDate != nil {
+		to := req.ToDate.AsTime()
+		filter.To = &to
+	}
+	if filter.From != nil && filter.To != nil && filter.From.After(*filter.To) {
+		return filter, fmt.Errorf("from_date is after to_date")
+	}
+	if len([]rune(filter.Query)) > 100 {
+		return filter, fmt.Errorf("query is longer than 100 characters")
+	}
+
+	switch req.SortBy {
+	case "", repository.OrderSortCreatedAt:
+		filter.SortBy = repository.OrderSortCreatedAt
+	case repository.OrderSortTotal:
+		filter.SortBy = repository.OrderSortTotal
+	default:
+		return filter, fmt.Errorf("unknown sort_by '%s', expected created_at or total", req.SortBy)
+	}
+
+	switch strings.ToLower(req.SortOrder) {
+	case "", "desc":
+	case "asc":
+		filter.Ascending = true
+	default:
+		return filter, fmt.Errorf("unknown sort_order '%s', expected asc or desc", req.SortOrder)
+	}
+
+	return filter, nil
+}
+
+// searchableStatuses are the statuses that can be searched for, including the statuses after a refund
+var searchableStatuses = map[string]bool{
+	"pending": true, "confirmed": true, "processing": true, "completed": true, "cancelled": true,
+	model.OrderPartiallyRefunded: true, model.OrderRefunded: true,
+}
+
+func encodeSearchCursor(c searchCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeSearchCursor(s string) (searchCursor, error) {
+	var c searchCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, err
+	}
+	err = json.Unmarshal(data, &c)
+	return c, err
+}
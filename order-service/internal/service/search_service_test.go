@@ -0,0 +1,114 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "order-service/api/order/v1"
+	"order-service/internal/model"
+	"order-service/internal/repository"
+	"order-service/internal/service"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newSearchService(search func(ctx context.Context, filter repository.OrderSearchFilter, limit int) ([]model.Order, error)) service.OrderService {
+	repo := &mockOrderRepository{searchOrdersFunc: search}
+	return service.NewOrderService(repo, &mockPromotionRepository{}, &mockPaymentRepository{}, &mockCompensationRepository{}, &mockReturnRepository{}, &mockInvoiceRepository{}, nil, &mockTemplateRepository{}, nil, nil, nil)
+}
+
+func TestSearchOrders_CursorPagination(t *testing.T) {
+	base := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	all := make([]model.Order, 5)
+	for i := range all {
+		// Newest orders first, as with created_at DESC
+		all[i] = model.Order{ID: int64(5 - i), UserID: 1, Status: "completed", CreatedAt: base.Add(-time.Duration(i) * time.Hour)}
+	}
+
+	var filters []repository.OrderSearchFilter
+	s := newSearchService(func(ctx context.Context, filter repository.OrderSearchFilter, limit int) ([]model.Order, error) {
+		filters = append(filters, filter)
+		rest := all
+		if filter.After != nil {
+			for i, o := range all {
+				if o.ID == filter.After.ID {
+					rest = all[i+1:]
+				}
+			}
+		}
+		return rest[:min(limit, len(rest))], nil
+	})
+	ctx := contextWithAuth("99", "admin")
+
+	first, err := s.SearchOrders(ctx, &pb.SearchOrdersRequest{Statuses: []string{"completed"}, PageSize: 2})
+	if err != nil {
+		t.Fatalf("SearchOrders: %v", err)
+	}
+	if len(first.Orders) != 2 || first.NextCursor == "" {
+		t.Fatalf("expected 2 orders and a cursor, got %d, %q", len(first.Orders), first.NextCursor)
+	}
+
+	var ids []int64
+	cursor := first.NextCursor
+	for _, o := range first.Orders {
+		ids = append(ids, o.Id)
+	}
+	for cursor != "" {
+		page, err := s.SearchOrders(ctx, &pb.SearchOrdersRequest{Statuses: []string{"completed"}, PageSize: 2, Cursor: cursor})
+		if err != nil {
+			t.Fatalf("SearchOrders page: %v", err)
+		}
+		for _, o := range page.Orders {
+			ids = append(ids, o.Id)
+		}
+		cursor = page.NextCursor
+	}
+
+	if len(ids) != 5 || ids[0] != 5 || ids[4] != 1 {
+		t.Errorf("expected orders 5..1 across pages, got %v", ids)
+	}
+	if after := filters[1].After; after == nil || after.ID != 4 || !after.CreatedAt.Equal(base.Add(-time.Hour)) {
+		t.Errorf("unexpected cursor of the second page: %+v", after)
+	}
+	if filters[0].SortBy != repository.OrderSortCreatedAt || filters[0].Ascending {
+		t.Errorf("expected created_at DESC by default, got %+v", filters[0])
+	}
+}
+
+func TestSearchOrders_Validation(t *testing.T) {
+	s := newSearchService(func(ctx context.Context, filter repository.OrderSearchFilter, limit int) ([]model.Order, error) {
+		return []model.Order{{ID: 1, TotalAmountMinor: 500}, {ID: 2, TotalAmountMinor: 400}}, nil
+	})
+	minTotal, maxTotal := int64(500), int64(100)
+
+	byTotal, err := s.SearchOrders(contextWithAuth("99", "admin"), &pb.SearchOrdersRequest{SortBy: "total", PageSize: 1})
+	if err != nil {
+		t.Fatalf("SearchOrders: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		req          *pb.SearchOrdersRequest
+		expectedCode codes.Code
+	}{
+		{name: "Not Admin", ctx: contextWithAuth("1", "user"), req: &pb.SearchOrdersRequest{}, expectedCode: codes.PermissionDenied},
+		{name: "Unknown Status", ctx: contextWithAuth("99", "admin"), req: &pb.SearchOrdersRequest{Statuses: []string{"lost"}}, expectedCode: codes.InvalidArgument},
+		{name: "Inverted Range", ctx: contextWithAuth("99", "admin"), req: &pb.SearchOrdersRequest{MinTotalMinor: &minTotal, MaxTotalMinor: &maxTotal}, expectedCode: codes.InvalidArgument},
+		{name: "Unknown Sort", ctx: contextWithAuth("99", "admin"), req: &pb.SearchOrdersRequest{SortBy: "user_id"}, expectedCode: codes.InvalidArgument},
+		{name: "Garbage Cursor", ctx: contextWithAuth("99", "admin"), req: &pb.SearchOrdersRequest{Cursor: "%%%"}, expectedCode: codes.InvalidArgument},
+		{name: "Cursor Of Another Sort", ctx: contextWithAuth("99", "admin"), req: &pb.SearchOrdersRequest{Cursor: byTotal.NextCursor}, expectedCode: codes.InvalidArgument},
+		{name: "Same Sort Cursor", ctx: contextWithAuth("99", "admin"), req: &pb.SearchOrdersRequest{SortBy: "total", Cursor: byTotal.NextCursor}, expectedCode: codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := s.SearchOrders(tt.ctx, tt.req)
+			if status.Code(err) != tt.expectedCode {
+				t.Errorf("expected %v, got %v", tt.expectedCode, err)
+			}
+		})
+	}
+}
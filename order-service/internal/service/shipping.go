@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	pb "order-service/api/order/v1"
+	"order-service/internal/model"
+	userpb "order-service/pkg/api/user/v1"
+	"strings"
+
+	"github.com/microserviceteam0/bff-gateway/shared/address"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type UserClient interface {
+	GetAddress(ctx context.Context, userID, addressID int64) (*userpb.Address, error)
+}
+
+// resolveShipping определяет адрес и способ доставки нового заказа.
+// Адрес из адресной книги копируется в заказ, чтобы последующие правки книги не меняли историю
+func (s *OrderServiceImpl) resolveShipping(ctx context.Context, req *pb.CreateOrderRequest) (model.ShippingAddress, string, error) {
+	method := strings.ToLower(strings.TrimSpace(req.DeliveryMethod))
+	if method == "" {
+		method = address.DefaultDeliveryMethod
+	}
+	if !address.ValidDeliveryMethod(method) {
+		return model.ShippingAddress{}, "", status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: Unknown delivery method %q", req.DeliveryMethod)
+	}
+
+	var (
+		addr      address.Address
+		addressID int64
+	)
+	switch shipping := req.Shipping.(type) {
+	case *pb.CreateOrderRequest_AddressId:
+		book, err := s.userClient.GetAddress(ctx, req.UserId, shipping.AddressId)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return model.ShippingAddress{}, "", status.Errorf(codes.NotFound, "ADDRESS_NOT_FOUND: Address %d not found", shipping.AddressId)
+			}
+			return model.ShippingAddress{}, "", status.Errorf(codes.Internal, "USER_SERVICE_ERROR: Failed to fetch address: %v", err)
+		}
+		addr = address.Address{
+			RecipientName: book.GetRecipientName(),
+			Phone:         book.GetPhone(),
+			Country:       book.GetCountry(),
+			Region:        book.GetRegion(),
+			City:          book.GetCity(),
+			PostalCode:    book.GetPostalCode(),
+			Line1:         book.GetLine1(),
+			Line2:         book.GetLine2(),
+		}
+		addressID = shipping.AddressId
+	case *pb.CreateOrderRequest_Address:
+		addr = address.Address{
+			RecipientName: shipping.Address.GetRecipientName(),
+			Phone:         shipping.Address.GetPhone(),
+			Country:       shipping.Address.GetCountry(),
+			Region:        shipping.Address.GetRegion(),
+			City:          shipping.Address.GetCity(),
+			PostalCode:    shipping.Address.GetPostalCode(),
+			Line1:         shipping.Address.GetLine1(),
+			Line2:         shipping.Address.GetLine2(),
+		}
+	default:
+		if method == address.DeliveryPickup {
+			return model.ShippingAddress{}, method, nil
+		}
+		return model.ShippingAddress{}, "", status.Errorf(codes.InvalidArgument, "INVALID_REQUEST: Shipping address is required for %s delivery", method)
+	}
+
+	addr = address.Normalize(addr)
+	if err := address.Validate(addr); err != nil {
+		return model.ShippingAddress{}, "", status.Errorf(codes.InvalidArgument, "INVALID_ADDRESS: %v", err)
+	}
+
+	return model.ShippingAddress{
+		AddressID:     addressID,
+		RecipientName: addr.RecipientName,
+		Phone:         addr.Phone,
+		Country:       addr.Country,
+		Region:        addr.Region,
+		City:          addr.City,
+		PostalCode:    addr.PostalCode,
+		Line1:         addr.Line1,
+		Line2:         addr.Line2,
+	}, method, nil
+}
+
+func shippingAddressToProto(a model.ShippingAddress) *pb.ShippingAddress {
+	if a.IsZero() {
+		return nil
+	}
+	return &pb.ShippingAddress{
+		AddressId:     a.AddressID,
+		RecipientName: a.RecipientName,
+		Phone:         a.Phone,
+		Country:       a.Country,
+		Region:        a.Region,
+		City:          a.City,
+		PostalCode:    a.PostalCode,
+		Line1:         a.Line1,
+		Line2:         a.Line2,
+	}
+}
@@ -0,0 +1,144 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	pb "order-service/api/order/v1"
+	"order-service/internal/model"
+	"order-service/internal/service"
+
+	userpb "order-service/pkg/api/user/v1"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockUserClient struct {
+	getAddressFunc func(ctx context.Context, userID, addressID int64) (*userpb.Address, error)
+}
+
+func (m *mockUserClient) GetAddress(ctx context.Context, userID, addressID int64) (*userpb.Address, error) {
+	if m.getAddressFunc != nil {
+		return m.getAddressFunc(ctx, userID, addressID)
+	}
+	return nil, status.Error(codes.NotFound, "address not found")
+}
+
+func TestCreateOrderShipping(t *testing.T) {
+	ctx := contextWithAuth("1", "user")
+	bookAddress := func(ctx context.Context, userID, addressID int64) (*userpb.Address, error) {
+		if userID != 1 || addressID != 7 {
+			return nil, status.Error(codes.NotFound, "address not found")
+		}
+		return &userpb.Address{Id: 7, UserId: 1, RecipientName: "John Smith", Country: "US", Region: "NY", City: "New York", PostalCode: "10001", Line1: "5th Ave 1"}, nil
+	}
+
+	tests := []struct {
+		name             string
+		shipping         any
+		deliveryMethod   string
+		expectedCode     codes.Code
+		expectedMsg      string
+		expectedAddress  model.ShippingAddress
+		expectedDelivery string
+	}{
+		{
+			name:             "Address From Book",
+			shipping:         &pb.CreateOrderRequest_AddressId{AddressId: 7},
+			deliveryMethod:   "Post",
+			expectedCode:     codes.OK,
+			expectedAddress:  model.ShippingAddress{AddressID: 7, RecipientName: "John Smith", Country: "US", Region: "NY", City: "New York", PostalCode: "10001", Line1: "5th Ave 1"},
+			expectedDelivery: "post",
+		},
+		{
+			name:             "Inline Address Normalized",
+			shipping:         testShipping(),
+			expectedCode:     codes.OK,
+			expectedAddress:  model.ShippingAddress{RecipientName: "Ivan Petrov", Country: "RU", City: "Moscow", PostalCode: "101000", Line1: "Tverskaya 1"},
+			expectedDelivery: "courier",
+		},
+		{
+			name:             "Pickup Without Address",
+			deliveryMethod:   "pickup",
+			expectedCode:     codes.OK,
+			expectedDelivery: "pickup",
+		},
+		{
+			name:         "Address Of Another User",
+			shipping:     &pb.CreateOrderRequest_AddressId{AddressId: 8},
+			expectedCode: codes.NotFound,
+			expectedMsg:  "ADDRESS_NOT_FOUND: Address 8 not found",
+		},
+		{
+			name:         "Missing Address For Courier",
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "INVALID_REQUEST: Shipping address is required for courier delivery",
+		},
+		{
+			name:           "Unknown Delivery Method",
+			shipping:       testShipping(),
+			deliveryMethod: "drone",
+			expectedCode:   codes.InvalidArgument,
+			expectedMsg:    `INVALID_REQUEST: Unknown delivery method "drone"`,
+		},
+		{
+			name: "Invalid Postal Code",
+			shipping: &pb.CreateOrderRequest_Address{Address: &pb.ShippingAddress{
+				RecipientName: "Ivan Petrov", Country: "RU", City: "Moscow", PostalCode: "1010", Line1: "Tverskaya 1",
+			}},
+			expectedCode: codes.InvalidArgument,
+			expectedMsg:  "INVALID_ADDRESS: postal_code: must look like 6 digits for RU",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var created *model.Order
+			mockRepo := &mockOrderRepository{
+				createOrderFunc: func(ctx context.Context, order *model.Order) (*model.Order, error) {
+					order.ID = 1
+					created = order
+					return order, nil
+				},
+			}
+			mockProd := &mockProductClient{getProductsFunc: promoProducts}
+			s := service.NewOrderService(mockRepo, &mockPromotionRepository{}, &mockPaymentRepository{}, &mockCompensationRepository{}, &mockReturnRepository{}, &mockInvoiceRepository{}, nil, &mockTemplateRepository{}, mockProd, &mockUserClient{getAddressFunc: bookAddress}, nil)
+
+			req := &pb.CreateOrderRequest{
+				UserId:         1,
+				Items:          []*pb.OrderItem{{ProductId: 101, Quantity: 1}},
+				DeliveryMethod: tt.deliveryMethod,
+			}
+			switch shipping := tt.shipping.(type) {
+			case *pb.CreateOrderRequest_AddressId:
+				req.Shipping = shipping
+			case *pb.CreateOrderRequest_Address:
+				req.Shipping = shipping
+			}
+
+			_, err := s.CreateOrder(ctx, req)
+
+			if tt.expectedCode != codes.OK {
+				st, _ := status.FromError(err)
+				if st.Code() != tt.expectedCode {
+					t.Errorf("Expected status code %v, got %v", tt.expectedCode, st.Code())
+				}
+				if st.Message() != tt.expectedMsg {
+					t.Errorf("Expected error message '%s', got '%s'", tt.expectedMsg, st.Message())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if created.ShippingAddress != tt.expectedAddress {
+				t.Errorf("Expected address %+v, got %+v", tt.expectedAddress, created.ShippingAddress)
+			}
+			if created.DeliveryMethod != tt.expectedDelivery {
+				t.Errorf("Expected delivery method %s, got %s", tt.expectedDelivery, created.DeliveryMethod)
+			}
+		})
+	}
+}
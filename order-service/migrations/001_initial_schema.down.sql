@@ -0,0 +1,10 @@
+-- migrations/001_initial_schema.down.sql
+
+DROP TABLE IF EXISTS stock_compensation_dead_letters;
+DROP TABLE IF EXISTS stock_compensations;
+DROP TABLE IF EXISTS payment_events;
+DROP TABLE IF EXISTS payments;
+DROP TABLE IF EXISTS order_promotions;
+DROP TABLE IF EXISTS promotions;
+DROP TABLE IF EXISTS order_items;
+DROP TABLE IF EXISTS orders;
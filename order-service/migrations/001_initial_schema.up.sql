@@ -0,0 +1,172 @@
+-- migrations/001_initial_schema.up.sql
+
+-- Schema previously created by gorm AutoMigrate. IF NOT EXISTS and gorm's index names
+-- let databases created by AutoMigrate adopt this migration without changes.
+
+CREATE TABLE IF NOT EXISTS orders (
+    id                      BIGSERIAL PRIMARY KEY,
+    user_id                 BIGINT         NOT NULL,
+    status                  VARCHAR(50)    NOT NULL DEFAULT 'created',
+    cancellation_reason     VARCHAR(255),
+    total_amount            DECIMAL(15, 2) NOT NULL,
+    total_amount_minor      BIGINT         NOT NULL DEFAULT 0,
+    subtotal_minor          BIGINT         NOT NULL DEFAULT 0,
+    discount_minor          BIGINT         NOT NULL DEFAULT 0,
+    currency                CHAR(3)        NOT NULL DEFAULT 'RUB',
+    shipping_address_id     BIGINT         NOT NULL DEFAULT 0,
+    shipping_recipient_name VARCHAR(255),
+    shipping_phone          VARCHAR(32),
+    shipping_country        CHAR(2),
+    shipping_region         VARCHAR(100),
+    shipping_city           VARCHAR(100),
+    shipping_postal_code    VARCHAR(20),
+    shipping_line1          VARCHAR(255),
+    shipping_line2          VARCHAR(255),
+    delivery_method         VARCHAR(20)    NOT NULL DEFAULT '',
+    created_at              TIMESTAMPTZ,
+    updated_at              TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_orders_user_id ON orders (user_id);
+
+CREATE TABLE IF NOT EXISTS order_items (
+    id             BIGSERIAL PRIMARY KEY,
+    order_id       BIGINT         NOT NULL,
+    product_id     BIGINT         NOT NULL,
+    product_name   TEXT           NOT NULL,
+    price          DECIMAL(15, 2) NOT NULL,
+    price_minor    BIGINT         NOT NULL DEFAULT 0,
+    currency       CHAR(3)        NOT NULL DEFAULT 'RUB',
+    quantity       INTEGER        NOT NULL,
+    discount_minor BIGINT         NOT NULL DEFAULT 0,
+    CONSTRAINT fk_orders_items FOREIGN KEY (order_id) REFERENCES orders (id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_items_order_id ON order_items (order_id);
+
+CREATE TABLE IF NOT EXISTS promotions (
+    id               BIGSERIAL PRIMARY KEY,
+    code             VARCHAR(64),
+    name             VARCHAR(255) NOT NULL,
+    type             VARCHAR(32)  NOT NULL,
+    percent_off      INTEGER      NOT NULL DEFAULT 0,
+    amount_off_minor BIGINT       NOT NULL DEFAULT 0,
+    currency         CHAR(3)      NOT NULL DEFAULT 'RUB',
+    buy_quantity     INTEGER      NOT NULL DEFAULT 0,
+    get_quantity     INTEGER      NOT NULL DEFAULT 0,
+    product_id       BIGINT       NOT NULL DEFAULT 0,
+    min_basket_minor BIGINT       NOT NULL DEFAULT 0,
+    per_user_limit   INTEGER      NOT NULL DEFAULT 0,
+    starts_at        TIMESTAMPTZ,
+    ends_at          TIMESTAMPTZ,
+    active           BOOLEAN      NOT NULL DEFAULT TRUE,
+    created_at       TIMESTAMPTZ,
+    updated_at       TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_promotions_code ON promotions (code);
+CREATE INDEX IF NOT EXISTS idx_promotions_active ON promotions (active);
+
+CREATE TABLE IF NOT EXISTS order_promotions (
+    id             BIGSERIAL PRIMARY KEY,
+    order_id       BIGINT  NOT NULL,
+    promotion_id   BIGINT  NOT NULL,
+    code           VARCHAR(64),
+    name           TEXT    NOT NULL,
+    discount_minor BIGINT  NOT NULL,
+    currency       CHAR(3) NOT NULL DEFAULT 'RUB',
+    CONSTRAINT fk_orders_promotions FOREIGN KEY (order_id) REFERENCES orders (id) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_promotions_order_id ON order_promotions (order_id);
+CREATE INDEX IF NOT EXISTS idx_order_promotions_promotion_id ON order_promotions (promotion_id);
+
+CREATE TABLE IF NOT EXISTS payments (
+    id             BIGSERIAL PRIMARY KEY,
+    order_id       BIGINT       NOT NULL,
+    provider       VARCHAR(50)  NOT NULL,
+    provider_ref   VARCHAR(255) NOT NULL,
+    status         VARCHAR(30)  NOT NULL,
+    amount_minor   BIGINT       NOT NULL,
+    captured_minor BIGINT       NOT NULL DEFAULT 0,
+    refunded_minor BIGINT       NOT NULL DEFAULT 0,
+    currency       CHAR(3)      NOT NULL,
+    failure_reason VARCHAR(255),
+    created_at     TIMESTAMPTZ,
+    updated_at     TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_payments_order_id ON payments (order_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_provider_ref ON payments (provider_ref);
+
+CREATE TABLE IF NOT EXISTS payment_events (
+    id         BIGSERIAL PRIMARY KEY,
+    event_id   VARCHAR(255) NOT NULL,
+    payment_id BIGINT       NOT NULL,
+    type       VARCHAR(50)  NOT NULL,
+    created_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payment_events_event_id ON payment_events (event_id);
+CREATE INDEX IF NOT EXISTS idx_payment_events_payment_id ON payment_events (payment_id);
+
+CREATE TABLE IF NOT EXISTS stock_compensations (
+    id              BIGSERIAL PRIMARY KEY,
+    order_id        BIGINT,
+    product_id      BIGINT      NOT NULL,
+    quantity_delta  INTEGER     NOT NULL,
+    reason          VARCHAR(50) NOT NULL,
+    attempts        INTEGER     NOT NULL DEFAULT 0,
+    last_error      TEXT,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    created_at      TIMESTAMPTZ,
+    updated_at      TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_compensations_order_id ON stock_compensations (order_id);
+CREATE INDEX IF NOT EXISTS idx_stock_compensations_next_attempt_at ON stock_compensations (next_attempt_at);
+
+CREATE TABLE IF NOT EXISTS stock_compensation_dead_letters (
+    id             BIGSERIAL PRIMARY KEY,
+    order_id       BIGINT,
+    product_id     BIGINT      NOT NULL,
+    quantity_delta INTEGER     NOT NULL,
+    reason         VARCHAR(50) NOT NULL,
+    attempts       INTEGER     NOT NULL,
+    last_error     TEXT,
+    created_at     TIMESTAMPTZ NOT NULL,
+    dead_at        TIMESTAMPTZ NOT NULL,
+    resolved_at    TIMESTAMPTZ,
+    resolved_by    BIGINT,
+    resolution     VARCHAR(255)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_compensation_dead_letters_order_id ON stock_compensation_dead_letters (order_id);
+CREATE INDEX IF NOT EXISTS idx_stock_compensation_dead_letters_resolved_at ON stock_compensation_dead_letters (resolved_at);
+
+-- Databases created by the original AutoMigrate have orders and order_items with only the first columns,
+-- and CREATE TABLE IF NOT EXISTS keeps them as they are. Columns added since then are added here.
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS cancellation_reason VARCHAR(255);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS total_amount_minor BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS subtotal_minor BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS discount_minor BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'RUB';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_address_id BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_recipient_name VARCHAR(255);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_phone VARCHAR(32);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_country CHAR(2);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_region VARCHAR(100);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_city VARCHAR(100);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_postal_code VARCHAR(20);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_line1 VARCHAR(255);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_line2 VARCHAR(255);
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS delivery_method VARCHAR(20) NOT NULL DEFAULT '';
+
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS price_minor BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS currency CHAR(3) NOT NULL DEFAULT 'RUB';
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS discount_minor BIGINT NOT NULL DEFAULT 0;
+
+-- Legacy decimal amounts are converted to minor units; orders created before promotions have no discount.
+UPDATE orders SET total_amount_minor = ROUND(total_amount * 100) WHERE total_amount_minor = 0 AND total_amount <> 0;
+UPDATE order_items SET price_minor = ROUND(price * 100) WHERE price_minor = 0 AND price <> 0;
+UPDATE orders SET subtotal_minor = total_amount_minor WHERE subtotal_minor = 0 AND discount_minor = 0;
@@ -0,0 +1,3 @@
+-- migrations/002_add_order_version.down.sql
+
+ALTER TABLE orders DROP COLUMN IF EXISTS version;
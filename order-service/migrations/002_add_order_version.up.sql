@@ -0,0 +1,4 @@
+-- migrations/002_add_order_version.up.sql
+
+-- Version for optimistic concurrency control: every update bumps it and checks the previous value.
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
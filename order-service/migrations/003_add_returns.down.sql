@@ -0,0 +1,5 @@
+-- migrations/003_add_returns.down.sql
+
+ALTER TABLE orders DROP COLUMN IF EXISTS refunded_minor;
+DROP TABLE IF EXISTS order_return_items;
+DROP TABLE IF EXISTS order_returns;
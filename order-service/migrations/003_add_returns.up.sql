@@ -0,0 +1,38 @@
+-- migrations/003_add_returns.up.sql
+
+-- Returns (RMA): a request per order with the returned lines; the refund is calculated when it is created.
+CREATE TABLE IF NOT EXISTS order_returns (
+    id               BIGSERIAL PRIMARY KEY,
+    order_id         BIGINT       NOT NULL REFERENCES orders (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    user_id          BIGINT       NOT NULL,
+    status           VARCHAR(20)  NOT NULL,
+    reason           VARCHAR(30)  NOT NULL,
+    comment          VARCHAR(1000),
+    refund_minor     BIGINT       NOT NULL DEFAULT 0,
+    currency         CHAR(3)      NOT NULL,
+    rejection_reason VARCHAR(255),
+    reviewed_by      BIGINT,
+    reviewed_at      TIMESTAMPTZ,
+    received_at      TIMESTAMPTZ,
+    payment_id       BIGINT,
+    refunded_at      TIMESTAMPTZ,
+    created_at       TIMESTAMPTZ,
+    updated_at       TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_returns_order_id ON order_returns (order_id);
+CREATE INDEX IF NOT EXISTS idx_order_returns_user_id ON order_returns (user_id);
+CREATE INDEX IF NOT EXISTS idx_order_returns_status ON order_returns (status);
+
+CREATE TABLE IF NOT EXISTS order_return_items (
+    id            BIGSERIAL PRIMARY KEY,
+    return_id     BIGINT  NOT NULL REFERENCES order_returns (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    product_id    BIGINT  NOT NULL,
+    quantity      INTEGER NOT NULL,
+    refund_minor  BIGINT  NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_return_items_return_id ON order_return_items (return_id);
+
+-- Money refunded for returned items; the order becomes partially_refunded or refunded.
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS refunded_minor BIGINT NOT NULL DEFAULT 0;
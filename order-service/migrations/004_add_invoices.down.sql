@@ -0,0 +1,6 @@
+-- migrations/004_add_invoices.down.sql
+
+DROP TRIGGER IF EXISTS trg_invoices_immutable ON invoices;
+DROP FUNCTION IF EXISTS invoices_immutable();
+DROP TABLE IF EXISTS invoices;
+DROP TABLE IF EXISTS invoice_number_counters;
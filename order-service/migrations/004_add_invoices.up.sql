@@ -0,0 +1,39 @@
+-- migrations/004_add_invoices.up.sql
+
+-- Invoice numbers run without gaps within a year: the counter row is updated in the issuing transaction.
+CREATE TABLE IF NOT EXISTS invoice_number_counters (
+    year        INTEGER PRIMARY KEY,
+    last_number BIGINT  NOT NULL
+);
+
+-- One invoice per order; the rendered documents are stored so that the issued invoice never changes.
+CREATE TABLE IF NOT EXISTS invoices (
+    id               BIGSERIAL PRIMARY KEY,
+    number           VARCHAR(32)  NOT NULL,
+    order_id         BIGINT       NOT NULL REFERENCES orders (id) ON UPDATE CASCADE ON DELETE RESTRICT,
+    user_id          BIGINT       NOT NULL,
+    currency         CHAR(3)      NOT NULL,
+    subtotal_minor   BIGINT       NOT NULL,
+    discount_minor   BIGINT       NOT NULL,
+    tax_minor        BIGINT       NOT NULL,
+    total_minor      BIGINT       NOT NULL,
+    tax_rate_percent INTEGER      NOT NULL,
+    html             TEXT         NOT NULL,
+    text             TEXT         NOT NULL,
+    issued_at        TIMESTAMPTZ  NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoices_number ON invoices (number);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoices_order_id ON invoices (order_id);
+CREATE INDEX IF NOT EXISTS idx_invoices_user_id ON invoices (user_id);
+
+CREATE OR REPLACE FUNCTION invoices_immutable() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'invoice % is issued and cannot be changed', OLD.number;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_invoices_immutable ON invoices;
+CREATE TRIGGER trg_invoices_immutable
+    BEFORE UPDATE OR DELETE ON invoices
+    FOR EACH ROW EXECUTE FUNCTION invoices_immutable();
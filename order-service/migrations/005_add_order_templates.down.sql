@@ -0,0 +1,4 @@
+-- migrations/005_add_order_templates.down.sql
+
+DROP TABLE IF EXISTS order_template_items;
+DROP TABLE IF EXISTS order_templates;
@@ -0,0 +1,23 @@
+-- migrations/005_add_order_templates.up.sql
+
+-- Named baskets saved by users; prices are not stored and are taken when a template is submitted.
+CREATE TABLE IF NOT EXISTS order_templates (
+    id              BIGSERIAL PRIMARY KEY,
+    user_id         BIGINT       NOT NULL,
+    name            VARCHAR(100) NOT NULL,
+    delivery_method VARCHAR(20)  NOT NULL DEFAULT '',
+    address_id      BIGINT       NOT NULL DEFAULT 0,
+    created_at      TIMESTAMPTZ,
+    updated_at      TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_order_templates_user_name ON order_templates (user_id, name);
+
+CREATE TABLE IF NOT EXISTS order_template_items (
+    id          BIGSERIAL PRIMARY KEY,
+    template_id BIGINT  NOT NULL REFERENCES order_templates (id) ON UPDATE CASCADE ON DELETE CASCADE,
+    product_id  BIGINT  NOT NULL,
+    quantity    INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_template_items_template_id ON order_template_items (template_id);
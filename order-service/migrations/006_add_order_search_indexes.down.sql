@@ -0,0 +1,8 @@
+-- migrations/006_add_order_search_indexes.down.sql
+
+DROP INDEX IF EXISTS idx_order_items_product_name_trgm;
+DROP INDEX IF EXISTS idx_order_items_product_id;
+DROP INDEX IF EXISTS idx_orders_user_id_created_at_id;
+DROP INDEX IF EXISTS idx_orders_status_created_at_id;
+DROP INDEX IF EXISTS idx_orders_total_id;
+DROP INDEX IF EXISTS idx_orders_created_at_id;
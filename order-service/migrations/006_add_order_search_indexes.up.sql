@@ -0,0 +1,13 @@
+-- migrations/006_add_order_search_indexes.up.sql
+
+-- Admin order search: keyset pagination by (created_at, id) or (total_amount_minor, id),
+-- optionally narrowed by status or user.
+CREATE INDEX IF NOT EXISTS idx_orders_created_at_id ON orders (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_orders_total_id ON orders (total_amount_minor, id);
+CREATE INDEX IF NOT EXISTS idx_orders_status_created_at_id ON orders (status, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_orders_user_id_created_at_id ON orders (user_id, created_at, id);
+
+-- Orders containing a product and substring search by product name (ILIKE '%...%').
+CREATE INDEX IF NOT EXISTS idx_order_items_product_id ON order_items (product_id);
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX IF NOT EXISTS idx_order_items_product_name_trgm ON order_items USING GIN (product_name gin_trgm_ops);
@@ -0,0 +1,8 @@
+-- migrations/007_add_order_item_variants.down.sql
+
+ALTER TABLE stock_compensation_dead_letters DROP COLUMN IF EXISTS variant_id;
+ALTER TABLE stock_compensations DROP COLUMN IF EXISTS variant_id;
+ALTER TABLE order_template_items DROP COLUMN IF EXISTS variant_id;
+ALTER TABLE order_return_items DROP COLUMN IF EXISTS variant_id;
+ALTER TABLE order_items DROP COLUMN IF EXISTS variant_sku;
+ALTER TABLE order_items DROP COLUMN IF EXISTS variant_id;
@@ -0,0 +1,12 @@
+-- migrations/007_add_order_item_variants.up.sql
+
+-- Order lines reference a product variant. 0 means the line was created before variants existed:
+-- such products have exactly one variant in Product Service, so stock operations still resolve it.
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS variant_id BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE order_items ADD COLUMN IF NOT EXISTS variant_sku VARCHAR(64) NOT NULL DEFAULT '';
+
+ALTER TABLE order_return_items ADD COLUMN IF NOT EXISTS variant_id BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE order_template_items ADD COLUMN IF NOT EXISTS variant_id BIGINT NOT NULL DEFAULT 0;
+
+ALTER TABLE stock_compensations ADD COLUMN IF NOT EXISTS variant_id BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE stock_compensation_dead_letters ADD COLUMN IF NOT EXISTS variant_id BIGINT NOT NULL DEFAULT 0;
@@ -3,6 +3,7 @@ package product
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	pb "order-service/pkg/api/product/v1"
@@ -16,8 +17,10 @@ type Client struct {
 	Service pb.ProductServiceClient
 }
 
-func NewClient(addr string) (*Client, error) {
-	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// NewClient connects to the service; opts are added to the default options (e.g. interceptors)
+func NewClient(addr string, opts ...grpc.DialOption) (*Client, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	conn, err := grpc.NewClient(addr, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to product service: %w", err)
 	}
@@ -55,22 +58,45 @@ func (c *Client) GetProducts(ctx context.Context, ids []int64) (map[int64]*pb.Pr
 	return productMap, nil
 }
 
-func (c *Client) CheckStock(ctx context.Context, productID int64, quantity int32) (*pb.CheckStockResponse, error) {
+// BatchUpdateStock applies variant stock changes in a single Product Service transaction.
+// The order of items is kept: the caller sorts them so that equal batches produce equal requests
+func (c *Client) BatchUpdateStock(ctx context.Context, items []*pb.StockAdjustment, reason string, orderID int64) (*pb.BatchUpdateStockResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	req := &pb.BatchUpdateStockRequest{Items: items, Reason: reason}
+	if orderID != 0 {
+		req.Reference = strconv.FormatInt(orderID, 10)
+	}
+	return c.Service.BatchUpdateStock(ctx, req)
+}
+
+// CheckStock checks the stock of a variant; variantID 0 means the whole stock of the product
+func (c *Client) CheckStock(ctx context.Context, productID, variantID int64, quantity int32) (*pb.CheckStockResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
 	return c.Service.CheckStock(ctx, &pb.CheckStockRequest{
 		ProductId: productID,
+		VariantId: variantID,
 		Quantity:  quantity,
 	})
 }
 
-func (c *Client) UpdateStock(ctx context.Context, productID int64, quantityDelta int32) (*pb.UpdateStockResponse, error) {
+// UpdateStock changes the stock of a variant (0 for the only variant of the product);
+// reason and orderID (0 if there is no order yet) go to the Product Service stock journal
+func (c *Client) UpdateStock(ctx context.Context, productID, variantID int64, quantityDelta int32, reason string, orderID int64) (*pb.UpdateStockResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
 	defer cancel()
 
-	return c.Service.UpdateStock(ctx, &pb.UpdateStockRequest{
+	req := &pb.UpdateStockRequest{
 		ProductId:     productID,
+		VariantId:     variantID,
 		QuantityDelta: quantityDelta,
-	})
+		Reason:        reason,
+	}
+	if orderID != 0 {
+		req.Reference = strconv.FormatInt(orderID, 10)
+	}
+	return c.Service.UpdateStock(ctx, req)
 }
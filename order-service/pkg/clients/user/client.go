@@ -0,0 +1,51 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	pb "order-service/pkg/api/user/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type Client struct {
+	conn    *grpc.ClientConn
+	Service pb.UserServiceClient
+}
+
+// NewClient подключается к сервису; opts добавляются к настройкам по умолчанию (например, интерсепторы)
+func NewClient(addr string, opts ...grpc.DialOption) (*Client, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	conn, err := grpc.NewClient(addr, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to user service: %w", err)
+	}
+
+	return &Client{
+		conn:    conn,
+		Service: pb.NewUserServiceClient(conn),
+	}, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// GetAddress возвращает адрес из адресной книги; user-service отвечает NotFound и на чужие адреса
+func (c *Client) GetAddress(ctx context.Context, userID, addressID int64) (*pb.Address, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.Service.GetAddress(ctx, &pb.GetAddressRequest{
+		UserId:    userID,
+		AddressId: addressID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.GetAddress(), nil
+}
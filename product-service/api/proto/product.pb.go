@@ -109,28 +109,49 @@ func (x *GetProductsRequest) GetIds() []int64 {
 	return nil
 }
 
-type CheckStockRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	Quantity      int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+type ListProductsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Page number from 1, ignored when cursor is set.
+	Page int32 `protobuf:"varint,1,opt,name=page,proto3" json:"page,omitempty"`
+	// Default 50, at most 200.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_cursor of the previous page; only valid with the same sort.
+	Cursor string `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// created_at (default), price, name or stock.
+	SortBy string `protobuf:"bytes,4,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
+	// asc or desc; default desc for created_at and asc otherwise.
+	SortOrder string `protobuf:"bytes,5,opt,name=sort_order,json=sortOrder,proto3" json:"sort_order,omitempty"`
+	// Price bounds in minor units of currency, inclusive.
+	MinPriceMinor *int64 `protobuf:"varint,6,opt,name=min_price_minor,json=minPriceMinor,proto3,oneof" json:"min_price_minor,omitempty"`
+	MaxPriceMinor *int64 `protobuf:"varint,7,opt,name=max_price_minor,json=maxPriceMinor,proto3,oneof" json:"max_price_minor,omitempty"`
+	InStock       bool   `protobuf:"varint,8,opt,name=in_stock,json=inStock,proto3" json:"in_stock,omitempty"`
+	// RFC 3339 timestamp; only products updated at or after it.
+	UpdatedSince string `protobuf:"bytes,9,opt,name=updated_since,json=updatedSince,proto3" json:"updated_since,omitempty"`
+	// Products of the category and all its subcategories; 0 means any category.
+	CategoryId int64 `protobuf:"varint,10,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	// draft, active or archived; empty lists active products only.
+	Status string `protobuf:"bytes,11,opt,name=status,proto3" json:"status,omitempty"`
+	// ISO 4217 code; only products priced in it. Required with a price bound or sort_by price,
+	// since prices in different currencies are not comparable.
+	Currency      string `protobuf:"bytes,12,opt,name=currency,proto3" json:"currency,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CheckStockRequest) Reset() {
-	*x = CheckStockRequest{}
+func (x *ListProductsRequest) Reset() {
+	*x = ListProductsRequest{}
 	mi := &file_api_proto_product_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CheckStockRequest) String() string {
+func (x *ListProductsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckStockRequest) ProtoMessage() {}
+func (*ListProductsRequest) ProtoMessage() {}
 
-func (x *CheckStockRequest) ProtoReflect() protoreflect.Message {
+func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_product_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -142,47 +163,122 @@ func (x *CheckStockRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckStockRequest.ProtoReflect.Descriptor instead.
-func (*CheckStockRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListProductsRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_product_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *CheckStockRequest) GetProductId() int64 {
+func (x *ListProductsRequest) GetPage() int32 {
 	if x != nil {
-		return x.ProductId
+		return x.Page
 	}
 	return 0
 }
 
-func (x *CheckStockRequest) GetQuantity() int32 {
+func (x *ListProductsRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.Quantity
+		return x.PageSize
 	}
 	return 0
 }
 
-type CheckStockResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Available     bool                   `protobuf:"varint,1,opt,name=available,proto3" json:"available,omitempty"`
-	CurrentStock  int32                  `protobuf:"varint,2,opt,name=current_stock,json=currentStock,proto3" json:"current_stock,omitempty"`
+func (x *ListProductsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetSortBy() string {
+	if x != nil {
+		return x.SortBy
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetSortOrder() string {
+	if x != nil {
+		return x.SortOrder
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetMinPriceMinor() int64 {
+	if x != nil && x.MinPriceMinor != nil {
+		return *x.MinPriceMinor
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetMaxPriceMinor() int64 {
+	if x != nil && x.MaxPriceMinor != nil {
+		return *x.MaxPriceMinor
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetInStock() bool {
+	if x != nil {
+		return x.InStock
+	}
+	return false
+}
+
+func (x *ListProductsRequest) GetUpdatedSince() string {
+	if x != nil {
+		return x.UpdatedSince
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetCategoryId() int64 {
+	if x != nil {
+		return x.CategoryId
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type ListProductsResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Products []*ProductResponse     `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	// Number of products matching the filters across all pages.
+	TotalCount int64 `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Page       int32 `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize   int32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Empty on the last page.
+	NextCursor    string `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CheckStockResponse) Reset() {
-	*x = CheckStockResponse{}
+func (x *ListProductsResponse) Reset() {
+	*x = ListProductsResponse{}
 	mi := &file_api_proto_product_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CheckStockResponse) String() string {
+func (x *ListProductsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CheckStockResponse) ProtoMessage() {}
+func (*ListProductsResponse) ProtoMessage() {}
 
-func (x *CheckStockResponse) ProtoReflect() protoreflect.Message {
+func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_product_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -194,47 +290,70 @@ func (x *CheckStockResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CheckStockResponse.ProtoReflect.Descriptor instead.
-func (*CheckStockResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
+func (*ListProductsResponse) Descriptor() ([]byte, []int) {
 	return file_api_proto_product_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *CheckStockResponse) GetAvailable() bool {
+func (x *ListProductsResponse) GetProducts() []*ProductResponse {
 	if x != nil {
-		return x.Available
+		return x.Products
 	}
-	return false
+	return nil
 }
 
-func (x *CheckStockResponse) GetCurrentStock() int32 {
+func (x *ListProductsResponse) GetTotalCount() int64 {
 	if x != nil {
-		return x.CurrentStock
+		return x.TotalCount
 	}
 	return 0
 }
 
-type UpdateStockRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ProductId     int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
-	QuantityDelta int32                  `protobuf:"varint,2,opt,name=quantity_delta,json=quantityDelta,proto3" json:"quantity_delta,omitempty"`
+func (x *ListProductsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListProductsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListProductsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type SearchProductsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Words are matched as prefixes and all of them must be present.
+	Query         string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Page          int32  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateStockRequest) Reset() {
-	*x = UpdateStockRequest{}
+func (x *SearchProductsRequest) Reset() {
+	*x = SearchProductsRequest{}
 	mi := &file_api_proto_product_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateStockRequest) String() string {
+func (x *SearchProductsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateStockRequest) ProtoMessage() {}
+func (*SearchProductsRequest) ProtoMessage() {}
 
-func (x *UpdateStockRequest) ProtoReflect() protoreflect.Message {
+func (x *SearchProductsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_product_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -246,46 +365,56 @@ func (x *UpdateStockRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateStockRequest.ProtoReflect.Descriptor instead.
-func (*UpdateStockRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SearchProductsRequest.ProtoReflect.Descriptor instead.
+func (*SearchProductsRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_product_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *UpdateStockRequest) GetProductId() int64 {
+func (x *SearchProductsRequest) GetQuery() string {
 	if x != nil {
-		return x.ProductId
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchProductsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
 	}
 	return 0
 }
 
-func (x *UpdateStockRequest) GetQuantityDelta() int32 {
+func (x *SearchProductsRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.QuantityDelta
+		return x.PageSize
 	}
 	return 0
 }
 
-type UpdateStockResponse struct {
+type SearchProductsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	NewStock      int32                  `protobuf:"varint,1,opt,name=new_stock,json=newStock,proto3" json:"new_stock,omitempty"`
+	Results       []*ProductSearchResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	TotalCount    int64                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateStockResponse) Reset() {
-	*x = UpdateStockResponse{}
+func (x *SearchProductsResponse) Reset() {
+	*x = SearchProductsResponse{}
 	mi := &file_api_proto_product_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateStockResponse) String() string {
+func (x *SearchProductsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateStockResponse) ProtoMessage() {}
+func (*SearchProductsResponse) ProtoMessage() {}
 
-func (x *UpdateStockResponse) ProtoReflect() protoreflect.Message {
+func (x *SearchProductsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_product_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -297,45 +426,64 @@ func (x *UpdateStockResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateStockResponse.ProtoReflect.Descriptor instead.
-func (*UpdateStockResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SearchProductsResponse.ProtoReflect.Descriptor instead.
+func (*SearchProductsResponse) Descriptor() ([]byte, []int) {
 	return file_api_proto_product_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *UpdateStockResponse) GetNewStock() int32 {
+func (x *SearchProductsResponse) GetResults() []*ProductSearchResult {
 	if x != nil {
-		return x.NewStock
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchProductsResponse) GetTotalCount() int64 {
+	if x != nil {
+		return x.TotalCount
 	}
 	return 0
 }
 
-type ProductResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
-	Stock         int32                  `protobuf:"varint,5,opt,name=stock,proto3" json:"stock,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *SearchProductsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
 }
 
-func (x *ProductResponse) Reset() {
-	*x = ProductResponse{}
+func (x *SearchProductsResponse) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ProductSearchResult struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Product *ProductResponse       `protobuf:"bytes,1,opt,name=product,proto3" json:"product,omitempty"`
+	Rank    float64                `protobuf:"fixed64,2,opt,name=rank,proto3" json:"rank,omitempty"`
+	// Fragments with matches wrapped in <b>...</b>.
+	NameHighlight        string `protobuf:"bytes,3,opt,name=name_highlight,json=nameHighlight,proto3" json:"name_highlight,omitempty"`
+	DescriptionHighlight string `protobuf:"bytes,4,opt,name=description_highlight,json=descriptionHighlight,proto3" json:"description_highlight,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ProductSearchResult) Reset() {
+	*x = ProductSearchResult{}
 	mi := &file_api_proto_product_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ProductResponse) String() string {
+func (x *ProductSearchResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProductResponse) ProtoMessage() {}
+func (*ProductSearchResult) ProtoMessage() {}
 
-func (x *ProductResponse) ProtoReflect() protoreflect.Message {
+func (x *ProductSearchResult) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_product_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -347,82 +495,123 @@ func (x *ProductResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProductResponse.ProtoReflect.Descriptor instead.
-func (*ProductResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ProductSearchResult.ProtoReflect.Descriptor instead.
+func (*ProductSearchResult) Descriptor() ([]byte, []int) {
 	return file_api_proto_product_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *ProductResponse) GetId() int64 {
+func (x *ProductSearchResult) GetProduct() *ProductResponse {
 	if x != nil {
-		return x.Id
+		return x.Product
+	}
+	return nil
+}
+
+func (x *ProductSearchResult) GetRank() float64 {
+	if x != nil {
+		return x.Rank
 	}
 	return 0
 }
 
-func (x *ProductResponse) GetName() string {
+func (x *ProductSearchResult) GetNameHighlight() string {
 	if x != nil {
-		return x.Name
+		return x.NameHighlight
 	}
 	return ""
 }
 
-func (x *ProductResponse) GetDescription() string {
+func (x *ProductSearchResult) GetDescriptionHighlight() string {
 	if x != nil {
-		return x.Description
+		return x.DescriptionHighlight
 	}
 	return ""
 }
 
-func (x *ProductResponse) GetPrice() float64 {
+type CheckStockRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ProductId int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Quantity  int32                  `protobuf:"varint,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// Variant to check; 0 checks the whole product stock.
+	VariantId     int64 `protobuf:"varint,3,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckStockRequest) Reset() {
+	*x = CheckStockRequest{}
+	mi := &file_api_proto_product_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckStockRequest) ProtoMessage() {}
+
+func (x *CheckStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_proto_msgTypes[7]
 	if x != nil {
-		return x.Price
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *ProductResponse) GetStock() int32 {
+// Deprecated: Use CheckStockRequest.ProtoReflect.Descriptor instead.
+func (*CheckStockRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CheckStockRequest) GetProductId() int64 {
 	if x != nil {
-		return x.Stock
+		return x.ProductId
 	}
 	return 0
 }
 
-func (x *ProductResponse) GetCreatedAt() string {
+func (x *CheckStockRequest) GetQuantity() int32 {
 	if x != nil {
-		return x.CreatedAt
+		return x.Quantity
 	}
-	return ""
+	return 0
 }
 
-func (x *ProductResponse) GetUpdatedAt() string {
+func (x *CheckStockRequest) GetVariantId() int64 {
 	if x != nil {
-		return x.UpdatedAt
+		return x.VariantId
 	}
-	return ""
+	return 0
 }
 
-type ProductsResponse struct {
+type CheckStockResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Products      []*ProductResponse     `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	Available     bool                   `protobuf:"varint,1,opt,name=available,proto3" json:"available,omitempty"`
+	CurrentStock  int32                  `protobuf:"varint,2,opt,name=current_stock,json=currentStock,proto3" json:"current_stock,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ProductsResponse) Reset() {
-	*x = ProductsResponse{}
-	mi := &file_api_proto_product_proto_msgTypes[7]
+func (x *CheckStockResponse) Reset() {
+	*x = CheckStockResponse{}
+	mi := &file_api_proto_product_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ProductsResponse) String() string {
+func (x *CheckStockResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ProductsResponse) ProtoMessage() {}
+func (*CheckStockResponse) ProtoMessage() {}
 
-func (x *ProductsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_product_proto_msgTypes[7]
+func (x *CheckStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -433,59 +622,1146 @@ func (x *ProductsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ProductsResponse.ProtoReflect.Descriptor instead.
-func (*ProductsResponse) Descriptor() ([]byte, []int) {
-	return file_api_proto_product_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use CheckStockResponse.ProtoReflect.Descriptor instead.
+func (*CheckStockResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ProductsResponse) GetProducts() []*ProductResponse {
+func (x *CheckStockResponse) GetAvailable() bool {
 	if x != nil {
-		return x.Products
+		return x.Available
 	}
-	return nil
+	return false
 }
 
-var File_api_proto_product_proto protoreflect.FileDescriptor
+func (x *CheckStockResponse) GetCurrentStock() int32 {
+	if x != nil {
+		return x.CurrentStock
+	}
+	return 0
+}
 
-const file_api_proto_product_proto_rawDesc = "" +
-	"\n" +
-	"\x17api/proto/product.proto\x12\aproduct\"#\n" +
-	"\x11GetProductRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x03R\x02id\"&\n" +
-	"\x12GetProductsRequest\x12\x10\n" +
-	"\x03ids\x18\x01 \x03(\x03R\x03ids\"N\n" +
-	"\x11CheckStockRequest\x12\x1d\n" +
-	"\n" +
-	"product_id\x18\x01 \x01(\x03R\tproductId\x12\x1a\n" +
-	"\bquantity\x18\x02 \x01(\x05R\bquantity\"W\n" +
-	"\x12CheckStockResponse\x12\x1c\n" +
+type UpdateStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	QuantityDelta int32                  `protobuf:"varint,2,opt,name=quantity_delta,json=quantityDelta,proto3" json:"quantity_delta,omitempty"`
+	// Why the stock changes (order_created, order_cancelled, ...), recorded in the stock movement ledger.
+	Reason string `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	// External reference of the change, usually the order ID.
+	Reference string `protobuf:"bytes,4,opt,name=reference,proto3" json:"reference,omitempty"`
+	// Variant whose stock changes; 0 is allowed only for a product with a single variant.
+	VariantId     int64 `protobuf:"varint,5,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateStockRequest) Reset() {
+	*x = UpdateStockRequest{}
+	mi := &file_api_proto_product_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateStockRequest) ProtoMessage() {}
+
+func (x *UpdateStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateStockRequest.ProtoReflect.Descriptor instead.
+func (*UpdateStockRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateStockRequest) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *UpdateStockRequest) GetQuantityDelta() int32 {
+	if x != nil {
+		return x.QuantityDelta
+	}
+	return 0
+}
+
+func (x *UpdateStockRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *UpdateStockRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *UpdateStockRequest) GetVariantId() int64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
+type UpdateStockResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Stock of the variant after the change.
+	NewStock      int32 `protobuf:"varint,1,opt,name=new_stock,json=newStock,proto3" json:"new_stock,omitempty"`
+	VariantId     int64 `protobuf:"varint,2,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateStockResponse) Reset() {
+	*x = UpdateStockResponse{}
+	mi := &file_api_proto_product_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateStockResponse) ProtoMessage() {}
+
+func (x *UpdateStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateStockResponse.ProtoReflect.Descriptor instead.
+func (*UpdateStockResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateStockResponse) GetNewStock() int32 {
+	if x != nil {
+		return x.NewStock
+	}
+	return 0
+}
+
+func (x *UpdateStockResponse) GetVariantId() int64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
+type StockAdjustment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	QuantityDelta int32                  `protobuf:"varint,2,opt,name=quantity_delta,json=quantityDelta,proto3" json:"quantity_delta,omitempty"`
+	// Same meaning as in UpdateStockRequest.
+	VariantId     int64 `protobuf:"varint,3,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockAdjustment) Reset() {
+	*x = StockAdjustment{}
+	mi := &file_api_proto_product_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockAdjustment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockAdjustment) ProtoMessage() {}
+
+func (x *StockAdjustment) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockAdjustment.ProtoReflect.Descriptor instead.
+func (*StockAdjustment) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StockAdjustment) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *StockAdjustment) GetQuantityDelta() int32 {
+	if x != nil {
+		return x.QuantityDelta
+	}
+	return 0
+}
+
+func (x *StockAdjustment) GetVariantId() int64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
+type BatchUpdateStockRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Items []*StockAdjustment     `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// Same meaning as in UpdateStockRequest, recorded for every item.
+	Reason        string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	Reference     string `protobuf:"bytes,3,opt,name=reference,proto3" json:"reference,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUpdateStockRequest) Reset() {
+	*x = BatchUpdateStockRequest{}
+	mi := &file_api_proto_product_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateStockRequest) ProtoMessage() {}
+
+func (x *BatchUpdateStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateStockRequest.ProtoReflect.Descriptor instead.
+func (*BatchUpdateStockRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BatchUpdateStockRequest) GetItems() []*StockAdjustment {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *BatchUpdateStockRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *BatchUpdateStockRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+type StockAdjustmentResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     int64                  `protobuf:"varint,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	QuantityDelta int32                  `protobuf:"varint,2,opt,name=quantity_delta,json=quantityDelta,proto3" json:"quantity_delta,omitempty"`
+	NewStock      int32                  `protobuf:"varint,3,opt,name=new_stock,json=newStock,proto3" json:"new_stock,omitempty"`
+	VariantId     int64                  `protobuf:"varint,4,opt,name=variant_id,json=variantId,proto3" json:"variant_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockAdjustmentResult) Reset() {
+	*x = StockAdjustmentResult{}
+	mi := &file_api_proto_product_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockAdjustmentResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockAdjustmentResult) ProtoMessage() {}
+
+func (x *StockAdjustmentResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockAdjustmentResult.ProtoReflect.Descriptor instead.
+func (*StockAdjustmentResult) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *StockAdjustmentResult) GetProductId() int64 {
+	if x != nil {
+		return x.ProductId
+	}
+	return 0
+}
+
+func (x *StockAdjustmentResult) GetQuantityDelta() int32 {
+	if x != nil {
+		return x.QuantityDelta
+	}
+	return 0
+}
+
+func (x *StockAdjustmentResult) GetNewStock() int32 {
+	if x != nil {
+		return x.NewStock
+	}
+	return 0
+}
+
+func (x *StockAdjustmentResult) GetVariantId() int64 {
+	if x != nil {
+		return x.VariantId
+	}
+	return 0
+}
+
+type BatchUpdateStockResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Results in request order.
+	Results       []*StockAdjustmentResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchUpdateStockResponse) Reset() {
+	*x = BatchUpdateStockResponse{}
+	mi := &file_api_proto_product_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchUpdateStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchUpdateStockResponse) ProtoMessage() {}
+
+func (x *BatchUpdateStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchUpdateStockResponse.ProtoReflect.Descriptor instead.
+func (*BatchUpdateStockResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *BatchUpdateStockResponse) GetResults() []*StockAdjustmentResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type ProductResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// Deprecated: use price_minor and currency.
+	//
+	// Deprecated: Marked as deprecated in api/proto/product.proto.
+	Price     float64 `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	Stock     int32   `protobuf:"varint,5,opt,name=stock,proto3" json:"stock,omitempty"`
+	CreatedAt string  `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt string  `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Price in minor units of currency (kopecks, cents).
+	PriceMinor int64 `protobuf:"varint,8,opt,name=price_minor,json=priceMinor,proto3" json:"price_minor,omitempty"`
+	// ISO 4217 currency code.
+	Currency string `protobuf:"bytes,9,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Incremented on every change of the product, including stock updates.
+	Version int64 `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"`
+	// Breadcrumbs of every category of the product, each from the root of the tree.
+	Categories []*CategoryPath `protobuf:"bytes,11,rep,name=categories,proto3" json:"categories,omitempty"`
+	// Every product has at least one variant; stock is the sum of their stock.
+	Variants []*ProductVariant `protobuf:"bytes,12,rep,name=variants,proto3" json:"variants,omitempty"`
+	// Gallery in display order; the first image is the main one.
+	Images []*ProductImage `protobuf:"bytes,13,rep,name=images,proto3" json:"images,omitempty"`
+	// Price before the running sale, set only when it is higher than the current price (strikethrough).
+	CompareAtPriceMinor *int64 `protobuf:"varint,14,opt,name=compare_at_price_minor,json=compareAtPriceMinor,proto3,oneof" json:"compare_at_price_minor,omitempty"`
+	// draft, active or archived. Only active products can be ordered; archived ones are hidden from
+	// the catalog but still resolvable by id for order history.
+	Status string `protobuf:"bytes,15,opt,name=status,proto3" json:"status,omitempty"`
+	// RFC 3339 time the product was archived; empty unless archived.
+	DeletedAt     string `protobuf:"bytes,16,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductResponse) Reset() {
+	*x = ProductResponse{}
+	mi := &file_api_proto_product_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductResponse) ProtoMessage() {}
+
+func (x *ProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductResponse.ProtoReflect.Descriptor instead.
+func (*ProductResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ProductResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ProductResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProductResponse) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in api/proto/product.proto.
+func (x *ProductResponse) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *ProductResponse) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+func (x *ProductResponse) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *ProductResponse) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *ProductResponse) GetPriceMinor() int64 {
+	if x != nil {
+		return x.PriceMinor
+	}
+	return 0
+}
+
+func (x *ProductResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ProductResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *ProductResponse) GetCategories() []*CategoryPath {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *ProductResponse) GetVariants() []*ProductVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+func (x *ProductResponse) GetImages() []*ProductImage {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *ProductResponse) GetCompareAtPriceMinor() int64 {
+	if x != nil && x.CompareAtPriceMinor != nil {
+		return *x.CompareAtPriceMinor
+	}
+	return 0
+}
+
+func (x *ProductResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ProductResponse) GetDeletedAt() string {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return ""
+}
+
+type ProductVariant struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Sku   string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	// Attribute values such as size or colour.
+	Attributes map[string]string `protobuf:"bytes,3,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Effective price of the variant in minor units.
+	PriceMinor int64 `protobuf:"varint,4,opt,name=price_minor,json=priceMinor,proto3" json:"price_minor,omitempty"`
+	// Set when the variant price differs from the product price.
+	PriceOverrideMinor *int64 `protobuf:"varint,5,opt,name=price_override_minor,json=priceOverrideMinor,proto3,oneof" json:"price_override_minor,omitempty"`
+	Stock              int32  `protobuf:"varint,6,opt,name=stock,proto3" json:"stock,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ProductVariant) Reset() {
+	*x = ProductVariant{}
+	mi := &file_api_proto_product_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductVariant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductVariant) ProtoMessage() {}
+
+func (x *ProductVariant) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductVariant.ProtoReflect.Descriptor instead.
+func (*ProductVariant) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ProductVariant) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ProductVariant) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ProductVariant) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+func (x *ProductVariant) GetPriceMinor() int64 {
+	if x != nil {
+		return x.PriceMinor
+	}
+	return 0
+}
+
+func (x *ProductVariant) GetPriceOverrideMinor() int64 {
+	if x != nil && x.PriceOverrideMinor != nil {
+		return *x.PriceOverrideMinor
+	}
+	return 0
+}
+
+func (x *ProductVariant) GetStock() int32 {
+	if x != nil {
+		return x.Stock
+	}
+	return 0
+}
+
+type ProductImage struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url         string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	ContentType string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`
+	Width       int32                  `protobuf:"varint,4,opt,name=width,proto3" json:"width,omitempty"`
+	Height      int32                  `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	AltText     string                 `protobuf:"bytes,6,opt,name=alt_text,json=altText,proto3" json:"alt_text,omitempty"`
+	// Thumbnail URLs by size name: small, medium, large.
+	Thumbnails    map[string]string `protobuf:"bytes,7,rep,name=thumbnails,proto3" json:"thumbnails,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductImage) Reset() {
+	*x = ProductImage{}
+	mi := &file_api_proto_product_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductImage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductImage) ProtoMessage() {}
+
+func (x *ProductImage) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductImage.ProtoReflect.Descriptor instead.
+func (*ProductImage) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ProductImage) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ProductImage) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *ProductImage) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *ProductImage) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *ProductImage) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *ProductImage) GetAltText() string {
+	if x != nil {
+		return x.AltText
+	}
+	return ""
+}
+
+func (x *ProductImage) GetThumbnails() map[string]string {
+	if x != nil {
+		return x.Thumbnails
+	}
+	return nil
+}
+
+type ProductsResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Products []*ProductResponse     `protobuf:"bytes,1,rep,name=products,proto3" json:"products,omitempty"`
+	// Requested ids that do not exist, in request order.
+	MissingIds    []int64 `protobuf:"varint,2,rep,packed,name=missing_ids,json=missingIds,proto3" json:"missing_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductsResponse) Reset() {
+	*x = ProductsResponse{}
+	mi := &file_api_proto_product_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductsResponse) ProtoMessage() {}
+
+func (x *ProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductsResponse.ProtoReflect.Descriptor instead.
+func (*ProductsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ProductsResponse) GetProducts() []*ProductResponse {
+	if x != nil {
+		return x.Products
+	}
+	return nil
+}
+
+func (x *ProductsResponse) GetMissingIds() []int64 {
+	if x != nil {
+		return x.MissingIds
+	}
+	return nil
+}
+
+type CategoryRef struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryRef) Reset() {
+	*x = CategoryRef{}
+	mi := &file_api_proto_product_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryRef) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryRef) ProtoMessage() {}
+
+func (x *CategoryRef) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryRef.ProtoReflect.Descriptor instead.
+func (*CategoryRef) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CategoryRef) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *CategoryRef) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CategoryPath struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Categories    []*CategoryRef         `protobuf:"bytes,1,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryPath) Reset() {
+	*x = CategoryPath{}
+	mi := &file_api_proto_product_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryPath) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryPath) ProtoMessage() {}
+
+func (x *CategoryPath) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryPath.ProtoReflect.Descriptor instead.
+func (*CategoryPath) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *CategoryPath) GetCategories() []*CategoryRef {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type PurgeProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeProductRequest) Reset() {
+	*x = PurgeProductRequest{}
+	mi := &file_api_proto_product_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeProductRequest) ProtoMessage() {}
+
+func (x *PurgeProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeProductRequest.ProtoReflect.Descriptor instead.
+func (*PurgeProductRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *PurgeProductRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type PurgeProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PurgeProductResponse) Reset() {
+	*x = PurgeProductResponse{}
+	mi := &file_api_proto_product_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PurgeProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PurgeProductResponse) ProtoMessage() {}
+
+func (x *PurgeProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_product_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PurgeProductResponse.ProtoReflect.Descriptor instead.
+func (*PurgeProductResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_product_proto_rawDescGZIP(), []int{22}
+}
+
+var File_api_proto_product_proto protoreflect.FileDescriptor
+
+const file_api_proto_product_proto_rawDesc = "" +
+	"\n" +
+	"\x17api/proto/product.proto\x12\aproduct\"#\n" +
+	"\x11GetProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"&\n" +
+	"\x12GetProductsRequest\x12\x10\n" +
+	"\x03ids\x18\x01 \x03(\x03R\x03ids\"\xad\x03\n" +
+	"\x13ListProductsRequest\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x17\n" +
+	"\asort_by\x18\x04 \x01(\tR\x06sortBy\x12\x1d\n" +
+	"\n" +
+	"sort_order\x18\x05 \x01(\tR\tsortOrder\x12+\n" +
+	"\x0fmin_price_minor\x18\x06 \x01(\x03H\x00R\rminPriceMinor\x88\x01\x01\x12+\n" +
+	"\x0fmax_price_minor\x18\a \x01(\x03H\x01R\rmaxPriceMinor\x88\x01\x01\x12\x19\n" +
+	"\bin_stock\x18\b \x01(\bR\ainStock\x12#\n" +
+	"\rupdated_since\x18\t \x01(\tR\fupdatedSince\x12\x1f\n" +
+	"\vcategory_id\x18\n" +
+	" \x01(\x03R\n" +
+	"categoryId\x12\x16\n" +
+	"\x06status\x18\v \x01(\tR\x06status\x12\x1a\n" +
+	"\bcurrency\x18\f \x01(\tR\bcurrencyB\x12\n" +
+	"\x10_min_price_minorB\x12\n" +
+	"\x10_max_price_minor\"\xbf\x01\n" +
+	"\x14ListProductsResponse\x124\n" +
+	"\bproducts\x18\x01 \x03(\v2\x18.product.ProductResponseR\bproducts\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x03R\n" +
+	"totalCount\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursor\"^\n" +
+	"\x15SearchProductsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\xa2\x01\n" +
+	"\x16SearchProductsResponse\x126\n" +
+	"\aresults\x18\x01 \x03(\v2\x1c.product.ProductSearchResultR\aresults\x12\x1f\n" +
+	"\vtotal_count\x18\x02 \x01(\x03R\n" +
+	"totalCount\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\xb9\x01\n" +
+	"\x13ProductSearchResult\x122\n" +
+	"\aproduct\x18\x01 \x01(\v2\x18.product.ProductResponseR\aproduct\x12\x12\n" +
+	"\x04rank\x18\x02 \x01(\x01R\x04rank\x12%\n" +
+	"\x0ename_highlight\x18\x03 \x01(\tR\rnameHighlight\x123\n" +
+	"\x15description_highlight\x18\x04 \x01(\tR\x14descriptionHighlight\"m\n" +
+	"\x11CheckStockRequest\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x03R\tproductId\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x05R\bquantity\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x03 \x01(\x03R\tvariantId\"W\n" +
+	"\x12CheckStockResponse\x12\x1c\n" +
 	"\tavailable\x18\x01 \x01(\bR\tavailable\x12#\n" +
-	"\rcurrent_stock\x18\x02 \x01(\x05R\fcurrentStock\"Z\n" +
+	"\rcurrent_stock\x18\x02 \x01(\x05R\fcurrentStock\"\xaf\x01\n" +
 	"\x12UpdateStockRequest\x12\x1d\n" +
 	"\n" +
 	"product_id\x18\x01 \x01(\x03R\tproductId\x12%\n" +
-	"\x0equantity_delta\x18\x02 \x01(\x05R\rquantityDelta\"2\n" +
+	"\x0equantity_delta\x18\x02 \x01(\x05R\rquantityDelta\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x12\x1c\n" +
+	"\treference\x18\x04 \x01(\tR\treference\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x05 \x01(\x03R\tvariantId\"Q\n" +
 	"\x13UpdateStockResponse\x12\x1b\n" +
-	"\tnew_stock\x18\x01 \x01(\x05R\bnewStock\"\xc1\x01\n" +
+	"\tnew_stock\x18\x01 \x01(\x05R\bnewStock\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x02 \x01(\x03R\tvariantId\"v\n" +
+	"\x0fStockAdjustment\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x03R\tproductId\x12%\n" +
+	"\x0equantity_delta\x18\x02 \x01(\x05R\rquantityDelta\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x03 \x01(\x03R\tvariantId\"\x7f\n" +
+	"\x17BatchUpdateStockRequest\x12.\n" +
+	"\x05items\x18\x01 \x03(\v2\x18.product.StockAdjustmentR\x05items\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\x12\x1c\n" +
+	"\treference\x18\x03 \x01(\tR\treference\"\x99\x01\n" +
+	"\x15StockAdjustmentResult\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\x03R\tproductId\x12%\n" +
+	"\x0equantity_delta\x18\x02 \x01(\x05R\rquantityDelta\x12\x1b\n" +
+	"\tnew_stock\x18\x03 \x01(\x05R\bnewStock\x12\x1d\n" +
+	"\n" +
+	"variant_id\x18\x04 \x01(\x03R\tvariantId\"T\n" +
+	"\x18BatchUpdateStockResponse\x128\n" +
+	"\aresults\x18\x01 \x03(\v2\x1e.product.StockAdjustmentResultR\aresults\"\xc3\x04\n" +
 	"\x0fProductResponse\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x04 \x01(\x01R\x05price\x12\x14\n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12\x18\n" +
+	"\x05price\x18\x04 \x01(\x01B\x02\x18\x01R\x05price\x12\x14\n" +
 	"\x05stock\x18\x05 \x01(\x05R\x05stock\x12\x1d\n" +
 	"\n" +
 	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\a \x01(\tR\tupdatedAt\"H\n" +
+	"updated_at\x18\a \x01(\tR\tupdatedAt\x12\x1f\n" +
+	"\vprice_minor\x18\b \x01(\x03R\n" +
+	"priceMinor\x12\x1a\n" +
+	"\bcurrency\x18\t \x01(\tR\bcurrency\x12\x18\n" +
+	"\aversion\x18\n" +
+	" \x01(\x03R\aversion\x125\n" +
+	"\n" +
+	"categories\x18\v \x03(\v2\x15.product.CategoryPathR\n" +
+	"categories\x123\n" +
+	"\bvariants\x18\f \x03(\v2\x17.product.ProductVariantR\bvariants\x12-\n" +
+	"\x06images\x18\r \x03(\v2\x15.product.ProductImageR\x06images\x128\n" +
+	"\x16compare_at_price_minor\x18\x0e \x01(\x03H\x00R\x13compareAtPriceMinor\x88\x01\x01\x12\x16\n" +
+	"\x06status\x18\x0f \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\x10 \x01(\tR\tdeletedAtB\x19\n" +
+	"\x17_compare_at_price_minor\"\xc1\x02\n" +
+	"\x0eProductVariant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12G\n" +
+	"\n" +
+	"attributes\x18\x03 \x03(\v2'.product.ProductVariant.AttributesEntryR\n" +
+	"attributes\x12\x1f\n" +
+	"\vprice_minor\x18\x04 \x01(\x03R\n" +
+	"priceMinor\x125\n" +
+	"\x14price_override_minor\x18\x05 \x01(\x03H\x00R\x12priceOverrideMinor\x88\x01\x01\x12\x14\n" +
+	"\x05stock\x18\x06 \x01(\x05R\x05stock\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x17\n" +
+	"\x15_price_override_minor\"\xa2\x02\n" +
+	"\fProductImage\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\x12\x14\n" +
+	"\x05width\x18\x04 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x05 \x01(\x05R\x06height\x12\x19\n" +
+	"\balt_text\x18\x06 \x01(\tR\aaltText\x12E\n" +
+	"\n" +
+	"thumbnails\x18\a \x03(\v2%.product.ProductImage.ThumbnailsEntryR\n" +
+	"thumbnails\x1a=\n" +
+	"\x0fThumbnailsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"i\n" +
 	"\x10ProductsResponse\x124\n" +
-	"\bproducts\x18\x01 \x03(\v2\x18.product.ProductResponseR\bproducts2\xac\x02\n" +
+	"\bproducts\x18\x01 \x03(\v2\x18.product.ProductResponseR\bproducts\x12\x1f\n" +
+	"\vmissing_ids\x18\x02 \x03(\x03R\n" +
+	"missingIds\"1\n" +
+	"\vCategoryRef\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"D\n" +
+	"\fCategoryPath\x124\n" +
+	"\n" +
+	"categories\x18\x01 \x03(\v2\x14.product.CategoryRefR\n" +
+	"categories\"%\n" +
+	"\x13PurgeProductRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x16\n" +
+	"\x14PurgeProductResponse2\xf2\x04\n" +
 	"\x0eProductService\x12B\n" +
 	"\n" +
 	"GetProduct\x12\x1a.product.GetProductRequest\x1a\x18.product.ProductResponse\x12E\n" +
-	"\vGetProducts\x12\x1b.product.GetProductsRequest\x1a\x19.product.ProductsResponse\x12E\n" +
+	"\vGetProducts\x12\x1b.product.GetProductsRequest\x1a\x19.product.ProductsResponse\x12K\n" +
+	"\fListProducts\x12\x1c.product.ListProductsRequest\x1a\x1d.product.ListProductsResponse\x12Q\n" +
+	"\x0eSearchProducts\x12\x1e.product.SearchProductsRequest\x1a\x1f.product.SearchProductsResponse\x12E\n" +
 	"\n" +
 	"CheckStock\x12\x1a.product.CheckStockRequest\x1a\x1b.product.CheckStockResponse\x12H\n" +
-	"\vUpdateStock\x12\x1b.product.UpdateStockRequest\x1a\x1c.product.UpdateStockResponseBDZBgithub.com/microserviceteam0/bff-gateway/product-service/api/protob\x06proto3"
+	"\vUpdateStock\x12\x1b.product.UpdateStockRequest\x1a\x1c.product.UpdateStockResponse\x12W\n" +
+	"\x10BatchUpdateStock\x12 .product.BatchUpdateStockRequest\x1a!.product.BatchUpdateStockResponse\x12K\n" +
+	"\fPurgeProduct\x12\x1c.product.PurgeProductRequest\x1a\x1d.product.PurgeProductResponseBDZBgithub.com/microserviceteam0/bff-gateway/product-service/api/protob\x06proto3"
 
 var (
 	file_api_proto_product_proto_rawDescOnce sync.Once
@@ -499,32 +1775,68 @@ func file_api_proto_product_proto_rawDescGZIP() []byte {
 	return file_api_proto_product_proto_rawDescData
 }
 
-var file_api_proto_product_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_api_proto_product_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_api_proto_product_proto_goTypes = []any{
-	(*GetProductRequest)(nil),   // 0: product.GetProductRequest
-	(*GetProductsRequest)(nil),  // 1: product.GetProductsRequest
-	(*CheckStockRequest)(nil),   // 2: product.CheckStockRequest
-	(*CheckStockResponse)(nil),  // 3: product.CheckStockResponse
-	(*UpdateStockRequest)(nil),  // 4: product.UpdateStockRequest
-	(*UpdateStockResponse)(nil), // 5: product.UpdateStockResponse
-	(*ProductResponse)(nil),     // 6: product.ProductResponse
-	(*ProductsResponse)(nil),    // 7: product.ProductsResponse
+	(*GetProductRequest)(nil),        // 0: product.GetProductRequest
+	(*GetProductsRequest)(nil),       // 1: product.GetProductsRequest
+	(*ListProductsRequest)(nil),      // 2: product.ListProductsRequest
+	(*ListProductsResponse)(nil),     // 3: product.ListProductsResponse
+	(*SearchProductsRequest)(nil),    // 4: product.SearchProductsRequest
+	(*SearchProductsResponse)(nil),   // 5: product.SearchProductsResponse
+	(*ProductSearchResult)(nil),      // 6: product.ProductSearchResult
+	(*CheckStockRequest)(nil),        // 7: product.CheckStockRequest
+	(*CheckStockResponse)(nil),       // 8: product.CheckStockResponse
+	(*UpdateStockRequest)(nil),       // 9: product.UpdateStockRequest
+	(*UpdateStockResponse)(nil),      // 10: product.UpdateStockResponse
+	(*StockAdjustment)(nil),          // 11: product.StockAdjustment
+	(*BatchUpdateStockRequest)(nil),  // 12: product.BatchUpdateStockRequest
+	(*StockAdjustmentResult)(nil),    // 13: product.StockAdjustmentResult
+	(*BatchUpdateStockResponse)(nil), // 14: product.BatchUpdateStockResponse
+	(*ProductResponse)(nil),          // 15: product.ProductResponse
+	(*ProductVariant)(nil),           // 16: product.ProductVariant
+	(*ProductImage)(nil),             // 17: product.ProductImage
+	(*ProductsResponse)(nil),         // 18: product.ProductsResponse
+	(*CategoryRef)(nil),              // 19: product.CategoryRef
+	(*CategoryPath)(nil),             // 20: product.CategoryPath
+	(*PurgeProductRequest)(nil),      // 21: product.PurgeProductRequest
+	(*PurgeProductResponse)(nil),     // 22: product.PurgeProductResponse
+	nil,                              // 23: product.ProductVariant.AttributesEntry
+	nil,                              // 24: product.ProductImage.ThumbnailsEntry
 }
 var file_api_proto_product_proto_depIdxs = []int32{
-	6, // 0: product.ProductsResponse.products:type_name -> product.ProductResponse
-	0, // 1: product.ProductService.GetProduct:input_type -> product.GetProductRequest
-	1, // 2: product.ProductService.GetProducts:input_type -> product.GetProductsRequest
-	2, // 3: product.ProductService.CheckStock:input_type -> product.CheckStockRequest
-	4, // 4: product.ProductService.UpdateStock:input_type -> product.UpdateStockRequest
-	6, // 5: product.ProductService.GetProduct:output_type -> product.ProductResponse
-	7, // 6: product.ProductService.GetProducts:output_type -> product.ProductsResponse
-	3, // 7: product.ProductService.CheckStock:output_type -> product.CheckStockResponse
-	5, // 8: product.ProductService.UpdateStock:output_type -> product.UpdateStockResponse
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	15, // 0: product.ListProductsResponse.products:type_name -> product.ProductResponse
+	6,  // 1: product.SearchProductsResponse.results:type_name -> product.ProductSearchResult
+	15, // 2: product.ProductSearchResult.product:type_name -> product.ProductResponse
+	11, // 3: product.BatchUpdateStockRequest.items:type_name -> product.StockAdjustment
+	13, // 4: product.BatchUpdateStockResponse.results:type_name -> product.StockAdjustmentResult
+	20, // 5: product.ProductResponse.categories:type_name -> product.CategoryPath
+	16, // 6: product.ProductResponse.variants:type_name -> product.ProductVariant
+	17, // 7: product.ProductResponse.images:type_name -> product.ProductImage
+	23, // 8: product.ProductVariant.attributes:type_name -> product.ProductVariant.AttributesEntry
+	24, // 9: product.ProductImage.thumbnails:type_name -> product.ProductImage.ThumbnailsEntry
+	15, // 10: product.ProductsResponse.products:type_name -> product.ProductResponse
+	19, // 11: product.CategoryPath.categories:type_name -> product.CategoryRef
+	0,  // 12: product.ProductService.GetProduct:input_type -> product.GetProductRequest
+	1,  // 13: product.ProductService.GetProducts:input_type -> product.GetProductsRequest
+	2,  // 14: product.ProductService.ListProducts:input_type -> product.ListProductsRequest
+	4,  // 15: product.ProductService.SearchProducts:input_type -> product.SearchProductsRequest
+	7,  // 16: product.ProductService.CheckStock:input_type -> product.CheckStockRequest
+	9,  // 17: product.ProductService.UpdateStock:input_type -> product.UpdateStockRequest
+	12, // 18: product.ProductService.BatchUpdateStock:input_type -> product.BatchUpdateStockRequest
+	21, // 19: product.ProductService.PurgeProduct:input_type -> product.PurgeProductRequest
+	15, // 20: product.ProductService.GetProduct:output_type -> product.ProductResponse
+	18, // 21: product.ProductService.GetProducts:output_type -> product.ProductsResponse
+	3,  // 22: product.ProductService.ListProducts:output_type -> product.ListProductsResponse
+	5,  // 23: product.ProductService.SearchProducts:output_type -> product.SearchProductsResponse
+	8,  // 24: product.ProductService.CheckStock:output_type -> product.CheckStockResponse
+	10, // 25: product.ProductService.UpdateStock:output_type -> product.UpdateStockResponse
+	14, // 26: product.ProductService.BatchUpdateStock:output_type -> product.BatchUpdateStockResponse
+	22, // 27: product.ProductService.PurgeProduct:output_type -> product.PurgeProductResponse
+	20, // [20:28] is the sub-list for method output_type
+	12, // [12:20] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_product_proto_init() }
@@ -532,13 +1844,16 @@ func file_api_proto_product_proto_init() {
 	if File_api_proto_product_proto != nil {
 		return
 	}
+	file_api_proto_product_proto_msgTypes[2].OneofWrappers = []any{}
+	file_api_proto_product_proto_msgTypes[15].OneofWrappers = []any{}
+	file_api_proto_product_proto_msgTypes[16].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_product_proto_rawDesc), len(file_api_proto_product_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
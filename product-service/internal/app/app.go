@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/microserviceteam0/bff-gateway/shared/identity"
 	"github.com/microserviceteam0/bff-gateway/shared/metrics"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	httpSwagger "github.com/swaggo/http-swagger"
@@ -23,10 +24,13 @@ import (
 	"github.com/microserviceteam0/bff-gateway/product-service/internal/config"
 	"github.com/microserviceteam0/bff-gateway/product-service/internal/handler"
 	"github.com/microserviceteam0/bff-gateway/product-service/internal/middleware"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/pricing"
 	"github.com/microserviceteam0/bff-gateway/product-service/internal/repository"
 	"github.com/microserviceteam0/bff-gateway/product-service/internal/service"
+	"github.com/microserviceteam0/bff-gateway/product-service/pkg/clients/order"
 	"github.com/microserviceteam0/bff-gateway/product-service/pkg/database"
 	"github.com/microserviceteam0/bff-gateway/product-service/pkg/logger"
+	"github.com/microserviceteam0/bff-gateway/product-service/pkg/storage"
 )
 
 func Run() error {
@@ -57,11 +61,39 @@ func Run() error {
 		return fmt.Errorf("run migrations: %w", err)
 	}
 
+	issuer, err := identity.NewIssuer(cfg.InternalAuthSecret, identity.DefaultTTL)
+	if err != nil {
+		logger.Fatal("failed to init identity issuer, check INTERNAL_AUTH_SECRET", zap.Error(err))
+		return fmt.Errorf("init identity issuer: %w", err)
+	}
+
+	// Подключение ленивое: order-service нужен только для удаления продуктов
+	orderClient, err := order.NewClient(cfg.OrderServiceAddr,
+		grpc.WithUnaryInterceptor(identity.UnaryClientInterceptor(issuer, "product-service")))
+	if err != nil {
+		logger.Fatal("failed to init order service client", zap.Error(err))
+		return fmt.Errorf("init order service client: %w", err)
+	}
+	defer func() {
+		_ = orderClient.Close()
+	}()
+
+	mediaStorage, err := storage.NewLocalStorage(cfg.MediaDir, cfg.MediaBaseURL)
+	if err != nil {
+		logger.Fatal("failed to init media storage, check MEDIA_DIR", zap.Error(err))
+		return fmt.Errorf("init media storage: %w", err)
+	}
+
 	productRepo := repository.NewPostgresRepository(db)
-	productService := service.NewProductService(productRepo)
+	productService := service.NewProductService(productRepo, mediaStorage)
+	categoryService := service.NewCategoryService(repository.NewPostgresCategoryRepository(db))
 
-	grpcServer := startGRPCServer(cfg.GRPCPort, productService)
-	httpServer := startHTTPServer(cfg.ServerPort, productService)
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	defer stopScheduler()
+	go pricing.NewWorker(productService, pricing.Config{Interval: cfg.PriceSchedulerInterval}).Run(schedulerCtx)
+
+	grpcServer := startGRPCServer(cfg.GRPCPort, productService, orderClient, issuer)
+	httpServer := startHTTPServer(cfg.ServerPort, productService, categoryService, mediaStorage)
 
 	waitForShutdown(httpServer, grpcServer)
 	return nil
@@ -89,7 +121,7 @@ func closeDatabase(db *sql.DB) {
 }
 
 // startHTTPServer запускает HTTP REST API сервер
-func startHTTPServer(port string, productService service.ProductService) *http.Server {
+func startHTTPServer(port string, productService service.ProductService, categoryService service.CategoryService, media *storage.LocalStorage) *http.Server {
 	router := mux.NewRouter()
 
 	router.Use(middleware.LoggingMiddleware)
@@ -97,11 +129,13 @@ func startHTTPServer(port string, productService service.ProductService) *http.S
 
 	productHandler := handler.NewProductHandler(productService)
 	productHandler.RegisterRoutes(router)
+	handler.NewCategoryHandler(categoryService).RegisterRoutes(router)
 
 	router.HandleFunc("/health", healthCheckHandler).Methods(http.MethodGet)
 	router.Handle("/metrics", promhttp.Handler()).Methods(http.MethodGet)
 	router.HandleFunc("/api/openapi.yaml", openapiHandler).Methods(http.MethodGet)
 	router.PathPrefix("/swagger/").Handler(swaggerHandler(port))
+	router.PathPrefix("/media/").Handler(mediaHandler(media)).Methods(http.MethodGet, http.MethodHead)
 
 	addr := fmt.Sprintf(":%s", port)
 	server := &http.Server{
@@ -127,18 +161,21 @@ func startHTTPServer(port string, productService service.ProductService) *http.S
 	return server
 }
 
-// startGRPCServer запускает gRPC сервер
-func startGRPCServer(port string, productService service.ProductService) *grpc.Server {
+// startGRPCServer запускает gRPC сервер; все вызовы должны нести identity-токен
+func startGRPCServer(port string, productService service.ProductService, orders handler.OrderReferences, issuer *identity.Issuer) *grpc.Server {
 	lis, err := net.Listen("tcp", ":"+port)
 	if err != nil {
 		logger.Fatal("failed to listen gRPC", zap.String("port", port), zap.Error(err))
 	}
 
 	grpcServer := grpc.NewServer(
-		grpc.UnaryInterceptor(metrics.GRPCUnaryServerInterceptor("product-service")),
+		grpc.ChainUnaryInterceptor(
+			metrics.GRPCUnaryServerInterceptor("product-service"),
+			identity.UnaryServerInterceptor(issuer),
+		),
 	)
 
-	pb.RegisterProductServiceServer(grpcServer, handler.NewProductGRPCHandler(productService))
+	pb.RegisterProductServiceServer(grpcServer, handler.NewProductGRPCHandler(productService, orders))
 
 	go func() {
 		logger.Info("gRPC server started",
@@ -166,6 +203,17 @@ func openapiHandler(w http.ResponseWriter, r *http.Request) {
 	http.ServeFile(w, r, "api/openapi.yaml")
 }
 
+// mediaHandler отдаёт изображения продуктов. Ключи файлов уникальны и не перезаписываются,
+// поэтому ответы можно кэшировать навсегда
+func mediaHandler(media *storage.LocalStorage) http.Handler {
+	files := http.StripPrefix("/media/", media.Handler())
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		files.ServeHTTP(w, r)
+	})
+}
+
 // swaggerHandler создаёт Swagger UI handler
 func swaggerHandler(port string) http.Handler {
 	return httpSwagger.Handler(
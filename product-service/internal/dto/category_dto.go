@@ -0,0 +1,40 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/model"
+)
+
+// CategoryRequest - DTO для создания и изменения категории; без parent_id — категория верхнего уровня
+type CategoryRequest struct {
+	Name     string `json:"name" validate:"required,max=255"`
+	ParentID *int64 `json:"parent_id" validate:"omitempty,gte=1"`
+}
+
+// SetProductCategoriesRequest - DTO для замены категорий продукта; пустой список отвязывает все
+type SetProductCategoriesRequest struct {
+	CategoryIDs []int64 `json:"category_ids"`
+}
+
+// CategoryResponse - DTO категории. Path — хлебные крошки от корня, Children — подкатегории
+type CategoryResponse struct {
+	ID        int64               `json:"id"`
+	Name      string              `json:"name"`
+	ParentID  *int64              `json:"parent_id"`
+	Path      model.CategoryPath  `json:"path,omitempty"`
+	Children  []*CategoryResponse `json:"children,omitempty"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+}
+
+// ToCategoryResponse конвертирует domain model в DTO
+func ToCategoryResponse(category *model.Category) *CategoryResponse {
+	return &CategoryResponse{
+		ID:        category.ID,
+		Name:      category.Name,
+		ParentID:  category.ParentID,
+		CreatedAt: category.CreatedAt,
+		UpdatedAt: category.UpdatedAt,
+	}
+}
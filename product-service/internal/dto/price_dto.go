@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/model"
+)
+
+// SchedulePriceRequest - DTO запланированной цены в валюте продукта; с ends_at это распродажа
+type SchedulePriceRequest struct {
+	PriceMinor int64      `json:"price_minor" validate:"gte=0"`
+	StartsAt   time.Time  `json:"starts_at" validate:"required"`
+	EndsAt     *time.Time `json:"ends_at"`
+}
+
+// PriceScheduleResponse - DTO запланированной цены
+type PriceScheduleResponse struct {
+	ID         int64      `json:"id"`
+	ProductID  int64      `json:"product_id"`
+	PriceMinor int64      `json:"price_minor"`
+	Currency   string     `json:"currency"`
+	StartsAt   time.Time  `json:"starts_at"`
+	EndsAt     *time.Time `json:"ends_at,omitempty"`
+	Status     string     `json:"status"`
+	// RevertPriceMinor — цена, которая вернется по окончании распродажи
+	RevertPriceMinor *int64     `json:"revert_price_minor,omitempty"`
+	Actor            string     `json:"actor,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+	AppliedAt        *time.Time `json:"applied_at,omitempty"`
+	FinishedAt       *time.Time `json:"finished_at,omitempty"`
+}
+
+// ToPriceScheduleResponse конвертирует запланированную цену в DTO
+func ToPriceScheduleResponse(s *model.PriceSchedule) *PriceScheduleResponse {
+	return &PriceScheduleResponse{
+		ID:               s.ID,
+		ProductID:        s.ProductID,
+		PriceMinor:       s.PriceMinor,
+		Currency:         s.Currency,
+		StartsAt:         s.StartsAt,
+		EndsAt:           s.EndsAt,
+		Status:           s.Status,
+		RevertPriceMinor: s.RevertPriceMinor,
+		Actor:            s.Actor,
+		CreatedAt:        s.CreatedAt,
+		AppliedAt:        s.AppliedAt,
+		FinishedAt:       s.FinishedAt,
+	}
+}
+
+// PriceChangeResponse - DTO записи истории цены
+type PriceChangeResponse struct {
+	ID                 int64     `json:"id"`
+	ProductID          int64     `json:"product_id"`
+	PriceMinor         int64     `json:"price_minor"`
+	Currency           string    `json:"currency"`
+	PreviousPriceMinor *int64    `json:"previous_price_minor,omitempty"`
+	PreviousCurrency   *string   `json:"previous_currency,omitempty"`
+	Reason             string    `json:"reason"`
+	ScheduleID         *int64    `json:"schedule_id,omitempty"`
+	Actor              string    `json:"actor,omitempty"`
+	CreatedAt          time.Time `json:"created_at"`
+}
+
+// ToPriceChangeResponse конвертирует запись истории цены в DTO
+func ToPriceChangeResponse(c *model.PriceChange) *PriceChangeResponse {
+	return &PriceChangeResponse{
+		ID:                 c.ID,
+		ProductID:          c.ProductID,
+		PriceMinor:         c.PriceMinor,
+		Currency:           c.Currency,
+		PreviousPriceMinor: c.PreviousPriceMinor,
+		PreviousCurrency:   c.PreviousCurrency,
+		Reason:             c.Reason,
+		ScheduleID:         c.ScheduleID,
+		Actor:              c.Actor,
+		CreatedAt:          c.CreatedAt,
+	}
+}
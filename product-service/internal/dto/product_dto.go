@@ -4,22 +4,37 @@ import (
 	"time"
 
 	"github.com/microserviceteam0/bff-gateway/product-service/internal/model"
+	"github.com/microserviceteam0/bff-gateway/shared/money"
 )
 
 // CreateProductRequest - DTO для создания продукта
 type CreateProductRequest struct {
 	Name        string  `json:"name" validate:"required,max=255"`
 	Description string  `json:"description"`
-	Price       float64 `json:"price" validate:"required,gte=0"`
+	Price       float64 `json:"price" validate:"required_without=PriceMinor,gte=0"`
+	PriceMinor  int64   `json:"price_minor" validate:"required_without=Price,gte=0"`
+	Currency    string  `json:"currency" validate:"omitempty,len=3,alpha"`
 	Stock       int     `json:"stock" validate:"required,gte=0"`
+	// SKU и Attributes описывают вариант по умолчанию; без SKU он получит артикул P-<id>
+	SKU        string            `json:"sku,omitempty" validate:"omitempty,max=64"`
+	Attributes map[string]string `json:"attributes,omitempty"`
+	// Status — draft или active (по умолчанию); черновик не виден в каталоге и не заказывается
+	Status string `json:"status,omitempty" validate:"omitempty,oneof=draft active"`
 }
 
-// UpdateProductRequest - DTO для обновления продукта
+// UpdateProductRequest - DTO для обновления продукта.
+// Version — ожидаемая версия продукта; 0 означает обновление без проверки
 type UpdateProductRequest struct {
 	Name        string  `json:"name" validate:"required,max=255"`
 	Description string  `json:"description"`
-	Price       float64 `json:"price" validate:"required,gte=0"`
+	Price       float64 `json:"price" validate:"required_without=PriceMinor,gte=0"`
+	PriceMinor  int64   `json:"price_minor" validate:"required_without=Price,gte=0"`
+	Currency    string  `json:"currency" validate:"omitempty,len=3,alpha"`
 	Stock       int     `json:"stock" validate:"required,gte=0"`
+	Version     int64   `json:"version,omitempty" validate:"gte=0"`
+	// Status публикует черновик (active) или снимает продукт с продажи (draft); пустой — без изменений.
+	// Архивный продукт возвращается только через restore
+	Status string `json:"status,omitempty" validate:"omitempty,oneof=draft active"`
 }
 
 // ProductResponse - DTO для ответа
@@ -28,21 +43,118 @@ type ProductResponse struct {
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
 	Price       float64   `json:"price"`
+	PriceMinor  int64     `json:"price_minor"`
+	Currency    string    `json:"currency"`
 	Stock       int       `json:"stock"`
+	Version     int64     `json:"version"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+	// Status — draft, active или archived; DeletedAt — время архивации
+	Status    string     `json:"status"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Categories — хлебные крошки каждой категории продукта, от корня дерева
+	Categories []model.CategoryPath `json:"categories,omitempty"`
+	// Variants — варианты продукта; Stock продукта — сумма их остатков
+	Variants []*VariantResponse `json:"variants,omitempty"`
+	// Images — галерея продукта, первое изображение — главное
+	Images []*ImageResponse `json:"images,omitempty"`
+	// CompareAtPriceMinor — цена до идущей распродажи, если она выше текущей (для зачеркнутой цены)
+	CompareAtPriceMinor *int64 `json:"compare_at_price_minor,omitempty"`
+}
+
+// ImageResponse - DTO изображения продукта с адресами оригинала и миниатюр
+type ImageResponse struct {
+	ID          int64             `json:"id"`
+	URL         string            `json:"url"`
+	ContentType string            `json:"content_type"`
+	Width       int               `json:"width"`
+	Height      int               `json:"height"`
+	AltText     string            `json:"alt_text,omitempty"`
+	Thumbnails  map[string]string `json:"thumbnails"`
+}
+
+// ReorderImagesRequest - DTO нового порядка галереи: id всех изображений продукта
+type ReorderImagesRequest struct {
+	ImageIDs []int64 `json:"image_ids" validate:"required,min=1"`
+}
+
+// VariantResponse - DTO варианта продукта. PriceMinor — итоговая цена варианта,
+// PriceOverrideMinor — собственная цена, если она отличается от цены продукта
+type VariantResponse struct {
+	ID                 int64             `json:"id"`
+	SKU                string            `json:"sku"`
+	Attributes         map[string]string `json:"attributes"`
+	PriceMinor         int64             `json:"price_minor"`
+	PriceOverrideMinor *int64            `json:"price_override_minor,omitempty"`
+	Stock              int               `json:"stock"`
+}
+
+// VariantRequest - DTO для создания и изменения варианта; без price_minor вариант стоит как продукт
+type VariantRequest struct {
+	SKU        string            `json:"sku" validate:"required,max=64"`
+	Attributes map[string]string `json:"attributes"`
+	PriceMinor *int64            `json:"price_minor" validate:"omitempty,gte=0"`
+	Stock      int               `json:"stock" validate:"gte=0"`
+}
+
+// ToVariant конвертирует VariantRequest в domain model
+func (r *VariantRequest) ToVariant(productID int64) *model.ProductVariant {
+	return &model.ProductVariant{
+		ProductID:  productID,
+		SKU:        r.SKU,
+		Attributes: r.Attributes,
+		PriceMinor: r.PriceMinor,
+		Stock:      r.Stock,
+	}
 }
 
 // ToProductResponse конвертирует domain model в DTO
 func ToProductResponse(product *model.Product) *ProductResponse {
+	var variants []*VariantResponse
+	for _, v := range product.Variants {
+		variants = append(variants, &VariantResponse{
+			ID:                 v.ID,
+			SKU:                v.SKU,
+			Attributes:         v.Attributes,
+			PriceMinor:         v.EffectivePriceMinor(product),
+			PriceOverrideMinor: v.PriceMinor,
+			Stock:              v.Stock,
+		})
+	}
+	var images []*ImageResponse
+	for _, img := range product.Images {
+		thumbnails := make(map[string]string, len(img.Thumbnails))
+		for _, t := range img.Thumbnails {
+			thumbnails[t.Name] = t.URL
+		}
+		images = append(images, &ImageResponse{
+			ID:          img.ID,
+			URL:         img.URL,
+			ContentType: img.ContentType,
+			Width:       img.Width,
+			Height:      img.Height,
+			AltText:     img.AltText,
+			Thumbnails:  thumbnails,
+		})
+	}
 	return &ProductResponse{
 		ID:          product.ID,
 		Name:        product.Name,
 		Description: product.Description,
 		Price:       product.Price,
+		PriceMinor:  product.PriceMinor,
+		Currency:    product.Currency,
 		Stock:       product.Stock,
+		Version:     product.Version,
 		CreatedAt:   product.CreatedAt,
 		UpdatedAt:   product.UpdatedAt,
+		Status:      product.Status,
+		DeletedAt:   product.DeletedAt,
+		Categories:  product.Categories,
+		Variants:    variants,
+		Images:      images,
+
+		CompareAtPriceMinor: product.CompareAtPriceMinor,
 	}
 }
 
@@ -57,20 +169,164 @@ func ToProductResponseList(products []*model.Product) []*ProductResponse {
 
 // ToProduct конвертирует CreateProductRequest в domain model
 func (r *CreateProductRequest) ToProduct() *model.Product {
-	return &model.Product{
+	product := &model.Product{
 		Name:        r.Name,
 		Description: r.Description,
-		Price:       r.Price,
 		Stock:       r.Stock,
+		Status:      r.Status,
+		Variants: []*model.ProductVariant{{
+			SKU:        r.SKU,
+			Attributes: r.Attributes,
+			Stock:      r.Stock,
+		}},
 	}
+	product.SetPrice(requestPrice(r.PriceMinor, r.Price, r.Currency))
+	return product
 }
 
 // ToProduct конвертирует UpdateProductRequest в domain model
 func (r *UpdateProductRequest) ToProduct() *model.Product {
-	return &model.Product{
+	product := &model.Product{
 		Name:        r.Name,
 		Description: r.Description,
-		Price:       r.Price,
 		Stock:       r.Stock,
+		Version:     r.Version,
+		Status:      r.Status,
+	}
+	product.SetPrice(requestPrice(r.PriceMinor, r.Price, r.Currency))
+	return product
+}
+
+// requestPrice отдаёт приоритет точной цене в минимальных единицах,
+// устаревшее поле price используется только если price_minor не передан
+func requestPrice(priceMinor int64, price float64, currency string) money.Money {
+	if priceMinor > 0 {
+		return money.New(priceMinor, currency)
+	}
+	return money.FromFloat(price, currency)
+}
+
+// ListProductsRequest — параметры списка продуктов. Cursor имеет приоритет над Page
+type ListProductsRequest struct {
+	Page          int
+	PageSize      int
+	Cursor        string
+	SortBy        string
+	SortOrder     string
+	MinPriceMinor *int64
+	MaxPriceMinor *int64
+	InStock       bool
+	UpdatedSince  *time.Time
+	// CategoryID — категория вместе с подкатегориями; 0 — все продукты
+	CategoryID int64
+	// Status — draft, active или archived; пустой — только активные, как видит каталог покупатель
+	Status string
+	// Currency — только продукты с ценой в этой валюте; обязательна при фильтре или сортировке по цене
+	Currency string
+}
+
+// ProductListResponse - страница списка продуктов
+type ProductListResponse struct {
+	Products   []*ProductResponse `json:"products"`
+	TotalCount int64              `json:"total_count"`
+	Page       int                `json:"page"`
+	PageSize   int                `json:"page_size"`
+	// NextCursor пустой на последней странице
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// SearchProductsRequest — полнотекстовый поиск по названию и описанию
+type SearchProductsRequest struct {
+	Query    string
+	Page     int
+	PageSize int
+}
+
+// ProductSearchResponse - страница результатов поиска, более релевантные первыми
+type ProductSearchResponse struct {
+	Results    []*ProductSearchResult `json:"results"`
+	TotalCount int64                  `json:"total_count"`
+	Page       int                    `json:"page"`
+	PageSize   int                    `json:"page_size"`
+}
+
+// ProductSearchResult - найденный продукт с оценкой релевантности и подсвеченными фрагментами
+type ProductSearchResult struct {
+	Product   *ProductResponse `json:"product"`
+	Rank      float64          `json:"rank"`
+	Highlight SearchHighlight  `json:"highlight"`
+}
+
+// SearchHighlight - фрагменты текста, совпадения обернуты в <b>...</b>
+type SearchHighlight struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// ToProductSearchResult конвертирует найденный продукт в DTO
+func ToProductSearchResult(hit *model.ProductSearchHit) *ProductSearchResult {
+	return &ProductSearchResult{
+		Product: ToProductResponse(&hit.Product),
+		Rank:    hit.Rank,
+		Highlight: SearchHighlight{
+			Name:        hit.NameHighlight,
+			Description: hit.DescriptionHighlight,
+		},
+	}
+}
+
+// AdjustStockRequest — изменение остатка с данными для журнала движения
+type AdjustStockRequest struct {
+	ProductID int64
+	// VariantID — вариант продукта; 0 допустим только для продукта с одним вариантом
+	VariantID int64
+	Delta     int
+	// Reason — зачем изменен остаток (order_created, order_cancelled, ...)
+	Reason string
+	// Reference — внешний идентификатор, обычно ID заказа
+	Reference string
+	// Actor — кто изменил остаток: сервис или пользователь
+	Actor string
+}
+
+// BatchAdjustStockRequest — несколько изменений остатка, применяемых вместе
+type BatchAdjustStockRequest struct {
+	Items     []StockItem
+	Reason    string
+	Reference string
+	Actor     string
+}
+
+type StockItem struct {
+	ProductID int64
+	VariantID int64
+	Delta     int
+}
+
+// StockMovementResponse - DTO записи журнала движения товара
+type StockMovementResponse struct {
+	ID         int64     `json:"id"`
+	ProductID  int64     `json:"product_id"`
+	VariantID  int64     `json:"variant_id,omitempty"`
+	Delta      int       `json:"delta"`
+	StockAfter int       `json:"stock_after"`
+	Reason     string    `json:"reason"`
+	Reference  string    `json:"reference,omitempty"`
+	Actor      string    `json:"actor,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ToStockMovementResponse конвертирует запись журнала в DTO
+func ToStockMovementResponse(m *model.StockMovement) *StockMovementResponse {
+	return &StockMovementResponse{
+		ID:         m.ID,
+		ProductID:  m.ProductID,
+		VariantID:  m.VariantID,
+		Delta:      m.Delta,
+		StockAfter: m.StockAfter,
+		Reason:     m.Reason,
+		Reference:  m.Reference,
+		Actor:      m.Actor,
+		CreatedAt:  m.CreatedAt,
 	}
 }
@@ -0,0 +1,196 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/dto"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/middleware"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/repository"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/service"
+	"github.com/microserviceteam0/bff-gateway/product-service/pkg/logger"
+	"github.com/microserviceteam0/bff-gateway/product-service/pkg/validator"
+)
+
+type CategoryHandler struct {
+	service   service.CategoryService
+	validator *validator.Validator
+}
+
+func NewCategoryHandler(service service.CategoryService) *CategoryHandler {
+	return &CategoryHandler{
+		service:   service,
+		validator: validator.New(),
+	}
+}
+
+func (h *CategoryHandler) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/api/categories", h.GetTree).Methods(http.MethodGet)
+	router.HandleFunc("/api/categories/{id}", h.GetByID).Methods(http.MethodGet)
+	router.HandleFunc("/api/categories", h.Create).Methods(http.MethodPost)
+	router.HandleFunc("/api/categories/{id}", h.Update).Methods(http.MethodPut)
+	router.HandleFunc("/api/categories/{id}", h.Delete).Methods(http.MethodDelete)
+}
+
+// GetTree отдает дерево категорий
+func (h *CategoryHandler) GetTree(w http.ResponseWriter, r *http.Request) {
+	requestID := middleware.GetRequestID(r.Context())
+
+	tree, err := h.service.Tree(r.Context())
+	if err != nil {
+		logger.Error("failed to fetch categories",
+			zap.String("request_id", requestID),
+			zap.Error(err),
+		)
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, tree)
+}
+
+// GetByID отдает категорию с хлебными крошками и подкатегориями
+func (h *CategoryHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	requestID := middleware.GetRequestID(r.Context())
+
+	id, ok := categoryID(w, r)
+	if !ok {
+		return
+	}
+
+	category, err := h.service.GetByID(r.Context(), id)
+	if err != nil {
+		h.respondCategoryError(w, requestID, "failed to fetch category", id, err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, category)
+}
+
+// Create создает категорию
+func (h *CategoryHandler) Create(w http.ResponseWriter, r *http.Request) {
+	requestID := middleware.GetRequestID(r.Context())
+
+	req, ok := h.decodeRequest(w, r)
+	if !ok {
+		return
+	}
+
+	category, err := h.service.Create(r.Context(), req)
+	if err != nil {
+		h.respondCategoryError(w, requestID, "failed to create category", 0, err)
+		return
+	}
+
+	logger.Info("category created successfully",
+		zap.String("request_id", requestID),
+		zap.Int64("category_id", category.ID),
+		zap.String("category_name", category.Name),
+	)
+
+	respondJSON(w, http.StatusCreated, category)
+}
+
+// Update переименовывает категорию или переносит ее к другому родителю
+func (h *CategoryHandler) Update(w http.ResponseWriter, r *http.Request) {
+	requestID := middleware.GetRequestID(r.Context())
+
+	id, ok := categoryID(w, r)
+	if !ok {
+		return
+	}
+	req, ok := h.decodeRequest(w, r)
+	if !ok {
+		return
+	}
+
+	category, err := h.service.Update(r.Context(), id, req)
+	if err != nil {
+		h.respondCategoryError(w, requestID, "failed to update category", id, err)
+		return
+	}
+
+	logger.Info("category updated successfully",
+		zap.String("request_id", requestID),
+		zap.Int64("category_id", category.ID),
+		zap.String("category_name", category.Name),
+	)
+
+	respondJSON(w, http.StatusOK, category)
+}
+
+// Delete удаляет категорию без подкатегорий
+func (h *CategoryHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	requestID := middleware.GetRequestID(r.Context())
+
+	id, ok := categoryID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.service.Delete(r.Context(), id); err != nil {
+		h.respondCategoryError(w, requestID, "failed to delete category", id, err)
+		return
+	}
+
+	logger.Info("category deleted successfully",
+		zap.String("request_id", requestID),
+		zap.Int64("category_id", id),
+	)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *CategoryHandler) decodeRequest(w http.ResponseWriter, r *http.Request) (*dto.CategoryRequest, bool) {
+	var req dto.CategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return nil, false
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+	return &req, true
+}
+
+func (h *CategoryHandler) respondCategoryError(w http.ResponseWriter, requestID, msg string, id int64, err error) {
+	statusCode := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, repository.ErrCategoryNotFound):
+		statusCode = http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidCategory), errors.Is(err, repository.ErrCategoryCycle):
+		statusCode = http.StatusBadRequest
+	case errors.Is(err, repository.ErrCategoryNameTaken), errors.Is(err, repository.ErrCategoryHasChildren):
+		statusCode = http.StatusConflict
+	}
+
+	if statusCode == http.StatusInternalServerError {
+		logger.Error(msg,
+			zap.String("request_id", requestID),
+			zap.Int64("category_id", id),
+			zap.Error(err),
+		)
+	} else {
+		logger.Warn(msg,
+			zap.String("request_id", requestID),
+			zap.Int64("category_id", id),
+			zap.Error(err),
+		)
+	}
+	respondError(w, statusCode, err.Error())
+}
+
+func categoryID(w http.ResponseWriter, r *http.Request) (int64, bool) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid category id")
+		return 0, false
+	}
+	return id, true
+}
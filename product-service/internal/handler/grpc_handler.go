@@ -2,6 +2,10 @@ package handler
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
 
 	"go.uber.org/zap"
 	"google.golang.org/grpc/codes"
@@ -9,17 +13,26 @@ import (
 
 	pb "github.com/microserviceteam0/bff-gateway/product-service/api/proto"
 	"github.com/microserviceteam0/bff-gateway/product-service/internal/dto"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/model"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/repository"
 	"github.com/microserviceteam0/bff-gateway/product-service/internal/service"
 	"github.com/microserviceteam0/bff-gateway/product-service/pkg/logger"
+	"github.com/microserviceteam0/bff-gateway/shared/identity"
 )
 
+// OrderReferences проверяет, упоминается ли продукт в заказах; реализация — клиент order-service
+type OrderReferences interface {
+	ProductOrdered(ctx context.Context, productID int64) (bool, error)
+}
+
 type ProductGRPCHandler struct {
 	pb.UnimplementedProductServiceServer
 	service service.ProductService
+	orders  OrderReferences
 }
 
-func NewProductGRPCHandler(service service.ProductService) *ProductGRPCHandler {
-	return &ProductGRPCHandler{service: service}
+func NewProductGRPCHandler(service service.ProductService, orders OrderReferences) *ProductGRPCHandler {
+	return &ProductGRPCHandler{service: service, orders: orders}
 }
 
 // GetProduct получает один продукт по ID
@@ -42,25 +55,23 @@ func (h *ProductGRPCHandler) GetProduct(ctx context.Context, req *pb.GetProductR
 		zap.String("product_name", product.Name),
 	)
 
-	return &pb.ProductResponse{
-		Id:          product.ID,
-		Name:        product.Name,
-		Description: product.Description,
-		Price:       product.Price,
-		Stock:       int32(product.Stock),
-		CreatedAt:   product.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-		UpdatedAt:   product.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-	}, nil
+	return toProductProto(product), nil
 }
 
-// GetProducts получает несколько продуктов по списку ID
+// GetProducts получает несколько продуктов по списку ID одним запросом к БД
 func (h *ProductGRPCHandler) GetProducts(ctx context.Context, req *pb.GetProductsRequest) (*pb.ProductsResponse, error) {
 	logger.Debug("gRPC GetProducts called",
 		zap.Int("ids_count", len(req.Ids)),
 		zap.Int64s("product_ids", req.Ids),
 	)
 
-	allProducts, err := h.service.GetAll(ctx)
+	found, missing, err := h.service.GetByIDs(ctx, req.Ids)
+	if errors.Is(err, service.ErrBatchTooLarge) {
+		logger.Warn("gRPC GetProducts rejected - batch too large",
+			zap.Int("ids_count", len(req.Ids)),
+		)
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
 	if err != nil {
 		logger.Error("gRPC GetProducts failed",
 			zap.Error(err),
@@ -68,38 +79,131 @@ func (h *ProductGRPCHandler) GetProducts(ctx context.Context, req *pb.GetProduct
 		return nil, status.Errorf(codes.Internal, "failed to get products: %v", err)
 	}
 
-	requestedIDs := make(map[int64]bool)
-	for _, id := range req.Ids {
-		requestedIDs[id] = true
+	products := make([]*pb.ProductResponse, 0, len(found))
+	for _, p := range found {
+		products = append(products, toProductProto(p))
 	}
 
-	var products []*pb.ProductResponse
-	for _, p := range allProducts {
-		if requestedIDs[p.ID] {
-			products = append(products, &pb.ProductResponse{
-				Id:          p.ID,
-				Name:        p.Name,
-				Description: p.Description,
-				Price:       p.Price,
-				Stock:       int32(p.Stock),
-				CreatedAt:   p.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
-				UpdatedAt:   p.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
-			})
+	logger.Info("gRPC GetProducts success",
+		zap.Int("requested_count", len(req.Ids)),
+		zap.Int("returned_count", len(products)),
+		zap.Int64s("missing_ids", missing),
+	)
+
+	return &pb.ProductsResponse{Products: products, MissingIds: missing}, nil
+}
+
+// ListProducts отдает страницу каталога с фильтрами и сортировкой
+func (h *ProductGRPCHandler) ListProducts(ctx context.Context, req *pb.ListProductsRequest) (*pb.ListProductsResponse, error) {
+	logger.Debug("gRPC ListProducts called",
+		zap.Int32("page", req.Page),
+		zap.Int32("page_size", req.PageSize),
+		zap.String("sort_by", req.SortBy),
+	)
+
+	listReq := &dto.ListProductsRequest{
+		Page:          int(req.Page),
+		PageSize:      int(req.PageSize),
+		Cursor:        req.Cursor,
+		SortBy:        req.SortBy,
+		SortOrder:     req.SortOrder,
+		MinPriceMinor: req.MinPriceMinor,
+		MaxPriceMinor: req.MaxPriceMinor,
+		InStock:       req.InStock,
+		CategoryID:    req.CategoryId,
+		Status:        req.Status,
+		Currency:      req.Currency,
+	}
+	if req.UpdatedSince != "" {
+		since, err := time.Parse(time.RFC3339, req.UpdatedSince)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid updated_since, expected RFC 3339: %v", err)
 		}
+		listReq.UpdatedSince = &since
 	}
 
-	logger.Info("gRPC GetProducts success",
-		zap.Int("requested_count", len(req.Ids)),
+	list, err := h.service.List(ctx, listReq)
+	if errors.Is(err, service.ErrInvalidListRequest) {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err != nil {
+		logger.Error("gRPC ListProducts failed",
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "failed to list products: %v", err)
+	}
+
+	products := make([]*pb.ProductResponse, 0, len(list.Products))
+	for _, p := range list.Products {
+		products = append(products, toProductProto(p))
+	}
+
+	logger.Info("gRPC ListProducts success",
 		zap.Int("returned_count", len(products)),
+		zap.Int64("total_count", list.TotalCount),
 	)
 
-	return &pb.ProductsResponse{Products: products}, nil
+	return &pb.ListProductsResponse{
+		Products:   products,
+		TotalCount: list.TotalCount,
+		Page:       int32(list.Page),
+		PageSize:   int32(list.PageSize),
+		NextCursor: list.NextCursor,
+	}, nil
 }
 
-// CheckStock проверяет наличие товара на складе
+// SearchProducts ищет продукты по названию и описанию
+func (h *ProductGRPCHandler) SearchProducts(ctx context.Context, req *pb.SearchProductsRequest) (*pb.SearchProductsResponse, error) {
+	logger.Debug("gRPC SearchProducts called",
+		zap.String("query", req.Query),
+		zap.Int32("page", req.Page),
+		zap.Int32("page_size", req.PageSize),
+	)
+
+	found, err := h.service.Search(ctx, &dto.SearchProductsRequest{
+		Query:    req.Query,
+		Page:     int(req.Page),
+		PageSize: int(req.PageSize),
+	})
+	if errors.Is(err, service.ErrInvalidSearchQuery) {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	if err != nil {
+		logger.Error("gRPC SearchProducts failed",
+			zap.String("query", req.Query),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "failed to search products: %v", err)
+	}
+
+	results := make([]*pb.ProductSearchResult, 0, len(found.Results))
+	for _, r := range found.Results {
+		results = append(results, &pb.ProductSearchResult{
+			Product:              toProductProto(r.Product),
+			Rank:                 r.Rank,
+			NameHighlight:        r.Highlight.Name,
+			DescriptionHighlight: r.Highlight.Description,
+		})
+	}
+
+	logger.Info("gRPC SearchProducts success",
+		zap.Int("returned_count", len(results)),
+		zap.Int64("total_count", found.TotalCount),
+	)
+
+	return &pb.SearchProductsResponse{
+		Results:    results,
+		TotalCount: found.TotalCount,
+		Page:       int32(found.Page),
+		PageSize:   int32(found.PageSize),
+	}, nil
+}
+
+// CheckStock проверяет наличие товара на складе: варианта, если он указан, иначе всего продукта
 func (h *ProductGRPCHandler) CheckStock(ctx context.Context, req *pb.CheckStockRequest) (*pb.CheckStockResponse, error) {
 	logger.Debug("gRPC CheckStock called",
 		zap.Int64("product_id", req.ProductId),
+		zap.Int64("variant_id", req.VariantId),
 		zap.Int32("requested_quantity", req.Quantity),
 	)
 
@@ -112,59 +216,90 @@ func (h *ProductGRPCHandler) CheckStock(ctx context.Context, req *pb.CheckStockR
 		return nil, status.Errorf(codes.NotFound, "product not found: %v", err)
 	}
 
-	available := int32(product.Stock) >= req.Quantity
+	stock := product.Stock
+	if req.VariantId != 0 {
+		i := slices.IndexFunc(product.Variants, func(v *dto.VariantResponse) bool { return v.ID == req.VariantId })
+		if i < 0 {
+			logger.Warn("gRPC CheckStock failed - variant not found",
+				zap.Int64("product_id", req.ProductId),
+				zap.Int64("variant_id", req.VariantId),
+			)
+			return nil, status.Errorf(codes.NotFound, "%v: variant %d of product %d", repository.ErrVariantNotFound, req.VariantId, req.ProductId)
+		}
+		stock = product.Variants[i].Stock
+	}
+
+	// Черновик и архивный продукт заказать нельзя, сколько бы их ни было на складе
+	available := product.Status == model.ProductStatusActive && int32(stock) >= req.Quantity
 
 	logger.Info("gRPC CheckStock completed",
 		zap.Int64("product_id", req.ProductId),
-		zap.Int32("current_stock", int32(product.Stock)),
+		zap.Int64("variant_id", req.VariantId),
+		zap.Int32("current_stock", int32(stock)),
 		zap.Int32("requested_quantity", req.Quantity),
 		zap.Bool("available", available),
 	)
 
 	return &pb.CheckStockResponse{
 		Available:    available,
-		CurrentStock: int32(product.Stock),
+		CurrentStock: int32(stock),
 	}, nil
 }
 
-// UpdateStock обновляет количество товара на складе
+// UpdateStock меняет количество товара на складе одним условным UPDATE, поэтому
+// одновременные заказы не продадут больше, чем есть. Изменение пишется в журнал движения
 func (h *ProductGRPCHandler) UpdateStock(ctx context.Context, req *pb.UpdateStockRequest) (*pb.UpdateStockResponse, error) {
 	logger.Debug("gRPC UpdateStock called",
 		zap.Int64("product_id", req.ProductId),
+		zap.Int64("variant_id", req.VariantId),
 		zap.Int32("quantity_delta", req.QuantityDelta),
+		zap.String("reason", req.Reason),
+		zap.String("reference", req.Reference),
 	)
 
-	product, err := h.service.GetByID(ctx, req.ProductId)
-	if err != nil {
-		logger.Error("gRPC UpdateStock failed - product not found",
+	// Остатки меняют только сервисы (order-service) и администраторы, но не пользователи напрямую
+	p, ok := identity.FromContext(ctx)
+	if !ok || !(p.IsService() || p.IsAdmin()) {
+		logger.Warn("gRPC UpdateStock denied",
 			zap.Int64("product_id", req.ProductId),
-			zap.Error(err),
+			zap.String("caller_service", p.Service),
+			zap.String("caller_role", p.Role),
 		)
-		return nil, status.Errorf(codes.NotFound, "product not found: %v", err)
+		return nil, status.Errorf(codes.PermissionDenied, "stock can only be changed by services or admins")
 	}
 
-	oldStock := int32(product.Stock)
-	newStock := oldStock + req.QuantityDelta
-
-	if newStock < 0 {
+	movement, err := h.service.AdjustStock(ctx, &dto.AdjustStockRequest{
+		ProductID: req.ProductId,
+		VariantID: req.VariantId,
+		Delta:     int(req.QuantityDelta),
+		Reason:    req.Reason,
+		Reference: req.Reference,
+		Actor:     stockActor(p),
+	})
+	switch {
+	case errors.Is(err, repository.ErrInsufficientStock):
 		logger.Warn("gRPC UpdateStock failed - insufficient stock",
 			zap.Int64("product_id", req.ProductId),
-			zap.Int32("current_stock", oldStock),
 			zap.Int32("requested_delta", req.QuantityDelta),
-			zap.Int32("would_be_stock", newStock),
 		)
 		return nil, status.Errorf(codes.InvalidArgument, "insufficient stock")
-	}
-
-	updateReq := &dto.UpdateProductRequest{
-		Name:        product.Name,
-		Description: product.Description,
-		Price:       product.Price,
-		Stock:       int(newStock),
-	}
-
-	updatedProduct, err := h.service.Update(ctx, req.ProductId, updateReq)
-	if err != nil {
+	case errors.Is(err, repository.ErrProductUnavailable):
+		logger.Warn("gRPC UpdateStock failed - product not on sale",
+			zap.Int64("product_id", req.ProductId),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	case errors.Is(err, repository.ErrVariantRequired):
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, repository.ErrProductNotFound), errors.Is(err, repository.ErrVariantNotFound):
+		logger.Error("gRPC UpdateStock failed - product not found",
+			zap.Int64("product_id", req.ProductId),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.NotFound, "product not found: %v", err)
+	case errors.Is(err, service.ErrInvalidStockChange):
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	case err != nil:
 		logger.Error("gRPC UpdateStock failed - update error",
 			zap.Int64("product_id", req.ProductId),
 			zap.Error(err),
@@ -174,13 +309,233 @@ func (h *ProductGRPCHandler) UpdateStock(ctx context.Context, req *pb.UpdateStoc
 
 	logger.Info("gRPC UpdateStock success",
 		zap.Int64("product_id", req.ProductId),
-		zap.String("product_name", updatedProduct.Name),
-		zap.Int32("old_stock", oldStock),
-		zap.Int32("new_stock", int32(updatedProduct.Stock)),
 		zap.Int32("delta", req.QuantityDelta),
+		zap.Int("new_stock", movement.StockAfter),
+		zap.String("reason", movement.Reason),
+		zap.String("actor", movement.Actor),
 	)
 
 	return &pb.UpdateStockResponse{
-		NewStock: int32(updatedProduct.Stock),
+		NewStock:  int32(movement.StockAfter),
+		VariantId: movement.VariantID,
 	}, nil
 }
+
+// BatchUpdateStock применяет изменения остатков нескольких товаров в одной транзакции:
+// либо все, либо ни одного. При нехватке ошибка называет товар
+func (h *ProductGRPCHandler) BatchUpdateStock(ctx context.Context, req *pb.BatchUpdateStockRequest) (*pb.BatchUpdateStockResponse, error) {
+	logger.Debug("gRPC BatchUpdateStock called",
+		zap.Int("items_count", len(req.Items)),
+		zap.String("reason", req.Reason),
+		zap.String("reference", req.Reference),
+	)
+
+	p, ok := identity.FromContext(ctx)
+	if !ok || !(p.IsService() || p.IsAdmin()) {
+		logger.Warn("gRPC BatchUpdateStock denied",
+			zap.String("caller_service", p.Service),
+			zap.String("caller_role", p.Role),
+		)
+		return nil, status.Errorf(codes.PermissionDenied, "stock can only be changed by services or admins")
+	}
+
+	items := make([]dto.StockItem, len(req.Items))
+	for i, item := range req.Items {
+		items[i] = dto.StockItem{ProductID: item.ProductId, VariantID: item.VariantId, Delta: int(item.QuantityDelta)}
+	}
+
+	movements, err := h.service.AdjustStockBatch(ctx, &dto.BatchAdjustStockRequest{
+		Items:     items,
+		Reason:    req.Reason,
+		Reference: req.Reference,
+		Actor:     stockActor(p),
+	})
+	switch {
+	case errors.Is(err, repository.ErrInsufficientStock):
+		logger.Warn("gRPC BatchUpdateStock failed - insufficient stock",
+			zap.String("reference", req.Reference),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, repository.ErrProductUnavailable):
+		logger.Warn("gRPC BatchUpdateStock failed - product not on sale",
+			zap.String("reference", req.Reference),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	case errors.Is(err, repository.ErrProductNotFound), errors.Is(err, repository.ErrVariantNotFound):
+		logger.Warn("gRPC BatchUpdateStock failed - product not found",
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.NotFound, "%v", err)
+	case errors.Is(err, service.ErrInvalidStockChange), errors.Is(err, service.ErrBatchTooLarge), errors.Is(err, repository.ErrVariantRequired):
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	case err != nil:
+		logger.Error("gRPC BatchUpdateStock failed - update error",
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "failed to update stock: %v", err)
+	}
+
+	results := make([]*pb.StockAdjustmentResult, len(movements))
+	for i, m := range movements {
+		results[i] = &pb.StockAdjustmentResult{
+			ProductId:     m.ProductID,
+			VariantId:     m.VariantID,
+			QuantityDelta: int32(m.Delta),
+			NewStock:      int32(m.StockAfter),
+		}
+	}
+
+	logger.Info("gRPC BatchUpdateStock success",
+		zap.Int("items_count", len(results)),
+		zap.String("reason", req.Reason),
+		zap.String("reference", req.Reference),
+		zap.String("actor", stockActor(p)),
+	)
+
+	return &pb.BatchUpdateStockResponse{Results: results}, nil
+}
+
+// PurgeProduct безвозвратно удаляет архивный продукт. Только для администраторов; продукт из заказов
+// не удаляется, иначе пропали бы названия товаров в их истории. Заказы проверяются в order-service
+// от имени того же администратора: поиск заказов доступен только администраторам
+func (h *ProductGRPCHandler) PurgeProduct(ctx context.Context, req *pb.PurgeProductRequest) (*pb.PurgeProductResponse, error) {
+	logger.Debug("gRPC PurgeProduct called",
+		zap.Int64("product_id", req.Id),
+	)
+
+	p, ok := identity.FromContext(ctx)
+	if !ok || !p.IsAdmin() {
+		logger.Warn("gRPC PurgeProduct denied",
+			zap.Int64("product_id", req.Id),
+			zap.String("caller_service", p.Service),
+			zap.String("caller_role", p.Role),
+		)
+		return nil, status.Errorf(codes.PermissionDenied, "products can only be purged by admins")
+	}
+
+	ordered, err := h.orders.ProductOrdered(identity.NewOutgoingContext(ctx, identity.Principal{UserID: p.UserID, Role: p.Role}), req.Id)
+	if err != nil {
+		logger.Error("gRPC PurgeProduct - order check failed",
+			zap.Int64("product_id", req.Id),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Unavailable, "failed to check orders of product %d: %v", req.Id, err)
+	}
+	if ordered {
+		return nil, status.Errorf(codes.FailedPrecondition, "product %d is referenced by orders", req.Id)
+	}
+
+	err = h.service.Purge(ctx, req.Id)
+	switch {
+	case errors.Is(err, service.ErrMediaCleanup):
+		// Продукт удален, оставшиеся файлы изображений не мешают вызывающему
+		logger.Warn("gRPC PurgeProduct - image files were not removed",
+			zap.Int64("product_id", req.Id),
+			zap.Error(err),
+		)
+	case errors.Is(err, repository.ErrProductNotFound):
+		return nil, status.Errorf(codes.NotFound, "%v", err)
+	case errors.Is(err, repository.ErrProductNotArchived):
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	case err != nil:
+		logger.Error("gRPC PurgeProduct failed",
+			zap.Int64("product_id", req.Id),
+			zap.Error(err),
+		)
+		return nil, status.Errorf(codes.Internal, "failed to purge product: %v", err)
+	}
+
+	logger.Info("gRPC PurgeProduct success",
+		zap.Int64("product_id", req.Id),
+		zap.String("actor", stockActor(p)),
+	)
+
+	return &pb.PurgeProductResponse{}, nil
+}
+
+// stockActor — автор изменения для журнала: имя сервиса или роль с ID пользователя
+func stockActor(p identity.Principal) string {
+	if p.IsService() {
+		return p.Service
+	}
+	return fmt.Sprintf("%s:%d", p.Role, p.UserID)
+}
+
+// toProductProto конвертирует DTO продукта в gRPC сообщение
+func toProductProto(p *dto.ProductResponse) *pb.ProductResponse {
+	return &pb.ProductResponse{
+		Id:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       p.Price,
+		PriceMinor:  p.PriceMinor,
+		Currency:    p.Currency,
+		Stock:       int32(p.Stock),
+		Version:     p.Version,
+		CreatedAt:   p.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:   p.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Categories:  toCategoryPathsProto(p.Categories),
+		Variants:    toVariantsProto(p.Variants),
+		Images:      toImagesProto(p.Images),
+		Status:      p.Status,
+		DeletedAt:   formatOptionalTime(p.DeletedAt),
+
+		CompareAtPriceMinor: p.CompareAtPriceMinor,
+	}
+}
+
+// formatOptionalTime форматирует время как created_at; nil — пустая строка
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("2006-01-02T15:04:05Z07:00")
+}
+
+func toImagesProto(images []*dto.ImageResponse) []*pb.ProductImage {
+	result := make([]*pb.ProductImage, 0, len(images))
+	for _, img := range images {
+		result = append(result, &pb.ProductImage{
+			Id:          img.ID,
+			Url:         img.URL,
+			ContentType: img.ContentType,
+			Width:       int32(img.Width),
+			Height:      int32(img.Height),
+			AltText:     img.AltText,
+			Thumbnails:  img.Thumbnails,
+		})
+	}
+	return result
+}
+
+func toVariantsProto(variants []*dto.VariantResponse) []*pb.ProductVariant {
+	result := make([]*pb.ProductVariant, 0, len(variants))
+	for _, v := range variants {
+		result = append(result, &pb.ProductVariant{
+			Id:                 v.ID,
+			Sku:                v.SKU,
+			Attributes:         v.Attributes,
+			PriceMinor:         v.PriceMinor,
+			PriceOverrideMinor: v.PriceOverrideMinor,
+			Stock:              int32(v.Stock),
+		})
+	}
+	return result
+}
+
+func toCategoryPathsProto(paths []model.CategoryPath) []*pb.CategoryPath {
+	if len(paths) == 0 {
+		return nil
+	}
+	result := make([]*pb.CategoryPath, 0, len(paths))
+	for _, path := range paths {
+		refs := make([]*pb.CategoryRef, 0, len(path))
+		for _, c := range path {
+			refs = append(refs, &pb.CategoryRef{Id: c.ID, Name: c.Name})
+		}
+		result = append(result, &pb.CategoryPath{Categories: refs})
+	}
+	return result
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	pb "github.com/microserviceteam0/bff-gateway/product-service/api/proto"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/service"
+	"github.com/microserviceteam0/bff-gateway/product-service/pkg/logger"
+	"github.com/microserviceteam0/bff-gateway/shared/identity"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// purgeService реализует только Purge; остальные методы паникуют через nil-интерфейс
+type purgeService struct {
+	service.ProductService
+	purged []int64
+}
+
+func (s *purgeService) Purge(ctx context.Context, id int64) error {
+	s.purged = append(s.purged, id)
+	return nil
+}
+
+type fakeOrders struct {
+	ordered bool
+	err     error
+	checked bool
+}
+
+func (f *fakeOrders) ProductOrdered(ctx context.Context, productID int64) (bool, error) {
+	f.checked = true
+	return f.ordered, f.err
+}
+
+func TestPurgeProductChecksOrders(t *testing.T) {
+	logger.Log = zap.NewNop()
+	admin := identity.NewContext(context.Background(), identity.Principal{UserID: 7, Role: identity.RoleAdmin, Service: "bff"})
+
+	tests := []struct {
+		name       string
+		ctx        context.Context
+		orders     *fakeOrders
+		wantCode   codes.Code
+		wantPurged bool
+	}{
+		{name: "not ordered", ctx: admin, orders: &fakeOrders{}, wantCode: codes.OK, wantPurged: true},
+		{name: "ordered", ctx: admin, orders: &fakeOrders{ordered: true}, wantCode: codes.FailedPrecondition},
+		{name: "order service down", ctx: admin, orders: &fakeOrders{err: errors.New("unavailable")}, wantCode: codes.Unavailable},
+		{
+			name:     "not admin",
+			ctx:      identity.NewContext(context.Background(), identity.Principal{Role: identity.RoleService, Service: "order-service"}),
+			orders:   &fakeOrders{},
+			wantCode: codes.PermissionDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &purgeService{}
+			h := NewProductGRPCHandler(svc, tt.orders)
+
+			_, err := h.PurgeProduct(tt.ctx, &pb.PurgeProductRequest{Id: 1})
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("PurgeProduct() code = %v, want %v (%v)", status.Code(err), tt.wantCode, err)
+			}
+			if purged := len(svc.purged) > 0; purged != tt.wantPurged {
+				t.Errorf("purged = %v, want %v", purged, tt.wantPurged)
+			}
+			if tt.wantCode == codes.PermissionDenied && tt.orders.checked {
+				t.Error("orders must not be checked for a caller without access")
+			}
+		})
+	}
+}
@@ -2,17 +2,24 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"go.uber.org/zap"
 
 	"github.com/microserviceteam0/bff-gateway/product-service/internal/dto"
 	"github.com/microserviceteam0/bff-gateway/product-service/internal/middleware"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/repository"
 	"github.com/microserviceteam0/bff-gateway/product-service/internal/service"
 	"github.com/microserviceteam0/bff-gateway/product-service/pkg/logger"
 	"github.com/microserviceteam0/bff-gateway/product-service/pkg/validator"
+	"github.com/microserviceteam0/bff-gateway/shared/etag"
 )
 
 type ProductHandler struct {
@@ -29,21 +36,52 @@ func NewProductHandler(service service.ProductService) *ProductHandler {
 
 func (h *ProductHandler) RegisterRoutes(router *mux.Router) {
 	router.HandleFunc("/api/products", h.GetAll).Methods(http.MethodGet)
+	// Регистрируется раньше /api/products/{id}, иначе "search" примется за id
+	router.HandleFunc("/api/products/search", h.Search).Methods(http.MethodGet)
 	router.HandleFunc("/api/products/{id}", h.GetByID).Methods(http.MethodGet)
 	router.HandleFunc("/api/products", h.Create).Methods(http.MethodPost)
 	router.HandleFunc("/api/products/{id}", h.Update).Methods(http.MethodPut)
 	router.HandleFunc("/api/products/{id}", h.Delete).Methods(http.MethodDelete)
+	router.HandleFunc("/api/products/{id}/restore", h.Restore).Methods(http.MethodPost)
+	router.HandleFunc("/api/products/{id}/stock-movements", h.GetStockMovements).Methods(http.MethodGet)
+	router.HandleFunc("/api/products/{id}/categories", h.SetCategories).Methods(http.MethodPut)
+	router.HandleFunc("/api/products/{id}/variants", h.CreateVariant).Methods(http.MethodPost)
+	router.HandleFunc("/api/products/{id}/variants/{variantId}", h.UpdateVariant).Methods(http.MethodPut)
+	router.HandleFunc("/api/products/{id}/variants/{variantId}", h.DeleteVariant).Methods(http.MethodDelete)
+	router.HandleFunc("/api/products/{id}/images", h.UploadImage).Methods(http.MethodPost)
+	router.HandleFunc("/api/products/{id}/images/order", h.ReorderImages).Methods(http.MethodPut)
+	router.HandleFunc("/api/products/{id}/images/{imageId}", h.DeleteImage).Methods(http.MethodDelete)
+	router.HandleFunc("/api/products/{id}/prices", h.GetPriceHistory).Methods(http.MethodGet)
+	router.HandleFunc("/api/products/{id}/price-schedules", h.GetPriceSchedules).Methods(http.MethodGet)
+	router.HandleFunc("/api/products/{id}/price-schedules", h.SchedulePrice).Methods(http.MethodPost)
+	router.HandleFunc("/api/products/{id}/price-schedules/{scheduleId}", h.CancelPriceSchedule).Methods(http.MethodDelete)
 }
 
-// GetAll получить все продукты
+// GetAll отдает страницу каталога. Тело — массив продуктов, как раньше; общее число — в X-Total-Count,
+// следующая страница — в Link (rel="next") и X-Next-Cursor
 func (h *ProductHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 	requestID := middleware.GetRequestID(r.Context())
 
-	logger.Debug("fetching all products",
+	req, err := parseListRequest(r)
+	if err != nil {
+		logger.Warn("invalid product list query",
+			zap.String("request_id", requestID),
+			zap.Error(err),
+		)
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	logger.Debug("fetching products",
 		zap.String("request_id", requestID),
+		zap.String("query", r.URL.RawQuery),
 	)
 
-	products, err := h.service.GetAll(r.Context())
+	list, err := h.service.List(r.Context(), req)
+	if errors.Is(err, service.ErrInvalidListRequest) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
 	if err != nil {
 		logger.Error("failed to fetch products",
 			zap.String("request_id", requestID),
@@ -55,10 +93,147 @@ func (h *ProductHandler) GetAll(w http.ResponseWriter, r *http.Request) {
 
 	logger.Info("products fetched successfully",
 		zap.String("request_id", requestID),
-		zap.Int("count", len(products)),
+		zap.Int("count", len(list.Products)),
+		zap.Int64("total", list.TotalCount),
+	)
+
+	w.Header().Set("X-Total-Count", strconv.FormatInt(list.TotalCount, 10))
+	if links := listLinks(r.URL, req, list); links != "" {
+		w.Header().Set("Link", links)
+	}
+	if list.NextCursor != "" {
+		w.Header().Set("X-Next-Cursor", list.NextCursor)
+	}
+	respondJSON(w, http.StatusOK, list.Products)
+}
+
+// Search ищет продукты по строке q; page и page_size — как у списка
+func (h *ProductHandler) Search(w http.ResponseWriter, r *http.Request) {
+	requestID := middleware.GetRequestID(r.Context())
+
+	query := r.URL.Query()
+	req := &dto.SearchProductsRequest{Query: query.Get("q")}
+	var err error
+	if v := query.Get("page"); v != "" {
+		if req.Page, err = strconv.Atoi(v); err != nil {
+			respondError(w, http.StatusBadRequest, "invalid page")
+			return
+		}
+	}
+	if v := query.Get("page_size"); v != "" {
+		if req.PageSize, err = strconv.Atoi(v); err != nil {
+			respondError(w, http.StatusBadRequest, "invalid page_size")
+			return
+		}
+	}
+
+	logger.Debug("searching products",
+		zap.String("request_id", requestID),
+		zap.String("query", req.Query),
+	)
+
+	found, err := h.service.Search(r.Context(), req)
+	if errors.Is(err, service.ErrInvalidSearchQuery) {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		logger.Error("failed to search products",
+			zap.String("request_id", requestID),
+			zap.String("query", req.Query),
+			zap.Error(err),
+		)
+		respondError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	logger.Info("products searched successfully",
+		zap.String("request_id", requestID),
+		zap.Int("count", len(found.Results)),
+		zap.Int64("total", found.TotalCount),
 	)
 
-	respondJSON(w, http.StatusOK, products)
+	respondJSON(w, http.StatusOK, found)
+}
+
+// parseListRequest читает параметры списка: page, page_size, cursor, sort, order,
+// min_price_minor, max_price_minor, currency, in_stock, updated_since (RFC 3339), category_id, status
+func parseListRequest(r *http.Request) (*dto.ListProductsRequest, error) {
+	query := r.URL.Query()
+	req := &dto.ListProductsRequest{
+		Cursor:    query.Get("cursor"),
+		SortBy:    query.Get("sort"),
+		SortOrder: query.Get("order"),
+		Status:    query.Get("status"),
+		Currency:  query.Get("currency"),
+	}
+
+	var err error
+	if v := query.Get("page"); v != "" {
+		if req.Page, err = strconv.Atoi(v); err != nil {
+			return nil, errors.New("invalid page")
+		}
+	}
+	if v := query.Get("page_size"); v != "" {
+		if req.PageSize, err = strconv.Atoi(v); err != nil {
+			return nil, errors.New("invalid page_size")
+		}
+	}
+	if v := query.Get("min_price_minor"); v != "" {
+		price, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, errors.New("invalid min_price_minor")
+		}
+		req.MinPriceMinor = &price
+	}
+	if v := query.Get("max_price_minor"); v != "" {
+		price, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, errors.New("invalid max_price_minor")
+		}
+		req.MaxPriceMinor = &price
+	}
+	if v := query.Get("in_stock"); v != "" {
+		if req.InStock, err = strconv.ParseBool(v); err != nil {
+			return nil, errors.New("invalid in_stock")
+		}
+	}
+	if v := query.Get("updated_since"); v != "" {
+		since, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return nil, errors.New("invalid updated_since, expected RFC 3339")
+		}
+		req.UpdatedSince = &since
+	}
+	if v := query.Get("category_id"); v != "" {
+		if req.CategoryID, err = strconv.ParseInt(v, 10, 64); err != nil {
+			return nil, errors.New("invalid category_id")
+		}
+	}
+	return req, nil
+}
+
+// listLinks строит заголовок Link: next по курсору, prev — для постраничного режима
+func listLinks(u *url.URL, req *dto.ListProductsRequest, list *dto.ProductListResponse) string {
+	link := func(rel string, set func(q url.Values)) string {
+		q := u.Query()
+		set(q)
+		return fmt.Sprintf(`<%s?%s>; rel="%s"`, u.Path, q.Encode(), rel)
+	}
+
+	var links []string
+	if list.NextCursor != "" {
+		links = append(links, link("next", func(q url.Values) {
+			q.Del("page")
+			q.Set("cursor", list.NextCursor)
+		}))
+	}
+	if req.Cursor == "" && list.Page > 1 {
+		links = append(links, link("prev", func(q url.Values) {
+			q.Set("page", strconv.Itoa(list.Page-1))
+		}))
+	}
+	return strings.Join(links, ", ")
 }
 
 // GetByID получить продукт по id
@@ -99,6 +274,7 @@ func (h *ProductHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 		zap.String("product_name", product.Name),
 	)
 
+	w.Header().Set("ETag", etag.Format(product.Version))
 	respondJSON(w, http.StatusOK, product)
 }
 
@@ -134,6 +310,15 @@ func (h *ProductHandler) Create(w http.ResponseWriter, r *http.Request) {
 	)
 
 	product, err := h.service.Create(r.Context(), &req)
+	if errors.Is(err, service.ErrInvalidVariant) || errors.Is(err, repository.ErrSKUTaken) {
+		logger.Warn("create product rejected - invalid default variant",
+			zap.String("request_id", requestID),
+			zap.String("product_name", req.Name),
+			zap.Error(err),
+		)
+		respondError(w, variantErrorStatus(err), err.Error())
+		return
+	}
 	if err != nil {
 		logger.Error("failed to create product",
 			zap.String("request_id", requestID),
@@ -152,10 +337,12 @@ func (h *ProductHandler) Create(w http.ResponseWriter, r *http.Request) {
 		zap.Int("stock", product.Stock),
 	)
 
+	w.Header().Set("ETag", etag.Format(product.Version))
 	respondJSON(w, http.StatusCreated, product)
 }
 
-// Update обновляет продукт
+// Update обновляет продукт. Ожидаемая версия берется из If-Match или поля version;
+// при несовпадении возвращается 412 (If-Match) или 409 (version в теле)
 func (h *ProductHandler) Update(w http.ResponseWriter, r *http.Request) {
 	requestID := middleware.GetRequestID(r.Context())
 
@@ -192,13 +379,52 @@ func (h *ProductHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ifMatch, err := etag.ParseIfMatch(r.Header.Get("If-Match"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if ifMatch != 0 {
+		req.Version = ifMatch
+	}
+
 	logger.Debug("updating product",
 		zap.String("request_id", requestID),
 		zap.Int64("product_id", id),
 		zap.String("product_name", req.Name),
+		zap.Int64("expected_version", req.Version),
 	)
 
 	product, err := h.service.Update(r.Context(), id, &req)
+	if errors.Is(err, repository.ErrVersionConflict) {
+		logger.Warn("product update rejected - stale version",
+			zap.String("request_id", requestID),
+			zap.Int64("product_id", id),
+			zap.Int64("expected_version", req.Version),
+		)
+		if ifMatch != 0 {
+			respondError(w, http.StatusPreconditionFailed, err.Error())
+		} else {
+			respondError(w, http.StatusConflict, err.Error())
+		}
+		return
+	}
+	if errors.Is(err, repository.ErrVariantRequired) {
+		logger.Warn("product update rejected - stock of a multi-variant product",
+			zap.String("request_id", requestID),
+			zap.Int64("product_id", id),
+		)
+		respondError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if errors.Is(err, repository.ErrProductArchived) {
+		logger.Warn("product update rejected - status of an archived product",
+			zap.String("request_id", requestID),
+			zap.Int64("product_id", id),
+		)
+		respondError(w, http.StatusConflict, err.Error())
+		return
+	}
 	if err != nil {
 		logger.Error("failed to update product",
 			zap.String("request_id", requestID),
@@ -215,11 +441,14 @@ func (h *ProductHandler) Update(w http.ResponseWriter, r *http.Request) {
 		zap.String("product_name", product.Name),
 		zap.Float64("price", product.Price),
 		zap.Int("stock", product.Stock),
+		zap.Int64("version", product.Version),
 	)
 
+	w.Header().Set("ETag", etag.Format(product.Version))
 	respondJSON(w, http.StatusOK, product)
 }
 
+// Delete переводит продукт в архив: он пропадает из каталога, но по-прежнему отдается по ID
 func (h *ProductHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	requestID := middleware.GetRequestID(r.Context())
 
@@ -235,22 +464,26 @@ func (h *ProductHandler) Delete(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	logger.Debug("deleting product",
+	logger.Debug("archiving product",
 		zap.String("request_id", requestID),
 		zap.Int64("product_id", id),
 	)
 
 	if err := h.service.Delete(r.Context(), id); err != nil {
-		logger.Error("failed to delete product",
+		statusCode := http.StatusInternalServerError
+		if errors.Is(err, repository.ErrProductNotFound) {
+			statusCode = http.StatusNotFound
+		}
+		logger.Error("failed to archive product",
 			zap.String("request_id", requestID),
 			zap.Int64("product_id", id),
 			zap.Error(err),
 		)
-		respondError(w, http.StatusNotFound, err.Error())
+		respondError(w, statusCode, err.Error())
 		return
 	}
 
-	logger.Info("product deleted successfully",
+	logger.Info("product archived successfully",
 		zap.String("request_id", requestID),
 		zap.Int64("product_id", id),
 	)
@@ -258,6 +491,164 @@ func (h *ProductHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// Restore возвращает архивный продукт в каталог; продукт не из архива — 409
+func (h *ProductHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	requestID := middleware.GetRequestID(r.Context())
+
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		logger.Warn("invalid product id format",
+			zap.String("request_id", requestID),
+			zap.String("id", vars["id"]),
+			zap.Error(err),
+		)
+		respondError(w, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	product, err := h.service.Restore(r.Context(), id)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, repository.ErrProductNotFound):
+			statusCode = http.StatusNotFound
+		case errors.Is(err, repository.ErrProductNotArchived):
+			statusCode = http.StatusConflict
+		}
+		logger.Warn("failed to restore product",
+			zap.String("request_id", requestID),
+			zap.Int64("product_id", id),
+			zap.Error(err),
+		)
+		respondError(w, statusCode, err.Error())
+		return
+	}
+
+	logger.Info("product restored successfully",
+		zap.String("request_id", requestID),
+		zap.Int64("product_id", id),
+		zap.Int64("version", product.Version),
+	)
+
+	w.Header().Set("ETag", etag.Format(product.Version))
+	respondJSON(w, http.StatusOK, product)
+}
+
+// GetStockMovements отдает журнал движения товара, новые записи первыми (limit, offset в query)
+func (h *ProductHandler) GetStockMovements(w http.ResponseWriter, r *http.Request) {
+	requestID := middleware.GetRequestID(r.Context())
+
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		logger.Warn("invalid product id format",
+			zap.String("request_id", requestID),
+			zap.String("id", vars["id"]),
+			zap.Error(err),
+		)
+		respondError(w, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	limit, offset, err := parsePage(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	movements, err := h.service.ListStockMovements(r.Context(), id, limit, offset)
+	if err != nil {
+		logger.Error("failed to fetch stock movements",
+			zap.String("request_id", requestID),
+			zap.Int64("product_id", id),
+			zap.Error(err),
+		)
+		respondError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	logger.Info("stock movements fetched successfully",
+		zap.String("request_id", requestID),
+		zap.Int64("product_id", id),
+		zap.Int("count", len(movements)),
+	)
+
+	respondJSON(w, http.StatusOK, movements)
+}
+
+// SetCategories заменяет категории продукта списком category_ids
+func (h *ProductHandler) SetCategories(w http.ResponseWriter, r *http.Request) {
+	requestID := middleware.GetRequestID(r.Context())
+
+	vars := mux.Vars(r)
+	id, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		logger.Warn("invalid product id format",
+			zap.String("request_id", requestID),
+			zap.String("id", vars["id"]),
+			zap.Error(err),
+		)
+		respondError(w, http.StatusBadRequest, "invalid product id")
+		return
+	}
+
+	var req dto.SetProductCategoriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.CategoryIDs == nil {
+		respondError(w, http.StatusBadRequest, "invalid request body, expected category_ids")
+		return
+	}
+
+	logger.Debug("setting product categories",
+		zap.String("request_id", requestID),
+		zap.Int64("product_id", id),
+		zap.Int64s("category_ids", req.CategoryIDs),
+	)
+
+	product, err := h.service.SetCategories(r.Context(), id, req.CategoryIDs)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrInvalidCategories), errors.Is(err, repository.ErrCategoryNotFound):
+			statusCode = http.StatusBadRequest
+		case errors.Is(err, repository.ErrProductNotFound):
+			statusCode = http.StatusNotFound
+		}
+		logger.Warn("failed to set product categories",
+			zap.String("request_id", requestID),
+			zap.Int64("product_id", id),
+			zap.Error(err),
+		)
+		respondError(w, statusCode, err.Error())
+		return
+	}
+
+	logger.Info("product categories updated",
+		zap.String("request_id", requestID),
+		zap.Int64("product_id", id),
+		zap.Int("categories", len(product.Categories)),
+	)
+
+	w.Header().Set("ETag", etag.Format(product.Version))
+	respondJSON(w, http.StatusOK, product)
+}
+
+// parsePage читает необязательные limit и offset из query
+func parsePage(r *http.Request) (limit, offset int, err error) {
+	query := r.URL.Query()
+	if v := query.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil || limit < 0 {
+			return 0, 0, errors.New("invalid limit")
+		}
+	}
+	if v := query.Get("offset"); v != "" {
+		if offset, err = strconv.Atoi(v); err != nil || offset < 0 {
+			return 0, 0, errors.New("invalid offset")
+		}
+	}
+	return limit, offset, nil
+}
+
 func respondJSON(w http.ResponseWriter, statusCode int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 
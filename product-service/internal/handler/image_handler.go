@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/dto"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/middleware"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/repository"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/service"
+	"github.com/microserviceteam0/bff-gateway/product-service/pkg/logger"
+	"github.com/microserviceteam0/bff-gateway/shared/etag"
+)
+
+// multipartOverhead — запас на заголовки частей и поле alt_text сверх размера файла
+const multipartOverhead = 1 << 20
+
+// UploadImage принимает multipart/form-data с файлом в поле file и необязательным alt_text
+func (h *ProductHandler) UploadImage(w http.ResponseWriter, r *http.Request) {
+	requestID := middleware.GetRequestID(r.Context())
+
+	productID, _, ok := imageIDs(w, r, false)
+	if !ok {
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, service.MaxImageBytes+multipartOverhead)
+	if err := r.ParseMultipartForm(multipartOverhead); err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			respondError(w, http.StatusRequestEntityTooLarge, service.ErrImageTooLarge.Error())
+			return
+		}
+		respondError(w, http.StatusBadRequest, "request must be multipart/form-data with a file field")
+		return
+	}
+	defer func() { _ = r.MultipartForm.RemoveAll() }()
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "file field is required")
+		return
+	}
+	defer func() { _ = file.Close() }()
+
+	product, err := h.service.UploadImage(r.Context(), productID, file, r.FormValue("alt_text"))
+	if err != nil {
+		respondImageError(w, requestID, "failed to upload product image", productID, 0, err)
+		return
+	}
+
+	logger.Info("product image uploaded successfully",
+		zap.String("request_id", requestID),
+		zap.Int64("product_id", productID),
+		zap.String("filename", header.Filename),
+		zap.Int64("size", header.Size),
+	)
+
+	w.Header().Set("ETag", etag.Format(product.Version))
+	respondJSON(w, http.StatusCreated, product)
+}
+
+// DeleteImage удаляет изображение; следующие изображения галереи сдвигаются на его место
+func (h *ProductHandler) DeleteImage(w http.ResponseWriter, r *http.Request) {
+	requestID := middleware.GetRequestID(r.Context())
+
+	productID, imageID, ok := imageIDs(w, r, true)
+	if !ok {
+		return
+	}
+
+	err := h.service.DeleteImage(r.Context(), productID, imageID)
+	if errors.Is(err, service.ErrMediaCleanup) {
+		// Запись удалена, оставшиеся файлы не видны клиенту
+		logger.Warn("product image deleted, but its files were not removed",
+			zap.String("request_id", requestID),
+			zap.Int64("product_id", productID),
+			zap.Int64("image_id", imageID),
+			zap.Error(err),
+		)
+		err = nil
+	}
+	if err != nil {
+		respondImageError(w, requestID, "failed to delete product image", productID, imageID, err)
+		return
+	}
+
+	logger.Info("product image deleted successfully",
+		zap.String("request_id", requestID),
+		zap.Int64("product_id", productID),
+		zap.Int64("image_id", imageID),
+	)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ReorderImages задает порядок галереи списком id всех изображений продукта
+func (h *ProductHandler) ReorderImages(w http.ResponseWriter, r *http.Request) {
+	requestID := middleware.GetRequestID(r.Context())
+
+	productID, _, ok := imageIDs(w, r, false)
+	if !ok {
+		return
+	}
+
+	var req dto.ReorderImagesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	product, err := h.service.ReorderImages(r.Context(), productID, req.ImageIDs)
+	if err != nil {
+		respondImageError(w, requestID, "failed to reorder product images", productID, 0, err)
+		return
+	}
+
+	logger.Info("product images reordered successfully",
+		zap.String("request_id", requestID),
+		zap.Int64("product_id", productID),
+		zap.Int64s("image_ids", req.ImageIDs),
+	)
+
+	w.Header().Set("ETag", etag.Format(product.Version))
+	respondJSON(w, http.StatusOK, product)
+}
+
+func respondImageError(w http.ResponseWriter, requestID, msg string, productID, imageID int64, err error) {
+	statusCode := imageErrorStatus(err)
+
+	if statusCode == http.StatusInternalServerError {
+		logger.Error(msg,
+			zap.String("request_id", requestID),
+			zap.Int64("product_id", productID),
+			zap.Int64("image_id", imageID),
+			zap.Error(err),
+		)
+	} else {
+		logger.Warn(msg,
+			zap.String("request_id", requestID),
+			zap.Int64("product_id", productID),
+			zap.Int64("image_id", imageID),
+			zap.Error(err),
+		)
+	}
+	respondError(w, statusCode, err.Error())
+}
+
+// imageErrorStatus переводит ошибки изображений в HTTP-статус; прочие ошибки — 500
+func imageErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrProductNotFound), errors.Is(err, repository.ErrImageNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidImage), errors.Is(err, repository.ErrImageOrderMismatch):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrImageTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, service.ErrUnsupportedImageType):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, repository.ErrTooManyImages):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// imageIDs читает id продукта и, если нужно, imageId из пути
+func imageIDs(w http.ResponseWriter, r *http.Request, withImage bool) (int64, int64, bool) {
+	vars := mux.Vars(r)
+	productID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid product id")
+		return 0, 0, false
+	}
+	if !withImage {
+		return productID, 0, true
+	}
+	imageID, err := strconv.ParseInt(vars["imageId"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid image id")
+		return 0, 0, false
+	}
+	return productID, imageID, true
+}
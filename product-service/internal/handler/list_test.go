@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/dto"
+)
+
+func TestParseListRequest(t *testing.T) {
+	r := httptest.NewRequest("GET", "/api/products?page=2&page_size=10&sort=price&order=desc&min_price_minor=100&currency=USD&in_stock=true&updated_since=2025-12-11T10:00:00Z", nil)
+
+	req, err := parseListRequest(r)
+	if err != nil {
+		t.Fatalf("parseListRequest: %v", err)
+	}
+	if req.Page != 2 || req.PageSize != 10 || req.SortBy != "price" || req.SortOrder != "desc" || !req.InStock {
+		t.Errorf("unexpected request %+v", req)
+	}
+	if req.MinPriceMinor == nil || *req.MinPriceMinor != 100 || req.MaxPriceMinor != nil || req.Currency != "USD" {
+		t.Errorf("unexpected price range %v - %v %s", req.MinPriceMinor, req.MaxPriceMinor, req.Currency)
+	}
+	if req.UpdatedSince == nil || req.UpdatedSince.Year() != 2025 {
+		t.Errorf("unexpected updated_since %v", req.UpdatedSince)
+	}
+
+	for _, query := range []string{"page=x", "min_price_minor=1.5", "in_stock=maybe", "updated_since=yesterday"} {
+		if _, err := parseListRequest(httptest.NewRequest("GET", "/api/products?"+query, nil)); err == nil {
+			t.Errorf("%s: expected error", query)
+		}
+	}
+}
+
+func TestListLinks(t *testing.T) {
+	r := httptest.NewRequest("GET", "/api/products?page=2&sort=name", nil)
+	req := &dto.ListProductsRequest{Page: 2, SortBy: "name"}
+
+	links := listLinks(r.URL, req, &dto.ProductListResponse{Page: 2, NextCursor: "abc"})
+	want := `</api/products?cursor=abc&sort=name>; rel="next", </api/products?page=1&sort=name>; rel="prev"`
+	if links != want {
+		t.Errorf("listLinks() = %s, want %s", links, want)
+	}
+
+	if links := listLinks(r.URL, req, &dto.ProductListResponse{Page: 1}); links != "" {
+		t.Errorf("expected no links on the only page, got %s", links)
+	}
+}
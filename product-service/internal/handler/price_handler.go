@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/dto"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/middleware"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/repository"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/service"
+	"github.com/microserviceteam0/bff-gateway/product-service/pkg/logger"
+)
+
+// GetPriceHistory отдает историю цены продукта, новые записи первыми (limit, offset в query)
+func (h *ProductHandler) GetPriceHistory(w http.ResponseWriter, r *http.Request) {
+	requestID := middleware.GetRequestID(r.Context())
+
+	productID, _, ok := priceScheduleIDs(w, r, false)
+	if !ok {
+		return
+	}
+
+	limit, offset, err := parsePage(r)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	changes, err := h.service.PriceHistory(r.Context(), productID, limit, offset)
+	if err != nil {
+		logger.Error("failed to fetch price history",
+			zap.String("request_id", requestID),
+			zap.Int64("product_id", productID),
+			zap.Error(err),
+		)
+		respondError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	logger.Info("price history fetched successfully",
+		zap.String("request_id", requestID),
+		zap.Int64("product_id", productID),
+		zap.Int("count", len(changes)),
+	)
+
+	respondJSON(w, http.StatusOK, changes)
+}
+
+// GetPriceSchedules отдает запланированные цены продукта по времени начала
+func (h *ProductHandler) GetPriceSchedules(w http.ResponseWriter, r *http.Request) {
+	requestID := middleware.GetRequestID(r.Context())
+
+	productID, _, ok := priceScheduleIDs(w, r, false)
+	if !ok {
+		return
+	}
+
+	schedules, err := h.service.PriceSchedules(r.Context(), productID)
+	if err != nil {
+		logger.Error("failed to fetch price schedules",
+			zap.String("request_id", requestID),
+			zap.Int64("product_id", productID),
+			zap.Error(err),
+		)
+		respondError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	respondJSON(w, http.StatusOK, schedules)
+}
+
+// SchedulePrice планирует цену продукта; с ends_at по окончании вернется прежняя цена
+func (h *ProductHandler) SchedulePrice(w http.ResponseWriter, r *http.Request) {
+	requestID := middleware.GetRequestID(r.Context())
+
+	productID, _, ok := priceScheduleIDs(w, r, false)
+	if !ok {
+		return
+	}
+
+	var req dto.SchedulePriceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	schedule, err := h.service.SchedulePrice(r.Context(), productID, &req)
+	if err != nil {
+		respondPriceError(w, requestID, "failed to schedule price", productID, 0, err)
+		return
+	}
+
+	logger.Info("price scheduled successfully",
+		zap.String("request_id", requestID),
+		zap.Int64("product_id", productID),
+		zap.Int64("schedule_id", schedule.ID),
+		zap.Int64("price_minor", schedule.PriceMinor),
+		zap.Time("starts_at", schedule.StartsAt),
+	)
+
+	respondJSON(w, http.StatusCreated, schedule)
+}
+
+// CancelPriceSchedule отменяет запланированную цену; идущая распродажа завершается сразу
+func (h *ProductHandler) CancelPriceSchedule(w http.ResponseWriter, r *http.Request) {
+	requestID := middleware.GetRequestID(r.Context())
+
+	productID, scheduleID, ok := priceScheduleIDs(w, r, true)
+	if !ok {
+		return
+	}
+
+	if err := h.service.CancelPriceSchedule(r.Context(), productID, scheduleID); err != nil {
+		respondPriceError(w, requestID, "failed to cancel price schedule", productID, scheduleID, err)
+		return
+	}
+
+	logger.Info("price schedule cancelled successfully",
+		zap.String("request_id", requestID),
+		zap.Int64("product_id", productID),
+		zap.Int64("schedule_id", scheduleID),
+	)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func respondPriceError(w http.ResponseWriter, requestID, msg string, productID, scheduleID int64, err error) {
+	statusCode := priceErrorStatus(err)
+
+	if statusCode == http.StatusInternalServerError {
+		logger.Error(msg,
+			zap.String("request_id", requestID),
+			zap.Int64("product_id", productID),
+			zap.Int64("schedule_id", scheduleID),
+			zap.Error(err),
+		)
+	} else {
+		logger.Warn(msg,
+			zap.String("request_id", requestID),
+			zap.Int64("product_id", productID),
+			zap.Int64("schedule_id", scheduleID),
+			zap.Error(err),
+		)
+	}
+	respondError(w, statusCode, err.Error())
+}
+
+// priceErrorStatus переводит ошибки запланированных цен в HTTP-статус; прочие ошибки — 500
+func priceErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrProductNotFound), errors.Is(err, repository.ErrPriceScheduleNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidPriceSchedule):
+		return http.StatusBadRequest
+	case errors.Is(err, repository.ErrPriceScheduleOverlap), errors.Is(err, repository.ErrPriceScheduleFinished):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// priceScheduleIDs читает id продукта и, если нужно, scheduleId из пути
+func priceScheduleIDs(w http.ResponseWriter, r *http.Request, withSchedule bool) (int64, int64, bool) {
+	vars := mux.Vars(r)
+	productID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid product id")
+		return 0, 0, false
+	}
+	if !withSchedule {
+		return productID, 0, true
+	}
+	scheduleID, err := strconv.ParseInt(vars["scheduleId"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid schedule id")
+		return 0, 0, false
+	}
+	return productID, scheduleID, true
+}
@@ -0,0 +1,167 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"go.uber.org/zap"
+
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/dto"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/middleware"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/repository"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/service"
+	"github.com/microserviceteam0/bff-gateway/product-service/pkg/logger"
+	"github.com/microserviceteam0/bff-gateway/shared/etag"
+)
+
+// CreateVariant добавляет вариант продукта и отдает продукт со всеми вариантами
+func (h *ProductHandler) CreateVariant(w http.ResponseWriter, r *http.Request) {
+	requestID := middleware.GetRequestID(r.Context())
+
+	productID, _, ok := variantIDs(w, r, false)
+	if !ok {
+		return
+	}
+	req, ok := h.decodeVariantRequest(w, r)
+	if !ok {
+		return
+	}
+
+	product, err := h.service.CreateVariant(r.Context(), productID, req)
+	if err != nil {
+		respondVariantError(w, requestID, "failed to create product variant", productID, 0, err)
+		return
+	}
+
+	logger.Info("product variant created successfully",
+		zap.String("request_id", requestID),
+		zap.Int64("product_id", productID),
+		zap.String("sku", req.SKU),
+	)
+
+	w.Header().Set("ETag", etag.Format(product.Version))
+	respondJSON(w, http.StatusCreated, product)
+}
+
+// UpdateVariant заменяет SKU, атрибуты, цену и остаток варианта
+func (h *ProductHandler) UpdateVariant(w http.ResponseWriter, r *http.Request) {
+	requestID := middleware.GetRequestID(r.Context())
+
+	productID, variantID, ok := variantIDs(w, r, true)
+	if !ok {
+		return
+	}
+	req, ok := h.decodeVariantRequest(w, r)
+	if !ok {
+		return
+	}
+
+	product, err := h.service.UpdateVariant(r.Context(), productID, variantID, req)
+	if err != nil {
+		respondVariantError(w, requestID, "failed to update product variant", productID, variantID, err)
+		return
+	}
+
+	logger.Info("product variant updated successfully",
+		zap.String("request_id", requestID),
+		zap.Int64("product_id", productID),
+		zap.Int64("variant_id", variantID),
+		zap.String("sku", req.SKU),
+		zap.Int("stock", req.Stock),
+	)
+
+	w.Header().Set("ETag", etag.Format(product.Version))
+	respondJSON(w, http.StatusOK, product)
+}
+
+// DeleteVariant удаляет вариант; последний вариант продукта удалить нельзя
+func (h *ProductHandler) DeleteVariant(w http.ResponseWriter, r *http.Request) {
+	requestID := middleware.GetRequestID(r.Context())
+
+	productID, variantID, ok := variantIDs(w, r, true)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteVariant(r.Context(), productID, variantID); err != nil {
+		respondVariantError(w, requestID, "failed to delete product variant", productID, variantID, err)
+		return
+	}
+
+	logger.Info("product variant deleted successfully",
+		zap.String("request_id", requestID),
+		zap.Int64("product_id", productID),
+		zap.Int64("variant_id", variantID),
+	)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *ProductHandler) decodeVariantRequest(w http.ResponseWriter, r *http.Request) (*dto.VariantRequest, bool) {
+	var req dto.VariantRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "invalid request body")
+		return nil, false
+	}
+	if err := h.validator.Validate(&req); err != nil {
+		respondError(w, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+	return &req, true
+}
+
+func respondVariantError(w http.ResponseWriter, requestID, msg string, productID, variantID int64, err error) {
+	statusCode := variantErrorStatus(err)
+
+	if statusCode == http.StatusInternalServerError {
+		logger.Error(msg,
+			zap.String("request_id", requestID),
+			zap.Int64("product_id", productID),
+			zap.Int64("variant_id", variantID),
+			zap.Error(err),
+		)
+	} else {
+		logger.Warn(msg,
+			zap.String("request_id", requestID),
+			zap.Int64("product_id", productID),
+			zap.Int64("variant_id", variantID),
+			zap.Error(err),
+		)
+	}
+	respondError(w, statusCode, err.Error())
+}
+
+// variantErrorStatus переводит ошибки вариантов в HTTP-статус; прочие ошибки — 500
+func variantErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, repository.ErrProductNotFound), errors.Is(err, repository.ErrVariantNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidVariant):
+		return http.StatusBadRequest
+	case errors.Is(err, repository.ErrSKUTaken), errors.Is(err, repository.ErrLastVariant), errors.Is(err, repository.ErrVariantRequired):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// variantIDs читает id продукта и, если нужно, variantId из пути
+func variantIDs(w http.ResponseWriter, r *http.Request, withVariant bool) (int64, int64, bool) {
+	vars := mux.Vars(r)
+	productID, err := strconv.ParseInt(vars["id"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid product id")
+		return 0, 0, false
+	}
+	if !withVariant {
+		return productID, 0, true
+	}
+	variantID, err := strconv.ParseInt(vars["variantId"], 10, 64)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "invalid variant id")
+		return 0, 0, false
+	}
+	return productID, variantID, true
+}
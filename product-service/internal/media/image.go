@@ -0,0 +1,128 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+
+	// Декодеры регистрируются для image.Decode
+	_ "image/gif"
+
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/model"
+)
+
+// ErrUnsupportedType возвращается для файлов, которые не являются JPEG, PNG или GIF
+var ErrUnsupportedType = errors.New("unsupported image type")
+
+// ErrInvalidImage возвращается для поврежденного изображения или слишком большого разрешения
+var ErrInvalidImage = errors.New("invalid image")
+
+// MaxPixels ограничивает разрешение, чтобы маленький файл не развернулся в гигабайты памяти
+const MaxPixels = 40_000_000
+
+// Расширения файлов по типу содержимого
+var extensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// Image — проверенное и декодированное изображение
+type Image struct {
+	ContentType string
+	Ext         string
+	Width       int
+	Height      int
+	img         image.Image
+}
+
+// Decode определяет тип по содержимому (заголовку Content-Type клиента не доверяем) и декодирует изображение
+func Decode(data []byte) (*Image, error) {
+	contentType := http.DetectContentType(data)
+	ext, ok := extensions[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, contentType)
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > MaxPixels {
+		return nil, fmt.Errorf("%w: %dx%d pixels", ErrInvalidImage, cfg.Width, cfg.Height)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	return &Image{ContentType: contentType, Ext: ext, Width: cfg.Width, Height: cfg.Height, img: img}, nil
+}
+
+// Thumbnail пишет в w копию изображения, вписанную в maxSide×maxSide, в формате contentType (image/jpeg или image/png)
+func (i *Image) Thumbnail(w io.Writer, maxSide int, contentType string) error {
+	thumb := resize(i.img, maxSide)
+	if contentType == "image/jpeg" {
+		return jpeg.Encode(w, thumb, &jpeg.Options{Quality: 85})
+	}
+	return png.Encode(w, thumb)
+}
+
+// resize уменьшает изображение усреднением по области (box filter). Цвета взвешиваются по альфе,
+// иначе по краям прозрачных областей появляется темная кайма
+func resize(src image.Image, maxSide int) *image.NRGBA {
+	img := toNRGBA(src)
+	sw, sh := img.Rect.Dx(), img.Rect.Dy()
+	w, h := model.FitWithin(sw, sh, maxSide)
+	if w == sw && h == sh {
+		return img
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		y0, y1 := y*sh/h, max((y+1)*sh/h, y*sh/h+1)
+		for x := range w {
+			x0, x1 := x*sw/w, max((x+1)*sw/w, x*sw/w+1)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				off := sy*img.Stride + x0*4
+				for sx := x0; sx < x1; sx++ {
+					p := img.Pix[off : off+4 : off+4]
+					alpha := uint64(p[3])
+					r += uint64(p[0]) * alpha
+					g += uint64(p[1]) * alpha
+					b += uint64(p[2]) * alpha
+					a += alpha
+					n++
+					off += 4
+				}
+			}
+
+			d := dst.PixOffset(x, y)
+			if a > 0 {
+				dst.Pix[d] = uint8(r / a)
+				dst.Pix[d+1] = uint8(g / a)
+				dst.Pix[d+2] = uint8(b / a)
+			}
+			dst.Pix[d+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
+
+func toNRGBA(src image.Image) *image.NRGBA {
+	if img, ok := src.(*image.NRGBA); ok && img.Rect.Min == (image.Point{}) {
+		return img
+	}
+	b := src.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Rect, src, b.Min, draw.Src)
+	return dst
+}
@@ -0,0 +1,21 @@
+package model
+
+import "time"
+
+// Category — узел дерева категорий; ParentID == nil у категорий верхнего уровня
+type Category struct {
+	ID        int64     `json:"id" db:"id"`
+	Name      string    `json:"name" db:"name"`
+	ParentID  *int64    `json:"parent_id" db:"parent_id"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CategoryRef — категория в хлебных крошках
+type CategoryRef struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// CategoryPath — хлебные крошки: путь от корня дерева до категории включительно
+type CategoryPath []CategoryRef
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"path"
+	"strings"
+	"time"
+)
+
+// ThumbnailSize — миниатюра, вписанная в квадрат MaxSide×MaxSide
+type ThumbnailSize struct {
+	Name    string
+	MaxSide int
+}
+
+// ThumbnailSizes — миниатюры, которые создаются для каждого изображения
+var ThumbnailSizes = []ThumbnailSize{
+	{Name: "small", MaxSide: 160},
+	{Name: "medium", MaxSide: 480},
+	{Name: "large", MaxSide: 1024},
+}
+
+// ProductImage — изображение продукта; Position задает порядок в галерее, 0 — главное изображение
+type ProductImage struct {
+	ID          int64     `json:"id" db:"id"`
+	ProductID   int64     `json:"product_id" db:"product_id"`
+	Position    int       `json:"position" db:"position"`
+	StorageKey  string    `json:"storage_key" db:"storage_key"`
+	ContentType string    `json:"content_type" db:"content_type"`
+	SizeBytes   int64     `json:"size_bytes" db:"size_bytes"`
+	Width       int       `json:"width" db:"width"`
+	Height      int       `json:"height" db:"height"`
+	AltText     string    `json:"alt_text" db:"alt_text"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	// URL и Thumbnails заполняет сервис по ключам хранилища
+	URL        string           `json:"url" db:"-"`
+	Thumbnails []ImageThumbnail `json:"thumbnails,omitempty" db:"-"`
+}
+
+// ImageThumbnail — миниатюра изображения в одном из ThumbnailSizes
+type ImageThumbnail struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+}
+
+// ThumbnailContentType — формат миниатюр: JPEG для фотографий, PNG для остального, чтобы сохранить прозрачность
+func (i *ProductImage) ThumbnailContentType() string {
+	if i.ContentType == "image/jpeg" {
+		return "image/jpeg"
+	}
+	return "image/png"
+}
+
+// ThumbnailKey — ключ миниатюры рядом с оригиналом: products/1/abc.png → products/1/abc_small.png
+func (i *ProductImage) ThumbnailKey(name string) string {
+	ext := ".png"
+	if i.ThumbnailContentType() == "image/jpeg" {
+		ext = ".jpg"
+	}
+	return strings.TrimSuffix(i.StorageKey, path.Ext(i.StorageKey)) + "_" + name + ext
+}
+
+// Keys — ключи оригинала и всех миниатюр
+func (i *ProductImage) Keys() []string {
+	keys := []string{i.StorageKey}
+	for _, size := range ThumbnailSizes {
+		keys = append(keys, i.ThumbnailKey(size.Name))
+	}
+	return keys
+}
+
+// FitWithin уменьшает размеры с сохранением пропорций, чтобы большая сторона не превышала maxSide; не увеличивает
+func FitWithin(width, height, maxSide int) (int, int) {
+	if width <= maxSide && height <= maxSide {
+		return width, height
+	}
+	if width >= height {
+		return maxSide, max(1, height*maxSide/width)
+	}
+	return max(1, width*maxSide/height), maxSide
+}
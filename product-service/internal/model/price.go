@@ -0,0 +1,80 @@
+package model
+
+import (
+	"time"
+
+	"github.com/microserviceteam0/bff-gateway/shared/money"
+)
+
+// Причины записей истории цен
+const (
+	PriceReasonInitial = "initial"
+	PriceReasonManual  = "manual"
+	// PriceReasonScheduled — запланированная цена вступила в силу
+	PriceReasonScheduled = "scheduled"
+	// PriceReasonScheduleEnded — распродажа закончилась, цена вернулась к прежней
+	PriceReasonScheduleEnded = "schedule_ended"
+	// PriceReasonScheduleCancelled — идущую распродажу отменили, цена вернулась к прежней
+	PriceReasonScheduleCancelled = "schedule_cancelled"
+)
+
+// Авторы изменений цены, которые ставит сам сервис
+const (
+	PriceActorAPI       = StockActorAPI
+	PriceActorScheduler = "price-scheduler"
+)
+
+// Статусы запланированной цены
+const (
+	PriceSchedulePending = "pending"
+	// PriceScheduleActive — распродажа идет, по окончании цена вернется к RevertPriceMinor
+	PriceScheduleActive    = "active"
+	PriceScheduleCompleted = "completed"
+	PriceScheduleCancelled = "cancelled"
+)
+
+// PriceChange — запись истории цены продукта; Previous* пусты у начальной цены
+type PriceChange struct {
+	ID                 int64     `json:"id" db:"id"`
+	ProductID          int64     `json:"product_id" db:"product_id"`
+	PriceMinor         int64     `json:"price_minor" db:"price_minor"`
+	Currency           string    `json:"currency" db:"currency"`
+	PreviousPriceMinor *int64    `json:"previous_price_minor" db:"previous_price_minor"`
+	PreviousCurrency   *string   `json:"previous_currency" db:"previous_currency"`
+	Reason             string    `json:"reason" db:"reason"`
+	ScheduleID         *int64    `json:"schedule_id" db:"schedule_id"`
+	Actor              string    `json:"actor" db:"actor"`
+	CreatedAt          time.Time `json:"created_at" db:"created_at"`
+}
+
+// PriceSchedule — цена, которая вступит в силу в StartsAt. С EndsAt это распродажа:
+// в EndsAt цена вернется к той, что была на момент начала
+type PriceSchedule struct {
+	ID         int64      `json:"id" db:"id"`
+	ProductID  int64      `json:"product_id" db:"product_id"`
+	PriceMinor int64      `json:"price_minor" db:"price_minor"`
+	Currency   string     `json:"currency" db:"currency"`
+	StartsAt   time.Time  `json:"starts_at" db:"starts_at"`
+	EndsAt     *time.Time `json:"ends_at" db:"ends_at"`
+	Status     string     `json:"status" db:"status"`
+	// RevertPriceMinor и RevertCurrency запоминаются при начале распродажи
+	RevertPriceMinor *int64     `json:"revert_price_minor" db:"revert_price_minor"`
+	RevertCurrency   *string    `json:"revert_currency" db:"revert_currency"`
+	Actor            string     `json:"actor" db:"actor"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	AppliedAt        *time.Time `json:"applied_at" db:"applied_at"`
+	FinishedAt       *time.Time `json:"finished_at" db:"finished_at"`
+}
+
+// Price — запланированная цена
+func (s *PriceSchedule) Price() money.Money {
+	return money.New(s.PriceMinor, s.Currency)
+}
+
+// RevertPrice — цена, к которой вернется продукт по окончании распродажи; ok = false, если она еще не запомнена
+func (s *PriceSchedule) RevertPrice() (money.Money, bool) {
+	if s.RevertPriceMinor == nil || s.RevertCurrency == nil {
+		return money.Money{}, false
+	}
+	return money.New(*s.RevertPriceMinor, *s.RevertCurrency), true
+}
@@ -1,13 +1,65 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"github.com/microserviceteam0/bff-gateway/shared/money"
+)
+
+// Статусы продукта. Покупателям видны и доступны для заказа только активные продукты;
+// архивные скрыты из каталога, но по-прежнему находятся по ID для истории заказов
+const (
+	ProductStatusDraft    = "draft"
+	ProductStatusActive   = "active"
+	ProductStatusArchived = "archived"
+)
 
 type Product struct {
 	ID          int64     `json:"id" db:"id"`
 	Name        string    `json:"name" db:"name"`
 	Description string    `json:"description" db:"description"`
 	Price       float64   `json:"price" db:"price"`
+	PriceMinor  int64     `json:"price_minor" db:"price_minor"`
+	Currency    string    `json:"currency" db:"currency"`
 	Stock       int       `json:"stock" db:"stock"`
+	Version     int64     `json:"version" db:"version"`
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+	Status      string    `json:"status" db:"status"`
+	// DeletedAt — время архивации; задано только у архивных продуктов
+	DeletedAt *time.Time `json:"deleted_at,omitempty" db:"deleted_at"`
+	// Categories — хлебные крошки всех категорий продукта; заполняет сервис, а не запросы к products
+	Categories []CategoryPath `json:"categories,omitempty" db:"-"`
+	// Variants — варианты продукта по возрастанию id; заполняет сервис
+	Variants []*ProductVariant `json:"variants,omitempty" db:"-"`
+	// Images — изображения продукта по возрастанию position; заполняет сервис
+	Images []*ProductImage `json:"images,omitempty" db:"-"`
+	// CompareAtPriceMinor — цена до идущей распродажи, если она выше текущей; заполняет сервис
+	CompareAtPriceMinor *int64 `json:"compare_at_price_minor,omitempty" db:"-"`
+}
+
+// PriceMoney возвращает цену как точную денежную сумму
+func (p *Product) PriceMoney() money.Money {
+	return money.New(p.PriceMinor, p.Currency)
+}
+
+// OnSale сообщает, можно ли заказать продукт
+func (p *Product) OnSale() bool {
+	return p.Status == ProductStatusActive
+}
+
+// SetPrice устанавливает цену и синхронизирует устаревшее поле Price
+func (p *Product) SetPrice(price money.Money) {
+	p.PriceMinor = price.Amount
+	p.Currency = price.Currency
+	p.Price = price.Float64()
+}
+
+// ProductSearchHit — продукт, найденный полнотекстовым поиском
+type ProductSearchHit struct {
+	Product
+	Rank float64
+	// Фрагменты названия и описания, совпадения выделены <b>...</b>
+	NameHighlight        string
+	DescriptionHighlight string
 }
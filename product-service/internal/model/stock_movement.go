@@ -0,0 +1,28 @@
+package model
+
+import "time"
+
+// Причины изменения остатка, которые ставит сам сервис; остальные передают вызывающие сервисы
+const (
+	StockReasonInitial = "initial"
+	StockReasonManual  = "manual"
+	// StockReasonVariantDeleted списывает остаток удаленного варианта
+	StockReasonVariantDeleted = "variant_deleted"
+)
+
+// StockActorAPI — автор изменений, сделанных через REST API
+const StockActorAPI = "rest-api"
+
+// StockMovement — запись журнала движения товара; StockAfter — остаток варианта после изменения
+type StockMovement struct {
+	ID        int64 `json:"id" db:"id"`
+	ProductID int64 `json:"product_id" db:"product_id"`
+	// VariantID — вариант, чей остаток изменился; 0 у записей до появления вариантов
+	VariantID  int64     `json:"variant_id" db:"variant_id"`
+	Delta      int       `json:"delta" db:"delta"`
+	StockAfter int       `json:"stock_after" db:"stock_after"`
+	Reason     string    `json:"reason" db:"reason"`
+	Reference  string    `json:"reference" db:"reference"`
+	Actor      string    `json:"actor" db:"actor"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
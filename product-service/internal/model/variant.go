@@ -0,0 +1,25 @@
+package model
+
+import "time"
+
+// ProductVariant — вариант продукта (размер, цвет...) со своим артикулом и остатком.
+// У каждого продукта есть хотя бы один вариант; Product.Stock — сумма остатков вариантов
+type ProductVariant struct {
+	ID         int64             `json:"id" db:"id"`
+	ProductID  int64             `json:"product_id" db:"product_id"`
+	SKU        string            `json:"sku" db:"sku"`
+	Attributes map[string]string `json:"attributes" db:"attributes"`
+	// PriceMinor переопределяет цену продукта; nil — вариант стоит как продукт
+	PriceMinor *int64    `json:"price_minor,omitempty" db:"price_minor"`
+	Stock      int       `json:"stock" db:"stock"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// EffectivePriceMinor возвращает цену варианта с учетом цены продукта
+func (v *ProductVariant) EffectivePriceMinor(p *Product) int64 {
+	if v.PriceMinor != nil {
+		return *v.PriceMinor
+	}
+	return p.PriceMinor
+}
@@ -0,0 +1,31 @@
+package pricing
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	runsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "product_price_scheduler_runs_total",
+			Help: "Total number of price scheduler runs",
+		},
+		[]string{"result"},
+	)
+
+	schedulesApplied = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "product_price_schedules_applied_total",
+			Help: "Total number of scheduled prices started or ended",
+		},
+	)
+
+	runDuration = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "product_price_scheduler_run_duration_seconds",
+			Help:    "Price scheduler run latency in seconds",
+			Buckets: []float64{.01, .05, .1, .25, .5, 1, 2.5, 5, 10},
+		},
+	)
+)
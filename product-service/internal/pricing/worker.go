@@ -0,0 +1,93 @@
+// Package pricing применяет запланированные цены: начинает их в starts_at и возвращает прежнюю цену
+// по окончании распродажи в ends_at.
+package pricing
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/microserviceteam0/bff-gateway/product-service/pkg/logger"
+)
+
+// maxBatchesPerRun ограничивает работу одного запуска, чтобы большой хвост разбирался постепенно
+const maxBatchesPerRun = 10
+
+// Applier применяет наступившие запланированные цены; реализуется сервисом продуктов
+type Applier interface {
+	ApplyDuePriceSchedules(ctx context.Context, now time.Time, limit int) (int, error)
+}
+
+type Config struct {
+	Interval time.Duration
+	// BatchSize — сколько запланированных цен обрабатывается за один вызов Applier
+	BatchSize int
+}
+
+type Worker struct {
+	applier Applier
+	cfg     Config
+	now     func() time.Time
+}
+
+func NewWorker(applier Applier, cfg Config) *Worker {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 30 * time.Second
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 100
+	}
+	return &Worker{applier: applier, cfg: cfg, now: time.Now}
+}
+
+// Run применяет цены сразу и затем раз в Interval до отмены контекста
+func (w *Worker) Run(ctx context.Context) {
+	logger.Info("price scheduler started",
+		zap.Duration("interval", w.cfg.Interval),
+		zap.Int("batch_size", w.cfg.BatchSize),
+	)
+
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		applied, err := w.RunOnce(ctx)
+		if err != nil {
+			logger.Error("price scheduler run failed", zap.Error(err))
+		} else if applied > 0 {
+			logger.Info("price scheduler run finished", zap.Int("applied", applied))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunOnce обрабатывает наступившие запланированные цены пачками и возвращает их количество
+func (w *Worker) RunOnce(ctx context.Context) (int, error) {
+	start := w.now()
+	defer func() {
+		runDuration.Observe(time.Since(start).Seconds())
+	}()
+
+	total := 0
+	for batch := 0; batch < maxBatchesPerRun; batch++ {
+		applied, err := w.applier.ApplyDuePriceSchedules(ctx, start, w.cfg.BatchSize)
+		total += applied
+		schedulesApplied.Add(float64(applied))
+		if err != nil {
+			runsTotal.WithLabelValues("error").Inc()
+			return total, err
+		}
+		if applied < w.cfg.BatchSize {
+			break
+		}
+	}
+
+	runsTotal.WithLabelValues("success").Inc()
+	return total, nil
+}
@@ -0,0 +1,86 @@
+package pricing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+type mockApplier struct {
+	batches []int
+	calls   int
+	nows    []time.Time
+	err     error
+}
+
+func (m *mockApplier) ApplyDuePriceSchedules(ctx context.Context, now time.Time, limit int) (int, error) {
+	m.nows = append(m.nows, now)
+	if m.calls >= len(m.batches) {
+		return 0, m.err
+	}
+	applied := m.batches[m.calls]
+	m.calls++
+	return applied, nil
+}
+
+func TestRunOnce(t *testing.T) {
+	tests := []struct {
+		name          string
+		batches       []int
+		err           error
+		expectedTotal int
+		expectedCalls int
+		expectError   bool
+	}{
+		{
+			name:          "Drains full batches until a short one",
+			batches:       []int{2, 2, 1},
+			expectedTotal: 5,
+			expectedCalls: 3,
+		},
+		{
+			name:          "Nothing due",
+			expectedCalls: 1,
+		},
+		{
+			name:          "Error stops the run and keeps the count",
+			batches:       []int{2},
+			err:           errors.New("db down"),
+			expectedTotal: 2,
+			expectedCalls: 2,
+			expectError:   true,
+		},
+		{
+			name:          "Stops after max batches",
+			batches:       []int{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2},
+			expectedTotal: 2 * maxBatchesPerRun,
+			expectedCalls: maxBatchesPerRun,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applier := &mockApplier{batches: tt.batches, err: tt.err}
+			now := time.Date(2025, 12, 11, 10, 0, 0, 0, time.UTC)
+			w := NewWorker(applier, Config{BatchSize: 2})
+			w.now = func() time.Time { return now }
+
+			total, err := w.RunOnce(context.Background())
+			if (err != nil) != tt.expectError {
+				t.Fatalf("Expected error %v, got %v", tt.expectError, err)
+			}
+			if total != tt.expectedTotal {
+				t.Errorf("Expected %d applied, got %d", tt.expectedTotal, total)
+			}
+			if len(applier.nows) != tt.expectedCalls {
+				t.Errorf("Expected %d calls, got %d", tt.expectedCalls, len(applier.nows))
+			}
+			for _, n := range applier.nows {
+				if !n.Equal(now) {
+					t.Errorf("Expected every batch to use the run start time, got %v", n)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,321 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/model"
+	"github.com/microserviceteam0/bff-gateway/shared/metrics"
+)
+
+var (
+	// ErrCategoryNotFound возвращается для несуществующей категории, в том числе при привязке продукта
+	ErrCategoryNotFound = errors.New("category not found")
+	// ErrCategoryNameTaken — у родителя уже есть подкатегория с таким названием
+	ErrCategoryNameTaken = errors.New("category name already taken")
+	// ErrCategoryHasChildren — категорию с подкатегориями нельзя удалить
+	ErrCategoryHasChildren = errors.New("category has subcategories")
+	// ErrCategoryCycle — категорию нельзя перенести в саму себя или в свою подкатегорию
+	ErrCategoryCycle = errors.New("category cannot be moved into its own subtree")
+)
+
+// Коды ошибок PostgreSQL
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
+
+type CategoryRepository interface {
+	FindByID(ctx context.Context, id int64) (*model.Category, error)
+	// FindAll отдает все категории, упорядоченные по названию
+	FindAll(ctx context.Context) ([]*model.Category, error)
+	Create(ctx context.Context, category *model.Category) error
+	// Update меняет название и родителя. Перенос в собственное поддерево — ErrCategoryCycle
+	Update(ctx context.Context, category *model.Category) error
+	// Delete удаляет категорию и ее связи с продуктами; сами продукты остаются
+	Delete(ctx context.Context, id int64) error
+	// Path отдает хлебные крошки категории от корня дерева
+	Path(ctx context.Context, id int64) (model.CategoryPath, error)
+}
+
+type postgresCategoryRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresCategoryRepository(db *sql.DB) CategoryRepository {
+	return &postgresCategoryRepository{db: db}
+}
+
+func (r *postgresCategoryRepository) FindByID(ctx context.Context, id int64) (*model.Category, error) {
+	start := time.Now()
+
+	query := `SELECT id, name, parent_id, created_at, updated_at FROM categories WHERE id = $1`
+
+	var category model.Category
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&category.ID,
+		&category.Name,
+		&category.ParentID,
+		&category.CreatedAt,
+		&category.UpdatedAt,
+	)
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "SELECT").Observe(duration)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: id %d", ErrCategoryNotFound, id)
+	}
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+func (r *postgresCategoryRepository) FindAll(ctx context.Context) ([]*model.Category, error) {
+	start := time.Now()
+
+	query := `SELECT id, name, parent_id, created_at, updated_at FROM categories ORDER BY name, id`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		duration := time.Since(start).Seconds()
+		metrics.DBQueryDuration.WithLabelValues("product-service", "SELECT").Observe(duration)
+		metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var categories []*model.Category
+	for rows.Next() {
+		var category model.Category
+		err := rows.Scan(
+			&category.ID,
+			&category.Name,
+			&category.ParentID,
+			&category.CreatedAt,
+			&category.UpdatedAt,
+		)
+		if err != nil {
+			metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+			return nil, err
+		}
+		categories = append(categories, &category)
+	}
+
+	if err = rows.Err(); err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+		return nil, err
+	}
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "SELECT").Observe(duration)
+
+	return categories, nil
+}
+
+func (r *postgresCategoryRepository) Create(ctx context.Context, category *model.Category) error {
+	start := time.Now()
+
+	query := `
+		INSERT INTO categories (name, parent_id)
+		VALUES ($1, $2)
+		RETURNING id, created_at, updated_at
+	`
+
+	err := r.db.QueryRowContext(ctx, query, category.Name, category.ParentID).Scan(
+		&category.ID,
+		&category.CreatedAt,
+		&category.UpdatedAt,
+	)
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "INSERT").Observe(duration)
+
+	if err != nil {
+		if mapped := categoryWriteError(err, category.ParentID); mapped != err {
+			return mapped
+		}
+		metrics.DBErrors.WithLabelValues("product-service", "INSERT").Inc()
+		return err
+	}
+
+	return nil
+}
+
+func (r *postgresCategoryRepository) Update(ctx context.Context, category *model.Category) error {
+	start := time.Now()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "UPDATE").Inc()
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Переносы выполняются по одному: два встречных переноса не замкнут дерево в цикл
+	_, err = tx.ExecContext(ctx, `LOCK TABLE categories IN SHARE ROW EXCLUSIVE MODE`)
+	if err == nil && category.ParentID != nil {
+		err = checkCategoryCycle(ctx, tx, category.ID, *category.ParentID)
+	}
+	if err == nil {
+		query := `
+			UPDATE categories
+			SET name = $1, parent_id = $2
+			WHERE id = $3
+			RETURNING created_at, updated_at
+		`
+		err = tx.QueryRowContext(ctx, query, category.Name, category.ParentID, category.ID).Scan(
+			&category.CreatedAt,
+			&category.UpdatedAt,
+		)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "UPDATE").Observe(duration)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: id %d", ErrCategoryNotFound, category.ID)
+	}
+	if errors.Is(err, ErrCategoryCycle) {
+		return err
+	}
+
+	if err != nil {
+		if mapped := categoryWriteError(err, category.ParentID); mapped != err {
+			return mapped
+		}
+		metrics.DBErrors.WithLabelValues("product-service", "UPDATE").Inc()
+		return err
+	}
+
+	return nil
+}
+
+// checkCategoryCycle проверяет, что новый родитель не лежит в поддереве категории
+func checkCategoryCycle(ctx context.Context, tx *sql.Tx, id, parentID int64) error {
+	query := `
+		WITH RECURSIVE subtree AS (
+			SELECT id FROM categories WHERE id = $1
+			UNION ALL
+			SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+		)
+		SELECT EXISTS (SELECT 1 FROM subtree WHERE id = $2)
+	`
+
+	var cycle bool
+	if err := tx.QueryRowContext(ctx, query, id, parentID).Scan(&cycle); err != nil {
+		return err
+	}
+	if cycle {
+		return ErrCategoryCycle
+	}
+	return nil
+}
+
+func (r *postgresCategoryRepository) Delete(ctx context.Context, id int64) error {
+	start := time.Now()
+
+	query := `DELETE FROM categories WHERE id = $1`
+	result, err := r.db.ExecContext(ctx, query, id)
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "DELETE").Observe(duration)
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == pgForeignKeyViolation {
+		return fmt.Errorf("%w: id %d", ErrCategoryHasChildren, id)
+	}
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "DELETE").Inc()
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "DELETE").Inc()
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: id %d", ErrCategoryNotFound, id)
+	}
+
+	return nil
+}
+
+func (r *postgresCategoryRepository) Path(ctx context.Context, id int64) (model.CategoryPath, error) {
+	start := time.Now()
+
+	query := `
+		WITH RECURSIVE chain AS (
+			SELECT id, name, parent_id, 0 AS depth FROM categories WHERE id = $1
+			UNION ALL
+			SELECT c.id, c.name, c.parent_id, chain.depth + 1
+			FROM categories c JOIN chain ON c.id = chain.parent_id
+		)
+		SELECT id, name FROM chain ORDER BY depth DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		duration := time.Since(start).Seconds()
+		metrics.DBQueryDuration.WithLabelValues("product-service", "SELECT").Observe(duration)
+		metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var path model.CategoryPath
+	for rows.Next() {
+		var ref model.CategoryRef
+		if err := rows.Scan(&ref.ID, &ref.Name); err != nil {
+			metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+			return nil, err
+		}
+		path = append(path, ref)
+	}
+
+	if err = rows.Err(); err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+		return nil, err
+	}
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "SELECT").Observe(duration)
+
+	if len(path) == 0 {
+		return nil, fmt.Errorf("%w: id %d", ErrCategoryNotFound, id)
+	}
+
+	return path, nil
+}
+
+// categoryWriteError переводит нарушения ограничений categories в ошибки репозитория
+func categoryWriteError(err error, parentID *int64) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+	switch pqErr.Code {
+	case pgUniqueViolation:
+		return ErrCategoryNameTaken
+	case pgForeignKeyViolation:
+		if parentID != nil {
+			return fmt.Errorf("%w: parent id %d", ErrCategoryNotFound, *parentID)
+		}
+	}
+	return err
+}
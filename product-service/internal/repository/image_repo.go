@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/model"
+	"github.com/microserviceteam0/bff-gateway/shared/metrics"
+)
+
+var (
+	// ErrImageNotFound возвращается для несуществующего изображения или изображения другого продукта
+	ErrImageNotFound = errors.New("product image not found")
+	// ErrTooManyImages — у продукта уже максимальное число изображений
+	ErrTooManyImages = errors.New("too many product images")
+	// ErrImageOrderMismatch — новый порядок должен содержать каждое изображение продукта ровно один раз
+	ErrImageOrderMismatch = errors.New("image order must list every image of the product exactly once")
+)
+
+func (r *postgresRepository) Images(ctx context.Context, productIDs []int64) (map[int64][]*model.ProductImage, error) {
+	start := time.Now()
+
+	query := `
+		SELECT id, product_id, position, storage_key, content_type, size_bytes, width, height, alt_text, created_at
+		FROM product_images
+		WHERE product_id = ANY($1)
+		ORDER BY product_id, position
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(productIDs))
+	if err != nil {
+		duration := time.Since(start).Seconds()
+		metrics.DBQueryDuration.WithLabelValues("product-service", "SELECT").Observe(duration)
+		metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	images := make(map[int64][]*model.ProductImage)
+	for rows.Next() {
+		img, err := scanImage(rows)
+		if err != nil {
+			metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+			return nil, err
+		}
+		images[img.ProductID] = append(images[img.ProductID], img)
+	}
+
+	if err = rows.Err(); err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+		return nil, err
+	}
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "SELECT").Observe(duration)
+
+	return images, nil
+}
+
+func (r *postgresRepository) CreateImage(ctx context.Context, image *model.ProductImage, maxImages int) error {
+	start := time.Now()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "INSERT").Inc()
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Блокировка продукта упорядочивает параллельные загрузки, поэтому позиции не повторяются
+	err = lockProduct(ctx, tx, image.ProductID, 0)
+	var count int
+	if err == nil {
+		err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM product_images WHERE product_id = $1`, image.ProductID).Scan(&count)
+	}
+	if err == nil && count >= maxImages {
+		err = fmt.Errorf("%w: at most %d", ErrTooManyImages, maxImages)
+	}
+	if err == nil {
+		image.Position = count
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO product_images (product_id, position, storage_key, content_type, size_bytes, width, height, alt_text)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+			RETURNING id, created_at`,
+			image.ProductID, image.Position, image.StorageKey, image.ContentType,
+			image.SizeBytes, image.Width, image.Height, image.AltText,
+		).Scan(&image.ID, &image.CreatedAt)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "INSERT").Observe(duration)
+
+	if errors.Is(err, ErrProductNotFound) || errors.Is(err, ErrTooManyImages) {
+		return err
+	}
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "INSERT").Inc()
+		return err
+	}
+
+	return nil
+}
+
+func (r *postgresRepository) DeleteImage(ctx context.Context, productID, imageID int64) (*model.ProductImage, error) {
+	start := time.Now()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "DELETE").Inc()
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var image *model.ProductImage
+	err = lockProduct(ctx, tx, productID, 0)
+	if err == nil {
+		image, err = scanImage(tx.QueryRowContext(ctx, `
+			DELETE FROM product_images
+			WHERE id = $1 AND product_id = $2
+			RETURNING id, product_id, position, storage_key, content_type, size_bytes, width, height, alt_text, created_at`,
+			imageID, productID,
+		))
+		if errors.Is(err, sql.ErrNoRows) {
+			err = fmt.Errorf("%w: id %d", ErrImageNotFound, imageID)
+		}
+	}
+	// Позиции остаются непрерывными: следующие изображения сдвигаются на место удаленного
+	if err == nil {
+		_, err = tx.ExecContext(ctx, `UPDATE product_images SET position = position - 1 WHERE product_id = $1 AND position > $2`, productID, image.Position)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "DELETE").Observe(duration)
+
+	if errors.Is(err, ErrProductNotFound) || errors.Is(err, ErrImageNotFound) {
+		return nil, err
+	}
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "DELETE").Inc()
+		return nil, err
+	}
+
+	return image, nil
+}
+
+func (r *postgresRepository) ReorderImages(ctx context.Context, productID int64, imageIDs []int64) error {
+	start := time.Now()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "UPDATE").Inc()
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	err = lockProduct(ctx, tx, productID, 0)
+	var current []int64
+	if err == nil {
+		current, err = imageIDsOf(ctx, tx, productID)
+	}
+	if err == nil && !slices.Equal(current, slices.Sorted(slices.Values(imageIDs))) {
+		err = ErrImageOrderMismatch
+	}
+	if err == nil {
+		// Уникальность (product_id, position) проверяется при коммите, поэтому позиции можно переставлять одним запросом
+		_, err = tx.ExecContext(ctx, `
+			UPDATE product_images AS i
+			SET position = o.ord - 1
+			FROM unnest($1::bigint[]) WITH ORDINALITY AS o(id, ord)
+			WHERE i.id = o.id AND i.product_id = $2`,
+			pq.Array(imageIDs), productID,
+		)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "UPDATE").Observe(duration)
+
+	if errors.Is(err, ErrProductNotFound) || errors.Is(err, ErrImageOrderMismatch) {
+		return err
+	}
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "UPDATE").Inc()
+		return err
+	}
+
+	return nil
+}
+
+// imageIDsOf блокирует изображения продукта и возвращает их id по возрастанию
+func imageIDsOf(ctx context.Context, tx *sql.Tx, productID int64) ([]int64, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT id FROM product_images WHERE product_id = $1 ORDER BY id FOR UPDATE`, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func scanImage(row interface{ Scan(dest ...any) error }) (*model.ProductImage, error) {
+	var img model.ProductImage
+	err := row.Scan(
+		&img.ID,
+		&img.ProductID,
+		&img.Position,
+		&img.StorageKey,
+		&img.ContentType,
+		&img.SizeBytes,
+		&img.Width,
+		&img.Height,
+		&img.AltText,
+		&img.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &img, nil
+}
@@ -0,0 +1,427 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/model"
+	"github.com/microserviceteam0/bff-gateway/shared/metrics"
+	"github.com/microserviceteam0/bff-gateway/shared/money"
+)
+
+var (
+	// ErrPriceScheduleNotFound возвращается для несуществующей запланированной цены или цены другого продукта
+	ErrPriceScheduleNotFound = errors.New("price schedule not found")
+	// ErrPriceScheduleOverlap — на это время у продукта уже запланирована другая цена
+	ErrPriceScheduleOverlap = errors.New("price schedule overlaps another schedule")
+	// ErrPriceScheduleFinished — запланированная цена уже завершена или отменена
+	ErrPriceScheduleFinished = errors.New("price schedule already finished")
+)
+
+const priceScheduleColumns = `id, product_id, price_minor, currency, starts_at, ends_at, status,
+	revert_price_minor, revert_currency, actor, created_at, applied_at, finished_at`
+
+func (r *postgresRepository) PriceHistory(ctx context.Context, productID int64, limit, offset int) ([]*model.PriceChange, error) {
+	start := time.Now()
+
+	query := `SELECT id, product_id, price_minor, currency, previous_price_minor, previous_currency, reason, schedule_id, actor, created_at
+		FROM product_prices WHERE product_id = $1 ORDER BY id DESC LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.QueryContext(ctx, query, productID, limit, offset)
+	if err != nil {
+		duration := time.Since(start).Seconds()
+		metrics.DBQueryDuration.WithLabelValues("product-service", "SELECT").Observe(duration)
+		metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	changes := make([]*model.PriceChange, 0)
+	for rows.Next() {
+		var c model.PriceChange
+		if err := rows.Scan(&c.ID, &c.ProductID, &c.PriceMinor, &c.Currency, &c.PreviousPriceMinor, &c.PreviousCurrency,
+			&c.Reason, &c.ScheduleID, &c.Actor, &c.CreatedAt); err != nil {
+			metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+			return nil, err
+		}
+		changes = append(changes, &c)
+	}
+
+	if err = rows.Err(); err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+		return nil, err
+	}
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "SELECT").Observe(duration)
+
+	return changes, nil
+}
+
+func (r *postgresRepository) PriceSchedules(ctx context.Context, productID int64) ([]*model.PriceSchedule, error) {
+	return r.queryPriceSchedules(ctx,
+		`SELECT `+priceScheduleColumns+` FROM price_schedules WHERE product_id = $1 ORDER BY starts_at, id`,
+		productID,
+	)
+}
+
+func (r *postgresRepository) ActivePriceSchedules(ctx context.Context, productIDs []int64) (map[int64]*model.PriceSchedule, error) {
+	schedules, err := r.queryPriceSchedules(ctx,
+		`SELECT `+priceScheduleColumns+` FROM price_schedules WHERE product_id = ANY($1) AND status = $2`,
+		pq.Array(productIDs), model.PriceScheduleActive,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	active := make(map[int64]*model.PriceSchedule, len(schedules))
+	for _, s := range schedules {
+		active[s.ProductID] = s
+	}
+	return active, nil
+}
+
+func (r *postgresRepository) queryPriceSchedules(ctx context.Context, query string, args ...any) ([]*model.PriceSchedule, error) {
+	start := time.Now()
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		duration := time.Since(start).Seconds()
+		metrics.DBQueryDuration.WithLabelValues("product-service", "SELECT").Observe(duration)
+		metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	schedules := make([]*model.PriceSchedule, 0)
+	for rows.Next() {
+		s, err := scanPriceSchedule(rows)
+		if err != nil {
+			metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+			return nil, err
+		}
+		schedules = append(schedules, s)
+	}
+
+	if err = rows.Err(); err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+		return nil, err
+	}
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "SELECT").Observe(duration)
+
+	return schedules, nil
+}
+
+func (r *postgresRepository) CreatePriceSchedule(ctx context.Context, schedule *model.PriceSchedule) error {
+	start := time.Now()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "INSERT").Inc()
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Блокировка продукта упорядочивает параллельные проверки пересечения
+	var current money.Money
+	current, err = lockProductPrice(ctx, tx, schedule.ProductID)
+	if err == nil && schedule.Currency == "" {
+		schedule.Currency = current.Currency
+	}
+	// Распродажа занимает полуинтервал [starts_at, ends_at), постоянная смена цены — точку starts_at
+	var overlaps bool
+	if err == nil {
+		err = tx.QueryRowContext(ctx, `
+			SELECT EXISTS (
+				SELECT 1 FROM price_schedules
+				WHERE product_id = $1 AND status IN ($2, $3)
+				  AND tstzrange(starts_at, COALESCE(ends_at, starts_at), CASE WHEN ends_at IS NULL THEN '[]' ELSE '[)' END)
+				   && tstzrange($4::timestamptz, COALESCE($5::timestamptz, $4::timestamptz), CASE WHEN $5::timestamptz IS NULL THEN '[]' ELSE '[)' END)
+			)`,
+			schedule.ProductID, model.PriceSchedulePending, model.PriceScheduleActive, schedule.StartsAt, schedule.EndsAt,
+		).Scan(&overlaps)
+	}
+	if err == nil && overlaps {
+		err = ErrPriceScheduleOverlap
+	}
+	if err == nil {
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO price_schedules (product_id, price_minor, currency, starts_at, ends_at, actor)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id, status, created_at`,
+			schedule.ProductID, schedule.PriceMinor, schedule.Currency, schedule.StartsAt, schedule.EndsAt, schedule.Actor,
+		).Scan(&schedule.ID, &schedule.Status, &schedule.CreatedAt)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "INSERT").Observe(duration)
+
+	if errors.Is(err, ErrProductNotFound) || errors.Is(err, ErrPriceScheduleOverlap) {
+		return err
+	}
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "INSERT").Inc()
+		return err
+	}
+
+	return nil
+}
+
+func (r *postgresRepository) CancelPriceSchedule(ctx context.Context, productID, scheduleID int64, actor string) error {
+	start := time.Now()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "UPDATE").Inc()
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var current money.Money
+	var schedule *model.PriceSchedule
+	current, err = lockProductPrice(ctx, tx, productID)
+	if err == nil {
+		schedule, err = lockPriceSchedule(ctx, tx, productID, scheduleID)
+	}
+	if err == nil && schedule.Status != model.PriceSchedulePending && schedule.Status != model.PriceScheduleActive {
+		err = fmt.Errorf("%w: schedule %d is %s", ErrPriceScheduleFinished, scheduleID, schedule.Status)
+	}
+	// Идущая распродажа отменяется сразу: цена возвращается к прежней
+	if err == nil && schedule.Status == model.PriceScheduleActive {
+		if revert, ok := schedule.RevertPrice(); ok {
+			err = setProductPrice(ctx, tx, productID, current, revert, model.PriceReasonScheduleCancelled, schedule.ID, actor)
+		}
+	}
+	if err == nil {
+		_, err = tx.ExecContext(ctx, `UPDATE price_schedules SET status = $1, finished_at = CURRENT_TIMESTAMP WHERE id = $2`,
+			model.PriceScheduleCancelled, scheduleID)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "UPDATE").Observe(duration)
+
+	if errors.Is(err, ErrProductNotFound) || errors.Is(err, ErrPriceScheduleNotFound) || errors.Is(err, ErrPriceScheduleFinished) {
+		return err
+	}
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "UPDATE").Inc()
+		return err
+	}
+
+	return nil
+}
+
+func (r *postgresRepository) ApplyDuePriceSchedules(ctx context.Context, now time.Time, limit int) (int, error) {
+	start := time.Now()
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, product_id FROM price_schedules
+		WHERE (status = $1 AND starts_at <= $3) OR (status = $2 AND ends_at <= $3)
+		ORDER BY CASE WHEN status = $1 THEN starts_at ELSE ends_at END, id
+		LIMIT $4`,
+		model.PriceSchedulePending, model.PriceScheduleActive, now, limit,
+	)
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+		return 0, err
+	}
+	var due [][2]int64
+	for rows.Next() {
+		var id, productID int64
+		if err = rows.Scan(&id, &productID); err != nil {
+			break
+		}
+		due = append(due, [2]int64{id, productID})
+	}
+	if err == nil {
+		err = rows.Err()
+	}
+	_ = rows.Close()
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+		return 0, err
+	}
+
+	applied := 0
+	for _, d := range due {
+		ok, err := r.applyPriceSchedule(ctx, d[1], d[0], now)
+		if err != nil {
+			metrics.DBErrors.WithLabelValues("product-service", "UPDATE").Inc()
+			return applied, fmt.Errorf("apply price schedule %d: %w", d[0], err)
+		}
+		if ok {
+			applied++
+		}
+	}
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "UPDATE").Observe(duration)
+
+	return applied, nil
+}
+
+// applyPriceSchedule начинает или завершает одну запланированную цену в своей транзакции.
+// Продукт блокируется раньше расписания, как и в Update и CancelPriceSchedule. Расписание,
+// которое уже обработал другой экземпляр сервиса, пропускается
+func (r *postgresRepository) applyPriceSchedule(ctx context.Context, productID, scheduleID int64, now time.Time) (bool, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	current, err := lockProductPrice(ctx, tx, productID)
+	if errors.Is(err, ErrProductNotFound) {
+		// Продукт удален вместе с расписанием
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	schedule, err := lockPriceSchedule(ctx, tx, productID, scheduleID)
+	if errors.Is(err, ErrPriceScheduleNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	starting := schedule.Status == model.PriceSchedulePending && !schedule.StartsAt.After(now)
+	ending := schedule.EndsAt != nil && !schedule.EndsAt.After(now) &&
+		(starting || schedule.Status == model.PriceScheduleActive)
+	if !starting && !ending {
+		return false, nil
+	}
+
+	if starting {
+		status := model.PriceScheduleCompleted
+		err = setProductPrice(ctx, tx, productID, current, schedule.Price(), model.PriceReasonScheduled, schedule.ID, model.PriceActorScheduler)
+		if err != nil {
+			return false, err
+		}
+		finishedAt := &now
+		if schedule.EndsAt != nil {
+			status, finishedAt = model.PriceScheduleActive, nil
+			schedule.RevertPriceMinor, schedule.RevertCurrency = &current.Amount, &current.Currency
+		}
+		_, err = tx.ExecContext(ctx, `
+			UPDATE price_schedules SET status = $1, applied_at = $2, finished_at = $3, revert_price_minor = $4, revert_currency = $5
+			WHERE id = $6`,
+			status, now, finishedAt, schedule.RevertPriceMinor, schedule.RevertCurrency, schedule.ID,
+		)
+		if err != nil {
+			return false, err
+		}
+		current = schedule.Price()
+	}
+	// Окно распродажи могло пройти целиком, пока сервис не работал: тогда она начинается и сразу заканчивается
+	if ending {
+		revert, ok := schedule.RevertPrice()
+		if ok {
+			err = setProductPrice(ctx, tx, productID, current, revert, model.PriceReasonScheduleEnded, schedule.ID, model.PriceActorScheduler)
+		}
+		if err == nil {
+			_, err = tx.ExecContext(ctx, `UPDATE price_schedules SET status = $1, finished_at = $2 WHERE id = $3`,
+				model.PriceScheduleCompleted, now, schedule.ID)
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return true, tx.Commit()
+}
+
+// lockProductPrice блокирует продукт и возвращает его текущую цену
+func lockProductPrice(ctx context.Context, tx *sql.Tx, productID int64) (money.Money, error) {
+	var price money.Money
+	err := tx.QueryRowContext(ctx, `SELECT price_minor, currency FROM products WHERE id = $1 FOR UPDATE`, productID).
+		Scan(&price.Amount, &price.Currency)
+	if errors.Is(err, sql.ErrNoRows) {
+		return price, fmt.Errorf("%w: id %d", ErrProductNotFound, productID)
+	}
+	return price, err
+}
+
+func lockPriceSchedule(ctx context.Context, tx *sql.Tx, productID, scheduleID int64) (*model.PriceSchedule, error) {
+	schedule, err := scanPriceSchedule(tx.QueryRowContext(ctx,
+		`SELECT `+priceScheduleColumns+` FROM price_schedules WHERE id = $1 AND product_id = $2 FOR UPDATE`,
+		scheduleID, productID,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("%w: schedule %d of product %d", ErrPriceScheduleNotFound, scheduleID, productID)
+	}
+	return schedule, err
+}
+
+// setProductPrice меняет цену заблокированного продукта, увеличивает версию и пишет изменение в историю.
+// Если цена не меняется, ничего не делает
+func setProductPrice(ctx context.Context, tx *sql.Tx, productID int64, current, price money.Money, reason string, scheduleID int64, actor string) error {
+	if current == price {
+		return nil
+	}
+	_, err := tx.ExecContext(ctx, `UPDATE products SET price = $1, price_minor = $2, currency = $3, version = version + 1 WHERE id = $4`,
+		price.Float64(), price.Amount, price.Currency, productID)
+	if err != nil {
+		return err
+	}
+	change := &model.PriceChange{
+		ProductID:          productID,
+		PriceMinor:         price.Amount,
+		Currency:           price.Currency,
+		PreviousPriceMinor: &current.Amount,
+		PreviousCurrency:   &current.Currency,
+		Reason:             reason,
+		Actor:              actor,
+	}
+	if scheduleID > 0 {
+		change.ScheduleID = &scheduleID
+	}
+	return insertPriceChange(ctx, tx, change)
+}
+
+func insertPriceChange(ctx context.Context, tx *sql.Tx, c *model.PriceChange) error {
+	query := `INSERT INTO product_prices (product_id, price_minor, currency, previous_price_minor, previous_currency, reason, schedule_id, actor)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, created_at`
+
+	return tx.QueryRowContext(ctx, query, c.ProductID, c.PriceMinor, c.Currency, c.PreviousPriceMinor, c.PreviousCurrency,
+		c.Reason, c.ScheduleID, c.Actor).Scan(&c.ID, &c.CreatedAt)
+}
+
+func scanPriceSchedule(row interface{ Scan(dest ...any) error }) (*model.PriceSchedule, error) {
+	var s model.PriceSchedule
+	err := row.Scan(
+		&s.ID,
+		&s.ProductID,
+		&s.PriceMinor,
+		&s.Currency,
+		&s.StartsAt,
+		&s.EndsAt,
+		&s.Status,
+		&s.RevertPriceMinor,
+		&s.RevertCurrency,
+		&s.Actor,
+		&s.CreatedAt,
+		&s.AppliedAt,
+		&s.FinishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
@@ -1,22 +1,177 @@
 package repository
 
 import (
+	"cmp"
 	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/lib/pq"
+
 	"github.com/microserviceteam0/bff-gateway/product-service/internal/model"
 	"github.com/microserviceteam0/bff-gateway/shared/metrics"
+	"github.com/microserviceteam0/bff-gateway/shared/money"
+)
+
+// ErrVersionConflict возвращается Update, если продукт изменился после чтения
+var ErrVersionConflict = errors.New("product version conflict")
+
+var (
+	// ErrProductNotFound возвращается AdjustStock для несуществующего продукта
+	ErrProductNotFound = errors.New("product not found")
+	// ErrInsufficientStock возвращается AdjustStock, если остаток ушел бы в минус
+	ErrInsufficientStock = errors.New("insufficient stock")
+	// ErrProductUnavailable возвращается AdjustStock при списании остатка черновика или архивного продукта
+	ErrProductUnavailable = errors.New("product is not available for sale")
+	// ErrProductArchived возвращается Update при смене статуса архивного продукта
+	ErrProductArchived = errors.New("product is archived")
+	// ErrProductNotArchived возвращается Restore и Purge для продукта, который не в архиве
+	ErrProductNotArchived = errors.New("product is not archived")
+)
+
+// InsufficientStockError называет позицию пакета, на которую не хватило товара
+type InsufficientStockError struct {
+	ProductID int64
+	VariantID int64
+	Delta     int
+	Available int
+}
+
+func (e *InsufficientStockError) Error() string {
+	if e.VariantID == 0 {
+		return fmt.Sprintf("insufficient stock for product %d: requested %d, available %d", e.ProductID, -e.Delta, e.Available)
+	}
+	return fmt.Sprintf("insufficient stock for product %d variant %d: requested %d, available %d", e.ProductID, e.VariantID, -e.Delta, e.Available)
+}
+
+func (e *InsufficientStockError) Is(target error) bool {
+	return target == ErrInsufficientStock
+}
+
+// Поля сортировки списка продуктов
+const (
+	ProductSortCreatedAt = "created_at"
+	ProductSortPrice     = "price"
+	ProductSortName      = "name"
+	ProductSortStock     = "stock"
 )
 
+// ProductFilter — условия, сортировка и страница списка продуктов.
+// Если задан After, страница берется по ключу после него, а Offset не учитывается
+type ProductFilter struct {
+	MinPriceMinor *int64
+	MaxPriceMinor *int64
+	// Currency оставляет продукты с ценой в этой валюте; пустая — любые
+	Currency     string
+	InStock      bool
+	UpdatedSince *time.Time
+	// CategoryID оставляет продукты категории и всех ее подкатегорий; 0 — без фильтра
+	CategoryID int64
+	// Status — статус продуктов списка; пустой — только активные
+	Status     string
+	SortBy     string
+	Descending bool
+	Limit      int
+	Offset     int
+	After      *ProductCursor
+}
+
+// ProductCursor — значения ключа сортировки последнего продукта страницы; заполнено поле SortBy
+type ProductCursor struct {
+	CreatedAt  time.Time `json:"c,omitempty"`
+	PriceMinor int64     `json:"p,omitempty"`
+	Name       string    `json:"n,omitempty"`
+	Stock      int       `json:"s,omitempty"`
+	ID         int64     `json:"i"`
+}
+
+// NewProductCursor строит курсор, указывающий на продукт p
+func NewProductCursor(p *model.Product) ProductCursor {
+	return ProductCursor{CreatedAt: p.CreatedAt, PriceMinor: p.PriceMinor, Name: p.Name, Stock: p.Stock, ID: p.ID}
+}
+
+// ProductSearch — полнотекстовый запрос. Terms содержат только буквы и цифры,
+// каждый терм ищется как префикс слова, все термы должны совпасть
+type ProductSearch struct {
+	Terms  []string
+	Limit  int
+	Offset int
+}
+
 type ProductRepository interface {
 	FindByID(ctx context.Context, id int64) (*model.Product, error)
+	// FindByIDs возвращает найденные продукты одним запросом; порядок строк не гарантирован
+	FindByIDs(ctx context.Context, ids []int64) ([]*model.Product, error)
 	FindAll(ctx context.Context) ([]*model.Product, error)
+	// List отдает страницу продуктов по фильтру
+	List(ctx context.Context, filter ProductFilter) ([]*model.Product, error)
+	// Count считает продукты, подходящие под фильтр, без учета страницы
+	Count(ctx context.Context, filter ProductFilter) (int64, error)
+	// Search отдает страницу найденных продуктов, более релевантные первыми
+	Search(ctx context.Context, search ProductSearch) ([]*model.ProductSearchHit, error)
+	// CountSearch считает все найденные продукты без учета страницы
+	CountSearch(ctx context.Context, search ProductSearch) (int64, error)
+	// Create сохраняет продукт вместе с product.Variants; без вариантов создается один
+	// вариант по умолчанию со всем остатком. Пустой SKU заменяется на P-<id продукта>
 	Create(ctx context.Context, product *model.Product) error
+	// Update сохраняет продукт и увеличивает версию. Если product.Version > 0,
+	// запись обновляется только при совпадении версии, иначе возвращается ErrVersionConflict.
+	// Остаток меняется только у продукта с одним вариантом, иначе — ErrVariantRequired
 	Update(ctx context.Context, product *model.Product) error
-	Delete(ctx context.Context, id int64) error
+	// Archive переводит продукт в архив: он пропадает из каталога, но находится по ID. Повторный вызов ничего не меняет
+	Archive(ctx context.Context, id int64) error
+	// Restore возвращает архивный продукт в каталог; продукт не из архива — ErrProductNotArchived
+	Restore(ctx context.Context, id int64) error
+	// Purge удаляет архивный продукт безвозвратно вместе с вариантами, журналом, историей цен и изображениями
+	// и возвращает удаленные изображения, чтобы вызывающий убрал их файлы. Продукт не из архива — ErrProductNotArchived
+	Purge(ctx context.Context, id int64) ([]*model.ProductImage, error)
+	// AdjustStock атомарно меняет остаток варианта на movement.Delta и пишет movement в журнал.
+	// VariantID == 0 — единственный вариант продукта. Заполняет VariantID, StockAfter, ID и CreatedAt
+	AdjustStock(ctx context.Context, movement *model.StockMovement) error
+	// AdjustStockBatch применяет все изменения в одной транзакции или ни одного.
+	// Строки блокируются по возрастанию id, варианты в пакете должны быть уникальны
+	AdjustStockBatch(ctx context.Context, movements []*model.StockMovement) error
+	// ListStockMovements отдает журнал продукта, новые записи первыми
+	ListStockMovements(ctx context.Context, productID int64, limit, offset int) ([]*model.StockMovement, error)
+	// CategoryPaths отдает хлебные крошки категорий каждого продукта; продуктов без категорий нет в ответе
+	CategoryPaths(ctx context.Context, productIDs []int64) (map[int64][]model.CategoryPath, error)
+	// SetCategories заменяет категории продукта и увеличивает его версию.
+	// Несуществующая категория — ErrCategoryNotFound, продукт не меняется
+	SetCategories(ctx context.Context, productID int64, categoryIDs []int64) error
+	// Variants отдает варианты каждого продукта по возрастанию id
+	Variants(ctx context.Context, productIDs []int64) (map[int64][]*model.ProductVariant, error)
+	// CreateVariant добавляет вариант, его остаток прибавляется к остатку продукта
+	CreateVariant(ctx context.Context, variant *model.ProductVariant) error
+	// UpdateVariant меняет SKU, атрибуты, цену и остаток варианта; изменение остатка пишется в журнал
+	UpdateVariant(ctx context.Context, variant *model.ProductVariant) error
+	// DeleteVariant удаляет вариант и списывает его остаток. Последний вариант удалить нельзя — ErrLastVariant
+	DeleteVariant(ctx context.Context, productID, variantID int64) error
+	// Images отдает изображения каждого продукта по возрастанию position
+	Images(ctx context.Context, productIDs []int64) (map[int64][]*model.ProductImage, error)
+	// CreateImage добавляет изображение в конец галереи; у продукта не больше maxImages изображений
+	CreateImage(ctx context.Context, image *model.ProductImage, maxImages int) error
+	// DeleteImage удаляет изображение и возвращает его, чтобы вызывающий удалил файлы
+	DeleteImage(ctx context.Context, productID, imageID int64) (*model.ProductImage, error)
+	// ReorderImages задает порядок галереи; imageIDs — все изображения продукта, первое становится главным
+	ReorderImages(ctx context.Context, productID int64, imageIDs []int64) error
+	// PriceHistory отдает историю цены продукта, новые записи первыми
+	PriceHistory(ctx context.Context, productID int64, limit, offset int) ([]*model.PriceChange, error)
+	// PriceSchedules отдает все запланированные цены продукта по времени начала
+	PriceSchedules(ctx context.Context, productID int64) ([]*model.PriceSchedule, error)
+	// ActivePriceSchedules отдает идущую распродажу каждого продукта; продуктов без распродажи нет в ответе
+	ActivePriceSchedules(ctx context.Context, productIDs []int64) (map[int64]*model.PriceSchedule, error)
+	// CreatePriceSchedule планирует цену в валюте продукта. Пересечение с другой
+	// ожидающей или идущей запланированной ценой — ErrPriceScheduleOverlap
+	CreatePriceSchedule(ctx context.Context, schedule *model.PriceSchedule) error
+	// CancelPriceSchedule отменяет запланированную цену; идущая распродажа сразу возвращает прежнюю цену
+	CancelPriceSchedule(ctx context.Context, productID, scheduleID int64, actor string) error
+	// ApplyDuePriceSchedules начинает и завершает до limit запланированных цен, время которых наступило к now,
+	// и возвращает, сколько обработано. Безопасно вызывать с нескольких экземпляров сервиса
+	ApplyDuePriceSchedules(ctx context.Context, now time.Time, limit int) (int, error)
 }
 
 type postgresRepository struct {
@@ -30,7 +185,7 @@ func NewPostgresRepository(db *sql.DB) ProductRepository {
 func (r *postgresRepository) FindByID(ctx context.Context, id int64) (*model.Product, error) {
 	start := time.Now()
 
-	query := `SELECT id, name, description, price, stock, created_at, updated_at FROM products WHERE id = $1`
+	query := `SELECT id, name, description, price, price_minor, currency, stock, version, created_at, updated_at, status, deleted_at FROM products WHERE id = $1`
 
 	var product model.Product
 	err := r.db.QueryRowContext(ctx, query, id).Scan(
@@ -38,9 +193,14 @@ func (r *postgresRepository) FindByID(ctx context.Context, id int64) (*model.Pro
 		&product.Name,
 		&product.Description,
 		&product.Price,
+		&product.PriceMinor,
+		&product.Currency,
 		&product.Stock,
+		&product.Version,
 		&product.CreatedAt,
 		&product.UpdatedAt,
+		&product.Status,
+		&product.DeletedAt,
 	)
 
 	duration := time.Since(start).Seconds()
@@ -58,12 +218,61 @@ func (r *postgresRepository) FindByID(ctx context.Context, id int64) (*model.Pro
 	return &product, nil
 }
 
+func (r *postgresRepository) FindByIDs(ctx context.Context, ids []int64) ([]*model.Product, error) {
+	start := time.Now()
+
+	query := `SELECT id, name, description, price, price_minor, currency, stock, version, created_at, updated_at, status, deleted_at FROM products WHERE id = ANY($1)`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		duration := time.Since(start).Seconds()
+		metrics.DBQueryDuration.WithLabelValues("product-service", "SELECT").Observe(duration)
+		metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	products := make([]*model.Product, 0, len(ids))
+	for rows.Next() {
+		var product model.Product
+		err := rows.Scan(
+			&product.ID,
+			&product.Name,
+			&product.Description,
+			&product.Price,
+			&product.PriceMinor,
+			&product.Currency,
+			&product.Stock,
+			&product.Version,
+			&product.CreatedAt,
+			&product.UpdatedAt,
+			&product.Status,
+			&product.DeletedAt,
+		)
+		if err != nil {
+			metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+			return nil, err
+		}
+		products = append(products, &product)
+	}
+
+	if err = rows.Err(); err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+		return nil, err
+	}
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "SELECT").Observe(duration)
+
+	return products, nil
+}
+
 func (r *postgresRepository) FindAll(ctx context.Context) ([]*model.Product, error) {
 	start := time.Now()
 
-	query := `SELECT id, name, description, price, stock, created_at, updated_at FROM products ORDER BY created_at DESC`
+	query := `SELECT id, name, description, price, price_minor, currency, stock, version, created_at, updated_at, status, deleted_at FROM products WHERE status = $1 ORDER BY created_at DESC`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := r.db.QueryContext(ctx, query, model.ProductStatusActive)
 	if err != nil {
 		duration := time.Since(start).Seconds()
 		metrics.DBQueryDuration.WithLabelValues("product-service", "SELECT").Observe(duration)
@@ -80,9 +289,84 @@ func (r *postgresRepository) FindAll(ctx context.Context) ([]*model.Product, err
 			&product.Name,
 			&product.Description,
 			&product.Price,
+			&product.PriceMinor,
+			&product.Currency,
+			&product.Stock,
+			&product.Version,
+			&product.CreatedAt,
+			&product.UpdatedAt,
+			&product.Status,
+			&product.DeletedAt,
+		)
+		if err != nil {
+			metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+			return nil, err
+		}
+		products = append(products, &product)
+	}
+
+	if err = rows.Err(); err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+		return nil, err
+	}
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "SELECT").Observe(duration)
+
+	return products, nil
+}
+
+func (r *postgresRepository) List(ctx context.Context, filter ProductFilter) ([]*model.Product, error) {
+	start := time.Now()
+
+	where, args := productConditions(filter)
+
+	column := productSortColumn(filter.SortBy)
+	dir, cmpOp := "ASC", ">"
+	if filter.Descending {
+		dir, cmpOp = "DESC", "<"
+	}
+	if filter.After != nil {
+		args = append(args, productCursorValue(filter.SortBy, filter.After), filter.After.ID)
+		where = append(where, fmt.Sprintf("(%s, id) %s ($%d, $%d)", column, cmpOp, len(args)-1, len(args)))
+	}
+
+	query := `SELECT id, name, description, price, price_minor, currency, stock, version, created_at, updated_at, status, deleted_at FROM products`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	args = append(args, filter.Limit)
+	query += fmt.Sprintf(" ORDER BY %s %s, id %s LIMIT $%d", column, dir, dir, len(args))
+	if filter.After == nil && filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		duration := time.Since(start).Seconds()
+		metrics.DBQueryDuration.WithLabelValues("product-service", "SELECT").Observe(duration)
+		metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	products := make([]*model.Product, 0, filter.Limit)
+	for rows.Next() {
+		var product model.Product
+		err := rows.Scan(
+			&product.ID,
+			&product.Name,
+			&product.Description,
+			&product.Price,
+			&product.PriceMinor,
+			&product.Currency,
 			&product.Stock,
+			&product.Version,
 			&product.CreatedAt,
 			&product.UpdatedAt,
+			&product.Status,
+			&product.DeletedAt,
 		)
 		if err != nil {
 			metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
@@ -102,21 +386,261 @@ func (r *postgresRepository) FindAll(ctx context.Context) ([]*model.Product, err
 	return products, nil
 }
 
+func (r *postgresRepository) Count(ctx context.Context, filter ProductFilter) (int64, error) {
+	start := time.Now()
+
+	where, args := productConditions(filter)
+	query := `SELECT COUNT(*) FROM products`
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+
+	var total int64
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&total)
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "SELECT").Observe(duration)
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+		return 0, err
+	}
+
+	return total, nil
+}
+
+func (r *postgresRepository) Search(ctx context.Context, search ProductSearch) ([]*model.ProductSearchHit, error) {
+	start := time.Now()
+
+	// Подсветка считается только для строк страницы, а не для всех совпадений
+	query := `
+		WITH q AS (SELECT to_tsquery('russian', $1) AS query),
+		hits AS (
+			SELECT p.id, p.name, p.description, p.price, p.price_minor, p.currency, p.stock, p.version,
+			       p.created_at, p.updated_at, p.status, p.deleted_at, ts_rank_cd(p.search_vector, q.query) AS rank
+			FROM products p, q
+			WHERE p.search_vector @@ q.query AND p.status = $4
+			ORDER BY rank DESC, p.id
+			LIMIT $2 OFFSET $3
+		)
+		SELECT h.id, h.name, h.description, h.price, h.price_minor, h.currency, h.stock, h.version,
+		       h.created_at, h.updated_at, h.status, h.deleted_at, h.rank,
+		       ts_headline('russian', h.name, q.query, 'HighlightAll=true'),
+		       ts_headline('russian', coalesce(h.description, ''), q.query, 'MaxFragments=2, MaxWords=20, MinWords=5')
+		FROM hits h, q
+		ORDER BY h.rank DESC, h.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, productTSQuery(search.Terms), search.Limit, search.Offset, model.ProductStatusActive)
+	if err != nil {
+		duration := time.Since(start).Seconds()
+		metrics.DBQueryDuration.WithLabelValues("product-service", "SELECT").Observe(duration)
+		metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	hits := make([]*model.ProductSearchHit, 0, search.Limit)
+	for rows.Next() {
+		var hit model.ProductSearchHit
+		err := rows.Scan(
+			&hit.ID,
+			&hit.Name,
+			&hit.Description,
+			&hit.Price,
+			&hit.PriceMinor,
+			&hit.Currency,
+			&hit.Stock,
+			&hit.Version,
+			&hit.CreatedAt,
+			&hit.UpdatedAt,
+			&hit.Status,
+			&hit.DeletedAt,
+			&hit.Rank,
+			&hit.NameHighlight,
+			&hit.DescriptionHighlight,
+		)
+		if err != nil {
+			metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+			return nil, err
+		}
+		hits = append(hits, &hit)
+	}
+
+	if err = rows.Err(); err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+		return nil, err
+	}
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "SELECT").Observe(duration)
+
+	return hits, nil
+}
+
+func (r *postgresRepository) CountSearch(ctx context.Context, search ProductSearch) (int64, error) {
+	start := time.Now()
+
+	query := `SELECT COUNT(*) FROM products WHERE search_vector @@ to_tsquery('russian', $1) AND status = $2`
+
+	var total int64
+	err := r.db.QueryRowContext(ctx, query, productTSQuery(search.Terms), model.ProductStatusActive).Scan(&total)
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "SELECT").Observe(duration)
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// productTSQuery строит запрос to_tsquery: все термы обязательны, каждый как префикс ("ноут:* & lenovo:*")
+func productTSQuery(terms []string) string {
+	parts := make([]string, len(terms))
+	for i, term := range terms {
+		parts[i] = term + ":*"
+	}
+	return strings.Join(parts, " & ")
+}
+
+// productConditions собирает условия WHERE фильтра с плейсхолдерами $1, $2, ...
+func productConditions(filter ProductFilter) ([]string, []any) {
+	var where []string
+	var args []any
+	add := func(cond string, arg any) {
+		args = append(args, arg)
+		where = append(where, fmt.Sprintf(cond, len(args)))
+	}
+
+	add("status = $%d", cmp.Or(filter.Status, model.ProductStatusActive))
+
+	if filter.Currency != "" {
+		add("currency = $%d", filter.Currency)
+	}
+	if filter.MinPriceMinor != nil {
+		add("price_minor >= $%d", *filter.MinPriceMinor)
+	}
+	if filter.MaxPriceMinor != nil {
+		add("price_minor <= $%d", *filter.MaxPriceMinor)
+	}
+	if filter.InStock {
+		where = append(where, "stock > 0")
+	}
+	if filter.UpdatedSince != nil {
+		add("updated_at >= $%d", *filter.UpdatedSince)
+	}
+	if filter.CategoryID != 0 {
+		add(`id IN (
+			SELECT pc.product_id FROM product_categories pc
+			WHERE pc.category_id IN (
+				WITH RECURSIVE subtree AS (
+					SELECT id FROM categories WHERE id = $%d
+					UNION ALL
+					SELECT c.id FROM categories c JOIN subtree s ON c.parent_id = s.id
+				)
+				SELECT id FROM subtree
+			)
+		)`, filter.CategoryID)
+	}
+	return where, args
+}
+
+// productSortColumn — колонка для SortBy; имена берутся только из этого списка, поэтому их можно вставлять в SQL
+func productSortColumn(sortBy string) string {
+	switch sortBy {
+	case ProductSortPrice:
+		return "price_minor"
+	case ProductSortName:
+		return "name"
+	case ProductSortStock:
+		return "stock"
+	default:
+		return "created_at"
+	}
+}
+
+func productCursorValue(sortBy string, c *ProductCursor) any {
+	switch sortBy {
+	case ProductSortPrice:
+		return c.PriceMinor
+	case ProductSortName:
+		return c.Name
+	case ProductSortStock:
+		return c.Stock
+	default:
+		return c.CreatedAt
+	}
+}
+
 func (r *postgresRepository) Create(ctx context.Context, product *model.Product) error {
 	start := time.Now()
 
-	query := `INSERT INTO products (name, description, price, stock) VALUES ($1, $2, $3, $4) RETURNING id, created_at, updated_at`
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "INSERT").Inc()
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
 
-	err := r.db.QueryRowContext(ctx, query,
+	if len(product.Variants) == 0 {
+		product.Variants = []*model.ProductVariant{{Stock: product.Stock}}
+	}
+	product.Stock = 0
+	for _, v := range product.Variants {
+		product.Stock += v.Stock
+	}
+	product.Status = cmp.Or(product.Status, model.ProductStatusActive)
+
+	query := `INSERT INTO products (name, description, price, price_minor, currency, stock, status) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, version, created_at, updated_at`
+
+	err = tx.QueryRowContext(ctx, query,
 		product.Name,
 		product.Description,
 		product.Price,
+		product.PriceMinor,
+		product.Currency,
 		product.Stock,
-	).Scan(&product.ID, &product.CreatedAt, &product.UpdatedAt)
+		product.Status,
+	).Scan(&product.ID, &product.Version, &product.CreatedAt, &product.UpdatedAt)
+	if err == nil {
+		err = insertPriceChange(ctx, tx, &model.PriceChange{
+			ProductID:  product.ID,
+			PriceMinor: product.PriceMinor,
+			Currency:   product.Currency,
+			Reason:     model.PriceReasonInitial,
+			Actor:      model.PriceActorAPI,
+		})
+	}
+	for _, v := range product.Variants {
+		if err != nil {
+			break
+		}
+		v.ProductID = product.ID
+		if err = insertVariant(ctx, tx, v); err == nil {
+			err = insertStockMovement(ctx, tx, &model.StockMovement{
+				ProductID:  product.ID,
+				VariantID:  v.ID,
+				Delta:      v.Stock,
+				StockAfter: v.Stock,
+				Reason:     model.StockReasonInitial,
+				Actor:      model.StockActorAPI,
+			})
+		}
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
 
 	duration := time.Since(start).Seconds()
 	metrics.DBQueryDuration.WithLabelValues("product-service", "INSERT").Observe(duration)
 
+	if errors.Is(err, ErrSKUTaken) {
+		return err
+	}
+
 	if err != nil {
 		metrics.DBErrors.WithLabelValues("product-service", "INSERT").Inc()
 		return err
@@ -128,22 +652,101 @@ func (r *postgresRepository) Create(ctx context.Context, product *model.Product)
 func (r *postgresRepository) Update(ctx context.Context, product *model.Product) error {
 	start := time.Now()
 
-	query := `UPDATE products SET name = $1, description = $2, price = $3, stock = $4 WHERE id = $5 RETURNING updated_at`
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "UPDATE").Inc()
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
 
-	err := r.db.QueryRowContext(ctx, query,
+	// Прежние остаток и цена берутся из заблокированной строки, чтобы записать в журнал и историю разницу.
+	// Пустой статус оставляет прежний; статус архивного продукта меняет только Restore
+	query := `UPDATE products p SET name = $1, description = $2, price = $3, price_minor = $4, currency = $5, stock = $6,
+			status = CASE WHEN p.status = $9 THEN p.status ELSE COALESCE(NULLIF($10, ''), p.status) END, version = p.version + 1
+		FROM (SELECT id, stock, price_minor, currency FROM products WHERE id = $7 FOR UPDATE) old
+		WHERE p.id = old.id AND ($8::bigint = 0 OR p.version = $8)
+		RETURNING p.version, p.created_at, p.updated_at, p.status, p.deleted_at, old.stock, old.price_minor, old.currency`
+
+	requestedStatus := product.Status
+	var oldStock int
+	var oldPrice money.Money
+	err = tx.QueryRowContext(ctx, query,
 		product.Name,
 		product.Description,
 		product.Price,
+		product.PriceMinor,
+		product.Currency,
 		product.Stock,
 		product.ID,
-	).Scan(&product.UpdatedAt)
+		product.Version,
+		model.ProductStatusArchived,
+		requestedStatus,
+	).Scan(&product.Version, &product.CreatedAt, &product.UpdatedAt, &product.Status, &product.DeletedAt, &oldStock, &oldPrice.Amount, &oldPrice.Currency)
+	if err == nil && requestedStatus != "" && requestedStatus != product.Status {
+		err = fmt.Errorf("%w: restore product %d before changing its status", ErrProductArchived, product.ID)
+	}
+	if err == nil && oldPrice != product.PriceMoney() {
+		err = insertPriceChange(ctx, tx, &model.PriceChange{
+			ProductID:          product.ID,
+			PriceMinor:         product.PriceMinor,
+			Currency:           product.Currency,
+			PreviousPriceMinor: &oldPrice.Amount,
+			PreviousCurrency:   &oldPrice.Currency,
+			Reason:             model.PriceReasonManual,
+			Actor:              model.PriceActorAPI,
+		})
+		// Цена, заданная вручную, важнее идущей распродажи: по ее окончании цена не вернется к прежней
+		if err == nil {
+			_, err = tx.ExecContext(ctx, `UPDATE price_schedules SET status = $1, finished_at = CURRENT_TIMESTAMP WHERE product_id = $2 AND status = $3`,
+				model.PriceScheduleCancelled, product.ID, model.PriceScheduleActive)
+		}
+	}
+	if err == nil && oldStock != product.Stock {
+		// Остаток продукта — сумма вариантов, поэтому напрямую его можно задать только единственному варианту
+		var variantIDs []int64
+		variantIDs, err = lockVariants(ctx, tx, product.ID)
+		if err == nil && len(variantIDs) != 1 {
+			err = fmt.Errorf("%w: product %d has %d variants, change stock per variant", ErrVariantRequired, product.ID, len(variantIDs))
+		}
+		if err == nil {
+			_, err = tx.ExecContext(ctx, `UPDATE product_variants SET stock = $1 WHERE id = $2`, product.Stock, variantIDs[0])
+		}
+		if err == nil {
+			err = insertStockMovement(ctx, tx, &model.StockMovement{
+				ProductID:  product.ID,
+				VariantID:  variantIDs[0],
+				Delta:      product.Stock - oldStock,
+				StockAfter: product.Stock,
+				Reason:     model.StockReasonManual,
+				Actor:      model.StockActorAPI,
+			})
+		}
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
 
 	duration := time.Since(start).Seconds()
 	metrics.DBQueryDuration.WithLabelValues("product-service", "UPDATE").Observe(duration)
 
 	if errors.Is(err, sql.ErrNoRows) {
+		if product.Version == 0 {
+			return fmt.Errorf("product with id %d not found", product.ID)
+		}
+		// Строка не обновилась: либо продукта нет, либо версия устарела
+		var exists bool
+		if err := r.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM products WHERE id = $1)`, product.ID).Scan(&exists); err != nil {
+			metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+			return err
+		}
+		if exists {
+			return ErrVersionConflict
+		}
 		return fmt.Errorf("product with id %d not found", product.ID)
 	}
+	if errors.Is(err, ErrVariantRequired) || errors.Is(err, ErrProductArchived) {
+		return err
+	}
 
 	if err != nil {
 		metrics.DBErrors.WithLabelValues("product-service", "UPDATE").Inc()
@@ -153,28 +756,420 @@ func (r *postgresRepository) Update(ctx context.Context, product *model.Product)
 	return nil
 }
 
-func (r *postgresRepository) Delete(ctx context.Context, id int64) error {
+func (r *postgresRepository) Archive(ctx context.Context, id int64) error {
 	start := time.Now()
 
-	query := `DELETE FROM products WHERE id = $1`
-	result, err := r.db.ExecContext(ctx, query, id)
+	query := `UPDATE products SET status = $1, deleted_at = CURRENT_TIMESTAMP, version = version + 1 WHERE id = $2 AND status <> $1`
+	result, err := r.db.ExecContext(ctx, query, model.ProductStatusArchived, id)
+
+	var rowsAffected int64
+	if err == nil {
+		rowsAffected, err = result.RowsAffected()
+	}
+	// Повторная архивация ничего не меняет, но продукт должен существовать
+	if err == nil && rowsAffected == 0 {
+		_, err = r.productStatus(ctx, id)
+	}
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "UPDATE").Observe(duration)
+
+	if errors.Is(err, ErrProductNotFound) {
+		return err
+	}
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "UPDATE").Inc()
+		return err
+	}
+
+	return nil
+}
+
+func (r *postgresRepository) Restore(ctx context.Context, id int64) error {
+	start := time.Now()
+
+	query := `UPDATE products SET status = $1, deleted_at = NULL, version = version + 1 WHERE id = $2 AND status = $3`
+	result, err := r.db.ExecContext(ctx, query, model.ProductStatusActive, id, model.ProductStatusArchived)
+
+	var rowsAffected int64
+	if err == nil {
+		rowsAffected, err = result.RowsAffected()
+	}
+	if err == nil && rowsAffected == 0 {
+		var status string
+		if status, err = r.productStatus(ctx, id); err == nil {
+			err = fmt.Errorf("%w: product %d is %s", ErrProductNotArchived, id, status)
+		}
+	}
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "UPDATE").Observe(duration)
+
+	if errors.Is(err, ErrProductNotFound) || errors.Is(err, ErrProductNotArchived) {
+		return err
+	}
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "UPDATE").Inc()
+		return err
+	}
+
+	return nil
+}
+
+func (r *postgresRepository) Purge(ctx context.Context, id int64) ([]*model.ProductImage, error) {
+	start := time.Now()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "DELETE").Inc()
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Блокировка не дает восстановить продукт между проверкой статуса и удалением
+	var status string
+	err = tx.QueryRowContext(ctx, `SELECT status FROM products WHERE id = $1 FOR UPDATE`, id).Scan(&status)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = fmt.Errorf("%w: id %d", ErrProductNotFound, id)
+	}
+	if err == nil && status != model.ProductStatusArchived {
+		err = fmt.Errorf("%w: archive product %d before purging it", ErrProductNotArchived, id)
+	}
+	var images []*model.ProductImage
+	if err == nil {
+		// CreateImage берет ту же блокировку, поэтому список полон: новое изображение появиться уже не может
+		images, err = purgedImages(ctx, tx, id)
+	}
+	if err == nil {
+		// Варианты, журнал, история цен, изображения и связи с категориями удаляются каскадно
+		_, err = tx.ExecContext(ctx, `DELETE FROM products WHERE id = $1`, id)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
 
 	duration := time.Since(start).Seconds()
 	metrics.DBQueryDuration.WithLabelValues("product-service", "DELETE").Observe(duration)
 
+	if errors.Is(err, ErrProductNotFound) || errors.Is(err, ErrProductNotArchived) {
+		return nil, err
+	}
+
 	if err != nil {
 		metrics.DBErrors.WithLabelValues("product-service", "DELETE").Inc()
+		return nil, err
+	}
+
+	return images, nil
+}
+
+func purgedImages(ctx context.Context, tx *sql.Tx, productID int64) ([]*model.ProductImage, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, product_id, position, storage_key, content_type, size_bytes, width, height, alt_text, created_at
+		FROM product_images
+		WHERE product_id = $1
+		ORDER BY position
+	`, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var images []*model.ProductImage
+	for rows.Next() {
+		img, err := scanImage(rows)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, img)
+	}
+	return images, rows.Err()
+}
+
+// productStatus отдает статус продукта или ErrProductNotFound
+func (r *postgresRepository) productStatus(ctx context.Context, id int64) (string, error) {
+	var status string
+	err := r.db.QueryRowContext(ctx, `SELECT status FROM products WHERE id = $1`, id).Scan(&status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", fmt.Errorf("%w: id %d", ErrProductNotFound, id)
+	}
+	return status, err
+}
+
+func (r *postgresRepository) AdjustStock(ctx context.Context, movement *model.StockMovement) error {
+	return r.AdjustStockBatch(ctx, []*model.StockMovement{movement})
+}
+
+func (r *postgresRepository) AdjustStockBatch(ctx context.Context, movements []*model.StockMovement) error {
+	start := time.Now()
+
+	err := r.adjustStockBatch(ctx, movements)
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "UPDATE").Observe(duration)
+
+	if err != nil && !errors.Is(err, ErrInsufficientStock) && !errors.Is(err, ErrProductNotFound) &&
+		!errors.Is(err, ErrVariantNotFound) && !errors.Is(err, ErrVariantRequired) && !errors.Is(err, ErrProductUnavailable) {
+		metrics.DBErrors.WithLabelValues("product-service", "UPDATE").Inc()
+	}
+	return err
+}
+
+// variantStock — заблокированный вариант и его остаток с учетом уже проверенных позиций пакета
+type variantStock struct {
+	productID int64
+	stock     int
+}
+
+func (r *postgresRepository) adjustStockBatch(ctx context.Context, movements []*model.StockMovement) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
 		return err
 	}
+	defer func() { _ = tx.Rollback() }()
 
-	rowsAffected, err := result.RowsAffected()
+	ids := make([]int64, len(movements))
+	for i, m := range movements {
+		ids[i] = m.ProductID
+	}
+
+	// Один порядок блокировок у всех пакетов: сначала продукты, затем их варианты, оба по возрастанию id.
+	// Встречные заказы не попадут в дедлок
+	rows, err := tx.QueryContext(ctx, `SELECT id, status FROM products WHERE id = ANY($1) ORDER BY id FOR UPDATE`, pq.Array(ids))
 	if err != nil {
-		metrics.DBErrors.WithLabelValues("product-service", "DELETE").Inc()
 		return err
 	}
+	statuses := make(map[int64]string, len(ids))
+	for rows.Next() {
+		var id int64
+		var status string
+		if err := rows.Scan(&id, &status); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		statuses[id] = status
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	rows, err = tx.QueryContext(ctx, `SELECT id, product_id, stock FROM product_variants WHERE product_id = ANY($1) ORDER BY id FOR UPDATE`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	variants := make(map[int64]*variantStock)
+	byProduct := make(map[int64][]int64, len(ids))
+	for rows.Next() {
+		var id int64
+		v := &variantStock{}
+		if err := rows.Scan(&id, &v.productID, &v.stock); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		variants[id] = v
+		byProduct[v.productID] = append(byProduct[v.productID], id)
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, m := range movements {
+		status, found := statuses[m.ProductID]
+		if !found {
+			return fmt.Errorf("%w: id %d", ErrProductNotFound, m.ProductID)
+		}
+		// Новые заказы не резервируют черновики и архивные продукты; возвраты и отмены пополняют их как обычно
+		if m.Delta < 0 && status != model.ProductStatusActive {
+			return fmt.Errorf("%w: product %d is %s", ErrProductUnavailable, m.ProductID, status)
+		}
+		if m.VariantID == 0 {
+			if len(byProduct[m.ProductID]) != 1 {
+				return fmt.Errorf("%w: product %d has %d variants", ErrVariantRequired, m.ProductID, len(byProduct[m.ProductID]))
+			}
+			m.VariantID = byProduct[m.ProductID][0]
+		}
+		v, ok := variants[m.VariantID]
+		if !ok || v.productID != m.ProductID {
+			return fmt.Errorf("%w: variant %d of product %d", ErrVariantNotFound, m.VariantID, m.ProductID)
+		}
+		if v.stock+m.Delta < 0 {
+			return &InsufficientStockError{ProductID: m.ProductID, VariantID: m.VariantID, Delta: m.Delta, Available: v.stock}
+		}
+		v.stock += m.Delta
+	}
+
+	sorted := slices.Clone(movements)
+	slices.SortFunc(sorted, func(a, b *model.StockMovement) int {
+		return cmp.Or(cmp.Compare(a.ProductID, b.ProductID), cmp.Compare(a.VariantID, b.VariantID))
+	})
+	for _, m := range sorted {
+		err := tx.QueryRowContext(ctx, `UPDATE product_variants SET stock = stock + $1 WHERE id = $2 RETURNING stock`,
+			m.Delta, m.VariantID).Scan(&m.StockAfter)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, `UPDATE products SET stock = stock + $1, version = version + 1 WHERE id = $2`, m.Delta, m.ProductID)
+		if err != nil {
+			return err
+		}
+		if err := insertStockMovement(ctx, tx, m); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *postgresRepository) ListStockMovements(ctx context.Context, productID int64, limit, offset int) ([]*model.StockMovement, error) {
+	start := time.Now()
+
+	query := `SELECT id, product_id, COALESCE(variant_id, 0), delta, stock_after, reason, reference, actor, created_at FROM stock_movements
+		WHERE product_id = $1 ORDER BY id DESC LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.QueryContext(ctx, query, productID, limit, offset)
+	if err != nil {
+		duration := time.Since(start).Seconds()
+		metrics.DBQueryDuration.WithLabelValues("product-service", "SELECT").Observe(duration)
+		metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	movements := make([]*model.StockMovement, 0)
+	for rows.Next() {
+		var m model.StockMovement
+		if err := rows.Scan(&m.ID, &m.ProductID, &m.VariantID, &m.Delta, &m.StockAfter, &m.Reason, &m.Reference, &m.Actor, &m.CreatedAt); err != nil {
+			metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+			return nil, err
+		}
+		movements = append(movements, &m)
+	}
 
-	if rowsAffected == 0 {
-		return fmt.Errorf("product with id %d not found", id)
+	if err = rows.Err(); err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+		return nil, err
+	}
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "SELECT").Observe(duration)
+
+	return movements, nil
+}
+
+// insertStockMovement пишет запись журнала в той же транзакции, что и изменение остатка
+func insertStockMovement(ctx context.Context, tx *sql.Tx, m *model.StockMovement) error {
+	query := `INSERT INTO stock_movements (product_id, variant_id, delta, stock_after, reason, reference, actor) VALUES ($1, NULLIF($2::bigint, 0), $3, $4, $5, $6, $7) RETURNING id, created_at`
+
+	return tx.QueryRowContext(ctx, query, m.ProductID, m.VariantID, m.Delta, m.StockAfter, m.Reason, m.Reference, m.Actor).Scan(&m.ID, &m.CreatedAt)
+}
+
+func (r *postgresRepository) CategoryPaths(ctx context.Context, productIDs []int64) (map[int64][]model.CategoryPath, error) {
+	start := time.Now()
+
+	// Поднимаемся от каждой категории продукта к корню; depth DESC дает путь от корня
+	query := `
+		WITH RECURSIVE chain AS (
+			SELECT pc.product_id, pc.category_id AS leaf_id, c.id, c.name, c.parent_id, 0 AS depth
+			FROM product_categories pc
+			JOIN categories c ON c.id = pc.category_id
+			WHERE pc.product_id = ANY($1)
+			UNION ALL
+			SELECT chain.product_id, chain.leaf_id, c.id, c.name, c.parent_id, chain.depth + 1
+			FROM categories c JOIN chain ON c.id = chain.parent_id
+		)
+		SELECT product_id, leaf_id, id, name FROM chain ORDER BY product_id, leaf_id, depth DESC
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(productIDs))
+	if err != nil {
+		duration := time.Since(start).Seconds()
+		metrics.DBQueryDuration.WithLabelValues("product-service", "SELECT").Observe(duration)
+		metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	paths := make(map[int64][]model.CategoryPath)
+	var lastProduct, lastLeaf int64
+	for rows.Next() {
+		var productID, leafID int64
+		var ref model.CategoryRef
+		if err := rows.Scan(&productID, &leafID, &ref.ID, &ref.Name); err != nil {
+			metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+			return nil, err
+		}
+		if productID != lastProduct || leafID != lastLeaf {
+			paths[productID] = append(paths[productID], nil)
+			lastProduct, lastLeaf = productID, leafID
+		}
+		current := paths[productID]
+		current[len(current)-1] = append(current[len(current)-1], ref)
+	}
+
+	if err = rows.Err(); err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+		return nil, err
+	}
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "SELECT").Observe(duration)
+
+	return paths, nil
+}
+
+func (r *postgresRepository) SetCategories(ctx context.Context, productID int64, categoryIDs []int64) error {
+	start := time.Now()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "UPDATE").Inc()
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Категории — часть представления продукта, поэтому меняется и версия (ETag)
+	var version int64
+	err = tx.QueryRowContext(ctx, `UPDATE products SET version = version + 1 WHERE id = $1 RETURNING version`, productID).Scan(&version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: id %d", ErrProductNotFound, productID)
+	}
+
+	var found int
+	if err == nil {
+		err = tx.QueryRowContext(ctx, `SELECT COUNT(*) FROM categories WHERE id = ANY($1)`, pq.Array(categoryIDs)).Scan(&found)
+	}
+	if err == nil && found != len(categoryIDs) {
+		return fmt.Errorf("%w: some of %v", ErrCategoryNotFound, categoryIDs)
+	}
+	if err == nil {
+		_, err = tx.ExecContext(ctx, `DELETE FROM product_categories WHERE product_id = $1`, productID)
+	}
+	if err == nil && len(categoryIDs) > 0 {
+		_, err = tx.ExecContext(ctx,
+			`INSERT INTO product_categories (product_id, category_id) SELECT $1, unnest($2::bigint[])`,
+			productID, pq.Array(categoryIDs),
+		)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "UPDATE").Observe(duration)
+
+	// Категорию могли удалить между проверкой и вставкой
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == pgForeignKeyViolation {
+		return fmt.Errorf("%w: some of %v", ErrCategoryNotFound, categoryIDs)
+	}
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "UPDATE").Inc()
+		return err
 	}
 
 	return nil
@@ -0,0 +1,113 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	_ "github.com/lib/pq"
+	"go.uber.org/zap"
+
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/repository"
+	"github.com/microserviceteam0/bff-gateway/product-service/pkg/database"
+	"github.com/microserviceteam0/bff-gateway/product-service/pkg/logger"
+)
+
+// openBenchDB применяет миграции в отдельной схеме базы из PRODUCT_SERVICE_TEST_DATABASE_URL;
+// без переменной бенчмарк пропускается
+func openBenchDB(b *testing.B) *sql.DB {
+	b.Helper()
+	dsn := os.Getenv("PRODUCT_SERVICE_TEST_DATABASE_URL")
+	if dsn == "" {
+		b.Skip("PRODUCT_SERVICE_TEST_DATABASE_URL is not set")
+	}
+	logger.Log = zap.NewNop()
+
+	admin, err := sql.Open("postgres", dsn)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { _ = admin.Close() })
+
+	schema := fmt.Sprintf("bench_%d", time.Now().UnixNano())
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { _, _ = admin.Exec("DROP SCHEMA " + schema + " CASCADE") })
+
+	u, err := url.Parse(dsn)
+	if err != nil {
+		b.Fatal(err)
+	}
+	q := u.Query()
+	q.Set("search_path", schema)
+	u.RawQuery = q.Encode()
+
+	db, err := sql.Open("postgres", u.String())
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Cleanup(func() { _ = db.Close() })
+
+	if err := database.RunMigrations(db, "../../migrations"); err != nil {
+		b.Fatal(err)
+	}
+	return db
+}
+
+// BenchmarkGetProducts сравнивает прежний способ (весь каталог + фильтр в Go) с выборкой по ID
+// на каталоге из 10 000 продуктов
+func BenchmarkGetProducts(b *testing.B) {
+	db := openBenchDB(b)
+	ctx := context.Background()
+
+	_, err := db.Exec(`INSERT INTO products (name, price, price_minor, stock)
+		SELECT 'Product ' || g, 100, 10000, 10 FROM generate_series(1, 10000) g`)
+	if err != nil {
+		b.Fatal(err)
+	}
+	if _, err := db.Exec("ANALYZE products"); err != nil {
+		b.Fatal(err)
+	}
+
+	repo := repository.NewPostgresRepository(db)
+	ids := []int64{42, 512, 1024, 4096, 9999}
+
+	b.Run("CatalogScan", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			all, err := repo.FindAll(ctx)
+			if err != nil {
+				b.Fatal(err)
+			}
+			requested := make(map[int64]bool, len(ids))
+			for _, id := range ids {
+				requested[id] = true
+			}
+			found := 0
+			for _, p := range all {
+				if requested[p.ID] {
+					found++
+				}
+			}
+			if found != len(ids) {
+				b.Fatalf("found %d products, want %d", found, len(ids))
+			}
+		}
+	})
+
+	b.Run("BatchLookup", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			products, err := repo.FindByIDs(ctx, ids)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if len(products) != len(ids) {
+				b.Fatalf("found %d products, want %d", len(products), len(ids))
+			}
+		}
+	})
+}
@@ -0,0 +1,326 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/model"
+	"github.com/microserviceteam0/bff-gateway/shared/metrics"
+)
+
+var (
+	// ErrVariantNotFound возвращается для несуществующего варианта или варианта другого продукта
+	ErrVariantNotFound = errors.New("product variant not found")
+	// ErrVariantRequired — у продукта несколько вариантов, и изменение остатка должно назвать вариант
+	ErrVariantRequired = errors.New("product variant must be specified")
+	// ErrSKUTaken — артикул уже занят другим вариантом (без учета регистра)
+	ErrSKUTaken = errors.New("sku already taken")
+	// ErrLastVariant — у продукта должен остаться хотя бы один вариант
+	ErrLastVariant = errors.New("cannot delete the last variant of a product")
+)
+
+func (r *postgresRepository) Variants(ctx context.Context, productIDs []int64) (map[int64][]*model.ProductVariant, error) {
+	start := time.Now()
+
+	query := `
+		SELECT id, product_id, sku, attributes, price_minor, stock, created_at, updated_at
+		FROM product_variants
+		WHERE product_id = ANY($1)
+		ORDER BY product_id, id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(productIDs))
+	if err != nil {
+		duration := time.Since(start).Seconds()
+		metrics.DBQueryDuration.WithLabelValues("product-service", "SELECT").Observe(duration)
+		metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	variants := make(map[int64][]*model.ProductVariant)
+	for rows.Next() {
+		var v model.ProductVariant
+		var attributes []byte
+		err := rows.Scan(
+			&v.ID,
+			&v.ProductID,
+			&v.SKU,
+			&attributes,
+			&v.PriceMinor,
+			&v.Stock,
+			&v.CreatedAt,
+			&v.UpdatedAt,
+		)
+		if err == nil {
+			err = json.Unmarshal(attributes, &v.Attributes)
+		}
+		if err != nil {
+			metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+			return nil, err
+		}
+		variants[v.ProductID] = append(variants[v.ProductID], &v)
+	}
+
+	if err = rows.Err(); err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "SELECT").Inc()
+		return nil, err
+	}
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "SELECT").Observe(duration)
+
+	return variants, nil
+}
+
+func (r *postgresRepository) CreateVariant(ctx context.Context, variant *model.ProductVariant) error {
+	start := time.Now()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "INSERT").Inc()
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	err = lockProduct(ctx, tx, variant.ProductID, variant.Stock)
+	if err == nil {
+		err = insertVariant(ctx, tx, variant)
+	}
+	if err == nil && variant.Stock != 0 {
+		err = insertStockMovement(ctx, tx, &model.StockMovement{
+			ProductID:  variant.ProductID,
+			VariantID:  variant.ID,
+			Delta:      variant.Stock,
+			StockAfter: variant.Stock,
+			Reason:     model.StockReasonInitial,
+			Actor:      model.StockActorAPI,
+		})
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "INSERT").Observe(duration)
+
+	if errors.Is(err, ErrProductNotFound) || errors.Is(err, ErrSKUTaken) {
+		return err
+	}
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "INSERT").Inc()
+		return err
+	}
+
+	return nil
+}
+
+func (r *postgresRepository) UpdateVariant(ctx context.Context, variant *model.ProductVariant) error {
+	start := time.Now()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "UPDATE").Inc()
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	attributes, err := variantAttributes(variant)
+	if err != nil {
+		return err
+	}
+
+	// Продукт блокируется раньше варианта, как в AdjustStockBatch
+	var oldStock int
+	err = lockProduct(ctx, tx, variant.ProductID, 0)
+	if err == nil {
+		query := `
+			UPDATE product_variants v
+			SET sku = $1, attributes = $2, price_minor = $3, stock = $4
+			FROM (SELECT id, stock FROM product_variants WHERE id = $5 AND product_id = $6 FOR UPDATE) old
+			WHERE v.id = old.id
+			RETURNING v.created_at, v.updated_at, old.stock
+		`
+		err = tx.QueryRowContext(ctx, query,
+			variant.SKU,
+			attributes,
+			variant.PriceMinor,
+			variant.Stock,
+			variant.ID,
+			variant.ProductID,
+		).Scan(&variant.CreatedAt, &variant.UpdatedAt, &oldStock)
+		if err != nil {
+			err = variantWriteError(err)
+		}
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		err = fmt.Errorf("%w: variant %d of product %d", ErrVariantNotFound, variant.ID, variant.ProductID)
+	}
+	if err == nil && oldStock != variant.Stock {
+		_, err = tx.ExecContext(ctx, `UPDATE products SET stock = stock + $1 WHERE id = $2`, variant.Stock-oldStock, variant.ProductID)
+		if err == nil {
+			err = insertStockMovement(ctx, tx, &model.StockMovement{
+				ProductID:  variant.ProductID,
+				VariantID:  variant.ID,
+				Delta:      variant.Stock - oldStock,
+				StockAfter: variant.Stock,
+				Reason:     model.StockReasonManual,
+				Actor:      model.StockActorAPI,
+			})
+		}
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "UPDATE").Observe(duration)
+
+	if errors.Is(err, ErrProductNotFound) || errors.Is(err, ErrVariantNotFound) || errors.Is(err, ErrSKUTaken) {
+		return err
+	}
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "UPDATE").Inc()
+		return err
+	}
+
+	return nil
+}
+
+func (r *postgresRepository) DeleteVariant(ctx context.Context, productID, variantID int64) error {
+	start := time.Now()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "DELETE").Inc()
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var variantIDs []int64
+	var stock int
+	err = lockProduct(ctx, tx, productID, 0)
+	if err == nil {
+		variantIDs, err = lockVariants(ctx, tx, productID)
+	}
+	if err == nil {
+		err = tx.QueryRowContext(ctx, `SELECT stock FROM product_variants WHERE id = $1 AND product_id = $2`, variantID, productID).Scan(&stock)
+	}
+	if errors.Is(err, sql.ErrNoRows) {
+		err = fmt.Errorf("%w: variant %d of product %d", ErrVariantNotFound, variantID, productID)
+	}
+	if err == nil && len(variantIDs) == 1 {
+		err = fmt.Errorf("%w: product %d", ErrLastVariant, productID)
+	}
+	// Списание пишется до удаления: ссылка на вариант в журнале станет NULL, а запись останется
+	if err == nil && stock != 0 {
+		err = insertStockMovement(ctx, tx, &model.StockMovement{
+			ProductID: productID,
+			VariantID: variantID,
+			Delta:     -stock,
+			Reason:    model.StockReasonVariantDeleted,
+			Actor:     model.StockActorAPI,
+		})
+	}
+	if err == nil {
+		_, err = tx.ExecContext(ctx, `UPDATE products SET stock = stock - $1, version = version + 1 WHERE id = $2`, stock, productID)
+	}
+	if err == nil {
+		_, err = tx.ExecContext(ctx, `DELETE FROM product_variants WHERE id = $1`, variantID)
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+
+	duration := time.Since(start).Seconds()
+	metrics.DBQueryDuration.WithLabelValues("product-service", "DELETE").Observe(duration)
+
+	if errors.Is(err, ErrProductNotFound) || errors.Is(err, ErrVariantNotFound) || errors.Is(err, ErrLastVariant) {
+		return err
+	}
+
+	if err != nil {
+		metrics.DBErrors.WithLabelValues("product-service", "DELETE").Inc()
+		return err
+	}
+
+	return nil
+}
+
+// lockProduct блокирует продукт, прибавляет delta к его остатку и увеличивает версию:
+// варианты — часть представления продукта (ETag)
+func lockProduct(ctx context.Context, tx *sql.Tx, productID int64, delta int) error {
+	var id int64
+	err := tx.QueryRowContext(ctx, `UPDATE products SET stock = stock + $1, version = version + 1 WHERE id = $2 RETURNING id`, delta, productID).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: id %d", ErrProductNotFound, productID)
+	}
+	return err
+}
+
+// lockVariants блокирует варианты продукта по возрастанию id и возвращает их id
+func lockVariants(ctx context.Context, tx *sql.Tx, productID int64) ([]int64, error) {
+	rows, err := tx.QueryContext(ctx, `SELECT id FROM product_variants WHERE product_id = $1 ORDER BY id FOR UPDATE`, productID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// insertVariant сохраняет вариант в транзакции продукта; пустой SKU заменяется на P-<id продукта>
+func insertVariant(ctx context.Context, tx *sql.Tx, v *model.ProductVariant) error {
+	if v.SKU == "" {
+		v.SKU = fmt.Sprintf("P-%d", v.ProductID)
+	}
+	attributes, err := variantAttributes(v)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO product_variants (product_id, sku, attributes, price_minor, stock)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, updated_at
+	`
+
+	err = tx.QueryRowContext(ctx, query, v.ProductID, v.SKU, attributes, v.PriceMinor, v.Stock).Scan(&v.ID, &v.CreatedAt, &v.UpdatedAt)
+	if err != nil {
+		return variantWriteError(err)
+	}
+	return nil
+}
+
+// variantAttributes кодирует атрибуты в JSONB; nil сохраняется как пустой объект
+func variantAttributes(v *model.ProductVariant) ([]byte, error) {
+	if v.Attributes == nil {
+		v.Attributes = map[string]string{}
+	}
+	return json.Marshal(v.Attributes)
+}
+
+// variantWriteError переводит нарушение уникальности SKU в ErrSKUTaken
+func variantWriteError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == pgUniqueViolation {
+		return ErrSKUTaken
+	}
+	return err
+}
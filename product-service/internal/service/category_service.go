@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/dto"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/model"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/repository"
+)
+
+// ErrInvalidCategory возвращается для неверного родителя категории
+var ErrInvalidCategory = errors.New("invalid category")
+
+type CategoryService interface {
+	// GetByID отдает категорию с хлебными крошками и прямыми подкатегориями
+	GetByID(ctx context.Context, id int64) (*dto.CategoryResponse, error)
+	// Tree отдает дерево категорий: категории верхнего уровня с вложенными подкатегориями
+	Tree(ctx context.Context) ([]*dto.CategoryResponse, error)
+	Create(ctx context.Context, req *dto.CategoryRequest) (*dto.CategoryResponse, error)
+	Update(ctx context.Context, id int64, req *dto.CategoryRequest) (*dto.CategoryResponse, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+type categoryService struct {
+	repo repository.CategoryRepository
+}
+
+func NewCategoryService(repo repository.CategoryRepository) CategoryService {
+	return &categoryService{repo: repo}
+}
+
+func (s *categoryService) GetByID(ctx context.Context, id int64) (*dto.CategoryResponse, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("%w: id %d", repository.ErrCategoryNotFound, id)
+	}
+
+	category, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	path, err := s.repo.Path(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	all, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := dto.ToCategoryResponse(category)
+	resp.Path = path
+	for _, c := range all {
+		if c.ParentID != nil && *c.ParentID == id {
+			resp.Children = append(resp.Children, dto.ToCategoryResponse(c))
+		}
+	}
+	return resp, nil
+}
+
+func (s *categoryService) Tree(ctx context.Context) ([]*dto.CategoryResponse, error) {
+	all, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return buildCategoryTree(all), nil
+}
+
+// buildCategoryTree раскладывает плоский список по родителям, сохраняя его порядок
+func buildCategoryTree(categories []*model.Category) []*dto.CategoryResponse {
+	nodes := make(map[int64]*dto.CategoryResponse, len(categories))
+	for _, c := range categories {
+		nodes[c.ID] = dto.ToCategoryResponse(c)
+	}
+
+	roots := make([]*dto.CategoryResponse, 0)
+	for _, c := range categories {
+		node := nodes[c.ID]
+		if c.ParentID == nil {
+			roots = append(roots, node)
+			continue
+		}
+		if parent, ok := nodes[*c.ParentID]; ok {
+			parent.Children = append(parent.Children, node)
+		}
+	}
+	return roots
+}
+
+func (s *categoryService) Create(ctx context.Context, req *dto.CategoryRequest) (*dto.CategoryResponse, error) {
+	category := &model.Category{Name: req.Name, ParentID: req.ParentID}
+
+	if err := s.repo.Create(ctx, category); err != nil {
+		return nil, parentError(err, req.ParentID)
+	}
+
+	return s.GetByID(ctx, category.ID)
+}
+
+func (s *categoryService) Update(ctx context.Context, id int64, req *dto.CategoryRequest) (*dto.CategoryResponse, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("%w: id %d", repository.ErrCategoryNotFound, id)
+	}
+
+	category := &model.Category{ID: id, Name: req.Name, ParentID: req.ParentID}
+
+	if err := s.repo.Update(ctx, category); err != nil {
+		// Несуществующую категорию отличаем от несуществующего родителя
+		if errors.Is(err, repository.ErrCategoryNotFound) && req.ParentID != nil {
+			if _, findErr := s.repo.FindByID(ctx, id); findErr != nil {
+				return nil, findErr
+			}
+		}
+		return nil, parentError(err, req.ParentID)
+	}
+
+	return s.GetByID(ctx, id)
+}
+
+func (s *categoryService) Delete(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return fmt.Errorf("%w: id %d", repository.ErrCategoryNotFound, id)
+	}
+
+	return s.repo.Delete(ctx, id)
+}
+
+// parentError превращает отсутствующего родителя в ошибку запроса, а не «категория не найдена»
+func parentError(err error, parentID *int64) error {
+	if parentID != nil && errors.Is(err, repository.ErrCategoryNotFound) {
+		return fmt.Errorf("%w: parent category %d not found", ErrInvalidCategory, *parentID)
+	}
+	return err
+}
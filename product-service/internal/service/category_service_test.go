@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/dto"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/model"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/repository"
+)
+
+// mockCategoryRepository хранит категории в памяти
+type mockCategoryRepository struct {
+	categories []*model.Category
+	updateErr  error
+}
+
+func (m *mockCategoryRepository) FindByID(ctx context.Context, id int64) (*model.Category, error) {
+	for _, c := range m.categories {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: id %d", repository.ErrCategoryNotFound, id)
+}
+
+func (m *mockCategoryRepository) FindAll(ctx context.Context) ([]*model.Category, error) {
+	return m.categories, nil
+}
+
+func (m *mockCategoryRepository) Create(ctx context.Context, category *model.Category) error {
+	if category.ParentID != nil {
+		if _, err := m.FindByID(ctx, *category.ParentID); err != nil {
+			return err
+		}
+	}
+	category.ID = int64(len(m.categories) + 1)
+	m.categories = append(m.categories, category)
+	return nil
+}
+
+func (m *mockCategoryRepository) Update(ctx context.Context, category *model.Category) error {
+	return m.updateErr
+}
+
+func (m *mockCategoryRepository) Delete(ctx context.Context, id int64) error {
+	return errors.New("not implemented")
+}
+
+func (m *mockCategoryRepository) Path(ctx context.Context, id int64) (model.CategoryPath, error) {
+	var path model.CategoryPath
+	for next := &id; next != nil; {
+		c, err := m.FindByID(ctx, *next)
+		if err != nil {
+			return nil, err
+		}
+		path = append(model.CategoryPath{{ID: c.ID, Name: c.Name}}, path...)
+		next = c.ParentID
+	}
+	return path, nil
+}
+
+func TestCategoryService_Tree(t *testing.T) {
+	ctx := context.Background()
+	repo := &mockCategoryRepository{}
+	service := NewCategoryService(repo)
+
+	electronics, _ := service.Create(ctx, &dto.CategoryRequest{Name: "Электроника"})
+	laptops, _ := service.Create(ctx, &dto.CategoryRequest{Name: "Ноутбуки", ParentID: &electronics.ID})
+	gaming, err := service.Create(ctx, &dto.CategoryRequest{Name: "Игровые", ParentID: &laptops.ID})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, err := service.Create(ctx, &dto.CategoryRequest{Name: "Книги"}); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(gaming.Path) != 3 || gaming.Path[0].Name != "Электроника" || gaming.Path[2].Name != "Игровые" {
+		t.Errorf("Expected breadcrumbs from the root, got %v", gaming.Path)
+	}
+
+	tree, err := service.Tree(ctx)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(tree) != 2 {
+		t.Fatalf("Expected 2 top-level categories, got %d", len(tree))
+	}
+	if len(tree[0].Children) != 1 || len(tree[0].Children[0].Children) != 1 || tree[0].Children[0].Children[0].ID != gaming.ID {
+		t.Errorf("Expected Электроника > Ноутбуки > Игровые, got %+v", tree[0])
+	}
+
+	electronicsResp, err := service.GetByID(ctx, electronics.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(electronicsResp.Children) != 1 || electronicsResp.Children[0].ID != laptops.ID {
+		t.Errorf("Expected direct subcategory Ноутбуки, got %+v", electronicsResp.Children)
+	}
+}
+
+func TestCategoryService_ParentErrors(t *testing.T) {
+	ctx := context.Background()
+	missing := int64(42)
+
+	repo := &mockCategoryRepository{categories: []*model.Category{{ID: 1, Name: "Электроника"}}}
+	service := NewCategoryService(repo)
+
+	t.Run("CreateUnderMissingParent", func(t *testing.T) {
+		_, err := service.Create(ctx, &dto.CategoryRequest{Name: "Ноутбуки", ParentID: &missing})
+		if !errors.Is(err, ErrInvalidCategory) {
+			t.Errorf("Expected ErrInvalidCategory, got %v", err)
+		}
+	})
+
+	t.Run("MoveUnderMissingParent", func(t *testing.T) {
+		repo.updateErr = fmt.Errorf("%w: parent id %d", repository.ErrCategoryNotFound, missing)
+		_, err := service.Update(ctx, 1, &dto.CategoryRequest{Name: "Электроника", ParentID: &missing})
+		if !errors.Is(err, ErrInvalidCategory) {
+			t.Errorf("Expected ErrInvalidCategory, got %v", err)
+		}
+	})
+
+	t.Run("MoveMissingCategory", func(t *testing.T) {
+		repo.updateErr = fmt.Errorf("%w: id %d", repository.ErrCategoryNotFound, 7)
+		parent := int64(1)
+		_, err := service.Update(ctx, 7, &dto.CategoryRequest{Name: "Ноутбуки", ParentID: &parent})
+		if !errors.Is(err, repository.ErrCategoryNotFound) || errors.Is(err, ErrInvalidCategory) {
+			t.Errorf("Expected ErrCategoryNotFound, got %v", err)
+		}
+	})
+
+	t.Run("MoveIntoOwnSubtree", func(t *testing.T) {
+		repo.updateErr = repository.ErrCategoryCycle
+		parent := int64(1)
+		_, err := service.Update(ctx, 1, &dto.CategoryRequest{Name: "Электроника", ParentID: &parent})
+		if !errors.Is(err, repository.ErrCategoryCycle) {
+			t.Errorf("Expected ErrCategoryCycle, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/dto"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/media"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/model"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/repository"
+)
+
+const (
+	// MaxImageBytes — предельный размер загружаемого файла
+	MaxImageBytes = 10 << 20
+	// MaxProductImages — сколько изображений может быть в галерее продукта
+	MaxProductImages = 20
+	// MaxImageAltTextLength — предельная длина альтернативного текста в символах
+	MaxImageAltTextLength = 255
+)
+
+var (
+	// ErrImageTooLarge возвращается UploadImage для файла больше MaxImageBytes
+	ErrImageTooLarge = errors.New("image is too large")
+	// ErrUnsupportedImageType возвращается UploadImage для файла не в формате JPEG, PNG или GIF
+	ErrUnsupportedImageType = errors.New("unsupported image type")
+	// ErrInvalidImage возвращается для поврежденного файла, слишком большого разрешения или длинного alt_text
+	ErrInvalidImage = errors.New("invalid image")
+	// ErrMediaCleanup — операция выполнена, но часть файлов не удалось удалить из хранилища
+	ErrMediaCleanup = errors.New("media cleanup failed")
+)
+
+func (s *productService) UploadImage(ctx context.Context, productID int64, r io.Reader, altText string) (*dto.ProductResponse, error) {
+	if productID <= 0 {
+		return nil, fmt.Errorf("%w: id %d", repository.ErrProductNotFound, productID)
+	}
+	altText = strings.TrimSpace(altText)
+	if utf8.RuneCountInString(altText) > MaxImageAltTextLength {
+		return nil, fmt.Errorf("%w: alt_text must be at most %d characters", ErrInvalidImage, MaxImageAltTextLength)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, MaxImageBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("read image: %w", err)
+	}
+	if len(data) > MaxImageBytes {
+		return nil, fmt.Errorf("%w: at most %d bytes", ErrImageTooLarge, MaxImageBytes)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: empty file", ErrInvalidImage)
+	}
+
+	decoded, err := media.Decode(data)
+	if errors.Is(err, media.ErrUnsupportedType) {
+		return nil, fmt.Errorf("%w: only JPEG, PNG and GIF are accepted", ErrUnsupportedImageType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+
+	image := &model.ProductImage{
+		ProductID:   productID,
+		StorageKey:  fmt.Sprintf("products/%d/%s%s", productID, uuid.NewString(), decoded.Ext),
+		ContentType: decoded.ContentType,
+		SizeBytes:   int64(len(data)),
+		Width:       decoded.Width,
+		Height:      decoded.Height,
+		AltText:     altText,
+	}
+
+	// Файлы пишутся до строки в БД, чтобы клиент не получил адрес, по которому еще ничего нет
+	if err := s.storeImage(ctx, image, data, decoded); err != nil {
+		return nil, errors.Join(err, s.removeImageFiles(ctx, image))
+	}
+	if err := s.repo.CreateImage(ctx, image, MaxProductImages); err != nil {
+		return nil, errors.Join(err, s.removeImageFiles(ctx, image))
+	}
+
+	return s.GetByID(ctx, productID)
+}
+
+func (s *productService) DeleteImage(ctx context.Context, productID, imageID int64) error {
+	if productID <= 0 || imageID <= 0 {
+		return fmt.Errorf("%w: image %d of product %d", repository.ErrImageNotFound, imageID, productID)
+	}
+
+	image, err := s.repo.DeleteImage(ctx, productID, imageID)
+	if err != nil {
+		return err
+	}
+	return s.removeImageFiles(ctx, image)
+}
+
+func (s *productService) ReorderImages(ctx context.Context, productID int64, imageIDs []int64) (*dto.ProductResponse, error) {
+	if productID <= 0 {
+		return nil, fmt.Errorf("%w: id %d", repository.ErrProductNotFound, productID)
+	}
+	if len(imageIDs) == 0 || len(imageIDs) > MaxProductImages || slices.ContainsFunc(imageIDs, func(id int64) bool { return id <= 0 }) {
+		return nil, repository.ErrImageOrderMismatch
+	}
+
+	if err := s.repo.ReorderImages(ctx, productID, imageIDs); err != nil {
+		return nil, err
+	}
+
+	return s.GetByID(ctx, productID)
+}
+
+// storeImage сохраняет оригинал и миниатюры всех размеров из ThumbnailSizes
+func (s *productService) storeImage(ctx context.Context, image *model.ProductImage, data []byte, decoded *media.Image) error {
+	if err := s.media.Put(ctx, image.StorageKey, bytes.NewReader(data), image.ContentType); err != nil {
+		return fmt.Errorf("store image: %w", err)
+	}
+
+	thumbType := image.ThumbnailContentType()
+	var buf bytes.Buffer
+	for _, size := range model.ThumbnailSizes {
+		buf.Reset()
+		if err := decoded.Thumbnail(&buf, size.MaxSide, thumbType); err != nil {
+			return fmt.Errorf("encode %s thumbnail: %w", size.Name, err)
+		}
+		if err := s.media.Put(ctx, image.ThumbnailKey(size.Name), &buf, thumbType); err != nil {
+			return fmt.Errorf("store %s thumbnail: %w", size.Name, err)
+		}
+	}
+	return nil
+}
+
+// removeImageFiles удаляет оригиналы и миниатюры; ошибки оборачиваются в ErrMediaCleanup
+func (s *productService) removeImageFiles(ctx context.Context, images ...*model.ProductImage) error {
+	var errs []error
+	for _, img := range images {
+		for _, key := range img.Keys() {
+			if err := s.media.Delete(ctx, key); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%w: %w", ErrMediaCleanup, errors.Join(errs...))
+	}
+	return nil
+}
+
+// setImageURLs заполняет публичные адреса оригинала и миниатюр
+func (s *productService) setImageURLs(img *model.ProductImage) {
+	if s.media == nil {
+		return
+	}
+	img.URL = s.media.URL(img.StorageKey)
+	img.Thumbnails = make([]model.ImageThumbnail, 0, len(model.ThumbnailSizes))
+	for _, size := range model.ThumbnailSizes {
+		w, h := model.FitWithin(img.Width, img.Height, size.MaxSide)
+		img.Thumbnails = append(img.Thumbnails, model.ImageThumbnail{
+			Name:   size.Name,
+			URL:    s.media.URL(img.ThumbnailKey(size.Name)),
+			Width:  w,
+			Height: h,
+		})
+	}
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/model"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/repository"
+	"github.com/microserviceteam0/bff-gateway/product-service/pkg/storage"
+)
+
+func testPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := range height {
+		for x := range width {
+			img.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestProductService_Images(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	media, err := storage.NewLocalStorage(root, "http://cdn.test/media/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stored []*model.ProductImage
+	mockRepo := &mockProductRepository{
+		findByIDFunc: func(ctx context.Context, id int64) (*model.Product, error) {
+			return &model.Product{ID: id, Name: "Poster"}, nil
+		},
+		imagesFunc: func(ctx context.Context, productIDs []int64) (map[int64][]*model.ProductImage, error) {
+			return map[int64][]*model.ProductImage{1: stored}, nil
+		},
+		createImageFunc: func(ctx context.Context, image *model.ProductImage, maxImages int) error {
+			if maxImages != MaxProductImages {
+				t.Errorf("Expected limit %d, got %d", MaxProductImages, maxImages)
+			}
+			image.ID = int64(len(stored) + 1)
+			image.Position = len(stored)
+			stored = append(stored, image)
+			return nil
+		},
+	}
+	service := NewProductService(mockRepo, media)
+
+	t.Run("Upload", func(t *testing.T) {
+		product, err := service.UploadImage(ctx, 1, bytes.NewReader(testPNG(t, 800, 400)), " Front view ")
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(product.Images) != 1 {
+			t.Fatalf("Expected 1 image, got %d", len(product.Images))
+		}
+
+		img := product.Images[0]
+		if img.ContentType != "image/png" || img.Width != 800 || img.Height != 400 || img.AltText != "Front view" {
+			t.Errorf("Unexpected image: %+v", img)
+		}
+		if !strings.HasPrefix(img.URL, "http://cdn.test/media/products/1/") || !strings.HasSuffix(img.URL, ".png") {
+			t.Errorf("Unexpected image URL %q", img.URL)
+		}
+		if len(img.Thumbnails) != len(model.ThumbnailSizes) {
+			t.Fatalf("Expected %d thumbnails, got %v", len(model.ThumbnailSizes), img.Thumbnails)
+		}
+
+		for _, key := range stored[0].Keys() {
+			if _, err := os.Stat(filepath.Join(root, key)); err != nil {
+				t.Errorf("Expected file %s to be stored: %v", key, err)
+			}
+		}
+		f, err := os.Open(filepath.Join(root, stored[0].ThumbnailKey("small")))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _ = f.Close() }()
+		cfg, err := png.DecodeConfig(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Width != 160 || cfg.Height != 80 {
+			t.Errorf("Expected small thumbnail 160x80, got %dx%d", cfg.Width, cfg.Height)
+		}
+	})
+
+	t.Run("RejectedFiles", func(t *testing.T) {
+		cases := map[string]struct {
+			data []byte
+			want error
+		}{
+			"Text":      {[]byte("definitely not an image"), ErrUnsupportedImageType},
+			"Truncated": {testPNG(t, 10, 10)[:40], ErrInvalidImage},
+			"Empty":     {nil, ErrInvalidImage},
+			"TooLarge":  {make([]byte, MaxImageBytes+1), ErrImageTooLarge},
+		}
+
+		for name, tc := range cases {
+			if _, err := service.UploadImage(ctx, 1, bytes.NewReader(tc.data), ""); !errors.Is(err, tc.want) {
+				t.Errorf("%s: expected %v, got %v", name, tc.want, err)
+			}
+		}
+		if len(stored) != 1 {
+			t.Errorf("Expected rejected files not to be saved, got %d images", len(stored))
+		}
+	})
+
+	t.Run("LimitRemovesFiles", func(t *testing.T) {
+		var rejected *model.ProductImage
+		mockRepo.createImageFunc = func(ctx context.Context, image *model.ProductImage, maxImages int) error {
+			rejected = image
+			return repository.ErrTooManyImages
+		}
+
+		_, err := service.UploadImage(ctx, 1, bytes.NewReader(testPNG(t, 20, 20)), "")
+		if !errors.Is(err, repository.ErrTooManyImages) {
+			t.Fatalf("Expected ErrTooManyImages, got %v", err)
+		}
+		for _, key := range rejected.Keys() {
+			if _, err := os.Stat(filepath.Join(root, key)); !errors.Is(err, os.ErrNotExist) {
+				t.Errorf("Expected file %s to be removed, got %v", key, err)
+			}
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		mockRepo.deleteImageFunc = func(ctx context.Context, productID, imageID int64) (*model.ProductImage, error) {
+			if imageID != stored[0].ID {
+				return nil, repository.ErrImageNotFound
+			}
+			return stored[0], nil
+		}
+
+		if err := service.DeleteImage(ctx, 1, 99); !errors.Is(err, repository.ErrImageNotFound) {
+			t.Errorf("Expected ErrImageNotFound, got %v", err)
+		}
+		if err := service.DeleteImage(ctx, 1, stored[0].ID); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(root, stored[0].StorageKey)); !errors.Is(err, os.ErrNotExist) {
+			t.Errorf("Expected original to be removed, got %v", err)
+		}
+	})
+
+	t.Run("PurgeRemovesFiles", func(t *testing.T) {
+		mockRepo.createImageFunc = func(ctx context.Context, image *model.ProductImage, maxImages int) error {
+			image.ID = 10
+			stored = []*model.ProductImage{image}
+			return nil
+		}
+		if _, err := service.UploadImage(ctx, 1, bytes.NewReader(testPNG(t, 40, 40)), ""); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		mockRepo.archiveFunc = func(ctx context.Context, id int64) error {
+			return nil
+		}
+		mockRepo.purgeFunc = func(ctx context.Context, id int64) ([]*model.ProductImage, error) {
+			return nil, repository.ErrProductNotArchived
+		}
+
+		// Архивный продукт можно восстановить, поэтому его файлы остаются
+		if err := service.Delete(ctx, 1); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if err := service.Purge(ctx, 1); !errors.Is(err, repository.ErrProductNotArchived) {
+			t.Fatalf("Expected ErrProductNotArchived, got %v", err)
+		}
+		for _, key := range stored[0].Keys() {
+			if _, err := os.Stat(filepath.Join(root, key)); err != nil {
+				t.Errorf("Expected file %s to be kept: %v", key, err)
+			}
+		}
+
+		// Файлы удаляются по списку, который Purge прочитал под блокировкой продукта
+		mockRepo.purgeFunc = func(ctx context.Context, id int64) ([]*model.ProductImage, error) {
+			return stored, nil
+		}
+		if err := service.Purge(ctx, 1); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		for _, key := range stored[0].Keys() {
+			if _, err := os.Stat(filepath.Join(root, key)); !errors.Is(err, os.ErrNotExist) {
+				t.Errorf("Expected file %s to be removed, got %v", key, err)
+			}
+		}
+	})
+
+	t.Run("ReorderValidation", func(t *testing.T) {
+		for _, ids := range [][]int64{nil, {1, 0}, make([]int64, MaxProductImages+1)} {
+			if _, err := service.ReorderImages(ctx, 1, ids); !errors.Is(err, repository.ErrImageOrderMismatch) {
+				t.Errorf("%v: expected ErrImageOrderMismatch, got %v", ids, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/dto"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/model"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/repository"
+)
+
+// ErrInvalidPriceSchedule возвращается SchedulePrice для цены в прошлом, пустого окна распродажи или слишком далекой даты
+var ErrInvalidPriceSchedule = errors.New("invalid price schedule")
+
+// MaxPriceScheduleAhead — на сколько вперед можно запланировать цену
+const MaxPriceScheduleAhead = 366 * 24 * time.Hour
+
+func (s *productService) PriceHistory(ctx context.Context, productID int64, limit, offset int) ([]*dto.PriceChangeResponse, error) {
+	if productID <= 0 {
+		return nil, fmt.Errorf("invalid product id: %d", productID)
+	}
+	if limit <= 0 {
+		limit = defaultMovementsLimit
+	}
+	limit = min(limit, maxMovementsLimit)
+	offset = max(offset, 0)
+
+	// Пустая история и несуществующий продукт должны различаться
+	if _, err := s.repo.FindByID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	changes, err := s.repo.PriceHistory(ctx, productID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*dto.PriceChangeResponse, 0, len(changes))
+	for _, c := range changes {
+		result = append(result, dto.ToPriceChangeResponse(c))
+	}
+	return result, nil
+}
+
+func (s *productService) PriceSchedules(ctx context.Context, productID int64) ([]*dto.PriceScheduleResponse, error) {
+	if productID <= 0 {
+		return nil, fmt.Errorf("invalid product id: %d", productID)
+	}
+
+	if _, err := s.repo.FindByID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	schedules, err := s.repo.PriceSchedules(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*dto.PriceScheduleResponse, 0, len(schedules))
+	for _, ps := range schedules {
+		result = append(result, dto.ToPriceScheduleResponse(ps))
+	}
+	return result, nil
+}
+
+func (s *productService) SchedulePrice(ctx context.Context, productID int64, req *dto.SchedulePriceRequest) (*dto.PriceScheduleResponse, error) {
+	if productID <= 0 {
+		return nil, fmt.Errorf("%w: id %d", repository.ErrProductNotFound, productID)
+	}
+
+	now := time.Now()
+	switch {
+	case req.PriceMinor < 0:
+		return nil, fmt.Errorf("%w: price_minor must not be negative", ErrInvalidPriceSchedule)
+	case !req.StartsAt.After(now):
+		return nil, fmt.Errorf("%w: starts_at must be in the future", ErrInvalidPriceSchedule)
+	case req.StartsAt.After(now.Add(MaxPriceScheduleAhead)):
+		return nil, fmt.Errorf("%w: starts_at must be within %d days", ErrInvalidPriceSchedule, int(MaxPriceScheduleAhead.Hours()/24))
+	case req.EndsAt != nil && !req.EndsAt.After(req.StartsAt):
+		return nil, fmt.Errorf("%w: ends_at must be after starts_at", ErrInvalidPriceSchedule)
+	}
+
+	schedule := &model.PriceSchedule{
+		ProductID:  productID,
+		PriceMinor: req.PriceMinor,
+		StartsAt:   req.StartsAt.UTC(),
+		EndsAt:     req.EndsAt,
+		Actor:      model.PriceActorAPI,
+	}
+	if schedule.EndsAt != nil {
+		endsAt := schedule.EndsAt.UTC()
+		schedule.EndsAt = &endsAt
+	}
+
+	if err := s.repo.CreatePriceSchedule(ctx, schedule); err != nil {
+		return nil, err
+	}
+
+	return dto.ToPriceScheduleResponse(schedule), nil
+}
+
+func (s *productService) CancelPriceSchedule(ctx context.Context, productID, scheduleID int64) error {
+	if productID <= 0 || scheduleID <= 0 {
+		return fmt.Errorf("%w: schedule %d of product %d", repository.ErrPriceScheduleNotFound, scheduleID, productID)
+	}
+
+	return s.repo.CancelPriceSchedule(ctx, productID, scheduleID, model.PriceActorAPI)
+}
+
+func (s *productService) ApplyDuePriceSchedules(ctx context.Context, now time.Time, limit int) (int, error) {
+	return s.repo.ApplyDuePriceSchedules(ctx, now, limit)
+}
+
+// compareAtPrice — цена до распродажи для зачеркивания; только если она в той же валюте и выше текущей
+func compareAtPrice(p *model.Product, sale *model.PriceSchedule) *int64 {
+	if sale == nil {
+		return nil
+	}
+	revert, ok := sale.RevertPrice()
+	if !ok || revert.Currency != p.Currency || revert.Amount <= p.PriceMinor {
+		return nil
+	}
+	return &revert.Amount
+}
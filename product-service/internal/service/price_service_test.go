@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/dto"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/model"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/repository"
+)
+
+func TestProductService_PriceSchedules(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := &mockProductRepository{}
+	service := NewProductService(mockRepo, nil)
+
+	t.Run("Schedule", func(t *testing.T) {
+		startsAt := time.Now().Add(24 * time.Hour)
+		endsAt := startsAt.Add(72 * time.Hour)
+		mockRepo.createScheduleFunc = func(ctx context.Context, schedule *model.PriceSchedule) error {
+			if schedule.ProductID != 1 || schedule.PriceMinor != 7900 || schedule.Actor != model.PriceActorAPI {
+				t.Errorf("Unexpected schedule: %+v", schedule)
+			}
+			if schedule.StartsAt.Location() != time.UTC || schedule.EndsAt == nil || !schedule.EndsAt.Equal(endsAt) {
+				t.Errorf("Expected UTC sale window, got %v - %v", schedule.StartsAt, schedule.EndsAt)
+			}
+			schedule.ID = 5
+			schedule.Currency = "RUB"
+			schedule.Status = model.PriceSchedulePending
+			return nil
+		}
+
+		resp, err := service.SchedulePrice(ctx, 1, &dto.SchedulePriceRequest{PriceMinor: 7900, StartsAt: startsAt, EndsAt: &endsAt})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if resp.ID != 5 || resp.Status != model.PriceSchedulePending || resp.Currency != "RUB" {
+			t.Errorf("Unexpected response: %+v", resp)
+		}
+	})
+
+	t.Run("InvalidSchedules", func(t *testing.T) {
+		now := time.Now()
+		before := now.Add(time.Hour)
+		requests := map[string]*dto.SchedulePriceRequest{
+			"NegativePrice": {PriceMinor: -1, StartsAt: now.Add(2 * time.Hour)},
+			"InThePast":     {PriceMinor: 100, StartsAt: now.Add(-time.Minute)},
+			"TooFarAhead":   {PriceMinor: 100, StartsAt: now.Add(MaxPriceScheduleAhead + time.Hour)},
+			"EndsBefore":    {PriceMinor: 100, StartsAt: now.Add(2 * time.Hour), EndsAt: &before},
+		}
+
+		for name, req := range requests {
+			if _, err := service.SchedulePrice(ctx, 1, req); !errors.Is(err, ErrInvalidPriceSchedule) {
+				t.Errorf("%s: expected ErrInvalidPriceSchedule, got %v", name, err)
+			}
+		}
+	})
+
+	t.Run("Overlap", func(t *testing.T) {
+		mockRepo.createScheduleFunc = func(ctx context.Context, schedule *model.PriceSchedule) error {
+			return repository.ErrPriceScheduleOverlap
+		}
+
+		_, err := service.SchedulePrice(ctx, 1, &dto.SchedulePriceRequest{PriceMinor: 100, StartsAt: time.Now().Add(time.Hour)})
+		if !errors.Is(err, repository.ErrPriceScheduleOverlap) {
+			t.Errorf("Expected ErrPriceScheduleOverlap, got %v", err)
+		}
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		mockRepo.cancelScheduleFunc = func(ctx context.Context, productID, scheduleID int64, actor string) error {
+			if productID != 1 || scheduleID != 5 || actor != model.PriceActorAPI {
+				t.Errorf("Unexpected cancel of schedule %d of product %d by %q", scheduleID, productID, actor)
+			}
+			return nil
+		}
+
+		if err := service.CancelPriceSchedule(ctx, 1, 5); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if err := service.CancelPriceSchedule(ctx, 1, 0); !errors.Is(err, repository.ErrPriceScheduleNotFound) {
+			t.Errorf("Expected ErrPriceScheduleNotFound, got %v", err)
+		}
+	})
+}
+
+func TestProductService_CompareAtPrice(t *testing.T) {
+	ctx := context.Background()
+	rub, usd := "RUB", "USD"
+	higher, lower := int64(9900), int64(5000)
+
+	tests := []struct {
+		name     string
+		sale     *model.PriceSchedule
+		expected *int64
+	}{
+		{name: "NoSale"},
+		{name: "Discount", sale: &model.PriceSchedule{RevertPriceMinor: &higher, RevertCurrency: &rub}, expected: &higher},
+		{name: "PriceIncrease", sale: &model.PriceSchedule{RevertPriceMinor: &lower, RevertCurrency: &rub}},
+		{name: "OtherCurrency", sale: &model.PriceSchedule{RevertPriceMinor: &higher, RevertCurrency: &usd}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockProductRepository{
+				findByIDFunc: func(ctx context.Context, id int64) (*model.Product, error) {
+					return &model.Product{ID: id, Name: "Sneakers", PriceMinor: 7900, Currency: "RUB"}, nil
+				},
+				activeSchedulesFunc: func(ctx context.Context, productIDs []int64) (map[int64]*model.PriceSchedule, error) {
+					if tt.sale == nil {
+						return nil, nil
+					}
+					return map[int64]*model.PriceSchedule{1: tt.sale}, nil
+				},
+			}
+
+			product, err := NewProductService(mockRepo, nil).GetByID(ctx, 1)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			got := product.CompareAtPriceMinor
+			if (got == nil) != (tt.expected == nil) || (got != nil && *got != *tt.expected) {
+				t.Errorf("Expected compare-at price %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
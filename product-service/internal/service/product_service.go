@@ -2,26 +2,133 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/microserviceteam0/bff-gateway/product-service/internal/dto"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/model"
 	"github.com/microserviceteam0/bff-gateway/product-service/internal/repository"
+	"github.com/microserviceteam0/bff-gateway/product-service/pkg/storage"
+	"github.com/microserviceteam0/bff-gateway/shared/money"
+)
+
+// MaxBatchSize — сколько разных продуктов можно запросить за один GetByIDs
+const MaxBatchSize = 500
+
+// ErrBatchTooLarge возвращается GetByIDs, если разных ID больше MaxBatchSize
+var ErrBatchTooLarge = errors.New("too many product ids")
+
+// ErrInvalidStockChange возвращается AdjustStock для нулевого изменения или слишком длинных полей журнала
+var ErrInvalidStockChange = errors.New("invalid stock change")
+
+// ErrInvalidListRequest возвращается List для неверной сортировки, диапазона или курсора
+var ErrInvalidListRequest = errors.New("invalid list request")
+
+// ErrInvalidCategories возвращается SetCategories для неположительных ID или слишком длинного списка
+var ErrInvalidCategories = errors.New("invalid category ids")
+
+// MaxProductCategories — в скольких категориях может состоять продукт
+const MaxProductCategories = 20
+
+// ErrInvalidVariant возвращается для варианта с пустым SKU, отрицательной ценой или остатком, неверными атрибутами
+var ErrInvalidVariant = errors.New("invalid product variant")
+
+const (
+	// MaxVariantAttributes — сколько атрибутов (размер, цвет...) может быть у варианта
+	MaxVariantAttributes = 20
+	// MaxVariantAttributeLength — предельная длина имени и значения атрибута
+	MaxVariantAttributeLength = 100
+	// MaxSKULength — предельная длина артикула
+	MaxSKULength = 64
+)
+
+// ErrInvalidSearchQuery возвращается Search для пустого или слишком длинного запроса
+var ErrInvalidSearchQuery = errors.New("invalid search query")
+
+const (
+	// MaxSearchQueryLength — предельная длина поисковой строки в символах
+	MaxSearchQueryLength = 200
+	// MaxSearchTerms — сколько слов запроса учитывается в поиске
+	MaxSearchTerms = 10
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+const (
+	// StockReasonUnspecified пишется в журнал, если вызывающий не передал причину
+	StockReasonUnspecified = "unspecified"
+
+	defaultMovementsLimit = 50
+	maxMovementsLimit     = 200
 )
 
 type ProductService interface {
 	GetByID(ctx context.Context, id int64) (*dto.ProductResponse, error)
+	// GetByIDs возвращает продукты в порядке запроса без повторов и ID, которых нет в каталоге
+	GetByIDs(ctx context.Context, ids []int64) ([]*dto.ProductResponse, []int64, error)
 	GetAll(ctx context.Context) ([]*dto.ProductResponse, error)
+	// List отдает страницу каталога с фильтрами, сортировкой и общим числом продуктов
+	List(ctx context.Context, req *dto.ListProductsRequest) (*dto.ProductListResponse, error)
+	// Search ищет продукты по словам запроса (с учетом префиксов) и ранжирует по релевантности
+	Search(ctx context.Context, req *dto.SearchProductsRequest) (*dto.ProductSearchResponse, error)
 	Create(ctx context.Context, req *dto.CreateProductRequest) (*dto.ProductResponse, error)
 	Update(ctx context.Context, id int64, req *dto.UpdateProductRequest) (*dto.ProductResponse, error)
+	// Delete переводит продукт в архив: он скрыт из каталога, но находится по ID для истории заказов
 	Delete(ctx context.Context, id int64) error
+	// Restore возвращает архивный продукт в каталог
+	Restore(ctx context.Context, id int64) (*dto.ProductResponse, error)
+	// Purge безвозвратно удаляет архивный продукт и файлы его изображений
+	Purge(ctx context.Context, id int64) error
+	// AdjustStock атомарно меняет остаток и записывает изменение в журнал
+	AdjustStock(ctx context.Context, req *dto.AdjustStockRequest) (*dto.StockMovementResponse, error)
+	// AdjustStockBatch применяет все изменения в одной транзакции; записи журнала в порядке запроса
+	AdjustStockBatch(ctx context.Context, req *dto.BatchAdjustStockRequest) ([]*dto.StockMovementResponse, error)
+	ListStockMovements(ctx context.Context, productID int64, limit, offset int) ([]*dto.StockMovementResponse, error)
+	// SetCategories заменяет категории продукта и возвращает его с новыми хлебными крошками
+	SetCategories(ctx context.Context, productID int64, categoryIDs []int64) (*dto.ProductResponse, error)
+	// CreateVariant добавляет вариант и возвращает продукт с пересчитанным остатком
+	CreateVariant(ctx context.Context, productID int64, req *dto.VariantRequest) (*dto.ProductResponse, error)
+	// UpdateVariant заменяет SKU, атрибуты, цену и остаток варианта
+	UpdateVariant(ctx context.Context, productID, variantID int64, req *dto.VariantRequest) (*dto.ProductResponse, error)
+	// DeleteVariant удаляет вариант, кроме последнего, и списывает его остаток
+	DeleteVariant(ctx context.Context, productID, variantID int64) error
+	// UploadImage проверяет файл, сохраняет оригинал с миниатюрами и добавляет его в конец галереи
+	UploadImage(ctx context.Context, productID int64, r io.Reader, altText string) (*dto.ProductResponse, error)
+	// DeleteImage удаляет изображение из галереи и его файлы из хранилища
+	DeleteImage(ctx context.Context, productID, imageID int64) error
+	// ReorderImages задает новый порядок галереи; imageIDs должен содержать все изображения продукта
+	ReorderImages(ctx context.Context, productID int64, imageIDs []int64) (*dto.ProductResponse, error)
+	// PriceHistory отдает историю цены продукта, новые записи первыми
+	PriceHistory(ctx context.Context, productID int64, limit, offset int) ([]*dto.PriceChangeResponse, error)
+	// PriceSchedules отдает запланированные цены продукта, включая завершенные
+	PriceSchedules(ctx context.Context, productID int64) ([]*dto.PriceScheduleResponse, error)
+	// SchedulePrice планирует цену на будущее; с ends_at по окончании вернется прежняя цена
+	SchedulePrice(ctx context.Context, productID int64, req *dto.SchedulePriceRequest) (*dto.PriceScheduleResponse, error)
+	// CancelPriceSchedule отменяет запланированную цену или досрочно завершает распродажу
+	CancelPriceSchedule(ctx context.Context, productID, scheduleID int64) error
+	// ApplyDuePriceSchedules применяет запланированные цены, время которых наступило; вызывается фоновым обработчиком
+	ApplyDuePriceSchedules(ctx context.Context, now time.Time, limit int) (int, error)
 }
 
 type productService struct {
-	repo repository.ProductRepository
+	repo  repository.ProductRepository
+	media storage.Storage
 }
 
-func NewProductService(repo repository.ProductRepository) ProductService {
-	return &productService{repo: repo}
+// NewProductService создает сервис; media хранит файлы изображений продуктов
+func NewProductService(repo repository.ProductRepository, media storage.Storage) ProductService {
+	return &productService{repo: repo, media: media}
 }
 
 func (s *productService) GetByID(ctx context.Context, id int64) (*dto.ProductResponse, error) {
@@ -33,21 +140,290 @@ func (s *productService) GetByID(ctx context.Context, id int64) (*dto.ProductRes
 	if err != nil {
 		return nil, err
 	}
+	if err := s.attachDetails(ctx, product); err != nil {
+		return nil, err
+	}
 
 	return dto.ToProductResponse(product), nil
 }
 
+func (s *productService) GetByIDs(ctx context.Context, ids []int64) ([]*dto.ProductResponse, []int64, error) {
+	unique := make([]int64, 0, len(ids))
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) > MaxBatchSize {
+		return nil, nil, fmt.Errorf("%w: %d, max %d", ErrBatchTooLarge, len(unique), MaxBatchSize)
+	}
+
+	// Несуществующие ID (в том числе неположительные) попадут в missing
+	valid := make([]int64, 0, len(unique))
+	for _, id := range unique {
+		if id > 0 {
+			valid = append(valid, id)
+		}
+	}
+
+	var products []*model.Product
+	if len(valid) > 0 {
+		var err error
+		if products, err = s.repo.FindByIDs(ctx, valid); err != nil {
+			return nil, nil, err
+		}
+		if err = s.attachDetails(ctx, products...); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	byID := make(map[int64]*dto.ProductResponse, len(products))
+	for _, p := range products {
+		byID[p.ID] = dto.ToProductResponse(p)
+	}
+
+	result := make([]*dto.ProductResponse, 0, len(products))
+	var missing []int64
+	for _, id := range unique {
+		if p, ok := byID[id]; ok {
+			result = append(result, p)
+		} else {
+			missing = append(missing, id)
+		}
+	}
+	return result, missing, nil
+}
+
 func (s *productService) GetAll(ctx context.Context) ([]*dto.ProductResponse, error) {
 	products, err := s.repo.FindAll(ctx)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.attachDetails(ctx, products...); err != nil {
+		return nil, err
+	}
 
 	return dto.ToProductResponseList(products), nil
 }
 
+// listCursor — курсор страницы; сортировка в нем не дает продолжить список с другим порядком
+type listCursor struct {
+	SortBy     string `json:"sb"`
+	Descending bool   `json:"d,omitempty"`
+	repository.ProductCursor
+}
+
+func (s *productService) List(ctx context.Context, req *dto.ListProductsRequest) (*dto.ProductListResponse, error) {
+	filter, err := listFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	page := max(req.Page, 1)
+	if filter.After == nil {
+		filter.Offset = (page - 1) * filter.Limit
+	}
+	pageSize := filter.Limit
+	// Лишняя строка показывает, есть ли следующая страница
+	filter.Limit++
+
+	products, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	total, err := s.repo.Count(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &dto.ProductListResponse{
+		TotalCount: total,
+		Page:       page,
+		PageSize:   pageSize,
+	}
+	if len(products) > pageSize {
+		products = products[:pageSize]
+		resp.NextCursor = encodeListCursor(listCursor{
+			SortBy:        filter.SortBy,
+			Descending:    filter.Descending,
+			ProductCursor: repository.NewProductCursor(products[len(products)-1]),
+		})
+	}
+	if err := s.attachDetails(ctx, products...); err != nil {
+		return nil, err
+	}
+	resp.Products = dto.ToProductResponseList(products)
+	return resp, nil
+}
+
+func (s *productService) Search(ctx context.Context, req *dto.SearchProductsRequest) (*dto.ProductSearchResponse, error) {
+	if utf8.RuneCountInString(req.Query) > MaxSearchQueryLength {
+		return nil, fmt.Errorf("%w: query must be at most %d characters", ErrInvalidSearchQuery, MaxSearchQueryLength)
+	}
+	terms := searchTerms(req.Query)
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("%w: query must contain letters or digits", ErrInvalidSearchQuery)
+	}
+	if req.Page < 0 || req.PageSize < 0 || req.PageSize > MaxPageSize {
+		return nil, fmt.Errorf("%w: page must be positive, page_size at most %d", ErrInvalidSearchQuery, MaxPageSize)
+	}
+
+	page := max(req.Page, 1)
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	}
+	search := repository.ProductSearch{
+		Terms:  terms,
+		Limit:  pageSize,
+		Offset: (page - 1) * pageSize,
+	}
+
+	hits, err := s.repo.Search(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+	products := make([]*model.Product, len(hits))
+	for i, hit := range hits {
+		products[i] = &hit.Product
+	}
+	if err := s.attachDetails(ctx, products...); err != nil {
+		return nil, err
+	}
+	total, err := s.repo.CountSearch(ctx, search)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*dto.ProductSearchResult, 0, len(hits))
+	for _, hit := range hits {
+		results = append(results, dto.ToProductSearchResult(hit))
+	}
+	return &dto.ProductSearchResponse{
+		Results:    results,
+		TotalCount: total,
+		Page:       page,
+		PageSize:   pageSize,
+	}, nil
+}
+
+// searchTerms разбивает запрос на слова из букв и цифр в нижнем регистре без повторов.
+// Остальные символы — разделители, поэтому синтаксис tsquery из запроса не попадает в БД
+func searchTerms(query string) []string {
+	var terms []string
+	seen := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if seen[word] {
+			continue
+		}
+		seen[word] = true
+		terms = append(terms, word)
+		if len(terms) == MaxSearchTerms {
+			break
+		}
+	}
+	return terms
+}
+
+func listFilter(req *dto.ListProductsRequest) (repository.ProductFilter, error) {
+	filter := repository.ProductFilter{
+		MinPriceMinor: req.MinPriceMinor,
+		MaxPriceMinor: req.MaxPriceMinor,
+		InStock:       req.InStock,
+		UpdatedSince:  req.UpdatedSince,
+		CategoryID:    req.CategoryID,
+		Status:        req.Status,
+		SortBy:        req.SortBy,
+		Limit:         req.PageSize,
+	}
+	if req.CategoryID < 0 {
+		return filter, fmt.Errorf("%w: category_id must be positive", ErrInvalidListRequest)
+	}
+	switch req.Status {
+	case "", model.ProductStatusDraft, model.ProductStatusActive, model.ProductStatusArchived:
+	default:
+		return filter, fmt.Errorf("%w: status must be one of draft, active, archived", ErrInvalidListRequest)
+	}
+
+	switch req.SortBy {
+	case "":
+		filter.SortBy = repository.ProductSortCreatedAt
+	case repository.ProductSortCreatedAt, repository.ProductSortPrice, repository.ProductSortName, repository.ProductSortStock:
+	default:
+		return filter, fmt.Errorf("%w: sort must be one of created_at, price, name, stock", ErrInvalidListRequest)
+	}
+	switch req.SortOrder {
+	case "":
+		// Новые продукты первыми, как раньше; остальные поля — по возрастанию
+		filter.Descending = filter.SortBy == repository.ProductSortCreatedAt
+	case "asc":
+	case "desc":
+		filter.Descending = true
+	default:
+		return filter, fmt.Errorf("%w: order must be asc or desc", ErrInvalidListRequest)
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = DefaultPageSize
+	}
+	if filter.Limit > MaxPageSize {
+		return filter, fmt.Errorf("%w: page_size must not exceed %d", ErrInvalidListRequest, MaxPageSize)
+	}
+	if req.Page < 0 {
+		return filter, fmt.Errorf("%w: page must be positive", ErrInvalidListRequest)
+	}
+	if (req.MinPriceMinor != nil && *req.MinPriceMinor < 0) || (req.MaxPriceMinor != nil && *req.MaxPriceMinor < 0) {
+		return filter, fmt.Errorf("%w: price must not be negative", ErrInvalidListRequest)
+	}
+	if req.MinPriceMinor != nil && req.MaxPriceMinor != nil && *req.MinPriceMinor > *req.MaxPriceMinor {
+		return filter, fmt.Errorf("%w: min_price must not exceed max_price", ErrInvalidListRequest)
+	}
+	// Цены в разных валютах несравнимы: фильтр и сортировка по цене работают внутри одной валюты
+	if req.Currency != "" {
+		filter.Currency = strings.ToUpper(strings.TrimSpace(req.Currency))
+		if !money.ValidCurrency(filter.Currency) {
+			return filter, fmt.Errorf("%w: currency must be an ISO 4217 code", ErrInvalidListRequest)
+		}
+	} else if req.MinPriceMinor != nil || req.MaxPriceMinor != nil || filter.SortBy == repository.ProductSortPrice {
+		return filter, fmt.Errorf("%w: currency is required to filter or sort by price", ErrInvalidListRequest)
+	}
+
+	if req.Cursor != "" {
+		c, err := decodeListCursor(req.Cursor)
+		if err != nil || c.SortBy != filter.SortBy || c.Descending != filter.Descending {
+			return filter, fmt.Errorf("%w: cursor is malformed or belongs to another sort", ErrInvalidListRequest)
+		}
+		filter.After = &c.ProductCursor
+	}
+	return filter, nil
+}
+
+func encodeListCursor(c listCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeListCursor(s string) (listCursor, error) {
+	var c listCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, err
+	}
+	err = json.Unmarshal(data, &c)
+	return c, err
+}
+
 func (s *productService) Create(ctx context.Context, req *dto.CreateProductRequest) (*dto.ProductResponse, error) {
 	product := req.ToProduct()
+	for _, v := range product.Variants {
+		if err := validateVariant(v, false); err != nil {
+			return nil, err
+		}
+	}
 
 	if err := s.repo.Create(ctx, product); err != nil {
 		return nil, err
@@ -67,14 +443,299 @@ func (s *productService) Update(ctx context.Context, id int64, req *dto.UpdatePr
 	if err := s.repo.Update(ctx, product); err != nil {
 		return nil, err
 	}
+	if err := s.attachDetails(ctx, product); err != nil {
+		return nil, err
+	}
 
 	return dto.ToProductResponse(product), nil
 }
 
 func (s *productService) Delete(ctx context.Context, id int64) error {
 	if id <= 0 {
-		return fmt.Errorf("invalid product id: %d", id)
+		return fmt.Errorf("%w: id %d", repository.ErrProductNotFound, id)
 	}
 
-	return s.repo.Delete(ctx, id)
+	return s.repo.Archive(ctx, id)
+}
+
+func (s *productService) Restore(ctx context.Context, id int64) (*dto.ProductResponse, error) {
+	if id <= 0 {
+		return nil, fmt.Errorf("%w: id %d", repository.ErrProductNotFound, id)
+	}
+
+	if err := s.repo.Restore(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return s.GetByID(ctx, id)
+}
+
+func (s *productService) Purge(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return fmt.Errorf("%w: id %d", repository.ErrProductNotFound, id)
+	}
+
+	// Файлы удаляются после строки в БД: иначе при ошибке удаления продукт остался бы без изображений
+	images, err := s.repo.Purge(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.removeImageFiles(ctx, images...)
+}
+
+func (s *productService) AdjustStock(ctx context.Context, req *dto.AdjustStockRequest) (*dto.StockMovementResponse, error) {
+	if req.ProductID <= 0 {
+		return nil, fmt.Errorf("invalid product id: %d", req.ProductID)
+	}
+	if req.Delta == 0 {
+		return nil, fmt.Errorf("%w: delta must not be zero", ErrInvalidStockChange)
+	}
+	if err := validateStockMeta(req.Reason, req.Reference, req.Actor); err != nil {
+		return nil, err
+	}
+
+	if req.VariantID < 0 {
+		return nil, fmt.Errorf("%w: invalid variant id %d", ErrInvalidStockChange, req.VariantID)
+	}
+
+	movement := &model.StockMovement{
+		ProductID: req.ProductID,
+		VariantID: req.VariantID,
+		Delta:     req.Delta,
+		Reason:    req.Reason,
+		Reference: req.Reference,
+		Actor:     req.Actor,
+	}
+	if movement.Reason == "" {
+		movement.Reason = StockReasonUnspecified
+	}
+
+	if err := s.repo.AdjustStock(ctx, movement); err != nil {
+		return nil, err
+	}
+
+	return dto.ToStockMovementResponse(movement), nil
+}
+
+func (s *productService) ListStockMovements(ctx context.Context, productID int64, limit, offset int) ([]*dto.StockMovementResponse, error) {
+	if productID <= 0 {
+		return nil, fmt.Errorf("invalid product id: %d", productID)
+	}
+	if limit <= 0 {
+		limit = defaultMovementsLimit
+	}
+	limit = min(limit, maxMovementsLimit)
+	offset = max(offset, 0)
+
+	// Пустой журнал и несуществующий продукт должны различаться
+	if _, err := s.repo.FindByID(ctx, productID); err != nil {
+		return nil, err
+	}
+
+	movements, err := s.repo.ListStockMovements(ctx, productID, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*dto.StockMovementResponse, 0, len(movements))
+	for _, m := range movements {
+		result = append(result, dto.ToStockMovementResponse(m))
+	}
+	return result, nil
+}
+
+func (s *productService) AdjustStockBatch(ctx context.Context, req *dto.BatchAdjustStockRequest) ([]*dto.StockMovementResponse, error) {
+	if len(req.Items) == 0 {
+		return nil, fmt.Errorf("%w: no items", ErrInvalidStockChange)
+	}
+	if len(req.Items) > MaxBatchSize {
+		return nil, fmt.Errorf("%w: %d, max %d", ErrBatchTooLarge, len(req.Items), MaxBatchSize)
+	}
+	if err := validateStockMeta(req.Reason, req.Reference, req.Actor); err != nil {
+		return nil, err
+	}
+	reason := req.Reason
+	if reason == "" {
+		reason = StockReasonUnspecified
+	}
+
+	// Продукт без варианта — это его единственный вариант, поэтому вместе с вариантами он повториться не может
+	type stockKey struct{ productID, variantID int64 }
+	movements := make([]*model.StockMovement, len(req.Items))
+	seen := make(map[stockKey]bool, len(req.Items))
+	products := make(map[int64]bool, len(req.Items))
+	for i, item := range req.Items {
+		if item.ProductID <= 0 {
+			return nil, fmt.Errorf("%w: invalid product id %d", ErrInvalidStockChange, item.ProductID)
+		}
+		if item.VariantID < 0 {
+			return nil, fmt.Errorf("%w: invalid variant id %d", ErrInvalidStockChange, item.VariantID)
+		}
+		if item.Delta == 0 {
+			return nil, fmt.Errorf("%w: delta for product %d must not be zero", ErrInvalidStockChange, item.ProductID)
+		}
+		key := stockKey{item.ProductID, item.VariantID}
+		if seen[key] || seen[stockKey{item.ProductID, 0}] || (item.VariantID == 0 && products[item.ProductID]) {
+			return nil, fmt.Errorf("%w: product %d variant %d is listed twice", ErrInvalidStockChange, item.ProductID, item.VariantID)
+		}
+		seen[key] = true
+		products[item.ProductID] = true
+
+		movements[i] = &model.StockMovement{
+			ProductID: item.ProductID,
+			VariantID: item.VariantID,
+			Delta:     item.Delta,
+			Reason:    reason,
+			Reference: req.Reference,
+			Actor:     req.Actor,
+		}
+	}
+
+	if err := s.repo.AdjustStockBatch(ctx, movements); err != nil {
+		return nil, err
+	}
+
+	result := make([]*dto.StockMovementResponse, len(movements))
+	for i, m := range movements {
+		result[i] = dto.ToStockMovementResponse(m)
+	}
+	return result, nil
+}
+
+func (s *productService) SetCategories(ctx context.Context, productID int64, categoryIDs []int64) (*dto.ProductResponse, error) {
+	if productID <= 0 {
+		return nil, fmt.Errorf("invalid product id: %d", productID)
+	}
+
+	unique := make([]int64, 0, len(categoryIDs))
+	for _, id := range categoryIDs {
+		if id <= 0 {
+			return nil, fmt.Errorf("%w: %d", ErrInvalidCategories, id)
+		}
+		if !slices.Contains(unique, id) {
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) > MaxProductCategories {
+		return nil, fmt.Errorf("%w: at most %d categories per product", ErrInvalidCategories, MaxProductCategories)
+	}
+
+	if err := s.repo.SetCategories(ctx, productID, unique); err != nil {
+		return nil, err
+	}
+
+	return s.GetByID(ctx, productID)
+}
+
+// attachDetails заполняет хлебные крошки, варианты, изображения и цену до распродажи продуктов четырьмя запросами
+func (s *productService) attachDetails(ctx context.Context, products ...*model.Product) error {
+	if len(products) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(products))
+	for i, p := range products {
+		ids[i] = p.ID
+	}
+	paths, err := s.repo.CategoryPaths(ctx, ids)
+	if err != nil {
+		return err
+	}
+	variants, err := s.repo.Variants(ctx, ids)
+	if err != nil {
+		return err
+	}
+	images, err := s.repo.Images(ctx, ids)
+	if err != nil {
+		return err
+	}
+	sales, err := s.repo.ActivePriceSchedules(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, p := range products {
+		p.CompareAtPriceMinor = compareAtPrice(p, sales[p.ID])
+		p.Categories = paths[p.ID]
+		p.Variants = variants[p.ID]
+		p.Images = images[p.ID]
+		for _, img := range p.Images {
+			s.setImageURLs(img)
+		}
+	}
+	return nil
+}
+
+func (s *productService) CreateVariant(ctx context.Context, productID int64, req *dto.VariantRequest) (*dto.ProductResponse, error) {
+	if productID <= 0 {
+		return nil, fmt.Errorf("%w: id %d", repository.ErrProductNotFound, productID)
+	}
+
+	variant := req.ToVariant(productID)
+	if err := validateVariant(variant, true); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateVariant(ctx, variant); err != nil {
+		return nil, err
+	}
+
+	return s.GetByID(ctx, productID)
+}
+
+func (s *productService) UpdateVariant(ctx context.Context, productID, variantID int64, req *dto.VariantRequest) (*dto.ProductResponse, error) {
+	if productID <= 0 || variantID <= 0 {
+		return nil, fmt.Errorf("%w: variant %d of product %d", repository.ErrVariantNotFound, variantID, productID)
+	}
+
+	variant := req.ToVariant(productID)
+	variant.ID = variantID
+	if err := validateVariant(variant, true); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateVariant(ctx, variant); err != nil {
+		return nil, err
+	}
+
+	return s.GetByID(ctx, productID)
+}
+
+func (s *productService) DeleteVariant(ctx context.Context, productID, variantID int64) error {
+	if productID <= 0 || variantID <= 0 {
+		return fmt.Errorf("%w: variant %d of product %d", repository.ErrVariantNotFound, variantID, productID)
+	}
+
+	return s.repo.DeleteVariant(ctx, productID, variantID)
+}
+
+// validateVariant проверяет артикул, цену, остаток и атрибуты. Пустой SKU допустим
+// только у варианта по умолчанию, которому репозиторий сам присвоит артикул
+func validateVariant(v *model.ProductVariant, skuRequired bool) error {
+	v.SKU = strings.TrimSpace(v.SKU)
+	if (skuRequired && v.SKU == "") || utf8.RuneCountInString(v.SKU) > MaxSKULength {
+		return fmt.Errorf("%w: sku must be 1 to %d characters", ErrInvalidVariant, MaxSKULength)
+	}
+	if v.PriceMinor != nil && *v.PriceMinor < 0 {
+		return fmt.Errorf("%w: price_minor must not be negative", ErrInvalidVariant)
+	}
+	if v.Stock < 0 {
+		return fmt.Errorf("%w: stock must not be negative", ErrInvalidVariant)
+	}
+	if len(v.Attributes) > MaxVariantAttributes {
+		return fmt.Errorf("%w: at most %d attributes", ErrInvalidVariant, MaxVariantAttributes)
+	}
+	for name, value := range v.Attributes {
+		if strings.TrimSpace(name) == "" || utf8.RuneCountInString(name) > MaxVariantAttributeLength ||
+			utf8.RuneCountInString(value) > MaxVariantAttributeLength {
+			return fmt.Errorf("%w: attribute names must be non-empty, names and values at most %d characters", ErrInvalidVariant, MaxVariantAttributeLength)
+		}
+	}
+	return nil
+}
+
+func validateStockMeta(reason, reference, actor string) error {
+	if len(reason) > 50 || len(reference) > 100 || len(actor) > 100 {
+		return fmt.Errorf("%w: reason, reference or actor is too long", ErrInvalidStockChange)
+	}
+	return nil
 }
@@ -3,18 +3,49 @@ package service
 import (
 	"context"
 	"errors"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/microserviceteam0/bff-gateway/product-service/internal/dto"
 	"github.com/microserviceteam0/bff-gateway/product-service/internal/model"
+	"github.com/microserviceteam0/bff-gateway/product-service/internal/repository"
 )
 
 type mockProductRepository struct {
-	findByIDFunc func(ctx context.Context, id int64) (*model.Product, error)
-	findAllFunc  func(ctx context.Context) ([]*model.Product, error)
-	createFunc   func(ctx context.Context, product *model.Product) error
-	updateFunc   func(ctx context.Context, product *model.Product) error
-	deleteFunc   func(ctx context.Context, id int64) error
+	findByIDFunc  func(ctx context.Context, id int64) (*model.Product, error)
+	findByIDsFunc func(ctx context.Context, ids []int64) ([]*model.Product, error)
+	findAllFunc   func(ctx context.Context) ([]*model.Product, error)
+	createFunc    func(ctx context.Context, product *model.Product) error
+	updateFunc    func(ctx context.Context, product *model.Product) error
+	archiveFunc   func(ctx context.Context, id int64) error
+	restoreFunc   func(ctx context.Context, id int64) error
+	purgeFunc     func(ctx context.Context, id int64) ([]*model.ProductImage, error)
+
+	listFunc               func(ctx context.Context, filter repository.ProductFilter) ([]*model.Product, error)
+	countFunc              func(ctx context.Context, filter repository.ProductFilter) (int64, error)
+	searchFunc             func(ctx context.Context, search repository.ProductSearch) ([]*model.ProductSearchHit, error)
+	countSearchFunc        func(ctx context.Context, search repository.ProductSearch) (int64, error)
+	categoryPathsFunc      func(ctx context.Context, productIDs []int64) (map[int64][]model.CategoryPath, error)
+	setCategoriesFunc      func(ctx context.Context, productID int64, categoryIDs []int64) error
+	adjustStockFunc        func(ctx context.Context, movement *model.StockMovement) error
+	adjustStockBatchFunc   func(ctx context.Context, movements []*model.StockMovement) error
+	listStockMovementsFunc func(ctx context.Context, productID int64, limit, offset int) ([]*model.StockMovement, error)
+	variantsFunc           func(ctx context.Context, productIDs []int64) (map[int64][]*model.ProductVariant, error)
+	createVariantFunc      func(ctx context.Context, variant *model.ProductVariant) error
+	updateVariantFunc      func(ctx context.Context, variant *model.ProductVariant) error
+	deleteVariantFunc      func(ctx context.Context, productID, variantID int64) error
+	imagesFunc             func(ctx context.Context, productIDs []int64) (map[int64][]*model.ProductImage, error)
+	createImageFunc        func(ctx context.Context, image *model.ProductImage, maxImages int) error
+	deleteImageFunc        func(ctx context.Context, productID, imageID int64) (*model.ProductImage, error)
+	reorderImagesFunc      func(ctx context.Context, productID int64, imageIDs []int64) error
+	priceHistoryFunc       func(ctx context.Context, productID int64, limit, offset int) ([]*model.PriceChange, error)
+	priceSchedulesFunc     func(ctx context.Context, productID int64) ([]*model.PriceSchedule, error)
+	activeSchedulesFunc    func(ctx context.Context, productIDs []int64) (map[int64]*model.PriceSchedule, error)
+	createScheduleFunc     func(ctx context.Context, schedule *model.PriceSchedule) error
+	cancelScheduleFunc     func(ctx context.Context, productID, scheduleID int64, actor string) error
+	applySchedulesFunc     func(ctx context.Context, now time.Time, limit int) (int, error)
 }
 
 func (m *mockProductRepository) FindByID(ctx context.Context, id int64) (*model.Product, error) {
@@ -24,6 +55,13 @@ func (m *mockProductRepository) FindByID(ctx context.Context, id int64) (*model.
 	return nil, errors.New("not implemented")
 }
 
+func (m *mockProductRepository) FindByIDs(ctx context.Context, ids []int64) ([]*model.Product, error) {
+	if m.findByIDsFunc != nil {
+		return m.findByIDsFunc(ctx, ids)
+	}
+	return nil, errors.New("not implemented")
+}
+
 func (m *mockProductRepository) FindAll(ctx context.Context) ([]*model.Product, error) {
 	if m.findAllFunc != nil {
 		return m.findAllFunc(ctx)
@@ -45,13 +83,190 @@ func (m *mockProductRepository) Update(ctx context.Context, product *model.Produ
 	return errors.New("not implemented")
 }
 
-func (m *mockProductRepository) Delete(ctx context.Context, id int64) error {
-	if m.deleteFunc != nil {
-		return m.deleteFunc(ctx, id)
+func (m *mockProductRepository) Archive(ctx context.Context, id int64) error {
+	if m.archiveFunc != nil {
+		return m.archiveFunc(ctx, id)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockProductRepository) Restore(ctx context.Context, id int64) error {
+	if m.restoreFunc != nil {
+		return m.restoreFunc(ctx, id)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockProductRepository) Purge(ctx context.Context, id int64) ([]*model.ProductImage, error) {
+	if m.purgeFunc != nil {
+		return m.purgeFunc(ctx, id)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockProductRepository) List(ctx context.Context, filter repository.ProductFilter) ([]*model.Product, error) {
+	if m.listFunc != nil {
+		return m.listFunc(ctx, filter)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockProductRepository) Count(ctx context.Context, filter repository.ProductFilter) (int64, error) {
+	if m.countFunc != nil {
+		return m.countFunc(ctx, filter)
+	}
+	return 0, errors.New("not implemented")
+}
+
+func (m *mockProductRepository) Search(ctx context.Context, search repository.ProductSearch) ([]*model.ProductSearchHit, error) {
+	if m.searchFunc != nil {
+		return m.searchFunc(ctx, search)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockProductRepository) CountSearch(ctx context.Context, search repository.ProductSearch) (int64, error) {
+	if m.countSearchFunc != nil {
+		return m.countSearchFunc(ctx, search)
+	}
+	return 0, errors.New("not implemented")
+}
+
+// CategoryPaths по умолчанию отвечает, что категорий у продуктов нет
+func (m *mockProductRepository) CategoryPaths(ctx context.Context, productIDs []int64) (map[int64][]model.CategoryPath, error) {
+	if m.categoryPathsFunc != nil {
+		return m.categoryPathsFunc(ctx, productIDs)
+	}
+	return nil, nil
+}
+
+func (m *mockProductRepository) SetCategories(ctx context.Context, productID int64, categoryIDs []int64) error {
+	if m.setCategoriesFunc != nil {
+		return m.setCategoriesFunc(ctx, productID, categoryIDs)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockProductRepository) AdjustStock(ctx context.Context, movement *model.StockMovement) error {
+	if m.adjustStockFunc != nil {
+		return m.adjustStockFunc(ctx, movement)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockProductRepository) AdjustStockBatch(ctx context.Context, movements []*model.StockMovement) error {
+	if m.adjustStockBatchFunc != nil {
+		return m.adjustStockBatchFunc(ctx, movements)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockProductRepository) ListStockMovements(ctx context.Context, productID int64, limit, offset int) ([]*model.StockMovement, error) {
+	if m.listStockMovementsFunc != nil {
+		return m.listStockMovementsFunc(ctx, productID, limit, offset)
+	}
+	return nil, errors.New("not implemented")
+}
+
+// Variants по умолчанию отвечает, что вариантов у продуктов нет
+func (m *mockProductRepository) Variants(ctx context.Context, productIDs []int64) (map[int64][]*model.ProductVariant, error) {
+	if m.variantsFunc != nil {
+		return m.variantsFunc(ctx, productIDs)
+	}
+	return nil, nil
+}
+
+func (m *mockProductRepository) CreateVariant(ctx context.Context, variant *model.ProductVariant) error {
+	if m.createVariantFunc != nil {
+		return m.createVariantFunc(ctx, variant)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockProductRepository) UpdateVariant(ctx context.Context, variant *model.ProductVariant) error {
+	if m.updateVariantFunc != nil {
+		return m.updateVariantFunc(ctx, variant)
 	}
 	return errors.New("not implemented")
 }
 
+func (m *mockProductRepository) DeleteVariant(ctx context.Context, productID, variantID int64) error {
+	if m.deleteVariantFunc != nil {
+		return m.deleteVariantFunc(ctx, productID, variantID)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockProductRepository) Images(ctx context.Context, productIDs []int64) (map[int64][]*model.ProductImage, error) {
+	if m.imagesFunc != nil {
+		return m.imagesFunc(ctx, productIDs)
+	}
+	return nil, nil
+}
+
+func (m *mockProductRepository) CreateImage(ctx context.Context, image *model.ProductImage, maxImages int) error {
+	if m.createImageFunc != nil {
+		return m.createImageFunc(ctx, image, maxImages)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockProductRepository) DeleteImage(ctx context.Context, productID, imageID int64) (*model.ProductImage, error) {
+	if m.deleteImageFunc != nil {
+		return m.deleteImageFunc(ctx, productID, imageID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockProductRepository) ReorderImages(ctx context.Context, productID int64, imageIDs []int64) error {
+	if m.reorderImagesFunc != nil {
+		return m.reorderImagesFunc(ctx, productID, imageIDs)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockProductRepository) PriceHistory(ctx context.Context, productID int64, limit, offset int) ([]*model.PriceChange, error) {
+	if m.priceHistoryFunc != nil {
+		return m.priceHistoryFunc(ctx, productID, limit, offset)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockProductRepository) PriceSchedules(ctx context.Context, productID int64) ([]*model.PriceSchedule, error) {
+	if m.priceSchedulesFunc != nil {
+		return m.priceSchedulesFunc(ctx, productID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockProductRepository) ActivePriceSchedules(ctx context.Context, productIDs []int64) (map[int64]*model.PriceSchedule, error) {
+	if m.activeSchedulesFunc != nil {
+		return m.activeSchedulesFunc(ctx, productIDs)
+	}
+	return nil, nil
+}
+
+func (m *mockProductRepository) CreatePriceSchedule(ctx context.Context, schedule *model.PriceSchedule) error {
+	if m.createScheduleFunc != nil {
+		return m.createScheduleFunc(ctx, schedule)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockProductRepository) CancelPriceSchedule(ctx context.Context, productID, scheduleID int64, actor string) error {
+	if m.cancelScheduleFunc != nil {
+		return m.cancelScheduleFunc(ctx, productID, scheduleID, actor)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockProductRepository) ApplyDuePriceSchedules(ctx context.Context, now time.Time, limit int) (int, error) {
+	if m.applySchedulesFunc != nil {
+		return m.applySchedulesFunc(ctx, now, limit)
+	}
+	return 0, errors.New("not implemented")
+}
+
 func TestProductService_GetByID(t *testing.T) {
 	ctx := context.Background()
 
@@ -68,7 +283,7 @@ func TestProductService_GetByID(t *testing.T) {
 			},
 		}
 
-		service := NewProductService(mockRepo)
+		service := NewProductService(mockRepo, nil)
 		product, err := service.GetByID(ctx, 1)
 
 		if err != nil {
@@ -84,7 +299,7 @@ func TestProductService_GetByID(t *testing.T) {
 
 	t.Run("InvalidID", func(t *testing.T) {
 		mockRepo := &mockProductRepository{}
-		service := NewProductService(mockRepo)
+		service := NewProductService(mockRepo, nil)
 
 		_, err := service.GetByID(ctx, 0)
 		if err == nil {
@@ -104,7 +319,7 @@ func TestProductService_GetByID(t *testing.T) {
 			},
 		}
 
-		service := NewProductService(mockRepo)
+		service := NewProductService(mockRepo, nil)
 		_, err := service.GetByID(ctx, 999)
 
 		if err == nil {
@@ -126,7 +341,7 @@ func TestProductService_GetAll(t *testing.T) {
 			},
 		}
 
-		service := NewProductService(mockRepo)
+		service := NewProductService(mockRepo, nil)
 		products, err := service.GetAll(ctx)
 
 		if err != nil {
@@ -144,7 +359,7 @@ func TestProductService_GetAll(t *testing.T) {
 			},
 		}
 
-		service := NewProductService(mockRepo)
+		service := NewProductService(mockRepo, nil)
 		products, err := service.GetAll(ctx)
 
 		if err != nil {
@@ -156,6 +371,264 @@ func TestProductService_GetAll(t *testing.T) {
 	})
 }
 
+func TestProductService_GetByIDs(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("RequestOrderAndMissing", func(t *testing.T) {
+		var queried []int64
+		mockRepo := &mockProductRepository{
+			findByIDsFunc: func(ctx context.Context, ids []int64) ([]*model.Product, error) {
+				queried = ids
+				// БД отдает строки в произвольном порядке
+				return []*model.Product{{ID: 1, Name: "Product 1"}, {ID: 3, Name: "Product 3"}}, nil
+			},
+		}
+
+		service := NewProductService(mockRepo, nil)
+		products, missing, err := service.GetByIDs(ctx, []int64{3, 7, 1, 3, 0})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !reflect.DeepEqual(queried, []int64{3, 7, 1}) {
+			t.Errorf("Expected repository to be queried with [3 7 1], got %v", queried)
+		}
+		if len(products) != 2 || products[0].ID != 3 || products[1].ID != 1 {
+			t.Errorf("Expected products [3 1] in request order, got %+v", products)
+		}
+		if !reflect.DeepEqual(missing, []int64{7, 0}) {
+			t.Errorf("Expected missing [7 0], got %v", missing)
+		}
+	})
+
+	t.Run("Empty", func(t *testing.T) {
+		service := NewProductService(&mockProductRepository{}, nil)
+		products, missing, err := service.GetByIDs(ctx, nil)
+
+		if err != nil || len(products) != 0 || len(missing) != 0 {
+			t.Errorf("Expected empty result without repository call, got %v, %v, %v", products, missing, err)
+		}
+	})
+
+	t.Run("BatchTooLarge", func(t *testing.T) {
+		ids := make([]int64, MaxBatchSize+1)
+		for i := range ids {
+			ids[i] = int64(i + 1)
+		}
+
+		service := NewProductService(&mockProductRepository{}, nil)
+		_, _, err := service.GetByIDs(ctx, ids)
+
+		if !errors.Is(err, ErrBatchTooLarge) {
+			t.Errorf("Expected ErrBatchTooLarge, got %v", err)
+		}
+	})
+}
+
+func TestProductService_List(t *testing.T) {
+	ctx := context.Background()
+
+	catalog := make([]*model.Product, 5)
+	for i := range catalog {
+		catalog[i] = &model.Product{ID: int64(i + 1), Name: "Product", PriceMinor: int64(100 * (i + 1)), Stock: i}
+	}
+	// Репозиторий в памяти: сортировка по цене по возрастанию, курсор — ID последнего продукта
+	var filters []repository.ProductFilter
+	mockRepo := &mockProductRepository{
+		listFunc: func(ctx context.Context, filter repository.ProductFilter) ([]*model.Product, error) {
+			filters = append(filters, filter)
+			rest := catalog
+			if filter.After != nil {
+				rest = catalog[filter.After.ID:]
+			}
+			rest = rest[min(filter.Offset, len(rest)):]
+			return rest[:min(filter.Limit, len(rest))], nil
+		},
+		countFunc: func(ctx context.Context, filter repository.ProductFilter) (int64, error) {
+			return int64(len(catalog)), nil
+		},
+	}
+	service := NewProductService(mockRepo, nil)
+
+	t.Run("CursorPagination", func(t *testing.T) {
+		filters = nil
+		var ids []int64
+		req := &dto.ListProductsRequest{SortBy: "price", Currency: "rub", PageSize: 2}
+		for {
+			list, err := service.List(ctx, req)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if list.TotalCount != 5 {
+				t.Errorf("Expected total 5, got %d", list.TotalCount)
+			}
+			for _, p := range list.Products {
+				ids = append(ids, p.ID)
+			}
+			if list.NextCursor == "" {
+				break
+			}
+			req.Cursor = list.NextCursor
+		}
+
+		if !reflect.DeepEqual(ids, []int64{1, 2, 3, 4, 5}) {
+			t.Errorf("Expected all products once, got %v", ids)
+		}
+		if f := filters[1]; f.After == nil || f.After.PriceMinor != 200 || f.SortBy != repository.ProductSortPrice || f.Descending || f.Currency != "RUB" {
+			t.Errorf("Unexpected filter of the second page: %+v", f)
+		}
+	})
+
+	t.Run("PageDefaults", func(t *testing.T) {
+		filters = nil
+		list, err := service.List(ctx, &dto.ListProductsRequest{Page: 2})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		f := filters[0]
+		if f.SortBy != repository.ProductSortCreatedAt || !f.Descending || f.Limit != DefaultPageSize+1 || f.Offset != DefaultPageSize {
+			t.Errorf("Expected created_at DESC, page size %d and offset %d, got %+v", DefaultPageSize, DefaultPageSize, f)
+		}
+		if list.Page != 2 || list.PageSize != DefaultPageSize || list.NextCursor != "" {
+			t.Errorf("Unexpected page metadata: %+v", list)
+		}
+	})
+
+	t.Run("InvalidRequests", func(t *testing.T) {
+		byName, err := service.List(ctx, &dto.ListProductsRequest{SortBy: "name", PageSize: 1})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		minPrice, maxPrice := int64(500), int64(100)
+
+		requests := map[string]*dto.ListProductsRequest{
+			"UnknownSort":         {SortBy: "rating"},
+			"UnknownOrder":        {SortOrder: "up"},
+			"PageSizeTooLarge":    {PageSize: MaxPageSize + 1},
+			"InvertedPriceRange":  {MinPriceMinor: &minPrice, MaxPriceMinor: &maxPrice, Currency: "RUB"},
+			"GarbageCursor":       {Cursor: "%%%"},
+			"CursorOfAnotherSort": {SortBy: "price", Currency: "RUB", Cursor: byName.NextCursor},
+			"UnknownStatus":       {Status: "deleted"},
+			// Цены в разных валютах несравнимы
+			"PriceFilterWithoutCurrency": {MaxPriceMinor: &minPrice},
+			"PriceSortWithoutCurrency":   {SortBy: "price"},
+			"InvalidCurrency":            {SortBy: "price", Currency: "rubles"},
+		}
+		for name, req := range requests {
+			if _, err := service.List(ctx, req); !errors.Is(err, ErrInvalidListRequest) {
+				t.Errorf("%s: expected ErrInvalidListRequest, got %v", name, err)
+			}
+		}
+	})
+}
+
+func TestProductService_Search(t *testing.T) {
+	ctx := context.Background()
+
+	var got repository.ProductSearch
+	mockRepo := &mockProductRepository{
+		searchFunc: func(ctx context.Context, search repository.ProductSearch) ([]*model.ProductSearchHit, error) {
+			got = search
+			return []*model.ProductSearchHit{{
+				Product:       model.Product{ID: 1, Name: "Lenovo ThinkPad Laptop"},
+				Rank:          0.5,
+				NameHighlight: "<b>Lenovo</b> ThinkPad <b>Laptop</b>",
+			}}, nil
+		},
+		countSearchFunc: func(ctx context.Context, search repository.ProductSearch) (int64, error) {
+			return 11, nil
+		},
+	}
+	service := NewProductService(mockRepo, nil)
+
+	t.Run("TermsAndPage", func(t *testing.T) {
+		found, err := service.Search(ctx, &dto.SearchProductsRequest{Query: "  Lenovo, lap:* & !lenovo", Page: 3, PageSize: 5})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+
+		// Синтаксис tsquery отброшен, регистр и повторы схлопнуты
+		if !reflect.DeepEqual(got.Terms, []string{"lenovo", "lap"}) {
+			t.Errorf("Expected terms [lenovo lap], got %q", got.Terms)
+		}
+		if got.Limit != 5 || got.Offset != 10 {
+			t.Errorf("Expected limit 5 and offset 10, got %d and %d", got.Limit, got.Offset)
+		}
+		if found.TotalCount != 11 || len(found.Results) != 1 {
+			t.Fatalf("Unexpected response: %+v", found)
+		}
+		if r := found.Results[0]; r.Product.ID != 1 || r.Highlight.Name != "<b>Lenovo</b> ThinkPad <b>Laptop</b>" {
+			t.Errorf("Unexpected result: %+v", r)
+		}
+	})
+
+	t.Run("Defaults", func(t *testing.T) {
+		found, err := service.Search(ctx, &dto.SearchProductsRequest{Query: "ноутбук"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if got.Limit != DefaultPageSize || got.Offset != 0 || found.Page != 1 {
+			t.Errorf("Expected first page of %d, got limit %d offset %d page %d", DefaultPageSize, got.Limit, got.Offset, found.Page)
+		}
+	})
+
+	t.Run("InvalidRequests", func(t *testing.T) {
+		requests := map[string]*dto.SearchProductsRequest{
+			"Empty":            {Query: ""},
+			"OnlyPunctuation":  {Query: "&|!:*"},
+			"TooLong":          {Query: strings.Repeat("a", MaxSearchQueryLength+1)},
+			"PageSizeTooLarge": {Query: "mouse", PageSize: MaxPageSize + 1},
+			"NegativePage":     {Query: "mouse", Page: -1},
+		}
+		for name, req := range requests {
+			if _, err := service.Search(ctx, req); !errors.Is(err, ErrInvalidSearchQuery) {
+				t.Errorf("%s: expected ErrInvalidSearchQuery, got %v", name, err)
+			}
+		}
+	})
+}
+
+func TestProductService_SetCategories(t *testing.T) {
+	ctx := context.Background()
+
+	breadcrumbs := model.CategoryPath{{ID: 1, Name: "Электроника"}, {ID: 4, Name: "Ноутбуки"}}
+	var linked []int64
+	mockRepo := &mockProductRepository{
+		setCategoriesFunc: func(ctx context.Context, productID int64, categoryIDs []int64) error {
+			linked = categoryIDs
+			return nil
+		},
+		findByIDFunc: func(ctx context.Context, id int64) (*model.Product, error) {
+			return &model.Product{ID: id, Name: "Laptop"}, nil
+		},
+		categoryPathsFunc: func(ctx context.Context, productIDs []int64) (map[int64][]model.CategoryPath, error) {
+			return map[int64][]model.CategoryPath{7: {breadcrumbs}}, nil
+		},
+	}
+	service := NewProductService(mockRepo, nil)
+
+	product, err := service.SetCategories(ctx, 7, []int64{4, 9, 4})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(linked, []int64{4, 9}) {
+		t.Errorf("Expected duplicates to be collapsed, got %v", linked)
+	}
+	if len(product.Categories) != 1 || !reflect.DeepEqual(product.Categories[0], breadcrumbs) {
+		t.Errorf("Expected breadcrumbs %v, got %v", breadcrumbs, product.Categories)
+	}
+
+	tooMany := make([]int64, MaxProductCategories+1)
+	for i := range tooMany {
+		tooMany[i] = int64(i + 1)
+	}
+	for name, ids := range map[string][]int64{"NonPositive": {3, 0}, "TooMany": tooMany} {
+		if _, err := service.SetCategories(ctx, 7, ids); !errors.Is(err, ErrInvalidCategories) {
+			t.Errorf("%s: expected ErrInvalidCategories, got %v", name, err)
+		}
+	}
+}
+
 func TestProductService_Create(t *testing.T) {
 	ctx := context.Background()
 
@@ -167,7 +640,7 @@ func TestProductService_Create(t *testing.T) {
 			},
 		}
 
-		service := NewProductService(mockRepo)
+		service := NewProductService(mockRepo, nil)
 		req := &dto.CreateProductRequest{
 			Name:        "New Product",
 			Description: "Description",
@@ -195,7 +668,7 @@ func TestProductService_Create(t *testing.T) {
 			},
 		}
 
-		service := NewProductService(mockRepo)
+		service := NewProductService(mockRepo, nil)
 		req := &dto.CreateProductRequest{
 			Name:  "New Product",
 			Price: 150.0,
@@ -219,7 +692,7 @@ func TestProductService_Update(t *testing.T) {
 			},
 		}
 
-		service := NewProductService(mockRepo)
+		service := NewProductService(mockRepo, nil)
 		req := &dto.UpdateProductRequest{
 			Name:        "Updated Product",
 			Description: "Updated Description",
@@ -239,7 +712,7 @@ func TestProductService_Update(t *testing.T) {
 
 	t.Run("InvalidID", func(t *testing.T) {
 		mockRepo := &mockProductRepository{}
-		service := NewProductService(mockRepo)
+		service := NewProductService(mockRepo, nil)
 		req := &dto.UpdateProductRequest{
 			Name:  "Updated Product",
 			Price: 200.0,
@@ -251,6 +724,34 @@ func TestProductService_Update(t *testing.T) {
 			t.Error("Expected error for invalid ID, got nil")
 		}
 	})
+
+	t.Run("ExpectedVersion", func(t *testing.T) {
+		var gotVersion int64
+		mockRepo := &mockProductRepository{
+			updateFunc: func(ctx context.Context, product *model.Product) error {
+				gotVersion = product.Version
+				if product.Version != 3 {
+					return repository.ErrVersionConflict
+				}
+				product.Version++
+				return nil
+			},
+		}
+		service := NewProductService(mockRepo, nil)
+
+		product, err := service.Update(ctx, 1, &dto.UpdateProductRequest{Name: "P", Price: 1, Stock: 1, Version: 3})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if gotVersion != 3 || product.Version != 4 {
+			t.Errorf("Expected version 3 passed and 4 returned, got %d and %d", gotVersion, product.Version)
+		}
+
+		_, err = service.Update(ctx, 1, &dto.UpdateProductRequest{Name: "P", Price: 1, Stock: 1, Version: 2})
+		if !errors.Is(err, repository.ErrVersionConflict) {
+			t.Errorf("Expected ErrVersionConflict, got %v", err)
+		}
+	})
 }
 
 func TestProductService_Delete(t *testing.T) {
@@ -258,12 +759,16 @@ func TestProductService_Delete(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		mockRepo := &mockProductRepository{
-			deleteFunc: func(ctx context.Context, id int64) error {
+			archiveFunc: func(ctx context.Context, id int64) error {
 				return nil
 			},
+			purgeFunc: func(ctx context.Context, id int64) ([]*model.ProductImage, error) {
+				t.Error("Delete must archive the product, not purge it")
+				return nil, nil
+			},
 		}
 
-		service := NewProductService(mockRepo)
+		service := NewProductService(mockRepo, nil)
 		err := service.Delete(ctx, 1)
 
 		if err != nil {
@@ -273,7 +778,7 @@ func TestProductService_Delete(t *testing.T) {
 
 	t.Run("InvalidID", func(t *testing.T) {
 		mockRepo := &mockProductRepository{}
-		service := NewProductService(mockRepo)
+		service := NewProductService(mockRepo, nil)
 
 		err := service.Delete(ctx, 0)
 		if err == nil {
@@ -283,16 +788,335 @@ func TestProductService_Delete(t *testing.T) {
 
 	t.Run("NotFound", func(t *testing.T) {
 		mockRepo := &mockProductRepository{
-			deleteFunc: func(ctx context.Context, id int64) error {
-				return errors.New("product not found")
+			archiveFunc: func(ctx context.Context, id int64) error {
+				return repository.ErrProductNotFound
 			},
 		}
 
-		service := NewProductService(mockRepo)
+		service := NewProductService(mockRepo, nil)
 		err := service.Delete(ctx, 999)
 
+		if !errors.Is(err, repository.ErrProductNotFound) {
+			t.Errorf("Expected ErrProductNotFound, got %v", err)
+		}
+	})
+}
+
+func TestProductService_Restore(t *testing.T) {
+	ctx := context.Background()
+
+	mockRepo := &mockProductRepository{
+		findByIDFunc: func(ctx context.Context, id int64) (*model.Product, error) {
+			return &model.Product{ID: id, Name: "Lamp", Status: model.ProductStatusActive, Version: 4}, nil
+		},
+	}
+	service := NewProductService(mockRepo, nil)
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.restoreFunc = func(ctx context.Context, id int64) error {
+			return nil
+		}
+
+		product, err := service.Restore(ctx, 1)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if product.Status != model.ProductStatusActive || product.DeletedAt != nil {
+			t.Errorf("Expected an active product, got %+v", product)
+		}
+	})
+
+	t.Run("NotArchived", func(t *testing.T) {
+		mockRepo.restoreFunc = func(ctx context.Context, id int64) error {
+			return repository.ErrProductNotArchived
+		}
+
+		if _, err := service.Restore(ctx, 1); !errors.Is(err, repository.ErrProductNotArchived) {
+			t.Errorf("Expected ErrProductNotArchived, got %v", err)
+		}
+		if _, err := service.Restore(ctx, 0); !errors.Is(err, repository.ErrProductNotFound) {
+			t.Errorf("Expected ErrProductNotFound, got %v", err)
+		}
+	})
+}
+
+func TestProductService_AdjustStock(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		var recorded *model.StockMovement
+		mockRepo := &mockProductRepository{
+			adjustStockFunc: func(ctx context.Context, movement *model.StockMovement) error {
+				recorded = movement
+				movement.ID = 7
+				movement.StockAfter = 8
+				return nil
+			},
+		}
+
+		service := NewProductService(mockRepo, nil)
+		movement, err := service.AdjustStock(ctx, &dto.AdjustStockRequest{ProductID: 1, Delta: -2, Reference: "42", Actor: "order-service"})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if recorded.Delta != -2 || recorded.Reason != StockReasonUnspecified || recorded.Reference != "42" {
+			t.Errorf("Unexpected movement passed to repository: %+v", recorded)
+		}
+		if movement.ID != 7 || movement.StockAfter != 8 {
+			t.Errorf("Expected movement 7 with stock 8, got %+v", movement)
+		}
+	})
+
+	t.Run("InsufficientStock", func(t *testing.T) {
+		mockRepo := &mockProductRepository{
+			adjustStockFunc: func(ctx context.Context, movement *model.StockMovement) error {
+				return repository.ErrInsufficientStock
+			},
+		}
+
+		service := NewProductService(mockRepo, nil)
+		_, err := service.AdjustStock(ctx, &dto.AdjustStockRequest{ProductID: 1, Delta: -100})
+
+		if !errors.Is(err, repository.ErrInsufficientStock) {
+			t.Errorf("Expected ErrInsufficientStock, got %v", err)
+		}
+	})
+
+	t.Run("ZeroDelta", func(t *testing.T) {
+		service := NewProductService(&mockProductRepository{}, nil)
+		_, err := service.AdjustStock(ctx, &dto.AdjustStockRequest{ProductID: 1})
+
+		if !errors.Is(err, ErrInvalidStockChange) {
+			t.Errorf("Expected ErrInvalidStockChange, got %v", err)
+		}
+	})
+}
+
+func TestProductService_AdjustStockBatch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ResultsInRequestOrder", func(t *testing.T) {
+		mockRepo := &mockProductRepository{
+			adjustStockBatchFunc: func(ctx context.Context, movements []*model.StockMovement) error {
+				for _, m := range movements {
+					if m.Reason != "order_created" || m.Reference != "42" {
+						t.Errorf("Unexpected movement metadata: %+v", m)
+					}
+					m.StockAfter = int(m.ProductID) * 10
+				}
+				return nil
+			},
+		}
+
+		service := NewProductService(mockRepo, nil)
+		results, err := service.AdjustStockBatch(ctx, &dto.BatchAdjustStockRequest{
+			Items:     []dto.StockItem{{ProductID: 3, Delta: -1}, {ProductID: 1, Delta: -2}},
+			Reason:    "order_created",
+			Reference: "42",
+		})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(results) != 2 || results[0].ProductID != 3 || results[0].StockAfter != 30 || results[1].ProductID != 1 {
+			t.Errorf("Expected results for products [3 1], got %+v", results)
+		}
+	})
+
+	t.Run("VariantsOfOneProduct", func(t *testing.T) {
+		mockRepo := &mockProductRepository{
+			adjustStockBatchFunc: func(ctx context.Context, movements []*model.StockMovement) error {
+				if len(movements) != 2 || movements[0].VariantID != 5 || movements[1].VariantID != 6 {
+					t.Errorf("Expected movements of variants [5 6], got %+v", movements)
+				}
+				return nil
+			},
+		}
+
+		service := NewProductService(mockRepo, nil)
+		results, err := service.AdjustStockBatch(ctx, &dto.BatchAdjustStockRequest{
+			Items: []dto.StockItem{{ProductID: 1, VariantID: 5, Delta: -1}, {ProductID: 1, VariantID: 6, Delta: -2}},
+		})
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(results) != 2 || results[0].VariantID != 5 || results[1].VariantID != 6 {
+			t.Errorf("Expected results for variants [5 6], got %+v", results)
+		}
+	})
+
+	t.Run("ShortItemIsNamed", func(t *testing.T) {
+		mockRepo := &mockProductRepository{
+			adjustStockBatchFunc: func(ctx context.Context, movements []*model.StockMovement) error {
+				return &repository.InsufficientStockError{ProductID: 2, Delta: -5, Available: 3}
+			},
+		}
+
+		service := NewProductService(mockRepo, nil)
+		_, err := service.AdjustStockBatch(ctx, &dto.BatchAdjustStockRequest{
+			Items: []dto.StockItem{{ProductID: 1, Delta: -1}, {ProductID: 2, Delta: -5}},
+		})
+
+		if !errors.Is(err, repository.ErrInsufficientStock) {
+			t.Fatalf("Expected ErrInsufficientStock, got %v", err)
+		}
+		if err.Error() != "insufficient stock for product 2: requested 5, available 3" {
+			t.Errorf("Unexpected error message: %v", err)
+		}
+	})
+
+	t.Run("InvalidItems", func(t *testing.T) {
+		service := NewProductService(&mockProductRepository{}, nil)
+		requests := map[string][]dto.StockItem{
+			"Empty":     nil,
+			"ZeroDelta": {{ProductID: 1, Delta: 0}},
+			"Duplicate": {{ProductID: 1, Delta: -1}, {ProductID: 1, Delta: -2}},
+			"BadID":     {{ProductID: 0, Delta: 1}},
+
+			"DuplicateVariant":  {{ProductID: 1, VariantID: 5, Delta: -1}, {ProductID: 1, VariantID: 5, Delta: -2}},
+			"ProductAndVariant": {{ProductID: 1, Delta: -1}, {ProductID: 1, VariantID: 5, Delta: -2}},
+			"VariantAndProduct": {{ProductID: 1, VariantID: 5, Delta: -1}, {ProductID: 1, Delta: -2}},
+			"NegativeVariantID": {{ProductID: 1, VariantID: -5, Delta: -1}},
+		}
+
+		for name, items := range requests {
+			_, err := service.AdjustStockBatch(ctx, &dto.BatchAdjustStockRequest{Items: items})
+			if !errors.Is(err, ErrInvalidStockChange) {
+				t.Errorf("%s: expected ErrInvalidStockChange, got %v", name, err)
+			}
+		}
+	})
+}
+
+func TestProductService_ListStockMovements(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("LimitIsCapped", func(t *testing.T) {
+		var gotLimit int
+		mockRepo := &mockProductRepository{
+			findByIDFunc: func(ctx context.Context, id int64) (*model.Product, error) {
+				return &model.Product{ID: id}, nil
+			},
+			listStockMovementsFunc: func(ctx context.Context, productID int64, limit, offset int) ([]*model.StockMovement, error) {
+				gotLimit = limit
+				return []*model.StockMovement{{ID: 2, ProductID: productID, Delta: -1}, {ID: 1, ProductID: productID, Delta: 10}}, nil
+			},
+		}
+
+		service := NewProductService(mockRepo, nil)
+		movements, err := service.ListStockMovements(ctx, 1, 1000, 0)
+
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if gotLimit != maxMovementsLimit {
+			t.Errorf("Expected limit %d, got %d", maxMovementsLimit, gotLimit)
+		}
+		if len(movements) != 2 || movements[0].ID != 2 {
+			t.Errorf("Expected 2 movements newest first, got %+v", movements)
+		}
+	})
+
+	t.Run("ProductNotFound", func(t *testing.T) {
+		mockRepo := &mockProductRepository{
+			findByIDFunc: func(ctx context.Context, id int64) (*model.Product, error) {
+				return nil, errors.New("product with id 999 not found")
+			},
+		}
+
+		service := NewProductService(mockRepo, nil)
+		_, err := service.ListStockMovements(ctx, 999, 0, 0)
+
 		if err == nil {
 			t.Error("Expected error, got nil")
 		}
 	})
 }
+
+func TestProductService_Variants(t *testing.T) {
+	ctx := context.Background()
+	override := int64(12000)
+
+	// Продукт с двумя вариантами: у второго своя цена
+	mockRepo := &mockProductRepository{
+		findByIDFunc: func(ctx context.Context, id int64) (*model.Product, error) {
+			return &model.Product{ID: id, Name: "Футболка", PriceMinor: 9900, Currency: "RUB", Stock: 7}, nil
+		},
+		variantsFunc: func(ctx context.Context, productIDs []int64) (map[int64][]*model.ProductVariant, error) {
+			return map[int64][]*model.ProductVariant{
+				1: {
+					{ID: 10, ProductID: 1, SKU: "TS-M", Attributes: map[string]string{"size": "M"}, Stock: 3},
+					{ID: 11, ProductID: 1, SKU: "TS-XL", Attributes: map[string]string{"size": "XL"}, PriceMinor: &override, Stock: 4},
+				},
+			}, nil
+		},
+	}
+	service := NewProductService(mockRepo, nil)
+
+	t.Run("EffectivePrices", func(t *testing.T) {
+		product, err := service.GetByID(ctx, 1)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if len(product.Variants) != 2 {
+			t.Fatalf("Expected 2 variants, got %d", len(product.Variants))
+		}
+		if v := product.Variants[0]; v.PriceMinor != 9900 || v.PriceOverrideMinor != nil {
+			t.Errorf("Expected variant without override to cost 9900, got %+v", v)
+		}
+		if v := product.Variants[1]; v.PriceMinor != 12000 || v.PriceOverrideMinor == nil {
+			t.Errorf("Expected variant override 12000, got %+v", v)
+		}
+	})
+
+	t.Run("CreateVariant", func(t *testing.T) {
+		mockRepo.createVariantFunc = func(ctx context.Context, variant *model.ProductVariant) error {
+			if variant.ProductID != 1 || variant.SKU != "TS-L" || variant.Attributes["colour"] != "red" {
+				t.Errorf("Unexpected variant: %+v", variant)
+			}
+			return nil
+		}
+
+		_, err := service.CreateVariant(ctx, 1, &dto.VariantRequest{
+			SKU:        " TS-L ",
+			Attributes: map[string]string{"size": "L", "colour": "red"},
+			Stock:      2,
+		})
+		if err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+
+	t.Run("InvalidVariants", func(t *testing.T) {
+		negative := int64(-1)
+		requests := map[string]*dto.VariantRequest{
+			"EmptySKU":           {SKU: "  "},
+			"LongSKU":            {SKU: strings.Repeat("x", MaxSKULength+1)},
+			"NegativePrice":      {SKU: "TS-S", PriceMinor: &negative},
+			"NegativeStock":      {SKU: "TS-S", Stock: -1},
+			"EmptyAttributeName": {SKU: "TS-S", Attributes: map[string]string{"": "S"}},
+		}
+
+		for name, req := range requests {
+			_, err := service.CreateVariant(ctx, 1, req)
+			if !errors.Is(err, ErrInvalidVariant) {
+				t.Errorf("%s: expected ErrInvalidVariant, got %v", name, err)
+			}
+		}
+	})
+
+	t.Run("DeleteLastVariant", func(t *testing.T) {
+		mockRepo.deleteVariantFunc = func(ctx context.Context, productID, variantID int64) error {
+			return repository.ErrLastVariant
+		}
+
+		if err := service.DeleteVariant(ctx, 1, 10); !errors.Is(err, repository.ErrLastVariant) {
+			t.Errorf("Expected ErrLastVariant, got %v", err)
+		}
+		if err := service.DeleteVariant(ctx, 1, 0); !errors.Is(err, repository.ErrVariantNotFound) {
+			t.Errorf("Expected ErrVariantNotFound, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,6 @@
+-- migrations/002_add_price_minor_and_currency.down.sql
+
+ALTER TABLE products
+    DROP CONSTRAINT IF EXISTS products_price_minor_check,
+    DROP COLUMN IF EXISTS currency,
+    DROP COLUMN IF EXISTS price_minor;
@@ -0,0 +1,13 @@
+-- migrations/002_add_price_minor_and_currency.up.sql
+
+-- Exact price in minor units (kopecks, cents) with an ISO 4217 currency code.
+-- The legacy DECIMAL price column is kept in sync for a compatibility period.
+ALTER TABLE products
+    ADD COLUMN price_minor BIGINT,
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'RUB';
+
+UPDATE products SET price_minor = ROUND(price * 100);
+
+ALTER TABLE products
+    ALTER COLUMN price_minor SET NOT NULL,
+    ADD CONSTRAINT products_price_minor_check CHECK (price_minor >= 0);
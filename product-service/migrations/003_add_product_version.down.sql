@@ -0,0 +1,3 @@
+-- migrations/003_add_product_version.down.sql
+
+ALTER TABLE products DROP COLUMN IF EXISTS version;
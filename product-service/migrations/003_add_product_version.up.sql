@@ -0,0 +1,4 @@
+-- migrations/003_add_product_version.up.sql
+
+-- Version for optimistic concurrency control, exposed over REST as ETag.
+ALTER TABLE products ADD COLUMN version BIGINT NOT NULL DEFAULT 1;
@@ -0,0 +1,3 @@
+-- migrations/004_create_stock_movements.down.sql
+
+DROP TABLE IF EXISTS stock_movements;
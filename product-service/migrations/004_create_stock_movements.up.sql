@@ -0,0 +1,19 @@
+-- migrations/004_create_stock_movements.up.sql
+
+-- Ledger of every stock change; stock_after is the product stock right after the change.
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id BIGSERIAL PRIMARY KEY,
+    product_id BIGINT NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    delta INTEGER NOT NULL,
+    stock_after INTEGER NOT NULL CHECK (stock_after >= 0),
+    reason VARCHAR(50) NOT NULL,
+    reference VARCHAR(100) NOT NULL DEFAULT '',
+    actor VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_stock_movements_product ON stock_movements (product_id, id DESC);
+
+-- Opening balance for products created before the ledger existed.
+INSERT INTO stock_movements (product_id, delta, stock_after, reason, actor)
+SELECT id, stock, stock, 'initial', 'migration' FROM products;
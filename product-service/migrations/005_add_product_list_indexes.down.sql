@@ -0,0 +1,7 @@
+-- migrations/005_add_product_list_indexes.down.sql
+
+DROP INDEX IF EXISTS idx_products_updated_at;
+DROP INDEX IF EXISTS idx_products_stock_id;
+DROP INDEX IF EXISTS idx_products_name_id;
+DROP INDEX IF EXISTS idx_products_price_minor_id;
+DROP INDEX IF EXISTS idx_products_created_at_id;
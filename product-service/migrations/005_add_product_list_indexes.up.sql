@@ -0,0 +1,8 @@
+-- migrations/005_add_product_list_indexes.up.sql
+
+-- Keyset pagination of the product list: every sort key is paired with id as a tiebreaker.
+CREATE INDEX IF NOT EXISTS idx_products_created_at_id ON products (created_at, id);
+CREATE INDEX IF NOT EXISTS idx_products_price_minor_id ON products (price_minor, id);
+CREATE INDEX IF NOT EXISTS idx_products_name_id ON products (name, id);
+CREATE INDEX IF NOT EXISTS idx_products_stock_id ON products (stock, id);
+CREATE INDEX IF NOT EXISTS idx_products_updated_at ON products (updated_at);
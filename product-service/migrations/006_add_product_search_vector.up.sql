@@ -0,0 +1,30 @@
+-- migrations/006_add_product_search_vector.up.sql
+
+-- Full-text search over the catalog: name weighs more than description.
+-- The russian configuration stems Russian words and handles Latin ones with the English stemmer.
+ALTER TABLE products ADD COLUMN IF NOT EXISTS search_vector tsvector;
+
+CREATE OR REPLACE FUNCTION products_search_vector_update()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector :=
+        setweight(to_tsvector('russian', coalesce(NEW.name, '')), 'A') ||
+        setweight(to_tsvector('russian', coalesce(NEW.description, '')), 'B');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER products_search_vector_trigger
+    BEFORE INSERT OR UPDATE OF name, description
+    ON products
+    FOR EACH ROW
+    EXECUTE FUNCTION products_search_vector_update();
+
+-- Backfill existing products without touching updated_at: the text of the products has not changed
+ALTER TABLE products DISABLE TRIGGER update_products_updated_at;
+UPDATE products
+SET search_vector = setweight(to_tsvector('russian', coalesce(name, '')), 'A') ||
+                    setweight(to_tsvector('russian', coalesce(description, '')), 'B');
+ALTER TABLE products ENABLE TRIGGER update_products_updated_at;
+
+CREATE INDEX IF NOT EXISTS idx_products_search_vector ON products USING GIN (search_vector);
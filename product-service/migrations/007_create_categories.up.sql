@@ -0,0 +1,31 @@
+-- migrations/007_create_categories.up.sql
+
+-- Category tree: parent_id points to the parent, NULL for top-level categories.
+-- A category with subcategories cannot be deleted; move or delete the children first.
+CREATE TABLE IF NOT EXISTS categories (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    parent_id BIGINT REFERENCES categories (id) ON DELETE RESTRICT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK (parent_id <> id)
+    );
+
+-- Sibling names are unique, top-level categories are siblings of each other
+CREATE UNIQUE INDEX idx_categories_parent_name ON categories (COALESCE(parent_id, 0), lower(name));
+CREATE INDEX idx_categories_parent_id ON categories (parent_id);
+
+CREATE TRIGGER update_categories_updated_at
+    BEFORE UPDATE
+    ON categories
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Many-to-many links: a product may belong to several categories
+CREATE TABLE IF NOT EXISTS product_categories (
+    product_id BIGINT NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    category_id BIGINT NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+    PRIMARY KEY (product_id, category_id)
+    );
+
+CREATE INDEX idx_product_categories_category_id ON product_categories (category_id);
@@ -0,0 +1,4 @@
+-- migrations/008_create_product_variants.down.sql
+
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS variant_id;
+DROP TABLE IF EXISTS product_variants;
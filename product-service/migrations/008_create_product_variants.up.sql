@@ -0,0 +1,34 @@
+-- migrations/008_create_product_variants.up.sql
+
+-- Variants of a product (size, colour, ...). Every product has at least one variant;
+-- products.stock stays the sum of its variants' stock so listings and filters keep working.
+-- price_minor overrides the product price, NULL means the variant costs the same as the product.
+CREATE TABLE IF NOT EXISTS product_variants (
+    id BIGSERIAL PRIMARY KEY,
+    product_id BIGINT NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    sku VARCHAR(64) NOT NULL,
+    attributes JSONB NOT NULL DEFAULT '{}',
+    price_minor BIGINT CHECK (price_minor >= 0),
+    stock INTEGER NOT NULL DEFAULT 0 CHECK (stock >= 0),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+    );
+
+CREATE UNIQUE INDEX idx_product_variants_sku ON product_variants (lower(sku));
+CREATE INDEX idx_product_variants_product_id ON product_variants (product_id, id);
+
+CREATE TRIGGER update_product_variants_updated_at
+    BEFORE UPDATE
+    ON product_variants
+    FOR EACH ROW
+    EXECUTE FUNCTION update_updated_at_column();
+
+-- Existing products become single-variant products holding all of their stock
+INSERT INTO product_variants (product_id, sku, stock)
+SELECT id, 'P-' || id, stock FROM products;
+
+-- Ledger rows written before variants existed keep variant_id NULL
+ALTER TABLE stock_movements
+    ADD COLUMN variant_id BIGINT REFERENCES product_variants (id) ON DELETE SET NULL;
+
+CREATE INDEX idx_stock_movements_variant ON stock_movements (variant_id, id DESC) WHERE variant_id IS NOT NULL;
@@ -0,0 +1,3 @@
+-- migrations/009_create_product_images.down.sql
+
+DROP TABLE IF EXISTS product_images;
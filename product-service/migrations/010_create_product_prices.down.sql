@@ -0,0 +1,4 @@
+-- migrations/010_create_product_prices.down.sql
+
+DROP TABLE IF EXISTS price_schedules;
+DROP TABLE IF EXISTS product_prices;
@@ -0,0 +1,47 @@
+-- migrations/010_create_product_prices.up.sql
+
+-- Future prices. A schedule with ends_at is a sale: when it ends the price reverts to revert_price_minor,
+-- the price the product had when the sale started. Without ends_at the new price simply stays.
+-- pending -> active (sale running) -> completed; pending or active -> cancelled.
+CREATE TABLE IF NOT EXISTS price_schedules (
+    id BIGSERIAL PRIMARY KEY,
+    product_id BIGINT NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    price_minor BIGINT NOT NULL CHECK (price_minor >= 0),
+    currency CHAR(3) NOT NULL,
+    starts_at TIMESTAMPTZ NOT NULL,
+    ends_at TIMESTAMPTZ CHECK (ends_at > starts_at),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'active', 'completed', 'cancelled')),
+    revert_price_minor BIGINT,
+    revert_currency CHAR(3),
+    actor VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    applied_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ
+    );
+
+CREATE INDEX idx_price_schedules_product ON price_schedules (product_id, starts_at);
+-- The applier only looks at schedules that still have work to do
+CREATE INDEX idx_price_schedules_due_start ON price_schedules (starts_at) WHERE status = 'pending';
+CREATE INDEX idx_price_schedules_due_end ON price_schedules (ends_at) WHERE status = 'active';
+
+-- History of every product price change. previous_price_minor is NULL for the opening price.
+-- schedule_id links changes made by the price scheduler to their schedule.
+CREATE TABLE IF NOT EXISTS product_prices (
+    id BIGSERIAL PRIMARY KEY,
+    product_id BIGINT NOT NULL REFERENCES products (id) ON DELETE CASCADE,
+    price_minor BIGINT NOT NULL CHECK (price_minor >= 0),
+    currency CHAR(3) NOT NULL,
+    previous_price_minor BIGINT,
+    previous_currency CHAR(3),
+    reason VARCHAR(50) NOT NULL,
+    schedule_id BIGINT REFERENCES price_schedules (id) ON DELETE SET NULL,
+    actor VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+    );
+
+CREATE INDEX idx_product_prices_product ON product_prices (product_id, id DESC);
+
+-- Opening price for products created before the history existed
+INSERT INTO product_prices (product_id, price_minor, currency, reason, actor)
+SELECT id, price_minor, currency, 'initial', 'migration' FROM products;
@@ -0,0 +1,6 @@
+-- migrations/011_add_product_status.down.sql
+
+DROP INDEX IF EXISTS idx_products_status;
+ALTER TABLE products DROP CONSTRAINT IF EXISTS chk_products_deleted_at;
+ALTER TABLE products DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE products DROP COLUMN IF EXISTS status;
@@ -0,0 +1,12 @@
+-- migrations/011_add_product_status.up.sql
+
+-- Lifecycle of a product instead of hard deletes: drafts are not published yet, archived products
+-- are hidden from the catalogue but still resolvable by id, so past orders keep pointing at them.
+-- deleted_at is when the product was archived and is set exactly for archived products.
+ALTER TABLE products
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'active'
+        CHECK (status IN ('draft', 'active', 'archived')),
+    ADD COLUMN deleted_at TIMESTAMPTZ,
+    ADD CONSTRAINT chk_products_deleted_at CHECK ((status = 'archived') = (deleted_at IS NOT NULL));
+
+CREATE INDEX IF NOT EXISTS idx_products_status ON products (status);
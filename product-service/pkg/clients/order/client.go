@@ -0,0 +1,49 @@
+// Package order — клиент order-service для проверок, которые product-service не может сделать по своей БД
+package order
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	orderv1 "github.com/microserviceteam0/bff-gateway/product-service/pkg/api/order/v1"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type Client struct {
+	conn    *grpc.ClientConn
+	Service orderv1.OrderServiceClient
+}
+
+// NewClient подключается к сервису; opts добавляются к настройкам по умолчанию (например, интерсепторы)
+func NewClient(addr string, opts ...grpc.DialOption) (*Client, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	conn, err := grpc.NewClient(addr, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to order service: %w", err)
+	}
+
+	return &Client{
+		conn:    conn,
+		Service: orderv1.NewOrderServiceClient(conn),
+	}, nil
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// ProductOrdered сообщает, есть ли заказы с продуктом. SearchOrders доступен только администраторам,
+// поэтому ctx должен нести личность администратора (identity.NewOutgoingContext)
+func (c *Client) ProductOrdered(ctx context.Context, productID int64) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.Service.SearchOrders(ctx, &orderv1.SearchOrdersRequest{ProductId: productID, PageSize: 1})
+	if err != nil {
+		return false, fmt.Errorf("failed to search orders: %w", err)
+	}
+	return len(resp.GetOrders()) > 0, nil
+}
@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLocalStorage(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewLocalStorage(t.TempDir(), "http://localhost:8083/media/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Put(ctx, "products/1/a.png", strings.NewReader("data"), "image/png"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	f, err := s.Open(ctx, "products/1/a.png")
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	data, _ := io.ReadAll(f)
+	_ = f.Close()
+	if string(data) != "data" {
+		t.Errorf("Expected stored data, got %q", data)
+	}
+	if got := s.URL("products/1/a.png"); got != "http://localhost:8083/media/products/1/a.png" {
+		t.Errorf("Unexpected URL %q", got)
+	}
+
+	if err := s.Delete(ctx, "products/1/a.png"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := s.Delete(ctx, "products/1/a.png"); err != nil {
+		t.Errorf("Expected deleting a missing object to succeed, got %v", err)
+	}
+	if _, err := s.Open(ctx, "products/1/a.png"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	for _, key := range []string{"", "/etc/passwd", "../secret", "a/../../b", "a//b"} {
+		if err := s.Put(ctx, key, strings.NewReader("x"), "text/plain"); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("%q: expected ErrInvalidKey, got %v", key, err)
+		}
+	}
+}
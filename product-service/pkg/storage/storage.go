@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound возвращается, если объекта с таким ключом нет
+var ErrNotFound = errors.New("object not found")
+
+// ErrInvalidKey возвращается для пустого ключа или ключа, выходящего за пределы хранилища
+var ErrInvalidKey = errors.New("invalid object key")
+
+// Storage — хранилище файлов. Ключ — относительный путь через "/", например "products/1/abc.jpg"
+type Storage interface {
+	// Put сохраняет объект, перезаписывая существующий
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete удаляет объект; отсутствие объекта ошибкой не считается
+	Delete(ctx context.Context, key string) error
+	// URL возвращает публичный адрес объекта
+	URL(key string) string
+}
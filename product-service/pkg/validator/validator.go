@@ -34,14 +34,29 @@ func formatValidationErrors(errs validator.ValidationErrors) error {
 	for _, err := range errs {
 		switch err.Tag() {
 		case "required":
-			messages = append(messages, fmt.Sprintf("%s is required", strings.ToLower(err.Field())))
+			messages = append(messages, fmt.Sprintf("%s is required", toSnakeCase(err.Field())))
 		case "max":
-			messages = append(messages, fmt.Sprintf("%s must not exceed %s characters", strings.ToLower(err.Field()), err.Param()))
+			messages = append(messages, fmt.Sprintf("%s must not exceed %s characters", toSnakeCase(err.Field()), err.Param()))
+		case "required_without":
+			messages = append(messages, fmt.Sprintf("%s is required when %s is not set", toSnakeCase(err.Field()), toSnakeCase(err.Param())))
+		case "len":
+			messages = append(messages, fmt.Sprintf("%s must be exactly %s characters long", toSnakeCase(err.Field()), err.Param()))
 		case "gte":
-			messages = append(messages, fmt.Sprintf("%s must be greater than or equal to %s", strings.ToLower(err.Field()), err.Param()))
+			messages = append(messages, fmt.Sprintf("%s must be greater than or equal to %s", toSnakeCase(err.Field()), err.Param()))
 		default:
-			messages = append(messages, fmt.Sprintf("%s is invalid", strings.ToLower(err.Field())))
+			messages = append(messages, fmt.Sprintf("%s is invalid", toSnakeCase(err.Field())))
 		}
 	}
-	return fmt.Errorf(strings.Join(messages, ", "))
+	return errors.New(strings.Join(messages, ", "))
+}
+
+func toSnakeCase(field string) string {
+	var b strings.Builder
+	for i, r := range field {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToLower(b.String())
 }
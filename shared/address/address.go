@@ -0,0 +1,151 @@
+package address
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Delivery methods supported by orders.
+const (
+	DeliveryCourier = "courier"
+	DeliveryPickup  = "pickup"
+	DeliveryPost    = "post"
+)
+
+// DefaultDeliveryMethod is used when the client does not choose one.
+const DefaultDeliveryMethod = DeliveryCourier
+
+// Address is a postal address in the form shared by user-service, order-service and the BFF.
+type Address struct {
+	RecipientName string
+	Phone         string
+	Country       string // ISO 3166-1 alpha-2
+	Region        string
+	City          string
+	PostalCode    string
+	Line1         string
+	Line2         string
+}
+
+// FieldError describes the first invalid field of an address.
+type FieldError struct {
+	Field  string
+	Reason string
+}
+
+func (e *FieldError) Error() string {
+	return e.Field + ": " + e.Reason
+}
+
+type countryRule struct {
+	postalCode     *regexp.Regexp
+	postalFormat   string
+	regionRequired bool
+}
+
+var sixDigits = regexp.MustCompile(`^\d{6}$`)
+
+// rules holds the postal code formats of the countries we ship to most.
+// Other countries only get the generic checks.
+var rules = map[string]countryRule{
+	"RU": {postalCode: sixDigits, postalFormat: "6 digits"},
+	"BY": {postalCode: sixDigits, postalFormat: "6 digits"},
+	"KZ": {postalCode: sixDigits, postalFormat: "6 digits"},
+	"DE": {postalCode: regexp.MustCompile(`^\d{5}$`), postalFormat: "5 digits"},
+	"US": {postalCode: regexp.MustCompile(`^\d{5}(-\d{4})?$`), postalFormat: "12345 or 12345-6789", regionRequired: true},
+	"CA": {postalCode: regexp.MustCompile(`^[A-Z]\d[A-Z] \d[A-Z]\d$`), postalFormat: "A1A 1A1", regionRequired: true},
+	"GB": {postalCode: regexp.MustCompile(`^[A-Z]{1,2}\d[A-Z\d]? \d[A-Z]{2}$`), postalFormat: "SW1A 1AA"},
+}
+
+var (
+	countryCode   = regexp.MustCompile(`^[A-Z]{2}$`)
+	genericPostal = regexp.MustCompile(`^[A-Z0-9][A-Z0-9 -]{0,9}$`)
+	phoneNumber   = regexp.MustCompile(`^\+?[0-9 ()-]{5,20}$`)
+)
+
+// Normalize trims all fields and brings the country and postal code to their canonical case.
+func Normalize(a Address) Address {
+	a.RecipientName = strings.TrimSpace(a.RecipientName)
+	a.Phone = strings.TrimSpace(a.Phone)
+	a.Country = strings.ToUpper(strings.TrimSpace(a.Country))
+	a.Region = strings.TrimSpace(a.Region)
+	a.City = strings.TrimSpace(a.City)
+	a.PostalCode = strings.ToUpper(strings.TrimSpace(a.PostalCode))
+	a.Line1 = strings.TrimSpace(a.Line1)
+	a.Line2 = strings.TrimSpace(a.Line2)
+	return a
+}
+
+// Validate checks a normalized address against the generic and per-country rules.
+// The returned error is a *FieldError.
+func Validate(a Address) error {
+	required := []struct {
+		field string
+		value string
+	}{
+		{"recipient_name", a.RecipientName},
+		{"country", a.Country},
+		{"city", a.City},
+		{"line1", a.Line1},
+	}
+	for _, r := range required {
+		if r.value == "" {
+			return &FieldError{Field: r.field, Reason: "is required"}
+		}
+	}
+
+	if !countryCode.MatchString(a.Country) {
+		return &FieldError{Field: "country", Reason: "must be an ISO 3166-1 alpha-2 code"}
+	}
+
+	limits := []struct {
+		field string
+		value string
+		max   int
+	}{
+		{"recipient_name", a.RecipientName, 255},
+		{"region", a.Region, 100},
+		{"city", a.City, 100},
+		{"line1", a.Line1, 255},
+		{"line2", a.Line2, 255},
+	}
+	for _, l := range limits {
+		if len([]rune(l.value)) > l.max {
+			return &FieldError{Field: l.field, Reason: fmt.Sprintf("must be at most %d characters", l.max)}
+		}
+	}
+
+	if a.Phone != "" && !phoneNumber.MatchString(a.Phone) {
+		return &FieldError{Field: "phone", Reason: "invalid phone number"}
+	}
+
+	rule, known := rules[a.Country]
+	if !known {
+		if a.PostalCode != "" && !genericPostal.MatchString(a.PostalCode) {
+			return &FieldError{Field: "postal_code", Reason: "invalid postal code"}
+		}
+		return nil
+	}
+
+	if a.PostalCode == "" {
+		return &FieldError{Field: "postal_code", Reason: "is required for " + a.Country}
+	}
+	if !rule.postalCode.MatchString(a.PostalCode) {
+		return &FieldError{Field: "postal_code", Reason: fmt.Sprintf("must look like %s for %s", rule.postalFormat, a.Country)}
+	}
+	if rule.regionRequired && a.Region == "" {
+		return &FieldError{Field: "region", Reason: "is required for " + a.Country}
+	}
+
+	return nil
+}
+
+// ValidDeliveryMethod reports whether the method is one of the supported delivery methods.
+func ValidDeliveryMethod(method string) bool {
+	switch method {
+	case DeliveryCourier, DeliveryPickup, DeliveryPost:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package address
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	base := Address{RecipientName: "Ivan Petrov", Country: "RU", City: "Moscow", PostalCode: "101000", Line1: "Tverskaya 1"}
+
+	tests := []struct {
+		name          string
+		modify        func(a *Address)
+		expectedField string
+	}{
+		{"Valid RU", func(a *Address) {}, ""},
+		{"Missing City", func(a *Address) { a.City = "" }, "city"},
+		{"Bad Country Code", func(a *Address) { a.Country = "RUS" }, "country"},
+		{"RU Postal Code Too Short", func(a *Address) { a.PostalCode = "10100" }, "postal_code"},
+		{"RU Postal Code Required", func(a *Address) { a.PostalCode = "" }, "postal_code"},
+		{"Bad Phone", func(a *Address) { a.Phone = "call me" }, "phone"},
+		{"Valid US ZIP+4", func(a *Address) { a.Country, a.Region, a.PostalCode = "US", "CA", "94105-1234" }, ""},
+		{"US Requires State", func(a *Address) { a.Country, a.PostalCode = "US", "94105" }, "region"},
+		{"Valid GB", func(a *Address) { a.Country, a.PostalCode = "GB", "SW1A 1AA" }, ""},
+		{"Invalid GB", func(a *Address) { a.Country, a.PostalCode = "GB", "12345" }, "postal_code"},
+		{"Valid CA", func(a *Address) { a.Country, a.Region, a.PostalCode = "CA", "ON", "K1A 0B1" }, ""},
+		{"Valid DE", func(a *Address) { a.Country, a.PostalCode = "DE", "10115" }, ""},
+		{"Unknown Country Without Postal Code", func(a *Address) { a.Country, a.PostalCode = "AE", "" }, ""},
+		{"Unknown Country Bad Postal Code", func(a *Address) { a.Country, a.PostalCode = "AE", "#1" }, "postal_code"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := base
+			tt.modify(&a)
+
+			err := Validate(Normalize(a))
+			if tt.expectedField == "" {
+				if err != nil {
+					t.Fatalf("Expected no error, got %v", err)
+				}
+				return
+			}
+
+			var fieldErr *FieldError
+			if !errors.As(err, &fieldErr) {
+				t.Fatalf("Expected *FieldError, got %v", err)
+			}
+			if fieldErr.Field != tt.expectedField {
+				t.Errorf("Expected error on %s, got %v", tt.expectedField, err)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	a := Normalize(Address{Country: " gb ", PostalCode: "sw1a 1aa", City: " London "})
+	if a.Country != "GB" || a.PostalCode != "SW1A 1AA" || a.City != "London" {
+		t.Errorf("Unexpected normalized address: %+v", a)
+	}
+}
@@ -0,0 +1,47 @@
+// Package etag связывает версию ресурса (оптимистичная блокировка) с заголовками ETag и If-Match
+package etag
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidIfMatch = errors.New("invalid If-Match header")
+
+// Format строит ETag по версии ресурса: "3"
+func Format(version int64) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// ParseIfMatch возвращает версию из заголовка If-Match.
+// Пустой заголовок и "*" означают, что версия не проверяется (0)
+func ParseIfMatch(header string) (int64, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	// Слабые ETag не подходят для If-Match, но клиенты и прокси иногда их присылают — версия та же
+	header = strings.TrimPrefix(header, "W/")
+	if len(header) < 2 || header[0] != '"' || header[len(header)-1] != '"' {
+		return 0, ErrInvalidIfMatch
+	}
+
+	version, err := strconv.ParseInt(header[1:len(header)-1], 10, 64)
+	if err != nil || version <= 0 {
+		return 0, ErrInvalidIfMatch
+	}
+	return version, nil
+}
+
+// ConflictStatus — HTTP-статус устаревшей версии. Клиент, приславший If-Match, ждет 412;
+// версия из тела запроса — обычный конфликт, 409
+func ConflictStatus(ifMatch string) int {
+	if ifMatch != "" {
+		return http.StatusPreconditionFailed
+	}
+	return http.StatusConflict
+}
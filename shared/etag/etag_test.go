@@ -0,0 +1,44 @@
+package etag
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestParseIfMatch(t *testing.T) {
+	tests := []struct {
+		header  string
+		version int64
+		wantErr bool
+	}{
+		{header: "", version: 0},
+		{header: "*", version: 0},
+		{header: `"7"`, version: 7},
+		{header: `W/"7"`, version: 7},
+		{header: Format(42), version: 42},
+		{header: "7", wantErr: true},
+		{header: `"abc"`, wantErr: true},
+		{header: `"0"`, wantErr: true},
+		{header: `"1", "2"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		version, err := ParseIfMatch(tt.header)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseIfMatch(%q) error = %v, wantErr %v", tt.header, err, tt.wantErr)
+			continue
+		}
+		if version != tt.version {
+			t.Errorf("ParseIfMatch(%q) = %d, want %d", tt.header, version, tt.version)
+		}
+	}
+}
+
+func TestConflictStatus(t *testing.T) {
+	if got := ConflictStatus(`"3"`); got != http.StatusPreconditionFailed {
+		t.Errorf("ConflictStatus with If-Match = %d, want 412", got)
+	}
+	if got := ConflictStatus(""); got != http.StatusConflict {
+		t.Errorf("ConflictStatus without If-Match = %d, want 409", got)
+	}
+}
@@ -0,0 +1,65 @@
+package identity
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MetadataKey — ключ gRPC-метаданных с токеном личности
+const MetadataKey = "x-identity-token"
+
+// UnaryServerInterceptor проверяет токен и кладет Principal в контекст.
+// Вызовы без валидного токена отклоняются с UNAUTHENTICATED, кроме методов из allowList:
+// полное имя метода ("/product.ProductService/GetProduct") или префикс сервиса со слэшем
+// на конце ("/grpc.health.v1.Health/"). Для разрешенных методов валидный токен все равно учитывается
+func UnaryServerInterceptor(issuer *Issuer, allowList ...string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		var token string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if vals := md.Get(MetadataKey); len(vals) > 0 {
+				token = vals[0]
+			}
+		}
+
+		p, err := issuer.Verify(token)
+		if err == nil {
+			return handler(NewContext(ctx, p), req)
+		}
+		if allowed(info.FullMethod, allowList) {
+			return handler(ctx, req)
+		}
+		return nil, status.Errorf(codes.Unauthenticated, "UNAUTHENTICATED: %v", err)
+	}
+}
+
+// UnaryClientInterceptor подписывает исходящие вызовы. Если в контексте задан
+// NewOutgoingContext, вызов идет от имени этого пользователя, иначе — от имени сервиса service
+func UnaryClientInterceptor(issuer *Issuer, service string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		p, ok := outgoingFromContext(ctx)
+		if !ok {
+			p = Principal{Role: RoleService}
+		}
+		p.Service = service
+
+		token, err := issuer.Sign(p)
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to sign identity token: %v", err)
+		}
+		return invoker(metadata.AppendToOutgoingContext(ctx, MetadataKey, token), method, req, reply, cc, opts...)
+	}
+}
+
+func allowed(method string, allowList []string) bool {
+	for _, entry := range allowList {
+		if method == entry || (strings.HasSuffix(entry, "/") && strings.HasPrefix(method, entry)) {
+			return true
+		}
+	}
+	return false
+}
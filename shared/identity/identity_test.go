@@ -0,0 +1,217 @@
+package identity
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func newTestIssuer(t *testing.T, now time.Time) *Issuer {
+	t.Helper()
+	issuer, err := NewIssuer(testSecret, time.Minute)
+	if err != nil {
+		t.Fatalf("NewIssuer() error = %v", err)
+	}
+	issuer.now = func() time.Time { return now }
+	return issuer
+}
+
+func TestNewIssuerRejectsShortSecret(t *testing.T) {
+	if _, err := NewIssuer("short", time.Minute); err == nil {
+		t.Error("expected error for short secret")
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	issuer := newTestIssuer(t, now)
+	want := Principal{UserID: 42, Role: RoleAdmin, Service: "bff"}
+
+	token, err := issuer.Sign(want)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	got, err := issuer.Verify(token)
+	if err != nil {
+		t.Fatalf("Verify() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("Verify() = %+v, want %+v", got, want)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		verify  *Issuer
+		wantErr error
+	}{
+		{name: "empty", token: "", verify: issuer, wantErr: ErrMissingToken},
+		{name: "garbage", token: "not-a-token", verify: issuer, wantErr: ErrInvalidToken},
+		{name: "tampered payload", token: tamper(token), verify: issuer, wantErr: ErrInvalidToken},
+		{name: "expired", token: token, verify: newTestIssuer(t, now.Add(2*time.Minute)), wantErr: ErrExpiredToken},
+		{name: "from the future", token: token, verify: newTestIssuer(t, now.Add(-time.Minute)), wantErr: ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.verify.Verify(tt.token); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	other, _ := NewIssuer("another-secret-another-secret-123", time.Minute)
+	if _, err := other.Verify(token); !errors.Is(err, ErrInvalidToken) {
+		t.Errorf("token signed with another secret must be rejected, got %v", err)
+	}
+}
+
+func TestVerifyRejectsForeignAudienceAndLongLifetime(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	internal := newTestIssuer(t, now)
+	external := internal.WithAudience("rest")
+	longLived, _ := NewIssuer(testSecret, 365*24*time.Hour)
+	longLived.now = internal.now
+
+	sign := func(issuer *Issuer) string {
+		token, err := issuer.Sign(Principal{UserID: 1, Role: RoleAdmin})
+		if err != nil {
+			t.Fatalf("Sign() error = %v", err)
+		}
+		return token
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		verify  *Issuer
+		wantErr error
+	}{
+		{name: "external token on internal issuer", token: sign(external), verify: internal, wantErr: ErrInvalidToken},
+		{name: "internal token on external issuer", token: sign(internal), verify: external, wantErr: ErrInvalidToken},
+		{name: "lifetime longer than ttl", token: sign(longLived), verify: internal, wantErr: ErrInvalidToken},
+		{name: "same audience", token: sign(external), verify: external, wantErr: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.verify.Verify(tt.token); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// tamper подставляет в токен чужой payload, сохраняя исходную подпись
+func tamper(token string) string {
+	issuer, _ := NewIssuer(testSecret, time.Minute)
+	forged, _ := issuer.Sign(Principal{UserID: 1, Role: RoleAdmin})
+	parts, forgedParts := strings.Split(token, "."), strings.Split(forged, ".")
+	return parts[0] + "." + forgedParts[1] + "." + parts[2]
+}
+
+func TestPrincipalCanActFor(t *testing.T) {
+	if !(Principal{UserID: 7, Role: RoleUser}).CanActFor(7) {
+		t.Error("user must act for themselves")
+	}
+	if (Principal{UserID: 7, Role: RoleUser}).CanActFor(8) {
+		t.Error("user must not act for others")
+	}
+	if !(Principal{Role: RoleService}).CanActFor(8) || !(Principal{UserID: 1, Role: RoleAdmin}).CanActFor(8) {
+		t.Error("services and admins act for anyone")
+	}
+	if (Principal{Role: RoleUser}).CanActFor(0) {
+		t.Error("anonymous user must not match user 0")
+	}
+}
+
+func TestServerInterceptor(t *testing.T) {
+	issuer := newTestIssuer(t, time.Now())
+	interceptor := UnaryServerInterceptor(issuer, "/pkg.Svc/Public", "/grpc.health.v1.Health/")
+
+	var seen Principal
+	var seenOK bool
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		seen, seenOK = FromContext(ctx)
+		return "ok", nil
+	}
+	call := func(method, token string) error {
+		ctx := context.Background()
+		if token != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(MetadataKey, token))
+		}
+		seenOK = false
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	token, _ := issuer.Sign(Principal{UserID: 5, Role: RoleUser, Service: "bff"})
+	if err := call("/pkg.Svc/Private", token); err != nil || !seenOK || seen.UserID != 5 {
+		t.Errorf("valid token: err = %v, principal = %+v", err, seen)
+	}
+	if err := call("/pkg.Svc/Private", ""); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("missing token: expected Unauthenticated, got %v", err)
+	}
+	if err := call("/pkg.Svc/Private", "v1.forged.token"); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("forged token: expected Unauthenticated, got %v", err)
+	}
+	if err := call("/pkg.Svc/Public", ""); err != nil || seenOK {
+		t.Errorf("allow-listed method: err = %v, principal set = %v", err, seenOK)
+	}
+	if err := call("/grpc.health.v1.Health/Check", ""); err != nil {
+		t.Errorf("allow-listed prefix: %v", err)
+	}
+	if err := call("/grpc.health.v1.HealthX/Check", ""); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("prefix must match the whole service name, got %v", err)
+	}
+}
+
+func TestClientServerRoundTrip(t *testing.T) {
+	issuer := newTestIssuer(t, time.Now())
+	client := UnaryClientInterceptor(issuer, "order-service")
+	server := UnaryServerInterceptor(issuer)
+
+	// invoker передает исходящие метаданные на "сервер", как это сделал бы транспорт
+	var got Principal
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		_, err := server(metadata.NewIncomingContext(ctx, md), req, &grpc.UnaryServerInfo{FullMethod: method},
+			func(ctx context.Context, req interface{}) (interface{}, error) {
+				got, _ = FromContext(ctx)
+				return nil, nil
+			})
+		return err
+	}
+
+	if err := client(context.Background(), "/pkg.Svc/M", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("service call: %v", err)
+	}
+	if got != (Principal{Role: RoleService, Service: "order-service"}) {
+		t.Errorf("service call principal = %+v", got)
+	}
+
+	// Личность входящего запроса не пробрасывается без NewOutgoingContext
+	incoming := NewContext(context.Background(), Principal{UserID: 3, Role: RoleUser})
+	if err := client(incoming, "/pkg.Svc/M", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("service call: %v", err)
+	}
+	if got.UserID != 0 || !got.IsService() {
+		t.Errorf("incoming principal leaked into outgoing call: %+v", got)
+	}
+
+	outgoing := NewOutgoingContext(context.Background(), Principal{UserID: 3, Role: RoleUser})
+	if err := client(outgoing, "/pkg.Svc/M", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("user call: %v", err)
+	}
+	if got != (Principal{UserID: 3, Role: RoleUser, Service: "order-service"}) {
+		t.Errorf("user call principal = %+v", got)
+	}
+}
@@ -0,0 +1,127 @@
+package identity
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultTTL — время жизни токена; он выпускается на каждый вызов, поэтому хватает минуты
+	DefaultTTL = time.Minute
+	// AudienceInternal — аудитория токенов для вызовов между сервисами, ее выставляет NewIssuer
+	AudienceInternal = "internal"
+	// clockSkew — допустимое расхождение часов между сервисами
+	clockSkew = 5 * time.Second
+	// minSecretLength — секрет короче этого легко подобрать
+	minSecretLength = 32
+	tokenVersion    = "v1"
+)
+
+var (
+	ErrMissingToken = errors.New("identity token is missing")
+	ErrInvalidToken = errors.New("identity token is invalid")
+	ErrExpiredToken = errors.New("identity token is expired")
+)
+
+type claims struct {
+	Principal
+	Audience  string `json:"aud"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// Issuer выпускает и проверяет токены личности. Все сервисы используют один секрет,
+// а токены разных аудиторий друг для друга не подходят
+type Issuer struct {
+	secret   []byte
+	ttl      time.Duration
+	audience string
+	now      func() time.Time
+}
+
+func NewIssuer(secret string, ttl time.Duration) (*Issuer, error) {
+	if len(secret) < minSecretLength {
+		return nil, fmt.Errorf("identity secret must be at least %d bytes", minSecretLength)
+	}
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Issuer{secret: []byte(secret), ttl: ttl, audience: AudienceInternal, now: time.Now}, nil
+}
+
+// WithAudience возвращает копию Issuer, которая выпускает и принимает только токены аудитории audience
+func (i *Issuer) WithAudience(audience string) *Issuer {
+	c := *i
+	c.audience = audience
+	return &c
+}
+
+// Sign возвращает токен вида v1.<payload>.<signature>
+func (i *Issuer) Sign(p Principal) (string, error) {
+	now := i.now()
+	payload, err := json.Marshal(claims{
+		Principal: p,
+		Audience:  i.audience,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(i.ttl).Unix(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signed := tokenVersion + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(i.mac(signed)), nil
+}
+
+// Verify проверяет подпись, аудиторию и срок действия токена. Токен, выпущенный на срок дольше ttl
+// этого Issuer, отклоняется, даже если подпись верна: так долгоживущий токен не примут сервисы с коротким ttl
+func (i *Issuer) Verify(token string) (Principal, error) {
+	if token == "" {
+		return Principal{}, ErrMissingToken
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != tokenVersion {
+		return Principal{}, ErrInvalidToken
+	}
+
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(sig, i.mac(parts[0]+"."+parts[1])) {
+		return Principal{}, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return Principal{}, ErrInvalidToken
+	}
+	var c claims
+	if err := json.Unmarshal(payload, &c); err != nil || c.Role == "" || c.Audience != i.audience {
+		return Principal{}, ErrInvalidToken
+	}
+
+	now := i.now()
+	if now.After(time.Unix(c.ExpiresAt, 0).Add(clockSkew)) {
+		return Principal{}, ErrExpiredToken
+	}
+	if now.Add(clockSkew).Before(time.Unix(c.IssuedAt, 0)) {
+		return Principal{}, ErrInvalidToken
+	}
+	// iat и exp хранятся в секундах, поэтому ttl округляется вверх
+	if time.Duration(c.ExpiresAt-c.IssuedAt)*time.Second > (i.ttl + time.Second - 1).Truncate(time.Second) {
+		return Principal{}, ErrInvalidToken
+	}
+
+	return c.Principal, nil
+}
+
+func (i *Issuer) mac(data string) []byte {
+	h := hmac.New(sha256.New, i.secret)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
@@ -0,0 +1,175 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is used for amounts that were stored before currencies were introduced.
+const DefaultCurrency = "RUB"
+
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// exponents lists ISO 4217 currencies whose minor unit is not 1/100.
+var exponents = map[string]int{
+	"JPY": 0,
+	"KRW": 0,
+	"VND": 0,
+	"CLP": 0,
+	"ISK": 0,
+	"BHD": 3,
+	"KWD": 3,
+	"OMR": 3,
+	"JOD": 3,
+	"TND": 3,
+}
+
+var symbols = map[string]string{
+	"RUB": "₽",
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+}
+
+// Money is an amount in minor units (kopecks, cents) of an ISO 4217 currency.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// New creates Money from minor units. An empty currency falls back to DefaultCurrency.
+func New(amountMinor int64, currency string) Money {
+	return Money{Amount: amountMinor, Currency: NormalizeCurrency(currency)}
+}
+
+// FromFloat converts a major-unit amount (e.g. 12.34) to Money, rounding half away from zero.
+// It exists for the legacy double price fields and must not be used for arithmetic.
+func FromFloat(amount float64, currency string) Money {
+	currency = NormalizeCurrency(currency)
+	scale := math.Pow10(Exponent(currency))
+	return Money{Amount: int64(math.Round(amount * scale)), Currency: currency}
+}
+
+// NormalizeCurrency upper-cases the code and applies DefaultCurrency to empty values.
+func NormalizeCurrency(currency string) string {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "" {
+		return DefaultCurrency
+	}
+	return currency
+}
+
+// ValidCurrency reports whether the code looks like an ISO 4217 alphabetic code.
+func ValidCurrency(currency string) bool {
+	if len(currency) != 3 {
+		return false
+	}
+	for _, r := range currency {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Exponent returns the number of digits after the decimal point for the currency.
+func Exponent(currency string) int {
+	if exp, ok := exponents[NormalizeCurrency(currency)]; ok {
+		return exp
+	}
+	return 2
+}
+
+// Zero returns a zero amount in the given currency.
+func Zero(currency string) Money {
+	return New(0, currency)
+}
+
+// IsZero reports whether the amount is zero.
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// Add sums two amounts of the same currency.
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	return Money{Amount: m.Amount + other.Amount, Currency: m.Currency}, nil
+}
+
+// Sub subtracts an amount of the same currency.
+func (m Money) Sub(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	return Money{Amount: m.Amount - other.Amount, Currency: m.Currency}, nil
+}
+
+// Mul multiplies the amount by an integer quantity.
+func (m Money) Mul(quantity int64) Money {
+	return Money{Amount: m.Amount * quantity, Currency: m.Currency}
+}
+
+// Float64 returns the amount in major units for the legacy double fields.
+func (m Money) Float64() float64 {
+	return float64(m.Amount) / math.Pow10(Exponent(m.Currency))
+}
+
+// Decimal renders the amount in major units without grouping, e.g. "1234.50".
+func (m Money) Decimal() string {
+	exp := Exponent(m.Currency)
+	sign := ""
+	amount := m.Amount
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	digits := strconv.FormatInt(amount, 10)
+	if exp == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exp {
+		digits = strings.Repeat("0", exp-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-exp] + "." + digits[len(digits)-exp:]
+}
+
+// String renders the amount with its currency code, e.g. "1234.50 RUB".
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+// Format renders the amount for humans with grouped thousands and a currency symbol, e.g. "1 234.50 ₽".
+func (m Money) Format() string {
+	decimal := m.Decimal()
+	sign := ""
+	if strings.HasPrefix(decimal, "-") {
+		sign = "-"
+		decimal = decimal[1:]
+	}
+
+	integer, fraction, hasFraction := strings.Cut(decimal, ".")
+	var grouped strings.Builder
+	for i, r := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			grouped.WriteRune(' ')
+		}
+		grouped.WriteRune(r)
+	}
+
+	result := sign + grouped.String()
+	if hasFraction {
+		result += "." + fraction
+	}
+
+	if symbol, ok := symbols[m.Currency]; ok {
+		return result + " " + symbol
+	}
+	return result + " " + m.Currency
+}
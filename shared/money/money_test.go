@@ -0,0 +1,75 @@
+package money
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFromFloat(t *testing.T) {
+	tests := []struct {
+		amount   float64
+		currency string
+		expected Money
+	}{
+		{0.1, "rub", Money{Amount: 10, Currency: "RUB"}},
+		{85000.00, "", Money{Amount: 8500000, Currency: "RUB"}},
+		{19.995, "USD", Money{Amount: 2000, Currency: "USD"}},
+		{1500, "JPY", Money{Amount: 1500, Currency: "JPY"}},
+		{1.2345, "KWD", Money{Amount: 1235, Currency: "KWD"}},
+	}
+
+	for _, tt := range tests {
+		if got := FromFloat(tt.amount, tt.currency); got != tt.expected {
+			t.Errorf("FromFloat(%v, %q) = %v, expected %v", tt.amount, tt.currency, got, tt.expected)
+		}
+	}
+}
+
+func TestArithmeticIsExact(t *testing.T) {
+	price := FromFloat(0.1, "RUB")
+	total := Zero("RUB")
+	for i := 0; i < 10; i++ {
+		var err error
+		total, err = total.Add(price)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if total.Amount != 100 {
+		t.Errorf("Expected 100 minor units, got %d", total.Amount)
+	}
+	if got := price.Mul(3).Decimal(); got != "0.30" {
+		t.Errorf("Expected 0.30, got %s", got)
+	}
+}
+
+func TestAddCurrencyMismatch(t *testing.T) {
+	_, err := New(100, "RUB").Add(New(100, "USD"))
+	if !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Expected ErrCurrencyMismatch, got %v", err)
+	}
+}
+
+func TestFormatting(t *testing.T) {
+	tests := []struct {
+		money     Money
+		decimal   string
+		formatted string
+	}{
+		{New(123456789, "RUB"), "1234567.89", "1 234 567.89 ₽"},
+		{New(5, "USD"), "0.05", "0.05 $"},
+		{New(-150, "EUR"), "-1.50", "-1.50 €"},
+		{New(1500, "JPY"), "1500", "1 500 ¥"},
+		{New(1234, "KWD"), "1.234", "1.234 KWD"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.money.Decimal(); got != tt.decimal {
+			t.Errorf("Decimal(%v) = %q, expected %q", tt.money, got, tt.decimal)
+		}
+		if got := tt.money.Format(); got != tt.formatted {
+			t.Errorf("Format(%v) = %q, expected %q", tt.money, got, tt.formatted)
+		}
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"user/internal/domain/repository"
 
 	userv1 "user/api/proto"
+	"user/internal/app/address_service"
 	"user/internal/app/user_service"
 	mygrpc "user/internal/transport/grpc"
 	"user/internal/transport/http/handler"
@@ -24,6 +25,7 @@ import (
 	_ "user/api/docs"
 
 	"github.com/gin-gonic/gin"
+	"github.com/microserviceteam0/bff-gateway/shared/identity"
 	"github.com/microserviceteam0/bff-gateway/shared/metrics"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	swaggerFiles "github.com/swaggo/files"
@@ -64,11 +66,14 @@ func main() {
 
 	userRepo := repository.NewUserRepository(db)
 	userService := user_service.NewUserService(userRepo)
+	addressRepo := repository.NewAddressRepository(db)
+	addressService := address_service.NewAddressService(addressRepo, userRepo)
 
 	userHandler := handler.NewUserHandler(userService)
+	addressHandler := handler.NewAddressHandler(addressService)
 	healthHandler := handler.NewHealthHandler(db)
 
-	grpcServer := mygrpc.NewServer(userService)
+	grpcServer := mygrpc.NewServer(userService, addressService)
 
 	go startGRPCServer(grpcServer, cfg, logger.Log)
 
@@ -76,7 +81,7 @@ func main() {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
-	router := setupHTTPRouter(userHandler, healthHandler, logger.Log)
+	router := setupHTTPRouter(userHandler, addressHandler, healthHandler, logger.Log)
 
 	startHTTPServer(router, cfg, logger.Log)
 }
@@ -90,7 +95,12 @@ func startGRPCServer(server *mygrpc.Server, cfg *config.Config, logger *zap.Logg
 		)
 	}
 
-	s := grpcgo.NewServer()
+	issuer, err := identity.NewIssuer(cfg.InternalAuthSecret, identity.DefaultTTL)
+	if err != nil {
+		logger.Fatal("Failed to initialize identity issuer, check INTERNAL_AUTH_SECRET", zap.Error(err))
+	}
+
+	s := grpcgo.NewServer(grpcgo.UnaryInterceptor(identity.UnaryServerInterceptor(issuer)))
 	userv1.RegisterUserServiceServer(s, server)
 
 	if cfg.Environment != "production" {
@@ -107,7 +117,7 @@ func startGRPCServer(server *mygrpc.Server, cfg *config.Config, logger *zap.Logg
 	}
 }
 
-func setupHTTPRouter(userHandler *handler.UserHandler, healthHandler *handler.HealthHandler, logger *zap.Logger) *gin.Engine {
+func setupHTTPRouter(userHandler *handler.UserHandler, addressHandler *handler.AddressHandler, healthHandler *handler.HealthHandler, logger *zap.Logger) *gin.Engine {
 	router := gin.New()
 
 	router.Use(middleware.CORS())
@@ -135,6 +145,12 @@ func setupHTTPRouter(userHandler *handler.UserHandler, healthHandler *handler.He
 			users.PUT("/:id", userHandler.UpdateUser)
 			users.DELETE("/:id", userHandler.DeleteUser)
 			users.POST("/validate", userHandler.ValidateUser)
+
+			users.GET("/:id/addresses", addressHandler.ListAddresses)
+			users.POST("/:id/addresses", addressHandler.CreateAddress)
+			users.GET("/:id/addresses/:address_id", addressHandler.GetAddress)
+			users.PUT("/:id/addresses/:address_id", addressHandler.UpdateAddress)
+			users.DELETE("/:id/addresses/:address_id", addressHandler.DeleteAddress)
 		}
 	}
 
@@ -0,0 +1,179 @@
+package address_service
+
+import (
+	"errors"
+	"user/internal/domain/entity"
+	"user/internal/domain/repository"
+
+	"github.com/microserviceteam0/bff-gateway/shared/address"
+	"gorm.io/gorm"
+)
+
+// MaxAddressesPerUser ограничивает размер адресной книги
+const MaxAddressesPerUser = 20
+
+var (
+	ErrUserNotFound     = errors.New("user not found")
+	ErrAddressNotFound  = errors.New("address not found")
+	ErrTooManyAddresses = errors.New("address book is full")
+)
+
+type AddressInput struct {
+	Label         string
+	RecipientName string
+	Phone         string
+	Country       string
+	Region        string
+	City          string
+	PostalCode    string
+	Line1         string
+	Line2         string
+	IsDefault     bool
+}
+
+type AddressService interface {
+	ListAddresses(userID int64) ([]entity.Address, error)
+	GetAddress(userID, addressID int64) (*entity.Address, error)
+	CreateAddress(userID int64, input AddressInput) (*entity.Address, error)
+	UpdateAddress(userID, addressID int64, input AddressInput) (*entity.Address, error)
+	DeleteAddress(userID, addressID int64) error
+}
+
+type addressService struct {
+	repo     repository.AddressRepository
+	userRepo repository.UserRepository
+}
+
+func NewAddressService(repo repository.AddressRepository, userRepo repository.UserRepository) AddressService {
+	return &addressService{repo: repo, userRepo: userRepo}
+}
+
+func (s *addressService) ListAddresses(userID int64) ([]entity.Address, error) {
+	if err := s.ensureUser(userID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByUserID(userID)
+}
+
+// GetAddress возвращает адрес только его владельцу: чужой адрес неотличим от несуществующего
+func (s *addressService) GetAddress(userID, addressID int64) (*entity.Address, error) {
+	addr, err := s.repo.FindByID(addressID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrAddressNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if addr.UserID != userID {
+		return nil, ErrAddressNotFound
+	}
+	return addr, nil
+}
+
+func (s *addressService) CreateAddress(userID int64, input AddressInput) (*entity.Address, error) {
+	if err := s.ensureUser(userID); err != nil {
+		return nil, err
+	}
+
+	a, err := validateInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.FindByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= MaxAddressesPerUser {
+		return nil, ErrTooManyAddresses
+	}
+
+	addr := &entity.Address{UserID: userID}
+	applyInput(addr, input.Label, a, input.IsDefault || len(existing) == 0)
+
+	if err := s.repo.Create(addr); err != nil {
+		return nil, err
+	}
+	return addr, nil
+}
+
+func (s *addressService) UpdateAddress(userID, addressID int64, input AddressInput) (*entity.Address, error) {
+	addr, err := s.GetAddress(userID, addressID)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := validateInput(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// Снять флаг по умолчанию можно только назначив другой адрес
+	applyInput(addr, input.Label, a, input.IsDefault || addr.IsDefault)
+
+	if err := s.repo.Update(addr); err != nil {
+		return nil, err
+	}
+	return addr, nil
+}
+
+func (s *addressService) DeleteAddress(userID, addressID int64) error {
+	addr, err := s.GetAddress(userID, addressID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(addr.ID); err != nil {
+		return err
+	}
+
+	if !addr.IsDefault {
+		return nil
+	}
+
+	// Адресом по умолчанию становится самый старый из оставшихся
+	remaining, err := s.repo.FindByUserID(userID)
+	if err != nil || len(remaining) == 0 {
+		return err
+	}
+	next := remaining[0]
+	next.IsDefault = true
+	return s.repo.Update(&next)
+}
+
+func (s *addressService) ensureUser(userID int64) error {
+	if userID == 0 {
+		return ErrUserNotFound
+	}
+	if _, err := s.userRepo.FindByID(userID); err != nil {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+func validateInput(input AddressInput) (address.Address, error) {
+	a := address.Normalize(address.Address{
+		RecipientName: input.RecipientName,
+		Phone:         input.Phone,
+		Country:       input.Country,
+		Region:        input.Region,
+		City:          input.City,
+		PostalCode:    input.PostalCode,
+		Line1:         input.Line1,
+		Line2:         input.Line2,
+	})
+	return a, address.Validate(a)
+}
+
+func applyInput(addr *entity.Address, label string, a address.Address, isDefault bool) {
+	addr.Label = label
+	addr.RecipientName = a.RecipientName
+	addr.Phone = a.Phone
+	addr.Country = a.Country
+	addr.Region = a.Region
+	addr.City = a.City
+	addr.PostalCode = a.PostalCode
+	addr.Line1 = a.Line1
+	addr.Line2 = a.Line2
+	addr.IsDefault = isDefault
+}
@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"time"
+	"user/internal/domain/entity"
+)
+
+type AddressRequest struct {
+	Label         string `json:"label"`
+	RecipientName string `json:"recipient_name" binding:"required"`
+	Phone         string `json:"phone"`
+	Country       string `json:"country" binding:"required,len=2"`
+	Region        string `json:"region"`
+	City          string `json:"city" binding:"required"`
+	PostalCode    string `json:"postal_code"`
+	Line1         string `json:"line1" binding:"required"`
+	Line2         string `json:"line2"`
+	IsDefault     bool   `json:"is_default"`
+}
+
+type AddressResponse struct {
+	ID            int64     `json:"id"`
+	UserID        int64     `json:"user_id"`
+	Label         string    `json:"label"`
+	RecipientName string    `json:"recipient_name"`
+	Phone         string    `json:"phone"`
+	Country       string    `json:"country"`
+	Region        string    `json:"region"`
+	City          string    `json:"city"`
+	PostalCode    string    `json:"postal_code"`
+	Line1         string    `json:"line1"`
+	Line2         string    `json:"line2"`
+	IsDefault     bool      `json:"is_default"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+func ToAddressResponse(address *entity.Address) AddressResponse {
+	return AddressResponse{
+		ID:            address.ID,
+		UserID:        address.UserID,
+		Label:         address.Label,
+		RecipientName: address.RecipientName,
+		Phone:         address.Phone,
+		Country:       address.Country,
+		Region:        address.Region,
+		City:          address.City,
+		PostalCode:    address.PostalCode,
+		Line1:         address.Line1,
+		Line2:         address.Line2,
+		IsDefault:     address.IsDefault,
+		CreatedAt:     address.CreatedAt,
+		UpdatedAt:     address.UpdatedAt,
+	}
+}
@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+type Address struct {
+	ID            int64     `gorm:"primaryKey" json:"id"`
+	UserID        int64     `gorm:"not null;index" json:"user_id"`
+	Label         string    `gorm:"size:100" json:"label"`
+	RecipientName string    `gorm:"size:255;not null" json:"recipient_name"`
+	Phone         string    `gorm:"size:32" json:"phone"`
+	Country       string    `gorm:"size:2;not null" json:"country"`
+	Region        string    `gorm:"size:100" json:"region"`
+	City          string    `gorm:"size:100;not null" json:"city"`
+	PostalCode    string    `gorm:"size:20" json:"postal_code"`
+	Line1         string    `gorm:"size:255;not null" json:"line1"`
+	Line2         string    `gorm:"size:255" json:"line2"`
+	IsDefault     bool      `gorm:"not null;default:false" json:"is_default"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"user/internal/domain/entity"
+
+	"gorm.io/gorm"
+)
+
+type AddressRepository interface {
+	Create(address *entity.Address) error
+	FindByID(id int64) (*entity.Address, error)
+	FindByUserID(userID int64) ([]entity.Address, error)
+	Update(address *entity.Address) error
+	Delete(id int64) error
+}
+
+type addressRepository struct {
+	db *gorm.DB
+}
+
+func NewAddressRepository(db *gorm.DB) AddressRepository {
+	return &addressRepository{db: db}
+}
+
+func (r *addressRepository) Create(address *entity.Address) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := clearDefault(tx, address); err != nil {
+			return err
+		}
+		return tx.Create(address).Error
+	})
+}
+
+func (r *addressRepository) FindByID(id int64) (*entity.Address, error) {
+	var address entity.Address
+	err := r.db.First(&address, id).Error
+	return &address, err
+}
+
+func (r *addressRepository) FindByUserID(userID int64) ([]entity.Address, error) {
+	var addresses []entity.Address
+	err := r.db.Where("user_id = ?", userID).Order("is_default DESC, id").Find(&addresses).Error
+	return addresses, err
+}
+
+func (r *addressRepository) Update(address *entity.Address) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := clearDefault(tx, address); err != nil {
+			return err
+		}
+		return tx.Save(address).Error
+	})
+}
+
+func (r *addressRepository) Delete(id int64) error {
+	return r.db.Delete(&entity.Address{}, id).Error
+}
+
+// clearDefault снимает флаг по умолчанию с остальных адресов пользователя,
+// если сохраняемый адрес становится адресом по умолчанию
+func clearDefault(tx *gorm.DB, address *entity.Address) error {
+	if !address.IsDefault {
+		return nil
+	}
+	return tx.Model(&entity.Address{}).
+		Where("user_id = ? AND id <> ? AND is_default", address.UserID, address.ID).
+		Update("is_default", false).Error
+}
@@ -0,0 +1,136 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"log"
+	userv1 "user/api/proto"
+	"user/internal/app/address_service"
+	"user/internal/domain/entity"
+
+	"github.com/microserviceteam0/bff-gateway/shared/address"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) ListAddresses(ctx context.Context, req *userv1.ListAddressesRequest) (*userv1.ListAddressesResponse, error) {
+	log.Printf("gRPC ListAddresses called for user ID: %d", req.UserId)
+
+	if err := authorizeUser(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	addresses, err := s.addressService.ListAddresses(req.UserId)
+	if err != nil {
+		return nil, addressError(err)
+	}
+
+	resp := &userv1.ListAddressesResponse{}
+	for _, addr := range addresses {
+		resp.Addresses = append(resp.Addresses, toProtoAddress(&addr))
+	}
+	return resp, nil
+}
+
+func (s *Server) GetAddress(ctx context.Context, req *userv1.GetAddressRequest) (*userv1.AddressResponse, error) {
+	log.Printf("gRPC GetAddress called with ID: %d for user ID: %d", req.AddressId, req.UserId)
+
+	if err := authorizeUser(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	addr, err := s.addressService.GetAddress(req.UserId, req.AddressId)
+	if err != nil {
+		return nil, addressError(err)
+	}
+	return &userv1.AddressResponse{Address: toProtoAddress(addr)}, nil
+}
+
+func (s *Server) CreateAddress(ctx context.Context, req *userv1.CreateAddressRequest) (*userv1.AddressResponse, error) {
+	log.Printf("gRPC CreateAddress called for user ID: %d", req.UserId)
+
+	if err := authorizeUser(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	addr, err := s.addressService.CreateAddress(req.UserId, toAddressInput(req.GetAddress()))
+	if err != nil {
+		return nil, addressError(err)
+	}
+	return &userv1.AddressResponse{Address: toProtoAddress(addr)}, nil
+}
+
+func (s *Server) UpdateAddress(ctx context.Context, req *userv1.UpdateAddressRequest) (*userv1.AddressResponse, error) {
+	log.Printf("gRPC UpdateAddress called with ID: %d for user ID: %d", req.AddressId, req.UserId)
+
+	if err := authorizeUser(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	addr, err := s.addressService.UpdateAddress(req.UserId, req.AddressId, toAddressInput(req.GetAddress()))
+	if err != nil {
+		return nil, addressError(err)
+	}
+	return &userv1.AddressResponse{Address: toProtoAddress(addr)}, nil
+}
+
+func (s *Server) DeleteAddress(ctx context.Context, req *userv1.DeleteAddressRequest) (*userv1.DeleteAddressResponse, error) {
+	log.Printf("gRPC DeleteAddress called with ID: %d for user ID: %d", req.AddressId, req.UserId)
+
+	if err := authorizeUser(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
+	if err := s.addressService.DeleteAddress(req.UserId, req.AddressId); err != nil {
+		return nil, addressError(err)
+	}
+	return &userv1.DeleteAddressResponse{Deleted: true}, nil
+}
+
+// addressError переводит ошибки адресной книги в gRPC статусы,
+// чтобы клиенты (order-service, BFF) могли отличить NOT_FOUND от ошибки валидации
+func addressError(err error) error {
+	var fieldErr *address.FieldError
+	switch {
+	case errors.As(err, &fieldErr):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, address_service.ErrUserNotFound), errors.Is(err, address_service.ErrAddressNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, address_service.ErrTooManyAddresses):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func toProtoAddress(addr *entity.Address) *userv1.Address {
+	return &userv1.Address{
+		Id:            addr.ID,
+		UserId:        addr.UserID,
+		Label:         addr.Label,
+		RecipientName: addr.RecipientName,
+		Phone:         addr.Phone,
+		Country:       addr.Country,
+		Region:        addr.Region,
+		City:          addr.City,
+		PostalCode:    addr.PostalCode,
+		Line1:         addr.Line1,
+		Line2:         addr.Line2,
+		IsDefault:     addr.IsDefault,
+	}
+}
+
+func toAddressInput(addr *userv1.Address) address_service.AddressInput {
+	return address_service.AddressInput{
+		Label:         addr.GetLabel(),
+		RecipientName: addr.GetRecipientName(),
+		Phone:         addr.GetPhone(),
+		Country:       addr.GetCountry(),
+		Region:        addr.GetRegion(),
+		City:          addr.GetCity(),
+		PostalCode:    addr.GetPostalCode(),
+		Line1:         addr.GetLine1(),
+		Line2:         addr.GetLine2(),
+		IsDefault:     addr.GetIsDefault(),
+	}
+}
@@ -0,0 +1,34 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/microserviceteam0/bff-gateway/shared/identity"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// authorizeUser разрешает вызов владельцу данных, администратору или сервису
+func authorizeUser(ctx context.Context, userID int64) error {
+	p, ok := identity.FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "UNAUTHENTICATED: identity is missing")
+	}
+	if !p.CanActFor(userID) {
+		return status.Error(codes.PermissionDenied, "PERMISSION_DENIED: access to another user's data")
+	}
+	return nil
+}
+
+// authorizeInternal разрешает вызов только сервисам и администраторам:
+// поиск по email, пакетное чтение и проверка пароля не нужны конечным пользователям
+func authorizeInternal(ctx context.Context) error {
+	p, ok := identity.FromContext(ctx)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "UNAUTHENTICATED: identity is missing")
+	}
+	if !p.IsService() && !p.IsAdmin() {
+		return status.Error(codes.PermissionDenied, "PERMISSION_DENIED: method is available to services only")
+	}
+	return nil
+}
@@ -4,23 +4,30 @@ import (
 	"context"
 	"log"
 	userv1 "user/api/proto"
+	"user/internal/app/address_service"
 	"user/internal/app/user_service"
 )
 
 type Server struct {
 	userv1.UnimplementedUserServiceServer
-	userService user_service.UserService
+	userService    user_service.UserService
+	addressService address_service.AddressService
 }
 
-func NewServer(userService user_service.UserService) *Server {
+func NewServer(userService user_service.UserService, addressService address_service.AddressService) *Server {
 	return &Server{
-		userService: userService,
+		userService:    userService,
+		addressService: addressService,
 	}
 }
 
 func (s *Server) GetUser(ctx context.Context, req *userv1.GetUserRequest) (*userv1.GetUserResponse, error) {
 	log.Printf("gRPC GetUser called with ID: %d", req.UserId)
 
+	if err := authorizeUser(ctx, req.UserId); err != nil {
+		return nil, err
+	}
+
 	user, err := s.userService.GetUserByID(req.UserId)
 	if err != nil {
 		return nil, err
@@ -39,6 +46,10 @@ func (s *Server) GetUser(ctx context.Context, req *userv1.GetUserRequest) (*user
 func (s *Server) GetUserByEmail(ctx context.Context, req *userv1.GetUserByEmailRequest) (*userv1.GetUserResponse, error) {
 	log.Printf("gRPC GetUserByEmail called with email: %s", req.Email)
 
+	if err := authorizeInternal(ctx); err != nil {
+		return nil, err
+	}
+
 	user, err := s.userService.GetUserByEmail(req.Email)
 	if err != nil {
 		return nil, err
@@ -57,6 +68,10 @@ func (s *Server) GetUserByEmail(ctx context.Context, req *userv1.GetUserByEmailR
 func (s *Server) GetUsers(ctx context.Context, req *userv1.GetUsersRequest) (*userv1.GetUsersResponse, error) {
 	log.Printf("gRPC GetUsers called with IDs: %v", req.UserIds)
 
+	if err := authorizeInternal(ctx); err != nil {
+		return nil, err
+	}
+
 	var users []*userv1.User
 	for _, userID := range req.UserIds {
 		user, err := s.userService.GetUserByID(userID)
@@ -78,6 +93,10 @@ func (s *Server) GetUsers(ctx context.Context, req *userv1.GetUsersRequest) (*us
 func (s *Server) UserExists(ctx context.Context, req *userv1.UserExistsRequest) (*userv1.UserExistsResponse, error) {
 	log.Printf("gRPC UserExists called with ID: %d", req.UserId)
 
+	if err := authorizeInternal(ctx); err != nil {
+		return nil, err
+	}
+
 	user, err := s.userService.GetUserByID(req.UserId)
 
 	return &userv1.UserExistsResponse{
@@ -88,6 +107,10 @@ func (s *Server) UserExists(ctx context.Context, req *userv1.UserExistsRequest)
 func (s *Server) ValidateCredentials(ctx context.Context, req *userv1.ValidateCredentialsRequest) (*userv1.ValidateCredentialsResponse, error) {
 	log.Printf("gRPC ValidateCredentials called for email: %s", req.Email)
 
+	if err := authorizeInternal(ctx); err != nil {
+		return nil, err
+	}
+
 	user, err := s.userService.ValidateCredentials(req.Email, req.Password)
 	if err != nil {
 		return &userv1.ValidateCredentialsResponse{
@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"user/internal/app/address_service"
+	"user/internal/app/dto"
+
+	"github.com/gin-gonic/gin"
+	"github.com/microserviceteam0/bff-gateway/shared/address"
+)
+
+type AddressHandler struct {
+	addressService address_service.AddressService
+}
+
+func NewAddressHandler(addressService address_service.AddressService) *AddressHandler {
+	return &AddressHandler{addressService: addressService}
+}
+
+func (h *AddressHandler) ListAddresses(c *gin.Context) {
+	userID, ok := parseIDParam(c, "id", "User ID must be a number")
+	if !ok {
+		return
+	}
+
+	addresses, err := h.addressService.ListAddresses(userID)
+	if err != nil {
+		respondAddressError(c, err, "internal_error")
+		return
+	}
+
+	response := make([]dto.AddressResponse, 0, len(addresses))
+	for _, addr := range addresses {
+		response = append(response, dto.ToAddressResponse(&addr))
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *AddressHandler) GetAddress(c *gin.Context) {
+	userID, ok := parseIDParam(c, "id", "User ID must be a number")
+	if !ok {
+		return
+	}
+	addressID, ok := parseIDParam(c, "address_id", "Address ID must be a number")
+	if !ok {
+		return
+	}
+
+	addr, err := h.addressService.GetAddress(userID, addressID)
+	if err != nil {
+		respondAddressError(c, err, "internal_error")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToAddressResponse(addr))
+}
+
+func (h *AddressHandler) CreateAddress(c *gin.Context) {
+	userID, ok := parseIDParam(c, "id", "User ID must be a number")
+	if !ok {
+		return
+	}
+
+	var req dto.AddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_error",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	addr, err := h.addressService.CreateAddress(userID, toAddressInput(req))
+	if err != nil {
+		respondAddressError(c, err, "creation_failed")
+		return
+	}
+
+	c.JSON(http.StatusCreated, dto.ToAddressResponse(addr))
+}
+
+func (h *AddressHandler) UpdateAddress(c *gin.Context) {
+	userID, ok := parseIDParam(c, "id", "User ID must be a number")
+	if !ok {
+		return
+	}
+	addressID, ok := parseIDParam(c, "address_id", "Address ID must be a number")
+	if !ok {
+		return
+	}
+
+	var req dto.AddressRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "validation_error",
+			"message": err.Error(),
+		})
+		return
+	}
+
+	addr, err := h.addressService.UpdateAddress(userID, addressID, toAddressInput(req))
+	if err != nil {
+		respondAddressError(c, err, "update_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.ToAddressResponse(addr))
+}
+
+func (h *AddressHandler) DeleteAddress(c *gin.Context) {
+	userID, ok := parseIDParam(c, "id", "User ID must be a number")
+	if !ok {
+		return
+	}
+	addressID, ok := parseIDParam(c, "address_id", "Address ID must be a number")
+	if !ok {
+		return
+	}
+
+	if err := h.addressService.DeleteAddress(userID, addressID); err != nil {
+		respondAddressError(c, err, "delete_failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Address deleted successfully",
+		"id":      addressID,
+	})
+}
+
+func parseIDParam(c *gin.Context, name, message string) (int64, bool) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_id",
+			"message": message,
+		})
+		return 0, false
+	}
+	return id, true
+}
+
+func respondAddressError(c *gin.Context, err error, code string) {
+	var fieldErr *address.FieldError
+	switch {
+	case errors.As(err, &fieldErr):
+		c.JSON(http.StatusBadRequest, gin.H{
+			"error":   "invalid_address",
+			"message": err.Error(),
+			"field":   fieldErr.Field,
+		})
+	case errors.Is(err, address_service.ErrUserNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "user_not_found",
+			"message": "User not found",
+		})
+	case errors.Is(err, address_service.ErrAddressNotFound):
+		c.JSON(http.StatusNotFound, gin.H{
+			"error":   "address_not_found",
+			"message": "Address not found",
+		})
+	case errors.Is(err, address_service.ErrTooManyAddresses):
+		c.JSON(http.StatusConflict, gin.H{
+			"error":   "too_many_addresses",
+			"message": err.Error(),
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{
+			"error":   code,
+			"message": err.Error(),
+		})
+	}
+}
+
+func toAddressInput(req dto.AddressRequest) address_service.AddressInput {
+	return address_service.AddressInput{
+		Label:         req.Label,
+		RecipientName: req.RecipientName,
+		Phone:         req.Phone,
+		Country:       req.Country,
+		Region:        req.Region,
+		City:          req.City,
+		PostalCode:    req.PostalCode,
+		Line1:         req.Line1,
+		Line2:         req.Line2,
+		IsDefault:     req.IsDefault,
+	}
+}
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE addresses (
+                           id BIGSERIAL PRIMARY KEY,
+                           user_id BIGINT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                           label VARCHAR(100) NOT NULL DEFAULT '',
+                           recipient_name VARCHAR(255) NOT NULL,
+                           phone VARCHAR(32) NOT NULL DEFAULT '',
+                           country CHAR(2) NOT NULL,
+                           region VARCHAR(100) NOT NULL DEFAULT '',
+                           city VARCHAR(100) NOT NULL,
+                           postal_code VARCHAR(20) NOT NULL DEFAULT '',
+                           line1 VARCHAR(255) NOT NULL,
+                           line2 VARCHAR(255) NOT NULL DEFAULT '',
+                           is_default BOOLEAN NOT NULL DEFAULT FALSE,
+                           created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+                           updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_addresses_user_id ON addresses(user_id);
+
+-- У пользователя может быть только один адрес по умолчанию
+CREATE UNIQUE INDEX idx_addresses_user_default ON addresses(user_id) WHERE is_default;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS addresses;
+-- +goose StatementEnd
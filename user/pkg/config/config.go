@@ -15,6 +15,8 @@ type Config struct {
 	AppPort     string
 	GRPCPort    string
 	Environment string
+	// InternalAuthSecret — общий ключ подписи identity-токенов между сервисами
+	InternalAuthSecret string
 }
 
 func Load() *Config {
@@ -29,6 +31,8 @@ func Load() *Config {
 		AppPort:     getEnv("APP_PORT", "8081"),
 		GRPCPort:    getEnv("GRPC_PORT", "50053"), // Значение по умолчанию 50051
 		Environment: getEnv("ENVIRONMENT", "development"),
+
+		InternalAuthSecret: os.Getenv("INTERNAL_AUTH_SECRET"),
 	}
 
 	return config